@@ -0,0 +1,62 @@
+import React from 'react'
+
+import classNames from 'classnames'
+import AccountIcon from 'mdi-react/AccountIcon'
+
+import { getOwnerMatchUrl, OwnerMatch } from '@sourcegraph/shared/src/search/stream'
+import { Link } from '@sourcegraph/wildcard'
+
+import { ResultContainer } from './ResultContainer'
+
+import styles from './SearchResult.module.scss'
+
+export interface OwnerSearchResultProps {
+    result: OwnerMatch
+    onSelect: () => void
+    containerClassName?: string
+    as?: React.ElementType
+    index: number
+}
+
+export const OwnerSearchResult: React.FunctionComponent<OwnerSearchResultProps> = ({
+    result,
+    onSelect,
+    containerClassName,
+    as,
+    index,
+}) => {
+    const renderTitle = (): JSX.Element => (
+        <div className={styles.title}>
+            <span className={classNames('test-search-result-label', styles.titleInner)}>
+                <Link to={getOwnerMatchUrl(result)}>{result.owner}</Link>
+            </span>
+        </div>
+    )
+
+    const renderBody = (): JSX.Element => (
+        <div data-testid="search-owner-result">
+            <div className={classNames(styles.searchResultMatch, 'p-2 flex-column')}>
+                <div className={styles.matchType}>
+                    <small>Code owner</small>
+                </div>
+            </div>
+        </div>
+    )
+
+    return (
+        <ResultContainer
+            index={index}
+            icon={AccountIcon}
+            collapsible={false}
+            defaultExpanded={true}
+            title={renderTitle()}
+            resultType={result.type}
+            onResultClicked={onSelect}
+            expandedChildren={renderBody()}
+            // Owners are not associated with a single repository.
+            repoName=""
+            className={containerClassName}
+            as={as}
+        />
+    )
+}
@@ -4,6 +4,7 @@ export * from './CommitSearchResult'
 export * from './CommitSearchResultMatch'
 export * from './FileSearchResult'
 export * from './LastSyncedIcon'
+export * from './OwnerSearchResult'
 export * from './RepoFileLink'
 export * from './RepoSearchResult'
 export * from './ResultContainer'
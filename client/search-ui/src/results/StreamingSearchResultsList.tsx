@@ -10,7 +10,13 @@ import { Observable } from 'rxjs'
 import { HoverMerged } from '@sourcegraph/client-api'
 import { Hoverifier } from '@sourcegraph/codeintellify'
 import { SearchContextProps } from '@sourcegraph/search'
-import { CommitSearchResult, RepoSearchResult, FileSearchResult, FetchFileParameters } from '@sourcegraph/search-ui'
+import {
+    CommitSearchResult,
+    RepoSearchResult,
+    FileSearchResult,
+    FetchFileParameters,
+    OwnerSearchResult,
+} from '@sourcegraph/search-ui'
 import { ActionItemAction } from '@sourcegraph/shared/src/actions/ActionItem'
 import { FilePrefetcher, PrefetchableFile } from '@sourcegraph/shared/src/components/PrefetchableFile'
 import { displayRepoName } from '@sourcegraph/shared/src/components/RepoLink'
@@ -195,6 +201,16 @@ export const StreamingSearchResultsList: React.FunctionComponent<
                             as="li"
                         />
                     )
+                case 'owner':
+                    return (
+                        <OwnerSearchResult
+                            index={index}
+                            result={result}
+                            onSelect={() => logSearchResultClicked(index, 'owner')}
+                            containerClassName={resultClassName}
+                            as="li"
+                        />
+                    )
             }
         },
         [
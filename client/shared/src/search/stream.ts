@@ -29,7 +29,7 @@ export type SearchEvent =
     | { type: 'error'; data: ErrorLike }
     | { type: 'done'; data: {} }
 
-export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch
+export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch | OwnerMatch
 
 export interface PathMatch {
     type: 'path'
@@ -40,8 +40,20 @@ export interface PathMatch {
     repoLastFetched?: string
     branches?: string[]
     commit?: string
+    revisionDiff?: RevisionDiff
+    /**
+     * The name of the remote Sourcegraph instance that federated search found the match
+     * on. Unset for matches on this instance.
+     */
+    source?: string
 }
 
+/**
+ * Whether the result of a revision diff search like `rev:feature...main` was added at
+ * the head revision or removed from the base revision.
+ */
+export type RevisionDiff = 'added' | 'removed'
+
 export interface ContentMatch {
     type: 'content'
     path: string
@@ -51,6 +63,8 @@ export interface ContentMatch {
     repoLastFetched?: string
     branches?: string[]
     commit?: string
+    revisionDiff?: RevisionDiff
+    source?: string
     lineMatches: LineMatch[]
     hunks?: DecoratedHunk[]
 }
@@ -93,6 +107,7 @@ export interface SymbolMatch {
     repoLastFetched?: string
     branches?: string[]
     commit?: string
+    source?: string
     symbols: MatchedSymbol[]
 }
 
@@ -102,6 +117,25 @@ export interface MatchedSymbol {
     containerName: string
     kind: SymbolKind
     line: number
+    /** Set for symbols returned by searches over a revision range. */
+    history?: SymbolHistory
+}
+
+/** The range of commits in which a symbol existed. */
+export interface SymbolHistory {
+    addedCommit: string
+    /** Empty if the symbol still exists at the end of the range. */
+    deletedCommit?: string
+    lastCommit: string
+}
+
+/**
+ * A code owner of files that matched a search, as selected with `select:file.owners`.
+ */
+export interface OwnerMatch {
+    type: 'owner'
+    /** The owner as written in a CODEOWNERS file, either a handle such as "@sourcegraph/search" or an email. */
+    owner: string
 }
 
 type MarkdownText = string
@@ -122,6 +156,7 @@ export interface CommitMatch {
     authorDate: string
     repoStars?: number
     repoLastFetched?: string
+    source?: string
 
     content: MarkdownText
     // Array of [line, character, length] triplets
@@ -140,6 +175,7 @@ export interface RepositoryMatch {
     private?: boolean
     branches?: string[]
     descriptionMatches?: Range[]
+    source?: string
 }
 
 /**
@@ -186,6 +222,7 @@ export interface Skipped {
      * - excluded-fork :: we did not search a repository because it is a fork.
      * - excluded-archive :: we did not search a repository because it is archived.
      * - display :: we hit the display limit, so we stopped sending results from the backend.
+     * - remote-instance :: a federated search on a remote Sourcegraph instance failed, timed out or did not return all results.
      */
     reason:
         | 'document-match-limit'
@@ -197,6 +234,7 @@ export interface Skipped {
         | 'excluded-fork'
         | 'excluded-archive'
         | 'display'
+        | 'remote-instance'
         | 'error'
     /**
      * A short message. eg 1,200 timed out.
@@ -222,7 +260,7 @@ export interface Filter {
     label: string
     count: number
     limitHit: boolean
-    kind: 'file' | 'repo' | 'lang' | 'utility'
+    kind: 'file' | 'repo' | 'lang' | 'utility' | 'owner'
 }
 
 export type AlertKind = 'lucky-search-queries'
@@ -543,6 +581,10 @@ export function getCommitMatchUrl(commitMatch: CommitMatch): string {
     return '/' + encodeURI(commitMatch.repository) + '/-/commit/' + commitMatch.oid
 }
 
+export function getOwnerMatchUrl(ownerMatch: OwnerMatch): string {
+    return '/search?q=' + encodeURIComponent(`file:has.owner(${ownerMatch.owner})`)
+}
+
 export function getMatchUrl(match: SearchMatch): string {
     switch (match.type) {
         case 'path':
@@ -553,6 +595,8 @@ export function getMatchUrl(match: SearchMatch): string {
             return getCommitMatchUrl(match)
         case 'repo':
             return getRepoMatchUrl(match)
+        case 'owner':
+            return getOwnerMatchUrl(match)
     }
 }
 
@@ -209,6 +209,7 @@ export function mergeSettings<S extends Settings>(values: S[]): S | null {
         notices: (base: any, add: any) => [...base, ...add],
         'search.scopes': (base: any, add: any) => [...base, ...add],
         'search.savedQueries': (base: any, add: any) => [...base, ...add],
+        'search.macros': (base: any, add: any) => ({ ...base, ...add }),
         'search.repositoryGroups': (base: any, add: any) => ({ ...base, ...add }),
         'insights.dashboards': (base: any, add: any) => ({ ...base, ...add }),
         'insights.allrepos': (base: any, add: any) => ({ ...base, ...add }),
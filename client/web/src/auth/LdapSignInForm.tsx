@@ -0,0 +1,124 @@
+import React, { useCallback, useState } from 'react'
+
+import { useLocation } from 'react-router-dom-v5-compat'
+
+import { Form } from '@sourcegraph/branded/src/components/Form'
+import { asError } from '@sourcegraph/common'
+import { Label, Button, LoadingSpinner, Text, Input } from '@sourcegraph/wildcard'
+
+import { AuthProvider, SourcegraphContext } from '../jscontext'
+import { eventLogger } from '../tracking/eventLogger'
+
+import { getReturnTo, PasswordInput } from './SignInSignUpCommon'
+
+interface Props {
+    provider: AuthProvider
+    onAuthError: (error: Error | null) => void
+    context: Pick<SourcegraphContext, 'xhrHeaders'>
+}
+
+/**
+ * The form for signing in with the username and password of an LDAP directory.
+ */
+export const LdapSignInForm: React.FunctionComponent<React.PropsWithChildren<Props>> = ({
+    provider,
+    onAuthError,
+    context,
+}) => {
+    const location = useLocation()
+    const [username, setUsername] = useState('')
+    const [password, setPassword] = useState('')
+    const [loading, setLoading] = useState(false)
+
+    const onUsernameFieldChange = useCallback((event: React.ChangeEvent<HTMLInputElement>): void => {
+        setUsername(event.target.value)
+    }, [])
+
+    const onPasswordFieldChange = useCallback((event: React.ChangeEvent<HTMLInputElement>): void => {
+        setPassword(event.target.value)
+    }, [])
+
+    const handleSubmit = useCallback(
+        (event: React.FormEvent<HTMLFormElement>): void => {
+            event.preventDefault()
+            if (loading) {
+                return
+            }
+
+            setLoading(true)
+            eventLogger.log('InitiateSignIn')
+            fetch(provider.authenticationURL, {
+                credentials: 'same-origin',
+                method: 'POST',
+                headers: {
+                    ...context.xhrHeaders,
+                    Accept: 'application/json',
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({ username, password }),
+            })
+                .then(async response => {
+                    if (response.status === 200) {
+                        if (new URLSearchParams(location.search).get('close') === 'true') {
+                            window.close()
+                        } else {
+                            const returnTo = getReturnTo(location)
+                            window.location.replace(returnTo)
+                        }
+                    } else if (response.status === 401) {
+                        throw new Error('User or password was incorrect')
+                    } else if (response.status === 429) {
+                        throw new Error('Too many failed sign-in attempts. Try again later.')
+                    } else {
+                        // The other errors have a message that is safe to display.
+                        throw new Error((await response.text()).trim() || 'Unknown Error')
+                    }
+                })
+                .catch(error => {
+                    console.error('Auth error:', error)
+                    setLoading(false)
+                    onAuthError(asError(error))
+                })
+        },
+        [username, loading, location, password, onAuthError, context, provider]
+    )
+
+    // The authentication URL is unique among the LDAP auth providers.
+    const id = `ldap-${provider.authenticationURL.replace(/\W+/g, '-')}`
+    return (
+        <Form onSubmit={handleSubmit} className="test-ldap-sign-in-form">
+            <Input
+                id={`${id}-username`}
+                label={<Text alignment="left">{provider.displayName} username</Text>}
+                onChange={onUsernameFieldChange}
+                required={true}
+                value={username}
+                disabled={loading}
+                autoCapitalize="off"
+                className="form-group"
+                autoComplete="username"
+            />
+
+            <div className="form-group d-flex flex-column align-content-start">
+                <Label htmlFor={`${id}-password`} className="align-self-start">
+                    Password
+                </Label>
+                <PasswordInput
+                    id={`${id}-password`}
+                    onChange={onPasswordFieldChange}
+                    value={password}
+                    required={true}
+                    disabled={loading}
+                    autoComplete="current-password"
+                    placeholder=" "
+                />
+            </div>
+
+            <div className="form-group">
+                <Button display="block" type="submit" disabled={loading} variant="secondary">
+                    {loading ? <LoadingSpinner /> : `Sign in with ${provider.displayName}`}
+                </Button>
+            </div>
+        </Form>
+    )
+}
@@ -1,3 +1,4 @@
+import { screen } from '@testing-library/react'
 import { Route, Routes } from 'react-router-dom-v5-compat'
 
 import { renderWithBrandedContext } from '@sourcegraph/shared/src/testing'
@@ -73,6 +74,41 @@ describe('SignInPage', () => {
         ).toMatchSnapshot()
     })
 
+    it('renders a sign in form for LDAP auth providers', () => {
+        renderWithBrandedContext(
+            <Routes>
+                <Route
+                    path="/sign-in"
+                    element={
+                        <SignInPage
+                            authenticatedUser={null}
+                            context={{
+                                allowSignup: false,
+                                sourcegraphDotComMode: false,
+                                authProviders: [
+                                    {
+                                        serviceType: 'ldap',
+                                        displayName: 'Corporate directory',
+                                        isBuiltin: false,
+                                        authenticationURL: '/.auth/ldap/sign-in?pc=corp',
+                                    },
+                                ],
+                                resetPasswordEnabled: false,
+                                xhrHeaders: {},
+                            }}
+                        />
+                    }
+                />
+            </Routes>,
+            { route: '/sign-in' }
+        )
+
+        expect(screen.getByLabelText('Corporate directory username')).toBeInTheDocument()
+        expect(screen.getByLabelText('Password')).toBeInTheDocument()
+        expect(screen.getByRole('button', { name: 'Sign in with Corporate directory' })).toBeInTheDocument()
+        expect(screen.queryByText(/No authentication providers are available/)).not.toBeInTheDocument()
+    })
+
     it('renders redirect when user is authenticated', () => {
         // eslint-disable-next-line @typescript-eslint/consistent-type-assertions
         const mockUser = {
@@ -15,6 +15,7 @@ import { SourcegraphContext } from '../jscontext'
 import { eventLogger } from '../tracking/eventLogger'
 
 import { SourcegraphIcon } from './icons'
+import { LdapSignInForm } from './LdapSignInForm'
 import { OrDivider } from './OrDivider'
 import { getReturnTo } from './SignInSignUpCommon'
 import { UsernamePasswordSignInForm } from './UsernamePasswordSignInForm'
@@ -40,13 +41,19 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
         return <Navigate to={returnTo} replace={true} />
     }
 
-    const [[builtInAuthProvider], thirdPartyAuthProviders] = partition(
+    const [[builtInAuthProvider], otherAuthProviders] = partition(
         props.context.authProviders,
         provider => provider.isBuiltin
     )
+    // LDAP providers sign users in with a username and password form, rather than with a redirect.
+    const [ldapAuthProviders, thirdPartyAuthProviders] = partition(
+        otherAuthProviders,
+        provider => provider.serviceType === 'ldap'
+    )
+    const passwordFormCount = (builtInAuthProvider ? 1 : 0) + ldapAuthProviders.length
 
     const body =
-        !builtInAuthProvider && thirdPartyAuthProviders.length === 0 ? (
+        passwordFormCount === 0 && thirdPartyAuthProviders.length === 0 ? (
             <Alert className="mt-3" variant="info">
                 No authentication providers are available. Contact a site administrator for help.
             </Alert>
@@ -64,10 +71,18 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
                         <UsernamePasswordSignInForm
                             {...props}
                             onAuthError={setError}
-                            noThirdPartyProviders={thirdPartyAuthProviders.length === 0}
+                            noThirdPartyProviders={
+                                thirdPartyAuthProviders.length === 0 && ldapAuthProviders.length === 0
+                            }
                         />
                     )}
-                    {builtInAuthProvider && thirdPartyAuthProviders.length > 0 && <OrDivider className="mb-3 py-1" />}
+                    {ldapAuthProviders.map((provider, index) => (
+                        <React.Fragment key={provider.authenticationURL}>
+                            {(builtInAuthProvider || index > 0) && <OrDivider className="mb-3 py-1" />}
+                            <LdapSignInForm provider={provider} onAuthError={setError} context={props.context} />
+                        </React.Fragment>
+                    ))}
+                    {passwordFormCount > 0 && thirdPartyAuthProviders.length > 0 && <OrDivider className="mb-3 py-1" />}
                     {thirdPartyAuthProviders.map((provider, index) => (
                         // Use index as key because display name may not be unique. This is OK
                         // here because this list will not be updated during this component's lifetime.
@@ -36,7 +36,7 @@ describe('FormTriggerArea', () => {
             query: 'test',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: false,
+            typeChecked: true,
             repoChecked: false,
             validChecked: true,
         },
@@ -44,7 +44,7 @@ describe('FormTriggerArea', () => {
             query: 'test patternType:literal',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: false,
+            typeChecked: true,
             repoChecked: false,
             validChecked: true,
         },
@@ -52,7 +52,7 @@ describe('FormTriggerArea', () => {
             query: 'test patternType:regexp',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: false,
+            typeChecked: true,
             repoChecked: false,
             validChecked: true,
         },
@@ -60,7 +60,7 @@ describe('FormTriggerArea', () => {
             query: 'test patternType:structural',
             isSourcegraphDotCom: true,
             patternTypeChecked: false,
-            typeChecked: false,
+            typeChecked: true,
             repoChecked: false,
             validChecked: true,
         },
@@ -72,6 +72,14 @@ describe('FormTriggerArea', () => {
             repoChecked: false,
             validChecked: true,
         },
+        {
+            query: 'test type:file',
+            isSourcegraphDotCom: true,
+            patternTypeChecked: true,
+            typeChecked: true,
+            repoChecked: false,
+            validChecked: true,
+        },
         {
             query: 'test type:diff',
             isSourcegraphDotCom: true,
@@ -92,7 +100,7 @@ describe('FormTriggerArea', () => {
             query: 'test repo:test',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: false,
+            typeChecked: true,
             repoChecked: true,
             validChecked: true,
         },
@@ -138,10 +138,10 @@ describe('Code monitoring', () => {
             await driver.page.click('.test-trigger-button')
 
             const input = await createEditorAPI(driver, '.test-trigger-input')
-            await input.append('foobar', 'type')
+            await input.append('foobar type:', 'type')
             await driver.page.waitForSelector('.test-is-invalid')
 
-            await input.append(' type:diff', 'type')
+            await input.append('diff', 'type')
             await driver.page.waitForSelector('.test-is-valid')
             await driver.page.waitForSelector('.test-preview-link')
             expect(
@@ -0,0 +1,200 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type auditLogsArgs struct {
+	graphqlutil.ConnectionArgs
+	After    *string
+	ActorUID *string
+	Action   *string
+	Entity   *string
+	Since    *time.Time
+	Until    *time.Time
+}
+
+// toListOpts transforms the GraphQL auditLogsArgs into options that can be
+// provided to the AuditLogStore's Count and List methods.
+func (args *auditLogsArgs) toListOpts() (database.AuditLogListOpts, error) {
+	opts := database.AuditLogListOpts{
+		Since: args.Since,
+		Until: args.Until,
+	}
+
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	} else {
+		opts.Limit = 50
+	}
+
+	if args.After != nil {
+		var err error
+		opts.Cursor, err = strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return opts, errors.Wrap(err, "parsing the after cursor")
+		}
+	}
+
+	if args.ActorUID != nil {
+		opts.ActorUID = *args.ActorUID
+	}
+	if args.Action != nil {
+		opts.Action = *args.Action
+	}
+	if args.Entity != nil {
+		opts.Entity = *args.Entity
+	}
+
+	return opts, nil
+}
+
+// AuditLogs is the top level query used to return audit log records.
+func (r *schemaResolver) AuditLogs(ctx context.Context, args *auditLogsArgs) (*auditLogConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins may read the audit log.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	return &auditLogConnectionResolver{
+		db:    r.db,
+		args:  args,
+		store: r.db.AuditLogs(),
+	}, nil
+}
+
+type auditLogConnectionResolver struct {
+	db    database.DB
+	args  *auditLogsArgs
+	store database.AuditLogStore
+
+	once sync.Once
+	logs []*database.AuditLog
+	next int64
+	err  error
+}
+
+func (r *auditLogConnectionResolver) Nodes(ctx context.Context) ([]*auditLogResolver, error) {
+	logs, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*auditLogResolver, len(logs))
+	for i, log := range logs {
+		nodes[i] = &auditLogResolver{
+			db:  r.db,
+			log: log,
+		}
+	}
+
+	return nodes, nil
+}
+
+func (r *auditLogConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	opts, err := r.args.toListOpts()
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := r.store.Count(ctx, opts)
+	return int32(count), err
+}
+
+func (r *auditLogConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next == 0 {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	return graphqlutil.NextPageCursor(fmt.Sprint(next)), nil
+}
+
+func (r *auditLogConnectionResolver) compute(ctx context.Context) ([]*database.AuditLog, int64, error) {
+	r.once.Do(func() {
+		r.err = func() error {
+			opts, err := r.args.toListOpts()
+			if err != nil {
+				return err
+			}
+
+			r.logs, r.next, err = r.store.List(ctx, opts)
+			return err
+		}()
+	})
+
+	return r.logs, r.next, r.err
+}
+
+type auditLogResolver struct {
+	db  database.DB
+	log *database.AuditLog
+}
+
+func (r *auditLogResolver) ID() graphql.ID {
+	return relay.MarshalID("AuditLog", r.log.ID)
+}
+
+func (r *auditLogResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.log.CreatedAt}
+}
+
+func (r *auditLogResolver) ActorUID() string {
+	return r.log.ActorUID
+}
+
+func (r *auditLogResolver) Actor(ctx context.Context) (*UserResolver, error) {
+	// Anonymous and unknown actors are not users.
+	userID, err := strconv.ParseInt(r.log.ActorUID, 10, 32)
+	if err != nil || userID <= 0 {
+		return nil, nil
+	}
+
+	user, err := UserByIDInt32(ctx, r.db, int32(userID))
+	if err != nil && errcode.IsNotFound(err) {
+		// Don't throw an error if a user has been deleted.
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *auditLogResolver) IP() string {
+	return r.log.IP
+}
+
+func (r *auditLogResolver) ForwardedFor() string {
+	return r.log.ForwardedFor
+}
+
+func (r *auditLogResolver) Entity() string {
+	return r.log.Entity
+}
+
+func (r *auditLogResolver) Action() string {
+	return r.log.Action
+}
+
+func (r *auditLogResolver) Fields() (JSONValue, error) {
+	var fields any
+	if err := json.Unmarshal(r.log.Fields, &fields); err != nil {
+		return JSONValue{}, err
+	}
+	return JSONValue{Value: fields}, nil
+}
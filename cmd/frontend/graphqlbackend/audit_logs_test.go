@@ -0,0 +1,223 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestAuditLogsArgs(t *testing.T) {
+	var (
+		now   = time.Date(2022, 9, 12, 10, 0, 0, 0, time.UTC)
+		later = now.Add(1 * time.Hour)
+	)
+
+	t.Run("success", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			input auditLogsArgs
+			want  database.AuditLogListOpts
+		}{
+			"no arguments": {
+				input: auditLogsArgs{},
+				want: database.AuditLogListOpts{
+					Limit: 50,
+				},
+			},
+			"all arguments": {
+				input: auditLogsArgs{
+					ConnectionArgs: graphqlutil.ConnectionArgs{
+						First: int32Ptr(25),
+					},
+					After:    stringPtr("40"),
+					ActorUID: stringPtr("1"),
+					Action:   stringPtr("site config updated"),
+					Entity:   stringPtr("site config"),
+					Since:    timePtr(now),
+					Until:    timePtr(later),
+				},
+				want: database.AuditLogListOpts{
+					Limit:    25,
+					Cursor:   40,
+					ActorUID: "1",
+					Action:   "site config updated",
+					Entity:   "site config",
+					Since:    timePtr(now),
+					Until:    timePtr(later),
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := tc.input.toListOpts()
+				assert.Nil(t, err)
+				assert.Equal(t, tc.want, have)
+			})
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, input := range []string{
+			"",
+			"-",
+			"0.0",
+			"foo",
+		} {
+			t.Run(input, func(t *testing.T) {
+				_, err := (&auditLogsArgs{After: &input}).toListOpts()
+				assert.NotNil(t, err)
+			})
+		}
+	})
+}
+
+func TestAuditLogs(t *testing.T) {
+	for name, tc := range map[string]struct {
+		user *types.User
+		want error
+	}{
+		"unauthenticated user": {user: nil, want: backend.ErrNotAuthenticated},
+		"regular user":         {user: &types.User{}, want: backend.ErrMustBeSiteAdmin},
+		"admin user":           {user: &types.User{SiteAdmin: true}, want: nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			users := database.NewMockUserStore()
+			users.GetByCurrentAuthUserFunc.SetDefaultReturn(tc.user, nil)
+
+			db := database.NewMockDB()
+			db.UsersFunc.SetDefaultReturn(users)
+			db.AuditLogsFunc.SetDefaultReturn(database.NewMockAuditLogStore())
+
+			_, err := newSchemaResolver(db).AuditLogs(context.Background(), &auditLogsArgs{})
+			if tc.want == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuditLogConnectionResolver(t *testing.T) {
+	ctx := context.Background()
+
+	logs := []*database.AuditLog{}
+	for i := 0; i < 20; i++ {
+		logs = append(logs, &database.AuditLog{})
+	}
+
+	t.Run("full and has next page", func(t *testing.T) {
+		store := database.NewMockAuditLogStore()
+		store.ListFunc.SetDefaultReturn(logs, 20, nil)
+
+		r := &auditLogConnectionResolver{
+			args: &auditLogsArgs{
+				ConnectionArgs: graphqlutil.ConnectionArgs{
+					First: int32Ptr(20),
+				},
+				Entity: stringPtr("site config"),
+			},
+			store: store,
+		}
+
+		nodes, err := r.Nodes(ctx)
+		for i, node := range nodes {
+			assert.Equal(t, logs[i], node.log)
+		}
+		assert.Nil(t, err)
+
+		page, err := r.PageInfo(ctx)
+		assert.True(t, page.HasNextPage())
+		assert.Equal(t, "20", *page.EndCursor())
+		assert.Nil(t, err)
+
+		mockassert.CalledOnceWith(
+			t, store.ListFunc,
+			mockassert.Values(
+				mockassert.Skip,
+				database.AuditLogListOpts{
+					Limit:  20,
+					Entity: "site config",
+				},
+			),
+		)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		want := errors.New("error")
+		store := database.NewMockAuditLogStore()
+		store.ListFunc.SetDefaultReturn(nil, 0, want)
+		store.CountFunc.SetDefaultReturn(0, want)
+
+		r := &auditLogConnectionResolver{
+			args:  &auditLogsArgs{},
+			store: store,
+		}
+
+		_, err := r.PageInfo(ctx)
+		assert.ErrorIs(t, err, want)
+
+		_, err = r.TotalCount(ctx)
+		assert.ErrorIs(t, err, want)
+	})
+}
+
+func TestAuditLogResolver(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fields", func(t *testing.T) {
+		r := &auditLogResolver{log: &database.AuditLog{Fields: json.RawMessage(`{"additional":"stuff"}`)}}
+
+		fields, err := r.Fields()
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"additional": "stuff"}, fields.Value)
+	})
+
+	t.Run("actors that are not users", func(t *testing.T) {
+		db := database.NewStrictMockDB()
+
+		for _, uid := range []string{"unknown", "6b0a4c83-5d2b-4a4c-9f07-0d2e26f3a3b2", "0"} {
+			r := &auditLogResolver{db: db, log: &database.AuditLog{ActorUID: uid}}
+
+			user, err := r.Actor(ctx)
+			assert.Nil(t, err)
+			assert.Nil(t, user)
+		}
+	})
+
+	t.Run("user actor", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+
+		r := &auditLogResolver{db: db, log: &database.AuditLog{ActorUID: "1"}}
+
+		user, err := r.Actor(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(1), user.DatabaseID())
+	})
+
+	t.Run("deleted user actor", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByIDFunc.SetDefaultReturn(nil, database.NewUserNotFoundError(1))
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+
+		r := &auditLogResolver{db: db, log: &database.AuditLog{ActorUID: "1"}}
+
+		user, err := r.Actor(ctx)
+		assert.Nil(t, err)
+		assert.Nil(t, user)
+	})
+}
@@ -67,6 +67,15 @@ type MonitorTriggerEventResolver interface {
 	Actions(ctx context.Context, args *ListActionArgs) (MonitorActionConnectionResolver, error)
 	ResultCount() int32
 	Query() *string
+	FileResults() []MonitorFileResultResolver
+}
+
+type MonitorFileResultResolver interface {
+	RepositoryName() string
+	Commit() string
+	Path() string
+	Removed() bool
+	Preview() *string
 }
 
 type MonitorActionConnectionResolver interface {
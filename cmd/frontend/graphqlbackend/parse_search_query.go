@@ -22,7 +22,13 @@ func (r *schemaResolver) ParseSearchQuery(ctx context.Context, args *struct {
 		searchType = query.SearchTypeLiteral
 	}
 
-	plan, err := query.Pipeline(query.Init(args.Query, searchType))
+	settings, err := DecodedViewerFinalSettings(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the parse tree with macros expanded, as it is searched.
+	plan, err := query.Pipeline(query.InitWithMacros(args.Query, searchType, query.Macros(settings.SearchMacros)))
 	if err != nil {
 		return nil, err
 	}
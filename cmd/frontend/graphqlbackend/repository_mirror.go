@@ -175,10 +175,11 @@ func (r *repositoryMirrorInfoResolver) UpdateSchedule(ctx context.Context) (*upd
 	if info.Schedule == nil {
 		return nil, nil
 	}
-	return &updateScheduleResolver{schedule: info.Schedule}, nil
+	return &updateScheduleResolver{db: r.db, schedule: info.Schedule}, nil
 }
 
 type updateScheduleResolver struct {
+	db       database.DB
 	schedule *repoupdaterprotocol.RepoScheduleState
 }
 
@@ -198,6 +199,46 @@ func (r *updateScheduleResolver) Total() int32 {
 	return int32(r.schedule.Total)
 }
 
+func (r *updateScheduleResolver) IntervalReason() string {
+	if r.schedule.IntervalReason == "" {
+		return "DEFAULT"
+	}
+	return strings.ToUpper(strings.ReplaceAll(r.schedule.IntervalReason, "-", "_"))
+}
+
+func (r *updateScheduleResolver) Priority() string {
+	return updatePriority(r.schedule.Priority)
+}
+
+func (r *updateScheduleResolver) LastUpdateDurationMilliseconds() *int32 {
+	if r.schedule.LastUpdateDuration == 0 {
+		return nil
+	}
+	ms := int32(r.schedule.LastUpdateDuration.Milliseconds())
+	return &ms
+}
+
+func (r *updateScheduleResolver) LastError(ctx context.Context) (*string, error) {
+	// 🚨 SECURITY: Errors of git fetches may contain details of the code host,
+	// so only site admins may see them.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	if r.schedule.LastError == "" {
+		return nil, nil
+	}
+	return &r.schedule.LastError, nil
+}
+
+// updatePriority returns the RepositoryUpdatePriority GraphQL enum value of a
+// priority in the update scheduler of repo-updater.
+func updatePriority(priority int) string {
+	if priority > 0 {
+		return "HIGH"
+	}
+	return "LOW"
+}
+
 func (r *repositoryMirrorInfoResolver) UpdateQueue(ctx context.Context) (*updateQueueResolver, error) {
 	info, err := r.repoUpdateSchedulerInfo(ctx)
 	if err != nil {
@@ -225,6 +266,10 @@ func (r *updateQueueResolver) Total() int32 {
 	return int32(r.queue.Total)
 }
 
+func (r *updateQueueResolver) Priority() string {
+	return updatePriority(r.queue.Priority)
+}
+
 func (r *schemaResolver) CheckMirrorRepositoryConnection(ctx context.Context, args *struct {
 	Repository *graphql.ID
 	Name       *string
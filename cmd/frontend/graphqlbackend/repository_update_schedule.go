@@ -0,0 +1,247 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	repoupdaterprotocol "github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// The range of update intervals that the update scheduler of repo-updater
+// supports.
+const (
+	minRepositoryUpdateInterval = 45 * time.Second
+	maxRepositoryUpdateInterval = 8 * time.Hour
+)
+
+type repositoryUpdateScheduleArgs struct {
+	First int32
+	After *string
+}
+
+// RepositoryUpdateSchedule is the top level query that lists the repos in the
+// update scheduler of repo-updater.
+func (r *schemaResolver) RepositoryUpdateSchedule(ctx context.Context, args *repositoryUpdateScheduleArgs) (*repositoryUpdateScheduleConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins may inspect the update scheduler.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if args.First < 0 {
+		return nil, errors.New("first must not be negative")
+	}
+	listArgs := repoupdaterprotocol.RepoUpdateSchedulerListArgs{Limit: int(args.First)}
+	if args.After != nil {
+		offset, err := strconv.Atoi(*args.After)
+		if err != nil || offset < 0 {
+			return nil, errors.Errorf("invalid after cursor %q", *args.After)
+		}
+		listArgs.Offset = offset
+	}
+
+	return &repositoryUpdateScheduleConnectionResolver{db: r.db, args: listArgs}, nil
+}
+
+type repositoryUpdateScheduleConnectionResolver struct {
+	db   database.DB
+	args repoupdaterprotocol.RepoUpdateSchedulerListArgs
+
+	once     sync.Once
+	result   *repoupdaterprotocol.RepoUpdateSchedulerListResult
+	repos    map[api.RepoID]*RepositoryResolver
+	settings map[api.RepoID]*database.RepoUpdateScheduleSetting
+	err      error
+}
+
+func (r *repositoryUpdateScheduleConnectionResolver) compute(ctx context.Context) (*repoupdaterprotocol.RepoUpdateSchedulerListResult, error) {
+	r.once.Do(func() {
+		r.err = func() error {
+			args := r.args
+			if args.Limit == 0 {
+				// repo-updater returns all repos for a limit of zero, but we only
+				// need the total count.
+				args.Limit = 1
+			}
+			result, err := repoupdater.DefaultClient.RepoUpdateSchedulerList(ctx, args)
+			if err != nil {
+				return err
+			}
+			if r.args.Limit == 0 {
+				result.Entries = nil
+			}
+			r.result = result
+
+			r.repos = make(map[api.RepoID]*RepositoryResolver, len(result.Entries))
+			if len(result.Entries) > 0 {
+				ids := make([]api.RepoID, 0, len(result.Entries))
+				for _, entry := range result.Entries {
+					ids = append(ids, entry.ID)
+				}
+				repos, err := r.db.Repos().List(ctx, database.ReposListOptions{IDs: ids})
+				if err != nil {
+					return err
+				}
+				for _, repo := range repos {
+					r.repos[repo.ID] = NewRepositoryResolver(r.db, repo)
+				}
+			}
+
+			settings, err := r.db.RepoUpdateScheduleSettings().List(ctx)
+			if err != nil {
+				return err
+			}
+			r.settings = make(map[api.RepoID]*database.RepoUpdateScheduleSetting, len(settings))
+			for _, setting := range settings {
+				r.settings[setting.RepoID] = setting
+			}
+			return nil
+		}()
+	})
+	return r.result, r.err
+}
+
+func (r *repositoryUpdateScheduleConnectionResolver) Nodes(ctx context.Context) ([]*repositoryUpdateScheduleEntryResolver, error) {
+	result, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*repositoryUpdateScheduleEntryResolver, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		nodes = append(nodes, &repositoryUpdateScheduleEntryResolver{
+			db:         r.db,
+			entry:      entry,
+			repository: r.repos[entry.ID],
+			setting:    r.settings[entry.ID],
+		})
+	}
+	return nodes, nil
+}
+
+func (r *repositoryUpdateScheduleConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	result, err := r.compute(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int32(result.Total), nil
+}
+
+func (r *repositoryUpdateScheduleConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	result, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	next := r.args.Offset + len(result.Entries)
+	if len(result.Entries) == 0 || next >= result.Total {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	return graphqlutil.NextPageCursor(strconv.Itoa(next)), nil
+}
+
+type repositoryUpdateScheduleEntryResolver struct {
+	db         database.DB
+	entry      repoupdaterprotocol.RepoUpdateSchedulerEntry
+	repository *RepositoryResolver
+	setting    *database.RepoUpdateScheduleSetting
+}
+
+func (r *repositoryUpdateScheduleEntryResolver) Repository() *RepositoryResolver {
+	return r.repository
+}
+
+func (r *repositoryUpdateScheduleEntryResolver) UpdateSchedule() *updateScheduleResolver {
+	if r.entry.Schedule == nil {
+		return nil
+	}
+	return &updateScheduleResolver{db: r.db, schedule: r.entry.Schedule}
+}
+
+func (r *repositoryUpdateScheduleEntryResolver) UpdateQueue() *updateQueueResolver {
+	if r.entry.Queue == nil {
+		return nil
+	}
+	return &updateQueueResolver{queue: r.entry.Queue}
+}
+
+func (r *repositoryUpdateScheduleEntryResolver) Setting() *repositoryUpdateScheduleSettingResolver {
+	if r.setting == nil {
+		return nil
+	}
+	return &repositoryUpdateScheduleSettingResolver{setting: r.setting}
+}
+
+type repositoryUpdateScheduleSettingResolver struct {
+	setting *database.RepoUpdateScheduleSetting
+}
+
+func (r *repositoryUpdateScheduleSettingResolver) IntervalSeconds() *int32 {
+	if r.setting.Interval == 0 {
+		return nil
+	}
+	seconds := int32(r.setting.Interval / time.Second)
+	return &seconds
+}
+
+func (r *repositoryUpdateScheduleSettingResolver) HighPriority() bool {
+	return r.setting.HighPriority
+}
+
+func (r *repositoryUpdateScheduleSettingResolver) UpdatedAt() DateTime {
+	return DateTime{Time: r.setting.UpdatedAt}
+}
+
+func (r *schemaResolver) SetRepositoryUpdateScheduleSetting(ctx context.Context, args *struct {
+	Repository      graphql.ID
+	IntervalSeconds *int32
+	HighPriority    bool
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may change how often repositories are updated.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	var interval time.Duration
+	if args.IntervalSeconds != nil {
+		interval = time.Duration(*args.IntervalSeconds) * time.Second
+		if interval < minRepositoryUpdateInterval || interval > maxRepositoryUpdateInterval {
+			return nil, errors.Errorf("intervalSeconds must be between %d and %d", int(minRepositoryUpdateInterval.Seconds()), int(maxRepositoryUpdateInterval.Seconds()))
+		}
+	}
+
+	repo, err := r.repositoryByID(ctx, args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	store := r.db.RepoUpdateScheduleSettings()
+	if interval == 0 && !args.HighPriority {
+		err = store.Delete(ctx, repo.IDInt32())
+	} else {
+		_, err = store.Upsert(ctx, &database.RepoUpdateScheduleSetting{
+			RepoID:       repo.IDInt32(),
+			Interval:     interval,
+			HighPriority: args.HighPriority,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The setting is stored, so it is applied when repo-updater restarts even
+	// if repo-updater can't apply it now.
+	if err := repoupdater.DefaultClient.RepoUpdateSchedulerReloadSetting(ctx, repo.IDInt32()); err != nil {
+		return nil, errors.Wrap(err, "applying the setting in repo-updater")
+	}
+	return &EmptyResponse{}, nil
+}
@@ -0,0 +1,297 @@
+package graphqlbackend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// mockRepoUpdater replaces the HTTP client of the default repo-updater client
+// with one that responds with handle, and restores it when the test finishes.
+func mockRepoUpdater(t *testing.T, handle func(path string, body []byte) any) {
+	t.Helper()
+
+	oldClient := repoupdater.DefaultClient.HTTPClient
+	repoupdater.DefaultClient.HTTPClient = &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := json.Marshal(handle(r.URL.Path, body))
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(resp)),
+			}, nil
+		}),
+	}
+	t.Cleanup(func() {
+		repoupdater.DefaultClient.HTTPClient = oldClient
+	})
+}
+
+func TestRepositoryUpdateSchedule(t *testing.T) {
+	due := time.Date(2022, 9, 15, 10, 0, 0, 0, time.UTC)
+
+	var gotArgs protocol.RepoUpdateSchedulerListArgs
+	mockRepoUpdater(t, func(path string, body []byte) any {
+		if path != "/repo-update-scheduler-list" {
+			t.Errorf("unexpected request to %s", path)
+		}
+		if err := json.Unmarshal(body, &gotArgs); err != nil {
+			t.Fatal(err)
+		}
+		return protocol.RepoUpdateSchedulerListResult{
+			Entries: []protocol.RepoUpdateSchedulerEntry{
+				{
+					ID:   1,
+					Name: "github.com/foo/bar",
+					Schedule: &protocol.RepoScheduleState{
+						IntervalSeconds:    3600,
+						Due:                due,
+						IntervalReason:     "fetch-error",
+						LastUpdateDuration: 1500 * time.Millisecond,
+						LastError:          "repository not found",
+						Priority:           1,
+					},
+					Queue: &protocol.RepoQueueState{Updating: true, Priority: 1},
+				},
+				{
+					ID:   2,
+					Name: "github.com/foo/deleted",
+					Schedule: &protocol.RepoScheduleState{
+						IntervalSeconds: 45,
+						Due:             due,
+					},
+				},
+			},
+			Total: 5,
+		}
+	})
+
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	repos := database.NewMockRepoStore()
+	repos.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) ([]*types.Repo, error) {
+		if len(opts.IDs) != 2 {
+			t.Errorf("got repo IDs %v, want 2 IDs", opts.IDs)
+		}
+		return []*types.Repo{{ID: 1, Name: "github.com/foo/bar"}}, nil
+	})
+
+	settings := database.NewMockRepoUpdateScheduleSettingsStore()
+	settings.ListFunc.SetDefaultReturn([]*database.RepoUpdateScheduleSetting{
+		{RepoID: 1, Interval: time.Hour, HighPriority: true, UpdatedAt: due},
+	}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.RepoUpdateScheduleSettingsFunc.SetDefaultReturn(settings)
+
+	RunTest(t, &Test{
+		Schema: mustParseGraphQLSchema(t, db),
+		Query: `
+			{
+				repositoryUpdateSchedule(first: 2, after: "1") {
+					nodes {
+						repository { name }
+						updateSchedule {
+							intervalSeconds
+							due
+							intervalReason
+							priority
+							lastUpdateDurationMilliseconds
+							lastError
+						}
+						updateQueue { updating priority }
+						setting { intervalSeconds highPriority updatedAt }
+					}
+					totalCount
+					pageInfo { hasNextPage endCursor }
+				}
+			}
+		`,
+		ExpectedResult: `
+			{
+				"repositoryUpdateSchedule": {
+					"nodes": [
+						{
+							"repository": { "name": "github.com/foo/bar" },
+							"updateSchedule": {
+								"intervalSeconds": 3600,
+								"due": "2022-09-15T10:00:00Z",
+								"intervalReason": "FETCH_ERROR",
+								"priority": "HIGH",
+								"lastUpdateDurationMilliseconds": 1500,
+								"lastError": "repository not found"
+							},
+							"updateQueue": { "updating": true, "priority": "HIGH" },
+							"setting": { "intervalSeconds": 3600, "highPriority": true, "updatedAt": "2022-09-15T10:00:00Z" }
+						},
+						{
+							"repository": null,
+							"updateSchedule": {
+								"intervalSeconds": 45,
+								"due": "2022-09-15T10:00:00Z",
+								"intervalReason": "DEFAULT",
+								"priority": "LOW",
+								"lastUpdateDurationMilliseconds": null,
+								"lastError": null
+							},
+							"updateQueue": null,
+							"setting": null
+						}
+					],
+					"totalCount": 5,
+					"pageInfo": { "hasNextPage": true, "endCursor": "3" }
+				}
+			}
+		`,
+	})
+
+	if want := (protocol.RepoUpdateSchedulerListArgs{Offset: 1, Limit: 2}); gotArgs != want {
+		t.Errorf("got list args %+v, want %+v", gotArgs, want)
+	}
+}
+
+func TestRepositoryUpdateSchedule_NonSiteAdmin(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	RunTest(t, &Test{
+		Schema: mustParseGraphQLSchema(t, db),
+		Query: `
+			{
+				repositoryUpdateSchedule { totalCount }
+			}
+		`,
+		ExpectedResult: `null`,
+		ExpectedErrors: []*gqlerrors.QueryError{
+			{
+				Path:    []any{"repositoryUpdateSchedule"},
+				Message: "must be site admin",
+			},
+		},
+	})
+}
+
+func TestSetRepositoryUpdateScheduleSetting(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	repos := database.NewMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: 1, Name: "github.com/foo/bar"}, nil)
+
+	settings := database.NewMockRepoUpdateScheduleSettingsStore()
+	settings.UpsertFunc.SetDefaultHook(func(_ context.Context, setting *database.RepoUpdateScheduleSetting) (*database.RepoUpdateScheduleSetting, error) {
+		return setting, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.RepoUpdateScheduleSettingsFunc.SetDefaultReturn(settings)
+
+	var reloaded []api.RepoID
+	mockRepoUpdater(t, func(path string, body []byte) any {
+		if path != "/repo-update-scheduler-reload-setting" {
+			t.Errorf("unexpected request to %s", path)
+		}
+		var args protocol.RepoUpdateSchedulerReloadSettingArgs
+		if err := json.Unmarshal(body, &args); err != nil {
+			t.Fatal(err)
+		}
+		reloaded = append(reloaded, args.ID)
+		return nil
+	})
+
+	const mutation = `
+		mutation($intervalSeconds: Int, $highPriority: Boolean = false) {
+			setRepositoryUpdateScheduleSetting(repository: "UmVwb3NpdG9yeTox", intervalSeconds: $intervalSeconds, highPriority: $highPriority) {
+				alwaysNil
+			}
+		}
+	`
+
+	t.Run("pin", func(t *testing.T) {
+		reloaded = nil
+
+		RunTest(t, &Test{
+			Schema:         mustParseGraphQLSchema(t, db),
+			Query:          mutation,
+			Variables:      map[string]any{"intervalSeconds": 3600, "highPriority": true},
+			ExpectedResult: `{"setRepositoryUpdateScheduleSetting": {"alwaysNil": null}}`,
+		})
+
+		calls := settings.UpsertFunc.History()
+		if len(calls) != 1 {
+			t.Fatalf("got %d calls to Upsert, want 1", len(calls))
+		}
+		want := database.RepoUpdateScheduleSetting{RepoID: 1, Interval: time.Hour, HighPriority: true}
+		if got := *calls[0].Arg1; got != want {
+			t.Errorf("got setting %+v, want %+v", got, want)
+		}
+		if len(reloaded) != 1 || reloaded[0] != 1 {
+			t.Errorf("got reloaded repos %v, want [1]", reloaded)
+		}
+	})
+
+	t.Run("unpin", func(t *testing.T) {
+		reloaded = nil
+
+		RunTest(t, &Test{
+			Schema:         mustParseGraphQLSchema(t, db),
+			Query:          mutation,
+			ExpectedResult: `{"setRepositoryUpdateScheduleSetting": {"alwaysNil": null}}`,
+		})
+
+		calls := settings.DeleteFunc.History()
+		if len(calls) != 1 || calls[0].Arg1 != 1 {
+			t.Fatalf("got Delete calls %+v, want one call for repo 1", calls)
+		}
+		if len(reloaded) != 1 || reloaded[0] != 1 {
+			t.Errorf("got reloaded repos %v, want [1]", reloaded)
+		}
+	})
+
+	t.Run("interval out of range", func(t *testing.T) {
+		reloaded = nil
+
+		RunTest(t, &Test{
+			Schema:         mustParseGraphQLSchema(t, db),
+			Query:          mutation,
+			Variables:      map[string]any{"intervalSeconds": 10},
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Path:    []any{"setRepositoryUpdateScheduleSetting"},
+					Message: "intervalSeconds must be between 45 and 28800",
+				},
+			},
+		})
+
+		if len(reloaded) != 0 {
+			t.Errorf("got reloaded repos %v, want none", reloaded)
+		}
+	})
+}
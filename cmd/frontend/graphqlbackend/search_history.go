@@ -0,0 +1,239 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type searchHistoryArgs struct {
+	graphqlutil.ConnectionArgs
+	After  *string
+	Query  *string
+	Pinned *bool
+}
+
+// toListOpts transforms the GraphQL searchHistoryArgs into options that can be
+// provided to the SearchHistoryStore's Count and List methods.
+func (args *searchHistoryArgs) toListOpts(userID int32) (database.SearchHistoryListOpts, error) {
+	opts := database.SearchHistoryListOpts{
+		UserID: userID,
+		Pinned: args.Pinned,
+	}
+
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	} else {
+		opts.Limit = 50
+	}
+
+	if args.After != nil {
+		var err error
+		opts.Cursor, err = strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return opts, errors.Wrap(err, "parsing the after cursor")
+		}
+	}
+
+	if args.Query != nil {
+		opts.Query = *args.Query
+	}
+
+	return opts, nil
+}
+
+// searchHistoryUserID returns the ID of the current user. Search history is only
+// accessible to the user it belongs to, so there is no userID argument.
+func searchHistoryUserID(ctx context.Context) (int32, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return 0, backend.ErrNotAuthenticated
+	}
+	return a.UID, nil
+}
+
+// SearchHistory is the top level query used to return the search history of
+// the current user.
+func (r *schemaResolver) SearchHistory(ctx context.Context, args *searchHistoryArgs) (*searchHistoryConnectionResolver, error) {
+	// 🚨 SECURITY: Users can only read their own search history.
+	userID, err := searchHistoryUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &searchHistoryConnectionResolver{
+		args:   args,
+		userID: userID,
+		store:  r.db.SearchHistory(),
+	}, nil
+}
+
+func (r *schemaResolver) SetSearchHistoryEntryPinned(ctx context.Context, args *struct {
+	ID     graphql.ID
+	Pinned bool
+}) (*searchHistoryEntryResolver, error) {
+	// 🚨 SECURITY: Users can only pin the entries of their own search history.
+	userID, err := searchHistoryUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalSearchHistoryEntryID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := r.db.SearchHistory().SetPinned(ctx, userID, id, args.Pinned)
+	if err != nil {
+		return nil, err
+	}
+	return &searchHistoryEntryResolver{entry: entry}, nil
+}
+
+func (r *schemaResolver) DeleteSearchHistoryEntry(ctx context.Context, args *struct {
+	ID graphql.ID
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Users can only delete the entries of their own search
+	// history.
+	userID, err := searchHistoryUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalSearchHistoryEntryID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.SearchHistory().Delete(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) ClearSearchHistory(ctx context.Context) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Users can only delete their own search history.
+	userID, err := searchHistoryUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.SearchHistory().DeleteAll(ctx, userID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+type searchHistoryConnectionResolver struct {
+	args   *searchHistoryArgs
+	userID int32
+	store  database.SearchHistoryStore
+
+	once    sync.Once
+	entries []*database.SearchHistoryEntry
+	next    int64
+	err     error
+}
+
+func (r *searchHistoryConnectionResolver) Nodes(ctx context.Context) ([]*searchHistoryEntryResolver, error) {
+	entries, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*searchHistoryEntryResolver, len(entries))
+	for i, entry := range entries {
+		nodes[i] = &searchHistoryEntryResolver{entry: entry}
+	}
+
+	return nodes, nil
+}
+
+func (r *searchHistoryConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	opts, err := r.args.toListOpts(r.userID)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := r.store.Count(ctx, opts)
+	return int32(count), err
+}
+
+func (r *searchHistoryConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next == 0 {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	return graphqlutil.NextPageCursor(fmt.Sprint(next)), nil
+}
+
+func (r *searchHistoryConnectionResolver) compute(ctx context.Context) ([]*database.SearchHistoryEntry, int64, error) {
+	r.once.Do(func() {
+		r.err = func() error {
+			opts, err := r.args.toListOpts(r.userID)
+			if err != nil {
+				return err
+			}
+
+			r.entries, r.next, err = r.store.List(ctx, opts)
+			return err
+		}()
+	})
+
+	return r.entries, r.next, r.err
+}
+
+func marshalSearchHistoryEntryID(id int64) graphql.ID {
+	return relay.MarshalID("SearchHistoryEntry", id)
+}
+
+func unmarshalSearchHistoryEntryID(id graphql.ID) (entryID int64, err error) {
+	err = relay.UnmarshalSpec(id, &entryID)
+	return
+}
+
+type searchHistoryEntryResolver struct {
+	entry *database.SearchHistoryEntry
+}
+
+func (r *searchHistoryEntryResolver) ID() graphql.ID {
+	return marshalSearchHistoryEntryID(r.entry.ID)
+}
+
+func (r *searchHistoryEntryResolver) Query() string {
+	return r.entry.Query
+}
+
+func (r *searchHistoryEntryResolver) PatternType() string {
+	return r.entry.PatternType
+}
+
+func (r *searchHistoryEntryResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.entry.CreatedAt}
+}
+
+func (r *searchHistoryEntryResolver) ResultCount() int32 {
+	return r.entry.ResultCount
+}
+
+func (r *searchHistoryEntryResolver) DurationMilliseconds() int32 {
+	return r.entry.DurationMs
+}
+
+func (r *searchHistoryEntryResolver) Pinned() bool {
+	return r.entry.Pinned
+}
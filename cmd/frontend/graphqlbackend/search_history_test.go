@@ -0,0 +1,189 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestSearchHistoryArgs(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		args := &searchHistoryArgs{}
+		opts, err := args.toListOpts(1)
+		require.NoError(t, err)
+		assert.Equal(t, database.SearchHistoryListOpts{UserID: 1, Limit: 50}, opts)
+	})
+
+	t.Run("all arguments", func(t *testing.T) {
+		args := &searchHistoryArgs{
+			ConnectionArgs: graphqlutil.ConnectionArgs{First: int32Ptr(10)},
+			After:          stringPtr("42"),
+			Query:          stringPtr("repo:foo"),
+			Pinned:         boolPtr(true),
+		}
+		opts, err := args.toListOpts(1)
+		require.NoError(t, err)
+		assert.Equal(t, database.SearchHistoryListOpts{
+			UserID: 1,
+			Limit:  10,
+			Cursor: 42,
+			Query:  "repo:foo",
+			Pinned: boolPtr(true),
+		}, opts)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		args := &searchHistoryArgs{After: stringPtr("foo")}
+		_, err := args.toListOpts(1)
+		assert.Error(t, err)
+	})
+}
+
+func TestSearchHistory(t *testing.T) {
+	createdAt := time.Date(2022, 9, 14, 10, 0, 0, 0, time.UTC)
+
+	store := database.NewMockSearchHistoryStore()
+	store.ListFunc.SetDefaultReturn([]*database.SearchHistoryEntry{{
+		ID:          2,
+		UserID:      1,
+		Query:       "repo:foo bar",
+		PatternType: "standard",
+		ResultCount: 10,
+		DurationMs:  120,
+		CreatedAt:   createdAt,
+	}}, 1, nil)
+	store.CountFunc.SetDefaultReturn(2, nil)
+
+	db := database.NewMockDB()
+	db.SearchHistoryFunc.SetDefaultReturn(store)
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := newSchemaResolver(db).SearchHistory(context.Background(), &searchHistoryArgs{})
+		assert.ErrorIs(t, err, backend.ErrNotAuthenticated)
+	})
+
+	t.Run("authenticated", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					searchHistory(first: 1) {
+						nodes {
+							query
+							patternType
+							createdAt
+							resultCount
+							durationMilliseconds
+							pinned
+						}
+						totalCount
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"searchHistory": {
+						"nodes": [{
+							"query": "repo:foo bar",
+							"patternType": "standard",
+							"createdAt": "2022-09-14T10:00:00Z",
+							"resultCount": 10,
+							"durationMilliseconds": 120,
+							"pinned": false
+						}],
+						"totalCount": 2,
+						"pageInfo": {
+							"hasNextPage": true,
+							"endCursor": "1"
+						}
+					}
+				}
+			`,
+		})
+
+		mockassert.CalledWith(t, store.ListFunc, mockassert.Values(mockassert.Skip, database.SearchHistoryListOpts{UserID: 1, Limit: 1}))
+	})
+}
+
+func TestSearchHistoryMutations(t *testing.T) {
+	id := marshalSearchHistoryEntryID(2)
+
+	setup := func() (database.DB, *database.MockSearchHistoryStore) {
+		store := database.NewMockSearchHistoryStore()
+		store.SetPinnedFunc.SetDefaultHook(func(_ context.Context, userID int32, id int64, pinned bool) (*database.SearchHistoryEntry, error) {
+			return &database.SearchHistoryEntry{ID: id, UserID: userID, Pinned: pinned}, nil
+		})
+		db := database.NewMockDB()
+		db.SearchHistoryFunc.SetDefaultReturn(store)
+		return db, store
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		db, store := setup()
+		r := newSchemaResolver(db)
+		ctx := context.Background()
+
+		_, err := r.SetSearchHistoryEntryPinned(ctx, &struct {
+			ID     graphql.ID
+			Pinned bool
+		}{ID: id, Pinned: true})
+		assert.ErrorIs(t, err, backend.ErrNotAuthenticated)
+
+		_, err = r.DeleteSearchHistoryEntry(ctx, &struct{ ID graphql.ID }{ID: id})
+		assert.ErrorIs(t, err, backend.ErrNotAuthenticated)
+
+		_, err = r.ClearSearchHistory(ctx)
+		assert.ErrorIs(t, err, backend.ErrNotAuthenticated)
+
+		mockassert.NotCalled(t, store.SetPinnedFunc)
+		mockassert.NotCalled(t, store.DeleteFunc)
+		mockassert.NotCalled(t, store.DeleteAllFunc)
+	})
+
+	t.Run("pin", func(t *testing.T) {
+		db, store := setup()
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+		entry, err := newSchemaResolver(db).SetSearchHistoryEntryPinned(ctx, &struct {
+			ID     graphql.ID
+			Pinned bool
+		}{ID: id, Pinned: true})
+		require.NoError(t, err)
+		assert.True(t, entry.Pinned())
+		mockassert.CalledOnceWith(t, store.SetPinnedFunc, mockassert.Values(mockassert.Skip, int32(1), int64(2), true))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		db, store := setup()
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+		_, err := newSchemaResolver(db).DeleteSearchHistoryEntry(ctx, &struct{ ID graphql.ID }{ID: id})
+		require.NoError(t, err)
+		mockassert.CalledOnceWith(t, store.DeleteFunc, mockassert.Values(mockassert.Skip, int32(1), int64(2)))
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		db, store := setup()
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+		_, err := newSchemaResolver(db).ClearSearchHistory(ctx)
+		require.NoError(t, err)
+		mockassert.CalledOnceWith(t, store.DeleteAllFunc, mockassert.Values(mockassert.Skip, int32(1)))
+	})
+}
@@ -146,6 +146,25 @@ func TestMergeSettings(t *testing.T) {
 				"test3": {"merged", 4},
 			},
 		},
+	}, {
+		name: "deep merge search macros",
+		left: &schema.Settings{
+			SearchMacros: map[string]string{
+				"site": "repo:site",
+				"both": "repo:org",
+			},
+		},
+		right: &schema.Settings{
+			SearchMacros: map[string]string{
+				"both": "repo:user",
+			},
+		},
+		expected: &schema.Settings{
+			SearchMacros: map[string]string{
+				"site": "repo:site",
+				"both": "repo:user",
+			},
+		},
 	}, {
 		name: "deep merge insightsDashboards",
 		left: &schema.Settings{
@@ -79,6 +79,9 @@ func NewHandler(db database.DB, logger log.Logger, githubAppSetupHandler http.Ha
 	// One-click export ZIP download
 	r.Get(router.OneClickExportArchive).Handler(trace.Route(oneClickExportHandler(db, logger)))
 
+	// Audit log JSON lines download
+	r.Get(router.AuditLogsExport).Handler(trace.Route(auditLogsExportHandler(db, logger)))
+
 	// Ping retrieval
 	r.Get(router.LatestPing).Handler(trace.Route(latestPingHandler(db)))
 
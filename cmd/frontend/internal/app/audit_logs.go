@@ -0,0 +1,111 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// auditLogsExportBatchSize is the number of audit log records read from the
+// database at a time while exporting.
+const auditLogsExportBatchSize = 1000
+
+// auditLogsExportHandler writes all audit log records matching the filters in
+// the query string as JSON lines, newest first. The supported filters are
+// actorUID, action, entity, and since and until as RFC 3339 timestamps.
+func auditLogsExportHandler(db database.DB, logger log.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🚨SECURITY: Only site admins may export the audit log.
+		ctx := r.Context()
+		if err := backend.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		opts, err := auditLogListOptsFromQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		opts.Limit = auditLogsExportBatchSize
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", "attachment; filename=\"SourcegraphAuditLog.jsonl\"")
+
+		store := db.AuditLogs()
+		enc := json.NewEncoder(w)
+		for {
+			logs, next, err := store.List(ctx, opts)
+			if err != nil {
+				// The headers may already have been sent, so all we can do
+				// is to stop writing.
+				logger.Error("listing audit log records", log.Error(err))
+				return
+			}
+
+			for _, l := range logs {
+				if err := enc.Encode(auditLogExportEntry{
+					ID:           l.ID,
+					CreatedAt:    l.CreatedAt,
+					ActorUID:     l.ActorUID,
+					IP:           l.IP,
+					ForwardedFor: l.ForwardedFor,
+					Entity:       l.Entity,
+					Action:       l.Action,
+					Fields:       l.Fields,
+				}); err != nil {
+					logger.Error("writing audit log record to HTTP response", log.Error(err))
+					return
+				}
+			}
+
+			if next == 0 {
+				return
+			}
+			opts.Cursor = next
+		}
+	}
+}
+
+type auditLogExportEntry struct {
+	ID           int64           `json:"id"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	ActorUID     string          `json:"actorUID"`
+	IP           string          `json:"ip"`
+	ForwardedFor string          `json:"forwardedFor"`
+	Entity       string          `json:"entity"`
+	Action       string          `json:"action"`
+	Fields       json.RawMessage `json:"fields"`
+}
+
+func auditLogListOptsFromQuery(r *http.Request) (database.AuditLogListOpts, error) {
+	q := r.URL.Query()
+	opts := database.AuditLogListOpts{
+		ActorUID: q.Get("actorUID"),
+		Action:   q.Get("action"),
+		Entity:   q.Get("entity"),
+	}
+
+	for param, dst := range map[string]**time.Time{
+		"since": &opts.Since,
+		"until": &opts.Until,
+	} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return opts, errors.Wrapf(err, "invalid %s parameter", param)
+		}
+		*dst = &t
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAuditLogsExportHandler(t *testing.T) {
+	logger := logtest.Scoped(t)
+
+	newDB := func(user *types.User, store database.AuditLogStore) database.DB {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(user, nil)
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.AuditLogsFunc.SetDefaultReturn(store)
+		return db
+	}
+
+	t.Run("non-admins cannot export the audit log", func(t *testing.T) {
+		store := database.NewMockAuditLogStore()
+		db := newDB(&types.User{ID: 1}, store)
+
+		req, _ := http.NewRequest("GET", "", nil)
+		rec := httptest.NewRecorder()
+		auditLogsExportHandler(db, logger)(rec, req.WithContext(actor.WithActor(context.Background(), actor.FromUser(1))))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		mockassert.NotCalled(t, store.ListFunc)
+	})
+
+	t.Run("invalid time range", func(t *testing.T) {
+		store := database.NewMockAuditLogStore()
+		db := newDB(&types.User{ID: 1, SiteAdmin: true}, store)
+
+		req, _ := http.NewRequest("GET", "/site-admin/audit-logs/export?since=yesterday", nil)
+		rec := httptest.NewRecorder()
+		auditLogsExportHandler(db, logger)(rec, req.WithContext(actor.WithActor(context.Background(), actor.FromUser(1))))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockassert.NotCalled(t, store.ListFunc)
+	})
+
+	t.Run("admins can export the audit log", func(t *testing.T) {
+		since := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+
+		store := database.NewMockAuditLogStore()
+		store.ListFunc.PushReturn([]*database.AuditLog{
+			{ID: 3, ActorUID: "1", Entity: "site config", Action: "update", Fields: json.RawMessage(`{"a":1}`)},
+			{ID: 2, ActorUID: "1", Entity: "site config", Action: "update", Fields: json.RawMessage(`{}`)},
+		}, 1, nil)
+		store.ListFunc.PushReturn([]*database.AuditLog{
+			{ID: 1, ActorUID: "1", Entity: "site config", Action: "update", Fields: json.RawMessage(`{}`)},
+		}, 0, nil)
+		db := newDB(&types.User{ID: 1, SiteAdmin: true}, store)
+
+		req, _ := http.NewRequest("GET", "/site-admin/audit-logs/export?entity=site+config&since=2022-09-01T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+		auditLogsExportHandler(db, logger)(rec, req.WithContext(actor.WithActor(context.Background(), actor.FromUser(1))))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+		var ids []int64
+		scanner := bufio.NewScanner(rec.Body)
+		for scanner.Scan() {
+			var entry auditLogExportEntry
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+			ids = append(ids, entry.ID)
+		}
+		assert.Equal(t, []int64{3, 2, 1}, ids)
+
+		history := store.ListFunc.History()
+		require.Len(t, history, 2)
+		assert.Equal(t, database.AuditLogListOpts{
+			Limit:  auditLogsExportBatchSize,
+			Entity: "site config",
+			Since:  &since,
+		}, history[0].Arg1)
+		assert.Equal(t, int64(1), history[1].Arg1.Cursor)
+	})
+}
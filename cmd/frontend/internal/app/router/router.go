@@ -38,6 +38,8 @@ const (
 
 	OneClickExportArchive = "one-click-export.archive"
 
+	AuditLogsExport = "audit-logs.export"
+
 	LatestPing = "pings.latest"
 
 	SetupGitHubAppCloud = "setup.github.app.cloud"
@@ -101,6 +103,8 @@ func newRouter() *mux.Router {
 
 	base.Path("/site-admin/data-export/archive").Methods("POST").Name(OneClickExportArchive)
 
+	base.Path("/site-admin/audit-logs/export").Methods("GET").Name(AuditLogsExport)
+
 	base.Path("/site-admin/pings/latest").Methods("GET").Name(LatestPing)
 
 	base.Path("/setup/github/app/cloud").Methods("GET").Name(SetupGitHubAppCloud)
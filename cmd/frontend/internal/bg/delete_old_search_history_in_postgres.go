@@ -0,0 +1,70 @@
+package bg
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	defaultSearchHistoryRetentionDays = 90
+
+	// minSearchHistoryRetention is the smallest retention period that users can
+	// configure, so only users with unpinned entries older than this can have
+	// entries to delete.
+	minSearchHistoryRetention = 24 * time.Hour
+)
+
+// DeleteOldSearchHistoryInPostgres periodically deletes the entries of the
+// search history of users that are older than the retention period in their
+// settings. Pinned entries are kept.
+func DeleteOldSearchHistoryInPostgres(ctx context.Context, db database.DB) {
+	for {
+		if err := deleteOldSearchHistory(ctx, db); err != nil {
+			log15.Error("deleting expired rows from search_history table", "error", err)
+		}
+		time.Sleep(time.Hour)
+	}
+}
+
+func deleteOldSearchHistory(ctx context.Context, db database.DB) error {
+	store := db.SearchHistory()
+	userIDs, err := store.ListUsersWithStaleEntries(ctx, minSearchHistoryRetention)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, userID := range userIDs {
+		retention, err := searchHistoryRetention(actor.WithActor(ctx, actor.FromUser(userID)), db)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		if err := store.DeleteStale(ctx, userID, retention); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// searchHistoryRetention returns the retention period of the search history in
+// the settings of the user in ctx.
+func searchHistoryRetention(ctx context.Context, db database.DB) (time.Duration, error) {
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+
+	retentionDays := defaultSearchHistoryRetentionDays
+	if settings != nil && settings.SearchHistoryRetentionDays > 0 {
+		retentionDays = settings.SearchHistoryRetentionDays
+	}
+	return time.Duration(retentionDays) * 24 * time.Hour, nil
+}
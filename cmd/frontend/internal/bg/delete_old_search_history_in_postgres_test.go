@@ -0,0 +1,45 @@
+package bg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestDeleteOldSearchHistory(t *testing.T) {
+	setup := func(settings *schema.Settings) *database.MockSearchHistoryStore {
+		graphqlbackend.MockDecodedViewerFinalSettings = settings
+		t.Cleanup(func() { graphqlbackend.MockDecodedViewerFinalSettings = nil })
+
+		store := database.NewMockSearchHistoryStore()
+		store.ListUsersWithStaleEntriesFunc.SetDefaultReturn([]int32{1}, nil)
+		return store
+	}
+	run := func(store *database.MockSearchHistoryStore) {
+		db := database.NewMockDB()
+		db.SearchHistoryFunc.SetDefaultReturn(store)
+		require.NoError(t, deleteOldSearchHistory(context.Background(), db))
+	}
+
+	t.Run("retention in settings", func(t *testing.T) {
+		store := setup(&schema.Settings{SearchHistoryRetentionDays: 7})
+		run(store)
+
+		mockassert.CalledOnceWith(t, store.ListUsersWithStaleEntriesFunc, mockassert.Values(mockassert.Skip, 24*time.Hour))
+		mockassert.CalledOnceWith(t, store.DeleteStaleFunc, mockassert.Values(mockassert.Skip, int32(1), 7*24*time.Hour))
+	})
+
+	t.Run("default retention", func(t *testing.T) {
+		store := setup(&schema.Settings{})
+		run(store)
+
+		mockassert.CalledOnceWith(t, store.DeleteStaleFunc, mockassert.Values(mockassert.Skip, int32(1), 90*24*time.Hour))
+	})
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/handlerutil"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/releasecache"
 	apirouter "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/router"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/scim"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/webhookhandlers"
 	frontendsearch "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
 	registry "github.com/sourcegraph/sourcegraph/cmd/frontend/registry/api"
@@ -85,6 +86,18 @@ func NewHandler(
 	gh := webhooks.GitHubWebhook{
 		ExternalServices: db.ExternalServices(),
 	}
+	gl := webhooks.GitLabWebhook{
+		ExternalServices: db.ExternalServices(),
+		Next:             handlers.GitLabWebhook,
+	}
+	bbs := webhooks.BitbucketServerWebhook{
+		ExternalServices: db.ExternalServices(),
+		Next:             handlers.BitbucketServerWebhook,
+	}
+	bbc := webhooks.BitbucketCloudWebhook{
+		ExternalServices: db.ExternalServices(),
+		Next:             handlers.BitbucketCloudWebhook,
+	}
 
 	webhookhandlers.Init(db, &gh)
 	webhookMiddleware := webhooks.NewLogMiddleware(
@@ -94,14 +107,17 @@ func NewHandler(
 	handlers.GitHubWebhook.Register(&gh)
 
 	m.Get(apirouter.GitHubWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&gh)))
-	m.Get(apirouter.GitLabWebhooks).Handler(trace.Route(webhookMiddleware.Logger(handlers.GitLabWebhook)))
-	m.Get(apirouter.BitbucketServerWebhooks).Handler(trace.Route(webhookMiddleware.Logger(handlers.BitbucketServerWebhook)))
-	m.Get(apirouter.BitbucketCloudWebhooks).Handler(trace.Route(webhookMiddleware.Logger(handlers.BitbucketCloudWebhook)))
+	m.Get(apirouter.GitLabWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&gl)))
+	m.Get(apirouter.BitbucketServerWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&bbs)))
+	m.Get(apirouter.BitbucketCloudWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&bbc)))
 	m.Get(apirouter.LSIFUpload).Handler(trace.Route(handlers.NewCodeIntelUploadHandler(false)))
 	m.Get(apirouter.ComputeStream).Handler(trace.Route(handlers.NewComputeStreamHandler()))
 
 	ghSync := repos.GitHubWebhookHandler{}
 	ghSync.Register(&gh)
+	repos.NewGitLabWebhookHandler(db.Repos()).Register(&gl)
+	repos.NewBitbucketServerWebhookHandler(db.Repos()).Register(&bbs)
+	repos.NewBitbucketCloudWebhookHandler(db.Repos()).Register(&bbc)
 
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/updates").Methods("GET", "POST").Name("updatecheck").Handler(trace.Route(http.HandlerFunc(updatecheck.HandlerWithLog(logger))))
@@ -111,6 +127,10 @@ func NewHandler(
 
 	m.Get(apirouter.SearchStream).Handler(trace.Route(frontendsearch.StreamHandler(db)))
 
+	exporter := frontendsearch.NewExporter(db)
+	m.Get(apirouter.SearchExport).Handler(trace.Route(http.HandlerFunc(exporter.ServeExport)))
+	m.Get(apirouter.SearchExportDownload).Handler(trace.Route(http.HandlerFunc(exporter.ServeDownload)))
+
 	// Return the minimum src-cli version that's compatible with this instance
 	m.Get(apirouter.SrcCli).Handler(trace.Route(newSrcCliVersionHandler(logger)))
 
@@ -120,6 +140,9 @@ func NewHandler(
 
 	m.Get(apirouter.Registry).Handler(trace.Route(handler(registry.HandleRegistry(db))))
 
+	// The SCIM API authenticates requests with its own bearer token.
+	m.Get(apirouter.SCIM).Handler(trace.Route(scim.NewHandler(logger, db)))
+
 	m.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("API no route: %s %s from %s", r.Method, r.URL, r.Referer())
 		http.Error(w, "no route", http.StatusNotFound)
@@ -0,0 +1,559 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// filter is a parsed SCIM filter expression (RFC 7644 section 3.4.2.2). Filters are evaluated
+// against resources decoded from JSON into maps.
+type filter interface {
+	matches(resource map[string]any) bool
+}
+
+// attrPath is a path to a (sub-)attribute, such as "userName" or "name.givenName". Attributes of
+// extension schemas are nested under the schema URI.
+type attrPath struct {
+	uri  string
+	attr string
+	sub  string
+}
+
+// values returns the values at the path. Multi-valued attributes are flattened, so that
+// "emails.value" yields every email address.
+func (p attrPath) values(resource map[string]any) []any {
+	if p.uri != "" {
+		ext, _ := lookup(resource, p.uri).(map[string]any)
+		resource = ext
+	}
+
+	var vs []any
+	for _, v := range flatten(lookup(resource, p.attr)) {
+		if p.sub == "" {
+			vs = append(vs, v)
+			continue
+		}
+		if m, ok := v.(map[string]any); ok {
+			vs = append(vs, flatten(lookup(m, p.sub))...)
+		}
+	}
+	return vs
+}
+
+func (p attrPath) String() string {
+	s := p.attr
+	if p.uri != "" {
+		s = p.uri + ":" + s
+	}
+	if p.sub != "" {
+		s += "." + p.sub
+	}
+	return s
+}
+
+// logicalFilter is an "and" or "or" of two filters.
+type logicalFilter struct {
+	and         bool
+	left, right filter
+}
+
+func (f *logicalFilter) matches(resource map[string]any) bool {
+	if f.and {
+		return f.left.matches(resource) && f.right.matches(resource)
+	}
+	return f.left.matches(resource) || f.right.matches(resource)
+}
+
+type notFilter struct {
+	filter filter
+}
+
+func (f *notFilter) matches(resource map[string]any) bool {
+	return !f.filter.matches(resource)
+}
+
+// attrFilter compares the values at a path with a literal.
+type attrFilter struct {
+	path  attrPath
+	op    string
+	value any
+}
+
+func (f *attrFilter) matches(resource map[string]any) bool {
+	values := f.path.values(resource)
+	switch f.op {
+	case "pr":
+		for _, v := range values {
+			if !isEmpty(v) {
+				return true
+			}
+		}
+		return false
+
+	case "ne":
+		return !(&attrFilter{path: f.path, op: "eq", value: f.value}).matches(resource)
+
+	case "eq":
+		if f.value == nil {
+			return !(&attrFilter{path: f.path, op: "pr"}).matches(resource)
+		}
+	}
+
+	for _, v := range values {
+		// Comparisons with a complex attribute without a sub-attribute apply to
+		// its "value" sub-attribute, as in `emails eq "alice@example.com"`.
+		if m, ok := v.(map[string]any); ok {
+			v = lookup(m, "value")
+		}
+		if compare(v, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// valuePathFilter matches if any value of a multi-valued attribute matches the filter, as in
+// `emails[type eq "work" and value co "@example.com"]`.
+type valuePathFilter struct {
+	path   attrPath
+	filter filter
+}
+
+func (f *valuePathFilter) matches(resource map[string]any) bool {
+	return len(f.elements(resource)) > 0
+}
+
+// elements returns the values of the attribute that match the filter.
+func (f *valuePathFilter) elements(resource map[string]any) []map[string]any {
+	var matches []map[string]any
+	for _, v := range f.path.values(resource) {
+		if m, ok := v.(map[string]any); ok && f.filter.matches(m) {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// template returns the attributes that an element of the multi-valued attribute must have to
+// match the filter, if the filter consists only of "eq" comparisons joined by "and". It is used to
+// create missing elements when patching, as Azure AD expects for paths like
+// `emails[type eq "work"].value`.
+func template(f filter) (map[string]any, bool) {
+	switch f := f.(type) {
+	case *attrFilter:
+		if f.op != "eq" || f.path.uri != "" || f.path.sub != "" || f.value == nil {
+			return nil, false
+		}
+		return map[string]any{f.path.attr: f.value}, true
+	case *logicalFilter:
+		if !f.and {
+			return nil, false
+		}
+		left, ok := template(f.left)
+		if !ok {
+			return nil, false
+		}
+		right, ok := template(f.right)
+		if !ok {
+			return nil, false
+		}
+		for k, v := range right {
+			left[k] = v
+		}
+		return left, true
+	}
+	return nil, false
+}
+
+// compare reports whether "actual op expected" holds. Strings are compared case-insensitively,
+// since none of the attributes we support are case-exact.
+func compare(actual any, op string, expected any) bool {
+	switch e := expected.(type) {
+	case string:
+		a, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		a, e = strings.ToLower(a), strings.ToLower(e)
+		switch op {
+		case "eq":
+			return a == e
+		case "co":
+			return strings.Contains(a, e)
+		case "sw":
+			return strings.HasPrefix(a, e)
+		case "ew":
+			return strings.HasSuffix(a, e)
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+
+	case bool:
+		a, ok := actual.(bool)
+		return ok && op == "eq" && a == e
+
+	case float64:
+		a, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return a == e
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	}
+	return false
+}
+
+var compareOps = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true,
+}
+
+// parseFilter parses a SCIM filter expression.
+func parseFilter(s string) (filter, error) {
+	p, err := newFilterParser(s)
+	if err != nil {
+		return nil, err
+	}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+	return f, nil
+}
+
+// parsePath parses the path of a PATCH operation (RFC 7644 section 3.5.2), which is an attribute
+// path, optionally followed by a filter on its values and a sub-attribute, as in
+// `emails[type eq "work"].value`. The filter is nil if there is none.
+func parsePath(s string) (path attrPath, valueFilter filter, sub string, err error) {
+	p, err := newFilterParser(s)
+	if err != nil {
+		return attrPath{}, nil, "", err
+	}
+	if p.done() {
+		return attrPath{}, nil, "", p.errorf("empty path")
+	}
+	path, err = p.parseAttrPath(p.next())
+	if err != nil {
+		return attrPath{}, nil, "", err
+	}
+	if p.peek() == "[" {
+		if path.sub != "" {
+			return attrPath{}, nil, "", p.errorf("unexpected %q", "[")
+		}
+		p.next()
+		if valueFilter, err = p.parseOr(); err != nil {
+			return attrPath{}, nil, "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return attrPath{}, nil, "", err
+		}
+		if strings.HasPrefix(p.peek(), ".") {
+			sub = strings.TrimPrefix(p.next(), ".")
+		}
+	}
+	if !p.done() {
+		return attrPath{}, nil, "", p.errorf("unexpected %q", p.peek())
+	}
+	if valueFilter == nil {
+		// Without a filter, "name.givenName" is an attribute and its sub-attribute.
+		sub, path.sub = path.sub, ""
+	}
+	return path, valueFilter, sub, nil
+}
+
+type filterParser struct {
+	input  string
+	tokens []string
+	pos    int
+}
+
+func newFilterParser(s string) (*filterParser, error) {
+	p := &filterParser{input: s}
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			p.tokens = append(p.tokens, s[i:i+1])
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, &scimError{status: 400, scimType: "invalidFilter", detail: fmt.Sprintf("unterminated string in %q", s)}
+			}
+			p.tokens = append(p.tokens, s[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(s) && !strings.ContainsRune(" ()[]\"", rune(s[j])); j++ {
+			}
+			p.tokens = append(p.tokens, s[i:j])
+			i = j
+		}
+	}
+	return p, nil
+}
+
+func (p *filterParser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *filterParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *filterParser) expect(token string) error {
+	if t := p.next(); t != token {
+		if t == "" {
+			return p.errorf("expected %q", token)
+		}
+		return p.errorf("expected %q, got %q", token, t)
+	}
+	return nil
+}
+
+func (p *filterParser) keyword(kw string) bool {
+	if strings.EqualFold(p.peek(), kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) errorf(format string, args ...any) error {
+	return &scimError{
+		status:   400,
+		scimType: "invalidFilter",
+		detail:   fmt.Sprintf("invalid filter %q: %s", p.input, fmt.Sprintf(format, args...)),
+	}
+}
+
+// parseOr parses "or" expressions, which bind less tightly than "and".
+func (p *filterParser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filter, error) {
+	if p.keyword("not") {
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: f}, nil
+	}
+
+	if p.peek() == "(" {
+		p.next()
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	if p.done() {
+		return nil, p.errorf("unexpected end of filter")
+	}
+	path, err := p.parseAttrPath(p.next())
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek() == "[" {
+		p.next()
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		return &valuePathFilter{path: path, filter: f}, nil
+	}
+
+	op := strings.ToLower(p.next())
+	if op == "pr" {
+		return &attrFilter{path: path, op: op}, nil
+	}
+	if !compareOps[op] {
+		return nil, p.errorf("unsupported operator %q", op)
+	}
+	if p.done() {
+		return nil, p.errorf("missing value after %q", op)
+	}
+	value, err := p.parseValue(p.next())
+	if err != nil {
+		return nil, err
+	}
+	return &attrFilter{path: path, op: op, value: value}, nil
+}
+
+// coreSchemas are the URIs of the schemas whose attributes are at the top level of resources.
+var coreSchemas = []string{schemaUser, schemaGroup}
+
+func (p *filterParser) parseAttrPath(token string) (attrPath, error) {
+	if token == "" || strings.ContainsAny(token[:1], "()[]\".") {
+		return attrPath{}, p.errorf("expected attribute, got %q", token)
+	}
+
+	var path attrPath
+	if strings.HasPrefix(strings.ToLower(token), "urn:") {
+		// The attribute is the part after the last colon, but the schema URI may
+		// contain dots (such as "2.0"), so only look for sub-attributes after it.
+		i := strings.LastIndex(token, ":")
+		path.uri, token = token[:i], token[i+1:]
+		for _, s := range coreSchemas {
+			if strings.EqualFold(path.uri, s) {
+				path.uri = ""
+			}
+		}
+	}
+
+	path.attr, path.sub, _ = strings.Cut(token, ".")
+	if path.attr == "" || strings.Contains(path.sub, ".") {
+		return attrPath{}, p.errorf("invalid attribute %q", token)
+	}
+	return path, nil
+}
+
+func (p *filterParser) parseValue(token string) (any, error) {
+	switch strings.ToLower(token) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	var v any
+	if err := json.Unmarshal([]byte(token), &v); err != nil {
+		return nil, p.errorf("invalid value %s", token)
+	}
+	switch v.(type) {
+	case string, float64:
+		return v, nil
+	}
+	return nil, p.errorf("invalid value %s", token)
+}
+
+// lookup returns the value of the attribute with the given name. Attribute names are
+// case-insensitive.
+func lookup(m map[string]any, name string) any {
+	if v, ok := m[name]; ok {
+		return v
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+// key returns the key under which the attribute with the given name is stored in m, or name if
+// there is none.
+func key(m map[string]any, name string) string {
+	if _, ok := m[name]; ok {
+		return name
+	}
+	for k := range m {
+		if strings.EqualFold(k, name) {
+			return k
+		}
+	}
+	return name
+}
+
+// flatten returns the values of a multi-valued attribute, or the value of a single-valued one.
+func flatten(v any) []any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	default:
+		return []any{v}
+	}
+}
+
+func isEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
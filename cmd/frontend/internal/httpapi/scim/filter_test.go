@@ -0,0 +1,141 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	var user map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"userName": "Alice@example.com",
+		"externalId": "00u1",
+		"active": true,
+		"name": {"givenName": "Alice", "familyName": "Smith"},
+		"emails": [
+			{"value": "alice@example.com", "type": "work", "primary": true},
+			{"value": "alice@home.example", "type": "home"}
+		],
+		"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"employeeNumber": "42"}
+	}`), &user); err != nil {
+		t.Fatal(err)
+	}
+
+	for filter, want := range map[string]bool{
+		// Okta and Azure AD look up users like this.
+		`userName eq "alice@example.com"`: true,
+		`userName eq "bob@example.com"`:   false,
+		`externalId eq "00u1"`:            true,
+		`USERNAME EQ "ALICE@EXAMPLE.COM"`: true,
+
+		`userName sw "alice"`:                        true,
+		`userName ew "@example.com"`:                 true,
+		`userName co "ce@ex"`:                        true,
+		`userName ne "alice@example.com"`:            false,
+		`name.familyName eq "Smith"`:                 true,
+		`emails.value eq "alice@home.example"`:       true,
+		`emails eq "alice@home.example"`:             true,
+		`emails[type eq "work" and value co "home"]`: false,
+		`emails[type eq "home" and value co "home"]`: true,
+		`active eq true`:                             true,
+		`active eq false`:                            false,
+		`title pr`:                                   false,
+		`name pr`:                                    true,
+		`title eq null`:                              true,
+		`userName eq "x" or externalId eq "00u1"`:    true,
+		`userName eq "x" or (externalId eq "00u1" and active eq false)`: false,
+		`not (userName eq "x")`: true,
+		`urn:ietf:params:scim:schemas:core:2.0:User:userName sw "a"`:                        true,
+		`urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:employeeNumber eq "42"`: true,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			f, err := parseFilter(filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.matches(user); got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFilter_Members(t *testing.T) {
+	// Azure AD checks group membership like this.
+	f, err := parseFilter(`id eq "g1" and members[value eq "u2"]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group := map[string]any{
+		"id":      "g1",
+		"members": []any{map[string]any{"value": "u1"}, map[string]any{"value": "u2"}},
+	}
+	if !f.matches(group) {
+		t.Error("expected member to match")
+	}
+	group["members"] = []any{map[string]any{"value": "u1"}}
+	if f.matches(group) {
+		t.Error("expected non-member not to match")
+	}
+}
+
+func TestFilter_Invalid(t *testing.T) {
+	for _, filter := range []string{
+		`userName`,
+		`userName eq`,
+		`userName xx "a"`,
+		`userName eq "a`,
+		`userName eq a`,
+		`(userName eq "a"`,
+		`userName eq "a")`,
+		`emails[type eq "work"`,
+		`userName eq "a" and`,
+		`not userName eq "a"`,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			_, err := parseFilter(filter)
+			se, ok := err.(*scimError)
+			if !ok {
+				t.Fatalf("got error %v, want scimError", err)
+			}
+			if se.scimType != "invalidFilter" {
+				t.Errorf("got scimType %q, want invalidFilter", se.scimType)
+			}
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	for _, tc := range []struct {
+		path       string
+		want       attrPath
+		wantFilter bool
+		wantSub    string
+	}{
+		{path: "active", want: attrPath{attr: "active"}},
+		{path: "name.givenName", want: attrPath{attr: "name"}, wantSub: "givenName"},
+		{path: `members[value eq "u1"]`, want: attrPath{attr: "members"}, wantFilter: true},
+		{path: `emails[type eq "work"].value`, want: attrPath{attr: "emails"}, wantFilter: true, wantSub: "value"},
+		{
+			path: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager.value",
+			want: attrPath{uri: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User", attr: "manager"}, wantSub: "value",
+		},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			path, f, sub, err := parsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != tc.want {
+				t.Errorf("got path %+v, want %+v", path, tc.want)
+			}
+			if (f != nil) != tc.wantFilter {
+				t.Errorf("got filter %v, want filter: %v", f, tc.wantFilter)
+			}
+			if sub != tc.wantSub {
+				t.Errorf("got sub-attribute %q, want %q", sub, tc.wantSub)
+			}
+		})
+	}
+}
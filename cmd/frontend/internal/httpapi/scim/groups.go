@@ -0,0 +1,297 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// groupFields are the attributes of a SCIM group that are synced to the organization.
+type groupFields struct {
+	displayName string
+	externalID  string
+	// members are the SCIM IDs of the members.
+	members []string
+}
+
+func parseGroup(resource map[string]any) (*groupFields, error) {
+	f := &groupFields{}
+
+	f.displayName, _ = lookup(resource, "displayName").(string)
+	if f.displayName == "" {
+		return nil, &scimError{status: http.StatusBadRequest, scimType: "invalidValue", detail: "displayName is required"}
+	}
+	f.externalID, _ = lookup(resource, "externalId").(string)
+
+	for _, v := range flatten(lookup(resource, "members")) {
+		member, _ := v.(map[string]any)
+		id, _ := lookup(member, "value").(string)
+		if id == "" {
+			return nil, &scimError{status: http.StatusBadRequest, scimType: "invalidValue", detail: "members must have a value"}
+		}
+		f.members = append(f.members, id)
+	}
+	return f, nil
+}
+
+// groupResource returns the SCIM resource of a group. Only members that are SCIM users are
+// included, and members are omitted altogether if withMembers is false.
+func groupResource(ctx context.Context, db database.DB, sg *database.SCIMGroup, withMembers bool) (map[string]any, error) {
+	resource := map[string]any{
+		"schemas":     []any{schemaGroup},
+		"id":          sg.ID,
+		"displayName": sg.DisplayName,
+		"meta": map[string]any{
+			"resourceType": "Group",
+			"created":      sg.CreatedAt.UTC().Format(time.RFC3339),
+			"lastModified": sg.UpdatedAt.UTC().Format(time.RFC3339),
+			"location":     location("Groups", sg.ID),
+		},
+	}
+	if sg.ExternalID != "" {
+		resource["externalId"] = sg.ExternalID
+	}
+
+	if withMembers {
+		users, err := db.SCIM().ListGroupMembers(ctx, sg.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		members := make([]any, 0, len(users))
+		for _, su := range users {
+			members = append(members, map[string]any{
+				"value":   su.ID,
+				"display": su.UserName,
+				"$ref":    location("Users", su.ID),
+			})
+		}
+		resource["members"] = members
+	}
+	return resource, nil
+}
+
+func (h *handler) listGroups(r *http.Request) (*response, error) {
+	params, err := parseListParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := r.Context()
+	groups, err := h.db.SCIM().ListGroups(ctx, params.listOptions())
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]map[string]any, 0, len(groups))
+	for _, sg := range groups {
+		// Azure AD excludes members when it lists groups, which saves us from
+		// looking them up.
+		res, err := groupResource(ctx, h.db, sg, !params.excluded["members"])
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+	}
+	return &response{status: http.StatusOK, body: params.page(resources)}, nil
+}
+
+func (h *handler) getGroup(r *http.Request, id string) (*response, error) {
+	params, err := parseListParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := r.Context()
+	sg, err := h.db.SCIM().GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := groupResource(ctx, h.db, sg, !params.excluded["members"])
+	if err != nil {
+		return nil, err
+	}
+	params.exclude(res)
+	return &response{status: http.StatusOK, body: res}, nil
+}
+
+func (h *handler) createGroup(r *http.Request) (_ *response, err error) {
+	resource, err := readResource(r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseGroup(resource)
+	if err != nil {
+		return nil, err
+	}
+	name, err := auth.NormalizeUsername(f.displayName)
+	if err != nil {
+		return nil, &scimError{status: http.StatusBadRequest, scimType: "invalidValue", detail: err.Error()}
+	}
+
+	ctx := r.Context()
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Organizations that were created before SCIM was set up are adopted by
+	// groups with the same name.
+	org, err := tx.Orgs().GetByName(ctx, name)
+	if errcode.IsNotFound(err) {
+		org, err = tx.Orgs().Create(ctx, name, &f.displayName)
+	} else if err == nil {
+		_, err = tx.Orgs().Update(ctx, org.ID, &f.displayName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sg, err := tx.SCIM().CreateGroup(ctx, &database.SCIMGroup{
+		OrgID:       org.ID,
+		ExternalID:  f.externalID,
+		DisplayName: f.displayName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := syncMembers(ctx, tx, org.ID, f.members); err != nil {
+		return nil, err
+	}
+
+	res, err := groupResource(ctx, tx, sg, true)
+	if err != nil {
+		return nil, err
+	}
+	return &response{status: http.StatusCreated, body: res}, nil
+}
+
+// syncMembers adds and removes members of the organization so that its members that are SCIM
+// users are exactly the given SCIM users. Members that were added by other means are kept.
+func syncMembers(ctx context.Context, db database.DB, orgID int32, members []string) error {
+	want := map[int32]bool{}
+	for _, id := range members {
+		su, err := db.SCIM().GetUser(ctx, id)
+		if errcode.IsNotFound(err) {
+			return &scimError{status: http.StatusBadRequest, scimType: "invalidValue", detail: fmt.Sprintf("no user with ID %q", id)}
+		} else if err != nil {
+			return err
+		}
+		want[su.UserID] = true
+	}
+
+	current, err := db.SCIM().ListGroupMembers(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for _, su := range current {
+		if want[su.UserID] {
+			delete(want, su.UserID)
+			continue
+		}
+		if err := db.OrgMembers().Remove(ctx, orgID, su.UserID); err != nil {
+			return err
+		}
+	}
+
+	for userID := range want {
+		if _, err := db.OrgMembers().Create(ctx, orgID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *handler) replaceGroup(r *http.Request, id string) (*response, error) {
+	resource, err := readResource(r)
+	if err != nil {
+		return nil, err
+	}
+	return h.updateGroup(r.Context(), id, func(map[string]any) (map[string]any, error) {
+		return resource, nil
+	})
+}
+
+func (h *handler) patchGroup(r *http.Request, id string) (*response, error) {
+	ops, err := readPatch(r)
+	if err != nil {
+		return nil, err
+	}
+	return h.updateGroup(r.Context(), id, func(resource map[string]any) (map[string]any, error) {
+		return resource, applyPatch(resource, ops)
+	})
+}
+
+// updateGroup replaces the SCIM group with the result of update, which is passed the current
+// resource, and syncs the changes to the organization. The name of the organization is never
+// changed, since it is used in URLs.
+func (h *handler) updateGroup(ctx context.Context, id string, update func(map[string]any) (map[string]any, error)) (_ *response, err error) {
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	sg, err := tx.SCIM().GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	current, err := groupResource(ctx, tx, sg, true)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := update(current)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseGroup(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.displayName != sg.DisplayName {
+		if _, err := tx.Orgs().Update(ctx, sg.OrgID, &f.displayName); err != nil {
+			return nil, err
+		}
+	}
+	sg.DisplayName = f.displayName
+	sg.ExternalID = f.externalID
+	if sg, err = tx.SCIM().UpdateGroup(ctx, sg); err != nil {
+		return nil, err
+	}
+	if err := syncMembers(ctx, tx, sg.OrgID, f.members); err != nil {
+		return nil, err
+	}
+
+	res, err := groupResource(ctx, tx, sg, true)
+	if err != nil {
+		return nil, err
+	}
+	return &response{status: http.StatusOK, body: res}, nil
+}
+
+// deleteGroup soft-deletes the organization and removes the SCIM group.
+func (h *handler) deleteGroup(r *http.Request, id string) (_ *response, err error) {
+	ctx := r.Context()
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	sg, err := tx.SCIM().GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Orgs().Delete(ctx, sg.OrgID); err != nil && !errcode.IsNotFound(err) {
+		return nil, err
+	}
+	if err := tx.SCIM().DeleteGroup(ctx, id); err != nil {
+		return nil, err
+	}
+	return &response{status: http.StatusNoContent}, nil
+}
@@ -0,0 +1,380 @@
+// Package scim implements a SCIM 2.0 (RFC 7643 and RFC 7644) service provider, which lets
+// identity providers such as Okta and Azure AD provision users and groups.
+//
+// SCIM users are Sourcegraph users: deactivating a SCIM user soft-deletes the Sourcegraph user,
+// and reactivating it recovers them. SCIM groups are organizations, whose members are kept in sync
+// with the group's members. The mapping between SCIM IDs and users and organizations is stored by
+// database.SCIMStore.
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// maxResults is the maximum number of resources returned by a single list request.
+	maxResults = 1000
+
+	// maxBodySize bounds the size of request bodies. Groups with many members are the largest.
+	maxBodySize = 10 << 20
+)
+
+// handler serves the SCIM API. The routing relies on the API router having injected a
+// gorilla.Mux variable called "rest" that includes the path to route.
+type handler struct {
+	logger    log.Logger
+	db        database.DB
+	authToken func() string
+}
+
+// NewHandler returns a handler for the SCIM API, which is authenticated by the bearer token in
+// the scim.authToken site configuration.
+func NewHandler(logger log.Logger, db database.DB) http.Handler {
+	return &handler{
+		logger:    logger.Scoped("scim", "SCIM provisioning API"),
+		db:        db,
+		authToken: func() string { return conf.Get().ScimAuthToken },
+	}
+}
+
+// response is the result of a SCIM request. A nil body results in a response without a body.
+type response struct {
+	status int
+	body   any
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authenticate(w, r) {
+		return
+	}
+
+	resp, err := h.serve(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if resp.body == nil {
+		w.WriteHeader(resp.status)
+		return
+	}
+	writeJSON(w, resp.status, resp.body)
+}
+
+// authenticate checks the bearer token of the request, and writes an error response if it is
+// missing or wrong.
+//
+// 🚨 SECURITY: The SCIM API can create, modify and delete any user, so this must be called before
+// anything else.
+func (h *handler) authenticate(w http.ResponseWriter, r *http.Request) bool {
+	expected := h.authToken()
+	if expected == "" {
+		h.writeError(w, &scimError{status: http.StatusNotFound, detail: "SCIM is not enabled on this instance"})
+		return false
+	}
+
+	scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(expected)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="SCIM"`)
+		h.writeError(w, &scimError{status: http.StatusUnauthorized, detail: "invalid or missing bearer token"})
+		return false
+	}
+	return true
+}
+
+func (h *handler) serve(r *http.Request) (*response, error) {
+	path := strings.Trim(mux.Vars(r)["rest"], "/")
+	resourceType, id, _ := strings.Cut(path, "/")
+	if strings.Contains(id, "/") {
+		return nil, &scimError{status: http.StatusNotFound, detail: fmt.Sprintf("no such endpoint: %s", path)}
+	}
+
+	switch {
+	case resourceType == "ServiceProviderConfig" && id == "" && r.Method == http.MethodGet:
+		return &response{status: http.StatusOK, body: serviceProviderConfig}, nil
+
+	case resourceType == "Users" && id == "" && r.Method == http.MethodGet:
+		return h.listUsers(r)
+	case resourceType == "Users" && id == "" && r.Method == http.MethodPost:
+		return h.createUser(r)
+	case resourceType == "Users" && id != "" && r.Method == http.MethodGet:
+		return h.getUser(r, id)
+	case resourceType == "Users" && id != "" && r.Method == http.MethodPut:
+		return h.replaceUser(r, id)
+	case resourceType == "Users" && id != "" && r.Method == http.MethodPatch:
+		return h.patchUser(r, id)
+	case resourceType == "Users" && id != "" && r.Method == http.MethodDelete:
+		return h.deleteUser(r, id)
+
+	case resourceType == "Groups" && id == "" && r.Method == http.MethodGet:
+		return h.listGroups(r)
+	case resourceType == "Groups" && id == "" && r.Method == http.MethodPost:
+		return h.createGroup(r)
+	case resourceType == "Groups" && id != "" && r.Method == http.MethodGet:
+		return h.getGroup(r, id)
+	case resourceType == "Groups" && id != "" && r.Method == http.MethodPut:
+		return h.replaceGroup(r, id)
+	case resourceType == "Groups" && id != "" && r.Method == http.MethodPatch:
+		return h.patchGroup(r, id)
+	case resourceType == "Groups" && id != "" && r.Method == http.MethodDelete:
+		return h.deleteGroup(r, id)
+	}
+
+	switch resourceType {
+	case "ServiceProviderConfig", "Users", "Groups":
+		return nil, &scimError{status: http.StatusMethodNotAllowed, detail: fmt.Sprintf("unsupported method %s", r.Method)}
+	}
+	return nil, &scimError{status: http.StatusNotFound, detail: fmt.Sprintf("no such endpoint: %s", path)}
+}
+
+var serviceProviderConfig = map[string]any{
+	"schemas":          []string{schemaServiceProviderConfig},
+	"documentationUri": "https://docs.sourcegraph.com/admin/auth/scim",
+	"patch":            map[string]any{"supported": true},
+	"bulk":             map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+	"filter":           map[string]any{"supported": true, "maxResults": maxResults},
+	"changePassword":   map[string]any{"supported": false},
+	"sort":             map[string]any{"supported": false},
+	"etag":             map[string]any{"supported": false},
+	"authenticationSchemes": []map[string]any{{
+		"type":        "oauthbearertoken",
+		"name":        "Bearer token",
+		"description": "Authentication with the token in the scim.authToken site configuration.",
+		"primary":     true,
+	}},
+}
+
+// scimError is an error that is reported to the client as a SCIM error response (RFC 7644
+// section 3.12).
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+func (h *handler) writeError(w http.ResponseWriter, err error) {
+	var se *scimError
+	switch {
+	case errors.As(err, &se):
+	case database.IsSCIMUniquenessError(err):
+		se = &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: err.Error()}
+	case database.IsUsernameExists(err):
+		se = &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "the username is already in use"}
+	case database.IsEmailExists(err):
+		se = &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "the email address is already in use"}
+	case errcode.IsNotFound(err):
+		se = &scimError{status: http.StatusNotFound, detail: err.Error()}
+	default:
+		h.logger.Error("SCIM request failed", log.Error(err))
+		se = &scimError{status: http.StatusInternalServerError, detail: "internal error"}
+	}
+
+	body := map[string]any{
+		"schemas": []string{schemaError},
+		"status":  fmt.Sprint(se.status),
+		"detail":  se.detail,
+	}
+	if se.scimType != "" {
+		body["scimType"] = se.scimType
+	}
+	writeJSON(w, se.status, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// readJSON decodes the request body into v.
+func readJSON(r *http.Request, v any) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return err
+	}
+	if len(body) > maxBodySize {
+		return &scimError{status: http.StatusRequestEntityTooLarge, detail: "request body is too large"}
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return &scimError{status: http.StatusBadRequest, scimType: "invalidSyntax", detail: fmt.Sprintf("invalid JSON: %s", err)}
+	}
+	return nil
+}
+
+// readResource decodes a resource from the request body.
+func readResource(r *http.Request) (map[string]any, error) {
+	var resource map[string]any
+	if err := readJSON(r, &resource); err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, &scimError{status: http.StatusBadRequest, scimType: "invalidSyntax", detail: "the request body must be a JSON object"}
+	}
+	return resource, nil
+}
+
+// readPatch decodes the operations of a PATCH request from the request body.
+func readPatch(r *http.Request) ([]patchOperation, error) {
+	var req patchRequest
+	if err := readJSON(r, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Operations) == 0 {
+		return nil, &scimError{status: http.StatusBadRequest, scimType: "invalidSyntax", detail: "no operations in patch request"}
+	}
+	return req.Operations, nil
+}
+
+// attributes that are set by the service provider, and never stored from requests.
+var readOnlyAttributes = []string{"id", "meta"}
+
+// storedAttributes returns the JSON of the resource without its read-only attributes.
+func storedAttributes(resource map[string]any) (json.RawMessage, error) {
+	stored := make(map[string]any, len(resource))
+	for k, v := range resource {
+		stored[k] = v
+	}
+	for _, name := range readOnlyAttributes {
+		delete(stored, key(stored, name))
+	}
+	return json.Marshal(stored)
+}
+
+// location returns the URL of a resource.
+func location(resourceType, id string) string {
+	return strings.TrimSuffix(conf.ExternalURL(), "/") + "/.api/scim/v2/" + resourceType + "/" + id
+}
+
+// setAttribute sets the attribute with the given name, replacing any attribute whose name differs
+// only in case.
+func setAttribute(resource map[string]any, name string, value any) {
+	resource[key(resource, name)] = value
+}
+
+// listParams are the query parameters of a list request (RFC 7644 section 3.4.2).
+type listParams struct {
+	filter     filter
+	startIndex int
+	count      int
+	excluded   map[string]bool
+}
+
+func parseListParams(r *http.Request) (*listParams, error) {
+	q := r.URL.Query()
+	params := &listParams{startIndex: 1, count: maxResults, excluded: map[string]bool{}}
+
+	if s := q.Get("filter"); s != "" {
+		f, err := parseFilter(s)
+		if err != nil {
+			return nil, err
+		}
+		params.filter = f
+	}
+
+	// Invalid values are interpreted as the defaults, as the spec requires for values less than
+	// 1 and 0 respectively.
+	if n, err := parseInt(q.Get("startIndex")); err == nil && n > 1 {
+		params.startIndex = n
+	}
+	if n, err := parseInt(q.Get("count")); err == nil && n >= 0 && n < maxResults {
+		params.count = n
+	}
+
+	for _, name := range strings.Split(q.Get("excludedAttributes"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			params.excluded[strings.ToLower(name)] = true
+		}
+	}
+	return params, nil
+}
+
+func parseInt(s string) (int, error) {
+	var n int
+	_, err := fmt.Sscanf(s, "%d", &n)
+	return n, err
+}
+
+// listOptions returns the list options for the database query that narrows down the resources
+// that the filter may match, for the simple filters that identity providers use to look up
+// resources.
+func (p *listParams) listOptions() database.SCIMListOpts {
+	f, ok := p.filter.(*attrFilter)
+	if !ok || f.op != "eq" || f.path.uri != "" || f.path.sub != "" {
+		return database.SCIMListOpts{}
+	}
+	value, ok := f.value.(string)
+	if !ok {
+		return database.SCIMListOpts{}
+	}
+
+	switch strings.ToLower(f.path.attr) {
+	case "username":
+		return database.SCIMListOpts{UserName: value}
+	case "displayname":
+		return database.SCIMListOpts{DisplayName: value}
+	case "externalid":
+		return database.SCIMListOpts{ExternalID: value}
+	}
+	return database.SCIMListOpts{}
+}
+
+// page filters the resources and returns the requested page of them as a list response.
+func (p *listParams) page(resources []map[string]any) map[string]any {
+	matches := []map[string]any{}
+	for _, res := range resources {
+		if p.filter == nil || p.filter.matches(res) {
+			matches = append(matches, res)
+		}
+	}
+
+	page := []map[string]any{}
+	if start := p.startIndex - 1; start < len(matches) {
+		end := start + p.count
+		if end > len(matches) {
+			end = len(matches)
+		}
+		page = matches[start:end]
+	}
+	for _, res := range page {
+		p.exclude(res)
+	}
+
+	return map[string]any{
+		"schemas":      []string{schemaListResponse},
+		"totalResults": len(matches),
+		"startIndex":   p.startIndex,
+		"itemsPerPage": len(page),
+		"Resources":    page,
+	}
+}
+
+// exclude removes the attributes listed in the excludedAttributes parameter from the resource.
+func (p *listParams) exclude(resource map[string]any) {
+	for k := range resource {
+		if p.excluded[strings.ToLower(k)] && k != "id" && k != "schemas" {
+			delete(resource, k)
+		}
+	}
+}
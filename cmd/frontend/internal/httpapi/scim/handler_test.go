@@ -0,0 +1,371 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const testToken = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var errNotFound = &errcode.Mock{Message: "not found", IsNotFound: true}
+
+// newTestDB returns a mock DB whose transactions are the DB itself.
+func newTestDB(scim *database.MockSCIMStore, users *database.MockUserStore) *database.MockDB {
+	db := database.NewMockDB()
+	db.TransactFunc.SetDefaultReturn(db, nil)
+	db.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	db.SCIMFunc.SetDefaultReturn(scim)
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+	return db
+}
+
+func serve(t *testing.T, db database.DB, token, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	h := &handler{logger: logtest.Scoped(t), db: db, authToken: func() string { return testToken }}
+	router := mux.NewRouter()
+	router.Path("/.api/scim/v2/{rest:.*}").Handler(h)
+
+	req := httptest.NewRequest(method, "/.api/scim/v2/"+path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeBody(t *testing.T, rec *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid response body %q: %s", rec.Body.String(), err)
+	}
+	return body
+}
+
+func TestAuthenticate(t *testing.T) {
+	db := newTestDB(database.NewMockSCIMStore(), database.NewMockUserStore())
+
+	t.Run("not enabled", func(t *testing.T) {
+		h := &handler{logger: logtest.Scoped(t), db: db, authToken: func() string { return "" }}
+		req := httptest.NewRequest(http.MethodGet, "/.api/scim/v2/Users", nil)
+		req.Header.Set("Authorization", "Bearer ")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+
+	for name, token := range map[string]string{"missing": "", "wrong": "not-the-token"} {
+		t.Run(name+" token", func(t *testing.T) {
+			rec := serve(t, db, token, http.MethodGet, "ServiceProviderConfig", "")
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+			}
+			if rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("missing WWW-Authenticate header")
+			}
+		})
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		rec := serve(t, db, testToken, http.MethodGet, "ServiceProviderConfig", "")
+		if rec.Code != http.StatusOK {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/scim+json" {
+			t.Errorf("got Content-Type %q", ct)
+		}
+	})
+}
+
+func TestCreateUser(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByUsernameFunc.SetDefaultReturn(nil, errNotFound)
+	users.GetByVerifiedEmailFunc.SetDefaultReturn(nil, errNotFound)
+	users.CreateFunc.SetDefaultReturn(&types.User{ID: 7}, nil)
+
+	scim := database.NewMockSCIMStore()
+	scim.CreateUserFunc.SetDefaultHook(func(_ context.Context, su *database.SCIMUser) (*database.SCIMUser, error) {
+		su.ID = "u1"
+		return su, nil
+	})
+
+	rec := serve(t, newTestDB(scim, users), testToken, http.MethodPost, "Users", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "alice@example.com",
+		"externalId": "00u1",
+		"name": {"givenName": "Alice", "familyName": "Smith"},
+		"emails": [{"value": "alice@example.com", "type": "work", "primary": true}],
+		"active": true
+	}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+
+	want := database.NewUser{
+		Username:        "alice",
+		DisplayName:     "Alice Smith",
+		Email:           "alice@example.com",
+		EmailIsVerified: true,
+	}
+	if diff := cmp.Diff(want, users.CreateFunc.History()[0].Arg1); diff != "" {
+		t.Errorf("unexpected new user (-want +got):\n%s", diff)
+	}
+
+	su := scim.CreateUserFunc.History()[0].Arg1
+	if su.UserID != 7 || su.UserName != "alice@example.com" || su.ExternalID != "00u1" || !su.Active {
+		t.Errorf("unexpected SCIM user %+v", su)
+	}
+
+	body := decodeBody(t, rec)
+	if body["id"] != "u1" || body["userName"] != "alice@example.com" {
+		t.Errorf("unexpected response %v", body)
+	}
+}
+
+func TestCreateUser_Adopt(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByUsernameFunc.SetDefaultReturn(nil, errNotFound)
+	users.GetByVerifiedEmailFunc.SetDefaultReturn(&types.User{ID: 3, Username: "asmith"}, nil)
+	users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 3, Username: "asmith"}, nil)
+
+	scim := database.NewMockSCIMStore()
+	scim.CreateUserFunc.SetDefaultHook(func(_ context.Context, su *database.SCIMUser) (*database.SCIMUser, error) {
+		return su, nil
+	})
+
+	rec := serve(t, newTestDB(scim, users), testToken, http.MethodPost, "Users", `{
+		"userName": "alice",
+		"emails": [{"value": "alice@example.com"}]
+	}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+
+	if len(users.CreateFunc.History()) != 0 {
+		t.Error("expected existing user to be adopted")
+	}
+	if got := scim.CreateUserFunc.History()[0].Arg1.UserID; got != 3 {
+		t.Errorf("got user ID %d, want 3", got)
+	}
+	if got := users.UpdateFunc.History()[0].Arg2.Username; got != "alice" {
+		t.Errorf("got username %q, want alice", got)
+	}
+}
+
+func TestPatchUser_Active(t *testing.T) {
+	newStores := func(active bool) (*database.MockSCIMStore, *database.MockUserStore) {
+		scim := database.NewMockSCIMStore()
+		scim.GetUserFunc.SetDefaultReturn(&database.SCIMUser{
+			ID:         "u1",
+			UserID:     7,
+			UserName:   "alice",
+			Active:     active,
+			Attributes: json.RawMessage(`{"userName": "alice", "active": true}`),
+		}, nil)
+		scim.UpdateUserFunc.SetDefaultHook(func(_ context.Context, su *database.SCIMUser) (*database.SCIMUser, error) {
+			return su, nil
+		})
+		return scim, database.NewMockUserStore()
+	}
+
+	t.Run("deactivate", func(t *testing.T) {
+		scim, users := newStores(true)
+
+		// Okta deactivates users like this.
+		rec := serve(t, newTestDB(scim, users), testToken, http.MethodPatch, "Users/u1", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "replace", "value": {"active": false}}]
+		}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+		}
+
+		if h := users.DeleteFunc.History(); len(h) != 1 || h[0].Arg1 != 7 {
+			t.Errorf("expected user 7 to be deleted, got %+v", h)
+		}
+		if scim.UpdateUserFunc.History()[0].Arg1.Active {
+			t.Error("expected SCIM user to be inactive")
+		}
+		if body := decodeBody(t, rec); body["active"] != false {
+			t.Errorf("got active %v, want false", body["active"])
+		}
+	})
+
+	t.Run("reactivate", func(t *testing.T) {
+		scim, users := newStores(false)
+		users.GetByIDFunc.PushReturn(nil, errNotFound)
+		users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 7, Username: "alice"}, nil)
+
+		// Azure AD reactivates users like this.
+		rec := serve(t, newTestDB(scim, users), testToken, http.MethodPatch, "Users/u1", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "Replace", "path": "active", "value": "True"}]
+		}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+		}
+
+		if h := users.RecoverListFunc.History(); len(h) != 1 || !cmp.Equal(h[0].Arg1, []int32{7}) {
+			t.Errorf("expected user 7 to be recovered, got %+v", h)
+		}
+		if len(users.DeleteFunc.History()) != 0 {
+			t.Error("expected user not to be deleted")
+		}
+		if !scim.UpdateUserFunc.History()[0].Arg1.Active {
+			t.Error("expected SCIM user to be active")
+		}
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	scim := database.NewMockSCIMStore()
+	scim.GetUserFunc.SetDefaultReturn(&database.SCIMUser{ID: "u1", UserID: 7, Active: true}, nil)
+	users := database.NewMockUserStore()
+
+	rec := serve(t, newTestDB(scim, users), testToken, http.MethodDelete, "Users/u1", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusNoContent, rec.Body)
+	}
+	if len(users.DeleteFunc.History()) != 1 || len(users.HardDeleteFunc.History()) != 0 {
+		t.Error("expected user to be soft-deleted")
+	}
+	if len(scim.DeleteUserFunc.History()) != 1 {
+		t.Error("expected SCIM user to be deleted")
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		scim := database.NewMockSCIMStore()
+		scim.GetUserFunc.SetDefaultReturn(nil, errNotFound)
+
+		rec := serve(t, newTestDB(scim, database.NewMockUserStore()), testToken, http.MethodDelete, "Users/u2", "")
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+}
+
+func TestListUsers(t *testing.T) {
+	now := time.Now()
+	scim := database.NewMockSCIMStore()
+	scim.ListUsersFunc.SetDefaultReturn([]*database.SCIMUser{
+		{ID: "u1", UserName: "alice", Active: true, Attributes: json.RawMessage(`{}`), CreatedAt: now, UpdatedAt: now},
+		{ID: "u2", UserName: "bob", Active: false, Attributes: json.RawMessage(`{}`), CreatedAt: now, UpdatedAt: now},
+		{ID: "u3", UserName: "carol", Active: true, Attributes: json.RawMessage(`{}`), CreatedAt: now, UpdatedAt: now},
+	}, nil)
+	db := newTestDB(scim, database.NewMockUserStore())
+
+	ids := func(body map[string]any) (ids []string) {
+		resources, _ := body["Resources"].([]any)
+		for _, res := range resources {
+			ids = append(ids, res.(map[string]any)["id"].(string))
+		}
+		return ids
+	}
+
+	t.Run("simple filter is pushed down", func(t *testing.T) {
+		serve(t, db, testToken, http.MethodGet, `Users?filter=userName+eq+"alice"`, "")
+		h := scim.ListUsersFunc.History()
+		if got := h[len(h)-1].Arg1; got != (database.SCIMListOpts{UserName: "alice"}) {
+			t.Errorf("got list options %+v", got)
+		}
+	})
+
+	t.Run("filter and page", func(t *testing.T) {
+		rec := serve(t, db, testToken, http.MethodGet, "Users?filter=active+eq+true&startIndex=2&count=1", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+		}
+		body := decodeBody(t, rec)
+		if body["totalResults"] != float64(2) || body["itemsPerPage"] != float64(1) {
+			t.Errorf("unexpected list response %v", body)
+		}
+		if diff := cmp.Diff([]string{"u3"}, ids(body)); diff != "" {
+			t.Errorf("unexpected users (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		rec := serve(t, db, testToken, http.MethodGet, "Users?filter=active+eq", "")
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("got status %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+		if body := decodeBody(t, rec); body["scimType"] != "invalidFilter" {
+			t.Errorf("got scimType %v, want invalidFilter", body["scimType"])
+		}
+	})
+}
+
+func TestPatchGroup_Members(t *testing.T) {
+	scimUsers := map[string]*database.SCIMUser{
+		"u1": {ID: "u1", UserID: 1, UserName: "alice"},
+		"u2": {ID: "u2", UserID: 2, UserName: "bob"},
+	}
+
+	scim := database.NewMockSCIMStore()
+	scim.GetGroupFunc.SetDefaultReturn(&database.SCIMGroup{ID: "g1", OrgID: 5, DisplayName: "Engineering"}, nil)
+	scim.UpdateGroupFunc.SetDefaultHook(func(_ context.Context, sg *database.SCIMGroup) (*database.SCIMGroup, error) {
+		return sg, nil
+	})
+	scim.GetUserFunc.SetDefaultHook(func(_ context.Context, id string) (*database.SCIMUser, error) {
+		if su, ok := scimUsers[id]; ok {
+			return su, nil
+		}
+		return nil, errNotFound
+	})
+	scim.ListGroupMembersFunc.SetDefaultReturn([]*database.SCIMUser{scimUsers["u1"]}, nil)
+
+	orgMembers := database.NewMockOrgMemberStore()
+	orgs := database.NewMockOrgStore()
+	db := newTestDB(scim, database.NewMockUserStore())
+	db.OrgMembersFunc.SetDefaultReturn(orgMembers)
+	db.OrgsFunc.SetDefaultReturn(orgs)
+
+	// Azure AD removes members with a value rather than a filter.
+	rec := serve(t, db, testToken, http.MethodPatch, "Groups/g1", `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "Add", "path": "members", "value": [{"value": "u2"}]},
+			{"op": "Remove", "path": "members", "value": [{"value": "u1"}]}
+		]
+	}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	if h := orgMembers.RemoveFunc.History(); len(h) != 1 || h[0].Arg1 != 5 || h[0].Arg2 != 1 {
+		t.Errorf("expected user 1 to be removed from org 5, got %+v", h)
+	}
+	if h := orgMembers.CreateFunc.History(); len(h) != 1 || h[0].Arg1 != 5 || h[0].Arg2 != 2 {
+		t.Errorf("expected user 2 to be added to org 5, got %+v", h)
+	}
+	if len(orgs.UpdateFunc.History()) != 0 {
+		t.Error("expected org not to be updated")
+	}
+
+	t.Run("unknown member", func(t *testing.T) {
+		rec := serve(t, db, testToken, http.MethodPatch, "Groups/g1", `{
+			"Operations": [{"op": "add", "path": "members", "value": [{"value": "u9"}]}]
+		}`)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package scim
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// patchRequest is the body of a PATCH request (RFC 7644 section 3.5.2).
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// applyPatch applies the operations to the resource in place.
+//
+// Identity providers don't agree on the finer points of the spec, so this is lenient where that
+// is unambiguous: operation names are case-insensitive (Azure AD sends "Replace"), operations
+// without a path may use paths as keys of their value, and removing values from a multi-valued
+// attribute may be done by listing them in the value (as Azure AD does for group members).
+func applyPatch(resource map[string]any, ops []patchOperation) error {
+	for _, op := range ops {
+		if err := applyOperation(resource, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyOperation(resource map[string]any, op patchOperation) error {
+	kind := strings.ToLower(op.Op)
+	switch kind {
+	case "add", "replace", "remove":
+	default:
+		return &scimError{status: 400, scimType: "invalidSyntax", detail: fmt.Sprintf("unsupported patch operation %q", op.Op)}
+	}
+
+	if op.Path == "" {
+		if kind == "remove" {
+			return &scimError{status: 400, scimType: "noTarget", detail: "remove operations require a path"}
+		}
+		values, ok := op.Value.(map[string]any)
+		if !ok {
+			return &scimError{status: 400, scimType: "invalidValue", detail: fmt.Sprintf("the value of a patch operation without a path must be an object, got %T", op.Value)}
+		}
+		for name, v := range values {
+			if err := applyOperation(resource, patchOperation{Op: kind, Path: name, Value: v}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path, valueFilter, sub, err := parsePath(op.Path)
+	if err != nil {
+		return err
+	}
+
+	parent := resource
+	if path.uri != "" {
+		ext, ok := lookup(resource, path.uri).(map[string]any)
+		if !ok {
+			if kind == "remove" {
+				return nil
+			}
+			ext = map[string]any{}
+			resource[key(resource, path.uri)] = ext
+		}
+		parent = ext
+	}
+
+	if valueFilter != nil {
+		return patchFiltered(parent, kind, path.attr, valueFilter, sub, op.Value)
+	}
+
+	if sub != "" {
+		complexValue, ok := lookup(parent, path.attr).(map[string]any)
+		if !ok {
+			if kind == "remove" {
+				return nil
+			}
+			complexValue = map[string]any{}
+			parent[key(parent, path.attr)] = complexValue
+		}
+		parent, path.attr = complexValue, sub
+	}
+
+	k := key(parent, path.attr)
+	switch kind {
+	case "add":
+		existing, isMulti := parent[k].([]any)
+		if !isMulti {
+			parent[k] = op.Value
+			return nil
+		}
+		for _, v := range flatten(op.Value) {
+			if !containsValue(existing, v) {
+				existing = append(existing, v)
+			}
+		}
+		parent[k] = existing
+
+	case "replace":
+		parent[k] = op.Value
+
+	case "remove":
+		existing, isMulti := parent[k].([]any)
+		if !isMulti || op.Value == nil {
+			delete(parent, k)
+			return nil
+		}
+		remaining := []any{}
+		for _, v := range existing {
+			if !containsValue(flatten(op.Value), v) {
+				remaining = append(remaining, v)
+			}
+		}
+		parent[k] = remaining
+	}
+	return nil
+}
+
+// patchFiltered applies an operation to the values of a multi-valued attribute that match a
+// filter, as in `members[value eq "2819c223"]` or `emails[type eq "work"].value`.
+func patchFiltered(parent map[string]any, kind, attr string, valueFilter filter, sub string, value any) error {
+	k := key(parent, attr)
+	existing := flatten(parent[k])
+
+	var matched bool
+	var result []any
+	for _, v := range existing {
+		m, ok := v.(map[string]any)
+		if !ok || !valueFilter.matches(m) {
+			result = append(result, v)
+			continue
+		}
+		matched = true
+
+		switch {
+		case kind == "remove" && sub == "":
+			continue
+		case kind == "remove":
+			delete(m, key(m, sub))
+		case sub != "":
+			m[key(m, sub)] = value
+		case kind == "replace":
+			if v, ok := value.(map[string]any); ok {
+				m = v
+			}
+		default:
+			if v, ok := value.(map[string]any); ok {
+				for vk, vv := range v {
+					m[key(m, vk)] = vv
+				}
+			}
+		}
+		result = append(result, m)
+	}
+
+	if !matched {
+		if kind == "remove" {
+			return nil
+		}
+		// Add a value with the attributes required by the filter.
+		m, ok := template(valueFilter)
+		if !ok {
+			return &scimError{status: 400, scimType: "noTarget", detail: fmt.Sprintf("no values of %q match the filter", attr)}
+		}
+		if sub != "" {
+			m[sub] = value
+		} else if v, ok := value.(map[string]any); ok {
+			for vk, vv := range v {
+				m[key(m, vk)] = vv
+			}
+		}
+		result = append(result, m)
+	}
+
+	if result == nil {
+		result = []any{}
+	}
+	parent[k] = result
+	return nil
+}
+
+// containsValue reports whether values contains v. Complex values with a "value" sub-attribute,
+// such as group members, are compared by it.
+func containsValue(values []any, v any) bool {
+	for _, existing := range values {
+		if reflect.DeepEqual(existing, v) {
+			return true
+		}
+		em, ok1 := existing.(map[string]any)
+		vm, ok2 := v.(map[string]any)
+		if ok1 && ok2 {
+			if ev, vv := lookup(em, "value"), lookup(vm, "value"); ev != nil && reflect.DeepEqual(ev, vv) {
+				return true
+			}
+		}
+	}
+	return false
+}
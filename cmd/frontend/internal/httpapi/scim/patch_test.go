@@ -0,0 +1,146 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestApplyPatch(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		resource string
+		ops      string
+		want     string
+	}{
+		{
+			name:     "Okta deactivation",
+			resource: `{"userName": "alice", "active": true}`,
+			ops:      `[{"op": "replace", "value": {"active": false}}]`,
+			want:     `{"userName": "alice", "active": false}`,
+		},
+		{
+			name:     "Azure AD deactivation",
+			resource: `{"userName": "alice", "active": true}`,
+			ops:      `[{"op": "Replace", "path": "active", "value": "False"}]`,
+			want:     `{"userName": "alice", "active": "False"}`,
+		},
+		{
+			name:     "attributes are case-insensitive",
+			resource: `{"userName": "alice", "displayName": "Alice"}`,
+			ops:      `[{"op": "replace", "path": "displayname", "value": "Alice Smith"}]`,
+			want:     `{"userName": "alice", "displayName": "Alice Smith"}`,
+		},
+		{
+			name:     "sub-attribute",
+			resource: `{"name": {"givenName": "Alice"}}`,
+			ops:      `[{"op": "replace", "path": "name.familyName", "value": "Smith"}, {"op": "remove", "path": "name.givenName"}]`,
+			want:     `{"name": {"familyName": "Smith"}}`,
+		},
+		{
+			name:     "path as key of value",
+			resource: `{"name": {"givenName": "Alice"}}`,
+			ops:      `[{"op": "replace", "value": {"name.givenName": "Alicia", "displayName": "Alicia"}}]`,
+			want:     `{"name": {"givenName": "Alicia"}, "displayName": "Alicia"}`,
+		},
+		{
+			name:     "filtered sub-attribute",
+			resource: `{"emails": [{"type": "work", "value": "a@example.com"}, {"type": "home", "value": "a@home.example"}]}`,
+			ops:      `[{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "alice@example.com"}]`,
+			want:     `{"emails": [{"type": "work", "value": "alice@example.com"}, {"type": "home", "value": "a@home.example"}]}`,
+		},
+		{
+			name:     "filtered sub-attribute without a match",
+			resource: `{"userName": "alice"}`,
+			ops:      `[{"op": "add", "path": "emails[type eq \"work\"].value", "value": "alice@example.com"}]`,
+			want:     `{"userName": "alice", "emails": [{"type": "work", "value": "alice@example.com"}]}`,
+		},
+		{
+			name:     "add members",
+			resource: `{"members": [{"value": "u1"}]}`,
+			ops:      `[{"op": "add", "path": "members", "value": [{"value": "u1"}, {"value": "u2"}]}]`,
+			want:     `{"members": [{"value": "u1"}, {"value": "u2"}]}`,
+		},
+		{
+			name:     "remove member by filter",
+			resource: `{"members": [{"value": "u1"}, {"value": "u2"}]}`,
+			ops:      `[{"op": "remove", "path": "members[value eq \"u1\"]"}]`,
+			want:     `{"members": [{"value": "u2"}]}`,
+		},
+		{
+			name:     "remove member by value",
+			resource: `{"members": [{"value": "u1", "display": "alice"}, {"value": "u2"}]}`,
+			ops:      `[{"op": "Remove", "path": "members", "value": [{"value": "u1"}]}]`,
+			want:     `{"members": [{"value": "u2"}]}`,
+		},
+		{
+			name:     "remove all members",
+			resource: `{"displayName": "Engineering", "members": [{"value": "u1"}]}`,
+			ops:      `[{"op": "remove", "path": "members"}]`,
+			want:     `{"displayName": "Engineering"}`,
+		},
+		{
+			name:     "replace members",
+			resource: `{"members": [{"value": "u1"}]}`,
+			ops:      `[{"op": "replace", "path": "members", "value": [{"value": "u3"}]}]`,
+			want:     `{"members": [{"value": "u3"}]}`,
+		},
+		{
+			name:     "extension attribute",
+			resource: `{"userName": "alice"}`,
+			ops:      `[{"op": "add", "path": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", "value": "R&D"}]`,
+			want:     `{"userName": "alice", "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "R&D"}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var resource, want map[string]any
+			var ops []patchOperation
+			mustUnmarshal(t, tc.resource, &resource)
+			mustUnmarshal(t, tc.ops, &ops)
+			mustUnmarshal(t, tc.want, &want)
+
+			if err := applyPatch(resource, ops); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, resource); diff != "" {
+				t.Errorf("unexpected resource (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyPatch_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		ops          string
+		wantScimType string
+	}{
+		{name: "unknown operation", ops: `[{"op": "move", "path": "userName"}]`, wantScimType: "invalidSyntax"},
+		{name: "remove without path", ops: `[{"op": "remove"}]`, wantScimType: "noTarget"},
+		{name: "non-object value without path", ops: `[{"op": "replace", "value": "alice"}]`, wantScimType: "invalidValue"},
+		{name: "invalid path", ops: `[{"op": "replace", "path": "emails[type eq]", "value": "a"}]`, wantScimType: "invalidFilter"},
+		{name: "no match for complex filter", ops: `[{"op": "replace", "path": "emails[type ne \"work\"].value", "value": "a"}]`, wantScimType: "noTarget"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var ops []patchOperation
+			mustUnmarshal(t, tc.ops, &ops)
+
+			err := applyPatch(map[string]any{"userName": "alice"}, ops)
+			se, ok := err.(*scimError)
+			if !ok {
+				t.Fatalf("got error %v, want scimError", err)
+			}
+			if se.scimType != tc.wantScimType {
+				t.Errorf("got scimType %q, want %q", se.scimType, tc.wantScimType)
+			}
+		})
+	}
+}
+
+func mustUnmarshal(t *testing.T, s string, v any) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(s), v); err != nil {
+		t.Fatal(err)
+	}
+}
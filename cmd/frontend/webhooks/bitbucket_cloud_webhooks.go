@@ -0,0 +1,82 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// BitbucketCloudWebhook is responsible for handling incoming http requests for
+// bitbucket cloud webhooks and routing to any registered WebhookHandlers.
+// Events are routed by their event key, passed in the X-Event-Key header.
+// Requests for event keys without registered handlers are passed on to Next.
+type BitbucketCloudWebhook struct {
+	ExternalServices database.ExternalServiceStore
+	Next             http.Handler
+
+	router
+}
+
+func (h *BitbucketCloudWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log15.Error("Error reading bitbucket cloud webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	eventKey := r.Header.Get("X-Event-Key")
+	h.serve(w, r, webhookRequest{
+		codeHost:  extsvc.TypeBitbucketCloud,
+		eventType: eventKey,
+		body:      body,
+		next:      h.Next,
+		validate: func(ctx context.Context) (*types.ExternalService, error) {
+			return h.getExternalService(ctx, r.FormValue(extsvc.IDParam), r.FormValue("secret"))
+		},
+		parse: func() (any, error) {
+			return bitbucketcloud.ParseWebhookEvent(eventKey, body)
+		},
+	})
+}
+
+// getExternalService returns the Bitbucket Cloud external service with the
+// given webhook secret. Bitbucket Cloud doesn't sign webhook payloads, so the
+// secret is passed in the webhook URL instead. The raw ID is empty for webhooks
+// that were created before it was added to the webhook URL, in which case all
+// Bitbucket Cloud external services are tried.
+func (h *BitbucketCloudWebhook) getExternalService(ctx context.Context, rawID, secret string) (*types.ExternalService, error) {
+	// 🚨 SECURITY: An empty secret never succeeds.
+	if secret == "" {
+		return nil, errUnauthorized
+	}
+
+	es, err := listExternalServices(ctx, h.ExternalServices, extsvc.KindBitbucketCloud, rawID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range es {
+		c, err := e.Configuration(ctx)
+		if err != nil {
+			return nil, err
+		}
+		con, ok := c.(*schema.BitbucketCloudConnection)
+		if !ok {
+			continue
+		}
+
+		if con.WebhookSecret == secret {
+			return e, nil
+		}
+	}
+	return nil, errUnauthorized
+}
@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestBitbucketCloudWebhook(t *testing.T) {
+	extSvc := newExternalService(t, 1, extsvc.KindBitbucketCloud, schema.BitbucketCloudConnection{
+		Url:           "https://bitbucket.org",
+		WebhookSecret: "secret",
+	})
+	h := &BitbucketCloudWebhook{ExternalServices: mockExternalServices(t, extSvc)}
+	dispatched := recordDispatches(h, "repo:push")
+
+	for _, tc := range []struct {
+		name   string
+		id     string
+		secret string
+		want   int
+	}{
+		{name: "valid secret", id: "1", secret: "secret", want: http.StatusOK},
+		{name: "valid secret without ID", secret: "secret", want: http.StatusOK},
+		{name: "wrong secret", id: "1", secret: "wrong", want: http.StatusUnauthorized},
+		{name: "empty secret", id: "1", want: http.StatusUnauthorized},
+		{name: "unknown ID", id: "2", secret: "secret", want: http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			*dispatched = nil
+			req := httptest.NewRequest("POST", "/.api/bitbucket-cloud-webhooks?externalServiceID="+tc.id+"&secret="+tc.secret, strings.NewReader(`{"repository":{"uuid":"{42}"},"push":{"changes":[]}}`))
+			req.Header.Set("X-Event-Key", "repo:push")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.want, rec.Code)
+			if tc.want == http.StatusOK {
+				assert.Equal(t, []*types.ExternalService{extSvc}, *dispatched)
+			} else {
+				assert.Empty(t, *dispatched)
+			}
+		})
+	}
+}
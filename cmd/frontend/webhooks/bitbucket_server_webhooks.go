@@ -0,0 +1,82 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	gh "github.com/google/go-github/v43/github"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// BitbucketServerWebhook is responsible for handling incoming http requests for
+// bitbucket server webhooks and routing to any registered WebhookHandlers.
+// Events are routed by their event type, passed in the X-Event-Key header.
+// Requests for event types without registered handlers are passed on to Next.
+type BitbucketServerWebhook struct {
+	ExternalServices database.ExternalServiceStore
+	Next             http.Handler
+
+	router
+}
+
+func (h *BitbucketServerWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log15.Error("Error reading bitbucket server webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	eventType := bitbucketserver.WebhookEventType(r)
+	h.serve(w, r, webhookRequest{
+		codeHost:  extsvc.TypeBitbucketServer,
+		eventType: eventType,
+		body:      body,
+		next:      h.Next,
+		validate: func(ctx context.Context) (*types.ExternalService, error) {
+			return h.getExternalService(ctx, r.FormValue(extsvc.IDParam), r.Header.Get("X-Hub-Signature"), body)
+		},
+		parse: func() (any, error) {
+			return bitbucketserver.ParseWebhookEvent(eventType, body)
+		},
+	})
+}
+
+// getExternalService returns the Bitbucket Server external service whose
+// webhook secret the payload was signed with. The raw ID is empty for webhooks
+// that were created before it was added to the webhook URL, in which case all
+// Bitbucket Server external services are tried.
+func (h *BitbucketServerWebhook) getExternalService(ctx context.Context, rawID, sig string, body []byte) (*types.ExternalService, error) {
+	es, err := listExternalServices(ctx, h.ExternalServices, extsvc.KindBitbucketServer, rawID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Try to authenticate the request with the webhook secrets of
+	// the external services. If there are no secrets or no secret managed to
+	// authenticate the request, we return an error to the client.
+	for _, e := range es {
+		c, err := e.Configuration(ctx)
+		if err != nil {
+			return nil, err
+		}
+		con, ok := c.(*schema.BitbucketServerConnection)
+		if !ok {
+			continue
+		}
+
+		if secret := con.WebhookSecret(); secret != "" {
+			if err := gh.ValidateSignature(sig, body, []byte(secret)); err == nil {
+				return e, nil
+			}
+		}
+	}
+	return nil, errUnauthorized
+}
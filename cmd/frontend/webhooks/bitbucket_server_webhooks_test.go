@@ -0,0 +1,52 @@
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestBitbucketServerWebhook(t *testing.T) {
+	extSvc := newExternalService(t, 1, extsvc.KindBitbucketServer, schema.BitbucketServerConnection{
+		Url:    "https://bitbucket.sgdev.org",
+		Plugin: &schema.BitbucketServerPlugin{Webhooks: &schema.BitbucketServerPluginWebhooks{Secret: "secret"}},
+	})
+	h := &BitbucketServerWebhook{ExternalServices: mockExternalServices(t, extSvc)}
+	dispatched := recordDispatches(h, "repo:refs_changed")
+
+	payload := `{"repository":{"id":42},"changes":[{"refId":"refs/heads/main"}]}`
+	for _, tc := range []struct {
+		name   string
+		id     string
+		secret string
+		want   int
+	}{
+		{name: "valid signature", id: "1", secret: "secret", want: http.StatusOK},
+		{name: "valid signature without ID", secret: "secret", want: http.StatusOK},
+		{name: "wrong signature", id: "1", secret: "wrong", want: http.StatusUnauthorized},
+		{name: "unknown ID", id: "2", secret: "secret", want: http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			*dispatched = nil
+			req := httptest.NewRequest("POST", "/.api/bitbucket-server-webhooks?externalServiceID="+tc.id, strings.NewReader(payload))
+			req.Header.Set("X-Event-Key", "repo:refs_changed")
+			req.Header.Set("X-Hub-Signature", sign(t, []byte(payload), []byte(tc.secret)))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.want, rec.Code)
+			if tc.want == http.StatusOK {
+				assert.Equal(t, []*types.ExternalService{extSvc}, *dispatched)
+			} else {
+				assert.Empty(t, *dispatched)
+			}
+		})
+	}
+}
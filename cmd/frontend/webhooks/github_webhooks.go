@@ -5,11 +5,9 @@ import (
 	"io"
 	"net/http"
 	"strconv"
-	"sync"
 
 	gh "github.com/google/go-github/v43/github"
 	"github.com/inconshreveable/log15"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -34,8 +32,7 @@ type WebhookHandler func(ctx context.Context, extSvc *types.ExternalService, eve
 type GitHubWebhook struct {
 	ExternalServices database.ExternalServiceStore
 
-	mu       sync.RWMutex
-	handlers map[string][]WebhookHandler
+	router
 }
 
 func (h *GitHubWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -78,36 +75,6 @@ func (h *GitHubWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Dispatch accepts an event for a particular event type and dispatches it
-// to the appropriate stack of handlers, if any are configured.
-func (h *GitHubWebhook) Dispatch(ctx context.Context, eventType string, extSvc *types.ExternalService, e any) error {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
-	g := errgroup.Group{}
-	for _, handler := range h.handlers[eventType] {
-		// capture the handler variable within this loop
-		handler := handler
-		g.Go(func() error {
-			return handler(ctx, extSvc, e)
-		})
-	}
-	return g.Wait()
-}
-
-// Register associates a given event type(s) with the specified handler.
-// Handlers are organized into a stack and executed sequentially, so the order in
-// which they are provided is significant.
-func (h *GitHubWebhook) Register(handler WebhookHandler, eventTypes ...string) {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-	if h.handlers == nil {
-		h.handlers = make(map[string][]WebhookHandler)
-	}
-	for _, eventType := range eventTypes {
-		h.handlers[eventType] = append(h.handlers[eventType], handler)
-	}
-}
-
 func (h *GitHubWebhook) getExternalService(r *http.Request, body []byte) (*types.ExternalService, error) {
 	var (
 		sig   = r.Header.Get("X-Hub-Signature")
@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GitLabWebhook is responsible for handling incoming http requests for gitlab
+// webhooks and routing to any registered WebhookHandlers. Events are routed by
+// their object kind, passed in the object_kind field of the payload. Requests
+// for object kinds without registered handlers are passed on to Next.
+type GitLabWebhook struct {
+	ExternalServices database.ExternalServiceStore
+	Next             http.Handler
+
+	router
+}
+
+func (h *GitLabWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log15.Error("Error reading gitlab webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Payloads that aren't JSON have no object kind and are passed on to Next.
+	var common gitlabwebhooks.EventCommon
+	_ = json.Unmarshal(body, &common)
+
+	h.serve(w, r, webhookRequest{
+		codeHost:  extsvc.TypeGitLab,
+		eventType: common.ObjectKind,
+		body:      body,
+		next:      h.Next,
+		validate: func(ctx context.Context) (*types.ExternalService, error) {
+			return h.getExternalService(ctx, r.FormValue(extsvc.IDParam), r.Header.Get(gitlabwebhooks.TokenHeaderName))
+		},
+		parse: func() (any, error) {
+			return gitlabwebhooks.UnmarshalEvent(body)
+		},
+	})
+}
+
+// getExternalService returns the GitLab external service with the given raw
+// ID if one of its webhooks has the given secret.
+func (h *GitLabWebhook) getExternalService(ctx context.Context, rawID, secret string) (*types.ExternalService, error) {
+	// 🚨 SECURITY: GitLab webhooks always contain the ID of the external
+	// service and an empty secret never succeeds.
+	if rawID == "" || secret == "" {
+		return nil, errUnauthorized
+	}
+
+	es, err := listExternalServices(ctx, h.ExternalServices, extsvc.KindGitLab, rawID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range es {
+		c, err := e.Configuration(ctx)
+		if err != nil {
+			return nil, err
+		}
+		gc, ok := c.(*schema.GitLabConnection)
+		if !ok {
+			continue
+		}
+
+		for _, hook := range gc.Webhooks {
+			if hook.Secret == secret {
+				return e, nil
+			}
+		}
+	}
+	return nil, errUnauthorized
+}
+
+// listExternalServices lists the external services of the given kind. If rawID
+// is not empty, only the external service with that ID is returned.
+func listExternalServices(ctx context.Context, store database.ExternalServiceStore, kind, rawID string) ([]*types.ExternalService, error) {
+	args := database.ExternalServicesListOptions{Kinds: []string{kind}}
+	if rawID != "" {
+		id, err := strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid external service id")
+		}
+		args.IDs = []int64{id}
+	}
+	return store.List(ctx, args)
+}
@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGitLabWebhook(t *testing.T) {
+	extSvc := newExternalService(t, 1, extsvc.KindGitLab, schema.GitLabConnection{
+		Url:      "https://gitlab.com",
+		Webhooks: []*schema.GitLabWebhook{{Secret: "secret"}},
+	})
+	h := &GitLabWebhook{ExternalServices: mockExternalServices(t, extSvc)}
+	dispatched := recordDispatches(h, "push")
+
+	for _, tc := range []struct {
+		name  string
+		id    string
+		token string
+		want  int
+	}{
+		{name: "valid secret", id: "1", token: "secret", want: http.StatusOK},
+		{name: "wrong secret", id: "1", token: "wrong", want: http.StatusUnauthorized},
+		{name: "empty secret", id: "1", token: "", want: http.StatusUnauthorized},
+		{name: "missing ID", token: "secret", want: http.StatusUnauthorized},
+		{name: "unknown ID", id: "2", token: "secret", want: http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			*dispatched = nil
+			req := httptest.NewRequest("POST", "/.api/gitlab-webhooks?externalServiceID="+tc.id, strings.NewReader(`{"object_kind":"push","project":{"id":42}}`))
+			req.Header.Set("X-Gitlab-Token", tc.token)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.want, rec.Code)
+			if tc.want == http.StatusOK {
+				assert.Equal(t, []*types.ExternalService{extSvc}, *dispatched)
+			} else {
+				assert.Empty(t, *dispatched)
+			}
+		})
+	}
+}
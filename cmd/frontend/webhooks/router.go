@@ -0,0 +1,124 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/inconshreveable/log15"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// router holds the WebhookHandlers registered for the event types of a code
+// host. It is embedded by the webhook handlers of each code host.
+type router struct {
+	mu       sync.RWMutex
+	handlers map[string][]WebhookHandler
+}
+
+// Dispatch accepts an event for a particular event type and dispatches it
+// to the appropriate stack of handlers, if any are configured.
+func (h *router) Dispatch(ctx context.Context, eventType string, extSvc *types.ExternalService, e any) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	g := errgroup.Group{}
+	for _, handler := range h.handlers[eventType] {
+		// capture the handler variable within this loop
+		handler := handler
+		g.Go(func() error {
+			return handler(ctx, extSvc, e)
+		})
+	}
+	return g.Wait()
+}
+
+// Register associates a given event type(s) with the specified handler.
+// Handlers are organized into a stack and executed sequentially, so the order in
+// which they are provided is significant.
+func (h *router) Register(handler WebhookHandler, eventTypes ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.handlers == nil {
+		h.handlers = make(map[string][]WebhookHandler)
+	}
+	for _, eventType := range eventTypes {
+		h.handlers[eventType] = append(h.handlers[eventType], handler)
+	}
+}
+
+// handles returns true if any handlers are registered for eventType.
+func (h *router) handles(eventType string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.handlers[eventType]) > 0
+}
+
+// errUnauthorized is returned by the validation of a webhook request if no
+// external service is configured with a matching secret.
+var errUnauthorized = errors.New("no external service with a matching webhook secret")
+
+// webhookRequest is a webhook request of a code host other than GitHub.
+type webhookRequest struct {
+	codeHost  string
+	eventType string
+	body      []byte
+
+	// next handles requests for event types without registered handlers.
+	next http.Handler
+	// validate returns the external service that the request was sent by, or
+	// errUnauthorized if the request could not be authenticated.
+	validate func(ctx context.Context) (*types.ExternalService, error)
+	// parse parses the event in body.
+	parse func() (any, error)
+}
+
+// serve dispatches the event in a webhook request to the registered handlers
+// after authenticating it. Requests for event types without handlers are
+// passed on unchanged to req.next, so that handlers for other events, such as
+// the batch changes webhooks, keep working.
+func (h *router) serve(w http.ResponseWriter, r *http.Request, req webhookRequest) {
+	if !h.handles(req.eventType) {
+		if req.next == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(req.body))
+		req.next.ServeHTTP(w, r)
+		return
+	}
+
+	extSvc, err := req.validate(r.Context())
+	if err != nil {
+		log15.Error("Could not find valid external service for webhook", "codeHost", req.codeHost, "error", err)
+		if errors.Is(err, errUnauthorized) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		} else {
+			http.Error(w, "External service not found", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use
+	// an internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	e, err := req.parse()
+	if err != nil {
+		log15.Error("Error parsing webhook event", "codeHost", req.codeHost, "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(ctx, req.eventType, extSvc, e); err != nil {
+		log15.Error("Error handling webhook event", "codeHost", req.codeHost, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,87 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRouterPassesOnUnhandledEvents(t *testing.T) {
+	var nextBody []byte
+	h := &GitLabWebhook{
+		ExternalServices: database.NewMockExternalServiceStore(),
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nextBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusTeapot)
+		}),
+	}
+	h.Register(func(ctx context.Context, extSvc *types.ExternalService, event any) error {
+		t.Error("unexpected call of handler")
+		return nil
+	}, "push")
+
+	payload := []byte(`{"object_kind":"merge_request"}`)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/.api/gitlab-webhooks", bytes.NewReader(payload)))
+
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, payload, nextBody)
+}
+
+// mockExternalServices returns an ExternalServiceStore that lists the given
+// external services if their kind and ID match the list options.
+func mockExternalServices(t *testing.T, es ...*types.ExternalService) database.ExternalServiceStore {
+	t.Helper()
+
+	store := database.NewMockExternalServiceStore()
+	store.ListFunc.SetDefaultHook(func(ctx context.Context, opts database.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		var matching []*types.ExternalService
+		for _, e := range es {
+			if len(opts.Kinds) > 0 && opts.Kinds[0] != e.Kind {
+				continue
+			}
+			if len(opts.IDs) > 0 && opts.IDs[0] != e.ID {
+				continue
+			}
+			matching = append(matching, e)
+		}
+		return matching, nil
+	})
+	return store
+}
+
+func newExternalService(t *testing.T, id int64, kind string, config any) *types.ExternalService {
+	t.Helper()
+
+	raw, err := json.Marshal(config)
+	require.NoError(t, err)
+	return &types.ExternalService{
+		ID:     id,
+		Kind:   kind,
+		Config: extsvc.NewUnencryptedConfig(string(raw)),
+	}
+}
+
+// recordDispatches registers a handler for eventType on r and returns the
+// external services of the dispatched events.
+func recordDispatches(r interface {
+	Register(WebhookHandler, ...string)
+}, eventType string) *[]*types.ExternalService {
+	var dispatched []*types.ExternalService
+	r.Register(func(ctx context.Context, extSvc *types.ExternalService, event any) error {
+		dispatched = append(dispatched, extSvc)
+		return nil
+	}, eventType)
+	return &dispatched
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	livedependencies "github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/live"
@@ -121,6 +122,7 @@ func main() {
 		logger.Fatal("failed to initialize database stores", zap.Error(err))
 	}
 	db := database.NewDB(logger, sqlDB)
+	audit.SetStore(db.AuditLogs())
 
 	repoStore := db.Repos()
 	depsSvc := livedependencies.GetService(db)
@@ -512,8 +514,16 @@ func getVCSSyncer(
 		}
 		cli := crates.NewClient(urn, httpcli.ExternalDoer)
 		return server.NewRustPackagesSyncer(&c, depsSvc, cli), nil
+	case extsvc.TypeOther:
+		var c schema.OtherExternalServiceConnection
+		if _, err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+		if c.Vcs == "hg" {
+			return &server.HgRepoSyncer{}, nil
+		}
 	}
-	return &server.GitRepoSyncer{}, nil
+	return &server.GitRepoSyncer{PartialClone: server.PartialCloneEnabled(conf.Get(), r.Name)}, nil
 }
 
 func syncSiteLevelExternalServiceRateLimiters(ctx context.Context, store database.ExternalServiceStore) error {
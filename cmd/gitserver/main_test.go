@@ -182,3 +182,38 @@ func TestGetVCSSyncer(t *testing.T) {
 		t.Fatalf("Want *server.PerforceDepotSyncer, got %T", s)
 	}
 }
+
+func TestGetVCSSyncer_Other(t *testing.T) {
+	repo := api.RepoName("hg.example.org/foo")
+	depsSvc := new(dependencies.Service)
+
+	repoStore := database.NewMockRepoStore()
+	repoStore.GetByNameFunc.SetDefaultReturn(&types.Repo{
+		ExternalRepo: api.ExternalRepoSpec{
+			ServiceType: extsvc.TypeOther,
+		},
+		Sources: map[string]*types.SourceInfo{
+			"a": {
+				ID:       "abc",
+				CloneURL: "https://hg.example.org/foo",
+			},
+		},
+	}, nil)
+
+	for config, want := range map[string]server.VCSSyncer{
+		`{"url": "https://git.example.org", "repos": ["foo"]}`:             &server.GitRepoSyncer{},
+		`{"url": "https://hg.example.org", "repos": ["foo"], "vcs": "hg"}`: &server.HgRepoSyncer{},
+	} {
+		extsvcStore := database.NewMockExternalServiceStore()
+		extsvcStore.GetByIDFunc.SetDefaultReturn(&types.ExternalService{
+			ID:          1,
+			Kind:        extsvc.KindOther,
+			DisplayName: "test",
+			Config:      extsvc.NewUnencryptedConfig(config),
+		}, nil)
+
+		s, err := getVCSSyncer(context.Background(), extsvcStore, repoStore, depsSvc, repo)
+		require.NoError(t, err)
+		assert.IsType(t, want, s, config)
+	}
+}
@@ -201,6 +201,20 @@ func (s *Server) cleanupRepos(gitServerAddrs gitserver.GitServerAddresses) {
 	}
 
 	repoToSize := make(map[api.RepoName]int64)
+	var partialCloneRepoCount int64
+	var partialCloneRepoSize int64
+	defer func() {
+		// We want to set the gauge only at the end when we know the total
+		partialCloneReposTotal.Set(float64(partialCloneRepoCount))
+		partialCloneReposSizeTotalBytes.Set(float64(partialCloneRepoSize))
+	}()
+
+	defer func() {
+		// We want to set the gauge only at the end when we know the total
+		replicaReposTotal.Set(float64(stats.ReplicaRepos))
+		replicaReposStaleTotal.Set(float64(stats.StaleReplicaRepos))
+	}()
+
 	var wrongShardRepoCount int64
 	var wrongShardRepoSize int64
 	defer func() {
@@ -223,9 +237,25 @@ func (s *Server) cleanupRepos(gitServerAddrs gitserver.GitServerAddresses) {
 		name := s.name(dir)
 		repoToSize[name] = size
 
+		if isPartialClone(dir) {
+			partialCloneRepoCount++
+			partialCloneRepoSize += size
+		}
+
 		// Record the number and disk usage used of repos that should
 		// not belong on this instance and remove up to SRC_WRONG_SHARD_DELETE_LIMIT in a single Janitor run.
 		addr, err := s.addrForRepo(bCtx, name, gitServerAddrs)
+		if s.holdsReplica(name, addr, gitServerAddrs) {
+			// Replicas are kept, and their size is recorded by the shard
+			// the repo is assigned to.
+			delete(repoToSize, name)
+			stats.ReplicaRepos++
+			stats.ReplicaGitDirBytes += size
+			if s.replicaIsStale(bCtx, name, dir) {
+				stats.StaleReplicaRepos++
+			}
+			return false, nil
+		}
 		if !s.hostnameMatch(addr) {
 			wrongShardRepoCount++
 			wrongShardRepoSize += size
@@ -307,6 +337,11 @@ func (s *Server) cleanupRepos(gitServerAddrs gitserver.GitServerAddresses) {
 	}
 
 	scrubRemoteURL := func(dir GitDir) (done bool, err error) {
+		// Partial clones keep their promisor remote, whose URL is stored
+		// without credentials.
+		if isPartialClone(dir) {
+			return false, nil
+		}
 		cmd := exec.Command("git", "remote", "remove", "origin")
 		dir.Set(cmd)
 		// ignore error since we fail if the remote has already been scrubbed.
@@ -1163,6 +1198,9 @@ func sgMaintenance(logger log.Logger, dir GitDir) (err error) {
 
 	cmd := exec.Command("sh")
 	dir.Set(cmd)
+	if isPartialClone(dir) {
+		cmd.Env = append(os.Environ(), "SG_PARTIAL_CLONE=1")
+	}
 
 	cmd.Stdin = strings.NewReader(sgMaintenanceScript)
 
@@ -1255,13 +1293,17 @@ func pruneIfNeeded(dir GitDir, limit int) (err error) {
 
 func needsMaintenance(dir GitDir) (bool, string, error) {
 	// Bitmaps store reachability information about the set of objects in a
-	// packfile which speeds up clone and fetch operations.
-	hasBm, err := hasBitmap(dir)
-	if err != nil {
-		return false, "", err
-	}
-	if !hasBm {
-		return true, "bitmap", nil
+	// packfile which speeds up clone and fetch operations. Partial clones don't
+	// have bitmaps, because a bitmap requires all objects that are reachable
+	// from the commits in the packfile to be in the packfile.
+	if !isPartialClone(dir) {
+		hasBm, err := hasBitmap(dir)
+		if err != nil {
+			return false, "", err
+		}
+		if !hasBm {
+			return true, "bitmap", nil
+		}
 	}
 
 	// The commit-graph file is a supplemental data structure that accelerates
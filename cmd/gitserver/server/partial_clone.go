@@ -0,0 +1,600 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// partialCloneRemote is the promisor remote of partial clones, which missing
+// objects are fetched from. It is kept in the Git config of partial clones, so
+// that Git treats the objects that it does not have as promisor objects. Its
+// URL is stored without credentials, and fetches rewrite it to the URL with
+// credentials, see promisorRemoteEnv.
+//
+// Git would fetch each missing object that a command reads on its own, without
+// a limit on the number of objects. Instead, gitserver lists the objects that a
+// command reads, fetches the missing ones in a single batch if there are no
+// more than the "gitPartialClones" site configuration allows for a request, and
+// runs the command with lazy fetching disabled, see noLazyFetchEnv.
+const partialCloneRemote = "origin"
+
+// errObjectNotAvailable is the error of commands that read objects of a partial
+// clone that were not fetched for the request.
+var errObjectNotAvailable = errors.New("partial clone: object not available")
+
+var partialCloneFetchedObjects = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_gitserver_partial_clone_fetched_objects_total",
+	Help: "The number of missing objects fetched for requests to partial clones",
+})
+
+// PartialCloneEnabled returns true if the "gitPartialClones" site
+// configuration says to clone repo as a partial clone.
+func PartialCloneEnabled(c *conf.Unified, repo api.RepoName) bool {
+	if c.GitPartialClones == nil {
+		return false
+	}
+	for _, pattern := range c.GitPartialClones.Repos {
+		// Invalid patterns are rejected by the validation of the site
+		// configuration.
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(string(repo)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPartialClone returns true if the repository in dir is a partial clone. Git
+// marks the packfiles of objects fetched from a promisor remote with a
+// .promisor file, and keeps promisor objects in such packfiles when repacking.
+func isPartialClone(dir GitDir) bool {
+	promisorPacks, _ := filepath.Glob(dir.Path("objects", "pack", "*.promisor"))
+	return len(promisorPacks) > 0
+}
+
+// objectsToFetch returns the objects that a git command with the given
+// arguments reads, as revision expressions like "<commit>:<path>". It only
+// returns objects for the commands that read files at a single revision: show
+// and cat-file of "<commit>:<path>", and archive. The blobs of other commands
+// are listed by the command that blobsListArgs returns.
+func objectsToFetch(args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+
+	dashDash := -1
+	for i, arg := range args {
+		if arg == "--" {
+			dashDash = i
+			break
+		}
+	}
+
+	switch args[0] {
+	case "show", "cat-file":
+		// git show <commit>:<path>
+		var objects []string
+		for _, arg := range args[1:] {
+			if !strings.HasPrefix(arg, "-") && strings.Contains(arg, ":") {
+				objects = append(objects, arg)
+			}
+		}
+		return objects
+
+	case "archive":
+		// git archive [<options>] <tree-ish> -- [<path>...]
+		if dashDash <= 1 {
+			return nil
+		}
+		treeish := args[dashDash-1]
+		paths := args[dashDash+1:]
+		objects := make([]string, 0, len(paths))
+		for _, path := range paths {
+			if strings.ContainsAny(path, "*?[") {
+				// We can't resolve pathspec patterns, so we fetch the
+				// missing objects of the whole tree.
+				return []string{treeish + "^{tree}"}
+			}
+			objects = append(objects, treeish+":"+strings.TrimSuffix(path, "/"))
+		}
+		if len(objects) == 0 {
+			return []string{treeish + "^{tree}"}
+		}
+		return objects
+	}
+	return nil
+}
+
+// rawDiffListArgs are the arguments of log, show and diff that make them list
+// the blobs of their diffs without reading them. Rename detection reads the
+// added and deleted blobs, which are listed without it.
+var rawDiffListArgs = []string{"--raw", "--no-abbrev", "--no-renames", "--format="}
+
+// blobsListArgs returns the arguments of a git command that lists the blobs that
+// a git command with the given arguments reads, without reading them. The
+// command prints the blobs in the raw diff format or in the format of ls-tree,
+// which parseListedBlobs parses. It returns nil for commands that don't read
+// blobs, and for the commands of objectsToFetch.
+func blobsListArgs(args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+
+	switch args[0] {
+	case "log", "show", "diff":
+		if args[0] == "log" && !logReadsBlobs(args[1:]) {
+			return nil
+		}
+		if args[0] == "show" && len(objectsToFetch(args)) > 0 {
+			return nil
+		}
+		list := append([]string{args[0]}, rawDiffListArgs...)
+		return append(list, diffSelectionArgs(args[1:])...)
+
+	case "blame":
+		// git blame [<options>] [<rev>] -- <path>
+		//
+		// Blame reads the versions of the file in the history of rev, which
+		// are the blobs of the diffs of the file, including the diffs of
+		// merges against each of their parents.
+		var revs, paths []string
+		for i, arg := range args[1:] {
+			if arg == "--" {
+				paths = args[i+2:]
+				break
+			}
+			if !strings.HasPrefix(arg, "-") {
+				revs = append(revs, arg)
+			}
+		}
+		if paths == nil && len(revs) > 0 {
+			paths, revs = revs[len(revs)-1:], revs[:len(revs)-1]
+		}
+		if len(revs) == 0 {
+			revs = []string{"HEAD"}
+		}
+		list := append([]string{"log"}, rawDiffListArgs...)
+		list = append(list, "-m")
+		list = append(list, revs...)
+		list = append(list, "--")
+		return append(list, paths...)
+
+	case "ls-tree":
+		// ls-tree --long reads the size of blobs, the same command without
+		// --long lists them.
+		var list []string
+		long := false
+		for _, arg := range args {
+			switch arg {
+			case "--long", "-l":
+				long = true
+			case "-z", "--name-only", "--name-status":
+			default:
+				list = append(list, arg)
+			}
+		}
+		if !long {
+			return nil
+		}
+		return list
+	}
+	return nil
+}
+
+// logReadsBlobs returns true if git log with the given arguments reads blobs,
+// which it does to print diffs, to detect renames and to search diffs.
+func logReadsBlobs(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, _, _ := strings.Cut(arg, "=")
+		if blobReadingLogArgs[name] {
+			return true
+		}
+		if strings.HasPrefix(arg, "-S") || strings.HasPrefix(arg, "-G") || strings.HasPrefix(arg, "-M") {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// blobReadingLogArgs are the arguments that make git log read blobs, in
+	// addition to -S, -G and -M.
+	blobReadingLogArgs = map[string]bool{
+		"-p": true, "--patch": true, "-u": true, "--unified": true, "--function-context": true,
+		"--raw": true, "--stat": true, "--numstat": true, "--name-only": true, "--name-status": true,
+		"--follow": true, "--find-renames": true, "--find-copies": true,
+	}
+
+	// diffOutputArgs are the arguments of log, show and diff that change their
+	// output or how they compute diffs, which blobsListArgs replaces with
+	// rawDiffListArgs.
+	diffOutputArgs = map[string]bool{
+		"-p": true, "--patch": true, "-u": true, "--unified": true, "--no-patch": true, "-s": true,
+		"--raw": true, "--stat": true, "--numstat": true, "--name-only": true, "--name-status": true,
+		"--function-context": true, "--no-prefix": true, "--full-index": true, "--no-abbrev": true, "-z": true,
+		"--format": true, "--pretty": true, "--follow": true, "--find-renames": true, "--find-copies": true, "--no-renames": true,
+		"--pickaxe-all": true, "--pickaxe-regex": true,
+	}
+
+	// diffOutputArgsWithValue are the arguments of diffOutputArgs that are
+	// followed by a value, unless the value is given with "=".
+	diffOutputArgsWithValue = map[string]bool{
+		"--inter-hunk-context": true, "--src-prefix": true, "--dst-prefix": true,
+	}
+
+	// countArgsWithValue are the arguments that limit the number of commits
+	// that are followed by a value, unless the value is given with "=".
+	countArgsWithValue = map[string]bool{"-n": true, "--max-count": true, "--skip": true}
+)
+
+// diffSelectionArgs returns the arguments of log, show or diff without the
+// arguments that change their output or how they compute diffs, so that only
+// the arguments that select commits and paths are left. Searching diffs with -S
+// and -G reads the diffs of commits that don't match, so the number of commits
+// is not limited then.
+func diffSelectionArgs(args []string) []string {
+	pickaxe := false
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "-S") || strings.HasPrefix(arg, "-G") {
+			pickaxe = true
+		}
+	}
+
+	var selection []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			selection = append(selection, args[i:]...)
+			break
+		}
+
+		name, _, hasValue := strings.Cut(arg, "=")
+		switch {
+		case diffOutputArgs[name]:
+		case diffOutputArgsWithValue[name], pickaxe && countArgsWithValue[name]:
+			if !hasValue {
+				i++
+			}
+		case arg == "-S", arg == "-G":
+			i++
+		case strings.HasPrefix(arg, "-S"), strings.HasPrefix(arg, "-G"), strings.HasPrefix(arg, "-M"):
+		case pickaxe && isCountArg(arg):
+		default:
+			selection = append(selection, arg)
+		}
+	}
+	return selection
+}
+
+// isCountArg returns true for arguments like -20 and -n20, which limit the
+// number of commits.
+func isCountArg(arg string) bool {
+	digits := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "n")
+	if digits == "" || !strings.HasPrefix(arg, "-") {
+		return false
+	}
+	_, err := strconv.Atoi(digits)
+	return err == nil
+}
+
+// parseListedBlobs returns the IDs of the blobs in the output of the command of
+// blobsListArgs, which are on lines like
+//
+//	:100644 100644 <old blob> <new blob> M	<path>
+//	100644 blob <blob>	<path>
+//
+// Merges have a colon and a blob for each parent.
+func parseListedBlobs(out []byte) []string {
+	var blobs []string
+	for _, line := range strings.Split(string(out), "\n") {
+		line, _, _ = strings.Cut(line, "\t")
+		fields := strings.Fields(line)
+		if parents := len(line) - len(strings.TrimLeft(line, ":")); parents > 0 {
+			// One mode and one blob for each parent and the result.
+			if len(fields) < 2*(parents+1) {
+				continue
+			}
+			blobs = append(blobs, fields[parents+1:2*(parents+1)]...)
+		} else if len(fields) == 3 && fields[1] == "blob" {
+			blobs = append(blobs, fields[2])
+		}
+	}
+
+	// Added and deleted files have a null blob on the other side.
+	nonNull := blobs[:0]
+	for _, blob := range blobs {
+		if strings.Trim(blob, "0") != "" {
+			nonNull = append(nonNull, blob)
+		}
+	}
+	return nonNull
+}
+
+// listMissingBlobs runs the git command with the given arguments in the
+// partial clone in dir, and returns the blobs in its output that the partial
+// clone does not have. If the command fails, it returns no blobs, and the git
+// command that reads them reports the error.
+func listMissingBlobs(ctx context.Context, dir GitDir, listArgs []string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", listArgs...)
+	cmd.Env = noLazyFetchEnv()
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, nil
+	}
+
+	blobs := parseListedBlobs(out)
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+
+	// With --ignore-missing, rev-list only prints the blobs that it has. It
+	// does not fetch missing objects with --missing.
+	cmd = exec.CommandContext(ctx, "git", "rev-list", "--objects", "--missing=print", "--ignore-missing", "--no-object-names", "--stdin")
+	cmd.Stdin = strings.NewReader(strings.Join(blobs, "\n") + "\n")
+	cmd.Env = noLazyFetchEnv()
+	dir.Set(cmd)
+	out, err = cmd.Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, errors.Wrap(err, "rev-list")
+	}
+	present := map[string]struct{}{}
+	for _, oid := range strings.Fields(string(out)) {
+		present[oid] = struct{}{}
+	}
+
+	var missing []string
+	for _, blob := range blobs {
+		if _, ok := present[blob]; !ok {
+			present[blob] = struct{}{}
+			missing = append(missing, blob)
+		}
+	}
+	return missing, nil
+}
+
+// missingObjects returns the IDs of the objects of the given trees and blobs
+// that are missing in the partial clone in dir. Objects that don't resolve are
+// ignored, the git command that reads them reports the error.
+func missingObjects(ctx context.Context, dir GitDir, objects []string) ([]string, error) {
+	var missing []string
+	seen := map[string]struct{}{}
+	add := func(oid string) {
+		if _, ok := seen[oid]; !ok {
+			seen[oid] = struct{}{}
+			missing = append(missing, oid)
+		}
+	}
+
+	for _, object := range objects {
+		// With --missing=print, rev-list prints the objects that it reaches
+		// but does not have with a "?" prefix instead of fetching them.
+		cmd := exec.CommandContext(ctx, "git", "rev-list", "--objects", "--missing=print", "--no-object-names", object, "--")
+		dir.Set(cmd)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// rev-list does not accept missing objects as arguments, which
+			// happens if the object is a missing blob.
+			if !strings.Contains(stderr.String(), "bad object") {
+				continue
+			}
+			cmd = exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--end-of-options", object)
+			dir.Set(cmd)
+			oid, err := cmd.Output()
+			if err != nil {
+				continue
+			}
+			add(strings.TrimSpace(string(oid)))
+			continue
+		}
+
+		for _, line := range strings.Split(string(out), "\n") {
+			if oid := strings.TrimPrefix(line, "?"); oid != line {
+				add(oid)
+			}
+		}
+	}
+	return missing, nil
+}
+
+// promisorRemoteURL returns the URL of the promisor remote that is stored in
+// the Git config of partial clones, which is remoteURL without credentials.
+// SSH URLs are kept as they are, since their user name is not a secret.
+func promisorRemoteURL(remoteURL *vcs.URL) string {
+	u := *remoteURL
+	if !u.IsSSH() {
+		u.User = nil
+	}
+	return u.String()
+}
+
+// configurePromisorRemote configures the promisor remote of the partial clone
+// in dir, so that Git fetches missing objects from remoteURL. Git only reads
+// extensions of repositories with format version 1.
+func configurePromisorRemote(dir GitDir, remoteURL *vcs.URL) error {
+	for _, kv := range [][2]string{
+		{"core.repositoryformatversion", "1"},
+		{"extensions.partialClone", partialCloneRemote},
+		{"remote." + partialCloneRemote + ".url", promisorRemoteURL(remoteURL)},
+		{"remote." + partialCloneRemote + ".promisor", "true"},
+		{"remote." + partialCloneRemote + ".partialclonefilter", "blob:none"},
+	} {
+		if err := gitConfigSet(dir, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// promisorRemoteEnv returns the environment of fetches in partial clones,
+// which rewrites the URL of the promisor remote in the Git config to remoteURL,
+// so that they use the credentials in remoteURL. We pass the Git config in the
+// environment instead of with "-c", because the URL in the name of the
+// "url.<base>.insteadOf" setting can contain "=".
+func promisorRemoteEnv(remoteURL *vcs.URL) []string {
+	env := append(os.Environ(), remoteGitCommandEnv(tlsExternal())...)
+	return append(env,
+		"GIT_CONFIG_COUNT=2",
+		"GIT_CONFIG_KEY_0=url."+remoteURL.String()+".insteadOf",
+		"GIT_CONFIG_VALUE_0="+promisorRemoteURL(remoteURL),
+		// Unset credential helper because the command is non-interactive.
+		"GIT_CONFIG_KEY_1=credential.helper",
+		"GIT_CONFIG_VALUE_1=",
+	)
+}
+
+// noLazyFetchEnv returns the environment of git commands that read objects of
+// partial clones. It allows no transport protocols, so that Git fails instead
+// of fetching the missing objects that the command reads on its own.
+func noLazyFetchEnv() []string {
+	return append(os.Environ(), "GIT_ALLOW_PROTOCOL=")
+}
+
+// isObjectNotAvailable returns true if the stderr of a git command shows that
+// it failed because lazy fetching of a missing object is disabled.
+func isObjectNotAvailable(stderr string) bool {
+	return strings.Contains(stderr, "from promisor remote")
+}
+
+// fetchObjects fetches the objects with the given IDs from the promisor remote
+// of the partial clone in dir, like Git does when it fetches missing objects.
+func fetchObjects(ctx context.Context, dir GitDir, remoteURL *vcs.URL, oids []string) error {
+	cmd := exec.CommandContext(ctx, "git",
+		"fetch",
+		"--no-auto-gc",
+		"--no-tags",
+		"--no-write-fetch-head",
+		"--recurse-submodules=no",
+		"--filter=blob:none",
+		"--stdin",
+		partialCloneRemote,
+	)
+	cmd.Stdin = strings.NewReader(strings.Join(oids, "\n") + "\n")
+	cmd.Env = promisorRemoteEnv(remoteURL)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, true, nil); err != nil {
+		return errors.Wrapf(err, "failed to fetch missing objects with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// objectFetcher fetches the missing objects of a partial clone for a single
+// request. Fetching them in a single batch is faster than letting Git fetch them
+// one at a time. A request fails if more objects are missing than the
+// "gitPartialClones" site configuration allows to fetch for it.
+type objectFetcher struct {
+	dir       GitDir
+	remoteURL *vcs.URL
+
+	mu        sync.Mutex
+	remaining int
+}
+
+func newObjectFetcher(dir GitDir, remoteURL *vcs.URL) *objectFetcher {
+	return &objectFetcher{
+		dir:       dir,
+		remoteURL: remoteURL,
+		remaining: conf.GitPartialCloneMaxFetchedObjects(),
+	}
+}
+
+// fetchMissingObjects fetches the objects that a git command with the given
+// arguments reads, if the partial clone does not have them.
+func (f *objectFetcher) fetchMissingObjects(ctx context.Context, args []string) error {
+	var missing []string
+	if objects := objectsToFetch(args); len(objects) > 0 {
+		objects, err := missingObjects(ctx, f.dir, objects)
+		if err != nil {
+			return errors.Wrap(err, "find missing objects")
+		}
+		missing = append(missing, objects...)
+	}
+	if listArgs := blobsListArgs(args); listArgs != nil {
+		blobs, err := listMissingBlobs(ctx, f.dir, listArgs)
+		if err != nil {
+			return errors.Wrap(err, "find missing objects")
+		}
+		missing = append(missing, blobs...)
+	}
+	return f.fetch(ctx, missing)
+}
+
+// fetchDiffObjects fetches the blobs of the diff of the given commit, as the
+// diff fetcher of commit search prints it, if the partial clone does not have
+// them. It is safe to call concurrently.
+func (f *objectFetcher) fetchDiffObjects(ctx context.Context, commit []byte) error {
+	missing, err := listMissingBlobs(ctx, f.dir, []string{"diff-tree", "--root", "-r", "--raw", "--no-abbrev", "--no-commit-id", string(commit)})
+	if err != nil {
+		return errors.Wrap(err, "find missing objects")
+	}
+	return f.fetch(ctx, missing)
+}
+
+func (f *objectFetcher) fetch(ctx context.Context, missing []string) error {
+	if len(missing) == 0 {
+		return nil
+	}
+
+	f.mu.Lock()
+	if len(missing) > f.remaining {
+		f.mu.Unlock()
+		return errors.Wrapf(errObjectNotAvailable, "%d objects are missing, more than the %d objects that are fetched for a single request", len(missing), conf.GitPartialCloneMaxFetchedObjects())
+	}
+	f.remaining -= len(missing)
+	f.mu.Unlock()
+
+	if err := fetchObjects(ctx, f.dir, f.remoteURL, missing); err != nil {
+		return err
+	}
+	partialCloneFetchedObjects.Add(float64(len(missing)))
+	return nil
+}
+
+// fetchHEADObjects fetches the missing objects of the tree at HEAD of the
+// partial clone in dir. Most requests read files at HEAD, so we fetch them
+// after each clone and fetch instead of on demand.
+func fetchHEADObjects(ctx context.Context, dir GitDir, remoteURL *vcs.URL) error {
+	missing, err := missingObjects(ctx, dir, []string{"HEAD^{tree}"})
+	if err != nil {
+		return errors.Wrap(err, "find missing objects")
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fetchObjects(ctx, dir, remoteURL, missing)
+}
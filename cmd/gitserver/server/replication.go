@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// A replica that was last fetched more than replicaMaxLag before the repository
+// was last fetched by the gitserver it is assigned to is reported as stale.
+var replicaMaxLag = env.MustGetDuration("SRC_GIT_REPLICA_MAX_LAG", time.Hour, "the duration after which a replica that was not fetched when the repository was fetched by the gitserver it is assigned to is reported as stale")
+
+// holdsReplica returns true if this gitserver holds a replica of repo, which is
+// assigned to the gitserver at primary.
+func (s *Server) holdsReplica(repo api.RepoName, primary string, gitServerAddrs gitserver.GitServerAddresses) bool {
+	if s.hostnameMatch(primary) {
+		return false
+	}
+	for _, addr := range gitserver.ReplicaAddrsForRepo(repo, primary, gitServerAddrs) {
+		if s.hostnameMatch(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReplica returns true if this gitserver holds a replica of repo instead of
+// being the gitserver that repo is assigned to. The state of repositories in
+// the database is owned by the gitserver they are assigned to, so replicas do
+// not update it.
+func (s *Server) isReplica(ctx context.Context, repo api.RepoName) bool {
+	gitServerAddrs := currentGitserverAddresses()
+	if !gitserver.IsReplicated(repo, gitServerAddrs.Replication) {
+		return false
+	}
+	primary, err := s.addrForRepo(ctx, repo, gitServerAddrs)
+	if err != nil {
+		return false
+	}
+	return s.holdsReplica(repo, primary, gitServerAddrs)
+}
+
+// replicaIsStale returns true if the replica of repo in dir was last fetched
+// more than replicaMaxLag before the gitserver repo is assigned to last fetched
+// it.
+func (s *Server) replicaIsStale(ctx context.Context, repo api.RepoName, dir GitDir) bool {
+	gr, err := s.DB.GitserverRepos().GetByName(ctx, repo)
+	if err != nil || gr == nil || gr.LastFetched.IsZero() {
+		return false
+	}
+	lastFetched, err := repoLastFetched(dir)
+	if err != nil {
+		return true
+	}
+	return lastFetched.Add(replicaMaxLag).Before(gr.LastFetched)
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestHoldsReplica(t *testing.T) {
+	addrs := gitserver.GitServerAddresses{
+		Addresses: []string{"gitserver-0:3178", "gitserver-1:3178", "gitserver-2:3178"},
+		Replication: &schema.GitServerReplication{
+			Repos:             []string{"^github\\.com/foo/"},
+			ReplicationFactor: 1,
+		},
+	}
+	repo := api.RepoName("github.com/foo/bar")
+	primary := "gitserver-0:3178"
+	replica := gitserver.ReplicaAddrsForRepo(repo, primary, addrs)[0]
+
+	for _, addr := range addrs.Addresses {
+		s := &Server{Hostname: addr[:len("gitserver-0")]}
+		assert.Equal(t, addr == replica, s.holdsReplica(repo, primary, addrs), addr)
+		assert.False(t, s.holdsReplica("github.com/bar/baz", primary, addrs), addr)
+	}
+}
@@ -562,6 +562,7 @@ func currentGitserverAddresses() gitserver.GitServerAddresses {
 	}
 	if cfg.ExperimentalFeatures != nil {
 		gitServerAddrs.PinnedServers = cfg.ExperimentalFeatures.GitServerPinnedRepos
+		gitServerAddrs.Replication = cfg.ExperimentalFeatures.GitServerReplication
 	}
 
 	return gitServerAddrs
@@ -672,6 +673,22 @@ var (
 		Name: "src_gitserver_repo_wrong_shard_bytes",
 		Help: "Size (in bytes) of repos that are on disk on the wrong shard",
 	})
+	partialCloneReposTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_repo_partial_clone",
+		Help: "The number of repos on disk that are partial clones",
+	})
+	partialCloneReposSizeTotalBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_repo_partial_clone_bytes",
+		Help: "Size (in bytes) of repos on disk that are partial clones",
+	})
+	replicaReposTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_repo_replica",
+		Help: "The number of repos on disk that are replicas of repos assigned to other shards",
+	})
+	replicaReposStaleTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_repo_replica_stale",
+		Help: "The number of replicas on disk that were not fetched when the shard the repo is assigned to fetched it",
+	})
 	wrongShardReposDeletedCounter = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "src_gitserver_repo_wrong_shard_deleted",
 		Help: "The number of repos on the wrong shard that we deleted",
@@ -1234,6 +1251,24 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 		}
 	}
 
+	// Diff search reads files that a partial clone may not have, which are
+	// fetched for the diff of each commit, up to the limit of objects for a
+	// request. Rename detection of the modified files would read the files of
+	// renames, so it is disabled.
+	var env []string
+	var fetchDiffObjects func([]byte) error
+	if isPartialClone(dir) {
+		remoteURL, err := s.getRemoteURL(ctx, args.Repo)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to determine Git remote URL")
+		}
+		env = append(noLazyFetchEnv(), "GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=diff.renames", "GIT_CONFIG_VALUE_0=false")
+		f := newObjectFetcher(dir, remoteURL)
+		fetchDiffObjects = func(commit []byte) error {
+			return f.fetchDiffObjects(ctx, commit)
+		}
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -1257,6 +1292,8 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 			Query:                mt,
 			IncludeDiff:          args.IncludeDiff,
 			IncludeModifiedFiles: args.IncludeModifiedFiles,
+			Env:                  env,
+			FetchDiffObjects:     fetchDiffObjects,
 		}
 
 		return searcher.Search(ctx, func(match *protocol.CommitMatch) {
@@ -1704,12 +1741,36 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 		}
 	}
 
+	// Fetch the objects that the command reads and a partial clone does not
+	// have from the code host, up to the limit of objects for a request.
+	partialClone := isPartialClone(dir)
+	if partialClone {
+		remoteURL, err := s.getRemoteURL(ctx, req.Repo)
+		if err != nil {
+			err = errors.Wrap(err, "failed to determine Git remote URL")
+		} else {
+			err = newObjectFetcher(dir, remoteURL).fetchMissingObjects(ctx, req.Args)
+		}
+		if err != nil {
+			execErr = err
+			status = strconv.Itoa(exitStatus)
+			logger.Warn("failed to fetch missing objects of partial clone", log.Error(err))
+			w.Header().Set("X-Exec-Error", errorString(execErr))
+			w.Header().Set("X-Exec-Exit-Status", status)
+			w.Header().Set("X-Exec-Stderr", errorString(execErr))
+			return
+		}
+	}
+
 	var stderrBuf bytes.Buffer
 	stdoutW := &writeCounter{w: w}
 	stderrW := &writeCounter{w: &limitWriter{W: &stderrBuf, N: 1024}}
 
 	cmdStart = time.Now()
 	cmd := exec.CommandContext(ctx, "git", req.Args...)
+	if partialClone {
+		cmd.Env = noLazyFetchEnv()
+	}
 	dir.Set(cmd)
 	cmd.Stdout = stdoutW
 	cmd.Stderr = stderrW
@@ -1722,6 +1783,10 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 
 	stderr := stderrBuf.String()
 	checkMaybeCorruptRepo(s.Logger, req.Repo, dir, stderr)
+	if partialClone && execErr != nil && isObjectNotAvailable(stderr) {
+		// The command read an object that we did not know to fetch for it.
+		execErr = errObjectNotAvailable
+	}
 
 	// write trailer
 	w.Header().Set("X-Exec-Error", errorString(execErr))
@@ -1900,6 +1965,10 @@ func (s *Server) p4exec(w http.ResponseWriter, r *http.Request, req *protocol.P4
 }
 
 func (s *Server) setLastFetched(ctx context.Context, name api.RepoName) error {
+	if s.isReplica(ctx, name) {
+		return nil
+	}
+
 	dir := s.dir(name)
 
 	lastFetched, err := repoLastFetched(dir)
@@ -1921,6 +1990,10 @@ func (s *Server) setLastFetched(ctx context.Context, name api.RepoName) error {
 
 // setLastErrorNonFatal will set the last_error column for the repo in the gitserver table.
 func (s *Server) setLastErrorNonFatal(ctx context.Context, name api.RepoName, err error) {
+	if s.isReplica(ctx, name) {
+		return
+	}
+
 	var errString string
 	if err != nil {
 		errString = err.Error()
@@ -1932,6 +2005,9 @@ func (s *Server) setLastErrorNonFatal(ctx context.Context, name api.RepoName, er
 }
 
 func (s *Server) setCloneStatus(ctx context.Context, name api.RepoName, status types.CloneStatus) (err error) {
+	if s.isReplica(ctx, name) {
+		return nil
+	}
 	return s.DB.GitserverRepos().SetCloneStatus(ctx, name, status, s.Hostname)
 }
 
@@ -1944,6 +2020,9 @@ func (s *Server) setCloneStatusNonFatal(ctx context.Context, name api.RepoName,
 
 // setRepoSize calculates the size of the repo and stores it in the database.
 func (s *Server) setRepoSize(ctx context.Context, name api.RepoName) error {
+	if s.isReplica(ctx, name) {
+		return nil
+	}
 	return s.DB.GitserverRepos().SetRepoSize(ctx, name, dirSize(s.dir(name).Path(".")), s.Hostname)
 }
 
@@ -2176,6 +2255,12 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 		return errors.Wrap(err, "failed to ensure HEAD exists")
 	}
 
+	if isPartialClone(tmp) {
+		if err := fetchHEADObjects(ctx, tmp, remoteURL); err != nil {
+			return errors.Wrap(err, "failed to fetch objects at HEAD of partial clone")
+		}
+	}
+
 	if err := setRepositoryType(tmp, syncer.Type()); err != nil {
 		return errors.Wrap(err, `git config set "sourcegraph.type"`)
 	}
@@ -2535,6 +2620,12 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 		return errors.Wrap(err, "failed to ensure HEAD exists")
 	}
 
+	if isPartialClone(dir) {
+		if err := fetchHEADObjects(ctx, dir, remoteURL); err != nil {
+			return errors.Wrap(err, "failed to fetch objects at HEAD of partial clone")
+		}
+	}
+
 	if err := setRepositoryType(dir, syncer.Type()); err != nil {
 		return errors.Wrap(err, `git config set "sourcegraph.type"`)
 	}
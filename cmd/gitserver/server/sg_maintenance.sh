@@ -52,7 +52,16 @@ git reflog expire --all
 # instances. Restricting the memory consumption by setting pack.windowMemory,
 # pack.deltaCacheSize and pack.threads in addition to --geometric=2 seemed to
 # have no effect.
-git repack -d -l -A --write-bitmap-index --window-memory 100m --unpack-unreachable=now
+#
+# Partial clones can't have bitmaps, because a bitmap requires all objects that
+# are reachable from the commits in the packfile to be in the packfile. Git
+# keeps the objects of a partial clone in a separate packfile marked with a
+# .promisor file when repacking.
+if [ -n "$SG_PARTIAL_CLONE" ]; then
+  git repack -d -l -A --window-memory 100m --unpack-unreachable=now
+else
+  git repack -d -l -A --write-bitmap-index --window-memory 100m --unpack-unreachable=now
+fi
 
 # With the --changed-paths option, compute and write information about the
 # paths changed between a commit and its first parent. This operation can take
@@ -11,7 +11,11 @@ import (
 )
 
 // GitRepoSyncer is a syncer for Git repositories.
-type GitRepoSyncer struct{}
+type GitRepoSyncer struct {
+	// PartialClone makes CloneCommand clone the repository as a partial clone
+	// without blobs. Fetch keeps the filter of partial clones.
+	PartialClone bool
+}
 
 func (s *GitRepoSyncer) Type() string {
 	return "git"
@@ -56,14 +60,29 @@ func (s *GitRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tm
 		return nil, errors.Wrapf(err, "clone setup failed")
 	}
 
-	cmd, _ = s.fetchCommand(ctx, remoteURL)
+	if s.PartialClone {
+		if err := configurePromisorRemote(GitDir(tmpPath), remoteURL); err != nil {
+			return nil, errors.Wrapf(err, "clone setup failed")
+		}
+	}
+
+	cmd, _ = s.fetchCommand(ctx, remoteURL, s.PartialClone)
 	cmd.Dir = tmpPath
 	return cmd, nil
 }
 
 // Fetch tries to fetch updates of a Git repository.
 func (s *GitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, revspec string) error {
-	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
+	partialClone := isPartialClone(dir)
+	if partialClone {
+		// The URL of the remote can change, and partial clones from before
+		// the promisor remote was kept in the Git config don't have it.
+		if err := configurePromisorRemote(dir, remoteURL); err != nil {
+			return errors.Wrap(err, "failed to configure promisor remote")
+		}
+	}
+
+	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL, partialClone)
 	dir.Set(cmd)
 	if output, err := runWith(ctx, cmd, configRemoteOpts, nil); err != nil {
 		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
@@ -76,7 +95,7 @@ func (s *GitRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.UR
 	return exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String()), nil
 }
 
-func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, configRemoteOpts bool) {
+func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL, partialClone bool) (cmd *exec.Cmd, configRemoteOpts bool) {
 	configRemoteOpts = true
 	if customCmd := customFetchCmd(ctx, remoteURL); customCmd != nil {
 		cmd = customCmd
@@ -84,11 +103,19 @@ func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (c
 	} else if useRefspecOverrides() {
 		cmd = refspecOverridesFetchCmd(ctx, remoteURL)
 	} else {
-		cmd = exec.CommandContext(ctx, "git", "fetch",
+		args := []string{"fetch",
 			// We already have janitor jobs that run git gc. We disable git gc here to avoid
 			// a possible corruption of repositories by competing gc processes.
 			"--no-auto-gc",
-			"--progress", "--prune", remoteURL.String(),
+			"--progress", "--prune"}
+		remote := remoteURL.String()
+		if partialClone {
+			// Git only fetches with a filter from the promisor remote of a
+			// partial clone, so we fetch from the remote by name.
+			remote = partialCloneRemote
+			args = append(args, "--filter=blob:none")
+		}
+		args = append(args, remote,
 			// Normal git refs
 			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
 			// GitHub pull requests
@@ -101,6 +128,10 @@ func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (c
 			"+refs/changes/*:refs/changes/*",
 			// Possibly deprecated refs for sourcegraph zap experiment?
 			"+refs/sourcegraph/*:refs/sourcegraph/*")
+		cmd = exec.CommandContext(ctx, "git", args...)
+		if partialClone {
+			cmd.Env = promisorRemoteEnv(remoteURL)
+		}
 	}
 	return cmd, configRemoteOpts
 }
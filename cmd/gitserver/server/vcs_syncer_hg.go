@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// hgRemote is the name of the remote that Mercurial repositories are fetched
+// from. git-remote-hg keeps the state of a conversion, like the marks files
+// that map Mercurial changesets to Git commits, under $GIT_DIR/hg/<remote>.
+// Fetching from a named remote instead of a URL makes git-remote-hg reuse that
+// state, so that later fetches only convert new changesets and the commits of
+// changesets that were already converted do not change.
+const hgRemote = "origin"
+
+// HgRepoSyncer is a syncer for Mercurial repositories. Repositories are
+// converted to Git repositories with the git-remote-hg remote helper: the
+// default branch is converted to "master", bookmarks to branches of the same
+// name, named branches to "branches/<name>" and tags to tags.
+type HgRepoSyncer struct{}
+
+func (s *HgRepoSyncer) Type() string {
+	return "hg"
+}
+
+// IsCloneable checks to see if the Mercurial remote URL is cloneable.
+func (s *HgRepoSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	ctx, cancel := context.WithTimeout(ctx, shortGitCommandTimeout([]string{"ls-remote"}))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "hg", "identify", "--noninteractive", "--rev", "tip", remoteURL.String())
+	out, err := runWith(ctx, cmd, false, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Errorf("%s (output follows)\n\n%s", err, newURLRedactor(remoteURL).redact(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand returns the command to be executed for cloning a Mercurial
+// repository as a Git repository.
+func (s *HgRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (cmd *exec.Cmd, err error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "clone setup failed")
+	}
+
+	cmd = s.fetchCommand(ctx, remoteURL)
+	cmd.Dir = tmpPath
+	return cmd, nil
+}
+
+// Fetch tries to fetch updates of a Mercurial repository as a Git repository.
+func (s *HgRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, _ string) error {
+	cmd := s.fetchCommand(ctx, remoteURL)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, true, nil); err != nil {
+		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing the remote
+// of a Mercurial repository.
+func (s *HgRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	// git-remote-hg does not report the HEAD of a remote. The default branch
+	// of a Mercurial repository is always converted to "master".
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+func (s *HgRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) *exec.Cmd {
+	// The remote is only configured for this command, so that the URL, which
+	// may contain credentials, is not stored in the Git config.
+	return exec.CommandContext(ctx, "git",
+		"-c", "remote."+hgRemote+".url=hg::"+remoteURL.String(),
+		"fetch",
+		// We already have janitor jobs that run git gc. We disable git gc here to avoid
+		// a possible corruption of repositories by competing gc processes.
+		"--no-auto-gc",
+		"--progress", "--prune", hgRemote,
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
+}
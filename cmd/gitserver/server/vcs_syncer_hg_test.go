@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+// hgChangeset describes a changeset of the Mercurial repositories that the
+// tests build.
+type hgChangeset struct {
+	// branch is the named branch of the changeset. The default branch is used
+	// if it is empty.
+	branch string
+	// bookmark is set on the changeset if it is not empty.
+	bookmark string
+
+	file    string
+	content string
+	message string
+}
+
+var hgFixture = []hgChangeset{
+	{file: "README", content: "hello\n", message: "add README"},
+	{file: "main.go", content: "package main\n", message: "add main.go"},
+	{branch: "stable", file: "README", content: "hello stable\n", message: "stable README"},
+	{file: "main.go", content: "package main\n\nfunc main() {}\n", message: "add main", bookmark: "feature"},
+}
+
+var hgFixtureUpdate = []hgChangeset{
+	{file: "main.go", content: "package main\n\nfunc main() { println() }\n", message: "print"},
+	{branch: "stable", file: "CHANGES", content: "v1.1\n", message: "add CHANGES"},
+}
+
+func TestHgRepoSyncer(t *testing.T) {
+	for _, bin := range []string{"hg", "git-remote-hg"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not found in PATH", bin)
+		}
+	}
+
+	ctx := context.Background()
+	remote := t.TempDir()
+	runHgCmd(t, remote, "init")
+	addHgChangesets(t, remote, hgFixture)
+
+	remoteURL, err := vcs.ParseURL(remote)
+	require.NoError(t, err)
+
+	s := &HgRepoSyncer{}
+	require.NoError(t, s.IsCloneable(ctx, remoteURL))
+
+	tmp := filepath.Join(t.TempDir(), ".git")
+	cmd, err := s.CloneCommand(ctx, remoteURL, tmp)
+	require.NoError(t, err)
+	output, err := runWith(ctx, cmd, true, nil)
+	require.NoError(t, err, string(output))
+
+	dir := GitDir(tmp)
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		dir.Set(cmd)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %s: %s", strings.Join(args, " "), out)
+		return strings.TrimSpace(string(out))
+	}
+
+	assert.Equal(t, "add main\nadd main.go\nadd README", git("log", "--format=%s", "master"))
+	assert.Equal(t, "stable README\nadd main.go\nadd README", git("log", "--format=%s", "branches/stable"))
+	assert.Equal(t, git("rev-parse", "master"), git("rev-parse", "feature"))
+
+	before := map[string]string{}
+	for _, ref := range []string{"master", "branches/stable", "feature"} {
+		before[ref] = git("rev-parse", ref)
+	}
+
+	addHgChangesets(t, remote, hgFixtureUpdate)
+	require.NoError(t, s.Fetch(ctx, remoteURL, dir, ""))
+
+	assert.Equal(t, "print\nadd main\nadd main.go\nadd README", git("log", "--format=%s", "master"))
+	assert.Equal(t, "add CHANGES\nstable README\nadd main.go\nadd README", git("log", "--format=%s", "branches/stable"))
+
+	// Changesets that were already converted keep their commits, so that
+	// links to them keep working.
+	assert.Equal(t, before["master"], git("rev-parse", "master~1"))
+	assert.Equal(t, before["branches/stable"], git("rev-parse", "branches/stable~1"))
+	assert.Equal(t, before["feature"], git("rev-parse", "feature"))
+
+	// The URL of the remote is not stored in the Git config.
+	cmd = exec.Command("git", "config", "--get-regexp", "^remote\\.")
+	dir.Set(cmd)
+	out, _ := cmd.Output()
+	assert.Empty(t, strings.TrimSpace(string(out)))
+}
+
+func addHgChangesets(t *testing.T, dir string, changesets []hgChangeset) {
+	t.Helper()
+	for _, cs := range changesets {
+		branch := cs.branch
+		if branch == "" {
+			branch = "default"
+		}
+		if strings.TrimSpace(runHgCmd(t, dir, "branch")) != branch {
+			if strings.Contains(runHgCmd(t, dir, "branches", "--template", "{branch}\n"), branch+"\n") {
+				runHgCmd(t, dir, "update", branch)
+			} else {
+				runHgCmd(t, dir, "branch", branch)
+			}
+		}
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, cs.file), []byte(cs.content), 0644))
+		runHgCmd(t, dir, "commit", "--addremove", "--message", cs.message)
+		if cs.bookmark != "" {
+			runHgCmd(t, dir, "bookmark", "--inactive", cs.bookmark)
+		}
+	}
+}
+
+func runHgCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("hg", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "HGPLAIN=1", "HGUSER=a <a@a.com>")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "hg %s: %s", strings.Join(args, " "), out)
+	return string(out)
+}
@@ -36,6 +36,8 @@ type Server struct {
 	Scheduler             interface {
 		UpdateOnce(id api.RepoID, name api.RepoName)
 		ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
+		ScheduleList(offset, limit int) *protocol.RepoUpdateSchedulerListResult
+		ReloadScheduleSetting(ctx context.Context, id api.RepoID) error
 	}
 	ChangesetSyncRegistry batches.ChangesetSyncRegistry
 	RateLimitSyncer       interface {
@@ -58,6 +60,8 @@ func (s *Server) Handler() http.Handler {
 		w.WriteHeader(http.StatusOK)
 	}))
 	mux.HandleFunc("/repo-update-scheduler-info", trace.WithRouteName("repo-update-scheduler-info", s.handleRepoUpdateSchedulerInfo))
+	mux.HandleFunc("/repo-update-scheduler-list", trace.WithRouteName("repo-update-scheduler-list", s.handleRepoUpdateSchedulerList))
+	mux.HandleFunc("/repo-update-scheduler-reload-setting", trace.WithRouteName("repo-update-scheduler-reload-setting", s.handleRepoUpdateSchedulerReloadSetting))
 	mux.HandleFunc("/repo-lookup", trace.WithRouteName("repo-lookup", s.handleRepoLookup))
 	mux.HandleFunc("/enqueue-repo-update", trace.WithRouteName("enqueue-repo-update", s.handleEnqueueRepoUpdate))
 	mux.HandleFunc("/sync-external-service", trace.WithRouteName("sync-external-service", s.handleExternalServiceSync))
@@ -105,6 +109,34 @@ func (s *Server) handleRepoUpdateSchedulerInfo(w http.ResponseWriter, r *http.Re
 	}
 }
 
+func (s *Server) handleRepoUpdateSchedulerList(w http.ResponseWriter, r *http.Request) {
+	var args protocol.RepoUpdateSchedulerListArgs
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := s.Scheduler.ScheduleList(args.Offset, args.Limit)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) handleRepoUpdateSchedulerReloadSetting(w http.ResponseWriter, r *http.Request) {
+	var args protocol.RepoUpdateSchedulerReloadSettingArgs
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		s.respond(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.Scheduler.ReloadScheduleSetting(r.Context(), args.ID); err != nil {
+		s.respond(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.respond(w, http.StatusOK, nil)
+}
+
 func (s *Server) handleRepoLookup(w http.ResponseWriter, r *http.Request) {
 	var args protocol.RepoLookupArgs
 	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
@@ -675,12 +675,75 @@ func TestServer_RepoLookup(t *testing.T) {
 	}
 }
 
-type fakeScheduler struct{}
+type fakeScheduler struct {
+	reloadErr error
+	reloaded  []api.RepoID
+}
 
 func (s *fakeScheduler) UpdateOnce(_ api.RepoID, _ api.RepoName) {}
 func (s *fakeScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult {
 	return &protocol.RepoUpdateSchedulerInfoResult{}
 }
+func (s *fakeScheduler) ScheduleList(offset, limit int) *protocol.RepoUpdateSchedulerListResult {
+	return &protocol.RepoUpdateSchedulerListResult{}
+}
+func (s *fakeScheduler) ReloadScheduleSetting(_ context.Context, id api.RepoID) error {
+	s.reloaded = append(s.reloaded, id)
+	return s.reloadErr
+}
+
+func TestServer_handleRepoUpdateSchedulerReloadSetting(t *testing.T) {
+	tests := []struct {
+		name           string
+		scheduler      *fakeScheduler
+		body           string
+		wantStatusCode int
+		wantBody       string
+		wantReloaded   []api.RepoID
+	}{
+		{
+			name:           "bad JSON",
+			scheduler:      &fakeScheduler{},
+			body:           "{",
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "unexpected EOF",
+		},
+		{
+			name:           "reload fails",
+			scheduler:      &fakeScheduler{reloadErr: errors.New("boom")},
+			body:           `{"ID": 1}`,
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "boom",
+			wantReloaded:   []api.RepoID{1},
+		},
+		{
+			name:           "successful call",
+			scheduler:      &fakeScheduler{},
+			body:           `{"ID": 1}`,
+			wantStatusCode: http.StatusOK,
+			wantBody:       "null",
+			wantReloaded:   []api.RepoID{1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/repo-update-scheduler-reload-setting", strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+
+			s := &Server{Logger: logtest.Scoped(t), Scheduler: test.scheduler}
+			s.handleRepoUpdateSchedulerReloadSetting(w, r)
+
+			if w.Code != test.wantStatusCode {
+				t.Fatalf("Code: want %v but got %v", test.wantStatusCode, w.Code)
+			} else if diff := cmp.Diff(test.wantBody, w.Body.String()); diff != "" {
+				t.Fatalf("Body mismatch (-want +got):\n%s", diff)
+			} else if diff := cmp.Diff(test.wantReloaded, test.scheduler.reloaded); diff != "" {
+				t.Fatalf("Reloaded mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
 
 type fakePermsSyncer struct{}
 
@@ -25,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/batches"
 	livedependencies "github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/live"
@@ -116,6 +117,7 @@ func Main(enterpriseInit EnterpriseInit) {
 		logger.Fatal("failed to initialize database store", log.Error(err))
 	}
 	db := database.NewDB(logger, sqlDB)
+	audit.SetStore(db.AuditLogs())
 
 	// Generally we'll mark the service as ready sometime after the database has been
 	// connected; migrations may take a while and we don't want to start accepting
@@ -377,7 +379,7 @@ func manualPurgeHandler(db database.DB) http.HandlerFunc {
 }
 
 func rateLimiterStateHandler(w http.ResponseWriter, r *http.Request) {
-	info := ratelimit.DefaultRegistry.LimitInfo()
+	info := ratelimit.DefaultRegistry.LimitInfo(r.Context())
 	resp, err := json.MarshalIndent(info, "", "  ")
 	if err != nil {
 		http.Error(w, fmt.Sprintf("failed to marshal rate limiter state: %q", err.Error()), http.StatusInternalServerError)
@@ -23,6 +23,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/go-ctags"
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/log"
@@ -58,6 +59,14 @@ type Service struct {
 	// single call to git archive. This mainly needs to be less than ARG_MAX
 	// for the exec.Command on gitserver.
 	MaxTotalPathsLength int
+
+	// NewSymbolParser returns a parser, usually ctags, to extract the symbols
+	// of matched files for requests that select symbols. If it is nil, such
+	// requests return no symbols.
+	NewSymbolParser func() (ctags.Parser, error)
+
+	symbolParsersOnce sync.Once
+	symbolParsers     *symbolParserPool
 }
 
 // ServeHTTP handles HTTP based search requests
@@ -233,7 +242,9 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 		return path, zf, err
 	}
 
-	hybrid := !p.IsStructuralPat && p.FeatHybrid
+	// Hybrid search returns the matches of the indexed revision without
+	// symbols, so it is skipped if the request selects symbols.
+	hybrid := !p.IsStructuralPat && p.FeatHybrid && !selectsSymbols(p)
 	if hybrid {
 		unsearched, ok, err := s.hybrid(ctx, p, sender)
 		if err != nil {
@@ -279,6 +290,13 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 	metricArchiveFiles.Observe(float64(nFiles))
 	metricArchiveSize.Observe(float64(bytes))
 
+	if selectsSymbols(p) && s.NewSymbolParser != nil {
+		s.symbolParsersOnce.Do(func() {
+			s.symbolParsers = newSymbolParserPool(s.NewSymbolParser, numWorkers)
+		})
+		sender = newSymbolSender(ctx, sender, zf, s.symbolParsers, s.Log)
+	}
+
 	if p.IsStructuralPat {
 		return filteredStructuralSearch(ctx, zipPath, zf, &p.PatternInfo, p.Repo, sender)
 	} else {
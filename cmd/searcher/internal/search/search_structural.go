@@ -43,14 +43,26 @@ func toFileMatch(zipReader *zip.Reader, combyMatch *comby.FileMatch) (protocol.F
 		return protocol.FileMatch{}, err
 	}
 
-	// Convert comby matches to ranges
-	ranges := make([]protocol.Range, 0, len(combyMatch.Matches))
+	// trust, but verify
 	for _, r := range combyMatch.Matches {
-		// trust, but verify
 		if r.Range.Start.Offset > len(fileBuf) || r.Range.End.Offset > len(fileBuf) {
 			return protocol.FileMatch{}, errors.New("comby match range does not fit in file")
 		}
+	}
+
+	chunks := chunkRanges(toRanges(combyMatch), 0)
+	chunkMatches := chunksToMatches(fileBuf, chunks)
+	return protocol.FileMatch{
+		Path:         combyMatch.URI,
+		ChunkMatches: chunkMatches,
+		LimitHit:     false,
+	}, nil
+}
 
+// toRanges converts comby matches to ranges.
+func toRanges(combyMatch *comby.FileMatch) []protocol.Range {
+	ranges := make([]protocol.Range, 0, len(combyMatch.Matches))
+	for _, r := range combyMatch.Matches {
 		ranges = append(ranges, protocol.Range{
 			Start: protocol.Location{
 				Offset: int32(r.Range.Start.Offset),
@@ -65,14 +77,7 @@ func toFileMatch(zipReader *zip.Reader, combyMatch *comby.FileMatch) (protocol.F
 			},
 		})
 	}
-
-	chunks := chunkRanges(ranges, 0)
-	chunkMatches := chunksToMatches(fileBuf, chunks)
-	return protocol.FileMatch{
-		Path:         combyMatch.URI,
-		ChunkMatches: chunkMatches,
-		LimitHit:     false,
-	}, nil
+	return ranges
 }
 
 func combyChunkMatchesToFileMatch(combyMatch *comby.FileMatchWithChunks) protocol.FileMatch {
@@ -422,6 +427,10 @@ func structuralSearch(ctx context.Context, inputType comby.Input, paths filePatt
 		NumWorkers:    numWorkers,
 	}
 
+	if comby.UseNativeMatcher() {
+		return runNativeMatcher(ctx, args, sender)
+	}
+
 	switch combyInput := inputType.(type) {
 	case comby.Tar:
 		return runCombyAgainstTar(ctx, args, combyInput, sender)
@@ -432,6 +441,30 @@ func structuralSearch(ctx context.Context, inputType comby.Input, paths filePatt
 	return errors.New("comby input must be either -tar or -zip for structural search")
 }
 
+// runNativeMatcher runs the in-process comby matcher against any input, and
+// sends the matches of each file to the result stream. Files whose matching
+// was stopped after too many steps mark the search as having hit a limit.
+func runNativeMatcher(ctx context.Context, args comby.Args, sender matchSender) error {
+	err := comby.NativeMatches(ctx, args, func(cfm *comby.FileMatch, content []byte) {
+		if cfm.LimitHit {
+			sender.SetLimitHit()
+			if len(cfm.Matches) == 0 {
+				return
+			}
+		}
+		sender.Send(protocol.FileMatch{
+			Path:         cfm.URI,
+			ChunkMatches: chunksToMatches(content, chunkRanges(toRanges(cfm), 0)),
+			LimitHit:     cfm.LimitHit,
+		})
+	})
+	if ctx.Err() != nil {
+		// context has been canceled, e.g. because the limit was hit
+		return nil
+	}
+	return err
+}
+
 // runCombyAgainstTar runs comby with the flags `-tar` and `-chunk-matches 0`. `-chunk-matches 0` instructs comby to return
 // chunks as part of matches that it finds. Data is streamed into stdin from the channel on tarInput and out from stdout
 // to the result stream.
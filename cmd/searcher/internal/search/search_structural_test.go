@@ -444,6 +444,22 @@ func bar() {
 	t.Run("many", test(12, 8, &protocol.PatternInfo{Pattern: "(:[_])"}))
 }
 
+func TestRunNativeMatcher_LimitHit(t *testing.T) {
+	// Lazy holes followed by a literal that is not in the rest of the source
+	// backtrack too much, so matching stops after the first match.
+	input := comby.FileContent("1x " + strings.Repeat("a", 1000))
+
+	ctx, cancel, sender := newLimitedStreamCollector(context.Background(), 1000)
+	defer cancel()
+	err := runNativeMatcher(ctx, comby.Args{Input: input, MatchTemplate: ":[a]:[b]:[c]:[d]x"}, sender)
+	require.NoError(t, err)
+
+	require.True(t, sender.LimitHit())
+	require.Len(t, sender.collected, 1)
+	require.True(t, sender.collected[0].LimitHit)
+	require.Equal(t, 1, sender.collected[0].MatchCount())
+}
+
 func TestMatchCountForMultilineMatches(t *testing.T) {
 	// If we are not on CI skip the test.
 	if os.Getenv("CI") == "" {
@@ -16,10 +16,12 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/go-ctags"
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/internal/search"
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	symbolsparser "github.com/sourcegraph/sourcegraph/cmd/symbols/parser"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
@@ -330,6 +332,73 @@ abc.txt
 	}
 }
 
+func TestSearch_symbols(t *testing.T) {
+	files := map[string]struct {
+		body string
+		typ  fileType
+	}{
+		"main.go": {`package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(greeting())
+}
+
+func greeting() string { return "Hello world" }
+`, typeFile},
+	}
+
+	s := newStore(t, files)
+	ts := httptest.NewServer(&search.Service{
+		Store: s,
+		Log:   s.Log,
+		NewSymbolParser: func() (ctags.Parser, error) {
+			return symbolsparser.NewGoParser(), nil
+		},
+	})
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		selectPath string
+		want       []protocol.Symbol
+	}{
+		{
+			selectPath: "symbol",
+			// The call of greeting on line 6 is not a definition.
+			want: []protocol.Symbol{{Name: "greeting", Kind: "func", Language: "Go", Line: 9, Column: 5}},
+		},
+		{
+			selectPath: "",
+			want:       nil,
+		},
+	} {
+		t.Run(tc.selectPath, func(t *testing.T) {
+			req := protocol.Request{
+				Repo:   "foo",
+				URL:    "u",
+				Commit: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+				PatternInfo: protocol.PatternInfo{
+					Pattern:               "greeting",
+					PatternMatchesContent: true,
+					Select:                tc.selectPath,
+				},
+				FetchTimeout: fetchTimeoutForCI(t),
+			}
+			m, err := doSearch(ts.URL, &req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(m) != 1 {
+				t.Fatalf("expected 1 file match, got %d", len(m))
+			}
+			if d := cmp.Diff(tc.want, m[0].Symbols); d != "" {
+				t.Errorf("unexpected symbols (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
 func TestSearch_badrequest(t *testing.T) {
 	cases := []protocol.Request{
 		// Bad regexp
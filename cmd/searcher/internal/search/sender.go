@@ -14,6 +14,9 @@ type matchSender interface {
 	SentCount() int
 	Remaining() int
 	LimitHit() bool
+	// SetLimitHit records that matches may be missing without canceling the
+	// search, e.g. because matching a file took too long.
+	SetLimitHit()
 }
 
 type limitedStream struct {
@@ -87,6 +90,10 @@ func (m *limitedStream) LimitHit() bool {
 	return m.limitHit.Load()
 }
 
+func (m *limitedStream) SetLimitHit() {
+	m.limitHit.Store(true)
+}
+
 type limitedStreamCollector struct {
 	collected []protocol.FileMatch
 	mux       sync.Mutex
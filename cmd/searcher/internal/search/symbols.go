@@ -0,0 +1,183 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/go-ctags"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// selectsSymbols returns true if the request selects the symbols of the
+// matched files, e.g. for the query `type:file foo select:symbol.function`.
+// Zoekt returns the symbols of matches in indexed revisions, so searcher
+// extracts them on the fly for all other revisions.
+func selectsSymbols(p *protocol.Request) bool {
+	return p.Select == filter.Symbol && !p.IsStructuralPat
+}
+
+// symbolParserPool is a pool of symbol parsers. Parsers are created on demand,
+// since each ctags parser runs a process and most requests do not select
+// symbols.
+type symbolParserPool struct {
+	newParser func() (ctags.Parser, error)
+	pool      chan ctags.Parser
+}
+
+func newSymbolParserPool(newParser func() (ctags.Parser, error), size int) *symbolParserPool {
+	pool := make(chan ctags.Parser, size)
+	for i := 0; i < size; i++ {
+		pool <- nil
+	}
+	return &symbolParserPool{
+		newParser: newParser,
+		pool:      pool,
+	}
+}
+
+// Get returns a parser from the pool. Done MUST be called with the parser once
+// it is no longer in use, or with nil if the parser failed.
+func (p *symbolParserPool) Get(ctx context.Context) (ctags.Parser, error) {
+	select {
+	case parser := <-p.pool:
+		if parser != nil {
+			return parser, nil
+		}
+		parser, err := p.newParser()
+		if err != nil {
+			p.pool <- nil
+			return nil, errors.Wrap(err, "failed to create symbol parser")
+		}
+		return parser, nil
+
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *symbolParserPool) Done(parser ctags.Parser) {
+	p.pool <- parser
+}
+
+// symbolSender extracts the symbols defined on the matched lines of files
+// before sending them, so that they can be selected like the symbols Zoekt
+// returns for indexed revisions.
+type symbolSender struct {
+	matchSender
+
+	ctx     context.Context
+	zf      *zipFile
+	parsers *symbolParserPool
+	log     log.Logger
+
+	filesOnce sync.Once
+	files     map[string]*srcFile
+}
+
+func newSymbolSender(ctx context.Context, sender matchSender, zf *zipFile, parsers *symbolParserPool, logger log.Logger) *symbolSender {
+	return &symbolSender{
+		matchSender: sender,
+		ctx:         ctx,
+		zf:          zf,
+		parsers:     parsers,
+		log:         logger,
+	}
+}
+
+func (s *symbolSender) Send(fm protocol.FileMatch) {
+	if len(fm.ChunkMatches) > 0 {
+		symbols, err := s.symbols(fm)
+		if err != nil && s.ctx.Err() == nil {
+			// The file is sent without symbols, so it is dropped by the
+			// selection in the frontend.
+			s.log.Warn("failed to extract symbols", log.String("path", fm.Path), log.Error(err))
+		}
+		fm.Symbols = symbols
+	}
+	s.matchSender.Send(fm)
+}
+
+func (s *symbolSender) symbols(fm protocol.FileMatch) (_ []protocol.Symbol, err error) {
+	s.filesOnce.Do(func() {
+		s.files = make(map[string]*srcFile, len(s.zf.Files))
+		for i := range s.zf.Files {
+			s.files[s.zf.Files[i].Name] = &s.zf.Files[i]
+		}
+	})
+	f, ok := s.files[fm.Path]
+	if !ok {
+		return nil, nil
+	}
+	content := s.zf.DataFor(f)
+
+	parser, err := s.parsers.Get(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil {
+			s.parsers.Done(parser)
+		} else {
+			// The parser may be unusable, e.g. because its ctags process
+			// exited, so it is replaced by a new one.
+			parser.Close()
+			s.parsers.Done(nil)
+		}
+	}()
+
+	entries, err := parser.Parse(fm.Path, content)
+	if err != nil {
+		return nil, err
+	}
+	return matchedSymbols(content, fm.ChunkMatches, entries), nil
+}
+
+// matchedSymbols returns the symbols that are defined on the lines of the
+// ranges of chunks.
+func matchedSymbols(content []byte, chunks []protocol.ChunkMatch, entries []*ctags.Entry) []protocol.Symbol {
+	// ctags lines are 1-based, the lines of ranges are 0-based.
+	matchedLines := map[int]struct{}{}
+	for _, cm := range chunks {
+		for _, rr := range cm.Ranges {
+			for line := rr.Start.Line; line <= rr.End.Line; line++ {
+				matchedLines[int(line)+1] = struct{}{}
+			}
+		}
+	}
+
+	var lines []string
+	var symbols []protocol.Symbol
+	for _, e := range entries {
+		if _, ok := matchedLines[e.Line]; !ok || e.Name == "" {
+			continue
+		}
+		if lines == nil {
+			lines = strings.Split(string(content), "\n")
+		}
+		if e.Line > len(lines) {
+			continue
+		}
+
+		column := strings.Index(lines[e.Line-1], e.Name)
+		if column == -1 {
+			// ctags doesn't always return the right line.
+			column = 0
+		}
+
+		symbols = append(symbols, protocol.Symbol{
+			Name:       e.Name,
+			Kind:       e.Kind,
+			Parent:     e.Parent,
+			ParentKind: e.ParentKind,
+			Language:   e.Language,
+			Line:       int32(e.Line),
+			Column:     int32(column),
+		})
+	}
+	return symbols
+}
@@ -21,11 +21,15 @@ import (
 	"github.com/getsentry/sentry-go"
 	"github.com/keegancsmith/tmpfriend"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/go-ctags"
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/internal/search"
+	symbolsparser "github.com/sourcegraph/sourcegraph/cmd/symbols/parser"
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/comby"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -51,6 +55,10 @@ var (
 	cacheSizeMB = env.Get("SEARCHER_CACHE_SIZE_MB", "100000", "maximum size of the on disk cache in megabytes")
 
 	maxTotalPathsLengthRaw = env.Get("MAX_TOTAL_PATHS_LENGTH", "100000", "maximum sum of lengths of all paths in a single call to git archive")
+
+	// ctagsConfig configures the parsers that extract the symbols of matched
+	// files for queries like `type:file foo select:symbol`.
+	ctagsConfig = types.LoadCtagsConfig(env.BaseConfig{})
 )
 
 const port = "3181"
@@ -139,6 +147,9 @@ func run(logger log.Logger) error {
 		return errors.Wrap(err, "failed to setup TMPDIR")
 	}
 
+	// Decide which structural search matcher to use, which logs it.
+	_ = comby.UseNativeMatcher()
+
 	storeObservationContext := &observation.Context{
 		// Explicitly don't scope Store logger under the parent logger
 		Logger:     log.Scoped("Store", "searcher archives store"),
@@ -185,6 +196,9 @@ func run(logger log.Logger) error {
 
 		GitDiffSymbols:      git.DiffSymbols,
 		MaxTotalPathsLength: maxTotalPathsLength,
+		NewSymbolParser: func() (ctags.Parser, error) {
+			return symbolsparser.SpawnCtags(logger, ctagsConfig)
+		},
 
 		Log: logger,
 	}
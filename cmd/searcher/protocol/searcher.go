@@ -201,6 +201,10 @@ type FileMatch struct {
 
 	ChunkMatches []ChunkMatch
 
+	// Symbols are the symbols defined on the lines of ChunkMatches. They are
+	// only extracted if the request selects symbols (Select is "symbol").
+	Symbols []Symbol `json:",omitempty"`
+
 	// LimitHit is true if LineMatches may not include all LineMatches.
 	LimitHit bool
 }
@@ -250,6 +254,21 @@ func (cm ChunkMatch) MatchedContent() []string {
 	return res
 }
 
+// Symbol is a symbol extracted by ctags from a matched file.
+type Symbol struct {
+	Name       string
+	Kind       string
+	Parent     string
+	ParentKind string
+	Language   string
+
+	// Line is the 1-based line number of the symbol, as reported by ctags.
+	Line int32
+
+	// Column is the byte offset of the symbol's name in its line.
+	Column int32
+}
+
 type Range struct {
 	Start Location
 	End   Location
@@ -46,6 +46,10 @@ func MakeSqliteSearchFunc(operations *sharedobservability.Operations, cachedData
 		}()
 		ctx = observability.SeedParseAmount(ctx)
 
+		if args.History {
+			return nil, errors.New("symbol history search is only supported for repositories indexed with Rockskip")
+		}
+
 		timeout := searchTimeout
 		if args.Timeout > 0 && time.Duration(args.Timeout)*time.Second < timeout {
 			timeout = time.Duration(args.Timeout) * time.Second
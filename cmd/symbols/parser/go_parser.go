@@ -0,0 +1,211 @@
+package parser
+
+import (
+	"bytes"
+	"go/ast"
+	goparser "go/parser"
+	"go/printer"
+	"go/token"
+	"strings"
+
+	"github.com/sourcegraph/go-ctags"
+)
+
+// goParser parses Go files with go/parser. Unlike ctags, it reports the
+// receiver type of methods as their parent, and the signatures of functions and
+// methods.
+type goParser struct{}
+
+func NewGoParser() ctags.Parser {
+	return goParser{}
+}
+
+func (goParser) Parse(path string, content []byte) ([]*ctags.Entry, error) {
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, path, content, goparser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	g := goSymbols{path: path, fset: fset, typeKinds: map[string]string{}}
+
+	// Collect the kinds of the types declared in the file first, so that the
+	// parent kind of methods is known regardless of declaration order.
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				g.typeKinds[ts.Name.Name] = typeKind(ts)
+			}
+		}
+	}
+
+	g.add(file.Name, "package", "", "", "")
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			g.addFunc(decl)
+		case *ast.GenDecl:
+			g.addGenDecl(decl)
+		}
+	}
+
+	return g.entries, nil
+}
+
+func (goParser) Close() {}
+
+// goSymbols accumulates the ctags entries of the top-level declarations of a
+// Go file.
+type goSymbols struct {
+	path      string
+	fset      *token.FileSet
+	typeKinds map[string]string
+	entries   []*ctags.Entry
+}
+
+func (g *goSymbols) add(name *ast.Ident, kind, parent, parentKind, signature string) {
+	if name == nil || name.Name == "_" {
+		return
+	}
+
+	g.entries = append(g.entries, &ctags.Entry{
+		Name:       name.Name,
+		Path:       g.path,
+		Line:       g.fset.Position(name.Pos()).Line,
+		Kind:       kind,
+		Language:   "Go",
+		Parent:     parent,
+		ParentKind: parentKind,
+		Signature:  signature,
+	})
+}
+
+func (g *goSymbols) addFunc(decl *ast.FuncDecl) {
+	signature := g.signature(decl.Type)
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		g.add(decl.Name, "func", "", "", signature)
+		return
+	}
+
+	receiver := receiverTypeName(decl.Recv.List[0].Type)
+	receiverKind, ok := g.typeKinds[receiver]
+	if !ok {
+		// The type is declared in another file of the package.
+		receiverKind = "type"
+	}
+	g.add(decl.Name, "method", receiver, receiverKind, signature)
+}
+
+func (g *goSymbols) addGenDecl(decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			g.addType(spec)
+
+		case *ast.ValueSpec:
+			kind := "variable"
+			if decl.Tok == token.CONST {
+				kind = "constant"
+			}
+			for _, name := range spec.Names {
+				g.add(name, kind, "", "", "")
+			}
+		}
+	}
+}
+
+func (g *goSymbols) addType(spec *ast.TypeSpec) {
+	kind := typeKind(spec)
+	g.add(spec.Name, kind, "", "", "")
+
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		for _, field := range t.Fields.List {
+			if len(field.Names) == 0 {
+				// Embedded fields are named after their type.
+				g.add(embeddedName(field.Type), "field", spec.Name.Name, kind, "")
+				continue
+			}
+			for _, name := range field.Names {
+				g.add(name, "field", spec.Name.Name, kind, "")
+			}
+		}
+
+	case *ast.InterfaceType:
+		for _, method := range t.Methods.List {
+			funcType, ok := method.Type.(*ast.FuncType)
+			if !ok {
+				// Embedded interfaces and type constraints are not symbols.
+				continue
+			}
+			for _, name := range method.Names {
+				g.add(name, "method", spec.Name.Name, kind, g.signature(funcType))
+			}
+		}
+	}
+}
+
+// signature returns the type parameters, parameters and results of a function,
+// e.g. "(ctx context.Context, id int) (*User, error)".
+func (g *goSymbols) signature(funcType *ast.FuncType) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, g.fset, funcType); err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(buf.String(), "func")
+}
+
+// typeKind returns the ctags kind of a type declaration.
+func typeKind(spec *ast.TypeSpec) string {
+	switch spec.Type.(type) {
+	case *ast.StructType:
+		return "struct"
+	case *ast.InterfaceType:
+		return "interface"
+	default:
+		return "type"
+	}
+}
+
+// receiverTypeName returns the name of the type of a method receiver, without
+// pointer and type parameters.
+func receiverTypeName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// embeddedName returns the identifier an embedded field is named after, e.g.
+// Mutex for *sync.Mutex.
+func embeddedName(expr ast.Expr) *ast.Ident {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			return e.Sel
+		case *ast.Ident:
+			return e
+		default:
+			return nil
+		}
+	}
+}
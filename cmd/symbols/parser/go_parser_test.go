@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/go-ctags"
+)
+
+func TestGoParser(t *testing.T) {
+	content := `package users
+
+import "context"
+
+const maxUsers = 10
+
+var (
+	ErrNotFound = errNotFound{}
+	_           = maxUsers
+)
+
+type Store interface {
+	GetByID(ctx context.Context, id int) (*User, error)
+	fmt.Stringer
+}
+
+type User struct {
+	ID, Name string
+	*sync.Mutex
+}
+
+type ID int
+
+func (u *User) String() string { return u.Name }
+
+func (c cache[K, V]) Get(key K) (V, bool) {
+	var v V
+	return v, false
+}
+
+func New[T any](values ...T) T {
+	type local struct{}
+	return values[0]
+}
+`
+
+	entries, err := NewGoParser().Parse("users/users.go", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := func(name string, line int, kind, parent, parentKind, signature string) *ctags.Entry {
+		return &ctags.Entry{
+			Name:       name,
+			Path:       "users/users.go",
+			Line:       line,
+			Kind:       kind,
+			Language:   "Go",
+			Parent:     parent,
+			ParentKind: parentKind,
+			Signature:  signature,
+		}
+	}
+	want := []*ctags.Entry{
+		entry("users", 1, "package", "", "", ""),
+		entry("maxUsers", 5, "constant", "", "", ""),
+		entry("ErrNotFound", 8, "variable", "", "", ""),
+		entry("Store", 12, "interface", "", "", ""),
+		entry("GetByID", 13, "method", "Store", "interface", "(ctx context.Context, id int) (*User, error)"),
+		entry("User", 17, "struct", "", "", ""),
+		entry("ID", 18, "field", "User", "struct", ""),
+		entry("Name", 18, "field", "User", "struct", ""),
+		entry("Mutex", 19, "field", "User", "struct", ""),
+		entry("ID", 22, "type", "", "", ""),
+		entry("String", 24, "method", "User", "struct", "() string"),
+		entry("Get", 26, "method", "cache", "type", "(key K) (V, bool)"),
+		entry("New", 31, "func", "", "", "[T any](values ...T) T"),
+	}
+	if diff := cmp.Diff(want, entries); diff != "" {
+		t.Errorf("unexpected entries (-want +got):\n%s", diff)
+	}
+}
+
+func TestRoutingParser(t *testing.T) {
+	fallback := &recordingParser{}
+	p := NewRoutingParser(fallback, NativeParsers())
+
+	entries, err := p.Parse("main.go", []byte("package main\n\nfunc main() {}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || fallback.calls != 0 {
+		t.Errorf("expected Go file to be parsed natively, got %d entries and %d fallback calls", len(entries), fallback.calls)
+	}
+
+	// Files with syntax errors and files of other languages are parsed with
+	// the fallback parser.
+	for _, path := range []string{"broken.go", "main.ts"} {
+		if _, err := p.Parse(path, []byte("func {")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fallback.calls != 2 {
+		t.Errorf("expected 2 fallback calls, got %d", fallback.calls)
+	}
+}
+
+type recordingParser struct {
+	calls int
+}
+
+func (p *recordingParser) Parse(path string, content []byte) ([]*ctags.Entry, error) {
+	p.calls++
+	return nil, nil
+}
+
+func (p *recordingParser) Close() {}
@@ -269,5 +269,9 @@ func SpawnCtags(logger log.Logger, ctagsConfig types.CtagsConfig) (ctags.Parser,
 		return nil, errors.Wrap(err, "failed to create new ctags parser")
 	}
 
+	if ctagsConfig.NativeParsers {
+		parser = NewRoutingParser(parser, NativeParsers())
+	}
+
 	return NewFilteringParser(parser, ctagsConfig.MaxFileSize, ctagsConfig.MaxSymbols), nil
 }
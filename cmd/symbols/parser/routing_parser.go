@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/go-ctags"
+)
+
+// RoutingParser parses each file with the parser registered for its extension,
+// and all other files with a fallback parser, which is usually ctags.
+// Language-specific parsers implement ctags.Parser, so that the symbols they
+// return are handled like those of ctags by the SQLite store and Rockskip.
+type RoutingParser struct {
+	fallback ctags.Parser
+	parsers  map[string]ctags.Parser
+}
+
+// NewRoutingParser returns a parser that routes files to the given parsers by
+// their extension, including the leading dot (e.g. ".go"). If a parser fails to
+// parse a file, for example because it contains syntax errors, the file is
+// parsed with the fallback parser instead.
+func NewRoutingParser(fallback ctags.Parser, parsers map[string]ctags.Parser) ctags.Parser {
+	return &RoutingParser{
+		fallback: fallback,
+		parsers:  parsers,
+	}
+}
+
+// NativeParsers returns the language-specific parsers that are used instead of
+// ctags, by file extension.
+func NativeParsers() map[string]ctags.Parser {
+	return map[string]ctags.Parser{
+		".go": NewGoParser(),
+	}
+}
+
+func (p *RoutingParser) Parse(path string, content []byte) ([]*ctags.Entry, error) {
+	if parser, ok := p.parsers[strings.ToLower(filepath.Ext(path))]; ok {
+		if entries, err := parser.Parse(path, content); err == nil {
+			return entries, nil
+		}
+	}
+
+	return p.fallback.Parse(path, content)
+}
+
+func (p *RoutingParser) Close() {
+	for _, parser := range p.parsers {
+		parser.Close()
+	}
+	p.fallback.Close()
+}
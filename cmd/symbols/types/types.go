@@ -44,6 +44,7 @@ type CtagsConfig struct {
 	DebugLogs          bool
 	MaxFileSize        int
 	MaxSymbols         int
+	NativeParsers      bool
 }
 
 func LoadCtagsConfig(baseConfig env.BaseConfig) CtagsConfig {
@@ -59,6 +60,7 @@ func LoadCtagsConfig(baseConfig env.BaseConfig) CtagsConfig {
 		DebugLogs:          false,
 		MaxFileSize:        baseConfig.GetInt("CTAGS_MAX_FILE_SIZE", "524288", "skip files larger than this size (in bytes)"),
 		MaxSymbols:         baseConfig.GetInt("CTAGS_MAX_SYMBOLS", "2000", "skip files with more than this many symbols"),
+		NativeParsers:      baseConfig.GetBool("USE_NATIVE_PARSERS", "true", "parse files of languages with a native parser (currently Go) without ctags"),
 	}
 }
 
@@ -0,0 +1,50 @@
+package auditlogs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type handler struct {
+	logger log.Logger
+	store  database.AuditLogStore
+}
+
+var _ goroutine.Handler = &handler{}
+var _ goroutine.ErrorHandler = &handler{}
+
+func (h *handler) Handle(ctx context.Context) error {
+	retention := h.calculateRetention(conf.Get())
+	h.logger.Debug("purging audit log records", log.Duration("retention", retention))
+
+	return h.store.DeleteStale(ctx, retention)
+}
+
+func (h *handler) HandleError(err error) {
+	h.logger.Error("error deleting stale audit log records", log.Error(err))
+}
+
+// This matches the documented value in the site configuration schema.
+const defaultRetention = 90 * 24 * time.Hour
+
+func (h *handler) calculateRetention(c *conf.Unified) time.Duration {
+	if c.Log == nil || c.Log.AuditLog == nil || c.Log.AuditLog.Retention == "" {
+		return defaultRetention
+	}
+
+	retention, err := time.ParseDuration(c.Log.AuditLog.Retention)
+	if err != nil {
+		h.logger.Warn("invalid audit log retention period; ignoring", log.String("raw", c.Log.AuditLog.Retention), log.Error(err))
+		return defaultRetention
+	}
+	if retention < time.Hour {
+		return time.Hour
+	}
+	return retention
+}
@@ -0,0 +1,66 @@
+package auditlogs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestHandler(t *testing.T) {
+	t.Run("store error", func(t *testing.T) {
+		want := errors.New("error")
+		store := database.NewMockAuditLogStore()
+		store.DeleteStaleFunc.SetDefaultReturn(want)
+
+		h := &handler{
+			logger: logtest.Scoped(t),
+			store:  store,
+		}
+
+		err := h.Handle(context.Background())
+		assert.ErrorIs(t, err, want)
+		mockassert.CalledOnce(t, store.DeleteStaleFunc)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		store := database.NewMockAuditLogStore()
+		h := &handler{
+			logger: logtest.Scoped(t),
+			store:  store,
+		}
+
+		err := h.Handle(context.Background())
+		assert.Nil(t, err)
+		mockassert.CalledOnce(t, store.DeleteStaleFunc)
+	})
+}
+
+func TestCalculateRetention(t *testing.T) {
+	h := &handler{logger: logtest.Scoped(t)}
+
+	for name, tc := range map[string]struct {
+		log  *schema.Log
+		want time.Duration
+	}{
+		"no log config":       {log: nil, want: defaultRetention},
+		"no audit log config": {log: &schema.Log{}, want: defaultRetention},
+		"empty retention":     {log: &schema.Log{AuditLog: &schema.AuditLog{}}, want: defaultRetention},
+		"invalid retention":   {log: &schema.Log{AuditLog: &schema.AuditLog{Retention: "forever"}}, want: defaultRetention},
+		"short retention":     {log: &schema.Log{AuditLog: &schema.AuditLog{Retention: "5m"}}, want: time.Hour},
+		"valid retention":     {log: &schema.Log{AuditLog: &schema.AuditLog{Retention: "720h"}}, want: 720 * time.Hour},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &conf.Unified{SiteConfiguration: schema.SiteConfiguration{Log: tc.log}}
+			assert.Equal(t, tc.want, h.calculateRetention(c))
+		})
+	}
+}
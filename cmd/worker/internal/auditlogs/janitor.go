@@ -0,0 +1,48 @@
+package auditlogs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// janitor is a worker responsible for expunging audit log records that are
+// older than the configured retention period from the database.
+type janitor struct{}
+
+var _ job.Job = &janitor{}
+
+func NewJanitor() job.Job {
+	return &janitor{}
+}
+
+func (j *janitor) Description() string {
+	return "Removes audit log records older than the configured retention period."
+}
+
+func (j *janitor) Config() []env.Config {
+	return nil
+}
+
+func (j *janitor) Routines(ctx context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		// Retention values under an hour aren't supported, so there is no
+		// point in running more frequently than that.
+		goroutine.NewPeriodicGoroutine(context.Background(), 1*time.Hour, &handler{
+			logger: logger.Scoped("handler", "audit log janitor handler"),
+			store:  database.NewDB(logger, db).AuditLogs(),
+		}),
+	}, nil
+}
@@ -15,6 +15,7 @@ type metrics struct {
 	// processing status
 	numRecordsEncrypted *prometheus.CounterVec
 	numRecordsDecrypted *prometheus.CounterVec
+	numRecordsRewrapped *prometheus.CounterVec
 	numErrors           prometheus.Counter
 }
 
@@ -65,6 +66,10 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		"src_records_decrypted_total",
 		"The number of encrypted database records that have been decrypted.",
 	)
+	numRecordsRewrapped := counterVec(
+		"src_records_rewrapped_total",
+		"The number of encrypted database records that have been re-encrypted with the latest version of a rotated key.",
+	)
 	numErrors := counter(
 		"src_record_encryption_errors_total",
 		"The number of errors that occur during record encryption/decryption.",
@@ -74,6 +79,7 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		// Initialize counters to zero
 		numRecordsEncrypted.WithLabelValues(config.TableName).Add(0)
 		numRecordsDecrypted.WithLabelValues(config.TableName).Add(0)
+		numRecordsRewrapped.WithLabelValues(config.TableName).Add(0)
 	}
 
 	return &metrics{
@@ -81,6 +87,7 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		numUnencryptedAtRest: numUnencryptedAtRest,
 		numRecordsEncrypted:  numRecordsEncrypted,
 		numRecordsDecrypted:  numRecordsDecrypted,
+		numRecordsRewrapped:  numRecordsRewrapped,
 		numErrors:            numErrors,
 	}
 }
@@ -87,6 +87,8 @@ func lintLoggingLibraries() *linter {
 			"dev/sg/linters",
 			// We allow one usage of a direct zap import here
 			"internal/observation/fields.go",
+			// Audit log fields are encoded to JSON to be stored in the database
+			"internal/audit/fields.go",
 			// Dependencies require direct usage of zap
 			"cmd/frontend/internal/app/otlpadapter",
 			// Not worth fixing the deprecated package
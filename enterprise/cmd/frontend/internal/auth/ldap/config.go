@@ -0,0 +1,146 @@
+package ldap
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const pkgName = "ldap"
+
+func Init() {
+	conf.ContributeValidator(validateConfig)
+
+	logger := log.Scoped(pkgName, "LDAP config watch")
+	go func() {
+		conf.Watch(func() {
+			ps := getProviders()
+			if len(ps) == 0 {
+				providers.Update(pkgName, nil)
+				return
+			}
+
+			if err := licensing.Check(licensing.FeatureSSO); err != nil {
+				logger.Error("Check license for SSO (LDAP)", log.Error(err))
+				providers.Update(pkgName, nil)
+				return
+			}
+			providers.Update(pkgName, ps)
+		})
+	}()
+}
+
+func getProviders() []providers.Provider {
+	var ps []providers.Provider
+	for _, p := range conf.Get().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+		ps = append(ps, &provider{config: *p.Ldap})
+	}
+	return ps
+}
+
+func validateConfig(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+	seen := map[string]int{}
+	for i, p := range c.SiteConfig().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+
+		id := providerConfigID(p.Ldap)
+		if j, ok := seen[id]; ok {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d is duplicate of index %d, ignoring", i, j)))
+			continue
+		}
+		seen[id] = i
+
+		for _, msg := range validateProvider(p.Ldap) {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: %s", i, msg)))
+		}
+	}
+	return problems
+}
+
+func validateProvider(pc *schema.LDAPAuthProvider) (problems []string) {
+	if u, err := url.Parse(pc.Url); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid url: %s", err))
+	} else if u.Scheme == "ldaps" && pc.StartTLS {
+		problems = append(problems, "startTLS cannot be used with an ldaps:// url")
+	}
+
+	if err := validateFilter(userSearchFilter(pc)); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if pc.Certificate != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(pc.Certificate)) {
+		problems = append(problems, "invalid certificate")
+	}
+
+	if pc.BindDN == "" && pc.BindPassword != "" {
+		problems = append(problems, "bindPassword is set, but bindDN is not")
+	}
+
+	return problems
+}
+
+// providerConfigID produces a semi-stable identifier for an LDAP auth provider config object. It
+// is used to distinguish between multiple auth providers of the same type when signing in. Its
+// value is never persisted, and it must be deterministic.
+func providerConfigID(pc *schema.LDAPAuthProvider) string {
+	if pc.ConfigID != "" {
+		return pc.ConfigID
+	}
+	data, err := json.Marshal(pc)
+	if err != nil {
+		panic(err)
+	}
+	b := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(b[:16])
+}
+
+func userSearchFilter(pc *schema.LDAPAuthProvider) string {
+	if pc.UserSearchFilter == "" {
+		return "(objectClass=person)"
+	}
+	return pc.UserSearchFilter
+}
+
+func usernameAttribute(pc *schema.LDAPAuthProvider) string {
+	if pc.UsernameAttribute == "" {
+		return "uid"
+	}
+	return pc.UsernameAttribute
+}
+
+func emailAttribute(pc *schema.LDAPAuthProvider) string {
+	if pc.EmailAttribute == "" {
+		return "mail"
+	}
+	return pc.EmailAttribute
+}
+
+func displayNameAttribute(pc *schema.LDAPAuthProvider) string {
+	if pc.DisplayNameAttribute == "" {
+		return "cn"
+	}
+	return pc.DisplayNameAttribute
+}
+
+func groupsAttributeName(pc *schema.LDAPAuthProvider) string {
+	if pc.GroupsAttributeName == "" {
+		return "memberOf"
+	}
+	return pc.GroupsAttributeName
+}
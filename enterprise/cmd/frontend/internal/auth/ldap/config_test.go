@@ -0,0 +1,62 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestValidateCustom(t *testing.T) {
+	valid := func() *schema.LDAPAuthProvider {
+		return &schema.LDAPAuthProvider{
+			Type:           "ldap",
+			Url:            "ldap://ldap.example.com",
+			UserSearchBase: "dc=example,dc=com",
+		}
+	}
+
+	tests := map[string]struct {
+		input        func(*schema.LDAPAuthProvider)
+		wantProblems conf.Problems
+	}{
+		"valid": {
+			input:        func(*schema.LDAPAuthProvider) {},
+			wantProblems: nil,
+		},
+		"ldaps with StartTLS": {
+			input: func(p *schema.LDAPAuthProvider) {
+				p.Url = "ldaps://ldap.example.com"
+				p.StartTLS = true
+			},
+			wantProblems: conf.NewSiteProblems("startTLS cannot be used"),
+		},
+		"invalid filter": {
+			input:        func(p *schema.LDAPAuthProvider) { p.UserSearchFilter = "(objectClass=person" },
+			wantProblems: conf.NewSiteProblems("invalid LDAP filter"),
+		},
+		"invalid certificate": {
+			input:        func(p *schema.LDAPAuthProvider) { p.Certificate = "-----BEGIN CERTIFICATE-----\nfoo" },
+			wantProblems: conf.NewSiteProblems("invalid certificate"),
+		},
+		"bindPassword without bindDN": {
+			input:        func(p *schema.LDAPAuthProvider) { p.BindPassword = "secret" },
+			wantProblems: conf.NewSiteProblems("bindDN is not"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := valid()
+			test.input(p)
+			conf.TestValidator(t, conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{{Ldap: p}},
+			}}, validateConfig, test.wantProblems)
+		})
+	}
+
+	t.Run("duplicate", func(t *testing.T) {
+		conf.TestValidator(t, conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			AuthProviders: []schema.AuthProviders{{Ldap: valid()}, {Ldap: valid()}},
+		}}, validateConfig, conf.NewSiteProblems("duplicate of index 0"))
+	})
+}
@@ -0,0 +1,88 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// dial connects to the LDAP server at the given ldap:// or ldaps:// URL. The deadline of ctx, if
+// any, applies to all operations on the returned connection.
+func dial(ctx context.Context, rawURL string, startTLS bool, tlsConfig *tls.Config) (*goldap.Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig = tlsConfig.Clone()
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = u.Hostname()
+	}
+
+	var (
+		d    net.Dialer
+		nc   net.Conn
+		addr = u.Host
+	)
+	switch strings.ToLower(u.Scheme) {
+	case "ldap":
+		if u.Port() == "" {
+			addr = net.JoinHostPort(u.Hostname(), "389")
+		}
+		nc, err = d.DialContext(ctx, "tcp", addr)
+	case "ldaps":
+		if startTLS {
+			return nil, errors.New("StartTLS cannot be used with an ldaps:// URL")
+		}
+		if u.Port() == "" {
+			addr = net.JoinHostPort(u.Hostname(), "636")
+		}
+		td := tls.Dialer{NetDialer: &d, Config: tlsConfig}
+		nc, err = td.DialContext(ctx, "tcp", addr)
+	default:
+		return nil, errors.Errorf("unsupported LDAP URL scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := goldap.NewConn(nc, strings.EqualFold(u.Scheme, "ldaps"))
+	c.Start()
+	if deadline, ok := ctx.Deadline(); ok {
+		c.SetTimeout(time.Until(deadline))
+	}
+
+	if startTLS {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			c.Close()
+			return nil, errors.Wrap(err, "StartTLS")
+		}
+	}
+	return c, nil
+}
+
+// validateFilter returns an error if s is not a valid search filter, as defined in RFC 4515.
+func validateFilter(s string) error {
+	if _, err := goldap.CompileFilter(s); err != nil {
+		return errors.Wrapf(err, "invalid LDAP filter %q", s)
+	}
+	return nil
+}
+
+// userFilter returns the filter that matches the entry of the user with the given username among
+// the entries that match the configured user search filter.
+func userFilter(searchFilter, usernameAttr, username string) string {
+	// 🚨 SECURITY: The username is user input, so it must be escaped before it is interpolated
+	// into the filter string.
+	return "(&" + searchFilter + "(" + usernameAttr + "=" + goldap.EscapeFilter(username) + "))"
+}
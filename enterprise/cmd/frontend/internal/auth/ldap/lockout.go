@@ -0,0 +1,57 @@
+package ldap
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+)
+
+// lockoutStore tracks failed sign-in attempts, so that a username can't be used to guess passwords
+// against the LDAP server indefinitely. Usernames are tracked rather than Sourcegraph users, since
+// a user might not have a Sourcegraph account yet.
+type lockoutStore interface {
+	// isLockedOut reports whether the key has been locked out after too many failed attempts.
+	isLockedOut(key string) bool
+	// increaseFailedAttempt increases the failed sign-in attempt count of the key by 1, and locks
+	// the key out when the count reaches the threshold.
+	increaseFailedAttempt(key string)
+	// reset clears the failed sign-in attempt count of the key.
+	reset(key string)
+}
+
+type redisLockoutStore struct {
+	failedThreshold int
+	lockouts        *rcache.Cache
+	failedAttempts  *rcache.Cache
+}
+
+// newLockoutStore returns a lockoutStore that keeps its state in Redis, so that it is shared by
+// all frontend replicas. It uses the same thresholds as the lockout of builtin password accounts.
+func newLockoutStore(failedThreshold int, lockoutPeriod, consecutivePeriod time.Duration) lockoutStore {
+	return &redisLockoutStore{
+		failedThreshold: failedThreshold,
+		lockouts:        rcache.NewWithTTL("ldap_lockout", int(lockoutPeriod.Seconds())),
+		failedAttempts:  rcache.NewWithTTL("ldap_failed_attempts", int(consecutivePeriod.Seconds())),
+	}
+}
+
+func (s *redisLockoutStore) isLockedOut(key string) bool {
+	_, locked := s.lockouts.Get(key)
+	return locked
+}
+
+func (s *redisLockoutStore) increaseFailedAttempt(key string) {
+	s.failedAttempts.Increase(key)
+
+	// Get right after Increase should make the key always exist
+	v, _ := s.failedAttempts.Get(key)
+	if count, _ := strconv.Atoi(string(v)); count >= s.failedThreshold {
+		s.lockouts.Set(key, []byte("too many failed attempts"))
+	}
+}
+
+func (s *redisLockoutStore) reset(key string) {
+	s.lockouts.Delete(key)
+	s.failedAttempts.Delete(key)
+}
@@ -0,0 +1,160 @@
+// Package ldap implements auth via an LDAP directory, such as Active Directory or OpenLDAP.
+package ldap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// All LDAP endpoints are under this path prefix.
+const authPrefix = auth.AuthURLPrefix + "/ldap"
+
+// Middleware is middleware for LDAP authentication, adding the sign-in endpoint under the auth path
+// prefix. LDAP users get a regular session once signed in, so there is nothing to do for other
+// requests.
+//
+// 🚨 SECURITY
+func Middleware(db database.DB) *auth.Middleware {
+	lockoutOptions := conf.AuthLockout()
+	return newMiddleware(db, newLockoutStore(
+		lockoutOptions.FailedAttemptThreshold,
+		time.Duration(lockoutOptions.LockoutPeriod)*time.Second,
+		time.Duration(lockoutOptions.ConsecutivePeriod)*time.Second,
+	))
+}
+
+func newMiddleware(db database.DB, lockouts lockoutStore) *auth.Middleware {
+	return &auth.Middleware{
+		API: func(next http.Handler) http.Handler {
+			return next
+		},
+		App: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == authPrefix+"/sign-in" {
+					signInHandler(db, lockouts)(w, r)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		},
+	}
+}
+
+// credentials is the body of a sign-in request.
+type credentials struct {
+	// ProviderID is the config ID of the LDAP auth provider. It may also be given in the pc query
+	// parameter, and may be omitted if there is only one.
+	ProviderID string `json:"pc"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+}
+
+// getProvider looks up the registered LDAP auth provider with the given ID. If id is empty and
+// there is exactly one LDAP auth provider, that provider is returned.
+func getProvider(id string) *provider {
+	if id != "" {
+		p, _ := providers.GetProviderByConfigID(providers.ConfigID{Type: providerType, ID: id}).(*provider)
+		return p
+	}
+
+	var found *provider
+	for _, p := range providers.Providers() {
+		if lp, ok := p.(*provider); ok {
+			if found != nil {
+				return nil
+			}
+			found = lp
+		}
+	}
+	return found
+}
+
+// signInHandler checks the posted credentials against the LDAP server, gets or creates the
+// corresponding user, and starts a session for them. Usernames with too many failed sign-in
+// attempts are locked out for a while.
+func signInHandler(db database.DB, lockouts lockoutStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("Unsupported method %s", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		var creds credentials
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+
+		if creds.ProviderID == "" {
+			creds.ProviderID = r.URL.Query().Get("pc")
+		}
+
+		p := getProvider(creds.ProviderID)
+		if p == nil {
+			log15.Error("No LDAP auth provider found with ID.", "id", creds.ProviderID)
+			http.Error(w, "Misconfigured LDAP auth provider.", http.StatusInternalServerError)
+			return
+		}
+
+		// 🚨 SECURITY: Check the lockout before contacting the LDAP server, so that passwords
+		// can't be guessed while a username is locked out.
+		lockoutKey := p.ConfigID().ID + ":" + strings.ToLower(creds.Username)
+		if lockouts.isLockedOut(lockoutKey) {
+			log15.Warn("LDAP sign-in attempt for locked out username.", "username", creds.Username)
+			http.Error(w, "Too many failed sign-in attempts. Try again later.", http.StatusTooManyRequests)
+			return
+		}
+
+		ctx := r.Context()
+		info, err := p.authenticate(ctx, creds.Username, creds.Password)
+		if errors.Is(err, errInvalidCredentials) {
+			lockouts.increaseFailedAttempt(lockoutKey)
+			log15.Warn("LDAP authentication failed.", "username", creds.Username)
+			http.Error(w, "Authentication failed", http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			log15.Error("Error authenticating with LDAP.", "id", p.ConfigID(), "username", creds.Username, "err", err)
+			http.Error(w, "Unexpected error authenticating with LDAP. Check the logs for more details.", http.StatusInternalServerError)
+			return
+		}
+
+		lockouts.reset(lockoutKey)
+
+		if !p.allowSignin(info) {
+			log15.Warn("Error authorizing LDAP-authenticated user.", "dn", info.DN, "Expected groups", p.config.AllowGroups, "Got", info.Groups)
+			http.Error(w, "Error authorizing LDAP-authenticated user. The user does not belong to one of the configured groups.", http.StatusForbidden)
+			return
+		}
+
+		actor, safeErrMsg, err := getOrCreateUser(ctx, db, p, info)
+		if err != nil {
+			log15.Error("Error looking up LDAP-authenticated user.", "err", err, "userErr", safeErrMsg)
+			http.Error(w, safeErrMsg, http.StatusInternalServerError)
+			return
+		}
+
+		user, err := db.Users().GetByID(ctx, actor.UID)
+		if err != nil {
+			log15.Error("Error retrieving LDAP-authenticated user from database.", "error", err)
+			http.Error(w, "Failed to retrieve user.", http.StatusInternalServerError)
+			return
+		}
+
+		if err := session.SetActor(w, r, actor, 0, user.CreatedAt); err != nil {
+			log15.Error("Error setting LDAP-authenticated actor in session.", "err", err)
+			http.Error(w, "Error starting LDAP-authenticated session. Try signing in again.", http.StatusInternalServerError)
+			return
+		}
+	}
+}
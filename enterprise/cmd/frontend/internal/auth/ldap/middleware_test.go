@@ -0,0 +1,154 @@
+package ldap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMiddleware(t *testing.T) {
+	server := newTestDirectory(t)
+
+	p := &provider{config: schema.LDAPAuthProvider{
+		Type:           providerType,
+		ConfigID:       "corp",
+		Url:            server.url(),
+		BindDN:         testBindDN,
+		BindPassword:   testBindPassword,
+		UserSearchBase: "ou=people,dc=example,dc=com",
+		AllowGroups:    []string{testEngineering},
+	}}
+	providers.MockProviders = []providers.Provider{p}
+	defer func() { providers.MockProviders = nil }()
+
+	cleanup := session.ResetMockSessionStore(t)
+	defer cleanup()
+
+	const mockedUserID = 123
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (userID int32, safeErrMsg string, err error) {
+		if op.ExternalAccount.ServiceType == "ldap" && op.ExternalAccount.ServiceID == server.url() && op.ExternalAccount.AccountID == "alice" {
+			return mockedUserID, "", nil
+		}
+		return 0, "safeErr", errors.Errorf("account %v not found in mock", op.ExternalAccount)
+	}
+	defer func() { auth.MockGetAndSaveUser = nil }()
+
+	users := database.NewStrictMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, CreatedAt: time.Now()}, nil
+	})
+	db := database.NewStrictMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	lockouts := newFakeLockoutStore(2)
+	handler := newMiddleware(db, lockouts).App(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("next"))
+	}))
+
+	doRequest := func(method, path, body string) *http.Response {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	t.Run("other paths are passed through", func(t *testing.T) {
+		resp := doRequest("GET", "/", "")
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+	})
+
+	t.Run("GET sign-in", func(t *testing.T) {
+		resp := doRequest("GET", "/.auth/ldap/sign-in", "")
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		path       string
+		body       string
+		wantStatus int
+	}{
+		"success":              {"", `{"username": "alice", "password": "alice-secret"}`, http.StatusOK},
+		"success with ID":      {"", `{"pc": "corp", "username": "alice", "password": "alice-secret"}`, http.StatusOK},
+		"unknown provider":     {"", `{"pc": "other", "username": "alice", "password": "alice-secret"}`, http.StatusInternalServerError},
+		"wrong password":       {"", `{"username": "alice", "password": "wrong"}`, http.StatusUnauthorized},
+		"not in allowed group": {"", `{"username": "bob", "password": "bob-secret"}`, http.StatusForbidden},
+		"invalid body":         {"", `{`, http.StatusBadRequest},
+		"ID in query":          {"?pc=corp", `{"username": "alice", "password": "alice-secret"}`, http.StatusOK},
+		"unknown ID in query":  {"?pc=other", `{"username": "alice", "password": "alice-secret"}`, http.StatusInternalServerError},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := doRequest("POST", "/.auth/ldap/sign-in"+tc.path, tc.body)
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+
+			hasSession := len(resp.Cookies()) > 0
+			if want := tc.wantStatus == http.StatusOK; hasSession != want {
+				t.Errorf("got session cookie %v, want %v", hasSession, want)
+			}
+		})
+	}
+
+	t.Run("lockout", func(t *testing.T) {
+		lockouts.failedAttempts = map[string]int{}
+
+		const wrong = `{"username": "Alice", "password": "wrong"}`
+		for i := 0; i < 2; i++ {
+			if resp := doRequest("POST", "/.auth/ldap/sign-in", wrong); resp.StatusCode != http.StatusUnauthorized {
+				t.Fatalf("got status %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+
+		// The username is locked out even with the right password.
+		resp := doRequest("POST", "/.auth/ldap/sign-in", `{"username": "alice", "password": "alice-secret"}`)
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+		}
+		if len(resp.Cookies()) > 0 {
+			t.Error("got session cookie for locked out username")
+		}
+
+		// Other usernames are not affected.
+		resp = doRequest("POST", "/.auth/ldap/sign-in", `{"username": "bob", "password": "bob-secret"}`)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusForbidden)
+		}
+	})
+}
+
+// fakeLockoutStore is an in-memory lockoutStore.
+type fakeLockoutStore struct {
+	failedThreshold int
+	failedAttempts  map[string]int
+}
+
+func newFakeLockoutStore(failedThreshold int) *fakeLockoutStore {
+	return &fakeLockoutStore{failedThreshold: failedThreshold, failedAttempts: map[string]int{}}
+}
+
+func (s *fakeLockoutStore) isLockedOut(key string) bool {
+	return s.failedAttempts[key] >= s.failedThreshold
+}
+
+func (s *fakeLockoutStore) increaseFailedAttempt(key string) {
+	s.failedAttempts[key]++
+}
+
+func (s *fakeLockoutStore) reset(key string) {
+	delete(s.failedAttempts, key)
+}
@@ -0,0 +1,225 @@
+package ldap
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// testEntry is an entry in the directory of a testServer.
+type testEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// testServer is a minimal in-process LDAP server, which supports simple binds and searches over a
+// fixed set of entries. Like many real servers, it accepts unauthenticated binds (a DN with an
+// empty password).
+type testServer struct {
+	t       *testing.T
+	ln      net.Listener
+	entries []*testEntry
+
+	// requireBind makes searches fail unless the connection is bound to an entry.
+	requireBind bool
+}
+
+func newTestServer(t *testing.T, entries ...*testEntry) *testServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testServer{t: t, ln: ln, entries: entries}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			nc, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(nc)
+		}
+	}()
+	return s
+}
+
+func (s *testServer) url() string {
+	return "ldap://" + s.ln.Addr().String()
+}
+
+func (s *testServer) serve(nc net.Conn) {
+	defer nc.Close()
+
+	r := bufio.NewReader(nc)
+	var bound string
+	for {
+		msg, err := ber.ReadPacket(r)
+		if err != nil {
+			return
+		}
+		if len(msg.Children) < 2 {
+			s.t.Errorf("test server: malformed message")
+			return
+		}
+		id := msg.Children[0].Value
+		op := msg.Children[1]
+
+		write := func(op *ber.Packet) {
+			envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+			envelope.AppendChild(op)
+			_, _ = nc.Write(envelope.Bytes())
+		}
+		result := func(tag ber.Tag, code int64) *ber.Packet {
+			p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+			p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+			p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+			p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+			return p
+		}
+
+		switch op.Tag {
+		case goldap.ApplicationBindRequest:
+			code, dn := s.bind(op)
+			if code == goldap.LDAPResultSuccess {
+				bound = dn
+			}
+			write(result(goldap.ApplicationBindResponse, code))
+
+		case goldap.ApplicationSearchRequest:
+			if s.requireBind && bound == "" {
+				write(result(goldap.ApplicationSearchResultDone, goldap.LDAPResultInsufficientAccessRights))
+				continue
+			}
+			entries, code := s.search(op)
+			for _, e := range entries {
+				write(e)
+			}
+			write(result(goldap.ApplicationSearchResultDone, code))
+
+		case goldap.ApplicationUnbindRequest:
+			return
+
+		default:
+			write(result(goldap.ApplicationExtendedResponse, goldap.LDAPResultProtocolError))
+		}
+	}
+}
+
+func (s *testServer) bind(op *ber.Packet) (code int64, dn string) {
+	if len(op.Children) != 3 {
+		return goldap.LDAPResultProtocolError, ""
+	}
+	dn, password := packetString(op.Children[1]), packetString(op.Children[2])
+
+	if password == "" {
+		// Anonymous or unauthenticated bind.
+		return goldap.LDAPResultSuccess, ""
+	}
+	for _, e := range s.entries {
+		if strings.EqualFold(e.dn, dn) && e.password == password {
+			return goldap.LDAPResultSuccess, e.dn
+		}
+	}
+	return goldap.LDAPResultInvalidCredentials, ""
+}
+
+func (s *testServer) search(op *ber.Packet) (entries []*ber.Packet, code int64) {
+	if len(op.Children) != 8 {
+		return nil, goldap.LDAPResultProtocolError
+	}
+	base := strings.ToLower(packetString(op.Children[0]))
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+	attrs := op.Children[7].Children
+
+	for _, e := range s.entries {
+		if !strings.HasSuffix(strings.ToLower(e.dn), base) || !matches(filter, e) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(entries)) == sizeLimit {
+			return entries, goldap.LDAPResultSizeLimitExceeded
+		}
+
+		encodedAttrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+		for _, a := range attrs {
+			for name, values := range e.attrs {
+				if !strings.EqualFold(name, packetString(a)) {
+					continue
+				}
+				attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+				attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+				vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+				for _, v := range values {
+					vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+				}
+				attr.AppendChild(vals)
+				encodedAttrs.AppendChild(attr)
+			}
+		}
+		entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+		entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "DN"))
+		entry.AppendChild(encodedAttrs)
+		entries = append(entries, entry)
+	}
+	return entries, goldap.LDAPResultSuccess
+}
+
+// matches evaluates a BER encoded filter against an entry. Only the filters used in tests are
+// supported, and values are compared case-insensitively.
+func matches(f *ber.Packet, e *testEntry) bool {
+	valuesOf := func(attr string) []string {
+		for name, vs := range e.attrs {
+			if strings.EqualFold(name, attr) {
+				return vs
+			}
+		}
+		return nil
+	}
+
+	switch f.Tag {
+	case goldap.FilterAnd:
+		for _, c := range f.Children {
+			if !matches(c, e) {
+				return false
+			}
+		}
+		return true
+	case goldap.FilterOr:
+		for _, c := range f.Children {
+			if matches(c, e) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterNot:
+		return !matches(f.Children[0], e)
+	case goldap.FilterPresent:
+		return len(valuesOf(packetString(f))) > 0
+	case goldap.FilterEqualityMatch:
+		for _, v := range valuesOf(packetString(f.Children[0])) {
+			if strings.EqualFold(v, packetString(f.Children[1])) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// packetString returns the contents of a primitive packet as a string.
+func packetString(p *ber.Packet) string {
+	if s, ok := p.Value.(string); ok {
+		return s
+	}
+	return p.Data.String()
+}
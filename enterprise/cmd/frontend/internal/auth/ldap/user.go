@@ -0,0 +1,179 @@
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// timeout bounds the time spent talking to the LDAP server for a single sign-in attempt.
+const timeout = 30 * time.Second
+
+var errInvalidCredentials = errors.New("invalid LDAP username or password")
+
+// userInfo is the information about an authenticated LDAP user. It is stored as the data of the
+// user's external account.
+type userInfo struct {
+	DN          string   `json:"dn"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Groups      []string `json:"groups,omitempty"`
+}
+
+// authenticate verifies the username and password against the LDAP server. It returns
+// errInvalidCredentials if the user doesn't exist or the password is wrong.
+//
+// The user's entry is searched for (as the bindDN user, or anonymously), and the password is then
+// checked by binding as that entry.
+func (p *provider) authenticate(ctx context.Context, username, password string) (*userInfo, error) {
+	// 🚨 SECURITY: A simple bind with a DN but an empty password is an "unauthenticated bind"
+	// (RFC 4513 section 5.1.2), which many servers report as successful without checking
+	// anything. Never let one through.
+	if username == "" || password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	tlsConfig, err := p.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c, err := dial(ctx, p.config.Url, p.config.StartTLS, tlsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to LDAP server")
+	}
+	defer c.Close()
+
+	if p.config.BindDN != "" {
+		if err := c.Bind(p.config.BindDN, p.config.BindPassword); err != nil {
+			return nil, errors.Wrap(err, "binding as bindDN")
+		}
+	}
+
+	usernameAttr := usernameAttribute(&p.config)
+	result, err := c.Search(goldap.NewSearchRequest(
+		p.config.UserSearchBase,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		// We only need to know whether there is more than one match.
+		2,
+		0,
+		false,
+		userFilter(userSearchFilter(&p.config), usernameAttr, username),
+		[]string{
+			usernameAttr,
+			emailAttribute(&p.config),
+			displayNameAttribute(&p.config),
+			groupsAttributeName(&p.config),
+		},
+		nil,
+	))
+	if goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) || (result != nil && len(result.Entries) > 1) {
+		return nil, errors.Errorf("more than one LDAP entry matches username %q", username)
+	} else if err != nil {
+		return nil, errors.Wrap(err, "searching for user")
+	}
+	if len(result.Entries) == 0 {
+		return nil, errInvalidCredentials
+	}
+	e := result.Entries[0]
+
+	// 🚨 SECURITY: Check the password by binding as the user.
+	if err := c.Bind(e.DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, errInvalidCredentials
+		}
+		return nil, errors.Wrap(err, "binding as user")
+	}
+
+	info := &userInfo{
+		DN:          e.DN,
+		Username:    e.GetEqualFoldAttributeValue(usernameAttr),
+		Email:       e.GetEqualFoldAttributeValue(emailAttribute(&p.config)),
+		DisplayName: e.GetEqualFoldAttributeValue(displayNameAttribute(&p.config)),
+		Groups:      e.GetEqualFoldAttributeValues(groupsAttributeName(&p.config)),
+	}
+	if len(info.Groups) == 0 {
+		info.Groups = nil
+	}
+	if info.Username == "" {
+		// Some servers don't return attributes used in the filter if the user can't read them.
+		info.Username = username
+	}
+	return info, nil
+}
+
+// allowSignin reports whether the user is a member of one of the groups that are allowed to sign
+// in, if any. Group DNs are compared case-insensitively.
+func (p *provider) allowSignin(info *userInfo) bool {
+	if len(p.config.AllowGroups) == 0 {
+		return true
+	}
+
+	for _, allowed := range p.config.AllowGroups {
+		for _, group := range info.Groups {
+			if strings.EqualFold(strings.TrimSpace(allowed), strings.TrimSpace(group)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getOrCreateUser gets or creates a user account based on the authenticated LDAP user. It returns
+// the authenticated actor if successful; otherwise it returns an friendly error message
+// (safeErrMsg) that is safe to display to users, and a non-nil err with lower-level error details.
+func getOrCreateUser(ctx context.Context, db database.DB, p *provider, info *userInfo) (_ *actor.Actor, safeErrMsg string, err error) {
+	login, err := auth.NormalizeUsername(info.Username)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", info.Username), err
+	}
+
+	serialized, err := json.Marshal(info)
+	if err != nil {
+		return nil, "", err
+	}
+
+	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, db, auth.GetAndSaveUserOp{
+		UserProps: database.NewUser{
+			Username: login,
+			Email:    info.Email,
+			// The directory is managed by the organization, so we trust the email addresses in it.
+			EmailIsVerified: info.Email != "",
+			DisplayName:     info.DisplayName,
+		},
+		ExternalAccount: extsvc.AccountSpec{
+			ServiceType: providerType,
+			ServiceID:   p.config.Url,
+			// Use the username as returned by the server rather than as entered, since LDAP
+			// usernames are usually case-insensitive.
+			AccountID: info.Username,
+		},
+		ExternalAccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(serialized),
+		},
+		// 🚨 SECURITY: Existing users are only matched by their external account or by the
+		// verified email from the directory. Never look them up by username, which would let an
+		// LDAP user sign in as any Sourcegraph user with the same username, such as a local site
+		// admin. Without a match, sign-in fails unless a new user may be created.
+		CreateIfNotExist: p.config.AllowSignup == nil || *p.config.AllowSignup,
+	})
+	if err != nil {
+		return nil, safeErrMsg, err
+	}
+	return actor.FromUser(userID), "", nil
+}
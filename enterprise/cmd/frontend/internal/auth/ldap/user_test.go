@@ -0,0 +1,277 @@
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	testBindDN       = "cn=sourcegraph,ou=services,dc=example,dc=com"
+	testBindPassword = "service-secret"
+	testEngineering  = "cn=engineering,ou=groups,dc=example,dc=com"
+)
+
+func newTestDirectory(t *testing.T) *testServer {
+	return newTestServer(t,
+		&testEntry{
+			dn:       testBindDN,
+			password: testBindPassword,
+			attrs:    map[string][]string{"objectClass": {"applicationProcess"}, "cn": {"sourcegraph"}},
+		},
+		&testEntry{
+			dn:       "uid=alice,ou=people,dc=example,dc=com",
+			password: "alice-secret",
+			attrs: map[string][]string{
+				"objectClass": {"person"},
+				"uid":         {"alice"},
+				"mail":        {"alice@example.com"},
+				"cn":          {"Alice Example"},
+				"memberOf":    {testEngineering},
+			},
+		},
+		&testEntry{
+			dn:       "uid=bob,ou=people,dc=example,dc=com",
+			password: "bob-secret",
+			attrs: map[string][]string{
+				"objectClass": {"person"},
+				"uid":         {"bob"},
+				"cn":          {"Bob Example"},
+			},
+		},
+		// Two entries with the same username.
+		&testEntry{
+			dn:       "uid=carol,ou=people,dc=example,dc=com",
+			password: "carol-secret",
+			attrs:    map[string][]string{"objectClass": {"person"}, "uid": {"carol"}},
+		},
+		&testEntry{
+			dn:       "uid=carol,ou=contractors,dc=example,dc=com",
+			password: "carol-secret",
+			attrs:    map[string][]string{"objectClass": {"person"}, "uid": {"carol"}},
+		},
+	)
+}
+
+func TestAuthenticate(t *testing.T) {
+	server := newTestDirectory(t)
+	server.requireBind = true
+
+	p := &provider{config: schema.LDAPAuthProvider{
+		Type:           providerType,
+		Url:            server.url(),
+		BindDN:         testBindDN,
+		BindPassword:   testBindPassword,
+		UserSearchBase: "dc=example,dc=com",
+	}}
+	ctx := context.Background()
+
+	t.Run("valid credentials", func(t *testing.T) {
+		info, err := p.authenticate(ctx, "ALICE", "alice-secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &userInfo{
+			DN:          "uid=alice,ou=people,dc=example,dc=com",
+			Username:    "alice",
+			Email:       "alice@example.com",
+			DisplayName: "Alice Example",
+			Groups:      []string{testEngineering},
+		}
+		if diff := cmp.Diff(want, info); diff != "" {
+			t.Errorf("unexpected user info (-want +got):\n%s", diff)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		username, password string
+	}{
+		"wrong password":   {"alice", "bob-secret"},
+		"unknown user":     {"dave", "alice-secret"},
+		"empty password":   {"alice", ""},
+		"empty username":   {"", "alice-secret"},
+		"filter wildcard":  {"*", "alice-secret"},
+		"filter injection": {"alice)(uid=*", "alice-secret"},
+		"search user":      {"sourcegraph", testBindPassword},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := p.authenticate(ctx, tc.username, tc.password)
+			if err != errInvalidCredentials {
+				t.Errorf("got error %v, want %v", err, errInvalidCredentials)
+			}
+		})
+	}
+
+	t.Run("ambiguous username", func(t *testing.T) {
+		_, err := p.authenticate(ctx, "carol", "carol-secret")
+		if err == nil || err == errInvalidCredentials {
+			t.Errorf("got error %v, want an error about multiple entries", err)
+		}
+	})
+
+	t.Run("custom attributes", func(t *testing.T) {
+		p := &provider{config: p.config}
+		p.config.UsernameAttribute = "cn"
+		p.config.EmailAttribute = "uid"
+		p.config.DisplayNameAttribute = "mail"
+
+		info, err := p.authenticate(ctx, "Bob Example", "bob-secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &userInfo{
+			DN:          "uid=bob,ou=people,dc=example,dc=com",
+			Username:    "Bob Example",
+			Email:       "bob",
+			DisplayName: "",
+		}
+		if diff := cmp.Diff(want, info); diff != "" {
+			t.Errorf("unexpected user info (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("user search filter", func(t *testing.T) {
+		p := &provider{config: p.config}
+		p.config.UserSearchFilter = "(memberOf=" + testEngineering + ")"
+
+		if _, err := p.authenticate(ctx, "alice", "alice-secret"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := p.authenticate(ctx, "bob", "bob-secret"); err != errInvalidCredentials {
+			t.Errorf("got error %v, want %v", err, errInvalidCredentials)
+		}
+	})
+
+	t.Run("wrong bind password", func(t *testing.T) {
+		p := &provider{config: p.config}
+		p.config.BindPassword = "wrong"
+
+		_, err := p.authenticate(ctx, "alice", "alice-secret")
+		if err == nil || err == errInvalidCredentials {
+			t.Errorf("got error %v, want a bind error", err)
+		}
+	})
+
+	t.Run("anonymous search", func(t *testing.T) {
+		server := newTestDirectory(t)
+		p := &provider{config: schema.LDAPAuthProvider{
+			Type:           providerType,
+			Url:            server.url(),
+			UserSearchBase: "ou=people,dc=example,dc=com",
+		}}
+
+		if _, err := p.authenticate(ctx, "alice", "alice-secret"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestAllowSignin(t *testing.T) {
+	info := &userInfo{Groups: []string{"CN=Engineering,OU=Groups,DC=example,DC=com"}}
+
+	for name, tc := range map[string]struct {
+		allowGroups []string
+		want        bool
+	}{
+		"no restrictions":  {nil, true},
+		"member":           {[]string{"cn=sales,ou=groups,dc=example,dc=com", testEngineering}, true},
+		"not a member":     {[]string{"cn=sales,ou=groups,dc=example,dc=com"}, false},
+		"empty allow list": {[]string{}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := &provider{config: schema.LDAPAuthProvider{AllowGroups: tc.allowGroups}}
+			if got := p.allowSignin(info); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetOrCreateUser(t *testing.T) {
+	p := &provider{config: schema.LDAPAuthProvider{Url: "ldap://ldap.example.com"}}
+	info := &userInfo{
+		DN:          "uid=alice,ou=people,dc=example,dc=com",
+		Username:    "alice",
+		Email:       "alice@example.com",
+		DisplayName: "Alice Example",
+	}
+
+	var got auth.GetAndSaveUserOp
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (int32, string, error) {
+		got = op
+		return 1, "", nil
+	}
+	defer func() { auth.MockGetAndSaveUser = nil }()
+
+	a, _, err := getOrCreateUser(context.Background(), database.NewMockDB(), p, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.UID != 1 {
+		t.Errorf("got UID %d, want 1", a.UID)
+	}
+
+	want := auth.GetAndSaveUserOp{
+		UserProps: database.NewUser{
+			Username:        "alice",
+			Email:           "alice@example.com",
+			EmailIsVerified: true,
+			DisplayName:     "Alice Example",
+		},
+		ExternalAccount: extsvc.AccountSpec{
+			ServiceType: "ldap",
+			ServiceID:   "ldap://ldap.example.com",
+			AccountID:   "alice",
+		},
+		CreateIfNotExist: true,
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(auth.GetAndSaveUserOp{}, "ExternalAccountData")); diff != "" {
+		t.Errorf("unexpected op (-want +got):\n%s", diff)
+	}
+
+	data, err := got.ExternalAccountData.Data.Decrypt(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	serialized, _ := json.Marshal(data)
+	var gotInfo userInfo
+	if err := json.Unmarshal(serialized, &gotInfo); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(info, &gotInfo); diff != "" {
+		t.Errorf("unexpected account data (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetOrCreateUser_NoEmail(t *testing.T) {
+	p := &provider{config: schema.LDAPAuthProvider{Url: "ldap://ldap.example.com"}}
+	info := &userInfo{DN: "uid=admin,ou=people,dc=example,dc=com", Username: "admin"}
+
+	var got auth.GetAndSaveUserOp
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (int32, string, error) {
+		got = op
+		return 1, "", nil
+	}
+	defer func() { auth.MockGetAndSaveUser = nil }()
+
+	if _, _, err := getOrCreateUser(context.Background(), database.NewMockDB(), p, info); err != nil {
+		t.Fatal(err)
+	}
+
+	// 🚨 SECURITY: An entry without an email must never be linked to an existing user with the
+	// same username.
+	if got.LookUpByUsername {
+		t.Error("got LookUpByUsername, want lookup by external account only")
+	}
+	if got.UserProps.EmailIsVerified {
+		t.Error("got EmailIsVerified for an entry without email")
+	}
+}
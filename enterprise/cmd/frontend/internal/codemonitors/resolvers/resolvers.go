@@ -971,9 +971,46 @@ func (m *monitorTriggerEvent) ResultCount() int32 {
 	for _, cm := range m.TriggerJob.SearchResults {
 		count += cm.ResultCount()
 	}
+	count += len(m.TriggerJob.FileResults)
 	return int32(count)
 }
 
+func (m *monitorTriggerEvent) FileResults() []graphqlbackend.MonitorFileResultResolver {
+	results := make([]graphqlbackend.MonitorFileResultResolver, 0, len(m.TriggerJob.FileResults))
+	for _, change := range m.TriggerJob.FileResults {
+		results = append(results, &monitorFileResult{change: change})
+	}
+	return results
+}
+
+// MonitorFileResult
+type monitorFileResult struct {
+	change *edb.FileMatchChange
+}
+
+func (r *monitorFileResult) RepositoryName() string {
+	return string(r.change.Repo.Name)
+}
+
+func (r *monitorFileResult) Commit() string {
+	return string(r.change.CommitID)
+}
+
+func (r *monitorFileResult) Path() string {
+	return r.change.Path
+}
+
+func (r *monitorFileResult) Removed() bool {
+	return r.change.Removed
+}
+
+func (r *monitorFileResult) Preview() *string {
+	if r.change.Preview == nil {
+		return nil
+	}
+	return &r.change.Preview.Content
+}
+
 func (m *monitorTriggerEvent) Message() *string {
 	return m.FailureMessage
 }
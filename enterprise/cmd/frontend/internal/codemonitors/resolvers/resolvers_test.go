@@ -1323,3 +1323,46 @@ func TestValidateSlackURL(t *testing.T) {
 		require.Error(t, validateSlackURL(url))
 	}
 }
+
+func TestMonitorTriggerEventFileResults(t *testing.T) {
+	event := &monitorTriggerEvent{
+		TriggerJob: &edb.TriggerJob{
+			FileResults: []*edb.FileMatchChange{
+				{
+					Repo:     types.MinimalRepo{ID: 1, Name: "github.com/foo/bar"},
+					CommitID: "deadbeef",
+					Path:     "main.go",
+					Preview:  &result.MatchedString{Content: "func main() {}"},
+				},
+				{
+					Repo:     types.MinimalRepo{ID: 1, Name: "github.com/foo/bar"},
+					CommitID: "deadbeef",
+					Path:     "old.go",
+					Removed:  true,
+				},
+			},
+		},
+	}
+
+	type fileResult struct {
+		RepositoryName string
+		Commit         string
+		Path           string
+		Removed        bool
+		Preview        *string
+	}
+	var got []fileResult
+	for _, r := range event.FileResults() {
+		got = append(got, fileResult{r.RepositoryName(), r.Commit(), r.Path(), r.Removed(), r.Preview()})
+	}
+
+	preview := "func main() {}"
+	want := []fileResult{
+		{"github.com/foo/bar", "deadbeef", "main.go", false, &preview},
+		{"github.com/foo/bar", "deadbeef", "old.go", true, nil},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected file results (-want +got):\n%s", diff)
+	}
+	require.Equal(t, int32(2), event.ResultCount())
+}
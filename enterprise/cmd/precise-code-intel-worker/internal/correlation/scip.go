@@ -0,0 +1,469 @@
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion/datastructures"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol/reader"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// IsSCIP returns true if the given reader looks like it contains a SCIP index rather than
+// newline-delimited LSIF JSON. The reader is not advanced.
+//
+// An LSIF upload always begins with a JSON object (possibly preceded by whitespace). A SCIP
+// index is a protobuf-encoded scip.Index message, which begins with the tag of one of the
+// metadata, documents, or external symbols fields.
+//
+// The metadata tag is the byte '\n', so an index beginning with metadata is only told apart
+// from LSIF preceded by a newline by decoding the metadata message that follows the tag.
+func IsSCIP(r *bufio.Reader) bool {
+	// Peek returns a short buffer along with an error for small inputs
+	prefix, _ := r.Peek(sniffLength)
+	if len(prefix) == 0 {
+		return false
+	}
+
+	switch prefix[0] {
+	case scipDocumentsTag, scipExternalSymbolsTag:
+		return true
+	case scipMetadataTag:
+		if isSCIPMetadata(prefix[1:]) {
+			return true
+		}
+	}
+
+	if trimmed := bytes.TrimLeft(prefix, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '{' {
+		return false
+	}
+
+	return prefix[0] == scipMetadataTag
+}
+
+// isSCIPMetadata returns true if the given buffer begins with a length-prefixed scip.Metadata
+// message that fits within the buffer and contains only known fields.
+func isSCIPMetadata(buf []byte) bool {
+	length, n := protowire.ConsumeVarint(buf)
+	if n < 0 || length > uint64(len(buf)-n) {
+		return false
+	}
+
+	var metadata scip.Metadata
+	if err := proto.Unmarshal(buf[n:n+int(length)], &metadata); err != nil {
+		return false
+	}
+
+	return len(metadata.ProtoReflect().GetUnknown()) == 0
+}
+
+const (
+	// sniffLength is the maximum number of bytes inspected to determine the upload format.
+	sniffLength = 512
+
+	// Protobuf tags (field number << 3 | wire type 2) of the top-level fields of scip.Index.
+	scipMetadataTag        = 1<<3 | 2
+	scipDocumentsTag       = 2<<3 | 2
+	scipExternalSymbolsTag = 3<<3 | 2
+)
+
+// CorrelateSCIP reads a SCIP index from the given reader and returns the same data grouped
+// for storage. The output has the same shape as the output of conversion.Correlate for an
+// LSIF upload of the same code, but is built directly from the SCIP index without first
+// re-encoding it as LSIF.
+//
+// If getChildren == nil, no pruning of irrelevant data is performed.
+func CorrelateSCIP(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading SCIP index")
+	}
+
+	var index scip.Index
+	if err := proto.Unmarshal(content, &index); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling SCIP index")
+	}
+
+	state, err := correlateIndex(&index, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return conversion.CorrelateState(ctx, state, root, getChildren)
+}
+
+// correlateIndex returns a correlation state populated from the given SCIP index. The data in the
+// correlation state is neither canonicalized nor pruned.
+func correlateIndex(index *scip.Index, root string) (*conversion.State, error) {
+	if index.Metadata == nil {
+		return nil, conversion.ErrMissingMetaData
+	}
+
+	s := newSCIPState(index.Metadata.ProjectRoot, root)
+
+	// Pass 1: create result sets for global symbols.
+	for _, info := range index.ExternalSymbols {
+		s.globalResultSets[info.Symbol] = s.addResultSet(info, precise.Import)
+	}
+	for _, document := range index.Documents {
+		for _, info := range document.Symbols {
+			s.registerInverseRelationships(info)
+
+			if scip.IsGlobalSymbol(info.Symbol) {
+				// Local symbols are handled in the second pass when processing individual documents
+				s.globalResultSets[info.Symbol] = s.addResultSet(info, precise.Export)
+			}
+		}
+	}
+
+	// Pass 2: add ranges for all documents.
+	for _, document := range index.Documents {
+		if err := s.addDocument(index.Metadata.ProjectRoot, document); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.State, nil
+}
+
+// scipState wraps a correlation state with the bookkeeping necessary to translate SCIP symbols
+// into the result set, result, and moniker identifiers used by the correlation state.
+type scipState struct {
+	*conversion.State
+	id                   int
+	globalResultSets     map[string]*resultSetIDs
+	inverseRelationships map[string][]*scip.Relationship
+	packageIDs           map[string]int
+}
+
+// resultSetIDs holds the correlation state identifiers related to a single SCIP symbol.
+type resultSetIDs struct {
+	resultSetID            int
+	definitionResultID     int
+	referenceResultID      int
+	implementationResultID int
+}
+
+func newSCIPState(projectRoot, root string) *scipState {
+	state := conversion.NewState()
+	state.LSIFVersion = "scip"
+	state.ProjectRoot = normalizeProjectRoot(projectRoot, root)
+
+	return &scipState{
+		State:                state,
+		globalResultSets:     map[string]*resultSetIDs{},
+		inverseRelationships: map[string][]*scip.Relationship{},
+		packageIDs:           map[string]int{},
+	}
+}
+
+// normalizeProjectRoot mirrors the project root handling of LSIF metadata vertices. We assume
+// that the project root is either the root of the index or the root of the repository, and
+// normalize to the former by appending the upload root when it's not already a suffix.
+func normalizeProjectRoot(projectRoot, root string) string {
+	if !strings.HasSuffix(projectRoot, "/") {
+		projectRoot += "/"
+	}
+
+	if root != "" && !strings.HasSuffix(projectRoot, "/"+root) {
+		projectRoot += root
+	}
+
+	return projectRoot
+}
+
+func (s *scipState) nextID() int {
+	s.id++
+	return s.id
+}
+
+// addResultSet creates a result set for the given symbol along with its reference and hover results,
+// and a definition result if the symbol is defined within the index. Import and export monikers are
+// attached to the result set.
+func (s *scipState) addResultSet(info *scip.SymbolInformation, monikerKind string) *resultSetIDs {
+	if ids, ok := s.globalResultSets[info.Symbol]; ok {
+		return ids
+	}
+
+	ids := &resultSetIDs{
+		resultSetID:       s.nextID(),
+		referenceResultID: s.nextID(),
+	}
+	s.ReferenceData[ids.referenceResultID] = datastructures.NewDefaultIDSetMap()
+
+	if monikerKind == precise.Export || monikerKind == precise.Local {
+		ids.definitionResultID = s.nextID()
+		s.DefinitionData[ids.definitionResultID] = datastructures.NewDefaultIDSetMap()
+	}
+
+	resultSet := conversion.ResultSet{
+		DefinitionResultID: ids.definitionResultID,
+		ReferenceResultID:  ids.referenceResultID,
+	}
+
+	// NOTE: SCIP splits documentation into separate sections, whereas LSIF indexers are expected
+	// to join them themselves. We merge the sections with a horizontal Markdown rule.
+	if hover := strings.Join(info.Documentation, "\n\n---\n\n"); hover != "" {
+		resultSet.HoverResultID = s.nextID()
+		s.HoverData[resultSet.HoverResultID] = hover
+	}
+
+	s.ResultSetData[ids.resultSetID] = resultSet
+
+	if monikerKind == precise.Export || monikerKind == precise.Import {
+		s.addMoniker(info.Symbol, monikerKind, ids.resultSetID)
+	}
+
+	return ids
+}
+
+// getOrAddResultSet returns the result set identifiers for the given symbol. Symbols that are not
+// defined in the index nor declared as an external symbol are treated as imported.
+func (s *scipState) getOrAddResultSet(symbol string, localResultSets map[string]*resultSetIDs) *resultSetIDs {
+	resultSets := s.globalResultSets
+	if scip.IsLocalSymbol(symbol) {
+		resultSets = localResultSets
+	}
+
+	ids, ok := resultSets[symbol]
+	if !ok {
+		ids = s.addResultSet(&scip.SymbolInformation{Symbol: symbol}, precise.Import)
+		resultSets[symbol] = ids
+	}
+
+	return ids
+}
+
+// addMoniker attaches a moniker with the given kind to the given result set, as well as package
+// information if the symbol identifies its package.
+func (s *scipState) addMoniker(symbolID, kind string, resultSetID int) {
+	symbol, err := scip.ParsePartialSymbol(symbolID, false)
+	if err != nil || symbol == nil || symbol.Scheme == "" {
+		// Silently ignore symbols that are missing the scheme. We can still provide accurate
+		// definitions, references, and hovers within the index, just not across indexes.
+		return
+	}
+
+	scheme := symbol.Scheme
+	if symbol.Package != nil {
+		// NOTE: the codeintel backend uses the scheme of a moniker where it should be using the
+		// manager of its package information. Keep the schemes used by the LSIF indexers.
+		switch symbol.Scheme {
+		case "scip-java", "lsif-java":
+			scheme = "semanticdb"
+		case "scip-typescript", "lsif-typescript":
+			scheme = "npm"
+		}
+	}
+
+	monikerID := s.nextID()
+	moniker := conversion.Moniker{
+		Moniker: reader.Moniker{
+			Kind:       kind,
+			Scheme:     scheme,
+			Identifier: symbolID,
+		},
+	}
+
+	if pkg := symbol.Package; pkg != nil && pkg.Manager != "" && pkg.Name != "" && pkg.Version != "" {
+		moniker.PackageInformationID = s.addPackageInformation(pkg)
+
+		switch kind {
+		case precise.Import:
+			s.ImportedMonikers.Add(monikerID)
+		case precise.Export:
+			s.ExportedMonikers.Add(monikerID)
+		case precise.Implementation:
+			s.ImplementedMonikers.Add(monikerID)
+		}
+	}
+
+	s.MonikerData[monikerID] = moniker
+	s.Monikers.AddID(resultSetID, monikerID)
+}
+
+func (s *scipState) addPackageInformation(pkg *scip.Package) int {
+	key := pkg.ID()
+	if id, ok := s.packageIDs[key]; ok {
+		return id
+	}
+
+	id := s.nextID()
+	s.packageIDs[key] = id
+	s.PackageInformationData[id] = conversion.PackageInformation{
+		Name:    pkg.Name,
+		Version: pkg.Version,
+		Manager: pkg.Manager,
+	}
+
+	return id
+}
+
+// addDocument adds the ranges and diagnostics of all occurrences in the given document, and
+// links each range to the result set of its symbol.
+func (s *scipState) addDocument(projectRoot string, document *scip.Document) error {
+	relativePath, err := filepath.Rel(s.ProjectRoot, filepath.Join(projectRoot, document.RelativePath))
+	if err != nil {
+		return errors.Errorf("document path %q is not relative to project root %q (%s)", document.RelativePath, s.ProjectRoot, err)
+	}
+
+	documentID := s.nextID()
+	s.DocumentData[documentID] = relativePath
+
+	symbols := make(map[string]*scip.SymbolInformation, len(document.Symbols))
+	localResultSets := map[string]*resultSetIDs{}
+
+	for _, info := range document.Symbols {
+		symbols[info.Symbol] = info
+
+		if scip.IsLocalSymbol(info.Symbol) {
+			localResultSets[info.Symbol] = s.addResultSet(info, precise.Local)
+		}
+
+		// Attach implementation monikers for implemented symbols that are defined outside of the index
+		for _, relationship := range info.Relationships {
+			if !relationship.IsImplementation {
+				continue
+			}
+			if relationshipIDs := s.getOrAddResultSet(relationship.Symbol, localResultSets); relationshipIDs.definitionResultID != 0 {
+				continue
+			}
+
+			ids := s.getOrAddResultSet(info.Symbol, localResultSets)
+			s.addMoniker(relationship.Symbol, precise.Implementation, ids.resultSetID)
+		}
+	}
+
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" && len(occurrence.Diagnostics) == 0 {
+			// Nothing to attach to this range (e.g. syntax highlighting data)
+			continue
+		}
+
+		rangeData, err := interpretSCIPRange(occurrence.Range)
+		if err != nil {
+			// Silently skip invalid ranges
+			continue
+		}
+
+		rangeID := s.nextID()
+		s.RangeData[rangeID] = conversion.Range{Range: reader.Range{RangeData: rangeData}}
+		s.Contains.AddID(documentID, rangeID)
+
+		if len(occurrence.Diagnostics) > 0 {
+			s.addDiagnostics(documentID, rangeData, occurrence.Diagnostics)
+		}
+
+		if occurrence.Symbol == "" {
+			continue
+		}
+
+		ids := s.getOrAddResultSet(occurrence.Symbol, localResultSets)
+		s.NextData[rangeID] = ids.resultSetID
+
+		if occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) != 0 && ids.definitionResultID != 0 {
+			s.DefinitionData[ids.definitionResultID].AddID(documentID, rangeID)
+
+			if info, ok := symbols[occurrence.Symbol]; ok {
+				s.addRelationships(documentID, rangeID, ids, localResultSets, info)
+			}
+		}
+
+		s.ReferenceData[ids.referenceResultID].AddID(documentID, rangeID)
+	}
+
+	return nil
+}
+
+// addRelationships links the given definition range to the implementation and reference results
+// of the symbols related to the given symbol (in both directions).
+func (s *scipState) addRelationships(documentID, rangeID int, ids *resultSetIDs, localResultSets map[string]*resultSetIDs, info *scip.SymbolInformation) {
+	relationships := append(append([]*scip.Relationship(nil), s.inverseRelationships[info.Symbol]...), info.Relationships...)
+
+	for _, relationship := range relationships {
+		relationshipIDs := s.getOrAddResultSet(relationship.Symbol, localResultSets)
+
+		if relationship.IsImplementation {
+			if relationshipIDs.implementationResultID == 0 {
+				relationshipIDs.implementationResultID = s.nextID()
+				s.ImplementationData[relationshipIDs.implementationResultID] = datastructures.NewDefaultIDSetMap()
+
+				resultSet := s.ResultSetData[relationshipIDs.resultSetID]
+				s.ResultSetData[relationshipIDs.resultSetID] = resultSet.SetImplementationResultID(relationshipIDs.implementationResultID)
+			}
+
+			s.ImplementationData[relationshipIDs.implementationResultID].AddID(documentID, rangeID)
+		}
+
+		if relationship.IsReference {
+			s.ReferenceData[relationshipIDs.referenceResultID].AddID(documentID, rangeID)
+			s.LinkedReferenceResults[ids.referenceResultID] = append(s.LinkedReferenceResults[ids.referenceResultID], relationshipIDs.referenceResultID)
+		}
+	}
+}
+
+func (s *scipState) addDiagnostics(documentID int, rangeData protocol.RangeData, diagnostics []*scip.Diagnostic) {
+	diagnosticResult := make([]conversion.Diagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		diagnosticResult = append(diagnosticResult, conversion.Diagnostic{
+			Severity:       int(diagnostic.Severity),
+			Code:           diagnostic.Code,
+			Message:        diagnostic.Message,
+			Source:         diagnostic.Source,
+			StartLine:      rangeData.Start.Line,
+			StartCharacter: rangeData.Start.Character,
+			EndLine:        rangeData.End.Line,
+			EndCharacter:   rangeData.End.Character,
+		})
+	}
+
+	diagnosticResultID := s.nextID()
+	s.DiagnosticResults[diagnosticResultID] = diagnosticResult
+	s.Diagnostics.AddID(documentID, diagnosticResultID)
+}
+
+// registerInverseRelationships records the relationships of the given symbol in the opposite
+// direction. For example, if a struct implements an interface, we'll record that the interface
+// is implemented by the struct.
+func (s *scipState) registerInverseRelationships(info *scip.SymbolInformation) {
+	for _, relationship := range info.Relationships {
+		s.inverseRelationships[relationship.Symbol] = append(s.inverseRelationships[relationship.Symbol], &scip.Relationship{
+			Symbol:           info.Symbol,
+			IsReference:      relationship.IsReference,
+			IsImplementation: relationship.IsImplementation,
+			IsTypeDefinition: relationship.IsTypeDefinition,
+		})
+	}
+}
+
+// interpretSCIPRange handles the difference between single-line and multi-line encoding of
+// range positions.
+func interpretSCIPRange(scipRange []int32) (protocol.RangeData, error) {
+	var startLine, startCharacter, endLine, endCharacter int32
+
+	switch len(scipRange) {
+	case 3:
+		startLine, startCharacter, endLine, endCharacter = scipRange[0], scipRange[1], scipRange[0], scipRange[2]
+	case 4:
+		startLine, startCharacter, endLine, endCharacter = scipRange[0], scipRange[1], scipRange[2], scipRange[3]
+	default:
+		return protocol.RangeData{}, errors.Newf("invalid SCIP range %v", scipRange)
+	}
+
+	return protocol.RangeData{
+		Start: protocol.Pos{Line: int(startLine), Character: int(startCharacter)},
+		End:   protocol.Pos{Line: int(endLine), Character: int(endCharacter)},
+	}, nil
+}
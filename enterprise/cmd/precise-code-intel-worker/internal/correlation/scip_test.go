@@ -0,0 +1,321 @@
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+const (
+	testSymbolFunc      = "scip-go gomod github.com/test/foo v1.0.0 `foo`/Func()."
+	testSymbolInterface = "scip-go gomod github.com/test/foo v1.0.0 `foo`/Interface#"
+	testSymbolStruct    = "scip-go gomod github.com/test/foo v1.0.0 `foo`/Struct#"
+	testSymbolExternal  = "scip-go gomod github.com/test/bar v2.0.0 `bar`/External()."
+	testSymbolLocal     = "local 0"
+)
+
+func TestIsSCIP(t *testing.T) {
+	index, err := proto.Marshal(testIndex())
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	// A 123-byte metadata message is encoded as the bytes "\n{", which looks like a newline
+	// followed by the beginning of a JSON object.
+	metadataIndex := &scip.Index{Metadata: &scip.Metadata{ToolInfo: &scip.ToolInfo{Name: "scip-go"}}}
+	metadataIndex.Metadata.ProjectRoot = "file:///" + strings.Repeat("a", 123-proto.Size(metadataIndex.Metadata)-len("file:///")-2)
+	metadataIndexContent, err := proto.Marshal(metadataIndex)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+	if prefix := string(metadataIndexContent[:2]); prefix != "\n{" {
+		t.Fatalf("unexpected index prefix. want=%q have=%q", "\n{", prefix)
+	}
+
+	testCases := map[string]bool{
+		string(index):                        true,
+		string(metadataIndexContent):         true,
+		`{"id":1,"type":"vertex"}`:           false,
+		"\n  {\"id\":1,\"type\":\"vertex\"}": false,
+		"\n{\"id\":1,\"type\":\"metaData\",\"version\":\"0.4.3\",\"projectRoot\":\"file:///repo\",\"positionEncoding\":\"utf-16\",\"toolInfo\":{\"name\":\"lsif-go\"}}\n": false,
+		"": false,
+	}
+
+	for content, expected := range testCases {
+		if isSCIP := IsSCIP(bufio.NewReader(strings.NewReader(content))); isSCIP != expected {
+			t.Errorf("unexpected result for %q. want=%v have=%v", content, expected, isSCIP)
+		}
+	}
+}
+
+func TestCorrelateSCIP(t *testing.T) {
+	content, err := proto.Marshal(testIndex())
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	groupedBundleData, err := CorrelateSCIP(context.Background(), bytes.NewReader(content), "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error correlating index: %s", err)
+	}
+	bundle := precise.GroupedBundleDataChansToMaps(groupedBundleData)
+
+	results, err := precise.Query(bundle, "a.go", 2, 6)
+	if err != nil {
+		t.Fatalf("unexpected error querying bundle: %s", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("unexpected number of results. want=%d have=%d", 1, len(results))
+	}
+
+	expectedDefinitions := []precise.LocationData{
+		{URI: "a.go", StartLine: 2, StartCharacter: 5, EndLine: 2, EndCharacter: 9},
+	}
+	if diff := cmp.Diff(expectedDefinitions, results[0].Definitions); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+
+	expectedReferences := []precise.LocationData{
+		{URI: "a.go", StartLine: 2, StartCharacter: 5, EndLine: 2, EndCharacter: 9},
+		{URI: "b.go", StartLine: 4, StartCharacter: 1, EndLine: 4, EndCharacter: 5},
+	}
+	if diff := cmp.Diff(expectedReferences, sortLocations(results[0].References)); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+
+	if expectedHover := "```go\nfunc Func()\n```\n\n---\n\nFunc does things."; results[0].Hover != expectedHover {
+		t.Errorf("unexpected hover. want=%q have=%q", expectedHover, results[0].Hover)
+	}
+
+	expectedMonikers := []precise.QualifiedMonikerData{
+		{
+			MonikerData: precise.MonikerData{
+				Kind:                 "export",
+				Scheme:               "scip-go",
+				Identifier:           testSymbolFunc,
+				PackageInformationID: results[0].Monikers[0].PackageInformationID,
+			},
+			PackageInformationData: precise.PackageInformationData{
+				Name:    "github.com/test/foo",
+				Version: "v1.0.0",
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedMonikers, results[0].Monikers); diff != "" {
+		t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+	}
+
+	expectedDiagnostics := []precise.DiagnosticData{
+		{Severity: 2, Code: "SA1019", Message: "External is deprecated", Source: "staticcheck", StartLine: 5, StartCharacter: 1, EndLine: 5, EndCharacter: 9},
+	}
+	if diff := cmp.Diff(expectedDiagnostics, bundle.Documents["b.go"].Diagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+	}
+
+	expectedPackages := []precise.Package{
+		{Scheme: "scip-go", Name: "github.com/test/foo", Version: "v1.0.0"},
+	}
+	if diff := cmp.Diff(expectedPackages, bundle.Packages); diff != "" {
+		t.Errorf("unexpected packages (-want +got):\n%s", diff)
+	}
+
+	expectedPackageReferences := []precise.PackageReference{
+		{Package: precise.Package{Scheme: "scip-go", Name: "github.com/test/bar", Version: "v2.0.0"}},
+	}
+	if diff := cmp.Diff(expectedPackageReferences, bundle.PackageReferences); diff != "" {
+		t.Errorf("unexpected package references (-want +got):\n%s", diff)
+	}
+}
+
+func TestCorrelateSCIPRoot(t *testing.T) {
+	index := testIndex()
+	index.Metadata.ProjectRoot = "file:///repo/sub"
+
+	content, err := proto.Marshal(index)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	groupedBundleData, err := CorrelateSCIP(context.Background(), bytes.NewReader(content), "sub/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error correlating index: %s", err)
+	}
+	bundle := precise.GroupedBundleDataChansToMaps(groupedBundleData)
+
+	var paths []string
+	for path := range bundle.Documents {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	if diff := cmp.Diff([]string{"a.go", "b.go"}, paths); diff != "" {
+		t.Errorf("unexpected document paths (-want +got):\n%s", diff)
+	}
+}
+
+// TestCorrelateSCIPMatchesLSIF ensures that SCIP indexes correlated directly produce the same
+// definitions, references, hovers, and monikers as the same index converted to LSIF first.
+func TestCorrelateSCIPMatchesLSIF(t *testing.T) {
+	index := testIndex()
+
+	content, err := proto.Marshal(index)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+	scipData, err := CorrelateSCIP(context.Background(), bytes.NewReader(content), "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error correlating SCIP index: %s", err)
+	}
+
+	elements, err := scip.ConvertSCIPToLSIF(index)
+	if err != nil {
+		t.Fatalf("unexpected error converting SCIP index to LSIF: %s", err)
+	}
+	var buf bytes.Buffer
+	if err := scip.WriteNDJSON(scip.ElementsToJsonElements(elements), &buf); err != nil {
+		t.Fatalf("unexpected error writing LSIF: %s", err)
+	}
+	lsifData, err := conversion.Correlate(context.Background(), &buf, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error correlating LSIF index: %s", err)
+	}
+
+	scipBundle := precise.GroupedBundleDataChansToMaps(scipData)
+	lsifBundle := precise.GroupedBundleDataChansToMaps(lsifData)
+
+	if diff := cmp.Diff(summarizeBundle(lsifBundle), summarizeBundle(scipBundle)); diff != "" {
+		t.Errorf("unexpected bundle data (-lsif +scip):\n%s", diff)
+	}
+	if diff := cmp.Diff(lsifBundle.Definitions, scipBundle.Definitions); diff != "" {
+		t.Errorf("unexpected moniker definitions (-lsif +scip):\n%s", diff)
+	}
+	if diff := cmp.Diff(lsifBundle.References, scipBundle.References); diff != "" {
+		t.Errorf("unexpected moniker references (-lsif +scip):\n%s", diff)
+	}
+	if diff := cmp.Diff(lsifBundle.Packages, scipBundle.Packages); diff != "" {
+		t.Errorf("unexpected packages (-lsif +scip):\n%s", diff)
+	}
+	if diff := cmp.Diff(lsifBundle.PackageReferences, scipBundle.PackageReferences); diff != "" {
+		t.Errorf("unexpected package references (-lsif +scip):\n%s", diff)
+	}
+}
+
+type rangeSummary struct {
+	Path        string
+	Range       [4]int
+	Definitions []precise.LocationData
+	References  []precise.LocationData
+	Hover       string
+	Monikers    []precise.MonikerData
+}
+
+// summarizeBundle resolves the data attached to each range of the given bundle so that bundles
+// can be compared independently of the identifiers they use.
+func summarizeBundle(bundle *precise.GroupedBundleDataMaps) []rangeSummary {
+	var summaries []rangeSummary
+	for path, document := range bundle.Documents {
+		for _, r := range document.Ranges {
+			result := precise.Resolve(bundle, document, r)
+
+			monikers := make([]precise.MonikerData, 0, len(result.Monikers))
+			for _, moniker := range result.Monikers {
+				monikers = append(monikers, precise.MonikerData{
+					Kind:       moniker.Kind,
+					Scheme:     moniker.Scheme,
+					Identifier: moniker.Identifier,
+				})
+			}
+			sort.Slice(monikers, func(i, j int) bool { return monikers[i].Identifier < monikers[j].Identifier })
+
+			summaries = append(summaries, rangeSummary{
+				Path:        path,
+				Range:       [4]int{r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter},
+				Definitions: sortLocations(result.Definitions),
+				References:  sortLocations(result.References),
+				Hover:       result.Hover,
+				Monikers:    monikers,
+			})
+		}
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Path != summaries[j].Path {
+			return summaries[i].Path < summaries[j].Path
+		}
+		for k := range summaries[i].Range {
+			if summaries[i].Range[k] != summaries[j].Range[k] {
+				return summaries[i].Range[k] < summaries[j].Range[k]
+			}
+		}
+		return false
+	})
+
+	return summaries
+}
+
+func sortLocations(locations []precise.LocationData) []precise.LocationData {
+	sort.Slice(locations, func(i, j int) bool { return precise.CompareLocations(locations[i], locations[j]) < 0 })
+	return locations
+}
+
+func testIndex() *scip.Index {
+	return &scip.Index{
+		Metadata: &scip.Metadata{
+			ToolInfo:             &scip.ToolInfo{Name: "scip-go", Version: "0.1.0"},
+			ProjectRoot:          "file:///repo",
+			TextDocumentEncoding: scip.TextEncoding_UTF8,
+		},
+		Documents: []*scip.Document{
+			{
+				RelativePath: "a.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{2, 5, 9}, Symbol: testSymbolFunc, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Range: []int32{3, 1, 2}, Symbol: testSymbolLocal, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Range: []int32{4, 8, 9}, Symbol: testSymbolLocal},
+					{Range: []int32{6, 5, 14}, Symbol: testSymbolInterface, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				},
+				Symbols: []*scip.SymbolInformation{
+					{Symbol: testSymbolFunc, Documentation: []string{"```go\nfunc Func()\n```", "Func does things."}},
+					{Symbol: testSymbolLocal, Documentation: []string{"```go\nvar x int\n```"}},
+					{Symbol: testSymbolInterface, Documentation: []string{"```go\ntype Interface interface\n```"}},
+				},
+			},
+			{
+				RelativePath: "b.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{2, 5, 11}, Symbol: testSymbolStruct, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Range: []int32{4, 1, 5}, Symbol: testSymbolFunc},
+					{
+						Range:  []int32{5, 1, 9},
+						Symbol: testSymbolExternal,
+						Diagnostics: []*scip.Diagnostic{
+							{Severity: scip.Severity_Warning, Code: "SA1019", Message: "External is deprecated", Source: "staticcheck"},
+						},
+					},
+				},
+				Symbols: []*scip.SymbolInformation{
+					{
+						Symbol:        testSymbolStruct,
+						Documentation: []string{"```go\ntype Struct struct\n```"},
+						Relationships: []*scip.Relationship{
+							{Symbol: testSymbolInterface, IsImplementation: true},
+						},
+					},
+				},
+			},
+		},
+		ExternalSymbols: []*scip.SymbolInformation{
+			{Symbol: testSymbolExternal, Documentation: []string{"```go\nfunc External()\n```"}},
+		},
+	}
+}
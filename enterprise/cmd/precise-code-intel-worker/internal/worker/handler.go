@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"bufio"
 	"compress/gzip"
 	"context"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/correlation"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -26,6 +28,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -146,9 +149,9 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, upload store.Up
 	}
 
 	return false, withUploadData(ctx, logger, h.uploadStore, upload.ID, trace, func(r io.Reader) (err error) {
-		groupedBundleData, err := conversion.Correlate(ctx, r, upload.Root, getChildren)
+		groupedBundleData, err := correlate(ctx, r, upload.Root, getChildren, trace)
 		if err != nil {
-			return errors.Wrap(err, "conversion.Correlate")
+			return err
 		}
 
 		// Note: this is writing to a different database than the block below, so we need to use a
@@ -236,6 +239,31 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, upload store.Up
 	})
 }
 
+// correlate converts the raw upload data in the given reader into the grouped bundle data that
+// is written to the codeintel database. Both LSIF and SCIP uploads are supported. SCIP indexes are
+// converted directly rather than being re-encoded as LSIF first.
+func correlate(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc, trace observation.TraceLogger) (*precise.GroupedBundleDataChans, error) {
+	br := bufio.NewReader(r)
+
+	if correlation.IsSCIP(br) {
+		trace.Log(otlog.String("format", "scip"))
+
+		groupedBundleData, err := correlation.CorrelateSCIP(ctx, br, root, getChildren)
+		if err != nil {
+			return nil, errors.Wrap(err, "correlation.CorrelateSCIP")
+		}
+		return groupedBundleData, nil
+	}
+
+	trace.Log(otlog.String("format", "lsif"))
+
+	groupedBundleData, err := conversion.Correlate(ctx, br, root, getChildren)
+	if err != nil {
+		return nil, errors.Wrap(err, "conversion.Correlate")
+	}
+	return groupedBundleData, nil
+}
+
 func inTransaction(ctx context.Context, dbStore DBStore, fn func(tx DBStore) error) (err error) {
 	tx, err := dbStore.Transact(ctx)
 	if err != nil {
@@ -283,8 +311,8 @@ func requeueIfCloningOrCommitUnknown(ctx context.Context, logger log.Logger, db
 }
 
 // withUploadData will invoke the given function with a reader of the upload's raw data. The
-// consumer should expect raw newline-delimited JSON content. If the function returns without
-// an error, the upload file will be deleted.
+// consumer should expect either raw newline-delimited LSIF JSON content or a protobuf-encoded
+// SCIP index. If the function returns without an error, the upload file will be deleted.
 func withUploadData(ctx context.Context, logger log.Logger, uploadStore uploadstore.Store, id int, trace observation.TraceLogger, fn func(r io.Reader) error) error {
 	uploadFilename := fmt.Sprintf("upload-%d.lsif.gz", id)
 
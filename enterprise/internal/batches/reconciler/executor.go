@@ -193,6 +193,27 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 		return err
 	}
 
+	// Some code hosts create changesets from the pushed commit itself, so the
+	// commit needs to be pushed to a special ref with a message that matches
+	// the changeset.
+	if rcss, ok := css.(sources.ReviewRefChangesetSource); ok {
+		body, err := e.decorateChangesetBody(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "decorating body for changeset %d", e.ch.ID)
+		}
+		cs := &sources.Changeset{
+			Title:      e.spec.Title,
+			Body:       body,
+			BaseRef:    e.spec.BaseRef,
+			HeadRef:    e.spec.HeadRef,
+			RemoteRepo: remoteRepo,
+			TargetRepo: e.targetRepo,
+			Changeset:  e.ch,
+		}
+		opts.CommitInfo.Message = rcss.CommitMessage(cs)
+		opts.Push.PushRef = rcss.PushRef(cs)
+	}
+
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
 	if errors.As(err, &pce) {
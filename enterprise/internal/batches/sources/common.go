@@ -48,6 +48,21 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A ReviewRefChangesetSource is a changeset source for code hosts on which
+// changesets are created and updated by pushing commits to a magic ref, rather
+// than by pushing a branch and opening a pull request for it, such as Gerrit.
+type ReviewRefChangesetSource interface {
+	ChangesetSource
+
+	// PushRef returns the ref on the code host that the commit of the given
+	// Changeset is pushed to.
+	PushRef(*Changeset) string
+	// CommitMessage returns the commit message of the commit of the given
+	// Changeset. The code host derives the title and body of the changeset from
+	// it, and uses it to associate new commits with an existing changeset.
+	CommitMessage(*Changeset) string
+}
+
 type ForkableChangesetSource interface {
 	ChangesetSource
 
@@ -0,0 +1,270 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is a ChangesetSource for Gerrit. Unlike on other code hosts,
+// changes on Gerrit are not opened for a branch: the push of a commit to
+// refs/for/<branch> creates the change, and every following push of a commit
+// with the same Change-Id footer adds a new patch set to it.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var (
+	_ DraftChangesetSource     = GerritSource{}
+	_ ReviewRefChangesetSource = GerritSource{}
+)
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	return &GerritSource{client: s.client.WithAuthenticator(a)}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedAccount(ctx)
+	return err
+}
+
+// PushRef returns the ref on the code host that the commit of the given
+// Changeset is pushed to. The head ref of the changeset is set as the topic of
+// the change, so that changes of the same batch change can be found on Gerrit.
+func (s GerritSource) PushRef(cs *Changeset) string {
+	return fmt.Sprintf("refs/for/%s%%topic=%s", gitdomain.AbbreviateRef(cs.BaseRef), gitdomain.AbbreviateRef(cs.HeadRef))
+}
+
+// CommitMessage returns the commit message of the commit of the given
+// Changeset: Gerrit uses the subject of the commit as the title of the change,
+// and the Change-Id footer to find the change that a commit belongs to.
+func (s GerritSource) CommitMessage(cs *Changeset) string {
+	message := cs.Title
+	if body := strings.TrimSpace(cs.Body); body != "" {
+		message += "\n\n" + body
+	}
+	return message + "\n\nChange-Id: " + changeID(cs) + "\n"
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	return s.loadChange(ctx, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	// The change was already created when the commit was pushed, so all we
+	// need to do is to find it.
+	project := gerritProject(cs.TargetRepo)
+	changes, err := s.client.QueryChanges(ctx, fmt.Sprintf("change:%s project:%s", changeID(cs), project))
+	if err != nil {
+		return false, errors.Wrap(err, "querying changes")
+	}
+
+	branch := gitdomain.AbbreviateRef(cs.BaseRef)
+	for _, change := range changes {
+		// The same Change-Id may be used for changes on other branches, for
+		// example if a change was cherry-picked.
+		if change.Branch != branch {
+			continue
+		}
+		if err := cs.SetMetadata(change); err != nil {
+			return false, errors.Wrap(err, "setting changeset metadata")
+		}
+		// A change with more than one patch set existed before the last push.
+		return change.CurrentRevisionInfo() != nil && change.CurrentRevisionInfo().Number > 1, nil
+	}
+
+	return false, errors.Errorf("no change with Change-Id %s found on branch %q of project %q", changeID(cs), branch, project)
+}
+
+// CreateDraftChangeset creates the given changeset on the code host in draft
+// mode, which is called work in progress on Gerrit.
+func (s GerritSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	exists, err := s.CreateChangeset(ctx, cs)
+	if err != nil {
+		return exists, err
+	}
+
+	change := cs.Metadata.(*gerrit.Change)
+	if change.WorkInProgress {
+		return exists, nil
+	}
+	if err := s.client.SetWorkInProgress(ctx, cs.ExternalID); err != nil {
+		return exists, errors.Wrap(err, "marking change as work in progress")
+	}
+	return exists, s.loadChange(ctx, cs)
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft
+// mode anymore.
+func (s GerritSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	if err := s.client.SetReadyForReview(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "marking change as ready for review")
+	}
+	return s.loadChange(ctx, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close" means
+// abandoning the change on Gerrit.
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	if err := s.client.AbandonChange(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "abandoning change")
+	}
+	return s.loadChange(ctx, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerrit.Change)
+
+	// Gerrit takes the title and the body of a change from the commit message,
+	// so they are updated by creating a new patch set with a different message.
+	// Gerrit rejects messages that are the same as the current one.
+	message := s.CommitMessage(cs)
+	if rev := change.CurrentRevisionInfo(); rev == nil || strings.TrimSpace(rev.Commit.Message) != strings.TrimSpace(message) {
+		if err := s.client.SetCommitMessage(ctx, cs.ExternalID, message); err != nil {
+			return errors.Wrap(err, "updating commit message")
+		}
+	}
+
+	if branch := gitdomain.AbbreviateRef(cs.BaseRef); change.Branch != branch {
+		if err := s.client.MoveChange(ctx, cs.ExternalID, branch); err != nil {
+			return errors.Wrap(err, "moving change")
+		}
+	}
+
+	return s.loadChange(ctx, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerrit.Change)
+	if change.Status != gerrit.ChangeStatusAbandoned {
+		return nil
+	}
+	if err := s.client.RestoreChange(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "restoring change")
+	}
+	return s.loadChange(ctx, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	return s.client.PostReviewComment(ctx, cs.ExternalID, comment)
+}
+
+// MergeChangeset submits the Changeset on the code host, if in a submittable
+// state. Gerrit changes consist of a single commit, and how they are merged is
+// configured per project, so squash is ignored.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	if err := s.client.SubmitChange(ctx, cs.ExternalID); err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "submitting change")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+	return s.loadChange(ctx, cs)
+}
+
+// loadChange gets the change of the Changeset and sets it as its metadata.
+// Changes are identified by their number, the external ID, since the other IDs
+// of a change aren't unique or change when the change is moved.
+func (s GerritSource) loadChange(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	if err := cs.SetMetadata(change); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
+// changeID returns the Change-Id of the change of the given Changeset. Gerrit
+// associates a pushed commit with an existing change through the Change-Id, so
+// it must be the same for every push of the changeset. It is derived from the
+// changeset, unless the changeset already has a change.
+func changeID(cs *Changeset) string {
+	if change, ok := cs.Metadata.(*gerrit.Change); ok && change.ChangeID != "" {
+		return change.ChangeID
+	}
+
+	h := sha1.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d", cs.TargetRepo.Name, gitdomain.AbbreviateRef(cs.HeadRef), cs.Changeset.ID)
+	return "I" + hex.EncodeToString(h.Sum(nil))
+}
+
+// gerritProject returns the name of the Gerrit project of the given repo.
+func gerritProject(repo *types.Repo) string {
+	// Gerrit encodes slashes in IDs, so need to decode them.
+	return strings.ReplaceAll(repo.Metadata.(*gerrit.Project).ID, "%2F", "/")
+}
@@ -10,6 +10,7 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -407,6 +408,39 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeGerritCheckState(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		labels map[string]gerrit.LabelInfo
+		want   btypes.ChangesetCheckState
+	}{
+		"no Verified label": {
+			labels: map[string]gerrit.LabelInfo{gerrit.LabelCodeReview: {}},
+			want:   btypes.ChangesetCheckStateUnknown,
+		},
+		"no votes": {
+			labels: map[string]gerrit.LabelInfo{gerrit.LabelVerified: {}},
+			want:   btypes.ChangesetCheckStatePending,
+		},
+		"verified": {
+			labels: map[string]gerrit.LabelInfo{gerrit.LabelVerified: {Approved: &gerrit.Account{ID: 1}}},
+			want:   btypes.ChangesetCheckStatePassed,
+		},
+		"failed": {
+			labels: map[string]gerrit.LabelInfo{gerrit.LabelVerified: {Approved: &gerrit.Account{ID: 1}, Rejected: &gerrit.Account{ID: 2}}},
+			want:   btypes.ChangesetCheckStateFailed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			change := &gerrit.Change{Labels: tc.labels}
+			if have := computeGerritCheckState(change); have != tc.want {
+				t.Errorf("wrong check state. have=%s, want=%s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
@@ -536,6 +570,32 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gerrit - no votes",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil),
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - +1 is not an approval",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				gerrit.LabelCodeReview: {Recommended: &gerrit.Account{ID: 1}},
+			}),
+			want: btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - +2",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				gerrit.LabelCodeReview: {Approved: &gerrit.Account{ID: 1}},
+			}),
+			want: btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gerrit - -1 takes precedence over +2",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				gerrit.LabelCodeReview: {Approved: &gerrit.Account{ID: 1}, Disliked: &gerrit.Account{ID: 2}},
+			}),
+			want: btypes.ChangesetReviewStateChangesRequested,
+		},
 	}
 
 	for i, tc := range tests {
@@ -744,6 +804,26 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateReadOnly,
 		},
+		{
+			name:      "gerrit - new",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil),
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gerrit - work in progress",
+			changeset: setDraft(gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil)),
+			want:      btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "gerrit - merged",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusMerged, nil),
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "gerrit - abandoned",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusAbandoned, nil),
+			want:      btypes.ChangesetExternalStateClosed,
+		},
 	}
 
 	for i, tc := range tests {
@@ -900,6 +980,17 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func gerritChangeset(updatedAt time.Time, status gerrit.ChangeStatus, labels map[string]gerrit.LabelInfo) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGerrit,
+		UpdatedAt:           updatedAt,
+		Metadata: &gerrit.Change{
+			Status: status,
+			Labels: labels,
+		},
+	}
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
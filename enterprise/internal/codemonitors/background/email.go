@@ -78,11 +78,26 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction)
 		priority = ""
 	}
 
-	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	var (
+		displayResults             []*DisplayResult
+		totalCount, truncatedCount int
+	)
+	if len(args.FileResults) > 0 {
+		var truncatedResults []*edb.FileMatchChange
+		truncatedResults, totalCount, truncatedCount = truncateFileResults(args.FileResults, 5)
+
+		displayResults = make([]*DisplayResult, len(truncatedResults))
+		for i, change := range truncatedResults {
+			displayResults[i] = toFileDisplayResult(change, args.ExternalURL)
+		}
+	} else {
+		var truncatedResults []*result.CommitMatch
+		truncatedResults, totalCount, truncatedCount = truncateResults(args.Results, 5)
 
-	displayResults := make([]*DisplayResult, len(truncatedResults))
-	for i, result := range truncatedResults {
-		displayResults[i] = toDisplayResult(result, args.ExternalURL)
+		displayResults = make([]*DisplayResult, len(truncatedResults))
+		for i, result := range truncatedResults {
+			displayResults[i] = toDisplayResult(result, args.ExternalURL)
+		}
 	}
 
 	return &TemplateDataNewSearchResults{
@@ -151,6 +166,10 @@ func getCommitURL(externalURL *url.URL, repoName, oid, utmSource string) string
 	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
 }
 
+func getFileURL(externalURL *url.URL, repoName, oid, path, utmSource string) string {
+	return sourcegraphURL(externalURL, fmt.Sprintf("%s@%s/-/blob/%s", repoName, oid, path), "", utmSource)
+}
+
 var (
 	externalURLOnce  sync.Once
 	externalURLValue *url.URL
@@ -199,6 +218,11 @@ type DisplayResult struct {
 	RepoName   string
 	CommitID   string
 	Content    string
+
+	// FileURL and Path are only set for the results of file content
+	// monitors.
+	FileURL string
+	Path    string
 }
 
 func toDisplayResult(result *result.CommitMatch, externalURL *url.URL) *DisplayResult {
@@ -222,3 +246,24 @@ func toDisplayResult(result *result.CommitMatch, externalURL *url.URL) *DisplayR
 		Content:    content,
 	}
 }
+
+func toFileDisplayResult(change *edb.FileMatchChange, externalURL *url.URL) *DisplayResult {
+	resultType := "File"
+	if change.Removed {
+		resultType = "Removed"
+	}
+
+	var content string
+	if change.Preview != nil {
+		content = truncateString(change.Preview.Content, 10)
+	}
+
+	return &DisplayResult{
+		ResultType: resultType,
+		FileURL:    getFileURL(externalURL, string(change.Repo.Name), string(change.CommitID), change.Path, utmSourceEmail),
+		RepoName:   string(change.Repo.Name),
+		CommitID:   change.CommitID.Short(),
+		Path:       change.Path,
+		Content:    content,
+	}
+}
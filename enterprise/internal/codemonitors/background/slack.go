@@ -11,6 +11,7 @@ import (
 
 	"github.com/slack-go/slack"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -25,6 +26,10 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
 	}
 
+	if len(args.FileResults) > 0 {
+		return slackFilePayload(args)
+	}
+
 	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
 
 	blocks := []slack.Block{
@@ -81,6 +86,64 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
 }
 
+func slackFilePayload(args actionArgs) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+
+	truncatedResults, totalCount, truncatedCount := truncateFileResults(args.FileResults, 5)
+
+	blocks := []slack.Block{
+		newMarkdownSection(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, *%s*, detected *%d* changed file matches.",
+			args.MonitorOwnerName,
+			args.MonitorDescription,
+			totalCount,
+		)),
+	}
+
+	if args.IncludeResults {
+		for _, change := range truncatedResults {
+			resultType := "File"
+			if change.Removed {
+				resultType = "Removed"
+			}
+			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+				"%s match: <%s|%s@%s:%s>",
+				resultType,
+				getFileURL(args.ExternalURL, string(change.Repo.Name), string(change.CommitID), change.Path, args.UTMSource),
+				change.Repo.Name,
+				change.CommitID.Short(),
+				change.Path,
+			)))
+			if change.Preview != nil {
+				blocks = append(blocks, newMarkdownSection(formatCodeBlock(truncateString(change.Preview.Content, 10))))
+			}
+		}
+		if truncatedCount > 0 {
+			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+				"...and <%s|%d more changed file matches>.",
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+				truncatedCount,
+			)))
+		}
+	} else {
+		blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+			"<%s|View results>",
+			getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+		)))
+	}
+
+	blocks = append(blocks,
+		newMarkdownSection(fmt.Sprintf(
+			`If you are %s, you can <%s|edit your code monitor>`,
+			args.MonitorOwnerName,
+			getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+		)),
+	)
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+}
+
 func formatCodeBlock(s string) string {
 	return fmt.Sprintf("```%s```", strings.ReplaceAll(s, "```", "\\`\\`\\`"))
 }
@@ -114,6 +177,14 @@ func truncateResults(results []*result.CommitMatch, maxResults int) (_ []*result
 	return output, totalCount, totalCount - outputCount
 }
 
+func truncateFileResults(changes []*edb.FileMatchChange, maxResults int) (_ []*edb.FileMatchChange, totalCount, truncatedCount int) {
+	totalCount = len(changes)
+	if totalCount <= maxResults {
+		return changes, totalCount, 0
+	}
+	return changes[:maxResults], totalCount, totalCount - maxResults
+}
+
 // adapted from slack.PostWebhookCustomHTTPContext
 func postSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *slack.WebhookMessage) error {
 	raw, err := json.Marshal(msg)
@@ -3,6 +3,7 @@ package background
 import (
 	"net/url"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
@@ -50,3 +51,31 @@ var commitResultMock = result.CommitMatch{
 }
 
 var commitDisplayResultMock = toDisplayResult(&commitResultMock, externalURLMock)
+
+var fileChangeMock = edb.FileMatchChange{
+	Repo: types.MinimalRepo{
+		Name: api.RepoName("github.com/test/test"),
+	},
+	CommitID: api.CommitID("7815187511872asbasdfgasd"),
+	Path:     "internal/test/test.go",
+	Preview: &result.MatchedString{
+		Content: "func Test() {\n\tmatched()\n}",
+		MatchedRanges: result.Ranges{{
+			Start: result.Location{Line: 1, Offset: 15, Column: 1},
+			End:   result.Location{Line: 1, Offset: 22, Column: 8},
+		}},
+	},
+}
+
+var fileDisplayResultMock = toFileDisplayResult(&fileChangeMock, externalURLMock)
+
+var removedFileChangeMock = edb.FileMatchChange{
+	Repo: types.MinimalRepo{
+		Name: api.RepoName("github.com/test/test"),
+	},
+	CommitID: api.CommitID("7815187511872asbasdfgasd"),
+	Path:     "internal/test/old.go",
+	Removed:  true,
+}
+
+var removedFileDisplayResultMock = toFileDisplayResult(&removedFileChangeMock, externalURLMock)
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -57,10 +58,11 @@ func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string,
 }
 
 type webhookPayload struct {
-	MonitorDescription string          `json:"monitorDescription"`
-	MonitorURL         string          `json:"monitorURL"`
-	Query              string          `json:"query"`
-	Results            []webhookResult `json:"results,omitempty"`
+	MonitorDescription string              `json:"monitorDescription"`
+	MonitorURL         string              `json:"monitorURL"`
+	Query              string              `json:"query"`
+	Results            []webhookResult     `json:"results,omitempty"`
+	FileResults        []webhookFileResult `json:"fileResults,omitempty"`
 }
 
 func generateWebhookPayload(args actionArgs) webhookPayload {
@@ -71,7 +73,11 @@ func generateWebhookPayload(args actionArgs) webhookPayload {
 	}
 
 	if args.IncludeResults {
-		p.Results = generateResults(args.Results)
+		if len(args.FileResults) > 0 {
+			p.FileResults = generateFileResults(args.FileResults)
+		} else {
+			p.Results = generateResults(args.Results)
+		}
 	}
 
 	return p
@@ -106,6 +112,33 @@ func generateResults(in []*result.CommitMatch) []webhookResult {
 	return out
 }
 
+type webhookFileResult struct {
+	Repository    string   `json:"repository"`
+	Commit        string   `json:"commit"`
+	Path          string   `json:"path"`
+	Removed       bool     `json:"removed,omitempty"`
+	Content       string   `json:"content,omitempty"`
+	MatchedRanges [][2]int `json:"matchedRanges,omitempty"`
+}
+
+func generateFileResults(in []*edb.FileMatchChange) []webhookFileResult {
+	out := make([]webhookFileResult, len(in))
+	for i, change := range in {
+		res := webhookFileResult{
+			Repository: string(change.Repo.Name),
+			Commit:     string(change.CommitID),
+			Path:       change.Path,
+			Removed:    change.Removed,
+		}
+		if change.Preview != nil {
+			res.Content = change.Preview.Content
+			res.MatchedRanges = rangesToInts(change.Preview.MatchedRanges)
+		}
+		out[i] = res
+	}
+	return out
+}
+
 func rangesToInts(ranges result.Ranges) [][2]int {
 	out := make([][2]int, len(ranges))
 	for i, r := range ranges {
@@ -17,7 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
-	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -172,8 +172,9 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, record work
 	}
 
 	query := q.QueryString
-	if !featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
-		// Only add an after filter when repo-aware monitors is disabled
+	if !featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) && isCommitQuery(query) {
+		// Only add an after filter when repo-aware monitors is disabled. File
+		// content monitors always diff against their last snapshot instead.
 		query = newQueryWithAfterFilter(q)
 	}
 	results, searchErr := codemonitors.Search(ctx, logger, r.db, query, m.ID, settings)
@@ -191,12 +192,19 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, record work
 	}
 
 	// Log the actual query we ran and whether we got any new results.
-	err = s.UpdateTriggerJobWithResults(ctx, triggerJob.ID, query, results)
-	if err != nil {
-		return errors.Wrap(err, "UpdateTriggerJobWithResults")
+	if results.FileMatchChanges != nil {
+		err = s.UpdateTriggerJobWithFileResults(ctx, triggerJob.ID, query, results.FileMatchChanges)
+		if err != nil {
+			return errors.Wrap(err, "UpdateTriggerJobWithFileResults")
+		}
+	} else {
+		err = s.UpdateTriggerJobWithResults(ctx, triggerJob.ID, query, results.CommitMatches)
+		if err != nil {
+			return errors.Wrap(err, "UpdateTriggerJobWithResults")
+		}
 	}
 
-	if len(results) > 0 {
+	if results.Len() > 0 {
 		_, err := s.EnqueueActionJobsForMonitor(ctx, m.ID, triggerJob.ID)
 		if err != nil {
 			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
@@ -269,6 +277,7 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		FileResults:        m.FileResults,
 		IncludeResults:     e.IncludeResults,
 	}
 
@@ -322,6 +331,7 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) erro
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		FileResults:        m.FileResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
@@ -358,6 +368,7 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		FileResults:        m.FileResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
@@ -393,8 +404,25 @@ func newQueryWithAfterFilter(q *edb.QueryTrigger) string {
 	return strings.Join([]string{q.QueryString, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
 }
 
-func latestResultTime(previousLastResult *time.Time, results []*result.CommitMatch, searchErr error) time.Time {
-	if searchErr != nil || len(results) == 0 {
+// isCommitQuery returns whether the query searches commits or diffs, as
+// opposed to file contents. Queries that cannot be parsed are assumed to be
+// commit queries so that the search reports the parse error.
+func isCommitQuery(q string) bool {
+	nodes, err := query.ParseLiteral(q)
+	if err != nil {
+		return true
+	}
+	isCommit := false
+	query.VisitField(nodes, query.FieldType, func(value string, negated bool, _ query.Annotation) {
+		if !negated && (value == "commit" || value == "diff") {
+			isCommit = true
+		}
+	})
+	return isCommit
+}
+
+func latestResultTime(previousLastResult *time.Time, results *codemonitors.SearchResults, searchErr error) time.Time {
+	if searchErr != nil || results.Len() == 0 {
 		// Error performing the search, or there were no results. Assume the
 		// previous info's result time.
 		if previousLastResult != nil {
@@ -403,8 +431,8 @@ func latestResultTime(previousLastResult *time.Time, results []*result.CommitMat
 		return time.Now()
 	}
 
-	if results[0].Commit.Committer != nil {
-		return results[0].Commit.Committer.Date
+	if len(results.CommitMatches) > 0 && results.CommitMatches[0].Commit.Committer != nil {
+		return results.CommitMatches[0].Commit.Committer.Date
 	}
 	return time.Now()
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -107,7 +108,28 @@ func Settings(ctx context.Context) (_ *schema.Settings, err error) {
 	return &unmarshaledSettings, nil
 }
 
-func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ []*result.CommitMatch, err error) {
+// SearchResults are the results of a code monitor search. Commit and diff
+// monitors produce CommitMatches, while file content monitors produce
+// FileMatchChanges.
+type SearchResults struct {
+	// CommitMatches are the commits and diffs that matched the query since
+	// the monitor last ran.
+	CommitMatches []*result.CommitMatch
+
+	// FileMatchChanges are the files that started or stopped matching the
+	// query since the monitor last ran.
+	FileMatchChanges []*edb.FileMatchChange
+}
+
+// Len returns the number of new results.
+func (r *SearchResults) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.CommitMatches) + len(r.FileMatchChanges)
+}
+
+func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ *SearchResults, err error) {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(ctx, "V3", nil, query, search.Streaming, settings, envvar.SourcegraphDotComMode())
 	if err != nil {
@@ -121,6 +143,14 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	if !job.HasDescendent[*commit.SearchJob](planJob) {
+		changes, err := searchFileMatches(ctx, db, clients, planJob, monitorID)
+		if err != nil {
+			return nil, err
+		}
+		return &SearchResults{FileMatchChanges: changes}, nil
+	}
+
 	if featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
 		hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 			return hookWithID(ctx, db, gs, monitorID, repoID, args, doSearch)
@@ -172,7 +202,7 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		results[i] = cm
 	}
 
-	return results, nil
+	return &SearchResults{CommitMatches: results}, nil
 }
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
@@ -191,6 +221,10 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return err
 	}
 
+	if !job.HasDescendent[*commit.SearchJob](planJob) {
+		return snapshotFileMatches(ctx, db, clients, planJob, monitorID)
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
@@ -251,6 +285,200 @@ func addCodeMonitorHook(in job.Job, hook commit.CodeMonitorHook) (_ job.Job, err
 	}), err
 }
 
+// prepareFileMatchJob removes the jobs that do not contribute file matches
+// from the job of a file content monitor.
+func prepareFileMatchJob(in job.Job) job.Job {
+	return job.Map(in, func(j job.Job) job.Job {
+		switch j.(type) {
+		case *jobutil.RepoSearchJob, *repos.ComputeExcludedJob, *jobutil.NoopJob:
+			// Repository matches have no file location to track, and
+			// ComputeExcludedJob only reports statistics.
+			return jobutil.NewNoopJob()
+		default:
+			return j
+		}
+	})
+}
+
+// runFileMatchJob runs the job of a file content monitor and returns the
+// file matches it produced.
+func runFileMatchJob(ctx context.Context, clients job.RuntimeClients, planJob job.Job) ([]*result.FileMatch, streaming.Stats, error) {
+	agg := streaming.NewAggregatingStream()
+	_, err := prepareFileMatchJob(planJob).Run(ctx, clients, agg)
+	if err != nil {
+		return nil, streaming.Stats{}, err
+	}
+
+	matches := make([]*result.FileMatch, len(agg.Results))
+	for i, res := range agg.Results {
+		fm, ok := res.(*result.FileMatch)
+		if !ok {
+			return nil, streaming.Stats{}, errors.Errorf("expected search to only return file matches, but got type %T", res)
+		}
+		matches[i] = fm
+	}
+	return matches, agg.Stats, nil
+}
+
+// searchFileMatches runs the search of a file content monitor and diffs the
+// matched files against the snapshot stored by the previous run. The new
+// snapshot is stored before returning the changes.
+func searchFileMatches(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) ([]*edb.FileMatchChange, error) {
+	matches, stats, err := runFileMatchJob(ctx, clients, planJob)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	previous, err := cm.GetFileMatchSnapshots(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, snapshots := diffFileMatches(previous, matches, stats)
+	for repoID, snapshot := range snapshots {
+		if err := cm.UpsertFileMatchSnapshot(ctx, monitorID, repoID, snapshot.CommitID, snapshot.Paths); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// snapshotFileMatches replaces the stored snapshots of a file content monitor
+// with the files that currently match its query.
+func snapshotFileMatches(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) error {
+	matches, stats, err := runFileMatchJob(ctx, clients, planJob)
+	if err != nil {
+		return err
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	if err := cm.DeleteFileMatchSnapshots(ctx, monitorID); err != nil {
+		return err
+	}
+
+	_, snapshots := diffFileMatches(nil, matches, stats)
+	for repoID, snapshot := range snapshots {
+		if err := cm.UpsertFileMatchSnapshot(ctx, monitorID, repoID, snapshot.CommitID, snapshot.Paths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffFileMatches compares the latest file matches of a file content monitor
+// with the snapshots of the previous run. It returns the files that started or
+// stopped matching, and the snapshots to store for the next run.
+//
+// A file is only reported as gone if we know that the search of its repository
+// was exhaustive. Otherwise, it is carried over to the next snapshot.
+func diffFileMatches(previous map[api.RepoID]*edb.FileMatchSnapshot, matches []*result.FileMatch, stats streaming.Stats) ([]*edb.FileMatchChange, map[api.RepoID]*edb.FileMatchSnapshot) {
+	exhaustive := func(repoID api.RepoID) bool {
+		return !stats.IsLimitHit && stats.Status.Get(repoID) == 0
+	}
+
+	var changes []*edb.FileMatchChange
+	snapshots := make(map[api.RepoID]*edb.FileMatchSnapshot)
+
+	byRepo := make(map[api.RepoID][]*result.FileMatch)
+	for _, fm := range matches {
+		byRepo[fm.Repo.ID] = append(byRepo[fm.Repo.ID], fm)
+	}
+
+	for repoID, fms := range byRepo {
+		repo := fms[0].Repo
+		snapshot := &edb.FileMatchSnapshot{RepoName: repo.Name, CommitID: fms[0].CommitID}
+		snapshots[repoID] = snapshot
+
+		prev := previous[repoID]
+		prevPaths := make(map[string]struct{})
+		if prev != nil {
+			for _, path := range prev.Paths {
+				prevPaths[path] = struct{}{}
+			}
+		}
+
+		paths := make(map[string]struct{}, len(fms))
+		for _, fm := range fms {
+			if _, ok := paths[fm.Path]; ok {
+				continue
+			}
+			paths[fm.Path] = struct{}{}
+			snapshot.Paths = append(snapshot.Paths, fm.Path)
+
+			if _, ok := prevPaths[fm.Path]; !ok {
+				changes = append(changes, &edb.FileMatchChange{
+					Repo:     repo,
+					CommitID: fm.CommitID,
+					Path:     fm.Path,
+					Preview:  filePreview(fm),
+				})
+			}
+		}
+
+		if prev == nil {
+			continue
+		}
+		for _, path := range prev.Paths {
+			if _, ok := paths[path]; ok {
+				continue
+			}
+			if !exhaustive(repoID) {
+				snapshot.Paths = append(snapshot.Paths, path)
+				continue
+			}
+			changes = append(changes, &edb.FileMatchChange{
+				Repo:     repo,
+				CommitID: prev.CommitID,
+				Path:     path,
+				Removed:  true,
+			})
+		}
+	}
+
+	// Repositories without any matches left are not part of the results, so
+	// we have to look for them in the previous snapshots.
+	for repoID, prev := range previous {
+		if _, ok := byRepo[repoID]; ok || len(prev.Paths) == 0 || !exhaustive(repoID) {
+			continue
+		}
+		for _, path := range prev.Paths {
+			changes = append(changes, &edb.FileMatchChange{
+				Repo:     types.MinimalRepo{ID: repoID, Name: prev.RepoName},
+				CommitID: prev.CommitID,
+				Path:     path,
+				Removed:  true,
+			})
+		}
+		snapshots[repoID] = &edb.FileMatchSnapshot{RepoName: prev.RepoName, CommitID: prev.CommitID}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Repo.Name != changes[j].Repo.Name {
+			return changes[i].Repo.Name < changes[j].Repo.Name
+		}
+		return changes[i].Path < changes[j].Path
+	})
+	for _, snapshot := range snapshots {
+		sort.Strings(snapshot.Paths)
+	}
+
+	return changes, snapshots
+}
+
+// filePreview returns the first matched chunk of the file with ranges relative
+// to the chunk, or nil if only the path matched.
+func filePreview(fm *result.FileMatch) *result.MatchedString {
+	if len(fm.ChunkMatches) == 0 {
+		return nil
+	}
+	chunk := fm.ChunkMatches[0]
+	return &result.MatchedString{
+		Content:       chunk.Content,
+		MatchedRanges: chunk.Ranges.Sub(chunk.ContentStart),
+	}
+}
+
 func hookWithID(
 	ctx context.Context,
 	db database.DB,
@@ -10,6 +10,7 @@ import (
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -19,7 +20,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -158,3 +161,106 @@ func TestCodeMonitorHook(t *testing.T) {
 	err = hookWithID(ctx, db, gs, fixtures.Monitor.ID, fixtures.Repo.ID, &gitprotocol.SearchRequest{}, doSearch)
 	require.NoError(t, err)
 }
+
+func TestDiffFileMatches(t *testing.T) {
+	t.Parallel()
+
+	repoA := types.MinimalRepo{ID: 1, Name: "github.com/test/a"}
+	repoB := types.MinimalRepo{ID: 2, Name: "github.com/test/b"}
+
+	fileMatch := func(repo types.MinimalRepo, commitID api.CommitID, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: repo, CommitID: commitID, Path: path}}
+	}
+
+	type change struct {
+		repo    api.RepoName
+		path    string
+		removed bool
+	}
+	summarize := func(changes []*edb.FileMatchChange) []change {
+		var out []change
+		for _, c := range changes {
+			out = append(out, change{repo: c.Repo.Name, path: c.Path, removed: c.Removed})
+		}
+		return out
+	}
+
+	t.Run("first run reports every match", func(t *testing.T) {
+		matches := []*result.FileMatch{
+			fileMatch(repoB, "b1", "main.go"),
+			fileMatch(repoA, "a1", "z.go"),
+			fileMatch(repoA, "a1", "a.go"),
+		}
+		changes, snapshots := diffFileMatches(nil, matches, streaming.Stats{})
+		require.Equal(t, []change{
+			{repo: repoA.Name, path: "a.go"},
+			{repo: repoA.Name, path: "z.go"},
+			{repo: repoB.Name, path: "main.go"},
+		}, summarize(changes))
+		require.Equal(t, map[api.RepoID]*edb.FileMatchSnapshot{
+			repoA.ID: {RepoName: repoA.Name, CommitID: "a1", Paths: []string{"a.go", "z.go"}},
+			repoB.ID: {RepoName: repoB.Name, CommitID: "b1", Paths: []string{"main.go"}},
+		}, snapshots)
+	})
+
+	t.Run("added and removed files", func(t *testing.T) {
+		previous := map[api.RepoID]*edb.FileMatchSnapshot{
+			repoA.ID: {RepoName: repoA.Name, CommitID: "a1", Paths: []string{"a.go", "b.go"}},
+			repoB.ID: {RepoName: repoB.Name, CommitID: "b1", Paths: []string{"main.go"}},
+		}
+		matches := []*result.FileMatch{
+			fileMatch(repoA, "a2", "a.go"),
+			fileMatch(repoA, "a2", "c.go"),
+		}
+		changes, snapshots := diffFileMatches(previous, matches, streaming.Stats{})
+		require.Equal(t, []change{
+			{repo: repoA.Name, path: "b.go", removed: true},
+			{repo: repoA.Name, path: "c.go"},
+			{repo: repoB.Name, path: "main.go", removed: true},
+		}, summarize(changes))
+		require.Equal(t, map[api.RepoID]*edb.FileMatchSnapshot{
+			repoA.ID: {RepoName: repoA.Name, CommitID: "a2", Paths: []string{"a.go", "c.go"}},
+			repoB.ID: {RepoName: repoB.Name, CommitID: "b1"},
+		}, snapshots)
+	})
+
+	t.Run("removals are not reported for incomplete repos", func(t *testing.T) {
+		previous := map[api.RepoID]*edb.FileMatchSnapshot{
+			repoA.ID: {RepoName: repoA.Name, CommitID: "a1", Paths: []string{"a.go", "b.go"}},
+			repoB.ID: {RepoName: repoB.Name, CommitID: "b1", Paths: []string{"main.go"}},
+		}
+		matches := []*result.FileMatch{
+			fileMatch(repoA, "a2", "a.go"),
+		}
+		var status search.RepoStatusMap
+		status.Update(repoA.ID, search.RepoStatusLimitHit)
+		status.Update(repoB.ID, search.RepoStatusTimedout)
+
+		changes, snapshots := diffFileMatches(previous, matches, streaming.Stats{Status: status})
+		require.Empty(t, changes)
+		require.Equal(t, map[api.RepoID]*edb.FileMatchSnapshot{
+			repoA.ID: {RepoName: repoA.Name, CommitID: "a2", Paths: []string{"a.go", "b.go"}},
+		}, snapshots)
+	})
+
+	t.Run("preview is relative to the chunk", func(t *testing.T) {
+		fm := fileMatch(repoA, "a1", "a.go")
+		fm.ChunkMatches = result.ChunkMatches{{
+			Content:      "func main() {}",
+			ContentStart: result.Location{Offset: 10, Line: 1},
+			Ranges: result.Ranges{{
+				Start: result.Location{Offset: 15, Line: 1, Column: 5},
+				End:   result.Location{Offset: 19, Line: 1, Column: 9},
+			}},
+		}}
+		changes, _ := diffFileMatches(nil, []*result.FileMatch{fm}, streaming.Stats{})
+		require.Len(t, changes, 1)
+		require.Equal(t, &result.MatchedString{
+			Content: "func main() {}",
+			MatchedRanges: result.Ranges{{
+				Start: result.Location{Offset: 5, Line: 0, Column: 5},
+				End:   result.Location{Offset: 9, Line: 0, Column: 9},
+			}},
+		}, changes[0].Preview)
+	})
+}
@@ -38,6 +38,7 @@ type ActionJobMetadata struct {
 	Description string
 	MonitorID   int64
 	Results     []*result.CommitMatch
+	FileResults []*FileMatchChange
 	OwnerName   string
 
 	// The query with after: filter.
@@ -228,6 +229,7 @@ SELECT
 	ctj.query_string,
 	cm.id AS monitorID,
 	ctj.search_results,
+	ctj.file_results,
 	CASE WHEN LENGTH(users.display_name) > 0 THEN users.display_name ELSE users.username END
 FROM cm_action_jobs caj
 INNER JOIN cm_trigger_jobs ctj on caj.trigger_event = ctj.id
@@ -240,14 +242,21 @@ WHERE caj.id = %s
 // GetActionJobMetada returns the set of fields needed to execute all action jobs
 func (s *codeMonitorStore) GetActionJobMetadata(ctx context.Context, jobID int32) (*ActionJobMetadata, error) {
 	row := s.Store.QueryRow(ctx, sqlf.Sprintf(getActionJobMetadataFmtStr, jobID))
-	var resultsJSON []byte
+	var resultsJSON, fileResultsJSON []byte
 	m := &ActionJobMetadata{}
-	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &m.OwnerName)
+	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &fileResultsJSON, &m.OwnerName)
 	if err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal(resultsJSON, &m.Results); err != nil {
-		return nil, err
+	if len(resultsJSON) > 0 {
+		if err := json.Unmarshal(resultsJSON, &m.Results); err != nil {
+			return nil, err
+		}
+	}
+	if len(fileResultsJSON) > 0 {
+		if err := json.Unmarshal(fileResultsJSON, &m.FileResults); err != nil {
+			return nil, err
+		}
 	}
 	return m, nil
 }
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// FileMatchSnapshot is the set of files in a repository that matched the
+// query of a file content code monitor the last time it ran.
+type FileMatchSnapshot struct {
+	RepoName api.RepoName
+	CommitID api.CommitID
+	Paths    []string
+}
+
+// FileMatchChange is a file that started or stopped matching the query of a
+// file content code monitor.
+type FileMatchChange struct {
+	Repo     types.MinimalRepo
+	CommitID api.CommitID
+	Path     string
+
+	// Removed is true if the file matched on the previous run, but no longer
+	// matches.
+	Removed bool
+
+	// Preview is the first matched chunk of the file, if any. It is always
+	// empty for removed files.
+	Preview *result.MatchedString
+}
+
+func (s *codeMonitorStore) UpsertFileMatchSnapshot(ctx context.Context, monitorID int64, repoID api.RepoID, commitID api.CommitID, paths []string) error {
+	rawQuery := `
+	INSERT INTO cm_file_match_snapshots (monitor_id, repo_id, commit_oid, paths)
+	VALUES (%s, %s, %s, %s)
+	ON CONFLICT (monitor_id, repo_id) DO UPDATE
+	SET commit_oid = EXCLUDED.commit_oid,
+		paths = EXCLUDED.paths
+	`
+
+	// Appease non-null constraint on column
+	if paths == nil {
+		paths = []string{}
+	}
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(repoID), string(commitID), pq.StringArray(paths))
+	return s.Exec(ctx, q)
+}
+
+func (s *codeMonitorStore) GetFileMatchSnapshots(ctx context.Context, monitorID int64) (map[api.RepoID]*FileMatchSnapshot, error) {
+	rawQuery := `
+	SELECT s.repo_id, repo.name, s.commit_oid, s.paths
+	FROM cm_file_match_snapshots s
+	JOIN repo ON repo.id = s.repo_id
+	WHERE s.monitor_id = %s
+		AND repo.deleted_at IS NULL
+	`
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(rawQuery, monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := make(map[api.RepoID]*FileMatchSnapshot)
+	for rows.Next() {
+		var (
+			repoID   api.RepoID
+			snapshot FileMatchSnapshot
+		)
+		if err := rows.Scan(&repoID, &snapshot.RepoName, &snapshot.CommitID, (*pq.StringArray)(&snapshot.Paths)); err != nil {
+			return nil, err
+		}
+		snapshots[repoID] = &snapshot
+	}
+	return snapshots, rows.Err()
+}
+
+func (s *codeMonitorStore) DeleteFileMatchSnapshots(ctx context.Context, monitorID int64) error {
+	rawQuery := `
+	DELETE FROM cm_file_match_snapshots
+	WHERE monitor_id = %s
+	`
+
+	return s.Exec(ctx, sqlf.Sprintf(rawQuery, monitorID))
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreFileMatchSnapshots(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	t.Run("insert get upsert get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		// Insert
+		err := cm.UpsertFileMatchSnapshot(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, "commit1", []string{"a.go", "b.go"})
+		require.NoError(t, err)
+
+		// Get
+		snapshots, err := cm.GetFileMatchSnapshots(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[api.RepoID]*FileMatchSnapshot{
+			fixtures.Repo.ID: {RepoName: fixtures.Repo.Name, CommitID: "commit1", Paths: []string{"a.go", "b.go"}},
+		}, snapshots)
+
+		// Update
+		err = cm.UpsertFileMatchSnapshot(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, "commit2", []string{"c.go"})
+		require.NoError(t, err)
+
+		// Get
+		snapshots, err = cm.GetFileMatchSnapshots(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[api.RepoID]*FileMatchSnapshot{
+			fixtures.Repo.ID: {RepoName: fixtures.Repo.Name, CommitID: "commit2", Paths: []string{"c.go"}},
+		}, snapshots)
+	})
+
+	t.Run("no error for missing get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		snapshots, err := cm.GetFileMatchSnapshots(ctx, fixtures.Monitor.ID+1)
+		require.NoError(t, err)
+		require.Empty(t, snapshots)
+	})
+
+	t.Run("nil paths", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		err := cm.UpsertFileMatchSnapshot(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, "commit1", nil)
+		require.NoError(t, err)
+
+		snapshots, err := cm.GetFileMatchSnapshots(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		require.Empty(t, snapshots[fixtures.Repo.ID].Paths)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		err := cm.UpsertFileMatchSnapshot(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, "commit1", []string{"a.go"})
+		require.NoError(t, err)
+
+		err = cm.DeleteFileMatchSnapshots(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+
+		snapshots, err := cm.GetFileMatchSnapshots(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Empty(t, snapshots)
+	})
+}
@@ -21,6 +21,9 @@ type TriggerJob struct {
 
 	SearchResults []*result.CommitMatch
 
+	// FileResults are the changed file matches of a file content monitor.
+	FileResults []*FileMatchChange
+
 	// Fields demanded for any dbworker.
 	State          string
 	FailureMessage *string
@@ -82,6 +85,26 @@ func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, trig
 	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, resultsJSON, triggerJobID))
 }
 
+const logFileSearchFmtStr = `
+UPDATE cm_trigger_jobs
+SET query_string = %s,
+    file_results = %s
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) UpdateTriggerJobWithFileResults(ctx context.Context, triggerJobID int32, queryString string, results []*FileMatchChange) error {
+	if results == nil {
+		// appease db array check constraint
+		results = []*FileMatchChange{}
+	}
+
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logFileSearchFmtStr, queryString, resultsJSON, triggerJobID))
+}
+
 const deleteOldJobLogsFmtStr = `
 DELETE FROM cm_trigger_jobs
 WHERE finished_at < (NOW() - (%s * '1 day'::interval));
@@ -143,7 +166,7 @@ func (s *codeMonitorStore) ListQueryTriggerJobs(ctx context.Context, opts ListTr
 const totalCountEventsForQueryIDInt64FmtStr = `
 SELECT COUNT(*)
 FROM cm_trigger_jobs
-WHERE ((state = 'completed' AND jsonb_array_length(search_results) > 0) OR (state != 'completed'))
+WHERE ((state = 'completed' AND (jsonb_array_length(search_results) > 0 OR jsonb_array_length(file_results) > 0)) OR (state != 'completed'))
 AND query = %s
 `
 
@@ -170,13 +193,14 @@ func scanTriggerJobs(rows *sql.Rows) ([]*TriggerJob, error) {
 }
 
 func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
-	var resultsJSON []byte
+	var resultsJSON, fileResultsJSON []byte
 	m := &TriggerJob{}
 	err := scanner.Scan(
 		&m.ID,
 		&m.Query,
 		&m.QueryString,
 		&resultsJSON,
+		&fileResultsJSON,
 		&m.State,
 		&m.FailureMessage,
 		&m.StartedAt,
@@ -196,6 +220,12 @@ func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 		}
 	}
 
+	if len(fileResultsJSON) > 0 {
+		if err := json.Unmarshal(fileResultsJSON, &m.FileResults); err != nil {
+			return nil, err
+		}
+	}
+
 	return m, nil
 }
 
@@ -204,6 +234,7 @@ var TriggerJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_trigger_jobs.query"),
 	sqlf.Sprintf("cm_trigger_jobs.query_string"),
 	sqlf.Sprintf("cm_trigger_jobs.search_results"),
+	sqlf.Sprintf("cm_trigger_jobs.file_results"),
 	sqlf.Sprintf("cm_trigger_jobs.state"),
 	sqlf.Sprintf("cm_trigger_jobs.failure_message"),
 	sqlf.Sprintf("cm_trigger_jobs.started_at"),
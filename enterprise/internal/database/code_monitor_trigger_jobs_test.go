@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 const setToCompletedFmtStr = `
@@ -84,6 +85,46 @@ func TestUpdateTriggerJob(t *testing.T) {
 		err = db.CodeMonitors().UpdateTriggerJobWithResults(ctx, jobs[0].ID, "", nil)
 		require.NoError(t, err)
 	})
+
+	t.Run("handles null file results", func(t *testing.T) {
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		_ = populateCodeMonitorFixtures(t, db)
+		jobs, err := db.CodeMonitors().EnqueueQueryTriggerJobs(ctx)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+
+		err = db.CodeMonitors().UpdateTriggerJobWithFileResults(ctx, jobs[0].ID, "", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("stores file results", func(t *testing.T) {
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		f := populateCodeMonitorFixtures(t, db)
+		jobs, err := db.CodeMonitors().EnqueueQueryTriggerJobs(ctx)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+
+		want := []*FileMatchChange{{
+			Repo:     types.MinimalRepo{ID: f.Repo.ID, Name: f.Repo.Name},
+			CommitID: "deadbeef",
+			Path:     "go.mod",
+		}, {
+			Repo:     types.MinimalRepo{ID: f.Repo.ID, Name: f.Repo.Name},
+			CommitID: "deadbeef",
+			Path:     "go.sum",
+			Removed:  true,
+		}}
+		err = db.CodeMonitors().UpdateTriggerJobWithFileResults(ctx, jobs[0].ID, "file:go.mod", want)
+		require.NoError(t, err)
+
+		js, err := db.CodeMonitors().ListQueryTriggerJobs(ctx, ListTriggerJobsOpts{QueryID: &f.Query.ID})
+		require.NoError(t, err)
+		require.Len(t, js, 1)
+		require.Equal(t, want, js[0].FileResults)
+		require.Empty(t, js[0].SearchResults)
+	})
 }
 
 func TestListTriggerJobs(t *testing.T) {
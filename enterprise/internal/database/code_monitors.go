@@ -50,6 +50,7 @@ type CodeMonitorStore interface {
 	CountQueryTriggerJobs(ctx context.Context, queryID int64) (int32, error)
 
 	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error
+	UpdateTriggerJobWithFileResults(ctx context.Context, triggerJobID int32, queryString string, results []*FileMatchChange) error
 	DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error
 
 	UpdateEmailAction(_ context.Context, id int64, _ *EmailActionArgs) (*EmailAction, error)
@@ -90,6 +91,12 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	// UpsertFileMatchSnapshot, GetFileMatchSnapshots and DeleteFileMatchSnapshots manage the
+	// per-repo sets of matched files that file content code monitors diff their results against.
+	UpsertFileMatchSnapshot(ctx context.Context, monitorID int64, repoID api.RepoID, commitID api.CommitID, paths []string) error
+	GetFileMatchSnapshots(ctx context.Context, monitorID int64) (map[api.RepoID]*FileMatchSnapshot, error)
+	DeleteFileMatchSnapshots(ctx context.Context, monitorID int64) error
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
@@ -70,6 +70,9 @@ type MockCodeMonitorStore struct {
 	// DeleteEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteEmailActions.
 	DeleteEmailActionsFunc *CodeMonitorStoreDeleteEmailActionsFunc
+	// DeleteFileMatchSnapshotsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteFileMatchSnapshots.
+	DeleteFileMatchSnapshotsFunc *CodeMonitorStoreDeleteFileMatchSnapshotsFunc
 	// DeleteMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteMonitor.
 	DeleteMonitorFunc *CodeMonitorStoreDeleteMonitorFunc
@@ -108,6 +111,9 @@ type MockCodeMonitorStore struct {
 	// GetEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetEmailAction.
 	GetEmailActionFunc *CodeMonitorStoreGetEmailActionFunc
+	// GetFileMatchSnapshotsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetFileMatchSnapshots.
+	GetFileMatchSnapshotsFunc *CodeMonitorStoreGetFileMatchSnapshotsFunc
 	// GetLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method GetLastSearched.
 	GetLastSearchedFunc *CodeMonitorStoreGetLastSearchedFunc
@@ -182,6 +188,10 @@ type MockCodeMonitorStore struct {
 	// UpdateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSlackWebhookAction.
 	UpdateSlackWebhookActionFunc *CodeMonitorStoreUpdateSlackWebhookActionFunc
+	// UpdateTriggerJobWithFileResultsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateTriggerJobWithFileResults.
+	UpdateTriggerJobWithFileResultsFunc *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc
 	// UpdateTriggerJobWithResultsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateTriggerJobWithResults.
@@ -189,6 +199,9 @@ type MockCodeMonitorStore struct {
 	// UpdateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateWebhookAction.
 	UpdateWebhookActionFunc *CodeMonitorStoreUpdateWebhookActionFunc
+	// UpsertFileMatchSnapshotFunc is an instance of a mock function object
+	// controlling the behavior of the method UpsertFileMatchSnapshot.
+	UpsertFileMatchSnapshotFunc *CodeMonitorStoreUpsertFileMatchSnapshotFunc
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
@@ -269,6 +282,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteFileMatchSnapshotsFunc: &CodeMonitorStoreDeleteFileMatchSnapshotsFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -329,6 +347,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetFileMatchSnapshotsFunc: &CodeMonitorStoreGetFileMatchSnapshotsFunc{
+			defaultHook: func(context.Context, int64) (r0 map[api.RepoID]*FileMatchSnapshot, r1 error) {
+				return
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
 				return
@@ -449,6 +472,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateTriggerJobWithFileResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc{
+			defaultHook: func(context.Context, int32, string, []*FileMatchChange) (r0 error) {
+				return
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) (r0 error) {
 				return
@@ -459,6 +487,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpsertFileMatchSnapshotFunc: &CodeMonitorStoreUpsertFileMatchSnapshotFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, api.CommitID, []string) (r0 error) {
+				return
+			},
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID, []string) (r0 error) {
 				return
@@ -541,6 +574,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteEmailActions")
 			},
 		},
+		DeleteFileMatchSnapshotsFunc: &CodeMonitorStoreDeleteFileMatchSnapshotsFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteFileMatchSnapshots")
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteMonitor")
@@ -601,6 +639,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetEmailAction")
 			},
 		},
+		GetFileMatchSnapshotsFunc: &CodeMonitorStoreGetFileMatchSnapshotsFunc{
+			defaultHook: func(context.Context, int64) (map[api.RepoID]*FileMatchSnapshot, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetFileMatchSnapshots")
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetLastSearched")
@@ -721,6 +764,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateSlackWebhookAction")
 			},
 		},
+		UpdateTriggerJobWithFileResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc{
+			defaultHook: func(context.Context, int32, string, []*FileMatchChange) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithFileResults")
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
@@ -731,6 +779,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateWebhookAction")
 			},
 		},
+		UpsertFileMatchSnapshotFunc: &CodeMonitorStoreUpsertFileMatchSnapshotFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, api.CommitID, []string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertFileMatchSnapshot")
+			},
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
@@ -786,6 +839,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: i.DeleteEmailActions,
 		},
+		DeleteFileMatchSnapshotsFunc: &CodeMonitorStoreDeleteFileMatchSnapshotsFunc{
+			defaultHook: i.DeleteFileMatchSnapshots,
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: i.DeleteMonitor,
 		},
@@ -822,6 +878,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: i.GetEmailAction,
 		},
+		GetFileMatchSnapshotsFunc: &CodeMonitorStoreGetFileMatchSnapshotsFunc{
+			defaultHook: i.GetFileMatchSnapshots,
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: i.GetLastSearched,
 		},
@@ -894,12 +953,18 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
 			defaultHook: i.UpdateSlackWebhookAction,
 		},
+		UpdateTriggerJobWithFileResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc{
+			defaultHook: i.UpdateTriggerJobWithFileResults,
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: i.UpdateTriggerJobWithResults,
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
 			defaultHook: i.UpdateWebhookAction,
 		},
+		UpsertFileMatchSnapshotFunc: &CodeMonitorStoreUpsertFileMatchSnapshotFunc{
+			defaultHook: i.UpsertFileMatchSnapshot,
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
@@ -2479,6 +2544,115 @@ func (c CodeMonitorStoreDeleteEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreDeleteFileMatchSnapshotsFunc describes the behavior when
+// the DeleteFileMatchSnapshots method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreDeleteFileMatchSnapshotsFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []CodeMonitorStoreDeleteFileMatchSnapshotsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteFileMatchSnapshots delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteFileMatchSnapshots(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteFileMatchSnapshotsFunc.nextHook()(v0, v1)
+	m.DeleteFileMatchSnapshotsFunc.appendCall(CodeMonitorStoreDeleteFileMatchSnapshotsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteFileMatchSnapshots method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteFileMatchSnapshotsFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteFileMatchSnapshots method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteFileMatchSnapshotsFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteFileMatchSnapshotsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteFileMatchSnapshotsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteFileMatchSnapshotsFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteFileMatchSnapshotsFunc) appendCall(r0 CodeMonitorStoreDeleteFileMatchSnapshotsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteFileMatchSnapshotsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteFileMatchSnapshotsFunc) History() []CodeMonitorStoreDeleteFileMatchSnapshotsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteFileMatchSnapshotsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteFileMatchSnapshotsFuncCall is an object that
+// describes an invocation of method DeleteFileMatchSnapshots on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteFileMatchSnapshotsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreDeleteFileMatchSnapshotsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteFileMatchSnapshotsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreDeleteMonitorFunc describes the behavior when the
 // DeleteMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -3803,6 +3977,118 @@ func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreGetFileMatchSnapshotsFunc describes the behavior when the
+// GetFileMatchSnapshots method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetFileMatchSnapshotsFunc struct {
+	defaultHook func(context.Context, int64) (map[api.RepoID]*FileMatchSnapshot, error)
+	hooks       []func(context.Context, int64) (map[api.RepoID]*FileMatchSnapshot, error)
+	history     []CodeMonitorStoreGetFileMatchSnapshotsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetFileMatchSnapshots delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetFileMatchSnapshots(v0 context.Context, v1 int64) (map[api.RepoID]*FileMatchSnapshot, error) {
+	r0, r1 := m.GetFileMatchSnapshotsFunc.nextHook()(v0, v1)
+	m.GetFileMatchSnapshotsFunc.appendCall(CodeMonitorStoreGetFileMatchSnapshotsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetFileMatchSnapshots method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetFileMatchSnapshotsFunc) SetDefaultHook(hook func(context.Context, int64) (map[api.RepoID]*FileMatchSnapshot, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetFileMatchSnapshots method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetFileMatchSnapshotsFunc) PushHook(hook func(context.Context, int64) (map[api.RepoID]*FileMatchSnapshot, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetFileMatchSnapshotsFunc) SetDefaultReturn(r0 map[api.RepoID]*FileMatchSnapshot, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (map[api.RepoID]*FileMatchSnapshot, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetFileMatchSnapshotsFunc) PushReturn(r0 map[api.RepoID]*FileMatchSnapshot, r1 error) {
+	f.PushHook(func(context.Context, int64) (map[api.RepoID]*FileMatchSnapshot, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetFileMatchSnapshotsFunc) nextHook() func(context.Context, int64) (map[api.RepoID]*FileMatchSnapshot, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetFileMatchSnapshotsFunc) appendCall(r0 CodeMonitorStoreGetFileMatchSnapshotsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetFileMatchSnapshotsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetFileMatchSnapshotsFunc) History() []CodeMonitorStoreGetFileMatchSnapshotsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetFileMatchSnapshotsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetFileMatchSnapshotsFuncCall is an object that describes
+// an invocation of method GetFileMatchSnapshots on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetFileMatchSnapshotsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID]*FileMatchSnapshot
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetFileMatchSnapshotsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetFileMatchSnapshotsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreGetLastSearchedFunc describes the behavior when the
 // GetLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -6459,37 +6745,37 @@ func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreUpdateTriggerJobWithResultsFunc describes the behavior
-// when the UpdateTriggerJobWithResults method of the parent
+// CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc describes the
+// behavior when the UpdateTriggerJobWithFileResults method of the parent
 // MockCodeMonitorStore instance is invoked.
-type CodeMonitorStoreUpdateTriggerJobWithResultsFunc struct {
-	defaultHook func(context.Context, int32, string, []*result.CommitMatch) error
-	hooks       []func(context.Context, int32, string, []*result.CommitMatch) error
-	history     []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall
+type CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc struct {
+	defaultHook func(context.Context, int32, string, []*FileMatchChange) error
+	hooks       []func(context.Context, int32, string, []*FileMatchChange) error
+	history     []CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateTriggerJobWithResults delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v1 int32, v2 string, v3 []*result.CommitMatch) error {
-	r0 := m.UpdateTriggerJobWithResultsFunc.nextHook()(v0, v1, v2, v3)
-	m.UpdateTriggerJobWithResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall{v0, v1, v2, v3, r0})
+// UpdateTriggerJobWithFileResults delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithFileResults(v0 context.Context, v1 int32, v2 string, v3 []*FileMatchChange) error {
+	r0 := m.UpdateTriggerJobWithFileResultsFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateTriggerJobWithFileResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall{v0, v1, v2, v3, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
-// UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
+// UpdateTriggerJobWithFileResults method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*FileMatchChange) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
+// UpdateTriggerJobWithFileResults method of the parent MockCodeMonitorStore
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) PushHook(hook func(context.Context, int32, string, []*FileMatchChange) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6497,20 +6783,20 @@ func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, []*FileMatchChange) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, []*FileMatchChange) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(context.Context, int32, string, []*result.CommitMatch) error {
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) nextHook() func(context.Context, int32, string, []*FileMatchChange) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6523,28 +6809,28 @@ func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(contex
 	return hook
 }
 
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) appendCall(r0 CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) appendCall(r0 CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall objects describing
-// the invocations of this function.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) History() []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall {
+// CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall objects
+// describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) History() []CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall is an object that
-// describes an invocation of method UpdateTriggerJobWithResults on an
+// CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall is an object that
+// describes an invocation of method UpdateTriggerJobWithFileResults on an
 // instance of MockCodeMonitorStore.
-type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
+type CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -6556,7 +6842,7 @@ type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
 	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 []*result.CommitMatch
+	Arg3 []*FileMatchChange
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -6564,36 +6850,151 @@ type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// CodeMonitorStoreUpdateWebhookActionFunc describes the behavior when the
-// UpdateWebhookAction method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreUpdateWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	history     []CodeMonitorStoreUpdateWebhookActionFuncCall
+// CodeMonitorStoreUpdateTriggerJobWithResultsFunc describes the behavior
+// when the UpdateTriggerJobWithResults method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpdateTriggerJobWithResultsFunc struct {
+	defaultHook func(context.Context, int32, string, []*result.CommitMatch) error
+	hooks       []func(context.Context, int32, string, []*result.CommitMatch) error
+	history     []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateWebhookAction delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*WebhookAction, error) {
-	r0, r1 := m.UpdateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.UpdateWebhookActionFunc.appendCall(CodeMonitorStoreUpdateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
-	return r0, r1
+// UpdateTriggerJobWithResults delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v1 int32, v2 string, v3 []*result.CommitMatch) error {
+	r0 := m.UpdateTriggerJobWithResultsFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateTriggerJobWithResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall{v0, v1, v2, v3, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the UpdateWebhookAction
-// method of the parent MockCodeMonitorStore instance is invoked and the
+// SetDefaultHook sets function that is called when the
+// UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(context.Context, int32, string, []*result.CommitMatch) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) appendCall(r0 CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) History() []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall is an object that
+// describes an invocation of method UpdateTriggerJobWithResults on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []*result.CommitMatch
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreUpdateWebhookActionFunc describes the behavior when the
+// UpdateWebhookAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpdateWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
+	history     []CodeMonitorStoreUpdateWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateWebhookAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*WebhookAction, error) {
+	r0, r1 := m.UpdateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateWebhookActionFunc.appendCall(CodeMonitorStoreUpdateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateWebhookAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
 func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
 	f.defaultHook = hook
@@ -6694,6 +7095,124 @@ func (c CodeMonitorStoreUpdateWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpsertFileMatchSnapshotFunc describes the behavior when
+// the UpsertFileMatchSnapshot method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpsertFileMatchSnapshotFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID, api.CommitID, []string) error
+	hooks       []func(context.Context, int64, api.RepoID, api.CommitID, []string) error
+	history     []CodeMonitorStoreUpsertFileMatchSnapshotFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertFileMatchSnapshot delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertFileMatchSnapshot(v0 context.Context, v1 int64, v2 api.RepoID, v3 api.CommitID, v4 []string) error {
+	r0 := m.UpsertFileMatchSnapshotFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpsertFileMatchSnapshotFunc.appendCall(CodeMonitorStoreUpsertFileMatchSnapshotFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpsertFileMatchSnapshot method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpsertFileMatchSnapshotFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID, api.CommitID, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertFileMatchSnapshot method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpsertFileMatchSnapshotFunc) PushHook(hook func(context.Context, int64, api.RepoID, api.CommitID, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertFileMatchSnapshotFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID, api.CommitID, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertFileMatchSnapshotFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID, api.CommitID, []string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertFileMatchSnapshotFunc) nextHook() func(context.Context, int64, api.RepoID, api.CommitID, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertFileMatchSnapshotFunc) appendCall(r0 CodeMonitorStoreUpsertFileMatchSnapshotFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpsertFileMatchSnapshotFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpsertFileMatchSnapshotFunc) History() []CodeMonitorStoreUpsertFileMatchSnapshotFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertFileMatchSnapshotFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertFileMatchSnapshotFuncCall is an object that
+// describes an invocation of method UpsertFileMatchSnapshot on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreUpsertFileMatchSnapshotFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 api.CommitID
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertFileMatchSnapshotFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertFileMatchSnapshotFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreUpsertLastSearchedFunc describes the behavior when the
 // UpsertLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -6816,6 +7335,9 @@ type MockEnterpriseDB struct {
 	// AccessTokensFunc is an instance of a mock function object controlling
 	// the behavior of the method AccessTokens.
 	AccessTokensFunc *EnterpriseDBAccessTokensFunc
+	// AuditLogsFunc is an instance of a mock function object controlling
+	// the behavior of the method AuditLogs.
+	AuditLogsFunc *EnterpriseDBAuditLogsFunc
 	// AuthzFunc is an instance of a mock function object controlling the
 	// behavior of the method Authz.
 	AuthzFunc *EnterpriseDBAuthzFunc
@@ -6889,15 +7411,25 @@ type MockEnterpriseDB struct {
 	// RepoStatisticsFunc is an instance of a mock function object
 	// controlling the behavior of the method RepoStatistics.
 	RepoStatisticsFunc *EnterpriseDBRepoStatisticsFunc
+	// RepoUpdateScheduleSettingsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// RepoUpdateScheduleSettings.
+	RepoUpdateScheduleSettingsFunc *EnterpriseDBRepoUpdateScheduleSettingsFunc
 	// ReposFunc is an instance of a mock function object controlling the
 	// behavior of the method Repos.
 	ReposFunc *EnterpriseDBReposFunc
+	// SCIMFunc is an instance of a mock function object controlling the
+	// behavior of the method SCIM.
+	SCIMFunc *EnterpriseDBSCIMFunc
 	// SavedSearchesFunc is an instance of a mock function object
 	// controlling the behavior of the method SavedSearches.
 	SavedSearchesFunc *EnterpriseDBSavedSearchesFunc
 	// SearchContextsFunc is an instance of a mock function object
 	// controlling the behavior of the method SearchContexts.
 	SearchContextsFunc *EnterpriseDBSearchContextsFunc
+	// SearchHistoryFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchHistory.
+	SearchHistoryFunc *EnterpriseDBSearchHistoryFunc
 	// SecurityEventLogsFunc is an instance of a mock function object
 	// controlling the behavior of the method SecurityEventLogs.
 	SecurityEventLogsFunc *EnterpriseDBSecurityEventLogsFunc
@@ -6942,6 +7474,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		AuditLogsFunc: &EnterpriseDBAuditLogsFunc{
+			defaultHook: func() (r0 database.AuditLogStore) {
+				return
+			},
+		},
 		AuthzFunc: &EnterpriseDBAuthzFunc{
 			defaultHook: func() (r0 database.AuthzStore) {
 				return
@@ -7062,11 +7599,21 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		RepoUpdateScheduleSettingsFunc: &EnterpriseDBRepoUpdateScheduleSettingsFunc{
+			defaultHook: func() (r0 database.RepoUpdateScheduleSettingsStore) {
+				return
+			},
+		},
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: func() (r0 database.RepoStore) {
 				return
 			},
 		},
+		SCIMFunc: &EnterpriseDBSCIMFunc{
+			defaultHook: func() (r0 database.SCIMStore) {
+				return
+			},
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: func() (r0 database.SavedSearchStore) {
 				return
@@ -7077,6 +7624,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		SearchHistoryFunc: &EnterpriseDBSearchHistoryFunc{
+			defaultHook: func() (r0 database.SearchHistoryStore) {
+				return
+			},
+		},
 		SecurityEventLogsFunc: &EnterpriseDBSecurityEventLogsFunc{
 			defaultHook: func() (r0 database.SecurityEventLogsStore) {
 				return
@@ -7144,6 +7696,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.AccessTokens")
 			},
 		},
+		AuditLogsFunc: &EnterpriseDBAuditLogsFunc{
+			defaultHook: func() database.AuditLogStore {
+				panic("unexpected invocation of MockEnterpriseDB.AuditLogs")
+			},
+		},
 		AuthzFunc: &EnterpriseDBAuthzFunc{
 			defaultHook: func() database.AuthzStore {
 				panic("unexpected invocation of MockEnterpriseDB.Authz")
@@ -7264,11 +7821,21 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.RepoStatistics")
 			},
 		},
+		RepoUpdateScheduleSettingsFunc: &EnterpriseDBRepoUpdateScheduleSettingsFunc{
+			defaultHook: func() database.RepoUpdateScheduleSettingsStore {
+				panic("unexpected invocation of MockEnterpriseDB.RepoUpdateScheduleSettings")
+			},
+		},
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: func() database.RepoStore {
 				panic("unexpected invocation of MockEnterpriseDB.Repos")
 			},
 		},
+		SCIMFunc: &EnterpriseDBSCIMFunc{
+			defaultHook: func() database.SCIMStore {
+				panic("unexpected invocation of MockEnterpriseDB.SCIM")
+			},
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: func() database.SavedSearchStore {
 				panic("unexpected invocation of MockEnterpriseDB.SavedSearches")
@@ -7279,6 +7846,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.SearchContexts")
 			},
 		},
+		SearchHistoryFunc: &EnterpriseDBSearchHistoryFunc{
+			defaultHook: func() database.SearchHistoryStore {
+				panic("unexpected invocation of MockEnterpriseDB.SearchHistory")
+			},
+		},
 		SecurityEventLogsFunc: &EnterpriseDBSecurityEventLogsFunc{
 			defaultHook: func() database.SecurityEventLogsStore {
 				panic("unexpected invocation of MockEnterpriseDB.SecurityEventLogs")
@@ -7345,6 +7917,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		AccessTokensFunc: &EnterpriseDBAccessTokensFunc{
 			defaultHook: i.AccessTokens,
 		},
+		AuditLogsFunc: &EnterpriseDBAuditLogsFunc{
+			defaultHook: i.AuditLogs,
+		},
 		AuthzFunc: &EnterpriseDBAuthzFunc{
 			defaultHook: i.Authz,
 		},
@@ -7417,15 +7992,24 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		RepoStatisticsFunc: &EnterpriseDBRepoStatisticsFunc{
 			defaultHook: i.RepoStatistics,
 		},
+		RepoUpdateScheduleSettingsFunc: &EnterpriseDBRepoUpdateScheduleSettingsFunc{
+			defaultHook: i.RepoUpdateScheduleSettings,
+		},
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: i.Repos,
 		},
+		SCIMFunc: &EnterpriseDBSCIMFunc{
+			defaultHook: i.SCIM,
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: i.SavedSearches,
 		},
 		SearchContextsFunc: &EnterpriseDBSearchContextsFunc{
 			defaultHook: i.SearchContexts,
 		},
+		SearchHistoryFunc: &EnterpriseDBSearchHistoryFunc{
+			defaultHook: i.SearchHistory,
+		},
 		SecurityEventLogsFunc: &EnterpriseDBSecurityEventLogsFunc{
 			defaultHook: i.SecurityEventLogs,
 		},
@@ -7462,35 +8046,134 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 	}
 }
 
-// EnterpriseDBAccessTokensFunc describes the behavior when the AccessTokens
+// EnterpriseDBAccessTokensFunc describes the behavior when the AccessTokens
+// method of the parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBAccessTokensFunc struct {
+	defaultHook func() database.AccessTokenStore
+	hooks       []func() database.AccessTokenStore
+	history     []EnterpriseDBAccessTokensFuncCall
+	mutex       sync.Mutex
+}
+
+// AccessTokens delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) AccessTokens() database.AccessTokenStore {
+	r0 := m.AccessTokensFunc.nextHook()()
+	m.AccessTokensFunc.appendCall(EnterpriseDBAccessTokensFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AccessTokens method
+// of the parent MockEnterpriseDB instance is invoked and the hook queue is
+// empty.
+func (f *EnterpriseDBAccessTokensFunc) SetDefaultHook(hook func() database.AccessTokenStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AccessTokens method of the parent MockEnterpriseDB instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *EnterpriseDBAccessTokensFunc) PushHook(hook func() database.AccessTokenStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBAccessTokensFunc) SetDefaultReturn(r0 database.AccessTokenStore) {
+	f.SetDefaultHook(func() database.AccessTokenStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBAccessTokensFunc) PushReturn(r0 database.AccessTokenStore) {
+	f.PushHook(func() database.AccessTokenStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBAccessTokensFunc) nextHook() func() database.AccessTokenStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBAccessTokensFunc) appendCall(r0 EnterpriseDBAccessTokensFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBAccessTokensFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBAccessTokensFunc) History() []EnterpriseDBAccessTokensFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBAccessTokensFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBAccessTokensFuncCall is an object that describes an
+// invocation of method AccessTokens on an instance of MockEnterpriseDB.
+type EnterpriseDBAccessTokensFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.AccessTokenStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBAccessTokensFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBAccessTokensFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// EnterpriseDBAuditLogsFunc describes the behavior when the AuditLogs
 // method of the parent MockEnterpriseDB instance is invoked.
-type EnterpriseDBAccessTokensFunc struct {
-	defaultHook func() database.AccessTokenStore
-	hooks       []func() database.AccessTokenStore
-	history     []EnterpriseDBAccessTokensFuncCall
+type EnterpriseDBAuditLogsFunc struct {
+	defaultHook func() database.AuditLogStore
+	hooks       []func() database.AuditLogStore
+	history     []EnterpriseDBAuditLogsFuncCall
 	mutex       sync.Mutex
 }
 
-// AccessTokens delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockEnterpriseDB) AccessTokens() database.AccessTokenStore {
-	r0 := m.AccessTokensFunc.nextHook()()
-	m.AccessTokensFunc.appendCall(EnterpriseDBAccessTokensFuncCall{r0})
+// AuditLogs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnterpriseDB) AuditLogs() database.AuditLogStore {
+	r0 := m.AuditLogsFunc.nextHook()()
+	m.AuditLogsFunc.appendCall(EnterpriseDBAuditLogsFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the AccessTokens method
-// of the parent MockEnterpriseDB instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the AuditLogs method of
+// the parent MockEnterpriseDB instance is invoked and the hook queue is
 // empty.
-func (f *EnterpriseDBAccessTokensFunc) SetDefaultHook(hook func() database.AccessTokenStore) {
+func (f *EnterpriseDBAuditLogsFunc) SetDefaultHook(hook func() database.AuditLogStore) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// AccessTokens method of the parent MockEnterpriseDB instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *EnterpriseDBAccessTokensFunc) PushHook(hook func() database.AccessTokenStore) {
+// AuditLogs method of the parent MockEnterpriseDB instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *EnterpriseDBAuditLogsFunc) PushHook(hook func() database.AuditLogStore) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -7498,20 +8181,20 @@ func (f *EnterpriseDBAccessTokensFunc) PushHook(hook func() database.AccessToken
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *EnterpriseDBAccessTokensFunc) SetDefaultReturn(r0 database.AccessTokenStore) {
-	f.SetDefaultHook(func() database.AccessTokenStore {
+func (f *EnterpriseDBAuditLogsFunc) SetDefaultReturn(r0 database.AuditLogStore) {
+	f.SetDefaultHook(func() database.AuditLogStore {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *EnterpriseDBAccessTokensFunc) PushReturn(r0 database.AccessTokenStore) {
-	f.PushHook(func() database.AccessTokenStore {
+func (f *EnterpriseDBAuditLogsFunc) PushReturn(r0 database.AuditLogStore) {
+	f.PushHook(func() database.AuditLogStore {
 		return r0
 	})
 }
 
-func (f *EnterpriseDBAccessTokensFunc) nextHook() func() database.AccessTokenStore {
+func (f *EnterpriseDBAuditLogsFunc) nextHook() func() database.AuditLogStore {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -7524,40 +8207,40 @@ func (f *EnterpriseDBAccessTokensFunc) nextHook() func() database.AccessTokenSto
 	return hook
 }
 
-func (f *EnterpriseDBAccessTokensFunc) appendCall(r0 EnterpriseDBAccessTokensFuncCall) {
+func (f *EnterpriseDBAuditLogsFunc) appendCall(r0 EnterpriseDBAuditLogsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnterpriseDBAccessTokensFuncCall objects
+// History returns a sequence of EnterpriseDBAuditLogsFuncCall objects
 // describing the invocations of this function.
-func (f *EnterpriseDBAccessTokensFunc) History() []EnterpriseDBAccessTokensFuncCall {
+func (f *EnterpriseDBAuditLogsFunc) History() []EnterpriseDBAuditLogsFuncCall {
 	f.mutex.Lock()
-	history := make([]EnterpriseDBAccessTokensFuncCall, len(f.history))
+	history := make([]EnterpriseDBAuditLogsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnterpriseDBAccessTokensFuncCall is an object that describes an
-// invocation of method AccessTokens on an instance of MockEnterpriseDB.
-type EnterpriseDBAccessTokensFuncCall struct {
+// EnterpriseDBAuditLogsFuncCall is an object that describes an invocation
+// of method AuditLogs on an instance of MockEnterpriseDB.
+type EnterpriseDBAuditLogsFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 database.AccessTokenStore
+	Result0 database.AuditLogStore
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnterpriseDBAccessTokensFuncCall) Args() []interface{} {
+func (c EnterpriseDBAuditLogsFuncCall) Args() []interface{} {
 	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnterpriseDBAccessTokensFuncCall) Results() []interface{} {
+func (c EnterpriseDBAuditLogsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
@@ -9997,6 +10680,109 @@ func (c EnterpriseDBRepoStatisticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBRepoUpdateScheduleSettingsFunc describes the behavior when
+// the RepoUpdateScheduleSettings method of the parent MockEnterpriseDB
+// instance is invoked.
+type EnterpriseDBRepoUpdateScheduleSettingsFunc struct {
+	defaultHook func() database.RepoUpdateScheduleSettingsStore
+	hooks       []func() database.RepoUpdateScheduleSettingsStore
+	history     []EnterpriseDBRepoUpdateScheduleSettingsFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoUpdateScheduleSettings delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) RepoUpdateScheduleSettings() database.RepoUpdateScheduleSettingsStore {
+	r0 := m.RepoUpdateScheduleSettingsFunc.nextHook()()
+	m.RepoUpdateScheduleSettingsFunc.appendCall(EnterpriseDBRepoUpdateScheduleSettingsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// RepoUpdateScheduleSettings method of the parent MockEnterpriseDB instance
+// is invoked and the hook queue is empty.
+func (f *EnterpriseDBRepoUpdateScheduleSettingsFunc) SetDefaultHook(hook func() database.RepoUpdateScheduleSettingsStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoUpdateScheduleSettings method of the parent MockEnterpriseDB instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnterpriseDBRepoUpdateScheduleSettingsFunc) PushHook(hook func() database.RepoUpdateScheduleSettingsStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBRepoUpdateScheduleSettingsFunc) SetDefaultReturn(r0 database.RepoUpdateScheduleSettingsStore) {
+	f.SetDefaultHook(func() database.RepoUpdateScheduleSettingsStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBRepoUpdateScheduleSettingsFunc) PushReturn(r0 database.RepoUpdateScheduleSettingsStore) {
+	f.PushHook(func() database.RepoUpdateScheduleSettingsStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBRepoUpdateScheduleSettingsFunc) nextHook() func() database.RepoUpdateScheduleSettingsStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBRepoUpdateScheduleSettingsFunc) appendCall(r0 EnterpriseDBRepoUpdateScheduleSettingsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// EnterpriseDBRepoUpdateScheduleSettingsFuncCall objects describing the
+// invocations of this function.
+func (f *EnterpriseDBRepoUpdateScheduleSettingsFunc) History() []EnterpriseDBRepoUpdateScheduleSettingsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBRepoUpdateScheduleSettingsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBRepoUpdateScheduleSettingsFuncCall is an object that
+// describes an invocation of method RepoUpdateScheduleSettings on an
+// instance of MockEnterpriseDB.
+type EnterpriseDBRepoUpdateScheduleSettingsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RepoUpdateScheduleSettingsStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBRepoUpdateScheduleSettingsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBRepoUpdateScheduleSettingsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBReposFunc describes the behavior when the Repos method of the
 // parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBReposFunc struct {
@@ -10095,6 +10881,104 @@ func (c EnterpriseDBReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBSCIMFunc describes the behavior when the SCIM method of the
+// parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBSCIMFunc struct {
+	defaultHook func() database.SCIMStore
+	hooks       []func() database.SCIMStore
+	history     []EnterpriseDBSCIMFuncCall
+	mutex       sync.Mutex
+}
+
+// SCIM delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnterpriseDB) SCIM() database.SCIMStore {
+	r0 := m.SCIMFunc.nextHook()()
+	m.SCIMFunc.appendCall(EnterpriseDBSCIMFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SCIM method of the
+// parent MockEnterpriseDB instance is invoked and the hook queue is empty.
+func (f *EnterpriseDBSCIMFunc) SetDefaultHook(hook func() database.SCIMStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SCIM method of the parent MockEnterpriseDB instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *EnterpriseDBSCIMFunc) PushHook(hook func() database.SCIMStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBSCIMFunc) SetDefaultReturn(r0 database.SCIMStore) {
+	f.SetDefaultHook(func() database.SCIMStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBSCIMFunc) PushReturn(r0 database.SCIMStore) {
+	f.PushHook(func() database.SCIMStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBSCIMFunc) nextHook() func() database.SCIMStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBSCIMFunc) appendCall(r0 EnterpriseDBSCIMFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBSCIMFuncCall objects describing
+// the invocations of this function.
+func (f *EnterpriseDBSCIMFunc) History() []EnterpriseDBSCIMFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBSCIMFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBSCIMFuncCall is an object that describes an invocation of
+// method SCIM on an instance of MockEnterpriseDB.
+type EnterpriseDBSCIMFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.SCIMStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBSCIMFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBSCIMFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBSavedSearchesFunc describes the behavior when the
 // SavedSearches method of the parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBSavedSearchesFunc struct {
@@ -10293,6 +11177,105 @@ func (c EnterpriseDBSearchContextsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBSearchHistoryFunc describes the behavior when the
+// SearchHistory method of the parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBSearchHistoryFunc struct {
+	defaultHook func() database.SearchHistoryStore
+	hooks       []func() database.SearchHistoryStore
+	history     []EnterpriseDBSearchHistoryFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchHistory delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) SearchHistory() database.SearchHistoryStore {
+	r0 := m.SearchHistoryFunc.nextHook()()
+	m.SearchHistoryFunc.appendCall(EnterpriseDBSearchHistoryFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SearchHistory method
+// of the parent MockEnterpriseDB instance is invoked and the hook queue is
+// empty.
+func (f *EnterpriseDBSearchHistoryFunc) SetDefaultHook(hook func() database.SearchHistoryStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchHistory method of the parent MockEnterpriseDB instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *EnterpriseDBSearchHistoryFunc) PushHook(hook func() database.SearchHistoryStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBSearchHistoryFunc) SetDefaultReturn(r0 database.SearchHistoryStore) {
+	f.SetDefaultHook(func() database.SearchHistoryStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBSearchHistoryFunc) PushReturn(r0 database.SearchHistoryStore) {
+	f.PushHook(func() database.SearchHistoryStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBSearchHistoryFunc) nextHook() func() database.SearchHistoryStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBSearchHistoryFunc) appendCall(r0 EnterpriseDBSearchHistoryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBSearchHistoryFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBSearchHistoryFunc) History() []EnterpriseDBSearchHistoryFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBSearchHistoryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBSearchHistoryFuncCall is an object that describes an
+// invocation of method SearchHistory on an instance of MockEnterpriseDB.
+type EnterpriseDBSearchHistoryFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.SearchHistoryStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBSearchHistoryFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBSearchHistoryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBSecurityEventLogsFunc describes the behavior when the
 // SecurityEventLogs method of the parent MockEnterpriseDB instance is
 // invoked.
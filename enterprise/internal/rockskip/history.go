@@ -0,0 +1,319 @@
+package rockskip
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	pg "github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// historyPageSize is the number of rows of rockskip_symbols that symbol history
+// searches read at a time.
+const historyPageSize = 1000
+
+// symbolLifetime is a row of rockskip_symbols whose lifetime intersects the
+// searched range of a first-parent history.
+type symbolLifetime struct {
+	path    string
+	name    string
+	added   string // commit hash
+	deleted string // commit hash, empty if the symbol still exists
+	last    string // commit hash
+}
+
+// querySymbolHistory returns the symbols that existed at any commit of the
+// first-parent history of args.CommitID after args.BaseCommitID.
+//
+// Each row of rockskip_symbols is a lifetime of a symbol: it is inserted with
+// the commit that added the symbol, and the commits in its deleted hops include
+// the commit that removed it. Both sets of hops can include commits of other
+// branches, so only the commits in the first-parent history are considered:
+// the lifetime starts at the earliest added hop, and ends at the earliest
+// deleted hop that is in the history.
+func (s *Service) querySymbolHistory(ctx context.Context, args search.SymbolsParameters, repoId int, threadStatus *ThreadStatus) (result.Symbols, error) {
+	repo := string(args.Repo)
+
+	threadStatus.Tasklog.Start("RevList")
+	// history[i] is the commit at height len(history)-i, the first commit has
+	// height 1.
+	var history []string
+	baseIndex := -1
+	err := s.git.RevList(ctx, repo, string(args.CommitID), func(commit string) (bool, error) {
+		if commit == string(args.BaseCommitID) {
+			baseIndex = len(history)
+		}
+		history = append(history, commit)
+		return true, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "RevList")
+	}
+	if args.BaseCommitID != "" && baseIndex == -1 {
+		return nil, errors.Newf("%s is not in the first-parent history of %s", args.BaseCommitID, args.CommitID)
+	}
+
+	headHeight := len(history)
+	baseHeight := 0
+	if baseIndex != -1 {
+		baseHeight = len(history) - baseIndex
+	}
+	hashAtHeight := func(height int) string {
+		if height < 1 || height > len(history) {
+			return ""
+		}
+		return history[len(history)-height]
+	}
+
+	limit := DEFAULT_LIMIT
+	if args.First > 0 {
+		limit = args.First
+	}
+
+	isMatch, err := mkIsMatch(args)
+	if err != nil {
+		return nil, err
+	}
+
+	threadStatus.Tasklog.Start("run query")
+	var lifetimes []symbolLifetime
+	lastId := 0
+	for len(lifetimes) < limit {
+		rows, err := s.getSymbolRows(ctx, args, repoId, lastId)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+		lastId = rows[len(rows)-1].id
+
+		commits, err := getCommitHeights(ctx, s.db, rows)
+		if err != nil {
+			return nil, err
+		}
+		inHistory := func(c commitHeight) bool {
+			return c.hash != "" && hashAtHeight(c.height) == c.hash
+		}
+
+		for _, row := range rows {
+			if !isMatch(row.name) {
+				continue
+			}
+
+			// The earliest added hop is the commit that inserted the row.
+			var added commitHeight
+			for _, commit := range row.added {
+				if c, ok := commits[commit]; ok && (added.hash == "" || c.height < added.height) {
+					added = c
+				}
+			}
+			if !inHistory(added) {
+				// The symbol was added on another branch.
+				continue
+			}
+
+			var deleted commitHeight
+			for _, commit := range row.deleted {
+				if c := commits[commit]; inHistory(c) && (deleted.hash == "" || c.height < deleted.height) {
+					deleted = c
+				}
+			}
+
+			lastHeight := headHeight
+			if deleted.hash != "" {
+				lastHeight = deleted.height - 1
+			}
+			if lastHeight <= baseHeight {
+				// The symbol was removed before the searched range.
+				continue
+			}
+
+			lifetimes = append(lifetimes, symbolLifetime{
+				path:    row.path,
+				name:    row.name,
+				added:   added.hash,
+				deleted: deleted.hash,
+				last:    hashAtHeight(lastHeight),
+			})
+			if len(lifetimes) >= limit {
+				break
+			}
+		}
+	}
+
+	return s.locateSymbolLifetimes(ctx, repo, lifetimes, threadStatus)
+}
+
+// locateSymbolLifetimes parses the files of the symbols at the last commit at
+// which they existed, to return their locations at that commit.
+func (s *Service) locateSymbolLifetimes(ctx context.Context, repo string, lifetimes []symbolLifetime, threadStatus *ThreadStatus) (result.Symbols, error) {
+	parser, err := s.createParser()
+	if err != nil {
+		return nil, errors.Wrap(err, "create parser")
+	}
+	defer parser.Close()
+
+	lifetimesByCommit := map[string][]symbolLifetime{}
+	var commits []string
+	for _, lifetime := range lifetimes {
+		if _, ok := lifetimesByCommit[lifetime.last]; !ok {
+			commits = append(commits, lifetime.last)
+		}
+		lifetimesByCommit[lifetime.last] = append(lifetimesByCommit[lifetime.last], lifetime)
+	}
+
+	symbols := make(result.Symbols, 0, len(lifetimes))
+	for _, commit := range commits {
+		lifetimes := lifetimesByCommit[commit]
+		pathSet := map[string]struct{}{}
+		var paths []string
+		for _, lifetime := range lifetimes {
+			if _, ok := pathSet[lifetime.path]; !ok {
+				pathSet[lifetime.path] = struct{}{}
+				paths = append(paths, lifetime.path)
+			}
+		}
+
+		threadStatus.Tasklog.Start("ArchiveEach")
+		located := map[pathSymbol]result.Symbol{}
+		err := archiveEach(ctx, s.fetcher, repo, commit, paths, func(path string, contents []byte) error {
+			entries, err := parser.Parse(path, contents)
+			if err != nil {
+				return err
+			}
+			lines := strings.Split(string(contents), "\n")
+			for _, entry := range entries {
+				key := pathSymbol{path: path, symbol: entry.Name}
+				if _, ok := located[key]; ok {
+					continue
+				}
+				if symbol, ok := entryToSymbol(path, lines, entry); ok {
+					located[key] = symbol
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lifetime := range lifetimes {
+			symbol, ok := located[pathSymbol{path: lifetime.path, symbol: lifetime.name}]
+			if !ok {
+				// The parser did not return the symbol again, so we only know
+				// its file.
+				symbol = result.Symbol{Name: lifetime.name, Path: lifetime.path}
+			}
+			symbol.History = &result.SymbolHistory{
+				AddedCommitID:   api.CommitID(lifetime.added),
+				DeletedCommitID: api.CommitID(lifetime.deleted),
+				LastCommitID:    api.CommitID(lifetime.last),
+			}
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	return symbols, nil
+}
+
+type symbolRow struct {
+	id      int
+	path    string
+	name    string
+	added   []CommitId
+	deleted []CommitId
+}
+
+// getSymbolRows returns the next page of rows of rockskip_symbols of the repo
+// that match the search, regardless of the commits at which they exist.
+func (s *Service) getSymbolRows(ctx context.Context, args search.SymbolsParameters, repoId int, afterId int) ([]symbolRow, error) {
+	q := sqlf.Sprintf(`
+		SELECT id, path, name, added, deleted
+		FROM rockskip_symbols
+		WHERE
+			%s && singleton_integer(repo_id)
+			AND id > %s
+			AND %s
+		ORDER BY id
+		LIMIT %s;`,
+		pg.Array([]int{repoId}),
+		afterId,
+		convertSearchArgsToSqlQuery(args),
+		historyPageSize,
+	)
+
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "getSymbolRows")
+	}
+	defer rows.Close()
+
+	var symbolRows []symbolRow
+	for rows.Next() {
+		var row symbolRow
+		var added, deleted []int64
+		if err := rows.Scan(&row.id, &row.path, &row.name, pg.Array(&added), pg.Array(&deleted)); err != nil {
+			return nil, errors.Wrap(err, "getSymbolRows: Scan")
+		}
+		if len(added) == 0 {
+			continue
+		}
+		for _, a := range added {
+			row.added = append(row.added, CommitId(a))
+		}
+		for _, d := range deleted {
+			row.deleted = append(row.deleted, CommitId(d))
+		}
+		symbolRows = append(symbolRows, row)
+	}
+	return symbolRows, rows.Err()
+}
+
+type commitHeight struct {
+	hash   string
+	height int
+}
+
+// getCommitHeights returns the hashes and heights of the commits in the hops
+// of the given rows.
+func getCommitHeights(ctx context.Context, db *sql.DB, rows []symbolRow) (map[CommitId]commitHeight, error) {
+	var ids []int
+	for _, row := range rows {
+		ids = append(ids, row.added...)
+		ids = append(ids, row.deleted...)
+	}
+
+	commits := map[CommitId]commitHeight{}
+	for _, chunk := range chunksOf(ids, 1000) {
+		rows, err := db.QueryContext(ctx, `
+			SELECT id, commit_id, height
+			FROM rockskip_ancestry
+			WHERE id = ANY($1)
+		`, pg.Array(chunk))
+		if err != nil {
+			return nil, errors.Wrap(err, "getCommitHeights")
+		}
+		for rows.Next() {
+			var id CommitId
+			var c commitHeight
+			if err := rows.Scan(&id, &c.hash, &c.height); err != nil {
+				rows.Close()
+				return nil, errors.Wrap(err, "getCommitHeights: Scan")
+			}
+			commits[id] = c
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return commits, nil
+}
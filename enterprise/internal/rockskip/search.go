@@ -15,6 +15,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 	pg "github.com/lib/pq"
 	"github.com/segmentio/fasthash/fnv1"
+	"github.com/sourcegraph/go-ctags"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search"
@@ -106,6 +107,14 @@ func (s *Service) Search(ctx context.Context, args search.SymbolsParameters) (_
 	}
 
 	// Finally search.
+	if args.History {
+		symbols, err := s.querySymbolHistory(ctx, args, repoId, threadStatus)
+		if err != nil {
+			return nil, errors.Wrap(err, "querySymbolHistory")
+		}
+		return symbols, nil
+	}
+
 	symbols, err := s.querySymbols(ctx, args, repoId, commit, threadStatus)
 	if err != nil {
 		return nil, errors.Wrap(err, "querySymbols")
@@ -261,27 +270,14 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 
 		lines := strings.Split(string(contents), "\n")
 
-		for _, symbol := range allSymbols {
-			if isMatch(symbol.Name) {
-				if symbol.Line < 1 || symbol.Line > len(lines) {
-					log15.Warn("ctags returned an invalid line number", "path", path, "line", symbol.Line, "len(lines)", len(lines), "symbol", symbol.Name)
+		for _, entry := range allSymbols {
+			if isMatch(entry.Name) {
+				symbol, ok := entryToSymbol(path, lines, entry)
+				if !ok {
 					continue
 				}
 
-				character := strings.Index(lines[symbol.Line-1], symbol.Name)
-				if character == -1 {
-					// Could not find the symbol in the line. ctags doesn't always return the right line.
-					character = 0
-				}
-
-				symbols = append(symbols, result.Symbol{
-					Name:      symbol.Name,
-					Path:      path,
-					Line:      symbol.Line - 1,
-					Character: character,
-					Kind:      symbol.Kind,
-					Parent:    symbol.Parent,
-				})
+				symbols = append(symbols, symbol)
 
 				if len(symbols) >= limit {
 					return stopErr
@@ -306,6 +302,34 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 	return symbols, nil
 }
 
+// entryToSymbol converts a ctags entry of the file with the given lines to a
+// symbol. It returns false if the entry has an invalid line number.
+func entryToSymbol(path string, lines []string, entry *ctags.Entry) (result.Symbol, bool) {
+	if entry.Line < 1 || entry.Line > len(lines) {
+		log15.Warn("ctags returned an invalid line number", "path", path, "line", entry.Line, "len(lines)", len(lines), "symbol", entry.Name)
+		return result.Symbol{}, false
+	}
+
+	character := strings.Index(lines[entry.Line-1], entry.Name)
+	if character == -1 {
+		// Could not find the symbol in the line. ctags doesn't always return the right line.
+		character = 0
+	}
+
+	return result.Symbol{
+		Name:        entry.Name,
+		Path:        path,
+		Line:        entry.Line - 1,
+		Character:   character,
+		Kind:        entry.Kind,
+		Language:    entry.Language,
+		Parent:      entry.Parent,
+		ParentKind:  entry.ParentKind,
+		Signature:   entry.Signature,
+		FileLimited: entry.FileLimited,
+	}, true
+}
+
 func logQuery(ctx context.Context, db database.DB, args search.SymbolsParameters, q *sqlf.Query, duration time.Duration, symbols int) error {
 	sb := &strings.Builder{}
 
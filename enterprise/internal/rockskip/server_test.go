@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -190,6 +191,89 @@ func TestIndex(t *testing.T) {
 	commit("rm a.txt")
 }
 
+func TestSearchHistory(t *testing.T) {
+	logger := logtest.Scoped(t)
+
+	gitDir := t.TempDir()
+	gitRun := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = gitDir
+		stdout, err := cmd.Output()
+		if err != nil {
+			t.Fatal(errors.Wrap(err, "git "+strings.Join(args, " ")))
+		}
+		return strings.TrimSpace(string(stdout))
+	}
+	commit := func(filename, contents string) string {
+		if contents == "" {
+			gitRun("rm", filename)
+		} else {
+			if err := os.WriteFile(path.Join(gitDir, filename), []byte(contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+			gitRun("add", filename)
+		}
+		gitRun("commit", "-m", "commit")
+		return gitRun("rev-parse", "HEAD")
+	}
+
+	gitRun("init")
+	gitRun("config", "user.email", "test@sourcegraph.com")
+	gitRun("config", "user.name", "test")
+
+	git, err := NewSubprocessGit(gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer git.Close()
+
+	db := dbtest.NewDB(logger, t)
+	defer db.Close()
+
+	createParser := func() (ctags.Parser, error) { return mockParser{}, nil }
+	service, err := NewService(db, git, newMockRepositoryFetcher(git), createParser, 1, 1, false, 1, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c1 := commit("a.txt", "old\nkept\n")
+	c2 := commit("a.txt", "kept\nnew\n")
+	c3 := commit("b.txt", "other\n")
+
+	searchHistory := func(base, head string) map[string]result.SymbolHistory {
+		symbols, err := service.Search(context.Background(), search.SymbolsParameters{
+			Repo:         "somerepo",
+			CommitID:     api.CommitID(head),
+			BaseCommitID: api.CommitID(base),
+			History:      true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := map[string]result.SymbolHistory{}
+		for _, symbol := range symbols {
+			got[symbol.Path+":"+symbol.Name] = *symbol.History
+		}
+		return got
+	}
+
+	want := map[string]result.SymbolHistory{
+		"a.txt:old":   {AddedCommitID: api.CommitID(c1), DeletedCommitID: api.CommitID(c2), LastCommitID: api.CommitID(c1)},
+		"a.txt:kept":  {AddedCommitID: api.CommitID(c1), LastCommitID: api.CommitID(c3)},
+		"a.txt:new":   {AddedCommitID: api.CommitID(c2), LastCommitID: api.CommitID(c3)},
+		"b.txt:other": {AddedCommitID: api.CommitID(c3), LastCommitID: api.CommitID(c3)},
+	}
+	if diff := cmp.Diff(want, searchHistory("", c3)); diff != "" {
+		t.Errorf("unexpected history (-want +got):\n%s", diff)
+	}
+
+	// Symbols that were removed before the range are not returned.
+	delete(want, "a.txt:old")
+	if diff := cmp.Diff(want, searchHistory(c1, c3)); diff != "" {
+		t.Errorf("unexpected history (-want +got):\n%s", diff)
+	}
+}
+
 type SubprocessGit struct {
 	gitDir        string
 	catFileCmd    *exec.Cmd
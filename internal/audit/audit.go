@@ -2,6 +2,9 @@ package audit
 
 import (
 	"context"
+	"encoding/json"
+	"sync"
+	"time"
 
 	"github.com/sourcegraph/log"
 
@@ -12,22 +15,60 @@ import (
 // Log creates an INFO log statement that will be a part of the audit log.
 // The audit log records comply with the following design: an actor takes an action on an entity within a context.
 // Refer to Record struct to see details about individual components.
+//
+// If a Store has been registered with SetStore, the record is also persisted
+// asynchronously so that it can be queried later.
 func Log(ctx context.Context, logger log.Logger, record Record) {
 	var fields []log.Field
 
 	client := requestclient.FromContext(ctx)
+	entry := &Entry{
+		ActorUID:     actorId(actor.FromContext(ctx)),
+		IP:           ip(client),
+		ForwardedFor: forwardedFor(client),
+		Entity:       record.Entity,
+		Action:       record.Action,
+	}
 
 	fields = append(fields, log.Object("audit",
-		log.String("entity", record.Entity),
+		log.String("entity", entry.Entity),
 		log.Object("actor",
-			log.String("actorUID", actorId(actor.FromContext(ctx))),
-			log.String("ip", ip(client)),
-			log.String("X-Forwarded-For", forwardedFor(client)))))
+			log.String("actorUID", entry.ActorUID),
+			log.String("ip", entry.IP),
+			log.String("X-Forwarded-For", entry.ForwardedFor))))
 	fields = append(fields, record.Fields...)
 
 	logger.Info(record.Action, fields...)
+
+	s := getStore()
+	if s == nil {
+		return
+	}
+
+	entry.Fields = encodeFields(record.Fields)
+
+	// The record is persisted in the background, so that the request that
+	// is audited neither waits for the insert nor cancels it when it ends.
+	inserts.Add(1)
+	go func() {
+		defer inserts.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), insertTimeout)
+		defer cancel()
+
+		if err := s.Insert(ctx, entry); err != nil {
+			logger.Error("failed to store audit log record", log.String("action", record.Action), log.Error(err))
+		}
+	}()
 }
 
+// insertTimeout is how long persisting a single record may take.
+const insertTimeout = 10 * time.Second
+
+// inserts tracks the records that are being persisted, so that tests can wait
+// for them.
+var inserts sync.WaitGroup
+
 func actorId(act *actor.Actor) string {
 	if act.UID > 0 {
 		return act.UIDString()
@@ -60,3 +101,45 @@ type Record struct {
 	// Fields hold any additional context relevant to the Action
 	Fields []log.Field
 }
+
+// Entry is an audit log record with the actor and client information resolved
+// from the context the record was logged in.
+type Entry struct {
+	// ActorUID is the ID of the user, the anonymous user ID, or "unknown"
+	ActorUID string
+	// IP is the IP address of the client, or "unknown"
+	IP string
+	// ForwardedFor is the value of the X-Forwarded-For header of the client, or "unknown"
+	ForwardedFor string
+	// Entity is the name of the audited entity
+	Entity string
+	// Action describes the state change relevant to the audit log
+	Action string
+	// Fields holds the additional context of the record encoded as a JSON object
+	Fields json.RawMessage
+}
+
+// Store persists audit log entries.
+type Store interface {
+	Insert(ctx context.Context, entry *Entry) error
+}
+
+var (
+	storeMu sync.RWMutex
+	store   Store
+)
+
+// SetStore registers the store that every record passed to Log is persisted
+// to. Services with access to the frontend database should call this once on
+// startup. Passing nil disables persistence.
+func SetStore(s Store) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+	store = s
+}
+
+func getStore() Store {
+	storeMu.RLock()
+	defer storeMu.RUnlock()
+	return store
+}
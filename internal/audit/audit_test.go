@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestLog(t *testing.T) {
@@ -144,3 +145,93 @@ func TestLog(t *testing.T) {
 		})
 	}
 }
+
+type fakeStore struct {
+	entries []*Entry
+	ctxErrs []error
+	err     error
+}
+
+func (s *fakeStore) Insert(ctx context.Context, entry *Entry) error {
+	s.entries = append(s.entries, entry)
+	s.ctxErrs = append(s.ctxErrs, ctx.Err())
+	return s.err
+}
+
+func TestLogStore(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	ctx = requestclient.WithClient(ctx, &requestclient.Client{IP: "192.168.0.1", ForwardedFor: "192.168.0.2"})
+
+	record := Record{
+		Entity: "test entity",
+		Action: "test audit action",
+		Fields: []log.Field{
+			log.String("additional", "stuff"),
+			log.Object("nested", log.Int("count", 2)),
+		},
+	}
+
+	t.Run("no store", func(t *testing.T) {
+		logger, exportLogs := logtest.Captured(t)
+		Log(ctx, logger, record)
+		assert.Len(t, exportLogs(), 1)
+	})
+
+	t.Run("store", func(t *testing.T) {
+		store := &fakeStore{}
+		SetStore(store)
+		t.Cleanup(func() { SetStore(nil) })
+
+		logger, exportLogs := logtest.Captured(t)
+		Log(ctx, logger, record)
+		inserts.Wait()
+		assert.Len(t, exportLogs(), 1)
+
+		assert.Len(t, store.entries, 1)
+		entry := store.entries[0]
+		assert.Equal(t, &Entry{
+			ActorUID:     "1",
+			IP:           "192.168.0.1",
+			ForwardedFor: "192.168.0.2",
+			Entity:       "test entity",
+			Action:       "test audit action",
+			Fields:       entry.Fields,
+		}, entry)
+		assert.JSONEq(t, `{"additional":"stuff","nested":{"count":2}}`, string(entry.Fields))
+	})
+
+	t.Run("cancelled request", func(t *testing.T) {
+		store := &fakeStore{}
+		SetStore(store)
+		t.Cleanup(func() { SetStore(nil) })
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		logger, _ := logtest.Captured(t)
+		Log(ctx, logger, record)
+		inserts.Wait()
+
+		assert.Len(t, store.entries, 1)
+		assert.Equal(t, []error{nil}, store.ctxErrs)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		store := &fakeStore{err: errors.New("boom")}
+		SetStore(store)
+		t.Cleanup(func() { SetStore(nil) })
+
+		logger, exportLogs := logtest.Captured(t)
+		Log(ctx, logger, record)
+		inserts.Wait()
+
+		logs := exportLogs()
+		assert.Len(t, logs, 2)
+		assert.Equal(t, "failed to store audit log record", logs[1].Message)
+	})
+}
+
+func TestEncodeFields(t *testing.T) {
+	assert.JSONEq(t, `{}`, string(encodeFields(nil)))
+	assert.JSONEq(t, `{"a":"b","n":1}`, string(encodeFields([]log.Field{log.String("a", "b"), log.Int("n", 1)})))
+}
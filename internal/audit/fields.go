@@ -0,0 +1,24 @@
+package audit
+
+import (
+	"encoding/json"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/sourcegraph/log"
+)
+
+// encodeFields encodes the given log fields as a JSON object, or as an empty
+// object if they cannot be marshalled.
+func encodeFields(fields []log.Field) json.RawMessage {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	b, err := json.Marshal(enc.Fields)
+	if err != nil {
+		return json.RawMessage(`{}`)
+	}
+	return b
+}
@@ -225,6 +225,14 @@ func Matches(ctx context.Context, args Args) ([]*FileMatch, error) {
 	defer span.Finish()
 
 	args.ResultKind = MatchOnly
+	if UseNativeMatcher() {
+		var matches []*FileMatch
+		err := NativeMatches(ctx, args, func(fm *FileMatch, _ []byte) {
+			matches = append(matches, fm)
+		})
+		return matches, err
+	}
+
 	results, err := Run(ctx, args, ToFileMatch)
 	if err != nil {
 		return nil, err
@@ -241,6 +249,13 @@ func Replacements(ctx context.Context, args Args) ([]*FileReplacement, error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Comby.Replacements")
 	defer span.Finish()
 
+	if UseNativeMatcher() {
+		if args.ResultKind == Diff {
+			return nil, errors.New("the native structural search matcher does not support diffs")
+		}
+		return nativeReplacements(ctx, args)
+	}
+
 	results, err := Run(ctx, args, toFileReplacement)
 	if err != nil {
 		return nil, err
@@ -258,6 +273,10 @@ func Outputs(ctx context.Context, args Args) (string, error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Comby.Outputs")
 	defer span.Finish()
 
+	if UseNativeMatcher() {
+		return nativeOutputs(ctx, args)
+	}
+
 	results, err := Run(ctx, args, toOutput)
 	if err != nil {
 		return "", err
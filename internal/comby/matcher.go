@@ -0,0 +1,658 @@
+package comby
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxMatchSteps bounds the work of matching a template against a single file,
+// since templates with many holes can backtrack a lot.
+const maxMatchSteps = 10_000_000
+
+// ctxCheckSteps is how many steps of matching are taken between checks of
+// whether the search was canceled.
+const ctxCheckSteps = 10_000
+
+// errMatchBudgetExceeded is returned with the matches found so far when
+// matching a file takes more than maxMatchSteps.
+var errMatchBudgetExceeded = errors.New("structural search stopped matching a file after too many steps")
+
+type nodeKind int
+
+const (
+	literalNode nodeKind = iota
+	whitespaceNode
+	holeNode
+	groupNode
+)
+
+// node is a node of a compiled match template.
+type node struct {
+	kind nodeKind
+
+	// text is the text of a literal, or the open delimiter of a group.
+	text string
+
+	// close is the close delimiter of a group, and children are the nodes
+	// between its delimiters.
+	close    string
+	children []node
+
+	hole hole
+}
+
+type holeKind int
+
+const (
+	holeAny        holeKind = iota // :[x] and ...
+	holeAlphanum                   // :[[x]]
+	holeNonSpace                   // :[x.]
+	holeLine                       // :[x\n]
+	holeWhitespace                 // :[ x]
+	holeRegexp                     // :[x~regexp]
+)
+
+type hole struct {
+	kind holeKind
+	name string
+	re   *regexp.Regexp
+
+	// toEndOfLine is true for a hole that ends a template at the top level,
+	// which matches up to the end of the line instead of matching nothing.
+	toEndOfLine bool
+}
+
+// named returns true if the hole binds its name. The value of a named hole
+// that occurs more than once must be the same at every occurrence.
+func (h hole) named() bool {
+	return h.name != "" && h.name != "_"
+}
+
+var holeName = regexp.MustCompile(`^\w*$`)
+
+// parseHole parses hole syntax like ":[x]", or returns false if s is not a
+// valid hole.
+func parseHole(s string) (hole, bool, error) {
+	if s == "..." {
+		return hole{kind: holeAny}, true, nil
+	}
+	if !strings.HasPrefix(s, ":[") || !strings.HasSuffix(s, "]") {
+		return hole{}, false, nil
+	}
+	inner := s[len(":[") : len(s)-len("]")]
+
+	var h hole
+	switch {
+	case strings.HasPrefix(inner, "[") && strings.HasSuffix(inner, "]"):
+		h = hole{kind: holeAlphanum, name: inner[1 : len(inner)-1]}
+	case strings.Contains(inner, "~"):
+		name, pattern, _ := strings.Cut(inner, "~")
+		re, err := regexp.Compile(`\A(?:` + pattern + `)`)
+		if err != nil {
+			return hole{}, false, errors.Wrapf(err, "invalid regular expression in hole %s", s)
+		}
+		h = hole{kind: holeRegexp, name: name, re: re}
+	case strings.HasPrefix(inner, " "):
+		h = hole{kind: holeWhitespace, name: strings.TrimLeft(inner, " ")}
+	case strings.HasSuffix(inner, "."):
+		h = hole{kind: holeNonSpace, name: strings.TrimSuffix(inner, ".")}
+	case strings.HasSuffix(inner, `\n`):
+		h = hole{kind: holeLine, name: strings.TrimSuffix(inner, `\n`)}
+	default:
+		h = hole{kind: holeAny, name: inner}
+	}
+	if !holeName.MatchString(h.name) {
+		return hole{}, false, nil
+	}
+	return h, true, nil
+}
+
+// compileTemplate compiles a comby match template to a tree of nodes, in which
+// balanced delimiters are groups. Unbalanced delimiters are literals.
+func compileTemplate(template string, syn *syntax) ([]node, error) {
+	var tokens []node
+	appendLiteral := func(s string) {
+		if n := len(tokens); n > 0 && tokens[n-1].kind == literalNode {
+			tokens[n-1].text += s
+			return
+		}
+		tokens = append(tokens, node{kind: literalNode, text: s})
+	}
+
+	for _, term := range parseTemplate([]byte(template)) {
+		if h, ok := term.(Hole); ok {
+			parsed, ok, err := parseHole(string(h))
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				tokens = append(tokens, node{kind: holeNode, hole: parsed})
+				continue
+			}
+		}
+
+		s := term.String()
+		for len(s) > 0 {
+			r, size := utf8.DecodeRuneInString(s)
+			switch {
+			case unicode.IsSpace(r):
+				end := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsSpace(r) })
+				if end == -1 {
+					end = len(s)
+				}
+				tokens = append(tokens, node{kind: whitespaceNode})
+				size = end
+			case strings.HasPrefix(s, "..."):
+				tokens = append(tokens, node{kind: holeNode, hole: hole{kind: holeAny}})
+				size = len("...")
+			default:
+				if close, n, ok := syn.openDelimiter([]byte(s)); ok {
+					tokens = append(tokens, node{kind: groupNode, text: s[:n], close: close})
+					size = n
+				} else if syn.isCloseDelimiter([]byte(s)) {
+					// Close delimiters are resolved below.
+					tokens = append(tokens, node{kind: groupNode, close: s[:size]})
+				} else {
+					appendLiteral(s[:size])
+				}
+			}
+			s = s[size:]
+		}
+	}
+
+	// Leading and trailing whitespace is insignificant.
+	for len(tokens) > 0 && tokens[0].kind == whitespaceNode {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 0 && tokens[len(tokens)-1].kind == whitespaceNode {
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	nodes := groupTokens(tokens)
+	if n := len(nodes); n > 0 && nodes[n-1].kind == holeNode && nodes[n-1].hole.kind == holeAny {
+		nodes[n-1].hole.toEndOfLine = true
+	}
+	return nodes, nil
+}
+
+// groupTokens nests the tokens between balanced delimiters in groups. Open
+// delimiters are group tokens with text, close delimiters are group tokens
+// without text.
+func groupTokens(tokens []node) []node {
+	type frame struct {
+		open  node
+		nodes []node
+	}
+	stack := []frame{{}}
+	appendNode := func(n node) {
+		top := &stack[len(stack)-1]
+		if n.kind == literalNode && len(top.nodes) > 0 && top.nodes[len(top.nodes)-1].kind == literalNode {
+			top.nodes[len(top.nodes)-1].text += n.text
+			return
+		}
+		top.nodes = append(top.nodes, n)
+	}
+
+	for _, t := range tokens {
+		switch {
+		case t.kind == groupNode && t.text != "":
+			stack = append(stack, frame{open: t})
+		case t.kind == groupNode && len(stack) > 1 && stack[len(stack)-1].open.close == t.close:
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			group := top.open
+			group.children = top.nodes
+			appendNode(group)
+		case t.kind == groupNode:
+			// An unbalanced close delimiter.
+			appendNode(node{kind: literalNode, text: t.close})
+		default:
+			appendNode(t)
+		}
+	}
+
+	// Unclosed groups are literal open delimiters followed by their nodes.
+	for len(stack) > 1 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		appendNode(node{kind: literalNode, text: top.open.text})
+		for _, n := range top.nodes {
+			appendNode(n)
+		}
+	}
+	return stack[0].nodes
+}
+
+// binding is the range of the source that a named hole matched.
+type binding struct {
+	name       string
+	start, end int
+}
+
+// matchBudget counts the steps of matching a file, including the steps of
+// matching the templates of its rule. Matching stops once err is set.
+type matchBudget struct {
+	ctx   context.Context
+	steps int
+	err   error
+}
+
+// spend takes a step and returns false if matching must stop, because the
+// budget is exceeded or the context is done.
+func (b *matchBudget) spend() bool {
+	b.steps++
+	if b.err == nil {
+		if b.steps > maxMatchSteps {
+			b.err = errMatchBudgetExceeded
+		} else if b.steps%ctxCheckSteps == 0 {
+			b.err = b.ctx.Err()
+		}
+	}
+	return b.err == nil
+}
+
+// matchState is the state of matching a template against a source.
+type matchState struct {
+	syntax   *syntax
+	src      []byte
+	bindings []binding
+	budget   *matchBudget
+}
+
+func (s *matchState) exhausted() bool {
+	return !s.budget.spend()
+}
+
+func (s *matchState) lookup(name string) (binding, bool) {
+	for i := len(s.bindings) - 1; i >= 0; i-- {
+		if s.bindings[i].name == name {
+			return s.bindings[i], true
+		}
+	}
+	return binding{}, false
+}
+
+// matchNodes matches nodes at position i of the source, and calls k with the
+// end of each match until k returns true. It backtracks over the possible
+// matches of holes: holes like :[x] match as little as possible, and holes
+// like :[[x]] and :[x.] match as much as possible.
+func (s *matchState) matchNodes(nodes []node, i int, k func(end int) bool) bool {
+	if len(nodes) == 0 {
+		return k(i)
+	}
+	if s.exhausted() {
+		return false
+	}
+
+	n, rest := &nodes[0], nodes[1:]
+	next := func(j int) bool {
+		return s.matchNodes(rest, j, k)
+	}
+
+	switch n.kind {
+	case literalNode:
+		if !hasPrefix(s.src[i:], n.text) {
+			return false
+		}
+		return next(i + len(n.text))
+
+	case whitespaceNode:
+		j := i
+		for j < len(s.src) && isSpace(s.src[j]) {
+			j++
+		}
+		if j == i && i > 0 && i < len(s.src) && isWordByte(s.src[i-1]) && isWordByte(s.src[i]) {
+			// Whitespace can only be omitted where it does not separate words.
+			return false
+		}
+		return next(j)
+
+	case groupNode:
+		if !hasPrefix(s.src[i:], n.text) {
+			return false
+		}
+		return s.matchNodes(n.children, i+len(n.text), func(j int) bool {
+			if !hasPrefix(s.src[j:], n.close) {
+				return false
+			}
+			return next(j + len(n.close))
+		})
+
+	case holeNode:
+		return s.matchHole(n.hole, i, next)
+	}
+	return false
+}
+
+func (s *matchState) matchHole(h hole, i int, next func(int) bool) bool {
+	bind := func(j int) bool {
+		if !h.named() {
+			return next(j)
+		}
+		s.bindings = append(s.bindings, binding{name: h.name, start: i, end: j})
+		ok := next(j)
+		s.bindings = s.bindings[:len(s.bindings)-1]
+		return ok
+	}
+
+	if h.named() {
+		if b, ok := s.lookup(h.name); ok {
+			value := s.src[b.start:b.end]
+			if !hasPrefix(s.src[i:], string(value)) {
+				return false
+			}
+			return next(i + len(value))
+		}
+	}
+
+	src := s.src
+	switch h.kind {
+	case holeAny:
+		if h.toEndOfLine {
+			j := i
+			for j < len(src) && src[j] != '\n' {
+				size := s.unit(src[j:])
+				if size <= 0 {
+					break
+				}
+				j += size
+			}
+			return bind(j)
+		}
+		for j := i; ; {
+			if bind(j) {
+				return true
+			}
+			size := s.unit(src[j:])
+			if size <= 0 || s.exhausted() {
+				return false
+			}
+			j += size
+		}
+
+	case holeAlphanum:
+		j := i
+		for j < len(src) {
+			r, size := utf8.DecodeRune(src[j:])
+			if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				break
+			}
+			j += size
+		}
+		for ; j > i; j-- {
+			if j == len(src) || utf8.RuneStart(src[j]) {
+				if bind(j) {
+					return true
+				}
+			}
+		}
+		return false
+
+	case holeNonSpace:
+		j := i
+		for j < len(src) && !isSpace(src[j]) {
+			_, size := utf8.DecodeRune(src[j:])
+			j += size
+		}
+		for ; j > i; j-- {
+			if j == len(src) || utf8.RuneStart(src[j]) {
+				if bind(j) {
+					return true
+				}
+			}
+		}
+		return false
+
+	case holeLine:
+		j := len(src)
+		if k := indexOf(src[i:], '\n'); k >= 0 {
+			j = i + k + 1
+		}
+		return bind(j)
+
+	case holeWhitespace:
+		j := i
+		for j < len(src) && (src[j] == ' ' || src[j] == '\t') {
+			j++
+		}
+		if j == i {
+			return false
+		}
+		return bind(j)
+
+	case holeRegexp:
+		loc := h.re.FindIndex(src[i:])
+		if loc == nil {
+			return false
+		}
+		return bind(i + loc[1])
+	}
+	return false
+}
+
+// unit returns the length of the next unit of src that a hole can match: a
+// comment, a string literal, text between balanced delimiters, or a single
+// character. It returns -1 at the end of src and at unbalanced close
+// delimiters, which holes never match.
+func (s *matchState) unit(src []byte) int {
+	if len(src) == 0 || s.syntax.isCloseDelimiter(src) {
+		return -1
+	}
+	if n := s.syntax.skipOpaque(src); n > 0 {
+		return n
+	}
+	if close, j, ok := s.syntax.openDelimiter(src); ok {
+		for j < len(src) {
+			if hasPrefix(src[j:], close) {
+				return j + len(close)
+			}
+			size := s.unit(src[j:])
+			if size <= 0 {
+				return -1
+			}
+			j += size
+		}
+		return -1
+	}
+	_, size := utf8.DecodeRune(src)
+	return size
+}
+
+// nativeMatch is a match of a template in a source.
+type nativeMatch struct {
+	start, end int
+	bindings   []binding
+}
+
+// nativeMatcher matches a compiled template and rule, which is what comby
+// does for each file.
+type nativeMatcher struct {
+	syntax *syntax
+	nodes  []node
+	rule   *rule
+
+	// startsWithWord and endsWithWord are true if the template starts and
+	// ends with a word, in which case matches cannot start or end within a
+	// word of the source.
+	startsWithWord, endsWithWord bool
+}
+
+func newNativeMatcher(template, ruleString, matcher string) (*nativeMatcher, error) {
+	syn := syntaxForMatcher(matcher)
+	nodes, err := compileTemplate(template, syn)
+	if err != nil {
+		return nil, err
+	}
+	r, err := parseRule(ruleString, syn)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &nativeMatcher{syntax: syn, nodes: nodes, rule: r}
+	if len(nodes) > 0 {
+		m.startsWithWord = isWordNode(nodes[0], true)
+		m.endsWithWord = isWordNode(nodes[len(nodes)-1], false)
+	}
+	return m, nil
+}
+
+func isWordNode(n node, start bool) bool {
+	switch n.kind {
+	case literalNode:
+		if start {
+			return isWordByte(n.text[0])
+		}
+		return isWordByte(n.text[len(n.text)-1])
+	case holeNode:
+		return n.hole.kind == holeAlphanum
+	}
+	return false
+}
+
+// matches returns the non-overlapping matches of the template in src, from
+// left to right. Matches never start in comments. If matching takes more than
+// maxMatchSteps or ctx is done, it returns the matches found so far with
+// errMatchBudgetExceeded or the error of ctx.
+func (m *nativeMatcher) matches(ctx context.Context, src []byte) ([]nativeMatch, error) {
+	b := &matchBudget{ctx: ctx}
+	matches := m.matchesWithBudget(b, src)
+	return matches, b.err
+}
+
+func (m *nativeMatcher) matchesWithBudget(b *matchBudget, src []byte) []nativeMatch {
+	if len(m.nodes) == 0 {
+		return nil
+	}
+
+	s := &matchState{syntax: m.syntax, src: src, budget: b}
+	var matches []nativeMatch
+	for i := 0; i < len(src); {
+		if b.err != nil {
+			break
+		}
+
+		if !m.startsWithWord || i == 0 || !isWordByte(src[i-1]) || !isWordByte(src[i]) {
+			var found *nativeMatch
+			s.matchNodes(m.nodes, i, func(j int) bool {
+				if j == i {
+					return false
+				}
+				if m.endsWithWord && j < len(src) && isWordByte(src[j-1]) && isWordByte(src[j]) {
+					return false
+				}
+				if m.rule != nil && !m.rule.eval(b, s.env()) {
+					return false
+				}
+				found = &nativeMatch{start: i, end: j, bindings: append([]binding(nil), s.bindings...)}
+				return true
+			})
+			if found != nil {
+				matches = append(matches, *found)
+				i = found.end
+				continue
+			}
+		}
+
+		if s.syntax.isComment(src[i:]) {
+			i += s.syntax.skipOpaque(src[i:])
+			continue
+		}
+		_, size := utf8.DecodeRune(src[i:])
+		i += size
+	}
+	return matches
+}
+
+// env returns the values of the holes bound in s.
+func (s *matchState) env() environment {
+	return func(name string) (string, bool) {
+		b, ok := s.lookup(name)
+		if !ok {
+			return "", false
+		}
+		return string(s.src[b.start:b.end]), true
+	}
+}
+
+// firstMatch returns the environment of the first match of the template in
+// value, for the cases of rules like `match :[x] { | "foo" -> ... }`. Its steps
+// are taken from the budget of the match that the rule is evaluated for.
+func (m *nativeMatcher) firstMatch(b *matchBudget, value string) (environment, bool) {
+	matches := m.matchesWithBudget(b, []byte(value))
+	if len(matches) == 0 {
+		return nil, false
+	}
+	s := &matchState{src: []byte(value), bindings: matches[0].bindings}
+	return s.env(), true
+}
+
+// environment returns the value of a named hole.
+type environment func(name string) (string, bool)
+
+// substitute substitutes the holes of a rewrite template with their values.
+// Holes that are not bound are left as is.
+func substitute(template string, env environment) string {
+	var b strings.Builder
+	for _, term := range parseTemplate([]byte(template)) {
+		if h, ok := term.(Hole); ok {
+			if parsed, ok, _ := parseHole(string(h)); ok && parsed.named() {
+				if value, ok := env(parsed.name); ok {
+					b.WriteString(value)
+					continue
+				}
+			}
+		}
+		b.WriteString(term.String())
+	}
+	return b.String()
+}
+
+// locator converts byte offsets of a source to comby locations, which have
+// 1-based lines and columns.
+type locator struct {
+	src        []byte
+	lineStarts []int
+}
+
+func newLocator(src []byte) *locator {
+	lineStarts := []int{0}
+	for i, c := range src {
+		if c == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	return &locator{src: src, lineStarts: lineStarts}
+}
+
+func (l *locator) location(offset int) Location {
+	line := sort.Search(len(l.lineStarts), func(i int) bool { return l.lineStarts[i] > offset }) - 1
+	return Location{
+		Offset: offset,
+		Line:   line + 1,
+		Column: utf8.RuneCount(l.src[l.lineStarts[line]:offset]) + 1,
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= utf8.RuneSelf
+}
+
+func indexOf(src []byte, c byte) int {
+	for i := range src {
+		if src[i] == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,287 @@
+package comby
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestNativeMatcher(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+		rule     string
+		matcher  string
+		src      string
+		want     []string
+	}{
+		{
+			name:     "hole matches balanced delimiters",
+			template: "foo(:[args])",
+			src:      "foo(bar(1, 2), [3]) foo()",
+			want:     []string{"foo(bar(1, 2), [3])", "foo()"},
+		},
+		{
+			name:     "hole does not match unbalanced delimiters",
+			template: "(:[x])",
+			src:      "(a) b) (c",
+			want:     []string{"(a)"},
+		},
+		{
+			name:     "nested matches are not returned",
+			template: "(:[_])",
+			src:      "(a (b) c) (d)",
+			want:     []string{"(a (b) c)", "(d)"},
+		},
+		{
+			name:     "ellipsis",
+			template: "if ... {",
+			src:      "if err != nil {\n}",
+			want:     []string{"if err != nil {"},
+		},
+		{
+			name:     "whitespace matches any whitespace",
+			template: "a  +   b",
+			src:      "a+b a +\n\tb",
+			want:     []string{"a+b", "a +\n\tb"},
+		},
+		{
+			name:     "whitespace does not join words",
+			template: "foo :[[x]]",
+			src:      "foobar foo bar",
+			want:     []string{"foo bar"},
+		},
+		{
+			name:     "alphanumeric hole",
+			template: "x.:[[field]]",
+			src:      "x.foo_bar1 + x.(baz)",
+			want:     []string{"x.foo_bar1"},
+		},
+		{
+			name:     "non-space hole",
+			template: "go get :[x.]",
+			src:      "go get foo.bar/baz@v1 -u",
+			want:     []string{"go get foo.bar/baz@v1"},
+		},
+		{
+			name:     "line hole",
+			template: "// :[line\\n]",
+			src:      "// a comment\ncode",
+			want:     []string{"// a comment\n"},
+		},
+		{
+			name:     "regexp hole",
+			template: "v:[x~\\d+]",
+			src:      "va v10 v2b",
+			want:     []string{"v10", "v2"},
+		},
+		{
+			name:     "trailing hole matches to the end of the line",
+			template: "return :[x]",
+			src:      "return a, b\nreturn c",
+			want:     []string{"return a, b", "return c"},
+		},
+		{
+			name:     "repeated holes match the same text",
+			template: ":[x] == :[x];",
+			src:      "a == b; c == c;",
+			want:     []string{"c == c;"},
+		},
+		{
+			name:     "delimiters in strings are opaque",
+			template: "print(:[x])",
+			matcher:  ".go",
+			src:      `print(")") print(')')`,
+			want:     []string{`print(")")`, `print(')')`},
+		},
+		{
+			name:     "matches do not start in comments",
+			template: "foo(:[x])",
+			matcher:  ".go",
+			src:      "/* foo(a) */ // foo(b)\nfoo(c)",
+			want:     []string{"foo(c)"},
+		},
+		{
+			name:     "matches start in comments of the generic language",
+			template: "foo(:[x])",
+			src:      "/* foo(a) */",
+			want:     []string{"foo(a)"},
+		},
+		{
+			name:     "python comments and strings",
+			template: "f(:[x])",
+			matcher:  ".py",
+			src:      "# f(a)\nf('''\n)''')",
+			want:     []string{"f('''\n)''')"},
+		},
+		{
+			name:     "rule",
+			template: "func :[[fn]](:[args])",
+			rule:     `where :[args] == "success"`,
+			src:      "func foo(success) {} func bar(fail) {}",
+			want:     []string{"func foo(success)"},
+		},
+		{
+			name:     "rule with match",
+			template: "foo(:[x])",
+			rule:     `where match :[x] { | "bar(:[y])" -> :[y] != "1" | "baz" -> true }`,
+			src:      "foo(bar(1)) foo(bar(2)) foo(baz) foo(qux)",
+			want:     []string{"foo(bar(2))", "foo(baz)"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := newNativeMatcher(tc.template, tc.rule, tc.matcher)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			matches, err := m.matches(context.Background(), []byte(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, match := range matches {
+				got = append(got, tc.src[match.start:match.end])
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected matches (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNativeMatcherBudget(t *testing.T) {
+	// Lazy holes followed by a literal that is not in the source backtrack
+	// over every way of splitting the source between the holes.
+	m, err := newNativeMatcher(":[a]:[b]:[c]:[d]x", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := []byte("1x " + strings.Repeat("a", 1000))
+
+	t.Run("steps", func(t *testing.T) {
+		_, err := m.matches(context.Background(), src)
+		if !errors.Is(err, errMatchBudgetExceeded) {
+			t.Fatalf("got error %v, want %v", err, errMatchBudgetExceeded)
+		}
+
+		var got []*FileMatch
+		err = NativeMatches(context.Background(), Args{Input: FileContent(src), MatchTemplate: ":[a]:[b]:[c]:[d]x"}, func(fm *FileMatch, _ []byte) {
+			got = append(got, fm)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The match before the pathological part of the source is found.
+		want := []*FileMatch{{
+			Matches: []Match{{
+				Range: Range{
+					Start: Location{Offset: 0, Line: 1, Column: 1},
+					End:   Location{Offset: 2, Line: 1, Column: 3},
+				},
+				Matched: "1x",
+			}},
+			LimitHit: true,
+		}}
+		if !cmp.Equal(want, got) {
+			t.Errorf("unexpected matches (-want +got):\n%s", cmp.Diff(want, got))
+		}
+
+		_, err = nativeOutputs(context.Background(), Args{Input: FileContent(src), MatchTemplate: ":[a]:[b]:[c]:[d]x", RewriteTemplate: ":[a]"})
+		if !errors.Is(err, errMatchBudgetExceeded) {
+			t.Fatalf("got error %v, want %v", err, errMatchBudgetExceeded)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := m.matches(ctx, src)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got error %v, want %v", err, context.Canceled)
+		}
+	})
+}
+
+func TestNativeMatcherLocations(t *testing.T) {
+	ctx := context.Background()
+	var got []*FileMatch
+	err := NativeMatches(ctx, Args{
+		Input:         FileContent("a\nfoo(\n  ü)"),
+		MatchTemplate: "(:[x])",
+	}, func(fm *FileMatch, _ []byte) {
+		got = append(got, fm)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*FileMatch{{
+		Matches: []Match{{
+			Range: Range{
+				Start: Location{Offset: 5, Line: 2, Column: 4},
+				End:   Location{Offset: 12, Line: 3, Column: 5},
+			},
+			Matched: "(\n  ü)",
+		}},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected matches (-want +got):\n%s", diff)
+	}
+}
+
+func TestNativeRewrites(t *testing.T) {
+	ctx := context.Background()
+	args := Args{
+		Input:           FileContent("Im a train. train(intercity, regional). train(lightrail, commuter)"),
+		MatchTemplate:   "train(:[x], :[y])",
+		RewriteTemplate: "train(:[y], :[x])",
+	}
+
+	replacements, err := nativeReplacements(ctx, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*FileReplacement{{Content: "Im a train. train(regional, intercity). train(commuter, lightrail)"}}
+	if diff := cmp.Diff(want, replacements); diff != "" {
+		t.Errorf("unexpected replacements (-want +got):\n%s", diff)
+	}
+
+	output, err := nativeOutputs(ctx, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "train(regional, intercity)\ntrain(commuter, lightrail)"; output != want {
+		t.Errorf("got output %q, want %q", output, want)
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	for _, rule := range []string{
+		`where true`,
+		`where :[x] == "a", :[y] != :[x]`,
+		`where match :[x] { | "a" -> true | ":[_]" -> false }`,
+		`where match :[x] { | "a" -> match :[y] { | "b" -> true } }`,
+	} {
+		if _, err := parseRule(rule, genericSyntax); err != nil {
+			t.Errorf("unexpected error parsing %q: %s", rule, err)
+		}
+	}
+
+	for _, rule := range []string{
+		`:[x] == "a"`,
+		`where :[x]`,
+		`where :[x] == "a`,
+		`where rewrite :[x] { "a" -> "b" }`,
+		`where match :[x] { | "a" }`,
+	} {
+		if _, err := parseRule(rule, genericSyntax); err == nil {
+			t.Errorf("expected error parsing %q", rule)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package comby
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var useNativeMatcher, _ = strconv.ParseBool(env.Get("COMBY_NATIVE_MATCHER", "false", "Use the in-process structural search matcher instead of the comby binary."))
+
+var (
+	nativeMatcherOnce sync.Once
+	nativeMatcherUsed bool
+)
+
+// UseNativeMatcher returns true if structural search should use the native
+// matcher, which implements comby's template syntax and the common rules
+// in-process. It is used if COMBY_NATIVE_MATCHER is set, or if the comby
+// binary is not installed. This is decided on the first call, which logs the
+// matcher that is used, so services should call it at startup.
+func UseNativeMatcher() bool {
+	nativeMatcherOnce.Do(func() {
+		switch {
+		case useNativeMatcher:
+			nativeMatcherUsed = true
+			log15.Info("structural search uses the native matcher, since COMBY_NATIVE_MATCHER is set")
+		case !Exists():
+			nativeMatcherUsed = true
+			log15.Warn("comby is not installed (it could not be found on the PATH), structural search falls back to the native matcher")
+		default:
+			log15.Info("structural search uses the comby binary")
+		}
+	})
+	return nativeMatcherUsed
+}
+
+// NativeMatches runs the native matcher over the files of the input of args,
+// and calls onMatch with the matches and content of each file that has
+// matches. Calls to onMatch are serialized. If the match template is empty,
+// onMatch is called without matches for every file, as comby does.
+//
+// If matching a file takes too many steps, onMatch is called with the matches
+// found so far and LimitHit set, even if there are none.
+func NativeMatches(ctx context.Context, args Args, onMatch func(fm *FileMatch, content []byte)) error {
+	m, err := newNativeMatcher(args.MatchTemplate, args.Rule, args.Matcher)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	return walkInput(ctx, args, func(uri string, content []byte) {
+		fm := &FileMatch{URI: uri}
+		if len(m.nodes) > 0 {
+			matches, err := m.matches(ctx, content)
+			if err != nil && !errors.Is(err, errMatchBudgetExceeded) {
+				// The context is done, which walkInput returns.
+				return
+			}
+			fm.LimitHit = err != nil
+			if len(matches) == 0 && !fm.LimitHit {
+				return
+			}
+			loc := newLocator(content)
+			fm.Matches = make([]Match, 0, len(matches))
+			for _, match := range matches {
+				fm.Matches = append(fm.Matches, Match{
+					Range: Range{
+						Start: loc.location(match.start),
+						End:   loc.location(match.end),
+					},
+					Matched: string(content[match.start:match.end]),
+				})
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		onMatch(fm, content)
+	})
+}
+
+// nativeRewrites calls onRewrite with the content of each file that has
+// matches and the match template substituted in each match. Since a partial
+// rewrite would be wrong, it returns errMatchBudgetExceeded if matching a file
+// takes too many steps.
+func nativeRewrites(ctx context.Context, args Args, onRewrite func(uri string, content []byte, matches []nativeMatch, rewrites []string)) error {
+	m, err := newNativeMatcher(args.MatchTemplate, args.Rule, args.Matcher)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var budgetErr error
+	err = walkInput(ctx, args, func(uri string, content []byte) {
+		matches, err := m.matches(ctx, content)
+		if errors.Is(err, errMatchBudgetExceeded) {
+			mu.Lock()
+			budgetErr = errors.Wrapf(err, "matching %q", uri)
+			mu.Unlock()
+			return
+		}
+		if err != nil || len(matches) == 0 {
+			return
+		}
+		rewrites := make([]string, 0, len(matches))
+		for _, match := range matches {
+			s := &matchState{src: content, bindings: match.bindings}
+			rewrites = append(rewrites, substitute(args.RewriteTemplate, s.env()))
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		onRewrite(uri, content, matches, rewrites)
+	})
+	if err != nil {
+		return err
+	}
+	return budgetErr
+}
+
+func nativeReplacements(ctx context.Context, args Args) ([]*FileReplacement, error) {
+	var replacements []*FileReplacement
+	err := nativeRewrites(ctx, args, func(uri string, content []byte, matches []nativeMatch, rewrites []string) {
+		var b strings.Builder
+		last := 0
+		for i, match := range matches {
+			b.Write(content[last:match.start])
+			b.WriteString(rewrites[i])
+			last = match.end
+		}
+		b.Write(content[last:])
+		replacements = append(replacements, &FileReplacement{URI: uri, Content: b.String()})
+	})
+	return replacements, err
+}
+
+func nativeOutputs(ctx context.Context, args Args) (string, error) {
+	var values []string
+	err := nativeRewrites(ctx, args, func(_ string, _ []byte, _ []nativeMatch, rewrites []string) {
+		values = append(values, rewrites...)
+	})
+	return strings.Join(values, "\n"), err
+}
+
+// walkInput calls f with the path and content of each file of the input of
+// args that matches its file patterns, using args.NumWorkers goroutines.
+func walkInput(ctx context.Context, args Args, f func(uri string, content []byte)) error {
+	numWorkers := args.NumWorkers
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+
+	type file struct {
+		uri     string
+		content []byte
+	}
+	files := make(chan file)
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range files {
+				if ctx.Err() == nil {
+					f(file.uri, file.content)
+				}
+			}
+		}()
+	}
+
+	send := func(uri string, content []byte) error {
+		if !matchesFilePatterns(uri, args.FilePatterns) {
+			return nil
+		}
+		select {
+		case files <- file{uri: uri, content: content}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	err := produceInput(args.Input, send)
+	close(files)
+	wg.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// produceInput calls send with the path and content of each file of input
+// until send returns an error.
+func produceInput(input Input, send func(uri string, content []byte) error) error {
+	switch i := input.(type) {
+	case FileContent:
+		return send("", i)
+
+	case ZipPath:
+		zr, err := zip.OpenReader(string(i))
+		if err != nil {
+			return errors.Wrap(err, "failed to open zip input")
+		}
+		defer zr.Close()
+		for _, zf := range zr.File {
+			if zf.FileInfo().IsDir() {
+				continue
+			}
+			content, err := readZipFile(zf)
+			if err != nil {
+				return err
+			}
+			if err := send(zf.Name, content); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case DirPath:
+		return filepath.WalkDir(string(i), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(string(i), path)
+			if err != nil {
+				return err
+			}
+			return send(filepath.ToSlash(rel), content)
+		})
+
+	case Tar:
+		var err error
+		// The channel is drained even if sending fails, since its producer
+		// blocks until it is read.
+		for event := range i.TarInputEventC {
+			if err == nil {
+				err = send(event.Header.Name, event.Content)
+			}
+		}
+		return err
+	}
+
+	log15.Error("unrecognized input type", "type", input)
+	return errors.Errorf("unrecognized comby input type %T", input)
+}
+
+func readZipFile(zf *zip.File) ([]byte, error) {
+	r, err := zf.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s in zip input", zf.Name)
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// matchesFilePatterns returns true if the path ends with one of the file
+// patterns, or if there are none. This is how comby interprets -f.
+func matchesFilePatterns(path string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if strings.HasSuffix(path, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,336 @@
+package comby
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// rule is a comby rule that the native matcher supports, like
+//
+//	where :[x] != "nil", match :[y] { | "err" -> true | :[_] -> false }
+//
+// A match satisfies a rule if it satisfies all of its clauses.
+type rule struct {
+	clauses []clause
+}
+
+func (r *rule) eval(b *matchBudget, env environment) bool {
+	return evalClauses(b, r.clauses, env)
+}
+
+type clause interface {
+	eval(b *matchBudget, env environment) bool
+}
+
+func evalClauses(b *matchBudget, clauses []clause, env environment) bool {
+	for _, c := range clauses {
+		if !c.eval(b, env) {
+			return false
+		}
+	}
+	return true
+}
+
+// constantClause is the clause `true` or `false`.
+type constantClause bool
+
+func (c constantClause) eval(*matchBudget, environment) bool {
+	return bool(c)
+}
+
+// compareClause is a clause like `:[x] == "foo"` or `:[x] != :[y]`.
+type compareClause struct {
+	left, right atom
+	equal       bool
+}
+
+func (c compareClause) eval(_ *matchBudget, env environment) bool {
+	return (c.left.value(env) == c.right.value(env)) == c.equal
+}
+
+// matchClause is a clause like `match :[x] { | "foo(:[y])" -> :[y] != "" }`.
+// The clauses of the first case whose template matches in the value of the
+// atom decide the clause, and the holes of the first match of the case
+// template are bound in them. The clause is false if no case matches.
+type matchClause struct {
+	atom  atom
+	cases []matchCase
+}
+
+type matchCase struct {
+	template *nativeMatcher
+	clauses  []clause
+}
+
+func (c matchClause) eval(b *matchBudget, env environment) bool {
+	value := c.atom.value(env)
+	for _, mc := range c.cases {
+		caseEnv, ok := mc.template.firstMatch(b, value)
+		if !ok {
+			continue
+		}
+		return evalClauses(b, mc.clauses, func(name string) (string, bool) {
+			if v, ok := caseEnv(name); ok {
+				return v, true
+			}
+			return env(name)
+		})
+	}
+	return false
+}
+
+// atom is a hole like `:[x]` or a string like `"foo :[x]"`, in which holes
+// are substituted with their values.
+type atom struct {
+	text   string
+	isHole bool
+}
+
+func (a atom) value(env environment) string {
+	if a.isHole {
+		if h, ok, _ := parseHole(a.text); ok && h.named() {
+			if v, ok := env(h.name); ok {
+				return v
+			}
+		}
+		return a.text
+	}
+	return substitute(a.text, env)
+}
+
+// parseRule parses a comby rule. It returns nil if the rule is empty.
+// Rewrite clauses are not supported.
+func parseRule(ruleString string, syn *syntax) (*rule, error) {
+	if strings.TrimSpace(ruleString) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenizeRule(ruleString)
+	if err != nil {
+		return nil, err
+	}
+	p := &ruleParser{tokens: tokens, syntax: syn}
+	if !p.accept("where") {
+		return nil, p.errorf("expected 'where'")
+	}
+	clauses, err := p.parseClauses()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+	return &rule{clauses: clauses}, nil
+}
+
+type ruleToken struct {
+	text string
+	// quoted is true for strings, whose text is unquoted.
+	quoted bool
+}
+
+// tokenizeRule splits a rule into keywords, operators, holes and strings.
+func tokenizeRule(s string) ([]ruleToken, error) {
+	var tokens []ruleToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+
+		case strings.HasPrefix(s[i:], ":["):
+			// Holes end at the first ']' that balances their '['.
+			depth, j := 0, i+1
+			for ; j < len(s); j++ {
+				if s[j] == '[' {
+					depth++
+				} else if s[j] == ']' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if j == len(s) {
+				return nil, errors.Errorf("invalid rule: unterminated hole %q", s[i:])
+			}
+			tokens = append(tokens, ruleToken{text: s[i : j+1]})
+			i = j + 1
+
+		case c == '"' || c == '\'' || c == '`':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && c != '`' && j+1 < len(s) {
+					j++
+					switch s[j] {
+					case 'n':
+						b.WriteByte('\n')
+					case 't':
+						b.WriteByte('\t')
+					default:
+						b.WriteByte(s[j])
+					}
+					continue
+				}
+				b.WriteByte(s[j])
+			}
+			if j == len(s) {
+				return nil, errors.Errorf("invalid rule: unterminated string %q", s[i:])
+			}
+			tokens = append(tokens, ruleToken{text: b.String(), quoted: true})
+			i = j + 1
+
+		case strings.HasPrefix(s[i:], "==") || strings.HasPrefix(s[i:], "!=") || strings.HasPrefix(s[i:], "->"):
+			tokens = append(tokens, ruleToken{text: s[i : i+2]})
+			i += 2
+
+		case strings.ContainsRune(",{}|", rune(c)):
+			tokens = append(tokens, ruleToken{text: s[i : i+1]})
+			i++
+
+		case isWordByte(c):
+			j := i
+			for j < len(s) && isWordByte(s[j]) {
+				j++
+			}
+			tokens = append(tokens, ruleToken{text: s[i:j]})
+			i = j
+
+		default:
+			return nil, errors.Errorf("invalid rule: unexpected %q", string(c))
+		}
+	}
+	return tokens, nil
+}
+
+type ruleParser struct {
+	tokens []ruleToken
+	syntax *syntax
+}
+
+func (p *ruleParser) done() bool {
+	return len(p.tokens) == 0
+}
+
+func (p *ruleParser) peek() ruleToken {
+	if p.done() {
+		return ruleToken{}
+	}
+	return p.tokens[0]
+}
+
+// accept consumes the next token if it is the keyword or operator text.
+func (p *ruleParser) accept(text string) bool {
+	if t := p.peek(); !p.done() && !t.quoted && t.text == text {
+		p.tokens = p.tokens[1:]
+		return true
+	}
+	return false
+}
+
+func (p *ruleParser) errorf(format string, args ...any) error {
+	return errors.Errorf("invalid rule: %s", fmt.Sprintf(format, args...))
+}
+
+// parseClauses parses comma-separated clauses.
+func (p *ruleParser) parseClauses() ([]clause, error) {
+	var clauses []clause
+	for {
+		c, err := p.parseClause()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, c)
+		if !p.accept(",") {
+			return clauses, nil
+		}
+	}
+}
+
+func (p *ruleParser) parseClause() (clause, error) {
+	switch {
+	case p.accept("true"):
+		return constantClause(true), nil
+	case p.accept("false"):
+		return constantClause(false), nil
+	case p.accept("match"):
+		return p.parseMatch()
+	case p.accept("rewrite"):
+		return nil, p.errorf("rewrite clauses are not supported")
+	}
+
+	left, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	var equal bool
+	switch {
+	case p.accept("=="):
+		equal = true
+	case p.accept("!="):
+		equal = false
+	default:
+		return nil, p.errorf("expected '==' or '!=' after %q", left.text)
+	}
+	right, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	return compareClause{left: left, right: right, equal: equal}, nil
+}
+
+func (p *ruleParser) parseMatch() (clause, error) {
+	a, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("{") {
+		return nil, p.errorf("expected '{' after 'match %s'", a.text)
+	}
+
+	var cases []matchCase
+	for p.accept("|") {
+		pattern, err := p.parseAtom()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept("->") {
+			return nil, p.errorf("expected '->' after case %q", pattern.text)
+		}
+		clauses, err := p.parseClauses()
+		if err != nil {
+			return nil, err
+		}
+
+		template, err := newNativeMatcher(pattern.text, "", "")
+		if err != nil {
+			return nil, err
+		}
+		template.syntax = p.syntax
+		cases = append(cases, matchCase{template: template, clauses: clauses})
+	}
+	if !p.accept("}") {
+		return nil, p.errorf("expected '|' or '}' in 'match %s'", a.text)
+	}
+	return matchClause{atom: a, cases: cases}, nil
+}
+
+func (p *ruleParser) parseAtom() (atom, error) {
+	if p.done() {
+		return atom{}, p.errorf("unexpected end of rule")
+	}
+	t := p.tokens[0]
+	if t.quoted {
+		p.tokens = p.tokens[1:]
+		return atom{text: t.text}, nil
+	}
+	if _, ok, _ := parseHole(t.text); ok {
+		p.tokens = p.tokens[1:]
+		return atom{text: t.text, isHole: true}, nil
+	}
+	return atom{}, p.errorf("expected a hole or a string, got %q", t.text)
+}
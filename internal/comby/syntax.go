@@ -0,0 +1,224 @@
+package comby
+
+import (
+	"bytes"
+	"strings"
+)
+
+// stringLiteral describes the delimiters of a kind of string literal.
+type stringLiteral struct {
+	open, close string
+	// escape is the character that escapes the close delimiter, or 0 if the
+	// string literal is raw.
+	escape byte
+	// multiline is true if the string literal can span lines.
+	multiline bool
+}
+
+// syntax describes the lexical structure of a language that the native
+// matcher needs to know about: holes never match unbalanced delimiters, and
+// delimiters inside of string literals and comments are ignored.
+type syntax struct {
+	delimiters    [][2]string
+	strings       []stringLiteral
+	lineComments  []string
+	blockComments [][2]string
+}
+
+var defaultDelimiters = [][2]string{{"(", ")"}, {"[", "]"}, {"{", "}"}}
+
+var (
+	doubleQuoted = stringLiteral{open: `"`, close: `"`, escape: '\\'}
+	singleQuoted = stringLiteral{open: `'`, close: `'`, escape: '\\'}
+	backQuoted   = stringLiteral{open: "`", close: "`", multiline: true}
+
+	cStyleComments = [][2]string{{"/*", "*/"}}
+)
+
+var (
+	genericSyntax = &syntax{
+		delimiters: defaultDelimiters,
+		strings:    []stringLiteral{doubleQuoted},
+	}
+
+	goSyntax = &syntax{
+		delimiters:    defaultDelimiters,
+		strings:       []stringLiteral{doubleQuoted, singleQuoted, backQuoted},
+		lineComments:  []string{"//"},
+		blockComments: cStyleComments,
+	}
+
+	// cSyntax is the syntax of C and of the languages with C-style strings
+	// and comments, like Java and C#.
+	cSyntax = &syntax{
+		delimiters:    defaultDelimiters,
+		strings:       []stringLiteral{doubleQuoted, singleQuoted},
+		lineComments:  []string{"//"},
+		blockComments: cStyleComments,
+	}
+
+	javaScriptSyntax = &syntax{
+		delimiters:    defaultDelimiters,
+		strings:       []stringLiteral{doubleQuoted, singleQuoted, backQuoted},
+		lineComments:  []string{"//"},
+		blockComments: cStyleComments,
+	}
+
+	// rustSyntax does not include single-quoted strings, since a single quote
+	// also starts a lifetime.
+	rustSyntax = &syntax{
+		delimiters:    defaultDelimiters,
+		strings:       []stringLiteral{doubleQuoted},
+		lineComments:  []string{"//"},
+		blockComments: cStyleComments,
+	}
+
+	pythonSyntax = &syntax{
+		delimiters: defaultDelimiters,
+		strings: []stringLiteral{
+			{open: `"""`, close: `"""`, escape: '\\', multiline: true},
+			{open: `'''`, close: `'''`, escape: '\\', multiline: true},
+			doubleQuoted,
+			singleQuoted,
+		},
+		lineComments: []string{"#"},
+	}
+
+	// shellSyntax is the syntax of shell scripts and of Ruby.
+	shellSyntax = &syntax{
+		delimiters:   defaultDelimiters,
+		strings:      []stringLiteral{doubleQuoted, singleQuoted},
+		lineComments: []string{"#"},
+	}
+
+	sqlSyntax = &syntax{
+		delimiters:    defaultDelimiters,
+		strings:       []stringLiteral{{open: `'`, close: `'`}, doubleQuoted},
+		lineComments:  []string{"--"},
+		blockComments: cStyleComments,
+	}
+
+	haskellSyntax = &syntax{
+		delimiters:    defaultDelimiters,
+		strings:       []stringLiteral{doubleQuoted},
+		lineComments:  []string{"--"},
+		blockComments: [][2]string{{"{-", "-}"}},
+	}
+
+	htmlSyntax = &syntax{
+		delimiters:    defaultDelimiters,
+		strings:       []stringLiteral{doubleQuoted},
+		blockComments: [][2]string{{"<!--", "-->"}},
+	}
+)
+
+// syntaxForMatcher returns the syntax of the language of a comby matcher,
+// which is a representative file extension like ".go". Languages that the
+// native matcher does not know about are matched with the generic syntax.
+func syntaxForMatcher(matcher string) *syntax {
+	switch strings.ToLower(matcher) {
+	case ".go":
+		return goSyntax
+	case ".c", ".h", ".cc", ".cpp", ".cs", ".java", ".kt", ".scala", ".swift", ".dart", ".php", ".css":
+		return cSyntax
+	case ".js", ".jsx", ".ts", ".tsx":
+		return javaScriptSyntax
+	case ".rs":
+		return rustSyntax
+	case ".py":
+		return pythonSyntax
+	case ".sh", ".rb":
+		return shellSyntax
+	case ".sql":
+		return sqlSyntax
+	case ".hs", ".elm":
+		return haskellSyntax
+	case ".html", ".xml":
+		return htmlSyntax
+	}
+	return genericSyntax
+}
+
+// openDelimiter returns the close delimiter of the open delimiter at the
+// start of src.
+func (s *syntax) openDelimiter(src []byte) (close string, size int, ok bool) {
+	for _, d := range s.delimiters {
+		if hasPrefix(src, d[0]) {
+			return d[1], len(d[0]), true
+		}
+	}
+	return "", 0, false
+}
+
+// isCloseDelimiter returns true if src starts with a close delimiter.
+func (s *syntax) isCloseDelimiter(src []byte) bool {
+	for _, d := range s.delimiters {
+		if hasPrefix(src, d[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipOpaque returns the length of the comment or string literal at the start
+// of src, or 0 if src does not start with one. The content of comments and
+// string literals is opaque to the matcher: their delimiters do not need to
+// be balanced. An unterminated block comment extends to the end of src, but a
+// quote that does not start a terminated string literal is an ordinary
+// character, like the apostrophe in "don't".
+func (s *syntax) skipOpaque(src []byte) int {
+	for _, prefix := range s.lineComments {
+		if hasPrefix(src, prefix) {
+			if i := bytes.IndexByte(src, '\n'); i >= 0 {
+				return i
+			}
+			return len(src)
+		}
+	}
+	for _, c := range s.blockComments {
+		if hasPrefix(src, c[0]) {
+			if i := bytes.Index(src[len(c[0]):], []byte(c[1])); i >= 0 {
+				return len(c[0]) + i + len(c[1])
+			}
+			return len(src)
+		}
+	}
+	for _, lit := range s.strings {
+		if !hasPrefix(src, lit.open) {
+			continue
+		}
+		for i := len(lit.open); i < len(src); i++ {
+			if lit.escape != 0 && src[i] == lit.escape {
+				i++
+				continue
+			}
+			if hasPrefix(src[i:], lit.close) {
+				return i + len(lit.close)
+			}
+			if src[i] == '\n' && !lit.multiline {
+				break
+			}
+		}
+		return 0
+	}
+	return 0
+}
+
+// isComment returns true if src starts with a comment.
+func (s *syntax) isComment(src []byte) bool {
+	for _, prefix := range s.lineComments {
+		if hasPrefix(src, prefix) {
+			return true
+		}
+	}
+	for _, c := range s.blockComments {
+		if hasPrefix(src, c[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPrefix(src []byte, prefix string) bool {
+	return len(src) >= len(prefix) && string(src[:len(prefix)]) == prefix
+}
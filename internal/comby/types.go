@@ -31,7 +31,8 @@ const (
 	MatchOnly resultKind = iota
 	// Replacement means comby returns the result of performing an in-place operation on file contents
 	Replacement
-	// Diff means comby returns a diff after performing an in-place operation on file contents
+	// Diff means comby returns a diff after performing an in-place operation on file contents.
+	// It is not supported by the native matcher, see UseNativeMatcher.
 	Diff
 	// NewlineSeparatedOutput means output the result of substituting the rewrite
 	// template, newline-separated for each result.
@@ -116,6 +117,10 @@ type FileMatchWithChunks struct {
 type FileMatch struct {
 	URI     string  `json:"uri"`
 	Matches []Match `json:"matches"`
+
+	// LimitHit is true if the native matcher stopped matching the file after
+	// too many steps, so Matches may be incomplete.
+	LimitHit bool `json:"-"`
 }
 
 // FileDiff represents a diff for a file
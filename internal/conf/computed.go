@@ -137,6 +137,15 @@ func SearchIndexEnabled() bool {
 	return true // always on by default in all deployment types, see confdefaults.go
 }
 
+// SearchHistoryEnabled returns true if the searches that users run are saved
+// to their search history.
+func SearchHistoryEnabled() bool {
+	if v := Get().SearchHistoryEnabled; v != nil {
+		return *v
+	}
+	return true
+}
+
 func BatchChangesEnabled() bool {
 	if enabled := Get().BatchChangesEnabled; enabled != nil {
 		return *enabled
@@ -425,6 +434,18 @@ func GitMaxCodehostRequestsPerSecond() int {
 	return *val
 }
 
+const defaultGitPartialCloneMaxFetchedObjects = 1000
+
+// GitPartialCloneMaxFetchedObjects returns the maximum number of missing
+// objects that gitserver fetches from the code host for a single request to a
+// partial clone. If not set, it returns the default value.
+func GitPartialCloneMaxFetchedObjects() int {
+	if c := Get().GitPartialClones; c != nil && c.MaxFetchedObjectsPerRequest > 0 {
+		return c.MaxFetchedObjectsPerRequest
+	}
+	return defaultGitPartialCloneMaxFetchedObjects
+}
+
 func GitMaxConcurrentClones() int {
 	v := Get().GitMaxConcurrentClones
 	if v <= 0 {
@@ -173,6 +173,38 @@ func TestGitMaxCodehostRequestsPerSecond(t *testing.T) {
 	}
 }
 
+func TestGitPartialCloneMaxFetchedObjects(t *testing.T) {
+	tests := []struct {
+		name string
+		sc   *Unified
+		want int
+	}{
+		{
+			name: "not set should return default",
+			sc:   &Unified{SiteConfiguration: schema.SiteConfiguration{}},
+			want: defaultGitPartialCloneMaxFetchedObjects,
+		},
+		{
+			name: "unset limit should return default",
+			sc:   &Unified{SiteConfiguration: schema.SiteConfiguration{GitPartialClones: &schema.GitPartialClones{Repos: []string{"^foo$"}}}},
+			want: defaultGitPartialCloneMaxFetchedObjects,
+		},
+		{
+			name: "set limit should return limit",
+			sc:   &Unified{SiteConfiguration: schema.SiteConfiguration{GitPartialClones: &schema.GitPartialClones{MaxFetchedObjectsPerRequest: 50}}},
+			want: 50,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Mock(test.sc)
+			if got, want := GitPartialCloneMaxFetchedObjects(), test.want; got != want {
+				t.Fatalf("GitPartialCloneMaxFetchedObjects() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestGitMaxConcurrentClones(t *testing.T) {
 	tests := []struct {
 		name string
@@ -212,8 +212,17 @@ var siteConfigSecrets = []struct {
 	{readPath: `gitHubApp.privateKey`, editPaths: []string{"gitHubApp", "privateKey"}},
 	{readPath: `gitHubApp.clientSecret`, editPaths: []string{"gitHubApp", "clientSecret"}},
 	{readPath: `auth\.unlockAccountLinkSigningKey`, editPaths: []string{"auth.unlockAccountLinkSigningKey"}},
+	{readPath: `scim\.authToken`, editPaths: []string{"scim.authToken"}},
 	{readPath: `dotcom.srcCliVersionCache.github.token`, editPaths: []string{"dotcom", "srcCliVersionCache", "github", "token"}},
 	{readPath: `dotcom.srcCliVersionCache.github.webhookSecret`, editPaths: []string{"dotcom", "srcCliVersionCache", "github", "webhookSecret"}},
+	{readPath: `encryption\.keys.batchChangesCredentialKey.token`, editPaths: []string{"encryption.keys", "batchChangesCredentialKey", "token"}},
+	{readPath: `encryption\.keys.batchChangesCredentialKey.appRole.secretId`, editPaths: []string{"encryption.keys", "batchChangesCredentialKey", "appRole", "secretId"}},
+	{readPath: `encryption\.keys.externalServiceKey.token`, editPaths: []string{"encryption.keys", "externalServiceKey", "token"}},
+	{readPath: `encryption\.keys.externalServiceKey.appRole.secretId`, editPaths: []string{"encryption.keys", "externalServiceKey", "appRole", "secretId"}},
+	{readPath: `encryption\.keys.userExternalAccountKey.token`, editPaths: []string{"encryption.keys", "userExternalAccountKey", "token"}},
+	{readPath: `encryption\.keys.userExternalAccountKey.appRole.secretId`, editPaths: []string{"encryption.keys", "userExternalAccountKey", "appRole", "secretId"}},
+	{readPath: `encryption\.keys.webhookLogKey.token`, editPaths: []string{"encryption.keys", "webhookLogKey", "token"}},
+	{readPath: `encryption\.keys.webhookLogKey.appRole.secretId`, editPaths: []string{"encryption.keys", "webhookLogKey", "appRole", "secretId"}},
 }
 
 // UnredactSecrets unredacts unchanged secrets back to their original value for
@@ -237,6 +246,9 @@ func UnredactSecrets(input string, raw conftypes.RawUnified) (string, error) {
 		if ap.Gitlab != nil {
 			oldSecrets[ap.Gitlab.ClientID] = ap.Gitlab.ClientSecret
 		}
+		if ap.Ldap != nil {
+			oldSecrets[ap.Ldap.BindDN] = ap.Ldap.BindPassword
+		}
 	}
 
 	newCfg, err := ParseConfig(conftypes.RawUnified{
@@ -255,12 +267,33 @@ func UnredactSecrets(input string, raw conftypes.RawUnified) (string, error) {
 		if ap.Gitlab != nil && ap.Gitlab.ClientSecret == redactedSecret {
 			ap.Gitlab.ClientSecret = oldSecrets[ap.Gitlab.ClientID]
 		}
+		if ap.Ldap != nil && ap.Ldap.BindPassword == redactedSecret {
+			ap.Ldap.BindPassword = oldSecrets[ap.Ldap.BindDN]
+		}
 	}
 	unredactedSite, err := jsonc.Edit(input, newCfg.AuthProviders, "auth.providers")
 	if err != nil {
 		return input, errors.Wrap(err, `unredact "auth.providers"`)
 	}
 
+	if newCfg.SearchFederation != nil && len(newCfg.SearchFederation.Remotes) > 0 {
+		oldTokens := map[string]string{}
+		if oldCfg.SearchFederation != nil {
+			for _, remote := range oldCfg.SearchFederation.Remotes {
+				oldTokens[remote.Name] = remote.Token
+			}
+		}
+		for _, remote := range newCfg.SearchFederation.Remotes {
+			if remote.Token == redactedSecret {
+				remote.Token = oldTokens[remote.Name]
+			}
+		}
+		unredactedSite, err = jsonc.Edit(unredactedSite, newCfg.SearchFederation.Remotes, "search.federation", "remotes")
+		if err != nil {
+			return input, errors.Wrap(err, `unredact "search.federation"`)
+		}
+	}
+
 	for _, secret := range siteConfigSecrets {
 		v := gjson.Get(unredactedSite, secret.readPath).String()
 		if v != redactedSecret {
@@ -302,6 +335,9 @@ func RedactSecrets(raw conftypes.RawUnified) (empty conftypes.RawUnified, err er
 		if ap.Gitlab != nil {
 			ap.Gitlab.ClientSecret = redactedSecret
 		}
+		if ap.Ldap != nil && ap.Ldap.BindPassword != "" {
+			ap.Ldap.BindPassword = redactedSecret
+		}
 	}
 	redactedSite := raw.Site
 	if len(cfg.AuthProviders) > 0 {
@@ -311,6 +347,16 @@ func RedactSecrets(raw conftypes.RawUnified) (empty conftypes.RawUnified, err er
 		}
 	}
 
+	if cfg.SearchFederation != nil && len(cfg.SearchFederation.Remotes) > 0 {
+		for _, remote := range cfg.SearchFederation.Remotes {
+			remote.Token = redactedSecret
+		}
+		redactedSite, err = jsonc.Edit(redactedSite, cfg.SearchFederation.Remotes, "search.federation", "remotes")
+		if err != nil {
+			return empty, errors.Wrap(err, `redact "search.federation"`)
+		}
+	}
+
 	for _, secret := range siteConfigSecrets {
 		v := gjson.Get(redactedSite, secret.readPath).String()
 		if v == "" {
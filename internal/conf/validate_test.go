@@ -181,6 +181,123 @@ func TestRedactSecrets_AuthProvidersSectionNotAdded(t *testing.T) {
 	assert.Equal(t, want, redacted.Site)
 }
 
+func TestRedactSecrets_LDAPBindPassword(t *testing.T) {
+	const cfgWithLDAP = `{
+  "auth.providers": [
+    {
+      "bindDN": "cn=sourcegraph,dc=example,dc=com",
+      "bindPassword": "%s",
+      "type": "ldap",
+      "url": "ldap://ldap.example.com",
+      "userSearchBase": "dc=example,dc=com"
+    }
+  ]
+}`
+	previousSite := fmt.Sprintf(cfgWithLDAP, "hunter2")
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: previousSite})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfgWithLDAP, redactedSecret), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: previousSite})
+	require.NoError(t, err)
+	assert.Equal(t, previousSite, unredacted)
+}
+
+func TestRedactSecrets_SCIMAuthToken(t *testing.T) {
+	const cfgWithSCIM = `{
+  "auth.providers": [
+    {
+      "type": "builtin"
+    }
+  ],
+  "scim.authToken": "%s"
+}`
+	previousSite := fmt.Sprintf(cfgWithSCIM, "scim-token")
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: previousSite})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfgWithSCIM, redactedSecret), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: previousSite})
+	require.NoError(t, err)
+	assert.Equal(t, previousSite, unredacted)
+}
+
+func TestRedactSecrets_VaultEncryptionKey(t *testing.T) {
+	const cfgWithVault = `{
+  "auth.providers": [
+    {
+      "type": "builtin"
+    }
+  ],
+  "encryption.keys": {
+    "externalServiceKey": {
+      "type": "vault",
+      "address": "https://vault.example.com",
+      "keyname": "external-services",
+      "token": "%s"
+    },
+    "webhookLogKey": {
+      "type": "vault",
+      "address": "https://vault.example.com",
+      "keyname": "webhook-logs",
+      "appRole": {
+        "roleId": "role",
+        "secretId": "%s"
+      }
+    }
+  }
+}`
+	previousSite := fmt.Sprintf(cfgWithVault, "vault-token", "approle-secret")
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: previousSite})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfgWithVault, redactedSecret, redactedSecret), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: previousSite})
+	require.NoError(t, err)
+	assert.Equal(t, previousSite, unredacted)
+}
+
+func TestRedactSecrets_SearchFederationTokens(t *testing.T) {
+	const cfgWithRemotes = `{
+  "auth.providers": [
+    {
+      "type": "builtin"
+    }
+  ],
+  "search.federation": {
+    "remotes": [
+      {
+        "name": "eu",
+        "token": "%s",
+        "url": "https://sourcegraph.eu.example.com"
+      },
+      {
+        "name": "us",
+        "token": "%s",
+        "url": "https://sourcegraph.us.example.com"
+      }
+    ]
+  }
+}`
+	previousSite := fmt.Sprintf(cfgWithRemotes, "eu-token", "us-token")
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: previousSite})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfgWithRemotes, redactedSecret, redactedSecret), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: previousSite})
+	require.NoError(t, err)
+	assert.Equal(t, previousSite, unredacted)
+
+	// A changed token is kept.
+	unredacted, err = UnredactSecrets(fmt.Sprintf(cfgWithRemotes, redactedSecret, "new-us-token"), conftypes.RawUnified{Site: previousSite})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfgWithRemotes, "eu-token", "new-us-token"), unredacted)
+}
+
 func TestUnredactSecrets(t *testing.T) {
 	previousSite := getTestSiteWithSecrets(
 		executorsAccessToken,
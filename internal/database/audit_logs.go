@@ -0,0 +1,260 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+// AuditLog is an audit log record stored in the database.
+type AuditLog struct {
+	ID           int64
+	CreatedAt    time.Time
+	ActorUID     string
+	IP           string
+	ForwardedFor string
+	Entity       string
+	Action       string
+	Fields       json.RawMessage
+}
+
+// AuditLogStore provides persistence for audit log records. It implements
+// audit.Store, so that it can be registered with audit.SetStore.
+type AuditLogStore interface {
+	basestore.ShareableStore
+
+	// Insert adds a new audit log record to the store.
+	Insert(context.Context, *audit.Entry) error
+	Count(context.Context, AuditLogListOpts) (int64, error)
+	List(context.Context, AuditLogListOpts) ([]*AuditLog, int64, error)
+	// DeleteStale removes all audit log records older than the given retention
+	// period.
+	DeleteStale(context.Context, time.Duration) error
+}
+
+type auditLogStore struct {
+	*basestore.Store
+}
+
+var _ AuditLogStore = &auditLogStore{}
+var _ audit.Store = &auditLogStore{}
+
+// AuditLogsWith instantiates and returns a new AuditLogStore using the other
+// store handle.
+func AuditLogsWith(other basestore.ShareableStore) AuditLogStore {
+	return &auditLogStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *auditLogStore) Insert(ctx context.Context, entry *audit.Entry) error {
+	fields := entry.Fields
+	if len(fields) == 0 {
+		fields = json.RawMessage(`{}`)
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		auditLogInsertQueryFmtstr,
+		timeutil.Now(),
+		entry.ActorUID,
+		entry.IP,
+		entry.ForwardedFor,
+		entry.Entity,
+		entry.Action,
+		fields,
+	))
+}
+
+type AuditLogListOpts struct {
+	// The maximum number of entries to return, and the cursor, if any. Like
+	// webhook logs, the cursor is based on the ID, since new records are added
+	// while paging through the result set.
+	Limit  int
+	Cursor int64
+
+	// If set, only records with exactly these values are returned.
+	ActorUID string
+	Entity   string
+	Action   string
+
+	Since *time.Time
+	Until *time.Time
+}
+
+func (opts *AuditLogListOpts) predicates() []*sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.ActorUID != "" {
+		preds = append(preds, sqlf.Sprintf("actor_uid = %s", opts.ActorUID))
+	}
+	if opts.Entity != "" {
+		preds = append(preds, sqlf.Sprintf("entity = %s", opts.Entity))
+	}
+	if opts.Action != "" {
+		preds = append(preds, sqlf.Sprintf("action = %s", opts.Action))
+	}
+	if since := opts.Since; since != nil {
+		preds = append(preds, sqlf.Sprintf("created_at >= %s", *since))
+	}
+	if until := opts.Until; until != nil {
+		preds = append(preds, sqlf.Sprintf("created_at <= %s", *until))
+	}
+
+	return preds
+}
+
+func (s *auditLogStore) Count(ctx context.Context, opts AuditLogListOpts) (int64, error) {
+	q := sqlf.Sprintf(
+		auditLogCountQueryFmtstr,
+		sqlf.Join(opts.predicates(), " AND "),
+	)
+
+	row := s.QueryRow(ctx, q)
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// List returns the audit log records matching the given options, newest
+// first, along with the cursor of the next page. The cursor is zero if there
+// are no more records.
+func (s *auditLogStore) List(ctx context.Context, opts AuditLogListOpts) (_ []*AuditLog, _ int64, err error) {
+	preds := opts.predicates()
+	if cursor := opts.Cursor; cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("id <= %s", cursor))
+	}
+
+	var limit *sqlf.Query
+	if opts.Limit != 0 {
+		limit = sqlf.Sprintf("LIMIT %s", opts.Limit+1)
+	} else {
+		limit = sqlf.Sprintf("")
+	}
+
+	q := sqlf.Sprintf(
+		auditLogListQueryFmtstr,
+		sqlf.Join(auditLogColumns, ", "),
+		sqlf.Join(preds, " AND "),
+		limit,
+	)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	logs := []*AuditLog{}
+	for rows.Next() {
+		var log AuditLog
+		if err := scanAuditLog(&log, rows); err != nil {
+			return nil, 0, err
+		}
+		logs = append(logs, &log)
+	}
+
+	var next int64 = 0
+	if opts.Limit != 0 && len(logs) == opts.Limit+1 {
+		next = logs[len(logs)-1].ID
+		logs = logs[:len(logs)-1]
+	}
+
+	return logs, next, nil
+}
+
+func (s *auditLogStore) DeleteStale(ctx context.Context, retention time.Duration) error {
+	before := timeutil.Now().Add(-retention)
+
+	return s.Exec(ctx, sqlf.Sprintf(auditLogDeleteStaleQueryFmtstr, before))
+}
+
+var auditLogColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("actor_uid"),
+	sqlf.Sprintf("ip"),
+	sqlf.Sprintf("forwarded_for"),
+	sqlf.Sprintf("entity"),
+	sqlf.Sprintf("action"),
+	sqlf.Sprintf("fields"),
+}
+
+const auditLogInsertQueryFmtstr = `
+-- source: internal/database/audit_logs.go:Insert
+INSERT INTO
+	audit_logs (
+		created_at,
+		actor_uid,
+		ip,
+		forwarded_for,
+		entity,
+		action,
+		fields
+	)
+	VALUES (
+		%s,
+		%s,
+		%s,
+		%s,
+		%s,
+		%s,
+		%s
+	)
+`
+
+const auditLogCountQueryFmtstr = `
+-- source: internal/database/audit_logs.go:Count
+SELECT
+	COUNT(id)
+FROM
+	audit_logs
+WHERE
+	%s
+`
+
+const auditLogListQueryFmtstr = `
+-- source: internal/database/audit_logs.go:List
+SELECT
+	%s
+FROM
+	audit_logs
+WHERE
+	%s
+ORDER BY
+	id DESC
+%s -- LIMIT
+`
+
+const auditLogDeleteStaleQueryFmtstr = `
+-- source: internal/database/audit_logs.go:DeleteStale
+DELETE FROM
+	audit_logs
+WHERE
+	created_at <= %s
+`
+
+func scanAuditLog(log *AuditLog, sc dbutil.Scanner) error {
+	var fields []byte
+	if err := sc.Scan(
+		&log.ID,
+		&log.CreatedAt,
+		&log.ActorUID,
+		&log.IP,
+		&log.ForwardedFor,
+		&log.Entity,
+		&log.Action,
+		&fields,
+	); err != nil {
+		return err
+	}
+
+	log.Fields = fields
+	return nil
+}
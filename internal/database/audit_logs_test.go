@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestAuditLogStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+
+	tx, err := db.Transact(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx.Done(errors.New("rollback")) }()
+
+	store := tx.AuditLogs()
+
+	entries := []*audit.Entry{
+		{ActorUID: "1", IP: "127.0.0.1", ForwardedFor: "unknown", Entity: "site config", Action: "update", Fields: json.RawMessage(`{"a":1}`)},
+		{ActorUID: "2", IP: "127.0.0.1", ForwardedFor: "unknown", Entity: "site config", Action: "update"},
+		{ActorUID: "1", IP: "127.0.0.1", ForwardedFor: "unknown", Entity: "user", Action: "promote to site admin"},
+	}
+	for _, entry := range entries {
+		require.NoError(t, store.Insert(ctx, entry))
+	}
+
+	t.Run("List", func(t *testing.T) {
+		logs, next, err := store.List(ctx, AuditLogListOpts{})
+		require.NoError(t, err)
+		assert.Zero(t, next)
+		require.Len(t, logs, 3)
+
+		// Records are returned newest first.
+		assert.Equal(t, "promote to site admin", logs[0].Action)
+		assert.Equal(t, "2", logs[1].ActorUID)
+		assert.JSONEq(t, `{}`, string(logs[1].Fields))
+		assert.JSONEq(t, `{"a":1}`, string(logs[2].Fields))
+		assert.NotZero(t, logs[2].CreatedAt)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			opts AuditLogListOpts
+			want int
+		}{
+			"actor":         {opts: AuditLogListOpts{ActorUID: "1"}, want: 2},
+			"entity":        {opts: AuditLogListOpts{Entity: "site config"}, want: 2},
+			"action":        {opts: AuditLogListOpts{Entity: "user", Action: "promote to site admin"}, want: 1},
+			"no match":      {opts: AuditLogListOpts{ActorUID: "3"}, want: 0},
+			"in the future": {opts: AuditLogListOpts{Since: timePtr(time.Now().Add(time.Hour))}, want: 0},
+		} {
+			t.Run(name, func(t *testing.T) {
+				logs, _, err := store.List(ctx, tc.opts)
+				require.NoError(t, err)
+				assert.Len(t, logs, tc.want)
+
+				count, err := store.Count(ctx, tc.opts)
+				require.NoError(t, err)
+				assert.EqualValues(t, tc.want, count)
+			})
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		first, next, err := store.List(ctx, AuditLogListOpts{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		require.NotZero(t, next)
+
+		second, next, err := store.List(ctx, AuditLogListOpts{Limit: 2, Cursor: next})
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+		assert.Zero(t, next)
+		assert.Less(t, second[0].ID, first[1].ID)
+	})
+
+	t.Run("DeleteStale", func(t *testing.T) {
+		_, err := tx.ExecContext(ctx, "UPDATE audit_logs SET created_at = NOW() - INTERVAL '2 days' WHERE entity = 'user'")
+		require.NoError(t, err)
+
+		require.NoError(t, store.DeleteStale(ctx, 24*time.Hour))
+
+		count, err := store.Count(ctx, AuditLogListOpts{})
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+	})
+}
@@ -20,6 +20,7 @@ type DB interface {
 	basestore.ShareableStore
 
 	AccessTokens() AccessTokenStore
+	AuditLogs() AuditLogStore
 	Authz() AuthzStore
 	BitbucketProjectPermissions() BitbucketProjectPermissionsStore
 	Conf() ConfStore
@@ -38,8 +39,11 @@ type DB interface {
 	Phabricator() PhabricatorStore
 	Repos() RepoStore
 	RepoKVPs() RepoKVPStore
+	RepoUpdateScheduleSettings() RepoUpdateScheduleSettingsStore
 	SavedSearches() SavedSearchStore
+	SCIM() SCIMStore
 	SearchContexts() SearchContextsStore
+	SearchHistory() SearchHistoryStore
 	Settings() SettingsStore
 	SubRepoPerms() SubRepoPermsStore
 	TemporarySettings() TemporarySettingsStore
@@ -100,6 +104,10 @@ func (d *db) AccessTokens() AccessTokenStore {
 	return AccessTokensWith(d.Store)
 }
 
+func (d *db) AuditLogs() AuditLogStore {
+	return AuditLogsWith(d.Store)
+}
+
 func (d *db) BitbucketProjectPermissions() BitbucketProjectPermissionsStore {
 	return BitbucketProjectPermissionsStoreWith(d.Store)
 }
@@ -172,14 +180,26 @@ func (d *db) RepoKVPs() RepoKVPStore {
 	return &repoKVPStore{d.Store}
 }
 
+func (d *db) RepoUpdateScheduleSettings() RepoUpdateScheduleSettingsStore {
+	return RepoUpdateScheduleSettingsWith(d.Store)
+}
+
 func (d *db) SavedSearches() SavedSearchStore {
 	return SavedSearchesWith(d.Store)
 }
 
+func (d *db) SCIM() SCIMStore {
+	return SCIMWith(d.Store)
+}
+
 func (d *db) SearchContexts() SearchContextsStore {
 	return SearchContextsWith(d.logger, d.Store)
 }
 
+func (d *db) SearchHistory() SearchHistoryStore {
+	return SearchHistoryWith(d.Store)
+}
+
 func (d *db) Settings() SettingsStore {
 	return SettingsWith(d.Store)
 }
@@ -3,11 +3,13 @@ package database
 import (
 	"context"
 	"sort"
+	"strings"
 
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type RecordEncrypter struct {
@@ -89,6 +91,71 @@ func (s *RecordEncrypter) EncryptBatch(ctx context.Context, config EncryptionCon
 	return len(encryptedValues), nil
 }
 
+// RewrapBatch re-encrypts a batch of records that were encrypted with an older
+// version of the current key with its latest version, so that records are
+// migrated after the key was rotated. Only keys that implement
+// encryption.Rewrapper are supported, for other keys no records are rewrapped.
+func (s *RecordEncrypter) RewrapBatch(ctx context.Context, config EncryptionConfig) (count int, err error) {
+	key := config.Key()
+	rw, ok := key.(encryption.Rewrapper)
+	if !ok {
+		return 0, nil
+	}
+
+	version, err := key.Version(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// Key IDs are the JSON of the key version, so records encrypted with other
+	// versions of the same key have key IDs with the same prefix.
+	keyID := version.JSON()
+	keyIDPrefix, _, _ := strings.Cut(keyID, `"Version":`)
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	values, err := config.Scan(tx.Query(ctx, sqlf.Sprintf(
+		"SELECT %s FROM %s WHERE left(%s, %s) = %s AND %s <> %s ORDER BY %s ASC LIMIT %s FOR UPDATE SKIP LOCKED",
+		fields(config),
+		quote(config.TableName),
+		quote(config.KeyIDFieldName),
+		len(keyIDPrefix),
+		keyIDPrefix,
+		quote(config.KeyIDFieldName),
+		keyID,
+		quote(config.IDFieldName),
+		config.Limit,
+	)))
+	if err != nil {
+		return 0, err
+	}
+
+	rewrappedValues, err := rewrapValues(ctx, rw, keyID, values)
+	if err != nil {
+		if errors.Is(err, encryption.ErrRewrapUnsupported) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	for id, ev := range rewrappedValues {
+		if err := tx.Exec(ctx, sqlf.Sprintf(
+			"UPDATE %s SET %s WHERE %s = %s",
+			quote(config.TableName),
+			updatePairs(config, ev),
+			quote(config.IDFieldName),
+			id,
+		)); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(rewrappedValues), nil
+}
+
 func (s *RecordEncrypter) DecryptBatch(ctx context.Context, config EncryptionConfig) (count int, err error) {
 	tx, err := s.Transact(ctx)
 	if err != nil {
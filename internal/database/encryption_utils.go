@@ -52,3 +52,26 @@ func decryptValues(ctx context.Context, key encryption.Key, m map[int]Encrypted)
 
 	return decryptedMap, nil
 }
+
+func rewrapValues(ctx context.Context, rw encryption.Rewrapper, keyID string, m map[int]Encrypted) (map[int]Encrypted, error) {
+	rewrappedMap := make(map[int]Encrypted, len(m))
+	for id, ev := range m {
+		rewrappedValues := make([]string, 0, len(ev.Values))
+		for _, v := range ev.Values {
+			if v == "" {
+				rewrappedValues = append(rewrappedValues, v)
+				continue
+			}
+			rv, err := rw.Rewrap(ctx, []byte(v))
+			if err != nil {
+				return nil, err
+			}
+
+			rewrappedValues = append(rewrappedValues, string(rv))
+		}
+
+		rewrappedMap[id] = Encrypted{Values: rewrappedValues, KeyID: keyID}
+	}
+
+	return rewrappedMap, nil
+}
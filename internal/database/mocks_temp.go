@@ -15,6 +15,7 @@ import (
 	github "github.com/google/go-github/v41/github"
 	sqlf "github.com/keegancsmith/sqlf"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
+	audit "github.com/sourcegraph/sourcegraph/internal/audit"
 	authz "github.com/sourcegraph/sourcegraph/internal/authz"
 	conf "github.com/sourcegraph/sourcegraph/internal/conf"
 	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -1675,6 +1676,638 @@ func (c AccessTokenStoreWithFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockAuditLogStore is a mock implementation of the AuditLogStore interface
+// (from the package github.com/sourcegraph/sourcegraph/internal/database)
+// used for unit testing.
+type MockAuditLogStore struct {
+	// CountFunc is an instance of a mock function object controlling the
+	// behavior of the method Count.
+	CountFunc *AuditLogStoreCountFunc
+	// DeleteStaleFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteStale.
+	DeleteStaleFunc *AuditLogStoreDeleteStaleFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *AuditLogStoreHandleFunc
+	// InsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Insert.
+	InsertFunc *AuditLogStoreInsertFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *AuditLogStoreListFunc
+}
+
+// NewMockAuditLogStore creates a new mock of the AuditLogStore interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockAuditLogStore() *MockAuditLogStore {
+	return &MockAuditLogStore{
+		CountFunc: &AuditLogStoreCountFunc{
+			defaultHook: func(context.Context, AuditLogListOpts) (r0 int64, r1 error) {
+				return
+			},
+		},
+		DeleteStaleFunc: &AuditLogStoreDeleteStaleFunc{
+			defaultHook: func(context.Context, time.Duration) (r0 error) {
+				return
+			},
+		},
+		HandleFunc: &AuditLogStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		InsertFunc: &AuditLogStoreInsertFunc{
+			defaultHook: func(context.Context, *audit.Entry) (r0 error) {
+				return
+			},
+		},
+		ListFunc: &AuditLogStoreListFunc{
+			defaultHook: func(context.Context, AuditLogListOpts) (r0 []*AuditLog, r1 int64, r2 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockAuditLogStore creates a new mock of the AuditLogStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockAuditLogStore() *MockAuditLogStore {
+	return &MockAuditLogStore{
+		CountFunc: &AuditLogStoreCountFunc{
+			defaultHook: func(context.Context, AuditLogListOpts) (int64, error) {
+				panic("unexpected invocation of MockAuditLogStore.Count")
+			},
+		},
+		DeleteStaleFunc: &AuditLogStoreDeleteStaleFunc{
+			defaultHook: func(context.Context, time.Duration) error {
+				panic("unexpected invocation of MockAuditLogStore.DeleteStale")
+			},
+		},
+		HandleFunc: &AuditLogStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockAuditLogStore.Handle")
+			},
+		},
+		InsertFunc: &AuditLogStoreInsertFunc{
+			defaultHook: func(context.Context, *audit.Entry) error {
+				panic("unexpected invocation of MockAuditLogStore.Insert")
+			},
+		},
+		ListFunc: &AuditLogStoreListFunc{
+			defaultHook: func(context.Context, AuditLogListOpts) ([]*AuditLog, int64, error) {
+				panic("unexpected invocation of MockAuditLogStore.List")
+			},
+		},
+	}
+}
+
+// NewMockAuditLogStoreFrom creates a new mock of the MockAuditLogStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockAuditLogStoreFrom(i AuditLogStore) *MockAuditLogStore {
+	return &MockAuditLogStore{
+		CountFunc: &AuditLogStoreCountFunc{
+			defaultHook: i.Count,
+		},
+		DeleteStaleFunc: &AuditLogStoreDeleteStaleFunc{
+			defaultHook: i.DeleteStale,
+		},
+		HandleFunc: &AuditLogStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		InsertFunc: &AuditLogStoreInsertFunc{
+			defaultHook: i.Insert,
+		},
+		ListFunc: &AuditLogStoreListFunc{
+			defaultHook: i.List,
+		},
+	}
+}
+
+// AuditLogStoreCountFunc describes the behavior when the Count method of
+// the parent MockAuditLogStore instance is invoked.
+type AuditLogStoreCountFunc struct {
+	defaultHook func(context.Context, AuditLogListOpts) (int64, error)
+	hooks       []func(context.Context, AuditLogListOpts) (int64, error)
+	history     []AuditLogStoreCountFuncCall
+	mutex       sync.Mutex
+}
+
+// Count delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAuditLogStore) Count(v0 context.Context, v1 AuditLogListOpts) (int64, error) {
+	r0, r1 := m.CountFunc.nextHook()(v0, v1)
+	m.CountFunc.appendCall(AuditLogStoreCountFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Count method of the
+// parent MockAuditLogStore instance is invoked and the hook queue is empty.
+func (f *AuditLogStoreCountFunc) SetDefaultHook(hook func(context.Context, AuditLogListOpts) (int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Count method of the parent MockAuditLogStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AuditLogStoreCountFunc) PushHook(hook func(context.Context, AuditLogListOpts) (int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreCountFunc) SetDefaultReturn(r0 int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, AuditLogListOpts) (int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreCountFunc) PushReturn(r0 int64, r1 error) {
+	f.PushHook(func(context.Context, AuditLogListOpts) (int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *AuditLogStoreCountFunc) nextHook() func(context.Context, AuditLogListOpts) (int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreCountFunc) appendCall(r0 AuditLogStoreCountFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreCountFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreCountFunc) History() []AuditLogStoreCountFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreCountFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreCountFuncCall is an object that describes an invocation of
+// method Count on an instance of MockAuditLogStore.
+type AuditLogStoreCountFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 AuditLogListOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreCountFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreCountFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AuditLogStoreDeleteStaleFunc describes the behavior when the DeleteStale
+// method of the parent MockAuditLogStore instance is invoked.
+type AuditLogStoreDeleteStaleFunc struct {
+	defaultHook func(context.Context, time.Duration) error
+	hooks       []func(context.Context, time.Duration) error
+	history     []AuditLogStoreDeleteStaleFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteStale delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAuditLogStore) DeleteStale(v0 context.Context, v1 time.Duration) error {
+	r0 := m.DeleteStaleFunc.nextHook()(v0, v1)
+	m.DeleteStaleFunc.appendCall(AuditLogStoreDeleteStaleFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteStale method
+// of the parent MockAuditLogStore instance is invoked and the hook queue is
+// empty.
+func (f *AuditLogStoreDeleteStaleFunc) SetDefaultHook(hook func(context.Context, time.Duration) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteStale method of the parent MockAuditLogStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *AuditLogStoreDeleteStaleFunc) PushHook(hook func(context.Context, time.Duration) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreDeleteStaleFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreDeleteStaleFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, time.Duration) error {
+		return r0
+	})
+}
+
+func (f *AuditLogStoreDeleteStaleFunc) nextHook() func(context.Context, time.Duration) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreDeleteStaleFunc) appendCall(r0 AuditLogStoreDeleteStaleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreDeleteStaleFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreDeleteStaleFunc) History() []AuditLogStoreDeleteStaleFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreDeleteStaleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreDeleteStaleFuncCall is an object that describes an
+// invocation of method DeleteStale on an instance of MockAuditLogStore.
+type AuditLogStoreDeleteStaleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Duration
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreDeleteStaleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreDeleteStaleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AuditLogStoreHandleFunc describes the behavior when the Handle method of
+// the parent MockAuditLogStore instance is invoked.
+type AuditLogStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []AuditLogStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAuditLogStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(AuditLogStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockAuditLogStore instance is invoked and the hook queue is empty.
+func (f *AuditLogStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockAuditLogStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AuditLogStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *AuditLogStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreHandleFunc) appendCall(r0 AuditLogStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreHandleFunc) History() []AuditLogStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreHandleFuncCall is an object that describes an invocation of
+// method Handle on an instance of MockAuditLogStore.
+type AuditLogStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AuditLogStoreInsertFunc describes the behavior when the Insert method of
+// the parent MockAuditLogStore instance is invoked.
+type AuditLogStoreInsertFunc struct {
+	defaultHook func(context.Context, *audit.Entry) error
+	hooks       []func(context.Context, *audit.Entry) error
+	history     []AuditLogStoreInsertFuncCall
+	mutex       sync.Mutex
+}
+
+// Insert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAuditLogStore) Insert(v0 context.Context, v1 *audit.Entry) error {
+	r0 := m.InsertFunc.nextHook()(v0, v1)
+	m.InsertFunc.appendCall(AuditLogStoreInsertFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Insert method of the
+// parent MockAuditLogStore instance is invoked and the hook queue is empty.
+func (f *AuditLogStoreInsertFunc) SetDefaultHook(hook func(context.Context, *audit.Entry) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Insert method of the parent MockAuditLogStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AuditLogStoreInsertFunc) PushHook(hook func(context.Context, *audit.Entry) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreInsertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *audit.Entry) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreInsertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *audit.Entry) error {
+		return r0
+	})
+}
+
+func (f *AuditLogStoreInsertFunc) nextHook() func(context.Context, *audit.Entry) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreInsertFunc) appendCall(r0 AuditLogStoreInsertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreInsertFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreInsertFunc) History() []AuditLogStoreInsertFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreInsertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreInsertFuncCall is an object that describes an invocation of
+// method Insert on an instance of MockAuditLogStore.
+type AuditLogStoreInsertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *audit.Entry
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreInsertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreInsertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AuditLogStoreListFunc describes the behavior when the List method of the
+// parent MockAuditLogStore instance is invoked.
+type AuditLogStoreListFunc struct {
+	defaultHook func(context.Context, AuditLogListOpts) ([]*AuditLog, int64, error)
+	hooks       []func(context.Context, AuditLogListOpts) ([]*AuditLog, int64, error)
+	history     []AuditLogStoreListFuncCall
+	mutex       sync.Mutex
+}
+
+// List delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAuditLogStore) List(v0 context.Context, v1 AuditLogListOpts) ([]*AuditLog, int64, error) {
+	r0, r1, r2 := m.ListFunc.nextHook()(v0, v1)
+	m.ListFunc.appendCall(AuditLogStoreListFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockAuditLogStore instance is invoked and the hook queue is empty.
+func (f *AuditLogStoreListFunc) SetDefaultHook(hook func(context.Context, AuditLogListOpts) ([]*AuditLog, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// List method of the parent MockAuditLogStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AuditLogStoreListFunc) PushHook(hook func(context.Context, AuditLogListOpts) ([]*AuditLog, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreListFunc) SetDefaultReturn(r0 []*AuditLog, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, AuditLogListOpts) ([]*AuditLog, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreListFunc) PushReturn(r0 []*AuditLog, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, AuditLogListOpts) ([]*AuditLog, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *AuditLogStoreListFunc) nextHook() func(context.Context, AuditLogListOpts) ([]*AuditLog, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreListFunc) appendCall(r0 AuditLogStoreListFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreListFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreListFunc) History() []AuditLogStoreListFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreListFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreListFuncCall is an object that describes an invocation of
+// method List on an instance of MockAuditLogStore.
+type AuditLogStoreListFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 AuditLogListOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*AuditLog
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // MockAuthzStore is a mock implementation of the AuthzStore interface (from
 // the package github.com/sourcegraph/sourcegraph/internal/database) used
 // for unit testing.
@@ -3618,6 +4251,9 @@ type MockDB struct {
 	// AccessTokensFunc is an instance of a mock function object controlling
 	// the behavior of the method AccessTokens.
 	AccessTokensFunc *DBAccessTokensFunc
+	// AuditLogsFunc is an instance of a mock function object controlling
+	// the behavior of the method AuditLogs.
+	AuditLogsFunc *DBAuditLogsFunc
 	// AuthzFunc is an instance of a mock function object controlling the
 	// behavior of the method Authz.
 	AuthzFunc *DBAuthzFunc
@@ -3685,15 +4321,25 @@ type MockDB struct {
 	// RepoStatisticsFunc is an instance of a mock function object
 	// controlling the behavior of the method RepoStatistics.
 	RepoStatisticsFunc *DBRepoStatisticsFunc
+	// RepoUpdateScheduleSettingsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// RepoUpdateScheduleSettings.
+	RepoUpdateScheduleSettingsFunc *DBRepoUpdateScheduleSettingsFunc
 	// ReposFunc is an instance of a mock function object controlling the
 	// behavior of the method Repos.
 	ReposFunc *DBReposFunc
+	// SCIMFunc is an instance of a mock function object controlling the
+	// behavior of the method SCIM.
+	SCIMFunc *DBSCIMFunc
 	// SavedSearchesFunc is an instance of a mock function object
 	// controlling the behavior of the method SavedSearches.
 	SavedSearchesFunc *DBSavedSearchesFunc
 	// SearchContextsFunc is an instance of a mock function object
 	// controlling the behavior of the method SearchContexts.
 	SearchContextsFunc *DBSearchContextsFunc
+	// SearchHistoryFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchHistory.
+	SearchHistoryFunc *DBSearchHistoryFunc
 	// SecurityEventLogsFunc is an instance of a mock function object
 	// controlling the behavior of the method SecurityEventLogs.
 	SecurityEventLogsFunc *DBSecurityEventLogsFunc
@@ -3738,6 +4384,11 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		AuditLogsFunc: &DBAuditLogsFunc{
+			defaultHook: func() (r0 AuditLogStore) {
+				return
+			},
+		},
 		AuthzFunc: &DBAuthzFunc{
 			defaultHook: func() (r0 AuthzStore) {
 				return
@@ -3848,11 +4499,21 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		RepoUpdateScheduleSettingsFunc: &DBRepoUpdateScheduleSettingsFunc{
+			defaultHook: func() (r0 RepoUpdateScheduleSettingsStore) {
+				return
+			},
+		},
 		ReposFunc: &DBReposFunc{
 			defaultHook: func() (r0 RepoStore) {
 				return
 			},
 		},
+		SCIMFunc: &DBSCIMFunc{
+			defaultHook: func() (r0 SCIMStore) {
+				return
+			},
+		},
 		SavedSearchesFunc: &DBSavedSearchesFunc{
 			defaultHook: func() (r0 SavedSearchStore) {
 				return
@@ -3863,6 +4524,11 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		SearchHistoryFunc: &DBSearchHistoryFunc{
+			defaultHook: func() (r0 SearchHistoryStore) {
+				return
+			},
+		},
 		SecurityEventLogsFunc: &DBSecurityEventLogsFunc{
 			defaultHook: func() (r0 SecurityEventLogsStore) {
 				return
@@ -3930,6 +4596,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.AccessTokens")
 			},
 		},
+		AuditLogsFunc: &DBAuditLogsFunc{
+			defaultHook: func() AuditLogStore {
+				panic("unexpected invocation of MockDB.AuditLogs")
+			},
+		},
 		AuthzFunc: &DBAuthzFunc{
 			defaultHook: func() AuthzStore {
 				panic("unexpected invocation of MockDB.Authz")
@@ -4040,11 +4711,21 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.RepoStatistics")
 			},
 		},
+		RepoUpdateScheduleSettingsFunc: &DBRepoUpdateScheduleSettingsFunc{
+			defaultHook: func() RepoUpdateScheduleSettingsStore {
+				panic("unexpected invocation of MockDB.RepoUpdateScheduleSettings")
+			},
+		},
 		ReposFunc: &DBReposFunc{
 			defaultHook: func() RepoStore {
 				panic("unexpected invocation of MockDB.Repos")
 			},
 		},
+		SCIMFunc: &DBSCIMFunc{
+			defaultHook: func() SCIMStore {
+				panic("unexpected invocation of MockDB.SCIM")
+			},
+		},
 		SavedSearchesFunc: &DBSavedSearchesFunc{
 			defaultHook: func() SavedSearchStore {
 				panic("unexpected invocation of MockDB.SavedSearches")
@@ -4055,6 +4736,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.SearchContexts")
 			},
 		},
+		SearchHistoryFunc: &DBSearchHistoryFunc{
+			defaultHook: func() SearchHistoryStore {
+				panic("unexpected invocation of MockDB.SearchHistory")
+			},
+		},
 		SecurityEventLogsFunc: &DBSecurityEventLogsFunc{
 			defaultHook: func() SecurityEventLogsStore {
 				panic("unexpected invocation of MockDB.SecurityEventLogs")
@@ -4120,6 +4806,9 @@ func NewMockDBFrom(i DB) *MockDB {
 		AccessTokensFunc: &DBAccessTokensFunc{
 			defaultHook: i.AccessTokens,
 		},
+		AuditLogsFunc: &DBAuditLogsFunc{
+			defaultHook: i.AuditLogs,
+		},
 		AuthzFunc: &DBAuthzFunc{
 			defaultHook: i.Authz,
 		},
@@ -4186,15 +4875,24 @@ func NewMockDBFrom(i DB) *MockDB {
 		RepoStatisticsFunc: &DBRepoStatisticsFunc{
 			defaultHook: i.RepoStatistics,
 		},
+		RepoUpdateScheduleSettingsFunc: &DBRepoUpdateScheduleSettingsFunc{
+			defaultHook: i.RepoUpdateScheduleSettings,
+		},
 		ReposFunc: &DBReposFunc{
 			defaultHook: i.Repos,
 		},
+		SCIMFunc: &DBSCIMFunc{
+			defaultHook: i.SCIM,
+		},
 		SavedSearchesFunc: &DBSavedSearchesFunc{
 			defaultHook: i.SavedSearches,
 		},
 		SearchContextsFunc: &DBSearchContextsFunc{
 			defaultHook: i.SearchContexts,
 		},
+		SearchHistoryFunc: &DBSearchHistoryFunc{
+			defaultHook: i.SearchHistory,
+		},
 		SecurityEventLogsFunc: &DBSecurityEventLogsFunc{
 			defaultHook: i.SecurityEventLogs,
 		},
@@ -4329,6 +5027,104 @@ func (c DBAccessTokensFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBAuditLogsFunc describes the behavior when the AuditLogs method of the
+// parent MockDB instance is invoked.
+type DBAuditLogsFunc struct {
+	defaultHook func() AuditLogStore
+	hooks       []func() AuditLogStore
+	history     []DBAuditLogsFuncCall
+	mutex       sync.Mutex
+}
+
+// AuditLogs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockDB) AuditLogs() AuditLogStore {
+	r0 := m.AuditLogsFunc.nextHook()()
+	m.AuditLogsFunc.appendCall(DBAuditLogsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AuditLogs method of
+// the parent MockDB instance is invoked and the hook queue is empty.
+func (f *DBAuditLogsFunc) SetDefaultHook(hook func() AuditLogStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AuditLogs method of the parent MockDB instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *DBAuditLogsFunc) PushHook(hook func() AuditLogStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBAuditLogsFunc) SetDefaultReturn(r0 AuditLogStore) {
+	f.SetDefaultHook(func() AuditLogStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBAuditLogsFunc) PushReturn(r0 AuditLogStore) {
+	f.PushHook(func() AuditLogStore {
+		return r0
+	})
+}
+
+func (f *DBAuditLogsFunc) nextHook() func() AuditLogStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBAuditLogsFunc) appendCall(r0 DBAuditLogsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBAuditLogsFuncCall objects describing the
+// invocations of this function.
+func (f *DBAuditLogsFunc) History() []DBAuditLogsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBAuditLogsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBAuditLogsFuncCall is an object that describes an invocation of method
+// AuditLogs on an instance of MockDB.
+type DBAuditLogsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 AuditLogStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBAuditLogsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBAuditLogsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBAuthzFunc describes the behavior when the Authz method of the parent
 // MockDB instance is invoked.
 type DBAuthzFunc struct {
@@ -6551,6 +7347,106 @@ func (c DBRepoStatisticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBRepoUpdateScheduleSettingsFunc describes the behavior when the
+// RepoUpdateScheduleSettings method of the parent MockDB instance is
+// invoked.
+type DBRepoUpdateScheduleSettingsFunc struct {
+	defaultHook func() RepoUpdateScheduleSettingsStore
+	hooks       []func() RepoUpdateScheduleSettingsStore
+	history     []DBRepoUpdateScheduleSettingsFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoUpdateScheduleSettings delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockDB) RepoUpdateScheduleSettings() RepoUpdateScheduleSettingsStore {
+	r0 := m.RepoUpdateScheduleSettingsFunc.nextHook()()
+	m.RepoUpdateScheduleSettingsFunc.appendCall(DBRepoUpdateScheduleSettingsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// RepoUpdateScheduleSettings method of the parent MockDB instance is
+// invoked and the hook queue is empty.
+func (f *DBRepoUpdateScheduleSettingsFunc) SetDefaultHook(hook func() RepoUpdateScheduleSettingsStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoUpdateScheduleSettings method of the parent MockDB instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBRepoUpdateScheduleSettingsFunc) PushHook(hook func() RepoUpdateScheduleSettingsStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBRepoUpdateScheduleSettingsFunc) SetDefaultReturn(r0 RepoUpdateScheduleSettingsStore) {
+	f.SetDefaultHook(func() RepoUpdateScheduleSettingsStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBRepoUpdateScheduleSettingsFunc) PushReturn(r0 RepoUpdateScheduleSettingsStore) {
+	f.PushHook(func() RepoUpdateScheduleSettingsStore {
+		return r0
+	})
+}
+
+func (f *DBRepoUpdateScheduleSettingsFunc) nextHook() func() RepoUpdateScheduleSettingsStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBRepoUpdateScheduleSettingsFunc) appendCall(r0 DBRepoUpdateScheduleSettingsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBRepoUpdateScheduleSettingsFuncCall
+// objects describing the invocations of this function.
+func (f *DBRepoUpdateScheduleSettingsFunc) History() []DBRepoUpdateScheduleSettingsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBRepoUpdateScheduleSettingsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBRepoUpdateScheduleSettingsFuncCall is an object that describes an
+// invocation of method RepoUpdateScheduleSettings on an instance of MockDB.
+type DBRepoUpdateScheduleSettingsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 RepoUpdateScheduleSettingsStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBRepoUpdateScheduleSettingsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBRepoUpdateScheduleSettingsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBReposFunc describes the behavior when the Repos method of the parent
 // MockDB instance is invoked.
 type DBReposFunc struct {
@@ -6649,6 +7545,104 @@ func (c DBReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBSCIMFunc describes the behavior when the SCIM method of the parent
+// MockDB instance is invoked.
+type DBSCIMFunc struct {
+	defaultHook func() SCIMStore
+	hooks       []func() SCIMStore
+	history     []DBSCIMFuncCall
+	mutex       sync.Mutex
+}
+
+// SCIM delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockDB) SCIM() SCIMStore {
+	r0 := m.SCIMFunc.nextHook()()
+	m.SCIMFunc.appendCall(DBSCIMFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SCIM method of the
+// parent MockDB instance is invoked and the hook queue is empty.
+func (f *DBSCIMFunc) SetDefaultHook(hook func() SCIMStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SCIM method of the parent MockDB instance invokes the hook at the front
+// of the queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *DBSCIMFunc) PushHook(hook func() SCIMStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBSCIMFunc) SetDefaultReturn(r0 SCIMStore) {
+	f.SetDefaultHook(func() SCIMStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBSCIMFunc) PushReturn(r0 SCIMStore) {
+	f.PushHook(func() SCIMStore {
+		return r0
+	})
+}
+
+func (f *DBSCIMFunc) nextHook() func() SCIMStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBSCIMFunc) appendCall(r0 DBSCIMFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBSCIMFuncCall objects describing the
+// invocations of this function.
+func (f *DBSCIMFunc) History() []DBSCIMFuncCall {
+	f.mutex.Lock()
+	history := make([]DBSCIMFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBSCIMFuncCall is an object that describes an invocation of method SCIM
+// on an instance of MockDB.
+type DBSCIMFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 SCIMStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBSCIMFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBSCIMFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBSavedSearchesFunc describes the behavior when the SavedSearches method
 // of the parent MockDB instance is invoked.
 type DBSavedSearchesFunc struct {
@@ -6846,6 +7840,104 @@ func (c DBSearchContextsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBSearchHistoryFunc describes the behavior when the SearchHistory method
+// of the parent MockDB instance is invoked.
+type DBSearchHistoryFunc struct {
+	defaultHook func() SearchHistoryStore
+	hooks       []func() SearchHistoryStore
+	history     []DBSearchHistoryFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchHistory delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDB) SearchHistory() SearchHistoryStore {
+	r0 := m.SearchHistoryFunc.nextHook()()
+	m.SearchHistoryFunc.appendCall(DBSearchHistoryFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SearchHistory method
+// of the parent MockDB instance is invoked and the hook queue is empty.
+func (f *DBSearchHistoryFunc) SetDefaultHook(hook func() SearchHistoryStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchHistory method of the parent MockDB instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBSearchHistoryFunc) PushHook(hook func() SearchHistoryStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBSearchHistoryFunc) SetDefaultReturn(r0 SearchHistoryStore) {
+	f.SetDefaultHook(func() SearchHistoryStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBSearchHistoryFunc) PushReturn(r0 SearchHistoryStore) {
+	f.PushHook(func() SearchHistoryStore {
+		return r0
+	})
+}
+
+func (f *DBSearchHistoryFunc) nextHook() func() SearchHistoryStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBSearchHistoryFunc) appendCall(r0 DBSearchHistoryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBSearchHistoryFuncCall objects describing
+// the invocations of this function.
+func (f *DBSearchHistoryFunc) History() []DBSearchHistoryFuncCall {
+	f.mutex.Lock()
+	history := make([]DBSearchHistoryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBSearchHistoryFuncCall is an object that describes an invocation of
+// method SearchHistory on an instance of MockDB.
+type DBSearchHistoryFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 SearchHistoryStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBSearchHistoryFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBSearchHistoryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBSecurityEventLogsFunc describes the behavior when the SecurityEventLogs
 // method of the parent MockDB instance is invoked.
 type DBSecurityEventLogsFunc struct {
@@ -30040,350 +31132,148 @@ func (c RepoStoreWithFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// MockSavedSearchStore is a mock implementation of the SavedSearchStore
-// interface (from the package
+// MockRepoUpdateScheduleSettingsStore is a mock implementation of the
+// RepoUpdateScheduleSettingsStore interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/database) used for unit
 // testing.
-type MockSavedSearchStore struct {
-	// CreateFunc is an instance of a mock function object controlling the
-	// behavior of the method Create.
-	CreateFunc *SavedSearchStoreCreateFunc
+type MockRepoUpdateScheduleSettingsStore struct {
 	// DeleteFunc is an instance of a mock function object controlling the
 	// behavior of the method Delete.
-	DeleteFunc *SavedSearchStoreDeleteFunc
-	// GetByIDFunc is an instance of a mock function object controlling the
-	// behavior of the method GetByID.
-	GetByIDFunc *SavedSearchStoreGetByIDFunc
+	DeleteFunc *RepoUpdateScheduleSettingsStoreDeleteFunc
+	// GetFunc is an instance of a mock function object controlling the
+	// behavior of the method Get.
+	GetFunc *RepoUpdateScheduleSettingsStoreGetFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
-	HandleFunc *SavedSearchStoreHandleFunc
-	// IsEmptyFunc is an instance of a mock function object controlling the
-	// behavior of the method IsEmpty.
-	IsEmptyFunc *SavedSearchStoreIsEmptyFunc
-	// ListAllFunc is an instance of a mock function object controlling the
-	// behavior of the method ListAll.
-	ListAllFunc *SavedSearchStoreListAllFunc
-	// ListSavedSearchesByOrgIDFunc is an instance of a mock function object
-	// controlling the behavior of the method ListSavedSearchesByOrgID.
-	ListSavedSearchesByOrgIDFunc *SavedSearchStoreListSavedSearchesByOrgIDFunc
-	// ListSavedSearchesByUserIDFunc is an instance of a mock function
-	// object controlling the behavior of the method
-	// ListSavedSearchesByUserID.
-	ListSavedSearchesByUserIDFunc *SavedSearchStoreListSavedSearchesByUserIDFunc
-	// TransactFunc is an instance of a mock function object controlling the
-	// behavior of the method Transact.
-	TransactFunc *SavedSearchStoreTransactFunc
-	// UpdateFunc is an instance of a mock function object controlling the
-	// behavior of the method Update.
-	UpdateFunc *SavedSearchStoreUpdateFunc
-	// WithFunc is an instance of a mock function object controlling the
-	// behavior of the method With.
-	WithFunc *SavedSearchStoreWithFunc
+	HandleFunc *RepoUpdateScheduleSettingsStoreHandleFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *RepoUpdateScheduleSettingsStoreListFunc
+	// UpsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Upsert.
+	UpsertFunc *RepoUpdateScheduleSettingsStoreUpsertFunc
 }
 
-// NewMockSavedSearchStore creates a new mock of the SavedSearchStore
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockSavedSearchStore() *MockSavedSearchStore {
-	return &MockSavedSearchStore{
-		CreateFunc: &SavedSearchStoreCreateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (r0 *types.SavedSearch, r1 error) {
-				return
-			},
-		},
-		DeleteFunc: &SavedSearchStoreDeleteFunc{
-			defaultHook: func(context.Context, int32) (r0 error) {
+// NewMockRepoUpdateScheduleSettingsStore creates a new mock of the
+// RepoUpdateScheduleSettingsStore interface. All methods return zero values
+// for all results, unless overwritten.
+func NewMockRepoUpdateScheduleSettingsStore() *MockRepoUpdateScheduleSettingsStore {
+	return &MockRepoUpdateScheduleSettingsStore{
+		DeleteFunc: &RepoUpdateScheduleSettingsStoreDeleteFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 error) {
 				return
 			},
 		},
-		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
-			defaultHook: func(context.Context, int32) (r0 *api.SavedQuerySpecAndConfig, r1 error) {
+		GetFunc: &RepoUpdateScheduleSettingsStoreGetFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 *RepoUpdateScheduleSetting, r1 error) {
 				return
 			},
 		},
-		HandleFunc: &SavedSearchStoreHandleFunc{
+		HandleFunc: &RepoUpdateScheduleSettingsStoreHandleFunc{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
 			},
 		},
-		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
-			defaultHook: func(context.Context) (r0 bool, r1 error) {
-				return
-			},
-		},
-		ListAllFunc: &SavedSearchStoreListAllFunc{
-			defaultHook: func(context.Context) (r0 []api.SavedQuerySpecAndConfig, r1 error) {
-				return
-			},
-		},
-		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
-			defaultHook: func(context.Context, int32) (r0 []*types.SavedSearch, r1 error) {
-				return
-			},
-		},
-		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
-			defaultHook: func(context.Context, int32) (r0 []*types.SavedSearch, r1 error) {
-				return
-			},
-		},
-		TransactFunc: &SavedSearchStoreTransactFunc{
-			defaultHook: func(context.Context) (r0 SavedSearchStore, r1 error) {
-				return
-			},
-		},
-		UpdateFunc: &SavedSearchStoreUpdateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (r0 *types.SavedSearch, r1 error) {
+		ListFunc: &RepoUpdateScheduleSettingsStoreListFunc{
+			defaultHook: func(context.Context) (r0 []*RepoUpdateScheduleSetting, r1 error) {
 				return
 			},
 		},
-		WithFunc: &SavedSearchStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) (r0 SavedSearchStore) {
+		UpsertFunc: &RepoUpdateScheduleSettingsStoreUpsertFunc{
+			defaultHook: func(context.Context, *RepoUpdateScheduleSetting) (r0 *RepoUpdateScheduleSetting, r1 error) {
 				return
 			},
 		},
 	}
 }
 
-// NewStrictMockSavedSearchStore creates a new mock of the SavedSearchStore
-// interface. All methods panic on invocation, unless overwritten.
-func NewStrictMockSavedSearchStore() *MockSavedSearchStore {
-	return &MockSavedSearchStore{
-		CreateFunc: &SavedSearchStoreCreateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.Create")
-			},
-		},
-		DeleteFunc: &SavedSearchStoreDeleteFunc{
-			defaultHook: func(context.Context, int32) error {
-				panic("unexpected invocation of MockSavedSearchStore.Delete")
+// NewStrictMockRepoUpdateScheduleSettingsStore creates a new mock of the
+// RepoUpdateScheduleSettingsStore interface. All methods panic on
+// invocation, unless overwritten.
+func NewStrictMockRepoUpdateScheduleSettingsStore() *MockRepoUpdateScheduleSettingsStore {
+	return &MockRepoUpdateScheduleSettingsStore{
+		DeleteFunc: &RepoUpdateScheduleSettingsStoreDeleteFunc{
+			defaultHook: func(context.Context, api.RepoID) error {
+				panic("unexpected invocation of MockRepoUpdateScheduleSettingsStore.Delete")
 			},
 		},
-		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
-			defaultHook: func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
-				panic("unexpected invocation of MockSavedSearchStore.GetByID")
+		GetFunc: &RepoUpdateScheduleSettingsStoreGetFunc{
+			defaultHook: func(context.Context, api.RepoID) (*RepoUpdateScheduleSetting, error) {
+				panic("unexpected invocation of MockRepoUpdateScheduleSettingsStore.Get")
 			},
 		},
-		HandleFunc: &SavedSearchStoreHandleFunc{
+		HandleFunc: &RepoUpdateScheduleSettingsStoreHandleFunc{
 			defaultHook: func() basestore.TransactableHandle {
-				panic("unexpected invocation of MockSavedSearchStore.Handle")
-			},
-		},
-		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
-			defaultHook: func(context.Context) (bool, error) {
-				panic("unexpected invocation of MockSavedSearchStore.IsEmpty")
-			},
-		},
-		ListAllFunc: &SavedSearchStoreListAllFunc{
-			defaultHook: func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
-				panic("unexpected invocation of MockSavedSearchStore.ListAll")
-			},
-		},
-		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
-			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByOrgID")
-			},
-		},
-		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
-			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByUserID")
-			},
-		},
-		TransactFunc: &SavedSearchStoreTransactFunc{
-			defaultHook: func(context.Context) (SavedSearchStore, error) {
-				panic("unexpected invocation of MockSavedSearchStore.Transact")
+				panic("unexpected invocation of MockRepoUpdateScheduleSettingsStore.Handle")
 			},
 		},
-		UpdateFunc: &SavedSearchStoreUpdateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.Update")
+		ListFunc: &RepoUpdateScheduleSettingsStoreListFunc{
+			defaultHook: func(context.Context) ([]*RepoUpdateScheduleSetting, error) {
+				panic("unexpected invocation of MockRepoUpdateScheduleSettingsStore.List")
 			},
 		},
-		WithFunc: &SavedSearchStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) SavedSearchStore {
-				panic("unexpected invocation of MockSavedSearchStore.With")
+		UpsertFunc: &RepoUpdateScheduleSettingsStoreUpsertFunc{
+			defaultHook: func(context.Context, *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error) {
+				panic("unexpected invocation of MockRepoUpdateScheduleSettingsStore.Upsert")
 			},
 		},
 	}
 }
 
-// NewMockSavedSearchStoreFrom creates a new mock of the
-// MockSavedSearchStore interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockSavedSearchStoreFrom(i SavedSearchStore) *MockSavedSearchStore {
-	return &MockSavedSearchStore{
-		CreateFunc: &SavedSearchStoreCreateFunc{
-			defaultHook: i.Create,
-		},
-		DeleteFunc: &SavedSearchStoreDeleteFunc{
+// NewMockRepoUpdateScheduleSettingsStoreFrom creates a new mock of the
+// MockRepoUpdateScheduleSettingsStore interface. All methods delegate to
+// the given implementation, unless overwritten.
+func NewMockRepoUpdateScheduleSettingsStoreFrom(i RepoUpdateScheduleSettingsStore) *MockRepoUpdateScheduleSettingsStore {
+	return &MockRepoUpdateScheduleSettingsStore{
+		DeleteFunc: &RepoUpdateScheduleSettingsStoreDeleteFunc{
 			defaultHook: i.Delete,
 		},
-		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
-			defaultHook: i.GetByID,
+		GetFunc: &RepoUpdateScheduleSettingsStoreGetFunc{
+			defaultHook: i.Get,
 		},
-		HandleFunc: &SavedSearchStoreHandleFunc{
+		HandleFunc: &RepoUpdateScheduleSettingsStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
-		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
-			defaultHook: i.IsEmpty,
-		},
-		ListAllFunc: &SavedSearchStoreListAllFunc{
-			defaultHook: i.ListAll,
-		},
-		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
-			defaultHook: i.ListSavedSearchesByOrgID,
-		},
-		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
-			defaultHook: i.ListSavedSearchesByUserID,
-		},
-		TransactFunc: &SavedSearchStoreTransactFunc{
-			defaultHook: i.Transact,
-		},
-		UpdateFunc: &SavedSearchStoreUpdateFunc{
-			defaultHook: i.Update,
+		ListFunc: &RepoUpdateScheduleSettingsStoreListFunc{
+			defaultHook: i.List,
 		},
-		WithFunc: &SavedSearchStoreWithFunc{
-			defaultHook: i.With,
+		UpsertFunc: &RepoUpdateScheduleSettingsStoreUpsertFunc{
+			defaultHook: i.Upsert,
 		},
 	}
 }
 
-// SavedSearchStoreCreateFunc describes the behavior when the Create method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreCreateFunc struct {
-	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	history     []SavedSearchStoreCreateFuncCall
-	mutex       sync.Mutex
-}
-
-// Create delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Create(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
-	r0, r1 := m.CreateFunc.nextHook()(v0, v1)
-	m.CreateFunc.appendCall(SavedSearchStoreCreateFuncCall{v0, v1, r0, r1})
-	return r0, r1
-}
-
-// SetDefaultHook sets function that is called when the Create method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreCreateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
-	f.defaultHook = hook
-}
-
-// PushHook adds a function to the end of hook queue. Each invocation of the
-// Create method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
-	f.mutex.Lock()
-	f.hooks = append(f.hooks, hook)
-	f.mutex.Unlock()
-}
-
-// SetDefaultReturn calls SetDefaultHook with a function that returns the
-// given values.
-func (f *SavedSearchStoreCreateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-		return r0, r1
-	})
-}
-
-// PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreCreateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-		return r0, r1
-	})
-}
-
-func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-	f.mutex.Lock()
-	defer f.mutex.Unlock()
-
-	if len(f.hooks) == 0 {
-		return f.defaultHook
-	}
-
-	hook := f.hooks[0]
-	f.hooks = f.hooks[1:]
-	return hook
-}
-
-func (f *SavedSearchStoreCreateFunc) appendCall(r0 SavedSearchStoreCreateFuncCall) {
-	f.mutex.Lock()
-	f.history = append(f.history, r0)
-	f.mutex.Unlock()
-}
-
-// History returns a sequence of SavedSearchStoreCreateFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreCreateFunc) History() []SavedSearchStoreCreateFuncCall {
-	f.mutex.Lock()
-	history := make([]SavedSearchStoreCreateFuncCall, len(f.history))
-	copy(history, f.history)
-	f.mutex.Unlock()
-
-	return history
-}
-
-// SavedSearchStoreCreateFuncCall is an object that describes an invocation
-// of method Create on an instance of MockSavedSearchStore.
-type SavedSearchStoreCreateFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 *types.SavedSearch
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 *types.SavedSearch
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
-}
-
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c SavedSearchStoreCreateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
-}
-
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c SavedSearchStoreCreateFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
-}
-
-// SavedSearchStoreDeleteFunc describes the behavior when the Delete method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreDeleteFunc struct {
-	defaultHook func(context.Context, int32) error
-	hooks       []func(context.Context, int32) error
-	history     []SavedSearchStoreDeleteFuncCall
+// RepoUpdateScheduleSettingsStoreDeleteFunc describes the behavior when the
+// Delete method of the parent MockRepoUpdateScheduleSettingsStore instance
+// is invoked.
+type RepoUpdateScheduleSettingsStoreDeleteFunc struct {
+	defaultHook func(context.Context, api.RepoID) error
+	hooks       []func(context.Context, api.RepoID) error
+	history     []RepoUpdateScheduleSettingsStoreDeleteFuncCall
 	mutex       sync.Mutex
 }
 
 // Delete delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Delete(v0 context.Context, v1 int32) error {
+func (m *MockRepoUpdateScheduleSettingsStore) Delete(v0 context.Context, v1 api.RepoID) error {
 	r0 := m.DeleteFunc.nextHook()(v0, v1)
-	m.DeleteFunc.appendCall(SavedSearchStoreDeleteFuncCall{v0, v1, r0})
+	m.DeleteFunc.appendCall(RepoUpdateScheduleSettingsStoreDeleteFuncCall{v0, v1, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the Delete method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+// parent MockRepoUpdateScheduleSettingsStore instance is invoked and the
+// hook queue is empty.
+func (f *RepoUpdateScheduleSettingsStoreDeleteFunc) SetDefaultHook(hook func(context.Context, api.RepoID) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Delete method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32) error) {
+// Delete method of the parent MockRepoUpdateScheduleSettingsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoUpdateScheduleSettingsStoreDeleteFunc) PushHook(hook func(context.Context, api.RepoID) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30391,20 +31281,20 @@ func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreDeleteFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32) error {
+func (f *RepoUpdateScheduleSettingsStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreDeleteFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32) error {
+func (f *RepoUpdateScheduleSettingsStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID) error {
 		return r0
 	})
 }
 
-func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) error {
+func (f *RepoUpdateScheduleSettingsStoreDeleteFunc) nextHook() func(context.Context, api.RepoID) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30417,32 +31307,34 @@ func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) err
 	return hook
 }
 
-func (f *SavedSearchStoreDeleteFunc) appendCall(r0 SavedSearchStoreDeleteFuncCall) {
+func (f *RepoUpdateScheduleSettingsStoreDeleteFunc) appendCall(r0 RepoUpdateScheduleSettingsStoreDeleteFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreDeleteFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreDeleteFunc) History() []SavedSearchStoreDeleteFuncCall {
+// History returns a sequence of
+// RepoUpdateScheduleSettingsStoreDeleteFuncCall objects describing the
+// invocations of this function.
+func (f *RepoUpdateScheduleSettingsStoreDeleteFunc) History() []RepoUpdateScheduleSettingsStoreDeleteFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreDeleteFuncCall, len(f.history))
+	history := make([]RepoUpdateScheduleSettingsStoreDeleteFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreDeleteFuncCall is an object that describes an invocation
-// of method Delete on an instance of MockSavedSearchStore.
-type SavedSearchStoreDeleteFuncCall struct {
+// RepoUpdateScheduleSettingsStoreDeleteFuncCall is an object that describes
+// an invocation of method Delete on an instance of
+// MockRepoUpdateScheduleSettingsStore.
+type RepoUpdateScheduleSettingsStoreDeleteFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -30450,45 +31342,47 @@ type SavedSearchStoreDeleteFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreDeleteFuncCall) Args() []interface{} {
+func (c RepoUpdateScheduleSettingsStoreDeleteFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreDeleteFuncCall) Results() []interface{} {
+func (c RepoUpdateScheduleSettingsStoreDeleteFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// SavedSearchStoreGetByIDFunc describes the behavior when the GetByID
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreGetByIDFunc struct {
-	defaultHook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
-	hooks       []func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
-	history     []SavedSearchStoreGetByIDFuncCall
+// RepoUpdateScheduleSettingsStoreGetFunc describes the behavior when the
+// Get method of the parent MockRepoUpdateScheduleSettingsStore instance is
+// invoked.
+type RepoUpdateScheduleSettingsStoreGetFunc struct {
+	defaultHook func(context.Context, api.RepoID) (*RepoUpdateScheduleSetting, error)
+	hooks       []func(context.Context, api.RepoID) (*RepoUpdateScheduleSetting, error)
+	history     []RepoUpdateScheduleSettingsStoreGetFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByID delegates to the next hook function in the queue and stores the
+// Get delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) GetByID(v0 context.Context, v1 int32) (*api.SavedQuerySpecAndConfig, error) {
-	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
-	m.GetByIDFunc.appendCall(SavedSearchStoreGetByIDFuncCall{v0, v1, r0, r1})
+func (m *MockRepoUpdateScheduleSettingsStore) Get(v0 context.Context, v1 api.RepoID) (*RepoUpdateScheduleSetting, error) {
+	r0, r1 := m.GetFunc.nextHook()(v0, v1)
+	m.GetFunc.appendCall(RepoUpdateScheduleSettingsStoreGetFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByID method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
+// SetDefaultHook sets function that is called when the Get method of the
+// parent MockRepoUpdateScheduleSettingsStore instance is invoked and the
+// hook queue is empty.
+func (f *RepoUpdateScheduleSettingsStoreGetFunc) SetDefaultHook(hook func(context.Context, api.RepoID) (*RepoUpdateScheduleSetting, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByID method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
+// Get method of the parent MockRepoUpdateScheduleSettingsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoUpdateScheduleSettingsStoreGetFunc) PushHook(hook func(context.Context, api.RepoID) (*RepoUpdateScheduleSetting, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30496,20 +31390,20 @@ func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreGetByIDFunc) SetDefaultReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+func (f *RepoUpdateScheduleSettingsStoreGetFunc) SetDefaultReturn(r0 *RepoUpdateScheduleSetting, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) (*RepoUpdateScheduleSetting, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreGetByIDFunc) PushReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
-	f.PushHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+func (f *RepoUpdateScheduleSettingsStoreGetFunc) PushReturn(r0 *RepoUpdateScheduleSetting, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) (*RepoUpdateScheduleSetting, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+func (f *RepoUpdateScheduleSettingsStoreGetFunc) nextHook() func(context.Context, api.RepoID) (*RepoUpdateScheduleSetting, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30522,35 +31416,36 @@ func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*
 	return hook
 }
 
-func (f *SavedSearchStoreGetByIDFunc) appendCall(r0 SavedSearchStoreGetByIDFuncCall) {
+func (f *RepoUpdateScheduleSettingsStoreGetFunc) appendCall(r0 RepoUpdateScheduleSettingsStoreGetFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreGetByIDFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreGetByIDFunc) History() []SavedSearchStoreGetByIDFuncCall {
+// History returns a sequence of RepoUpdateScheduleSettingsStoreGetFuncCall
+// objects describing the invocations of this function.
+func (f *RepoUpdateScheduleSettingsStoreGetFunc) History() []RepoUpdateScheduleSettingsStoreGetFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreGetByIDFuncCall, len(f.history))
+	history := make([]RepoUpdateScheduleSettingsStoreGetFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreGetByIDFuncCall is an object that describes an invocation
-// of method GetByID on an instance of MockSavedSearchStore.
-type SavedSearchStoreGetByIDFuncCall struct {
+// RepoUpdateScheduleSettingsStoreGetFuncCall is an object that describes an
+// invocation of method Get on an instance of
+// MockRepoUpdateScheduleSettingsStore.
+type RepoUpdateScheduleSettingsStoreGetFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *api.SavedQuerySpecAndConfig
+	Result0 *RepoUpdateScheduleSetting
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -30558,45 +31453,47 @@ type SavedSearchStoreGetByIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreGetByIDFuncCall) Args() []interface{} {
+func (c RepoUpdateScheduleSettingsStoreGetFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreGetByIDFuncCall) Results() []interface{} {
+func (c RepoUpdateScheduleSettingsStoreGetFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreHandleFunc describes the behavior when the Handle method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreHandleFunc struct {
+// RepoUpdateScheduleSettingsStoreHandleFunc describes the behavior when the
+// Handle method of the parent MockRepoUpdateScheduleSettingsStore instance
+// is invoked.
+type RepoUpdateScheduleSettingsStoreHandleFunc struct {
 	defaultHook func() basestore.TransactableHandle
 	hooks       []func() basestore.TransactableHandle
-	history     []SavedSearchStoreHandleFuncCall
+	history     []RepoUpdateScheduleSettingsStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
 // Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Handle() basestore.TransactableHandle {
+func (m *MockRepoUpdateScheduleSettingsStore) Handle() basestore.TransactableHandle {
 	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(SavedSearchStoreHandleFuncCall{r0})
+	m.HandleFunc.appendCall(RepoUpdateScheduleSettingsStoreHandleFuncCall{r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the Handle method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+// parent MockRepoUpdateScheduleSettingsStore instance is invoked and the
+// hook queue is empty.
+func (f *RepoUpdateScheduleSettingsStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+// Handle method of the parent MockRepoUpdateScheduleSettingsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoUpdateScheduleSettingsStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30604,20 +31501,20 @@ func (f *SavedSearchStoreHandleFunc) PushHook(hook func() basestore.Transactable
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+func (f *RepoUpdateScheduleSettingsStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
 	f.SetDefaultHook(func() basestore.TransactableHandle {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+func (f *RepoUpdateScheduleSettingsStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
 	f.PushHook(func() basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *SavedSearchStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+func (f *RepoUpdateScheduleSettingsStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30630,26 +31527,28 @@ func (f *SavedSearchStoreHandleFunc) nextHook() func() basestore.TransactableHan
 	return hook
 }
 
-func (f *SavedSearchStoreHandleFunc) appendCall(r0 SavedSearchStoreHandleFuncCall) {
+func (f *RepoUpdateScheduleSettingsStoreHandleFunc) appendCall(r0 RepoUpdateScheduleSettingsStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreHandleFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreHandleFunc) History() []SavedSearchStoreHandleFuncCall {
+// History returns a sequence of
+// RepoUpdateScheduleSettingsStoreHandleFuncCall objects describing the
+// invocations of this function.
+func (f *RepoUpdateScheduleSettingsStoreHandleFunc) History() []RepoUpdateScheduleSettingsStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreHandleFuncCall, len(f.history))
+	history := make([]RepoUpdateScheduleSettingsStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreHandleFuncCall is an object that describes an invocation
-// of method Handle on an instance of MockSavedSearchStore.
-type SavedSearchStoreHandleFuncCall struct {
+// RepoUpdateScheduleSettingsStoreHandleFuncCall is an object that describes
+// an invocation of method Handle on an instance of
+// MockRepoUpdateScheduleSettingsStore.
+type RepoUpdateScheduleSettingsStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 basestore.TransactableHandle
@@ -30657,45 +31556,47 @@ type SavedSearchStoreHandleFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreHandleFuncCall) Args() []interface{} {
+func (c RepoUpdateScheduleSettingsStoreHandleFuncCall) Args() []interface{} {
 	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreHandleFuncCall) Results() []interface{} {
+func (c RepoUpdateScheduleSettingsStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// SavedSearchStoreIsEmptyFunc describes the behavior when the IsEmpty
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreIsEmptyFunc struct {
-	defaultHook func(context.Context) (bool, error)
-	hooks       []func(context.Context) (bool, error)
-	history     []SavedSearchStoreIsEmptyFuncCall
+// RepoUpdateScheduleSettingsStoreListFunc describes the behavior when the
+// List method of the parent MockRepoUpdateScheduleSettingsStore instance is
+// invoked.
+type RepoUpdateScheduleSettingsStoreListFunc struct {
+	defaultHook func(context.Context) ([]*RepoUpdateScheduleSetting, error)
+	hooks       []func(context.Context) ([]*RepoUpdateScheduleSetting, error)
+	history     []RepoUpdateScheduleSettingsStoreListFuncCall
 	mutex       sync.Mutex
 }
 
-// IsEmpty delegates to the next hook function in the queue and stores the
+// List delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) IsEmpty(v0 context.Context) (bool, error) {
-	r0, r1 := m.IsEmptyFunc.nextHook()(v0)
-	m.IsEmptyFunc.appendCall(SavedSearchStoreIsEmptyFuncCall{v0, r0, r1})
+func (m *MockRepoUpdateScheduleSettingsStore) List(v0 context.Context) ([]*RepoUpdateScheduleSetting, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0)
+	m.ListFunc.appendCall(RepoUpdateScheduleSettingsStoreListFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the IsEmpty method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreIsEmptyFunc) SetDefaultHook(hook func(context.Context) (bool, error)) {
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockRepoUpdateScheduleSettingsStore instance is invoked and the
+// hook queue is empty.
+func (f *RepoUpdateScheduleSettingsStoreListFunc) SetDefaultHook(hook func(context.Context) ([]*RepoUpdateScheduleSetting, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// IsEmpty method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreIsEmptyFunc) PushHook(hook func(context.Context) (bool, error)) {
+// List method of the parent MockRepoUpdateScheduleSettingsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoUpdateScheduleSettingsStoreListFunc) PushHook(hook func(context.Context) ([]*RepoUpdateScheduleSetting, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30703,20 +31604,20 @@ func (f *SavedSearchStoreIsEmptyFunc) PushHook(hook func(context.Context) (bool,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreIsEmptyFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context) (bool, error) {
+func (f *RepoUpdateScheduleSettingsStoreListFunc) SetDefaultReturn(r0 []*RepoUpdateScheduleSetting, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*RepoUpdateScheduleSetting, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreIsEmptyFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context) (bool, error) {
+func (f *RepoUpdateScheduleSettingsStoreListFunc) PushReturn(r0 []*RepoUpdateScheduleSetting, r1 error) {
+	f.PushHook(func(context.Context) ([]*RepoUpdateScheduleSetting, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreIsEmptyFunc) nextHook() func(context.Context) (bool, error) {
+func (f *RepoUpdateScheduleSettingsStoreListFunc) nextHook() func(context.Context) ([]*RepoUpdateScheduleSetting, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30729,32 +31630,33 @@ func (f *SavedSearchStoreIsEmptyFunc) nextHook() func(context.Context) (bool, er
 	return hook
 }
 
-func (f *SavedSearchStoreIsEmptyFunc) appendCall(r0 SavedSearchStoreIsEmptyFuncCall) {
+func (f *RepoUpdateScheduleSettingsStoreListFunc) appendCall(r0 RepoUpdateScheduleSettingsStoreListFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreIsEmptyFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreIsEmptyFunc) History() []SavedSearchStoreIsEmptyFuncCall {
+// History returns a sequence of RepoUpdateScheduleSettingsStoreListFuncCall
+// objects describing the invocations of this function.
+func (f *RepoUpdateScheduleSettingsStoreListFunc) History() []RepoUpdateScheduleSettingsStoreListFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreIsEmptyFuncCall, len(f.history))
+	history := make([]RepoUpdateScheduleSettingsStoreListFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreIsEmptyFuncCall is an object that describes an invocation
-// of method IsEmpty on an instance of MockSavedSearchStore.
-type SavedSearchStoreIsEmptyFuncCall struct {
+// RepoUpdateScheduleSettingsStoreListFuncCall is an object that describes
+// an invocation of method List on an instance of
+// MockRepoUpdateScheduleSettingsStore.
+type RepoUpdateScheduleSettingsStoreListFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 []*RepoUpdateScheduleSetting
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -30762,45 +31664,47 @@ type SavedSearchStoreIsEmptyFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreIsEmptyFuncCall) Args() []interface{} {
+func (c RepoUpdateScheduleSettingsStoreListFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreIsEmptyFuncCall) Results() []interface{} {
+func (c RepoUpdateScheduleSettingsStoreListFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreListAllFunc describes the behavior when the ListAll
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreListAllFunc struct {
-	defaultHook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
-	hooks       []func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
-	history     []SavedSearchStoreListAllFuncCall
+// RepoUpdateScheduleSettingsStoreUpsertFunc describes the behavior when the
+// Upsert method of the parent MockRepoUpdateScheduleSettingsStore instance
+// is invoked.
+type RepoUpdateScheduleSettingsStoreUpsertFunc struct {
+	defaultHook func(context.Context, *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error)
+	hooks       []func(context.Context, *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error)
+	history     []RepoUpdateScheduleSettingsStoreUpsertFuncCall
 	mutex       sync.Mutex
 }
 
-// ListAll delegates to the next hook function in the queue and stores the
+// Upsert delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) ListAll(v0 context.Context) ([]api.SavedQuerySpecAndConfig, error) {
-	r0, r1 := m.ListAllFunc.nextHook()(v0)
-	m.ListAllFunc.appendCall(SavedSearchStoreListAllFuncCall{v0, r0, r1})
+func (m *MockRepoUpdateScheduleSettingsStore) Upsert(v0 context.Context, v1 *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error) {
+	r0, r1 := m.UpsertFunc.nextHook()(v0, v1)
+	m.UpsertFunc.appendCall(RepoUpdateScheduleSettingsStoreUpsertFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListAll method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreListAllFunc) SetDefaultHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
+// SetDefaultHook sets function that is called when the Upsert method of the
+// parent MockRepoUpdateScheduleSettingsStore instance is invoked and the
+// hook queue is empty.
+func (f *RepoUpdateScheduleSettingsStoreUpsertFunc) SetDefaultHook(hook func(context.Context, *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListAll method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreListAllFunc) PushHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
+// Upsert method of the parent MockRepoUpdateScheduleSettingsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoUpdateScheduleSettingsStoreUpsertFunc) PushHook(hook func(context.Context, *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30808,20 +31712,20 @@ func (f *SavedSearchStoreListAllFunc) PushHook(hook func(context.Context) ([]api
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreListAllFunc) SetDefaultReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
-	f.SetDefaultHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+func (f *RepoUpdateScheduleSettingsStoreUpsertFunc) SetDefaultReturn(r0 *RepoUpdateScheduleSetting, r1 error) {
+	f.SetDefaultHook(func(context.Context, *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreListAllFunc) PushReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
-	f.PushHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+func (f *RepoUpdateScheduleSettingsStoreUpsertFunc) PushReturn(r0 *RepoUpdateScheduleSetting, r1 error) {
+	f.PushHook(func(context.Context, *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreListAllFunc) nextHook() func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+func (f *RepoUpdateScheduleSettingsStoreUpsertFunc) nextHook() func(context.Context, *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30834,32 +31738,37 @@ func (f *SavedSearchStoreListAllFunc) nextHook() func(context.Context) ([]api.Sa
 	return hook
 }
 
-func (f *SavedSearchStoreListAllFunc) appendCall(r0 SavedSearchStoreListAllFuncCall) {
+func (f *RepoUpdateScheduleSettingsStoreUpsertFunc) appendCall(r0 RepoUpdateScheduleSettingsStoreUpsertFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreListAllFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreListAllFunc) History() []SavedSearchStoreListAllFuncCall {
+// History returns a sequence of
+// RepoUpdateScheduleSettingsStoreUpsertFuncCall objects describing the
+// invocations of this function.
+func (f *RepoUpdateScheduleSettingsStoreUpsertFunc) History() []RepoUpdateScheduleSettingsStoreUpsertFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreListAllFuncCall, len(f.history))
+	history := make([]RepoUpdateScheduleSettingsStoreUpsertFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreListAllFuncCall is an object that describes an invocation
-// of method ListAll on an instance of MockSavedSearchStore.
-type SavedSearchStoreListAllFuncCall struct {
+// RepoUpdateScheduleSettingsStoreUpsertFuncCall is an object that describes
+// an invocation of method Upsert on an instance of
+// MockRepoUpdateScheduleSettingsStore.
+type RepoUpdateScheduleSettingsStoreUpsertFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *RepoUpdateScheduleSetting
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []api.SavedQuerySpecAndConfig
+	Result0 *RepoUpdateScheduleSetting
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -30867,47 +31776,312 @@ type SavedSearchStoreListAllFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreListAllFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c RepoUpdateScheduleSettingsStoreUpsertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreListAllFuncCall) Results() []interface{} {
+func (c RepoUpdateScheduleSettingsStoreUpsertFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreListSavedSearchesByOrgIDFunc describes the behavior when
-// the ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
-// instance is invoked.
-type SavedSearchStoreListSavedSearchesByOrgIDFunc struct {
-	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
-	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
-	history     []SavedSearchStoreListSavedSearchesByOrgIDFuncCall
-	mutex       sync.Mutex
-}
-
-// ListSavedSearchesByOrgID delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockSavedSearchStore) ListSavedSearchesByOrgID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
-	r0, r1 := m.ListSavedSearchesByOrgIDFunc.nextHook()(v0, v1)
-	m.ListSavedSearchesByOrgIDFunc.appendCall(SavedSearchStoreListSavedSearchesByOrgIDFuncCall{v0, v1, r0, r1})
-	return r0, r1
-}
-
-// SetDefaultHook sets function that is called when the
-// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
-// instance is invoked and the hook queue is empty.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
-	f.defaultHook = hook
+// MockSCIMStore is a mock implementation of the SCIMStore interface (from
+// the package github.com/sourcegraph/sourcegraph/internal/database) used
+// for unit testing.
+type MockSCIMStore struct {
+	// CreateGroupFunc is an instance of a mock function object controlling
+	// the behavior of the method CreateGroup.
+	CreateGroupFunc *SCIMStoreCreateGroupFunc
+	// CreateUserFunc is an instance of a mock function object controlling
+	// the behavior of the method CreateUser.
+	CreateUserFunc *SCIMStoreCreateUserFunc
+	// DeleteGroupFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteGroup.
+	DeleteGroupFunc *SCIMStoreDeleteGroupFunc
+	// DeleteUserFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteUser.
+	DeleteUserFunc *SCIMStoreDeleteUserFunc
+	// DoneFunc is an instance of a mock function object controlling the
+	// behavior of the method Done.
+	DoneFunc *SCIMStoreDoneFunc
+	// GetGroupFunc is an instance of a mock function object controlling the
+	// behavior of the method GetGroup.
+	GetGroupFunc *SCIMStoreGetGroupFunc
+	// GetUserFunc is an instance of a mock function object controlling the
+	// behavior of the method GetUser.
+	GetUserFunc *SCIMStoreGetUserFunc
+	// GetUserByUserIDFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUserByUserID.
+	GetUserByUserIDFunc *SCIMStoreGetUserByUserIDFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *SCIMStoreHandleFunc
+	// ListGroupMembersFunc is an instance of a mock function object
+	// controlling the behavior of the method ListGroupMembers.
+	ListGroupMembersFunc *SCIMStoreListGroupMembersFunc
+	// ListGroupsFunc is an instance of a mock function object controlling
+	// the behavior of the method ListGroups.
+	ListGroupsFunc *SCIMStoreListGroupsFunc
+	// ListUsersFunc is an instance of a mock function object controlling
+	// the behavior of the method ListUsers.
+	ListUsersFunc *SCIMStoreListUsersFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *SCIMStoreTransactFunc
+	// UpdateGroupFunc is an instance of a mock function object controlling
+	// the behavior of the method UpdateGroup.
+	UpdateGroupFunc *SCIMStoreUpdateGroupFunc
+	// UpdateUserFunc is an instance of a mock function object controlling
+	// the behavior of the method UpdateUser.
+	UpdateUserFunc *SCIMStoreUpdateUserFunc
+}
+
+// NewMockSCIMStore creates a new mock of the SCIMStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockSCIMStore() *MockSCIMStore {
+	return &MockSCIMStore{
+		CreateGroupFunc: &SCIMStoreCreateGroupFunc{
+			defaultHook: func(context.Context, *SCIMGroup) (r0 *SCIMGroup, r1 error) {
+				return
+			},
+		},
+		CreateUserFunc: &SCIMStoreCreateUserFunc{
+			defaultHook: func(context.Context, *SCIMUser) (r0 *SCIMUser, r1 error) {
+				return
+			},
+		},
+		DeleteGroupFunc: &SCIMStoreDeleteGroupFunc{
+			defaultHook: func(context.Context, string) (r0 error) {
+				return
+			},
+		},
+		DeleteUserFunc: &SCIMStoreDeleteUserFunc{
+			defaultHook: func(context.Context, string) (r0 error) {
+				return
+			},
+		},
+		DoneFunc: &SCIMStoreDoneFunc{
+			defaultHook: func(error) (r0 error) {
+				return
+			},
+		},
+		GetGroupFunc: &SCIMStoreGetGroupFunc{
+			defaultHook: func(context.Context, string) (r0 *SCIMGroup, r1 error) {
+				return
+			},
+		},
+		GetUserFunc: &SCIMStoreGetUserFunc{
+			defaultHook: func(context.Context, string) (r0 *SCIMUser, r1 error) {
+				return
+			},
+		},
+		GetUserByUserIDFunc: &SCIMStoreGetUserByUserIDFunc{
+			defaultHook: func(context.Context, int32) (r0 *SCIMUser, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &SCIMStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		ListGroupMembersFunc: &SCIMStoreListGroupMembersFunc{
+			defaultHook: func(context.Context, int32) (r0 []*SCIMUser, r1 error) {
+				return
+			},
+		},
+		ListGroupsFunc: &SCIMStoreListGroupsFunc{
+			defaultHook: func(context.Context, SCIMListOpts) (r0 []*SCIMGroup, r1 error) {
+				return
+			},
+		},
+		ListUsersFunc: &SCIMStoreListUsersFunc{
+			defaultHook: func(context.Context, SCIMListOpts) (r0 []*SCIMUser, r1 error) {
+				return
+			},
+		},
+		TransactFunc: &SCIMStoreTransactFunc{
+			defaultHook: func(context.Context) (r0 SCIMStore, r1 error) {
+				return
+			},
+		},
+		UpdateGroupFunc: &SCIMStoreUpdateGroupFunc{
+			defaultHook: func(context.Context, *SCIMGroup) (r0 *SCIMGroup, r1 error) {
+				return
+			},
+		},
+		UpdateUserFunc: &SCIMStoreUpdateUserFunc{
+			defaultHook: func(context.Context, *SCIMUser) (r0 *SCIMUser, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockSCIMStore creates a new mock of the SCIMStore interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockSCIMStore() *MockSCIMStore {
+	return &MockSCIMStore{
+		CreateGroupFunc: &SCIMStoreCreateGroupFunc{
+			defaultHook: func(context.Context, *SCIMGroup) (*SCIMGroup, error) {
+				panic("unexpected invocation of MockSCIMStore.CreateGroup")
+			},
+		},
+		CreateUserFunc: &SCIMStoreCreateUserFunc{
+			defaultHook: func(context.Context, *SCIMUser) (*SCIMUser, error) {
+				panic("unexpected invocation of MockSCIMStore.CreateUser")
+			},
+		},
+		DeleteGroupFunc: &SCIMStoreDeleteGroupFunc{
+			defaultHook: func(context.Context, string) error {
+				panic("unexpected invocation of MockSCIMStore.DeleteGroup")
+			},
+		},
+		DeleteUserFunc: &SCIMStoreDeleteUserFunc{
+			defaultHook: func(context.Context, string) error {
+				panic("unexpected invocation of MockSCIMStore.DeleteUser")
+			},
+		},
+		DoneFunc: &SCIMStoreDoneFunc{
+			defaultHook: func(error) error {
+				panic("unexpected invocation of MockSCIMStore.Done")
+			},
+		},
+		GetGroupFunc: &SCIMStoreGetGroupFunc{
+			defaultHook: func(context.Context, string) (*SCIMGroup, error) {
+				panic("unexpected invocation of MockSCIMStore.GetGroup")
+			},
+		},
+		GetUserFunc: &SCIMStoreGetUserFunc{
+			defaultHook: func(context.Context, string) (*SCIMUser, error) {
+				panic("unexpected invocation of MockSCIMStore.GetUser")
+			},
+		},
+		GetUserByUserIDFunc: &SCIMStoreGetUserByUserIDFunc{
+			defaultHook: func(context.Context, int32) (*SCIMUser, error) {
+				panic("unexpected invocation of MockSCIMStore.GetUserByUserID")
+			},
+		},
+		HandleFunc: &SCIMStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockSCIMStore.Handle")
+			},
+		},
+		ListGroupMembersFunc: &SCIMStoreListGroupMembersFunc{
+			defaultHook: func(context.Context, int32) ([]*SCIMUser, error) {
+				panic("unexpected invocation of MockSCIMStore.ListGroupMembers")
+			},
+		},
+		ListGroupsFunc: &SCIMStoreListGroupsFunc{
+			defaultHook: func(context.Context, SCIMListOpts) ([]*SCIMGroup, error) {
+				panic("unexpected invocation of MockSCIMStore.ListGroups")
+			},
+		},
+		ListUsersFunc: &SCIMStoreListUsersFunc{
+			defaultHook: func(context.Context, SCIMListOpts) ([]*SCIMUser, error) {
+				panic("unexpected invocation of MockSCIMStore.ListUsers")
+			},
+		},
+		TransactFunc: &SCIMStoreTransactFunc{
+			defaultHook: func(context.Context) (SCIMStore, error) {
+				panic("unexpected invocation of MockSCIMStore.Transact")
+			},
+		},
+		UpdateGroupFunc: &SCIMStoreUpdateGroupFunc{
+			defaultHook: func(context.Context, *SCIMGroup) (*SCIMGroup, error) {
+				panic("unexpected invocation of MockSCIMStore.UpdateGroup")
+			},
+		},
+		UpdateUserFunc: &SCIMStoreUpdateUserFunc{
+			defaultHook: func(context.Context, *SCIMUser) (*SCIMUser, error) {
+				panic("unexpected invocation of MockSCIMStore.UpdateUser")
+			},
+		},
+	}
+}
+
+// NewMockSCIMStoreFrom creates a new mock of the MockSCIMStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockSCIMStoreFrom(i SCIMStore) *MockSCIMStore {
+	return &MockSCIMStore{
+		CreateGroupFunc: &SCIMStoreCreateGroupFunc{
+			defaultHook: i.CreateGroup,
+		},
+		CreateUserFunc: &SCIMStoreCreateUserFunc{
+			defaultHook: i.CreateUser,
+		},
+		DeleteGroupFunc: &SCIMStoreDeleteGroupFunc{
+			defaultHook: i.DeleteGroup,
+		},
+		DeleteUserFunc: &SCIMStoreDeleteUserFunc{
+			defaultHook: i.DeleteUser,
+		},
+		DoneFunc: &SCIMStoreDoneFunc{
+			defaultHook: i.Done,
+		},
+		GetGroupFunc: &SCIMStoreGetGroupFunc{
+			defaultHook: i.GetGroup,
+		},
+		GetUserFunc: &SCIMStoreGetUserFunc{
+			defaultHook: i.GetUser,
+		},
+		GetUserByUserIDFunc: &SCIMStoreGetUserByUserIDFunc{
+			defaultHook: i.GetUserByUserID,
+		},
+		HandleFunc: &SCIMStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListGroupMembersFunc: &SCIMStoreListGroupMembersFunc{
+			defaultHook: i.ListGroupMembers,
+		},
+		ListGroupsFunc: &SCIMStoreListGroupsFunc{
+			defaultHook: i.ListGroups,
+		},
+		ListUsersFunc: &SCIMStoreListUsersFunc{
+			defaultHook: i.ListUsers,
+		},
+		TransactFunc: &SCIMStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpdateGroupFunc: &SCIMStoreUpdateGroupFunc{
+			defaultHook: i.UpdateGroup,
+		},
+		UpdateUserFunc: &SCIMStoreUpdateUserFunc{
+			defaultHook: i.UpdateUser,
+		},
+	}
+}
+
+// SCIMStoreCreateGroupFunc describes the behavior when the CreateGroup
+// method of the parent MockSCIMStore instance is invoked.
+type SCIMStoreCreateGroupFunc struct {
+	defaultHook func(context.Context, *SCIMGroup) (*SCIMGroup, error)
+	hooks       []func(context.Context, *SCIMGroup) (*SCIMGroup, error)
+	history     []SCIMStoreCreateGroupFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateGroup delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSCIMStore) CreateGroup(v0 context.Context, v1 *SCIMGroup) (*SCIMGroup, error) {
+	r0, r1 := m.CreateGroupFunc.nextHook()(v0, v1)
+	m.CreateGroupFunc.appendCall(SCIMStoreCreateGroupFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateGroup method
+// of the parent MockSCIMStore instance is invoked and the hook queue is
+// empty.
+func (f *SCIMStoreCreateGroupFunc) SetDefaultHook(hook func(context.Context, *SCIMGroup) (*SCIMGroup, error)) {
+	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// CreateGroup method of the parent MockSCIMStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreCreateGroupFunc) PushHook(hook func(context.Context, *SCIMGroup) (*SCIMGroup, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30915,20 +32089,20 @@ func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushHook(hook func(contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *SCIMStoreCreateGroupFunc) SetDefaultReturn(r0 *SCIMGroup, r1 error) {
+	f.SetDefaultHook(func(context.Context, *SCIMGroup) (*SCIMGroup, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *SCIMStoreCreateGroupFunc) PushReturn(r0 *SCIMGroup, r1 error) {
+	f.PushHook(func(context.Context, *SCIMGroup) (*SCIMGroup, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *SCIMStoreCreateGroupFunc) nextHook() func(context.Context, *SCIMGroup) (*SCIMGroup, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30941,37 +32115,35 @@ func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) nextHook() func(context.C
 	return hook
 }
 
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByOrgIDFuncCall) {
+func (f *SCIMStoreCreateGroupFunc) appendCall(r0 SCIMStoreCreateGroupFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SavedSearchStoreListSavedSearchesByOrgIDFuncCall objects describing the
-// invocations of this function.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) History() []SavedSearchStoreListSavedSearchesByOrgIDFuncCall {
+// History returns a sequence of SCIMStoreCreateGroupFuncCall objects
+// describing the invocations of this function.
+func (f *SCIMStoreCreateGroupFunc) History() []SCIMStoreCreateGroupFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreListSavedSearchesByOrgIDFuncCall, len(f.history))
+	history := make([]SCIMStoreCreateGroupFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreListSavedSearchesByOrgIDFuncCall is an object that
-// describes an invocation of method ListSavedSearchesByOrgID on an instance
-// of MockSavedSearchStore.
-type SavedSearchStoreListSavedSearchesByOrgIDFuncCall struct {
+// SCIMStoreCreateGroupFuncCall is an object that describes an invocation of
+// method CreateGroup on an instance of MockSCIMStore.
+type SCIMStoreCreateGroupFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 *SCIMGroup
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.SavedSearch
+	Result0 *SCIMGroup
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -30979,47 +32151,44 @@ type SavedSearchStoreListSavedSearchesByOrgIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Args() []interface{} {
+func (c SCIMStoreCreateGroupFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Results() []interface{} {
+func (c SCIMStoreCreateGroupFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreListSavedSearchesByUserIDFunc describes the behavior when
-// the ListSavedSearchesByUserID method of the parent MockSavedSearchStore
-// instance is invoked.
-type SavedSearchStoreListSavedSearchesByUserIDFunc struct {
-	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
-	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
-	history     []SavedSearchStoreListSavedSearchesByUserIDFuncCall
+// SCIMStoreCreateUserFunc describes the behavior when the CreateUser method
+// of the parent MockSCIMStore instance is invoked.
+type SCIMStoreCreateUserFunc struct {
+	defaultHook func(context.Context, *SCIMUser) (*SCIMUser, error)
+	hooks       []func(context.Context, *SCIMUser) (*SCIMUser, error)
+	history     []SCIMStoreCreateUserFuncCall
 	mutex       sync.Mutex
 }
 
-// ListSavedSearchesByUserID delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockSavedSearchStore) ListSavedSearchesByUserID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
-	r0, r1 := m.ListSavedSearchesByUserIDFunc.nextHook()(v0, v1)
-	m.ListSavedSearchesByUserIDFunc.appendCall(SavedSearchStoreListSavedSearchesByUserIDFuncCall{v0, v1, r0, r1})
+// CreateUser delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSCIMStore) CreateUser(v0 context.Context, v1 *SCIMUser) (*SCIMUser, error) {
+	r0, r1 := m.CreateUserFunc.nextHook()(v0, v1)
+	m.CreateUserFunc.appendCall(SCIMStoreCreateUserFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
-// instance is invoked and the hook queue is empty.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// SetDefaultHook sets function that is called when the CreateUser method of
+// the parent MockSCIMStore instance is invoked and the hook queue is empty.
+func (f *SCIMStoreCreateUserFunc) SetDefaultHook(hook func(context.Context, *SCIMUser) (*SCIMUser, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// CreateUser method of the parent MockSCIMStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreCreateUserFunc) PushHook(hook func(context.Context, *SCIMUser) (*SCIMUser, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31027,20 +32196,20 @@ func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushHook(hook func(conte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *SCIMStoreCreateUserFunc) SetDefaultReturn(r0 *SCIMUser, r1 error) {
+	f.SetDefaultHook(func(context.Context, *SCIMUser) (*SCIMUser, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *SCIMStoreCreateUserFunc) PushReturn(r0 *SCIMUser, r1 error) {
+	f.PushHook(func(context.Context, *SCIMUser) (*SCIMUser, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *SCIMStoreCreateUserFunc) nextHook() func(context.Context, *SCIMUser) (*SCIMUser, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31053,37 +32222,35 @@ func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) nextHook() func(context.
 	return hook
 }
 
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByUserIDFuncCall) {
+func (f *SCIMStoreCreateUserFunc) appendCall(r0 SCIMStoreCreateUserFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SavedSearchStoreListSavedSearchesByUserIDFuncCall objects describing the
-// invocations of this function.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) History() []SavedSearchStoreListSavedSearchesByUserIDFuncCall {
+// History returns a sequence of SCIMStoreCreateUserFuncCall objects
+// describing the invocations of this function.
+func (f *SCIMStoreCreateUserFunc) History() []SCIMStoreCreateUserFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreListSavedSearchesByUserIDFuncCall, len(f.history))
+	history := make([]SCIMStoreCreateUserFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreListSavedSearchesByUserIDFuncCall is an object that
-// describes an invocation of method ListSavedSearchesByUserID on an
-// instance of MockSavedSearchStore.
-type SavedSearchStoreListSavedSearchesByUserIDFuncCall struct {
+// SCIMStoreCreateUserFuncCall is an object that describes an invocation of
+// method CreateUser on an instance of MockSCIMStore.
+type SCIMStoreCreateUserFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 *SCIMUser
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.SavedSearch
+	Result0 *SCIMUser
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -31091,45 +32258,45 @@ type SavedSearchStoreListSavedSearchesByUserIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Args() []interface{} {
+func (c SCIMStoreCreateUserFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Results() []interface{} {
+func (c SCIMStoreCreateUserFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreTransactFunc describes the behavior when the Transact
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreTransactFunc struct {
-	defaultHook func(context.Context) (SavedSearchStore, error)
-	hooks       []func(context.Context) (SavedSearchStore, error)
-	history     []SavedSearchStoreTransactFuncCall
+// SCIMStoreDeleteGroupFunc describes the behavior when the DeleteGroup
+// method of the parent MockSCIMStore instance is invoked.
+type SCIMStoreDeleteGroupFunc struct {
+	defaultHook func(context.Context, string) error
+	hooks       []func(context.Context, string) error
+	history     []SCIMStoreDeleteGroupFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Transact(v0 context.Context) (SavedSearchStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(SavedSearchStoreTransactFuncCall{v0, r0, r1})
-	return r0, r1
+// DeleteGroup delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSCIMStore) DeleteGroup(v0 context.Context, v1 string) error {
+	r0 := m.DeleteGroupFunc.nextHook()(v0, v1)
+	m.DeleteGroupFunc.appendCall(SCIMStoreDeleteGroupFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the DeleteGroup method
+// of the parent MockSCIMStore instance is invoked and the hook queue is
 // empty.
-func (f *SavedSearchStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SavedSearchStore, error)) {
+func (f *SCIMStoreDeleteGroupFunc) SetDefaultHook(hook func(context.Context, string) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreTransactFunc) PushHook(hook func(context.Context) (SavedSearchStore, error)) {
+// DeleteGroup method of the parent MockSCIMStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreDeleteGroupFunc) PushHook(hook func(context.Context, string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31137,20 +32304,20 @@ func (f *SavedSearchStoreTransactFunc) PushHook(hook func(context.Context) (Save
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreTransactFunc) SetDefaultReturn(r0 SavedSearchStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (SavedSearchStore, error) {
-		return r0, r1
+func (f *SCIMStoreDeleteGroupFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreTransactFunc) PushReturn(r0 SavedSearchStore, r1 error) {
-	f.PushHook(func(context.Context) (SavedSearchStore, error) {
-		return r0, r1
+func (f *SCIMStoreDeleteGroupFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string) error {
+		return r0
 	})
 }
 
-func (f *SavedSearchStoreTransactFunc) nextHook() func(context.Context) (SavedSearchStore, error) {
+func (f *SCIMStoreDeleteGroupFunc) nextHook() func(context.Context, string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31163,78 +32330,282 @@ func (f *SavedSearchStoreTransactFunc) nextHook() func(context.Context) (SavedSe
 	return hook
 }
 
-func (f *SavedSearchStoreTransactFunc) appendCall(r0 SavedSearchStoreTransactFuncCall) {
+func (f *SCIMStoreDeleteGroupFunc) appendCall(r0 SCIMStoreDeleteGroupFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreTransactFuncCall objects
+// History returns a sequence of SCIMStoreDeleteGroupFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreTransactFunc) History() []SavedSearchStoreTransactFuncCall {
+func (f *SCIMStoreDeleteGroupFunc) History() []SCIMStoreDeleteGroupFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreTransactFuncCall, len(f.history))
+	history := make([]SCIMStoreDeleteGroupFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreTransactFuncCall is an object that describes an
-// invocation of method Transact on an instance of MockSavedSearchStore.
-type SavedSearchStoreTransactFuncCall struct {
+// SCIMStoreDeleteGroupFuncCall is an object that describes an invocation of
+// method DeleteGroup on an instance of MockSCIMStore.
+type SCIMStoreDeleteGroupFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 SavedSearchStore
-	// Result1 is the value of the 2nd result returned from this method
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIMStoreDeleteGroupFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIMStoreDeleteGroupFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SCIMStoreDeleteUserFunc describes the behavior when the DeleteUser method
+// of the parent MockSCIMStore instance is invoked.
+type SCIMStoreDeleteUserFunc struct {
+	defaultHook func(context.Context, string) error
+	hooks       []func(context.Context, string) error
+	history     []SCIMStoreDeleteUserFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteUser delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSCIMStore) DeleteUser(v0 context.Context, v1 string) error {
+	r0 := m.DeleteUserFunc.nextHook()(v0, v1)
+	m.DeleteUserFunc.appendCall(SCIMStoreDeleteUserFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteUser method of
+// the parent MockSCIMStore instance is invoked and the hook queue is empty.
+func (f *SCIMStoreDeleteUserFunc) SetDefaultHook(hook func(context.Context, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteUser method of the parent MockSCIMStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreDeleteUserFunc) PushHook(hook func(context.Context, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIMStoreDeleteUserFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIMStoreDeleteUserFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+func (f *SCIMStoreDeleteUserFunc) nextHook() func(context.Context, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIMStoreDeleteUserFunc) appendCall(r0 SCIMStoreDeleteUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIMStoreDeleteUserFuncCall objects
+// describing the invocations of this function.
+func (f *SCIMStoreDeleteUserFunc) History() []SCIMStoreDeleteUserFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIMStoreDeleteUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIMStoreDeleteUserFuncCall is an object that describes an invocation of
+// method DeleteUser on an instance of MockSCIMStore.
+type SCIMStoreDeleteUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Result1 error
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreTransactFuncCall) Args() []interface{} {
+func (c SCIMStoreDeleteUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIMStoreDeleteUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SCIMStoreDoneFunc describes the behavior when the Done method of the
+// parent MockSCIMStore instance is invoked.
+type SCIMStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []SCIMStoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSCIMStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(SCIMStoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockSCIMStore instance is invoked and the hook queue is empty.
+func (f *SCIMStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Done method of the parent MockSCIMStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *SCIMStoreDoneFunc) PushHook(hook func(error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIMStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIMStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
+	})
+}
+
+func (f *SCIMStoreDoneFunc) nextHook() func(error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIMStoreDoneFunc) appendCall(r0 SCIMStoreDoneFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIMStoreDoneFuncCall objects describing
+// the invocations of this function.
+func (f *SCIMStoreDoneFunc) History() []SCIMStoreDoneFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIMStoreDoneFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIMStoreDoneFuncCall is an object that describes an invocation of method
+// Done on an instance of MockSCIMStore.
+type SCIMStoreDoneFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIMStoreDoneFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreTransactFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SCIMStoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SavedSearchStoreUpdateFunc describes the behavior when the Update method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreUpdateFunc struct {
-	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	history     []SavedSearchStoreUpdateFuncCall
+// SCIMStoreGetGroupFunc describes the behavior when the GetGroup method of
+// the parent MockSCIMStore instance is invoked.
+type SCIMStoreGetGroupFunc struct {
+	defaultHook func(context.Context, string) (*SCIMGroup, error)
+	hooks       []func(context.Context, string) (*SCIMGroup, error)
+	history     []SCIMStoreGetGroupFuncCall
 	mutex       sync.Mutex
 }
 
-// Update delegates to the next hook function in the queue and stores the
+// GetGroup delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Update(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
-	r0, r1 := m.UpdateFunc.nextHook()(v0, v1)
-	m.UpdateFunc.appendCall(SavedSearchStoreUpdateFuncCall{v0, v1, r0, r1})
+func (m *MockSCIMStore) GetGroup(v0 context.Context, v1 string) (*SCIMGroup, error) {
+	r0, r1 := m.GetGroupFunc.nextHook()(v0, v1)
+	m.GetGroupFunc.appendCall(SCIMStoreGetGroupFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Update method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreUpdateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+// SetDefaultHook sets function that is called when the GetGroup method of
+// the parent MockSCIMStore instance is invoked and the hook queue is empty.
+func (f *SCIMStoreGetGroupFunc) SetDefaultHook(hook func(context.Context, string) (*SCIMGroup, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Update method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreUpdateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+// GetGroup method of the parent MockSCIMStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreGetGroupFunc) PushHook(hook func(context.Context, string) (*SCIMGroup, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31242,20 +32613,20 @@ func (f *SavedSearchStoreUpdateFunc) PushHook(hook func(context.Context, *types.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreUpdateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *SCIMStoreGetGroupFunc) SetDefaultReturn(r0 *SCIMGroup, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (*SCIMGroup, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreUpdateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *SCIMStoreGetGroupFunc) PushReturn(r0 *SCIMGroup, r1 error) {
+	f.PushHook(func(context.Context, string) (*SCIMGroup, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreUpdateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *SCIMStoreGetGroupFunc) nextHook() func(context.Context, string) (*SCIMGroup, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31268,35 +32639,35 @@ func (f *SavedSearchStoreUpdateFunc) nextHook() func(context.Context, *types.Sav
 	return hook
 }
 
-func (f *SavedSearchStoreUpdateFunc) appendCall(r0 SavedSearchStoreUpdateFuncCall) {
+func (f *SCIMStoreGetGroupFunc) appendCall(r0 SCIMStoreGetGroupFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreUpdateFuncCall objects
+// History returns a sequence of SCIMStoreGetGroupFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreUpdateFunc) History() []SavedSearchStoreUpdateFuncCall {
+func (f *SCIMStoreGetGroupFunc) History() []SCIMStoreGetGroupFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreUpdateFuncCall, len(f.history))
+	history := make([]SCIMStoreGetGroupFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreUpdateFuncCall is an object that describes an invocation
-// of method Update on an instance of MockSavedSearchStore.
-type SavedSearchStoreUpdateFuncCall struct {
+// SCIMStoreGetGroupFuncCall is an object that describes an invocation of
+// method GetGroup on an instance of MockSCIMStore.
+type SCIMStoreGetGroupFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *types.SavedSearch
+	Arg1 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SavedSearch
+	Result0 *SCIMGroup
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -31304,45 +32675,44 @@ type SavedSearchStoreUpdateFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreUpdateFuncCall) Args() []interface{} {
+func (c SCIMStoreGetGroupFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreUpdateFuncCall) Results() []interface{} {
+func (c SCIMStoreGetGroupFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreWithFunc describes the behavior when the With method of
-// the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreWithFunc struct {
-	defaultHook func(basestore.ShareableStore) SavedSearchStore
-	hooks       []func(basestore.ShareableStore) SavedSearchStore
-	history     []SavedSearchStoreWithFuncCall
+// SCIMStoreGetUserFunc describes the behavior when the GetUser method of
+// the parent MockSCIMStore instance is invoked.
+type SCIMStoreGetUserFunc struct {
+	defaultHook func(context.Context, string) (*SCIMUser, error)
+	hooks       []func(context.Context, string) (*SCIMUser, error)
+	history     []SCIMStoreGetUserFuncCall
 	mutex       sync.Mutex
 }
 
-// With delegates to the next hook function in the queue and stores the
+// GetUser delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) With(v0 basestore.ShareableStore) SavedSearchStore {
-	r0 := m.WithFunc.nextHook()(v0)
-	m.WithFunc.appendCall(SavedSearchStoreWithFuncCall{v0, r0})
-	return r0
+func (m *MockSCIMStore) GetUser(v0 context.Context, v1 string) (*SCIMUser, error) {
+	r0, r1 := m.GetUserFunc.nextHook()(v0, v1)
+	m.GetUserFunc.appendCall(SCIMStoreGetUserFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the With method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) SavedSearchStore) {
+// SetDefaultHook sets function that is called when the GetUser method of
+// the parent MockSCIMStore instance is invoked and the hook queue is empty.
+func (f *SCIMStoreGetUserFunc) SetDefaultHook(hook func(context.Context, string) (*SCIMUser, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// With method of the parent MockSavedSearchStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
+// GetUser method of the parent MockSCIMStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *SavedSearchStoreWithFunc) PushHook(hook func(basestore.ShareableStore) SavedSearchStore) {
+func (f *SCIMStoreGetUserFunc) PushHook(hook func(context.Context, string) (*SCIMUser, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31350,20 +32720,20 @@ func (f *SavedSearchStoreWithFunc) PushHook(hook func(basestore.ShareableStore)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreWithFunc) SetDefaultReturn(r0 SavedSearchStore) {
-	f.SetDefaultHook(func(basestore.ShareableStore) SavedSearchStore {
-		return r0
+func (f *SCIMStoreGetUserFunc) SetDefaultReturn(r0 *SCIMUser, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (*SCIMUser, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreWithFunc) PushReturn(r0 SavedSearchStore) {
-	f.PushHook(func(basestore.ShareableStore) SavedSearchStore {
-		return r0
+func (f *SCIMStoreGetUserFunc) PushReturn(r0 *SCIMUser, r1 error) {
+	f.PushHook(func(context.Context, string) (*SCIMUser, error) {
+		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreWithFunc) nextHook() func(basestore.ShareableStore) SavedSearchStore {
+func (f *SCIMStoreGetUserFunc) nextHook() func(context.Context, string) (*SCIMUser, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31376,62 +32746,2291 @@ func (f *SavedSearchStoreWithFunc) nextHook() func(basestore.ShareableStore) Sav
 	return hook
 }
 
-func (f *SavedSearchStoreWithFunc) appendCall(r0 SavedSearchStoreWithFuncCall) {
+func (f *SCIMStoreGetUserFunc) appendCall(r0 SCIMStoreGetUserFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreWithFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreWithFunc) History() []SavedSearchStoreWithFuncCall {
+// History returns a sequence of SCIMStoreGetUserFuncCall objects describing
+// the invocations of this function.
+func (f *SCIMStoreGetUserFunc) History() []SCIMStoreGetUserFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreWithFuncCall, len(f.history))
+	history := make([]SCIMStoreGetUserFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreWithFuncCall is an object that describes an invocation of
-// method With on an instance of MockSavedSearchStore.
-type SavedSearchStoreWithFuncCall struct {
+// SCIMStoreGetUserFuncCall is an object that describes an invocation of
+// method GetUser on an instance of MockSCIMStore.
+type SCIMStoreGetUserFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 basestore.ShareableStore
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 SavedSearchStore
+	Result0 *SCIMUser
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreWithFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SCIMStoreGetUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreWithFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SCIMStoreGetUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockSearchContextsStore is a mock implementation of the
-// SearchContextsStore interface (from the package
-// github.com/sourcegraph/sourcegraph/internal/database) used for unit
-// testing.
-type MockSearchContextsStore struct {
-	// CountSearchContextsFunc is an instance of a mock function object
-	// controlling the behavior of the method CountSearchContexts.
-	CountSearchContextsFunc *SearchContextsStoreCountSearchContextsFunc
-	// CreateSearchContextWithRepositoryRevisionsFunc is an instance of a
-	// mock function object controlling the behavior of the method
-	// CreateSearchContextWithRepositoryRevisions.
-	CreateSearchContextWithRepositoryRevisionsFunc *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc
-	// DeleteSearchContextFunc is an instance of a mock function object
-	// controlling the behavior of the method DeleteSearchContext.
-	DeleteSearchContextFunc *SearchContextsStoreDeleteSearchContextFunc
-	// DoneFunc is an instance of a mock function object controlling the
+// SCIMStoreGetUserByUserIDFunc describes the behavior when the
+// GetUserByUserID method of the parent MockSCIMStore instance is invoked.
+type SCIMStoreGetUserByUserIDFunc struct {
+	defaultHook func(context.Context, int32) (*SCIMUser, error)
+	hooks       []func(context.Context, int32) (*SCIMUser, error)
+	history     []SCIMStoreGetUserByUserIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUserByUserID delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSCIMStore) GetUserByUserID(v0 context.Context, v1 int32) (*SCIMUser, error) {
+	r0, r1 := m.GetUserByUserIDFunc.nextHook()(v0, v1)
+	m.GetUserByUserIDFunc.appendCall(SCIMStoreGetUserByUserIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUserByUserID
+// method of the parent MockSCIMStore instance is invoked and the hook queue
+// is empty.
+func (f *SCIMStoreGetUserByUserIDFunc) SetDefaultHook(hook func(context.Context, int32) (*SCIMUser, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUserByUserID method of the parent MockSCIMStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SCIMStoreGetUserByUserIDFunc) PushHook(hook func(context.Context, int32) (*SCIMUser, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIMStoreGetUserByUserIDFunc) SetDefaultReturn(r0 *SCIMUser, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*SCIMUser, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIMStoreGetUserByUserIDFunc) PushReturn(r0 *SCIMUser, r1 error) {
+	f.PushHook(func(context.Context, int32) (*SCIMUser, error) {
+		return r0, r1
+	})
+}
+
+func (f *SCIMStoreGetUserByUserIDFunc) nextHook() func(context.Context, int32) (*SCIMUser, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIMStoreGetUserByUserIDFunc) appendCall(r0 SCIMStoreGetUserByUserIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIMStoreGetUserByUserIDFuncCall objects
+// describing the invocations of this function.
+func (f *SCIMStoreGetUserByUserIDFunc) History() []SCIMStoreGetUserByUserIDFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIMStoreGetUserByUserIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIMStoreGetUserByUserIDFuncCall is an object that describes an
+// invocation of method GetUserByUserID on an instance of MockSCIMStore.
+type SCIMStoreGetUserByUserIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *SCIMUser
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIMStoreGetUserByUserIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIMStoreGetUserByUserIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SCIMStoreHandleFunc describes the behavior when the Handle method of the
+// parent MockSCIMStore instance is invoked.
+type SCIMStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []SCIMStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSCIMStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(SCIMStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockSCIMStore instance is invoked and the hook queue is empty.
+func (f *SCIMStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockSCIMStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIMStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIMStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *SCIMStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIMStoreHandleFunc) appendCall(r0 SCIMStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIMStoreHandleFuncCall objects describing
+// the invocations of this function.
+func (f *SCIMStoreHandleFunc) History() []SCIMStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIMStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIMStoreHandleFuncCall is an object that describes an invocation of
+// method Handle on an instance of MockSCIMStore.
+type SCIMStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIMStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIMStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SCIMStoreListGroupMembersFunc describes the behavior when the
+// ListGroupMembers method of the parent MockSCIMStore instance is invoked.
+type SCIMStoreListGroupMembersFunc struct {
+	defaultHook func(context.Context, int32) ([]*SCIMUser, error)
+	hooks       []func(context.Context, int32) ([]*SCIMUser, error)
+	history     []SCIMStoreListGroupMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// ListGroupMembers delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSCIMStore) ListGroupMembers(v0 context.Context, v1 int32) ([]*SCIMUser, error) {
+	r0, r1 := m.ListGroupMembersFunc.nextHook()(v0, v1)
+	m.ListGroupMembersFunc.appendCall(SCIMStoreListGroupMembersFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListGroupMembers
+// method of the parent MockSCIMStore instance is invoked and the hook queue
+// is empty.
+func (f *SCIMStoreListGroupMembersFunc) SetDefaultHook(hook func(context.Context, int32) ([]*SCIMUser, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListGroupMembers method of the parent MockSCIMStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SCIMStoreListGroupMembersFunc) PushHook(hook func(context.Context, int32) ([]*SCIMUser, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIMStoreListGroupMembersFunc) SetDefaultReturn(r0 []*SCIMUser, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*SCIMUser, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIMStoreListGroupMembersFunc) PushReturn(r0 []*SCIMUser, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*SCIMUser, error) {
+		return r0, r1
+	})
+}
+
+func (f *SCIMStoreListGroupMembersFunc) nextHook() func(context.Context, int32) ([]*SCIMUser, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIMStoreListGroupMembersFunc) appendCall(r0 SCIMStoreListGroupMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIMStoreListGroupMembersFuncCall objects
+// describing the invocations of this function.
+func (f *SCIMStoreListGroupMembersFunc) History() []SCIMStoreListGroupMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIMStoreListGroupMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIMStoreListGroupMembersFuncCall is an object that describes an
+// invocation of method ListGroupMembers on an instance of MockSCIMStore.
+type SCIMStoreListGroupMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*SCIMUser
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIMStoreListGroupMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIMStoreListGroupMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SCIMStoreListGroupsFunc describes the behavior when the ListGroups method
+// of the parent MockSCIMStore instance is invoked.
+type SCIMStoreListGroupsFunc struct {
+	defaultHook func(context.Context, SCIMListOpts) ([]*SCIMGroup, error)
+	hooks       []func(context.Context, SCIMListOpts) ([]*SCIMGroup, error)
+	history     []SCIMStoreListGroupsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListGroups delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSCIMStore) ListGroups(v0 context.Context, v1 SCIMListOpts) ([]*SCIMGroup, error) {
+	r0, r1 := m.ListGroupsFunc.nextHook()(v0, v1)
+	m.ListGroupsFunc.appendCall(SCIMStoreListGroupsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListGroups method of
+// the parent MockSCIMStore instance is invoked and the hook queue is empty.
+func (f *SCIMStoreListGroupsFunc) SetDefaultHook(hook func(context.Context, SCIMListOpts) ([]*SCIMGroup, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListGroups method of the parent MockSCIMStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreListGroupsFunc) PushHook(hook func(context.Context, SCIMListOpts) ([]*SCIMGroup, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIMStoreListGroupsFunc) SetDefaultReturn(r0 []*SCIMGroup, r1 error) {
+	f.SetDefaultHook(func(context.Context, SCIMListOpts) ([]*SCIMGroup, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIMStoreListGroupsFunc) PushReturn(r0 []*SCIMGroup, r1 error) {
+	f.PushHook(func(context.Context, SCIMListOpts) ([]*SCIMGroup, error) {
+		return r0, r1
+	})
+}
+
+func (f *SCIMStoreListGroupsFunc) nextHook() func(context.Context, SCIMListOpts) ([]*SCIMGroup, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIMStoreListGroupsFunc) appendCall(r0 SCIMStoreListGroupsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIMStoreListGroupsFuncCall objects
+// describing the invocations of this function.
+func (f *SCIMStoreListGroupsFunc) History() []SCIMStoreListGroupsFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIMStoreListGroupsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIMStoreListGroupsFuncCall is an object that describes an invocation of
+// method ListGroups on an instance of MockSCIMStore.
+type SCIMStoreListGroupsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 SCIMListOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*SCIMGroup
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIMStoreListGroupsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIMStoreListGroupsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SCIMStoreListUsersFunc describes the behavior when the ListUsers method
+// of the parent MockSCIMStore instance is invoked.
+type SCIMStoreListUsersFunc struct {
+	defaultHook func(context.Context, SCIMListOpts) ([]*SCIMUser, error)
+	hooks       []func(context.Context, SCIMListOpts) ([]*SCIMUser, error)
+	history     []SCIMStoreListUsersFuncCall
+	mutex       sync.Mutex
+}
+
+// ListUsers delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSCIMStore) ListUsers(v0 context.Context, v1 SCIMListOpts) ([]*SCIMUser, error) {
+	r0, r1 := m.ListUsersFunc.nextHook()(v0, v1)
+	m.ListUsersFunc.appendCall(SCIMStoreListUsersFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListUsers method of
+// the parent MockSCIMStore instance is invoked and the hook queue is empty.
+func (f *SCIMStoreListUsersFunc) SetDefaultHook(hook func(context.Context, SCIMListOpts) ([]*SCIMUser, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListUsers method of the parent MockSCIMStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreListUsersFunc) PushHook(hook func(context.Context, SCIMListOpts) ([]*SCIMUser, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIMStoreListUsersFunc) SetDefaultReturn(r0 []*SCIMUser, r1 error) {
+	f.SetDefaultHook(func(context.Context, SCIMListOpts) ([]*SCIMUser, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIMStoreListUsersFunc) PushReturn(r0 []*SCIMUser, r1 error) {
+	f.PushHook(func(context.Context, SCIMListOpts) ([]*SCIMUser, error) {
+		return r0, r1
+	})
+}
+
+func (f *SCIMStoreListUsersFunc) nextHook() func(context.Context, SCIMListOpts) ([]*SCIMUser, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIMStoreListUsersFunc) appendCall(r0 SCIMStoreListUsersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIMStoreListUsersFuncCall objects
+// describing the invocations of this function.
+func (f *SCIMStoreListUsersFunc) History() []SCIMStoreListUsersFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIMStoreListUsersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIMStoreListUsersFuncCall is an object that describes an invocation of
+// method ListUsers on an instance of MockSCIMStore.
+type SCIMStoreListUsersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 SCIMListOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*SCIMUser
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIMStoreListUsersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIMStoreListUsersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SCIMStoreTransactFunc describes the behavior when the Transact method of
+// the parent MockSCIMStore instance is invoked.
+type SCIMStoreTransactFunc struct {
+	defaultHook func(context.Context) (SCIMStore, error)
+	hooks       []func(context.Context) (SCIMStore, error)
+	history     []SCIMStoreTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSCIMStore) Transact(v0 context.Context) (SCIMStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(SCIMStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockSCIMStore instance is invoked and the hook queue is empty.
+func (f *SCIMStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SCIMStore, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Transact method of the parent MockSCIMStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreTransactFunc) PushHook(hook func(context.Context) (SCIMStore, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIMStoreTransactFunc) SetDefaultReturn(r0 SCIMStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (SCIMStore, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIMStoreTransactFunc) PushReturn(r0 SCIMStore, r1 error) {
+	f.PushHook(func(context.Context) (SCIMStore, error) {
+		return r0, r1
+	})
+}
+
+func (f *SCIMStoreTransactFunc) nextHook() func(context.Context) (SCIMStore, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIMStoreTransactFunc) appendCall(r0 SCIMStoreTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIMStoreTransactFuncCall objects
+// describing the invocations of this function.
+func (f *SCIMStoreTransactFunc) History() []SCIMStoreTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIMStoreTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIMStoreTransactFuncCall is an object that describes an invocation of
+// method Transact on an instance of MockSCIMStore.
+type SCIMStoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 SCIMStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIMStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIMStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SCIMStoreUpdateGroupFunc describes the behavior when the UpdateGroup
+// method of the parent MockSCIMStore instance is invoked.
+type SCIMStoreUpdateGroupFunc struct {
+	defaultHook func(context.Context, *SCIMGroup) (*SCIMGroup, error)
+	hooks       []func(context.Context, *SCIMGroup) (*SCIMGroup, error)
+	history     []SCIMStoreUpdateGroupFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateGroup delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSCIMStore) UpdateGroup(v0 context.Context, v1 *SCIMGroup) (*SCIMGroup, error) {
+	r0, r1 := m.UpdateGroupFunc.nextHook()(v0, v1)
+	m.UpdateGroupFunc.appendCall(SCIMStoreUpdateGroupFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateGroup method
+// of the parent MockSCIMStore instance is invoked and the hook queue is
+// empty.
+func (f *SCIMStoreUpdateGroupFunc) SetDefaultHook(hook func(context.Context, *SCIMGroup) (*SCIMGroup, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateGroup method of the parent MockSCIMStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreUpdateGroupFunc) PushHook(hook func(context.Context, *SCIMGroup) (*SCIMGroup, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIMStoreUpdateGroupFunc) SetDefaultReturn(r0 *SCIMGroup, r1 error) {
+	f.SetDefaultHook(func(context.Context, *SCIMGroup) (*SCIMGroup, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIMStoreUpdateGroupFunc) PushReturn(r0 *SCIMGroup, r1 error) {
+	f.PushHook(func(context.Context, *SCIMGroup) (*SCIMGroup, error) {
+		return r0, r1
+	})
+}
+
+func (f *SCIMStoreUpdateGroupFunc) nextHook() func(context.Context, *SCIMGroup) (*SCIMGroup, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIMStoreUpdateGroupFunc) appendCall(r0 SCIMStoreUpdateGroupFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIMStoreUpdateGroupFuncCall objects
+// describing the invocations of this function.
+func (f *SCIMStoreUpdateGroupFunc) History() []SCIMStoreUpdateGroupFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIMStoreUpdateGroupFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIMStoreUpdateGroupFuncCall is an object that describes an invocation of
+// method UpdateGroup on an instance of MockSCIMStore.
+type SCIMStoreUpdateGroupFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *SCIMGroup
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *SCIMGroup
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIMStoreUpdateGroupFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIMStoreUpdateGroupFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SCIMStoreUpdateUserFunc describes the behavior when the UpdateUser method
+// of the parent MockSCIMStore instance is invoked.
+type SCIMStoreUpdateUserFunc struct {
+	defaultHook func(context.Context, *SCIMUser) (*SCIMUser, error)
+	hooks       []func(context.Context, *SCIMUser) (*SCIMUser, error)
+	history     []SCIMStoreUpdateUserFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateUser delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSCIMStore) UpdateUser(v0 context.Context, v1 *SCIMUser) (*SCIMUser, error) {
+	r0, r1 := m.UpdateUserFunc.nextHook()(v0, v1)
+	m.UpdateUserFunc.appendCall(SCIMStoreUpdateUserFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateUser method of
+// the parent MockSCIMStore instance is invoked and the hook queue is empty.
+func (f *SCIMStoreUpdateUserFunc) SetDefaultHook(hook func(context.Context, *SCIMUser) (*SCIMUser, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateUser method of the parent MockSCIMStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SCIMStoreUpdateUserFunc) PushHook(hook func(context.Context, *SCIMUser) (*SCIMUser, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIMStoreUpdateUserFunc) SetDefaultReturn(r0 *SCIMUser, r1 error) {
+	f.SetDefaultHook(func(context.Context, *SCIMUser) (*SCIMUser, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIMStoreUpdateUserFunc) PushReturn(r0 *SCIMUser, r1 error) {
+	f.PushHook(func(context.Context, *SCIMUser) (*SCIMUser, error) {
+		return r0, r1
+	})
+}
+
+func (f *SCIMStoreUpdateUserFunc) nextHook() func(context.Context, *SCIMUser) (*SCIMUser, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIMStoreUpdateUserFunc) appendCall(r0 SCIMStoreUpdateUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIMStoreUpdateUserFuncCall objects
+// describing the invocations of this function.
+func (f *SCIMStoreUpdateUserFunc) History() []SCIMStoreUpdateUserFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIMStoreUpdateUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIMStoreUpdateUserFuncCall is an object that describes an invocation of
+// method UpdateUser on an instance of MockSCIMStore.
+type SCIMStoreUpdateUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *SCIMUser
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *SCIMUser
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIMStoreUpdateUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIMStoreUpdateUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockSavedSearchStore is a mock implementation of the SavedSearchStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockSavedSearchStore struct {
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *SavedSearchStoreCreateFunc
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *SavedSearchStoreDeleteFunc
+	// GetByIDFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByID.
+	GetByIDFunc *SavedSearchStoreGetByIDFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *SavedSearchStoreHandleFunc
+	// IsEmptyFunc is an instance of a mock function object controlling the
+	// behavior of the method IsEmpty.
+	IsEmptyFunc *SavedSearchStoreIsEmptyFunc
+	// ListAllFunc is an instance of a mock function object controlling the
+	// behavior of the method ListAll.
+	ListAllFunc *SavedSearchStoreListAllFunc
+	// ListSavedSearchesByOrgIDFunc is an instance of a mock function object
+	// controlling the behavior of the method ListSavedSearchesByOrgID.
+	ListSavedSearchesByOrgIDFunc *SavedSearchStoreListSavedSearchesByOrgIDFunc
+	// ListSavedSearchesByUserIDFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListSavedSearchesByUserID.
+	ListSavedSearchesByUserIDFunc *SavedSearchStoreListSavedSearchesByUserIDFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *SavedSearchStoreTransactFunc
+	// UpdateFunc is an instance of a mock function object controlling the
+	// behavior of the method Update.
+	UpdateFunc *SavedSearchStoreUpdateFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *SavedSearchStoreWithFunc
+}
+
+// NewMockSavedSearchStore creates a new mock of the SavedSearchStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockSavedSearchStore() *MockSavedSearchStore {
+	return &MockSavedSearchStore{
+		CreateFunc: &SavedSearchStoreCreateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (r0 *types.SavedSearch, r1 error) {
+				return
+			},
+		},
+		DeleteFunc: &SavedSearchStoreDeleteFunc{
+			defaultHook: func(context.Context, int32) (r0 error) {
+				return
+			},
+		},
+		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
+			defaultHook: func(context.Context, int32) (r0 *api.SavedQuerySpecAndConfig, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &SavedSearchStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
+			defaultHook: func(context.Context) (r0 bool, r1 error) {
+				return
+			},
+		},
+		ListAllFunc: &SavedSearchStoreListAllFunc{
+			defaultHook: func(context.Context) (r0 []api.SavedQuerySpecAndConfig, r1 error) {
+				return
+			},
+		},
+		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
+			defaultHook: func(context.Context, int32) (r0 []*types.SavedSearch, r1 error) {
+				return
+			},
+		},
+		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
+			defaultHook: func(context.Context, int32) (r0 []*types.SavedSearch, r1 error) {
+				return
+			},
+		},
+		TransactFunc: &SavedSearchStoreTransactFunc{
+			defaultHook: func(context.Context) (r0 SavedSearchStore, r1 error) {
+				return
+			},
+		},
+		UpdateFunc: &SavedSearchStoreUpdateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (r0 *types.SavedSearch, r1 error) {
+				return
+			},
+		},
+		WithFunc: &SavedSearchStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) (r0 SavedSearchStore) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockSavedSearchStore creates a new mock of the SavedSearchStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockSavedSearchStore() *MockSavedSearchStore {
+	return &MockSavedSearchStore{
+		CreateFunc: &SavedSearchStoreCreateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.Create")
+			},
+		},
+		DeleteFunc: &SavedSearchStoreDeleteFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockSavedSearchStore.Delete")
+			},
+		},
+		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
+			defaultHook: func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+				panic("unexpected invocation of MockSavedSearchStore.GetByID")
+			},
+		},
+		HandleFunc: &SavedSearchStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockSavedSearchStore.Handle")
+			},
+		},
+		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
+			defaultHook: func(context.Context) (bool, error) {
+				panic("unexpected invocation of MockSavedSearchStore.IsEmpty")
+			},
+		},
+		ListAllFunc: &SavedSearchStoreListAllFunc{
+			defaultHook: func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListAll")
+			},
+		},
+		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
+			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByOrgID")
+			},
+		},
+		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
+			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByUserID")
+			},
+		},
+		TransactFunc: &SavedSearchStoreTransactFunc{
+			defaultHook: func(context.Context) (SavedSearchStore, error) {
+				panic("unexpected invocation of MockSavedSearchStore.Transact")
+			},
+		},
+		UpdateFunc: &SavedSearchStoreUpdateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.Update")
+			},
+		},
+		WithFunc: &SavedSearchStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) SavedSearchStore {
+				panic("unexpected invocation of MockSavedSearchStore.With")
+			},
+		},
+	}
+}
+
+// NewMockSavedSearchStoreFrom creates a new mock of the
+// MockSavedSearchStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockSavedSearchStoreFrom(i SavedSearchStore) *MockSavedSearchStore {
+	return &MockSavedSearchStore{
+		CreateFunc: &SavedSearchStoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		DeleteFunc: &SavedSearchStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
+			defaultHook: i.GetByID,
+		},
+		HandleFunc: &SavedSearchStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
+			defaultHook: i.IsEmpty,
+		},
+		ListAllFunc: &SavedSearchStoreListAllFunc{
+			defaultHook: i.ListAll,
+		},
+		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
+			defaultHook: i.ListSavedSearchesByOrgID,
+		},
+		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
+			defaultHook: i.ListSavedSearchesByUserID,
+		},
+		TransactFunc: &SavedSearchStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpdateFunc: &SavedSearchStoreUpdateFunc{
+			defaultHook: i.Update,
+		},
+		WithFunc: &SavedSearchStoreWithFunc{
+			defaultHook: i.With,
+		},
+	}
+}
+
+// SavedSearchStoreCreateFunc describes the behavior when the Create method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreCreateFunc struct {
+	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	history     []SavedSearchStoreCreateFuncCall
+	mutex       sync.Mutex
+}
+
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Create(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1)
+	m.CreateFunc.appendCall(SavedSearchStoreCreateFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreCreateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Create method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreCreateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreCreateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreCreateFunc) appendCall(r0 SavedSearchStoreCreateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreCreateFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreCreateFunc) History() []SavedSearchStoreCreateFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreCreateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreCreateFuncCall is an object that describes an invocation
+// of method Create on an instance of MockSavedSearchStore.
+type SavedSearchStoreCreateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.SavedSearch
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SavedSearch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreCreateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreCreateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreDeleteFunc describes the behavior when the Delete method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreDeleteFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []SavedSearchStoreDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Delete(v0 context.Context, v1 int32) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(SavedSearchStoreDeleteFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Delete method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
+	})
+}
+
+func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreDeleteFunc) appendCall(r0 SavedSearchStoreDeleteFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreDeleteFunc) History() []SavedSearchStoreDeleteFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreDeleteFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreDeleteFuncCall is an object that describes an invocation
+// of method Delete on an instance of MockSavedSearchStore.
+type SavedSearchStoreDeleteFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SavedSearchStoreGetByIDFunc describes the behavior when the GetByID
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
+	hooks       []func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
+	history     []SavedSearchStoreGetByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByID delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) GetByID(v0 context.Context, v1 int32) (*api.SavedQuerySpecAndConfig, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(SavedSearchStoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByID method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreGetByIDFunc) SetDefaultReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreGetByIDFunc) PushReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
+	f.PushHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreGetByIDFunc) appendCall(r0 SavedSearchStoreGetByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreGetByIDFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreGetByIDFunc) History() []SavedSearchStoreGetByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreGetByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreGetByIDFuncCall is an object that describes an invocation
+// of method GetByID on an instance of MockSavedSearchStore.
+type SavedSearchStoreGetByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *api.SavedQuerySpecAndConfig
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreGetByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreHandleFunc describes the behavior when the Handle method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []SavedSearchStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(SavedSearchStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *SavedSearchStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreHandleFunc) appendCall(r0 SavedSearchStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreHandleFunc) History() []SavedSearchStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreHandleFuncCall is an object that describes an invocation
+// of method Handle on an instance of MockSavedSearchStore.
+type SavedSearchStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SavedSearchStoreIsEmptyFunc describes the behavior when the IsEmpty
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreIsEmptyFunc struct {
+	defaultHook func(context.Context) (bool, error)
+	hooks       []func(context.Context) (bool, error)
+	history     []SavedSearchStoreIsEmptyFuncCall
+	mutex       sync.Mutex
+}
+
+// IsEmpty delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) IsEmpty(v0 context.Context) (bool, error) {
+	r0, r1 := m.IsEmptyFunc.nextHook()(v0)
+	m.IsEmptyFunc.appendCall(SavedSearchStoreIsEmptyFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the IsEmpty method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreIsEmptyFunc) SetDefaultHook(hook func(context.Context) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsEmpty method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreIsEmptyFunc) PushHook(hook func(context.Context) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreIsEmptyFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreIsEmptyFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreIsEmptyFunc) nextHook() func(context.Context) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreIsEmptyFunc) appendCall(r0 SavedSearchStoreIsEmptyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreIsEmptyFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreIsEmptyFunc) History() []SavedSearchStoreIsEmptyFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreIsEmptyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreIsEmptyFuncCall is an object that describes an invocation
+// of method IsEmpty on an instance of MockSavedSearchStore.
+type SavedSearchStoreIsEmptyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreIsEmptyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreIsEmptyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreListAllFunc describes the behavior when the ListAll
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreListAllFunc struct {
+	defaultHook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
+	hooks       []func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
+	history     []SavedSearchStoreListAllFuncCall
+	mutex       sync.Mutex
+}
+
+// ListAll delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListAll(v0 context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+	r0, r1 := m.ListAllFunc.nextHook()(v0)
+	m.ListAllFunc.appendCall(SavedSearchStoreListAllFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListAll method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreListAllFunc) SetDefaultHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListAll method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreListAllFunc) PushHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreListAllFunc) SetDefaultReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreListAllFunc) PushReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
+	f.PushHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreListAllFunc) nextHook() func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreListAllFunc) appendCall(r0 SavedSearchStoreListAllFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreListAllFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreListAllFunc) History() []SavedSearchStoreListAllFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreListAllFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreListAllFuncCall is an object that describes an invocation
+// of method ListAll on an instance of MockSavedSearchStore.
+type SavedSearchStoreListAllFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []api.SavedQuerySpecAndConfig
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreListAllFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreListAllFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreListSavedSearchesByOrgIDFunc describes the behavior when
+// the ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
+// instance is invoked.
+type SavedSearchStoreListSavedSearchesByOrgIDFunc struct {
+	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
+	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
+	history     []SavedSearchStoreListSavedSearchesByOrgIDFuncCall
+	mutex       sync.Mutex
+}
+
+// ListSavedSearchesByOrgID delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListSavedSearchesByOrgID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
+	r0, r1 := m.ListSavedSearchesByOrgIDFunc.nextHook()(v0, v1)
+	m.ListSavedSearchesByOrgIDFunc.appendCall(SavedSearchStoreListSavedSearchesByOrgIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
+// instance is invoked and the hook queue is empty.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByOrgIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SavedSearchStoreListSavedSearchesByOrgIDFuncCall objects describing the
+// invocations of this function.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) History() []SavedSearchStoreListSavedSearchesByOrgIDFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreListSavedSearchesByOrgIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreListSavedSearchesByOrgIDFuncCall is an object that
+// describes an invocation of method ListSavedSearchesByOrgID on an instance
+// of MockSavedSearchStore.
+type SavedSearchStoreListSavedSearchesByOrgIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.SavedSearch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreListSavedSearchesByUserIDFunc describes the behavior when
+// the ListSavedSearchesByUserID method of the parent MockSavedSearchStore
+// instance is invoked.
+type SavedSearchStoreListSavedSearchesByUserIDFunc struct {
+	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
+	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
+	history     []SavedSearchStoreListSavedSearchesByUserIDFuncCall
+	mutex       sync.Mutex
+}
+
+// ListSavedSearchesByUserID delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListSavedSearchesByUserID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
+	r0, r1 := m.ListSavedSearchesByUserIDFunc.nextHook()(v0, v1)
+	m.ListSavedSearchesByUserIDFunc.appendCall(SavedSearchStoreListSavedSearchesByUserIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
+// instance is invoked and the hook queue is empty.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByUserIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SavedSearchStoreListSavedSearchesByUserIDFuncCall objects describing the
+// invocations of this function.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) History() []SavedSearchStoreListSavedSearchesByUserIDFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreListSavedSearchesByUserIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreListSavedSearchesByUserIDFuncCall is an object that
+// describes an invocation of method ListSavedSearchesByUserID on an
+// instance of MockSavedSearchStore.
+type SavedSearchStoreListSavedSearchesByUserIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.SavedSearch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreTransactFunc describes the behavior when the Transact
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreTransactFunc struct {
+	defaultHook func(context.Context) (SavedSearchStore, error)
+	hooks       []func(context.Context) (SavedSearchStore, error)
+	history     []SavedSearchStoreTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Transact(v0 context.Context) (SavedSearchStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(SavedSearchStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SavedSearchStore, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Transact method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreTransactFunc) PushHook(hook func(context.Context) (SavedSearchStore, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreTransactFunc) SetDefaultReturn(r0 SavedSearchStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (SavedSearchStore, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreTransactFunc) PushReturn(r0 SavedSearchStore, r1 error) {
+	f.PushHook(func(context.Context) (SavedSearchStore, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreTransactFunc) nextHook() func(context.Context) (SavedSearchStore, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreTransactFunc) appendCall(r0 SavedSearchStoreTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreTransactFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreTransactFunc) History() []SavedSearchStoreTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of MockSavedSearchStore.
+type SavedSearchStoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 SavedSearchStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreUpdateFunc describes the behavior when the Update method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreUpdateFunc struct {
+	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	history     []SavedSearchStoreUpdateFuncCall
+	mutex       sync.Mutex
+}
+
+// Update delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Update(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
+	r0, r1 := m.UpdateFunc.nextHook()(v0, v1)
+	m.UpdateFunc.appendCall(SavedSearchStoreUpdateFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Update method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreUpdateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Update method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreUpdateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreUpdateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreUpdateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreUpdateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreUpdateFunc) appendCall(r0 SavedSearchStoreUpdateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreUpdateFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreUpdateFunc) History() []SavedSearchStoreUpdateFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreUpdateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreUpdateFuncCall is an object that describes an invocation
+// of method Update on an instance of MockSavedSearchStore.
+type SavedSearchStoreUpdateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.SavedSearch
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SavedSearch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreUpdateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreUpdateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreWithFunc describes the behavior when the With method of
+// the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) SavedSearchStore
+	hooks       []func(basestore.ShareableStore) SavedSearchStore
+	history     []SavedSearchStoreWithFuncCall
+	mutex       sync.Mutex
+}
+
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) With(v0 basestore.ShareableStore) SavedSearchStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(SavedSearchStoreWithFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) SavedSearchStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// With method of the parent MockSavedSearchStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SavedSearchStoreWithFunc) PushHook(hook func(basestore.ShareableStore) SavedSearchStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreWithFunc) SetDefaultReturn(r0 SavedSearchStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) SavedSearchStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreWithFunc) PushReturn(r0 SavedSearchStore) {
+	f.PushHook(func(basestore.ShareableStore) SavedSearchStore {
+		return r0
+	})
+}
+
+func (f *SavedSearchStoreWithFunc) nextHook() func(basestore.ShareableStore) SavedSearchStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreWithFunc) appendCall(r0 SavedSearchStoreWithFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreWithFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreWithFunc) History() []SavedSearchStoreWithFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreWithFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreWithFuncCall is an object that describes an invocation of
+// method With on an instance of MockSavedSearchStore.
+type SavedSearchStoreWithFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 basestore.ShareableStore
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 SavedSearchStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockSearchContextsStore is a mock implementation of the
+// SearchContextsStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockSearchContextsStore struct {
+	// CountSearchContextsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountSearchContexts.
+	CountSearchContextsFunc *SearchContextsStoreCountSearchContextsFunc
+	// CreateSearchContextWithRepositoryRevisionsFunc is an instance of a
+	// mock function object controlling the behavior of the method
+	// CreateSearchContextWithRepositoryRevisions.
+	CreateSearchContextWithRepositoryRevisionsFunc *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc
+	// DeleteSearchContextFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteSearchContext.
+	DeleteSearchContextFunc *SearchContextsStoreDeleteSearchContextFunc
+	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *SearchContextsStoreDoneFunc
 	// ExecFunc is an instance of a mock function object controlling the
@@ -31469,243 +35068,1009 @@ type MockSearchContextsStore struct {
 	UpdateSearchContextWithRepositoryRevisionsFunc *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc
 }
 
-// NewMockSearchContextsStore creates a new mock of the SearchContextsStore
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockSearchContextsStore() *MockSearchContextsStore {
-	return &MockSearchContextsStore{
-		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
-			defaultHook: func(context.Context, ListSearchContextsOptions) (r0 int32, r1 error) {
-				return
-			},
-		},
-		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (r0 *types.SearchContext, r1 error) {
-				return
-			},
-		},
-		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
-			defaultHook: func(context.Context, int64) (r0 error) {
-				return
-			},
-		},
-		DoneFunc: &SearchContextsStoreDoneFunc{
-			defaultHook: func(error) (r0 error) {
-				return
-			},
-		},
-		ExecFunc: &SearchContextsStoreExecFunc{
-			defaultHook: func(context.Context, *sqlf.Query) (r0 error) {
-				return
-			},
-		},
-		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
-			defaultHook: func(context.Context) (r0 []string, r1 error) {
-				return
-			},
-		},
-		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
-			defaultHook: func(context.Context, []api.RepoID) (r0 map[api.RepoID][]string, r1 error) {
-				return
-			},
-		},
-		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
-			defaultHook: func(context.Context, GetSearchContextOptions) (r0 *types.SearchContext, r1 error) {
-				return
-			},
-		},
-		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, int64) (r0 []*types.SearchContextRepositoryRevisions, r1 error) {
-				return
-			},
-		},
-		HandleFunc: &SearchContextsStoreHandleFunc{
-			defaultHook: func() (r0 basestore.TransactableHandle) {
-				return
-			},
-		},
-		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
-			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) (r0 []*types.SearchContext, r1 error) {
-				return
-			},
-		},
-		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) (r0 error) {
-				return
-			},
-		},
-		TransactFunc: &SearchContextsStoreTransactFunc{
-			defaultHook: func(context.Context) (r0 SearchContextsStore, r1 error) {
-				return
-			},
-		},
-		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (r0 *types.SearchContext, r1 error) {
-				return
-			},
-		},
+// NewMockSearchContextsStore creates a new mock of the SearchContextsStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockSearchContextsStore() *MockSearchContextsStore {
+	return &MockSearchContextsStore{
+		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
+			defaultHook: func(context.Context, ListSearchContextsOptions) (r0 int32, r1 error) {
+				return
+			},
+		},
+		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (r0 *types.SearchContext, r1 error) {
+				return
+			},
+		},
+		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
+		DoneFunc: &SearchContextsStoreDoneFunc{
+			defaultHook: func(error) (r0 error) {
+				return
+			},
+		},
+		ExecFunc: &SearchContextsStoreExecFunc{
+			defaultHook: func(context.Context, *sqlf.Query) (r0 error) {
+				return
+			},
+		},
+		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
+			defaultHook: func(context.Context) (r0 []string, r1 error) {
+				return
+			},
+		},
+		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
+			defaultHook: func(context.Context, []api.RepoID) (r0 map[api.RepoID][]string, r1 error) {
+				return
+			},
+		},
+		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
+			defaultHook: func(context.Context, GetSearchContextOptions) (r0 *types.SearchContext, r1 error) {
+				return
+			},
+		},
+		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, int64) (r0 []*types.SearchContextRepositoryRevisions, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &SearchContextsStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
+			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) (r0 []*types.SearchContext, r1 error) {
+				return
+			},
+		},
+		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) (r0 error) {
+				return
+			},
+		},
+		TransactFunc: &SearchContextsStoreTransactFunc{
+			defaultHook: func(context.Context) (r0 SearchContextsStore, r1 error) {
+				return
+			},
+		},
+		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (r0 *types.SearchContext, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockSearchContextsStore creates a new mock of the
+// SearchContextsStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
+	return &MockSearchContextsStore{
+		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
+			defaultHook: func(context.Context, ListSearchContextsOptions) (int32, error) {
+				panic("unexpected invocation of MockSearchContextsStore.CountSearchContexts")
+			},
+		},
+		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+				panic("unexpected invocation of MockSearchContextsStore.CreateSearchContextWithRepositoryRevisions")
+			},
+		},
+		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockSearchContextsStore.DeleteSearchContext")
+			},
+		},
+		DoneFunc: &SearchContextsStoreDoneFunc{
+			defaultHook: func(error) error {
+				panic("unexpected invocation of MockSearchContextsStore.Done")
+			},
+		},
+		ExecFunc: &SearchContextsStoreExecFunc{
+			defaultHook: func(context.Context, *sqlf.Query) error {
+				panic("unexpected invocation of MockSearchContextsStore.Exec")
+			},
+		},
+		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
+			defaultHook: func(context.Context) ([]string, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetAllQueries")
+			},
+		},
+		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
+			defaultHook: func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetAllRevisionsForRepos")
+			},
+		},
+		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
+			defaultHook: func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetSearchContext")
+			},
+		},
+		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetSearchContextRepositoryRevisions")
+			},
+		},
+		HandleFunc: &SearchContextsStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockSearchContextsStore.Handle")
+			},
+		},
+		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
+			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
+				panic("unexpected invocation of MockSearchContextsStore.ListSearchContexts")
+			},
+		},
+		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
+				panic("unexpected invocation of MockSearchContextsStore.SetSearchContextRepositoryRevisions")
+			},
+		},
+		TransactFunc: &SearchContextsStoreTransactFunc{
+			defaultHook: func(context.Context) (SearchContextsStore, error) {
+				panic("unexpected invocation of MockSearchContextsStore.Transact")
+			},
+		},
+		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+				panic("unexpected invocation of MockSearchContextsStore.UpdateSearchContextWithRepositoryRevisions")
+			},
+		},
+	}
+}
+
+// NewMockSearchContextsStoreFrom creates a new mock of the
+// MockSearchContextsStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockSearchContextsStoreFrom(i SearchContextsStore) *MockSearchContextsStore {
+	return &MockSearchContextsStore{
+		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
+			defaultHook: i.CountSearchContexts,
+		},
+		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: i.CreateSearchContextWithRepositoryRevisions,
+		},
+		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
+			defaultHook: i.DeleteSearchContext,
+		},
+		DoneFunc: &SearchContextsStoreDoneFunc{
+			defaultHook: i.Done,
+		},
+		ExecFunc: &SearchContextsStoreExecFunc{
+			defaultHook: i.Exec,
+		},
+		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
+			defaultHook: i.GetAllQueries,
+		},
+		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
+			defaultHook: i.GetAllRevisionsForRepos,
+		},
+		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
+			defaultHook: i.GetSearchContext,
+		},
+		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
+			defaultHook: i.GetSearchContextRepositoryRevisions,
+		},
+		HandleFunc: &SearchContextsStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
+			defaultHook: i.ListSearchContexts,
+		},
+		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
+			defaultHook: i.SetSearchContextRepositoryRevisions,
+		},
+		TransactFunc: &SearchContextsStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: i.UpdateSearchContextWithRepositoryRevisions,
+		},
+	}
+}
+
+// SearchContextsStoreCountSearchContextsFunc describes the behavior when
+// the CountSearchContexts method of the parent MockSearchContextsStore
+// instance is invoked.
+type SearchContextsStoreCountSearchContextsFunc struct {
+	defaultHook func(context.Context, ListSearchContextsOptions) (int32, error)
+	hooks       []func(context.Context, ListSearchContextsOptions) (int32, error)
+	history     []SearchContextsStoreCountSearchContextsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountSearchContexts delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) CountSearchContexts(v0 context.Context, v1 ListSearchContextsOptions) (int32, error) {
+	r0, r1 := m.CountSearchContextsFunc.nextHook()(v0, v1)
+	m.CountSearchContextsFunc.appendCall(SearchContextsStoreCountSearchContextsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountSearchContexts
+// method of the parent MockSearchContextsStore instance is invoked and the
+// hook queue is empty.
+func (f *SearchContextsStoreCountSearchContextsFunc) SetDefaultHook(hook func(context.Context, ListSearchContextsOptions) (int32, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountSearchContexts method of the parent MockSearchContextsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SearchContextsStoreCountSearchContextsFunc) PushHook(hook func(context.Context, ListSearchContextsOptions) (int32, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreCountSearchContextsFunc) SetDefaultReturn(r0 int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListSearchContextsOptions) (int32, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreCountSearchContextsFunc) PushReturn(r0 int32, r1 error) {
+	f.PushHook(func(context.Context, ListSearchContextsOptions) (int32, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreCountSearchContextsFunc) nextHook() func(context.Context, ListSearchContextsOptions) (int32, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreCountSearchContextsFunc) appendCall(r0 SearchContextsStoreCountSearchContextsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreCountSearchContextsFuncCall objects describing the
+// invocations of this function.
+func (f *SearchContextsStoreCountSearchContextsFunc) History() []SearchContextsStoreCountSearchContextsFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreCountSearchContextsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreCountSearchContextsFuncCall is an object that
+// describes an invocation of method CountSearchContexts on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreCountSearchContextsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListSearchContextsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreCountSearchContextsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreCountSearchContextsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc
+// describes the behavior when the
+// CreateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc struct {
+	defaultHook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
+	hooks       []func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
+	history     []SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateSearchContextWithRepositoryRevisions delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) CreateSearchContextWithRepositoryRevisions(v0 context.Context, v1 *types.SearchContext, v2 []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+	r0, r1 := m.CreateSearchContextWithRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
+	m.CreateSearchContextWithRepositoryRevisionsFunc.appendCall(SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) PushHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) PushReturn(r0 *types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) nextHook() func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall
+// objects describing the invocations of this function.
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) History() []SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall is
+// an object that describes an invocation of method
+// CreateSearchContextWithRepositoryRevisions on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.SearchContext
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*types.SearchContextRepositoryRevisions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SearchContext
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreDeleteSearchContextFunc describes the behavior when
+// the DeleteSearchContext method of the parent MockSearchContextsStore
+// instance is invoked.
+type SearchContextsStoreDeleteSearchContextFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []SearchContextsStoreDeleteSearchContextFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteSearchContext delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) DeleteSearchContext(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteSearchContextFunc.nextHook()(v0, v1)
+	m.DeleteSearchContextFunc.appendCall(SearchContextsStoreDeleteSearchContextFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteSearchContext
+// method of the parent MockSearchContextsStore instance is invoked and the
+// hook queue is empty.
+func (f *SearchContextsStoreDeleteSearchContextFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteSearchContext method of the parent MockSearchContextsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SearchContextsStoreDeleteSearchContextFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreDeleteSearchContextFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreDeleteSearchContextFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *SearchContextsStoreDeleteSearchContextFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreDeleteSearchContextFunc) appendCall(r0 SearchContextsStoreDeleteSearchContextFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreDeleteSearchContextFuncCall objects describing the
+// invocations of this function.
+func (f *SearchContextsStoreDeleteSearchContextFunc) History() []SearchContextsStoreDeleteSearchContextFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreDeleteSearchContextFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreDeleteSearchContextFuncCall is an object that
+// describes an invocation of method DeleteSearchContext on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreDeleteSearchContextFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreDeleteSearchContextFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreDeleteSearchContextFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SearchContextsStoreDoneFunc describes the behavior when the Done method
+// of the parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []SearchContextsStoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchContextsStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(SearchContextsStoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockSearchContextsStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchContextsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Done method of the parent MockSearchContextsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchContextsStoreDoneFunc) PushHook(hook func(error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
+	})
+}
+
+func (f *SearchContextsStoreDoneFunc) nextHook() func(error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreDoneFunc) appendCall(r0 SearchContextsStoreDoneFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SearchContextsStoreDoneFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreDoneFunc) History() []SearchContextsStoreDoneFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreDoneFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreDoneFuncCall is an object that describes an invocation
+// of method Done on an instance of MockSearchContextsStore.
+type SearchContextsStoreDoneFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreDoneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SearchContextsStoreExecFunc describes the behavior when the Exec method
+// of the parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreExecFunc struct {
+	defaultHook func(context.Context, *sqlf.Query) error
+	hooks       []func(context.Context, *sqlf.Query) error
+	history     []SearchContextsStoreExecFuncCall
+	mutex       sync.Mutex
+}
+
+// Exec delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchContextsStore) Exec(v0 context.Context, v1 *sqlf.Query) error {
+	r0 := m.ExecFunc.nextHook()(v0, v1)
+	m.ExecFunc.appendCall(SearchContextsStoreExecFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Exec method of the
+// parent MockSearchContextsStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchContextsStoreExecFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Exec method of the parent MockSearchContextsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchContextsStoreExecFunc) PushHook(hook func(context.Context, *sqlf.Query) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreExecFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *sqlf.Query) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreExecFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *sqlf.Query) error {
+		return r0
+	})
+}
+
+func (f *SearchContextsStoreExecFunc) nextHook() func(context.Context, *sqlf.Query) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreExecFunc) appendCall(r0 SearchContextsStoreExecFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SearchContextsStoreExecFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreExecFunc) History() []SearchContextsStoreExecFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreExecFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreExecFuncCall is an object that describes an invocation
+// of method Exec on an instance of MockSearchContextsStore.
+type SearchContextsStoreExecFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *sqlf.Query
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreExecFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreExecFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SearchContextsStoreGetAllQueriesFunc describes the behavior when the
+// GetAllQueries method of the parent MockSearchContextsStore instance is
+// invoked.
+type SearchContextsStoreGetAllQueriesFunc struct {
+	defaultHook func(context.Context) ([]string, error)
+	hooks       []func(context.Context) ([]string, error)
+	history     []SearchContextsStoreGetAllQueriesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetAllQueries delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetAllQueries(v0 context.Context) ([]string, error) {
+	r0, r1 := m.GetAllQueriesFunc.nextHook()(v0)
+	m.GetAllQueriesFunc.appendCall(SearchContextsStoreGetAllQueriesFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetAllQueries method
+// of the parent MockSearchContextsStore instance is invoked and the hook
+// queue is empty.
+func (f *SearchContextsStoreGetAllQueriesFunc) SetDefaultHook(hook func(context.Context) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetAllQueries method of the parent MockSearchContextsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SearchContextsStoreGetAllQueriesFunc) PushHook(hook func(context.Context) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreGetAllQueriesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreGetAllQueriesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreGetAllQueriesFunc) nextHook() func(context.Context) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
 	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreGetAllQueriesFunc) appendCall(r0 SearchContextsStoreGetAllQueriesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SearchContextsStoreGetAllQueriesFuncCall
+// objects describing the invocations of this function.
+func (f *SearchContextsStoreGetAllQueriesFunc) History() []SearchContextsStoreGetAllQueriesFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreGetAllQueriesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreGetAllQueriesFuncCall is an object that describes an
+// invocation of method GetAllQueries on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreGetAllQueriesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreGetAllQueriesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreGetAllQueriesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreGetAllRevisionsForReposFunc describes the behavior
+// when the GetAllRevisionsForRepos method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreGetAllRevisionsForReposFunc struct {
+	defaultHook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
+	hooks       []func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
+	history     []SearchContextsStoreGetAllRevisionsForReposFuncCall
+	mutex       sync.Mutex
+}
+
+// GetAllRevisionsForRepos delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetAllRevisionsForRepos(v0 context.Context, v1 []api.RepoID) (map[api.RepoID][]string, error) {
+	r0, r1 := m.GetAllRevisionsForReposFunc.nextHook()(v0, v1)
+	m.GetAllRevisionsForReposFunc.appendCall(SearchContextsStoreGetAllRevisionsForReposFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// NewStrictMockSearchContextsStore creates a new mock of the
-// SearchContextsStore interface. All methods panic on invocation, unless
-// overwritten.
-func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
-	return &MockSearchContextsStore{
-		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
-			defaultHook: func(context.Context, ListSearchContextsOptions) (int32, error) {
-				panic("unexpected invocation of MockSearchContextsStore.CountSearchContexts")
-			},
-		},
-		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-				panic("unexpected invocation of MockSearchContextsStore.CreateSearchContextWithRepositoryRevisions")
-			},
-		},
-		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
-			defaultHook: func(context.Context, int64) error {
-				panic("unexpected invocation of MockSearchContextsStore.DeleteSearchContext")
-			},
-		},
-		DoneFunc: &SearchContextsStoreDoneFunc{
-			defaultHook: func(error) error {
-				panic("unexpected invocation of MockSearchContextsStore.Done")
-			},
-		},
-		ExecFunc: &SearchContextsStoreExecFunc{
-			defaultHook: func(context.Context, *sqlf.Query) error {
-				panic("unexpected invocation of MockSearchContextsStore.Exec")
-			},
-		},
-		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
-			defaultHook: func(context.Context) ([]string, error) {
-				panic("unexpected invocation of MockSearchContextsStore.GetAllQueries")
-			},
-		},
-		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
-			defaultHook: func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
-				panic("unexpected invocation of MockSearchContextsStore.GetAllRevisionsForRepos")
-			},
-		},
-		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
-			defaultHook: func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
-				panic("unexpected invocation of MockSearchContextsStore.GetSearchContext")
-			},
-		},
-		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
-				panic("unexpected invocation of MockSearchContextsStore.GetSearchContextRepositoryRevisions")
-			},
-		},
-		HandleFunc: &SearchContextsStoreHandleFunc{
-			defaultHook: func() basestore.TransactableHandle {
-				panic("unexpected invocation of MockSearchContextsStore.Handle")
-			},
-		},
-		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
-			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
-				panic("unexpected invocation of MockSearchContextsStore.ListSearchContexts")
-			},
-		},
-		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
-				panic("unexpected invocation of MockSearchContextsStore.SetSearchContextRepositoryRevisions")
-			},
-		},
-		TransactFunc: &SearchContextsStoreTransactFunc{
-			defaultHook: func(context.Context) (SearchContextsStore, error) {
-				panic("unexpected invocation of MockSearchContextsStore.Transact")
-			},
-		},
-		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-				panic("unexpected invocation of MockSearchContextsStore.UpdateSearchContextWithRepositoryRevisions")
-			},
-		},
-	}
+// SetDefaultHook sets function that is called when the
+// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
+// instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
+	f.defaultHook = hook
 }
 
-// NewMockSearchContextsStoreFrom creates a new mock of the
-// MockSearchContextsStore interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockSearchContextsStoreFrom(i SearchContextsStore) *MockSearchContextsStore {
-	return &MockSearchContextsStore{
-		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
-			defaultHook: i.CountSearchContexts,
-		},
-		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: i.CreateSearchContextWithRepositoryRevisions,
-		},
-		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
-			defaultHook: i.DeleteSearchContext,
-		},
-		DoneFunc: &SearchContextsStoreDoneFunc{
-			defaultHook: i.Done,
-		},
-		ExecFunc: &SearchContextsStoreExecFunc{
-			defaultHook: i.Exec,
-		},
-		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
-			defaultHook: i.GetAllQueries,
-		},
-		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
-			defaultHook: i.GetAllRevisionsForRepos,
-		},
-		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
-			defaultHook: i.GetSearchContext,
-		},
-		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
-			defaultHook: i.GetSearchContextRepositoryRevisions,
-		},
-		HandleFunc: &SearchContextsStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
-			defaultHook: i.ListSearchContexts,
-		},
-		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
-			defaultHook: i.SetSearchContextRepositoryRevisions,
-		},
-		TransactFunc: &SearchContextsStoreTransactFunc{
-			defaultHook: i.Transact,
-		},
-		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: i.UpdateSearchContextWithRepositoryRevisions,
-		},
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.PushHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) nextHook() func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
 	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
 }
 
-// SearchContextsStoreCountSearchContextsFunc describes the behavior when
-// the CountSearchContexts method of the parent MockSearchContextsStore
-// instance is invoked.
-type SearchContextsStoreCountSearchContextsFunc struct {
-	defaultHook func(context.Context, ListSearchContextsOptions) (int32, error)
-	hooks       []func(context.Context, ListSearchContextsOptions) (int32, error)
-	history     []SearchContextsStoreCountSearchContextsFuncCall
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) appendCall(r0 SearchContextsStoreGetAllRevisionsForReposFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreGetAllRevisionsForReposFuncCall objects describing the
+// invocations of this function.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) History() []SearchContextsStoreGetAllRevisionsForReposFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreGetAllRevisionsForReposFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreGetAllRevisionsForReposFuncCall is an object that
+// describes an invocation of method GetAllRevisionsForRepos on an instance
+// of MockSearchContextsStore.
+type SearchContextsStoreGetAllRevisionsForReposFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID][]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreGetSearchContextFunc describes the behavior when the
+// GetSearchContext method of the parent MockSearchContextsStore instance is
+// invoked.
+type SearchContextsStoreGetSearchContextFunc struct {
+	defaultHook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)
+	hooks       []func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)
+	history     []SearchContextsStoreGetSearchContextFuncCall
 	mutex       sync.Mutex
 }
 
-// CountSearchContexts delegates to the next hook function in the queue and
+// GetSearchContext delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) CountSearchContexts(v0 context.Context, v1 ListSearchContextsOptions) (int32, error) {
-	r0, r1 := m.CountSearchContextsFunc.nextHook()(v0, v1)
-	m.CountSearchContextsFunc.appendCall(SearchContextsStoreCountSearchContextsFuncCall{v0, v1, r0, r1})
+func (m *MockSearchContextsStore) GetSearchContext(v0 context.Context, v1 GetSearchContextOptions) (*types.SearchContext, error) {
+	r0, r1 := m.GetSearchContextFunc.nextHook()(v0, v1)
+	m.GetSearchContextFunc.appendCall(SearchContextsStoreGetSearchContextFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CountSearchContexts
+// SetDefaultHook sets function that is called when the GetSearchContext
 // method of the parent MockSearchContextsStore instance is invoked and the
 // hook queue is empty.
-func (f *SearchContextsStoreCountSearchContextsFunc) SetDefaultHook(hook func(context.Context, ListSearchContextsOptions) (int32, error)) {
+func (f *SearchContextsStoreGetSearchContextFunc) SetDefaultHook(hook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CountSearchContexts method of the parent MockSearchContextsStore instance
+// GetSearchContext method of the parent MockSearchContextsStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *SearchContextsStoreCountSearchContextsFunc) PushHook(hook func(context.Context, ListSearchContextsOptions) (int32, error)) {
+func (f *SearchContextsStoreGetSearchContextFunc) PushHook(hook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31713,20 +36078,20 @@ func (f *SearchContextsStoreCountSearchContextsFunc) PushHook(hook func(context.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreCountSearchContextsFunc) SetDefaultReturn(r0 int32, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListSearchContextsOptions) (int32, error) {
+func (f *SearchContextsStoreGetSearchContextFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreCountSearchContextsFunc) PushReturn(r0 int32, r1 error) {
-	f.PushHook(func(context.Context, ListSearchContextsOptions) (int32, error) {
+func (f *SearchContextsStoreGetSearchContextFunc) PushReturn(r0 *types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreCountSearchContextsFunc) nextHook() func(context.Context, ListSearchContextsOptions) (int32, error) {
+func (f *SearchContextsStoreGetSearchContextFunc) nextHook() func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31739,37 +36104,150 @@ func (f *SearchContextsStoreCountSearchContextsFunc) nextHook() func(context.Con
 	return hook
 }
 
-func (f *SearchContextsStoreCountSearchContextsFunc) appendCall(r0 SearchContextsStoreCountSearchContextsFuncCall) {
+func (f *SearchContextsStoreGetSearchContextFunc) appendCall(r0 SearchContextsStoreGetSearchContextFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SearchContextsStoreGetSearchContextFuncCall
+// objects describing the invocations of this function.
+func (f *SearchContextsStoreGetSearchContextFunc) History() []SearchContextsStoreGetSearchContextFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreGetSearchContextFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreGetSearchContextFuncCall is an object that describes
+// an invocation of method GetSearchContext on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreGetSearchContextFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 GetSearchContextOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SearchContext
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreGetSearchContextFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreGetSearchContextFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreGetSearchContextRepositoryRevisionsFunc describes the
+// behavior when the GetSearchContextRepositoryRevisions method of the
+// parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreGetSearchContextRepositoryRevisionsFunc struct {
+	defaultHook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
+	hooks       []func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
+	history     []SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSearchContextRepositoryRevisions delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) GetSearchContextRepositoryRevisions(v0 context.Context, v1 int64) ([]*types.SearchContextRepositoryRevisions, error) {
+	r0, r1 := m.GetSearchContextRepositoryRevisionsFunc.nextHook()(v0, v1)
+	m.GetSearchContextRepositoryRevisionsFunc.appendCall(SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetSearchContextRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSearchContextRepositoryRevisions method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) PushHook(hook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) SetDefaultReturn(r0 []*types.SearchContextRepositoryRevisions, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) PushReturn(r0 []*types.SearchContextRepositoryRevisions, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) nextHook() func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// SearchContextsStoreCountSearchContextsFuncCall objects describing the
-// invocations of this function.
-func (f *SearchContextsStoreCountSearchContextsFunc) History() []SearchContextsStoreCountSearchContextsFuncCall {
+// SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) History() []SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreCountSearchContextsFuncCall, len(f.history))
+	history := make([]SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreCountSearchContextsFuncCall is an object that
-// describes an invocation of method CountSearchContexts on an instance of
+// SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall is an
+// object that describes an invocation of method
+// GetSearchContextRepositoryRevisions on an instance of
 // MockSearchContextsStore.
-type SearchContextsStoreCountSearchContextsFuncCall struct {
+type SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListSearchContextsOptions
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int32
+	Result0 []*types.SearchContextRepositoryRevisions
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -31777,49 +36255,146 @@ type SearchContextsStoreCountSearchContextsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreCountSearchContextsFuncCall) Args() []interface{} {
+func (c SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreCountSearchContextsFuncCall) Results() []interface{} {
+func (c SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc
-// describes the behavior when the
-// CreateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked.
-type SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc struct {
-	defaultHook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
-	hooks       []func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
-	history     []SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall
+// SearchContextsStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []SearchContextsStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateSearchContextWithRepositoryRevisions delegates to the next hook
-// function in the queue and stores the parameter and result values of this
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchContextsStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(SearchContextsStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockSearchContextsStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchContextsStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockSearchContextsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchContextsStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *SearchContextsStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreHandleFunc) appendCall(r0 SearchContextsStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SearchContextsStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreHandleFunc) History() []SearchContextsStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockSearchContextsStore.
+type SearchContextsStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
 // invocation.
-func (m *MockSearchContextsStore) CreateSearchContextWithRepositoryRevisions(v0 context.Context, v1 *types.SearchContext, v2 []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-	r0, r1 := m.CreateSearchContextWithRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
-	m.CreateSearchContextWithRepositoryRevisionsFunc.appendCall(SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall{v0, v1, v2, r0, r1})
+func (c SearchContextsStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SearchContextsStoreListSearchContextsFunc describes the behavior when the
+// ListSearchContexts method of the parent MockSearchContextsStore instance
+// is invoked.
+type SearchContextsStoreListSearchContextsFunc struct {
+	defaultHook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)
+	hooks       []func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)
+	history     []SearchContextsStoreListSearchContextsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListSearchContexts delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) ListSearchContexts(v0 context.Context, v1 ListSearchContextsPageOptions, v2 ListSearchContextsOptions) ([]*types.SearchContext, error) {
+	r0, r1 := m.ListSearchContextsFunc.nextHook()(v0, v1, v2)
+	m.ListSearchContextsFunc.appendCall(SearchContextsStoreListSearchContextsFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// CreateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+// SetDefaultHook sets function that is called when the ListSearchContexts
+// method of the parent MockSearchContextsStore instance is invoked and the
+// hook queue is empty.
+func (f *SearchContextsStoreListSearchContextsFunc) SetDefaultHook(hook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance invokes the hook at the front of the
-// queue and discards it. After the queue is empty, the default hook
-// function is invoked for any future action.
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) PushHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+// ListSearchContexts method of the parent MockSearchContextsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SearchContextsStoreListSearchContextsFunc) PushHook(hook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31827,20 +36402,20 @@ func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) Push
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
-	f.SetDefaultHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+func (f *SearchContextsStoreListSearchContextsFunc) SetDefaultReturn(r0 []*types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) PushReturn(r0 *types.SearchContext, r1 error) {
-	f.PushHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+func (f *SearchContextsStoreListSearchContextsFunc) PushReturn(r0 []*types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) nextHook() func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+func (f *SearchContextsStoreListSearchContextsFunc) nextHook() func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31853,41 +36428,40 @@ func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) next
 	return hook
 }
 
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) {
+func (f *SearchContextsStoreListSearchContextsFunc) appendCall(r0 SearchContextsStoreListSearchContextsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall
-// objects describing the invocations of this function.
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) History() []SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall {
+// SearchContextsStoreListSearchContextsFuncCall objects describing the
+// invocations of this function.
+func (f *SearchContextsStoreListSearchContextsFunc) History() []SearchContextsStoreListSearchContextsFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall, len(f.history))
+	history := make([]SearchContextsStoreListSearchContextsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall is
-// an object that describes an invocation of method
-// CreateSearchContextWithRepositoryRevisions on an instance of
+// SearchContextsStoreListSearchContextsFuncCall is an object that describes
+// an invocation of method ListSearchContexts on an instance of
 // MockSearchContextsStore.
-type SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall struct {
+type SearchContextsStoreListSearchContextsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *types.SearchContext
+	Arg1 ListSearchContextsPageOptions
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 []*types.SearchContextRepositoryRevisions
+	Arg2 ListSearchContextsOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SearchContext
+	Result0 []*types.SearchContext
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -31895,47 +36469,48 @@ type SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall struc
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) Args() []interface{} {
+func (c SearchContextsStoreListSearchContextsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) Results() []interface{} {
+func (c SearchContextsStoreListSearchContextsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreDeleteSearchContextFunc describes the behavior when
-// the DeleteSearchContext method of the parent MockSearchContextsStore
-// instance is invoked.
-type SearchContextsStoreDeleteSearchContextFunc struct {
-	defaultHook func(context.Context, int64) error
-	hooks       []func(context.Context, int64) error
-	history     []SearchContextsStoreDeleteSearchContextFuncCall
+// SearchContextsStoreSetSearchContextRepositoryRevisionsFunc describes the
+// behavior when the SetSearchContextRepositoryRevisions method of the
+// parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreSetSearchContextRepositoryRevisionsFunc struct {
+	defaultHook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
+	hooks       []func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
+	history     []SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall
 	mutex       sync.Mutex
 }
 
-// DeleteSearchContext delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) DeleteSearchContext(v0 context.Context, v1 int64) error {
-	r0 := m.DeleteSearchContextFunc.nextHook()(v0, v1)
-	m.DeleteSearchContextFunc.appendCall(SearchContextsStoreDeleteSearchContextFuncCall{v0, v1, r0})
+// SetSearchContextRepositoryRevisions delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) SetSearchContextRepositoryRevisions(v0 context.Context, v1 int64, v2 []*types.SearchContextRepositoryRevisions) error {
+	r0 := m.SetSearchContextRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
+	m.SetSearchContextRepositoryRevisionsFunc.appendCall(SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the DeleteSearchContext
-// method of the parent MockSearchContextsStore instance is invoked and the
-// hook queue is empty.
-func (f *SearchContextsStoreDeleteSearchContextFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+// SetDefaultHook sets function that is called when the
+// SetSearchContextRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DeleteSearchContext method of the parent MockSearchContextsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *SearchContextsStoreDeleteSearchContextFunc) PushHook(hook func(context.Context, int64) error) {
+// SetSearchContextRepositoryRevisions method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) PushHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31943,20 +36518,20 @@ func (f *SearchContextsStoreDeleteSearchContextFunc) PushHook(hook func(context.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreDeleteSearchContextFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64) error {
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreDeleteSearchContextFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64) error {
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
 		return r0
 	})
 }
 
-func (f *SearchContextsStoreDeleteSearchContextFunc) nextHook() func(context.Context, int64) error {
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) nextHook() func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31969,34 +36544,38 @@ func (f *SearchContextsStoreDeleteSearchContextFunc) nextHook() func(context.Con
 	return hook
 }
 
-func (f *SearchContextsStoreDeleteSearchContextFunc) appendCall(r0 SearchContextsStoreDeleteSearchContextFuncCall) {
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// SearchContextsStoreDeleteSearchContextFuncCall objects describing the
-// invocations of this function.
-func (f *SearchContextsStoreDeleteSearchContextFunc) History() []SearchContextsStoreDeleteSearchContextFuncCall {
+// SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) History() []SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreDeleteSearchContextFuncCall, len(f.history))
+	history := make([]SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreDeleteSearchContextFuncCall is an object that
-// describes an invocation of method DeleteSearchContext on an instance of
+// SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall is an
+// object that describes an invocation of method
+// SetSearchContextRepositoryRevisions on an instance of
 // MockSearchContextsStore.
-type SearchContextsStoreDeleteSearchContextFuncCall struct {
+type SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*types.SearchContextRepositoryRevisions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -32004,45 +36583,45 @@ type SearchContextsStoreDeleteSearchContextFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreDeleteSearchContextFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreDeleteSearchContextFuncCall) Results() []interface{} {
+func (c SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// SearchContextsStoreDoneFunc describes the behavior when the Done method
-// of the parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []SearchContextsStoreDoneFuncCall
+// SearchContextsStoreTransactFunc describes the behavior when the Transact
+// method of the parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreTransactFunc struct {
+	defaultHook func(context.Context) (SearchContextsStore, error)
+	hooks       []func(context.Context) (SearchContextsStore, error)
+	history     []SearchContextsStoreTransactFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
+// Transact delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSearchContextsStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(SearchContextsStoreDoneFuncCall{v0, r0})
-	return r0
+func (m *MockSearchContextsStore) Transact(v0 context.Context) (SearchContextsStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(SearchContextsStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockSearchContextsStore instance is invoked and the hook queue is
-// empty.
-func (f *SearchContextsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockSearchContextsStore instance is invoked and the hook queue
+// is empty.
+func (f *SearchContextsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SearchContextsStore, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockSearchContextsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SearchContextsStoreDoneFunc) PushHook(hook func(error) error) {
+// Transact method of the parent MockSearchContextsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SearchContextsStoreTransactFunc) PushHook(hook func(context.Context) (SearchContextsStore, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32050,20 +36629,20 @@ func (f *SearchContextsStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
-		return r0
+func (f *SearchContextsStoreTransactFunc) SetDefaultReturn(r0 SearchContextsStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (SearchContextsStore, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
-		return r0
+func (f *SearchContextsStoreTransactFunc) PushReturn(r0 SearchContextsStore, r1 error) {
+	f.PushHook(func(context.Context) (SearchContextsStore, error) {
+		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreDoneFunc) nextHook() func(error) error {
+func (f *SearchContextsStoreTransactFunc) nextHook() func(context.Context) (SearchContextsStore, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32076,75 +36655,82 @@ func (f *SearchContextsStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *SearchContextsStoreDoneFunc) appendCall(r0 SearchContextsStoreDoneFuncCall) {
+func (f *SearchContextsStoreTransactFunc) appendCall(r0 SearchContextsStoreTransactFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreDoneFuncCall objects
+// History returns a sequence of SearchContextsStoreTransactFuncCall objects
 // describing the invocations of this function.
-func (f *SearchContextsStoreDoneFunc) History() []SearchContextsStoreDoneFuncCall {
+func (f *SearchContextsStoreTransactFunc) History() []SearchContextsStoreTransactFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreDoneFuncCall, len(f.history))
+	history := make([]SearchContextsStoreTransactFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreDoneFuncCall is an object that describes an invocation
-// of method Done on an instance of MockSearchContextsStore.
-type SearchContextsStoreDoneFuncCall struct {
+// SearchContextsStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of MockSearchContextsStore.
+type SearchContextsStoreTransactFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
+	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 SearchContextsStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreDoneFuncCall) Args() []interface{} {
+func (c SearchContextsStoreTransactFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreDoneFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SearchContextsStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreExecFunc describes the behavior when the Exec method
-// of the parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreExecFunc struct {
-	defaultHook func(context.Context, *sqlf.Query) error
-	hooks       []func(context.Context, *sqlf.Query) error
-	history     []SearchContextsStoreExecFuncCall
+// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc
+// describes the behavior when the
+// UpdateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc struct {
+	defaultHook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
+	hooks       []func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
+	history     []SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall
 	mutex       sync.Mutex
 }
 
-// Exec delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSearchContextsStore) Exec(v0 context.Context, v1 *sqlf.Query) error {
-	r0 := m.ExecFunc.nextHook()(v0, v1)
-	m.ExecFunc.appendCall(SearchContextsStoreExecFuncCall{v0, v1, r0})
-	return r0
+// UpdateSearchContextWithRepositoryRevisions delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) UpdateSearchContextWithRepositoryRevisions(v0 context.Context, v1 *types.SearchContext, v2 []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+	r0, r1 := m.UpdateSearchContextWithRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
+	m.UpdateSearchContextWithRepositoryRevisionsFunc.appendCall(SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Exec method of the
-// parent MockSearchContextsStore instance is invoked and the hook queue is
-// empty.
-func (f *SearchContextsStoreExecFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) error) {
+// SetDefaultHook sets function that is called when the
+// UpdateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Exec method of the parent MockSearchContextsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SearchContextsStoreExecFunc) PushHook(hook func(context.Context, *sqlf.Query) error) {
+// UpdateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) PushHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32152,20 +36738,20 @@ func (f *SearchContextsStoreExecFunc) PushHook(hook func(context.Context, *sqlf.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreExecFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, *sqlf.Query) error {
-		return r0
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreExecFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, *sqlf.Query) error {
-		return r0
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) PushReturn(r0 *types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreExecFunc) nextHook() func(context.Context, *sqlf.Query) error {
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) nextHook() func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32178,80 +36764,263 @@ func (f *SearchContextsStoreExecFunc) nextHook() func(context.Context, *sqlf.Que
 	return hook
 }
 
-func (f *SearchContextsStoreExecFunc) appendCall(r0 SearchContextsStoreExecFuncCall) {
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreExecFuncCall objects
-// describing the invocations of this function.
-func (f *SearchContextsStoreExecFunc) History() []SearchContextsStoreExecFuncCall {
+// History returns a sequence of
+// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall
+// objects describing the invocations of this function.
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) History() []SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreExecFuncCall, len(f.history))
+	history := make([]SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreExecFuncCall is an object that describes an invocation
-// of method Exec on an instance of MockSearchContextsStore.
-type SearchContextsStoreExecFuncCall struct {
+// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall is
+// an object that describes an invocation of method
+// UpdateSearchContextWithRepositoryRevisions on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *sqlf.Query
+	Arg1 *types.SearchContext
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*types.SearchContextRepositoryRevisions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *types.SearchContext
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreExecFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreExecFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreGetAllQueriesFunc describes the behavior when the
-// GetAllQueries method of the parent MockSearchContextsStore instance is
-// invoked.
-type SearchContextsStoreGetAllQueriesFunc struct {
-	defaultHook func(context.Context) ([]string, error)
-	hooks       []func(context.Context) ([]string, error)
-	history     []SearchContextsStoreGetAllQueriesFuncCall
+// MockSearchHistoryStore is a mock implementation of the SearchHistoryStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockSearchHistoryStore struct {
+	// CountFunc is an instance of a mock function object controlling the
+	// behavior of the method Count.
+	CountFunc *SearchHistoryStoreCountFunc
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *SearchHistoryStoreCreateFunc
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *SearchHistoryStoreDeleteFunc
+	// DeleteAllFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteAll.
+	DeleteAllFunc *SearchHistoryStoreDeleteAllFunc
+	// DeleteStaleFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteStale.
+	DeleteStaleFunc *SearchHistoryStoreDeleteStaleFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *SearchHistoryStoreHandleFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *SearchHistoryStoreListFunc
+	// ListUsersWithStaleEntriesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListUsersWithStaleEntries.
+	ListUsersWithStaleEntriesFunc *SearchHistoryStoreListUsersWithStaleEntriesFunc
+	// SetPinnedFunc is an instance of a mock function object controlling
+	// the behavior of the method SetPinned.
+	SetPinnedFunc *SearchHistoryStoreSetPinnedFunc
+}
+
+// NewMockSearchHistoryStore creates a new mock of the SearchHistoryStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockSearchHistoryStore() *MockSearchHistoryStore {
+	return &MockSearchHistoryStore{
+		CountFunc: &SearchHistoryStoreCountFunc{
+			defaultHook: func(context.Context, SearchHistoryListOpts) (r0 int64, r1 error) {
+				return
+			},
+		},
+		CreateFunc: &SearchHistoryStoreCreateFunc{
+			defaultHook: func(context.Context, *SearchHistoryEntry) (r0 *SearchHistoryEntry, r1 error) {
+				return
+			},
+		},
+		DeleteFunc: &SearchHistoryStoreDeleteFunc{
+			defaultHook: func(context.Context, int32, int64) (r0 error) {
+				return
+			},
+		},
+		DeleteAllFunc: &SearchHistoryStoreDeleteAllFunc{
+			defaultHook: func(context.Context, int32) (r0 error) {
+				return
+			},
+		},
+		DeleteStaleFunc: &SearchHistoryStoreDeleteStaleFunc{
+			defaultHook: func(context.Context, int32, time.Duration) (r0 error) {
+				return
+			},
+		},
+		HandleFunc: &SearchHistoryStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		ListFunc: &SearchHistoryStoreListFunc{
+			defaultHook: func(context.Context, SearchHistoryListOpts) (r0 []*SearchHistoryEntry, r1 int64, r2 error) {
+				return
+			},
+		},
+		ListUsersWithStaleEntriesFunc: &SearchHistoryStoreListUsersWithStaleEntriesFunc{
+			defaultHook: func(context.Context, time.Duration) (r0 []int32, r1 error) {
+				return
+			},
+		},
+		SetPinnedFunc: &SearchHistoryStoreSetPinnedFunc{
+			defaultHook: func(context.Context, int32, int64, bool) (r0 *SearchHistoryEntry, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockSearchHistoryStore creates a new mock of the
+// SearchHistoryStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockSearchHistoryStore() *MockSearchHistoryStore {
+	return &MockSearchHistoryStore{
+		CountFunc: &SearchHistoryStoreCountFunc{
+			defaultHook: func(context.Context, SearchHistoryListOpts) (int64, error) {
+				panic("unexpected invocation of MockSearchHistoryStore.Count")
+			},
+		},
+		CreateFunc: &SearchHistoryStoreCreateFunc{
+			defaultHook: func(context.Context, *SearchHistoryEntry) (*SearchHistoryEntry, error) {
+				panic("unexpected invocation of MockSearchHistoryStore.Create")
+			},
+		},
+		DeleteFunc: &SearchHistoryStoreDeleteFunc{
+			defaultHook: func(context.Context, int32, int64) error {
+				panic("unexpected invocation of MockSearchHistoryStore.Delete")
+			},
+		},
+		DeleteAllFunc: &SearchHistoryStoreDeleteAllFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockSearchHistoryStore.DeleteAll")
+			},
+		},
+		DeleteStaleFunc: &SearchHistoryStoreDeleteStaleFunc{
+			defaultHook: func(context.Context, int32, time.Duration) error {
+				panic("unexpected invocation of MockSearchHistoryStore.DeleteStale")
+			},
+		},
+		HandleFunc: &SearchHistoryStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockSearchHistoryStore.Handle")
+			},
+		},
+		ListFunc: &SearchHistoryStoreListFunc{
+			defaultHook: func(context.Context, SearchHistoryListOpts) ([]*SearchHistoryEntry, int64, error) {
+				panic("unexpected invocation of MockSearchHistoryStore.List")
+			},
+		},
+		ListUsersWithStaleEntriesFunc: &SearchHistoryStoreListUsersWithStaleEntriesFunc{
+			defaultHook: func(context.Context, time.Duration) ([]int32, error) {
+				panic("unexpected invocation of MockSearchHistoryStore.ListUsersWithStaleEntries")
+			},
+		},
+		SetPinnedFunc: &SearchHistoryStoreSetPinnedFunc{
+			defaultHook: func(context.Context, int32, int64, bool) (*SearchHistoryEntry, error) {
+				panic("unexpected invocation of MockSearchHistoryStore.SetPinned")
+			},
+		},
+	}
+}
+
+// NewMockSearchHistoryStoreFrom creates a new mock of the
+// MockSearchHistoryStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockSearchHistoryStoreFrom(i SearchHistoryStore) *MockSearchHistoryStore {
+	return &MockSearchHistoryStore{
+		CountFunc: &SearchHistoryStoreCountFunc{
+			defaultHook: i.Count,
+		},
+		CreateFunc: &SearchHistoryStoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		DeleteFunc: &SearchHistoryStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		DeleteAllFunc: &SearchHistoryStoreDeleteAllFunc{
+			defaultHook: i.DeleteAll,
+		},
+		DeleteStaleFunc: &SearchHistoryStoreDeleteStaleFunc{
+			defaultHook: i.DeleteStale,
+		},
+		HandleFunc: &SearchHistoryStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListFunc: &SearchHistoryStoreListFunc{
+			defaultHook: i.List,
+		},
+		ListUsersWithStaleEntriesFunc: &SearchHistoryStoreListUsersWithStaleEntriesFunc{
+			defaultHook: i.ListUsersWithStaleEntries,
+		},
+		SetPinnedFunc: &SearchHistoryStoreSetPinnedFunc{
+			defaultHook: i.SetPinned,
+		},
+	}
+}
+
+// SearchHistoryStoreCountFunc describes the behavior when the Count method
+// of the parent MockSearchHistoryStore instance is invoked.
+type SearchHistoryStoreCountFunc struct {
+	defaultHook func(context.Context, SearchHistoryListOpts) (int64, error)
+	hooks       []func(context.Context, SearchHistoryListOpts) (int64, error)
+	history     []SearchHistoryStoreCountFuncCall
 	mutex       sync.Mutex
 }
 
-// GetAllQueries delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) GetAllQueries(v0 context.Context) ([]string, error) {
-	r0, r1 := m.GetAllQueriesFunc.nextHook()(v0)
-	m.GetAllQueriesFunc.appendCall(SearchContextsStoreGetAllQueriesFuncCall{v0, r0, r1})
+// Count delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchHistoryStore) Count(v0 context.Context, v1 SearchHistoryListOpts) (int64, error) {
+	r0, r1 := m.CountFunc.nextHook()(v0, v1)
+	m.CountFunc.appendCall(SearchHistoryStoreCountFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetAllQueries method
-// of the parent MockSearchContextsStore instance is invoked and the hook
-// queue is empty.
-func (f *SearchContextsStoreGetAllQueriesFunc) SetDefaultHook(hook func(context.Context) ([]string, error)) {
+// SetDefaultHook sets function that is called when the Count method of the
+// parent MockSearchHistoryStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchHistoryStoreCountFunc) SetDefaultHook(hook func(context.Context, SearchHistoryListOpts) (int64, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetAllQueries method of the parent MockSearchContextsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *SearchContextsStoreGetAllQueriesFunc) PushHook(hook func(context.Context) ([]string, error)) {
+// Count method of the parent MockSearchHistoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchHistoryStoreCountFunc) PushHook(hook func(context.Context, SearchHistoryListOpts) (int64, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32259,20 +37028,20 @@ func (f *SearchContextsStoreGetAllQueriesFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreGetAllQueriesFunc) SetDefaultReturn(r0 []string, r1 error) {
-	f.SetDefaultHook(func(context.Context) ([]string, error) {
+func (f *SearchHistoryStoreCountFunc) SetDefaultReturn(r0 int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, SearchHistoryListOpts) (int64, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreGetAllQueriesFunc) PushReturn(r0 []string, r1 error) {
-	f.PushHook(func(context.Context) ([]string, error) {
+func (f *SearchHistoryStoreCountFunc) PushReturn(r0 int64, r1 error) {
+	f.PushHook(func(context.Context, SearchHistoryListOpts) (int64, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreGetAllQueriesFunc) nextHook() func(context.Context) ([]string, error) {
+func (f *SearchHistoryStoreCountFunc) nextHook() func(context.Context, SearchHistoryListOpts) (int64, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32285,33 +37054,35 @@ func (f *SearchContextsStoreGetAllQueriesFunc) nextHook() func(context.Context)
 	return hook
 }
 
-func (f *SearchContextsStoreGetAllQueriesFunc) appendCall(r0 SearchContextsStoreGetAllQueriesFuncCall) {
+func (f *SearchHistoryStoreCountFunc) appendCall(r0 SearchHistoryStoreCountFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreGetAllQueriesFuncCall
-// objects describing the invocations of this function.
-func (f *SearchContextsStoreGetAllQueriesFunc) History() []SearchContextsStoreGetAllQueriesFuncCall {
+// History returns a sequence of SearchHistoryStoreCountFuncCall objects
+// describing the invocations of this function.
+func (f *SearchHistoryStoreCountFunc) History() []SearchHistoryStoreCountFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreGetAllQueriesFuncCall, len(f.history))
+	history := make([]SearchHistoryStoreCountFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreGetAllQueriesFuncCall is an object that describes an
-// invocation of method GetAllQueries on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreGetAllQueriesFuncCall struct {
+// SearchHistoryStoreCountFuncCall is an object that describes an invocation
+// of method Count on an instance of MockSearchHistoryStore.
+type SearchHistoryStoreCountFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 SearchHistoryListOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []string
+	Result0 int64
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -32319,47 +37090,45 @@ type SearchContextsStoreGetAllQueriesFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetAllQueriesFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SearchHistoryStoreCountFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetAllQueriesFuncCall) Results() []interface{} {
+func (c SearchHistoryStoreCountFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreGetAllRevisionsForReposFunc describes the behavior
-// when the GetAllRevisionsForRepos method of the parent
-// MockSearchContextsStore instance is invoked.
-type SearchContextsStoreGetAllRevisionsForReposFunc struct {
-	defaultHook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
-	hooks       []func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
-	history     []SearchContextsStoreGetAllRevisionsForReposFuncCall
+// SearchHistoryStoreCreateFunc describes the behavior when the Create
+// method of the parent MockSearchHistoryStore instance is invoked.
+type SearchHistoryStoreCreateFunc struct {
+	defaultHook func(context.Context, *SearchHistoryEntry) (*SearchHistoryEntry, error)
+	hooks       []func(context.Context, *SearchHistoryEntry) (*SearchHistoryEntry, error)
+	history     []SearchHistoryStoreCreateFuncCall
 	mutex       sync.Mutex
 }
 
-// GetAllRevisionsForRepos delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) GetAllRevisionsForRepos(v0 context.Context, v1 []api.RepoID) (map[api.RepoID][]string, error) {
-	r0, r1 := m.GetAllRevisionsForReposFunc.nextHook()(v0, v1)
-	m.GetAllRevisionsForReposFunc.appendCall(SearchContextsStoreGetAllRevisionsForReposFuncCall{v0, v1, r0, r1})
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchHistoryStore) Create(v0 context.Context, v1 *SearchHistoryEntry) (*SearchHistoryEntry, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1)
+	m.CreateFunc.appendCall(SearchHistoryStoreCreateFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
-// instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockSearchHistoryStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchHistoryStoreCreateFunc) SetDefaultHook(hook func(context.Context, *SearchHistoryEntry) (*SearchHistoryEntry, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
+// Create method of the parent MockSearchHistoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchHistoryStoreCreateFunc) PushHook(hook func(context.Context, *SearchHistoryEntry) (*SearchHistoryEntry, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32367,20 +37136,20 @@ func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushHook(hook func(cont
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
-	f.SetDefaultHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+func (f *SearchHistoryStoreCreateFunc) SetDefaultReturn(r0 *SearchHistoryEntry, r1 error) {
+	f.SetDefaultHook(func(context.Context, *SearchHistoryEntry) (*SearchHistoryEntry, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
-	f.PushHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+func (f *SearchHistoryStoreCreateFunc) PushReturn(r0 *SearchHistoryEntry, r1 error) {
+	f.PushHook(func(context.Context, *SearchHistoryEntry) (*SearchHistoryEntry, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) nextHook() func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+func (f *SearchHistoryStoreCreateFunc) nextHook() func(context.Context, *SearchHistoryEntry) (*SearchHistoryEntry, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32393,37 +37162,35 @@ func (f *SearchContextsStoreGetAllRevisionsForReposFunc) nextHook() func(context
 	return hook
 }
 
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) appendCall(r0 SearchContextsStoreGetAllRevisionsForReposFuncCall) {
+func (f *SearchHistoryStoreCreateFunc) appendCall(r0 SearchHistoryStoreCreateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreGetAllRevisionsForReposFuncCall objects describing the
-// invocations of this function.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) History() []SearchContextsStoreGetAllRevisionsForReposFuncCall {
+// History returns a sequence of SearchHistoryStoreCreateFuncCall objects
+// describing the invocations of this function.
+func (f *SearchHistoryStoreCreateFunc) History() []SearchHistoryStoreCreateFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreGetAllRevisionsForReposFuncCall, len(f.history))
+	history := make([]SearchHistoryStoreCreateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreGetAllRevisionsForReposFuncCall is an object that
-// describes an invocation of method GetAllRevisionsForRepos on an instance
-// of MockSearchContextsStore.
-type SearchContextsStoreGetAllRevisionsForReposFuncCall struct {
+// SearchHistoryStoreCreateFuncCall is an object that describes an
+// invocation of method Create on an instance of MockSearchHistoryStore.
+type SearchHistoryStoreCreateFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 []api.RepoID
+	Arg1 *SearchHistoryEntry
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 map[api.RepoID][]string
+	Result0 *SearchHistoryEntry
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -32431,47 +37198,45 @@ type SearchContextsStoreGetAllRevisionsForReposFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Args() []interface{} {
+func (c SearchHistoryStoreCreateFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Results() []interface{} {
+func (c SearchHistoryStoreCreateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
-}
-
-// SearchContextsStoreGetSearchContextFunc describes the behavior when the
-// GetSearchContext method of the parent MockSearchContextsStore instance is
-// invoked.
-type SearchContextsStoreGetSearchContextFunc struct {
-	defaultHook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)
-	hooks       []func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)
-	history     []SearchContextsStoreGetSearchContextFuncCall
+}
+
+// SearchHistoryStoreDeleteFunc describes the behavior when the Delete
+// method of the parent MockSearchHistoryStore instance is invoked.
+type SearchHistoryStoreDeleteFunc struct {
+	defaultHook func(context.Context, int32, int64) error
+	hooks       []func(context.Context, int32, int64) error
+	history     []SearchHistoryStoreDeleteFuncCall
 	mutex       sync.Mutex
 }
 
-// GetSearchContext delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) GetSearchContext(v0 context.Context, v1 GetSearchContextOptions) (*types.SearchContext, error) {
-	r0, r1 := m.GetSearchContextFunc.nextHook()(v0, v1)
-	m.GetSearchContextFunc.appendCall(SearchContextsStoreGetSearchContextFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchHistoryStore) Delete(v0 context.Context, v1 int32, v2 int64) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1, v2)
+	m.DeleteFunc.appendCall(SearchHistoryStoreDeleteFuncCall{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the GetSearchContext
-// method of the parent MockSearchContextsStore instance is invoked and the
-// hook queue is empty.
-func (f *SearchContextsStoreGetSearchContextFunc) SetDefaultHook(hook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)) {
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockSearchHistoryStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchHistoryStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32, int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetSearchContext method of the parent MockSearchContextsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *SearchContextsStoreGetSearchContextFunc) PushHook(hook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)) {
+// Delete method of the parent MockSearchHistoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchHistoryStoreDeleteFunc) PushHook(hook func(context.Context, int32, int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32479,20 +37244,20 @@ func (f *SearchContextsStoreGetSearchContextFunc) PushHook(hook func(context.Con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreGetSearchContextFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
-	f.SetDefaultHook(func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
-		return r0, r1
+func (f *SearchHistoryStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, int64) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreGetSearchContextFunc) PushReturn(r0 *types.SearchContext, r1 error) {
-	f.PushHook(func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
-		return r0, r1
+func (f *SearchHistoryStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, int64) error {
+		return r0
 	})
 }
 
-func (f *SearchContextsStoreGetSearchContextFunc) nextHook() func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
+func (f *SearchHistoryStoreDeleteFunc) nextHook() func(context.Context, int32, int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32505,85 +37270,81 @@ func (f *SearchContextsStoreGetSearchContextFunc) nextHook() func(context.Contex
 	return hook
 }
 
-func (f *SearchContextsStoreGetSearchContextFunc) appendCall(r0 SearchContextsStoreGetSearchContextFuncCall) {
+func (f *SearchHistoryStoreDeleteFunc) appendCall(r0 SearchHistoryStoreDeleteFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreGetSearchContextFuncCall
-// objects describing the invocations of this function.
-func (f *SearchContextsStoreGetSearchContextFunc) History() []SearchContextsStoreGetSearchContextFuncCall {
+// History returns a sequence of SearchHistoryStoreDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *SearchHistoryStoreDeleteFunc) History() []SearchHistoryStoreDeleteFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreGetSearchContextFuncCall, len(f.history))
+	history := make([]SearchHistoryStoreDeleteFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreGetSearchContextFuncCall is an object that describes
-// an invocation of method GetSearchContext on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreGetSearchContextFuncCall struct {
+// SearchHistoryStoreDeleteFuncCall is an object that describes an
+// invocation of method Delete on an instance of MockSearchHistoryStore.
+type SearchHistoryStoreDeleteFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 GetSearchContextOptions
-	// Result0 is the value of the 1st result returned from this method
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result0 *types.SearchContext
-	// Result1 is the value of the 2nd result returned from this method
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetSearchContextFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SearchHistoryStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetSearchContextFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SearchHistoryStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SearchContextsStoreGetSearchContextRepositoryRevisionsFunc describes the
-// behavior when the GetSearchContextRepositoryRevisions method of the
-// parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreGetSearchContextRepositoryRevisionsFunc struct {
-	defaultHook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
-	hooks       []func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
-	history     []SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall
+// SearchHistoryStoreDeleteAllFunc describes the behavior when the DeleteAll
+// method of the parent MockSearchHistoryStore instance is invoked.
+type SearchHistoryStoreDeleteAllFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []SearchHistoryStoreDeleteAllFuncCall
 	mutex       sync.Mutex
 }
 
-// GetSearchContextRepositoryRevisions delegates to the next hook function
-// in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockSearchContextsStore) GetSearchContextRepositoryRevisions(v0 context.Context, v1 int64) ([]*types.SearchContextRepositoryRevisions, error) {
-	r0, r1 := m.GetSearchContextRepositoryRevisionsFunc.nextHook()(v0, v1)
-	m.GetSearchContextRepositoryRevisionsFunc.appendCall(SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// DeleteAll delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchHistoryStore) DeleteAll(v0 context.Context, v1 int32) error {
+	r0 := m.DeleteAllFunc.nextHook()(v0, v1)
+	m.DeleteAllFunc.appendCall(SearchHistoryStoreDeleteAllFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// GetSearchContextRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)) {
+// SetDefaultHook sets function that is called when the DeleteAll method of
+// the parent MockSearchHistoryStore instance is invoked and the hook queue
+// is empty.
+func (f *SearchHistoryStoreDeleteAllFunc) SetDefaultHook(hook func(context.Context, int32) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetSearchContextRepositoryRevisions method of the parent
-// MockSearchContextsStore instance invokes the hook at the front of the
-// queue and discards it. After the queue is empty, the default hook
-// function is invoked for any future action.
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) PushHook(hook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)) {
+// DeleteAll method of the parent MockSearchHistoryStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SearchHistoryStoreDeleteAllFunc) PushHook(hook func(context.Context, int32) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32591,20 +37352,20 @@ func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) PushHook(ho
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) SetDefaultReturn(r0 []*types.SearchContextRepositoryRevisions, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
-		return r0, r1
+func (f *SearchHistoryStoreDeleteAllFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) PushReturn(r0 []*types.SearchContextRepositoryRevisions, r1 error) {
-	f.PushHook(func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
-		return r0, r1
+func (f *SearchHistoryStoreDeleteAllFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) nextHook() func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
+func (f *SearchHistoryStoreDeleteAllFunc) nextHook() func(context.Context, int32) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32617,84 +37378,79 @@ func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) nextHook()
 	return hook
 }
 
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) {
+func (f *SearchHistoryStoreDeleteAllFunc) appendCall(r0 SearchHistoryStoreDeleteAllFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall objects
+// History returns a sequence of SearchHistoryStoreDeleteAllFuncCall objects
 // describing the invocations of this function.
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) History() []SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall {
+func (f *SearchHistoryStoreDeleteAllFunc) History() []SearchHistoryStoreDeleteAllFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall, len(f.history))
+	history := make([]SearchHistoryStoreDeleteAllFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall is an
-// object that describes an invocation of method
-// GetSearchContextRepositoryRevisions on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall struct {
+// SearchHistoryStoreDeleteAllFuncCall is an object that describes an
+// invocation of method DeleteAll on an instance of MockSearchHistoryStore.
+type SearchHistoryStoreDeleteAllFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int64
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.SearchContextRepositoryRevisions
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) Args() []interface{} {
+func (c SearchHistoryStoreDeleteAllFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SearchHistoryStoreDeleteAllFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SearchContextsStoreHandleFunc describes the behavior when the Handle
-// method of the parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreHandleFunc struct {
-	defaultHook func() basestore.TransactableHandle
-	hooks       []func() basestore.TransactableHandle
-	history     []SearchContextsStoreHandleFuncCall
+// SearchHistoryStoreDeleteStaleFunc describes the behavior when the
+// DeleteStale method of the parent MockSearchHistoryStore instance is
+// invoked.
+type SearchHistoryStoreDeleteStaleFunc struct {
+	defaultHook func(context.Context, int32, time.Duration) error
+	hooks       []func(context.Context, int32, time.Duration) error
+	history     []SearchHistoryStoreDeleteStaleFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSearchContextsStore) Handle() basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(SearchContextsStoreHandleFuncCall{r0})
+// DeleteStale delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSearchHistoryStore) DeleteStale(v0 context.Context, v1 int32, v2 time.Duration) error {
+	r0 := m.DeleteStaleFunc.nextHook()(v0, v1, v2)
+	m.DeleteStaleFunc.appendCall(SearchHistoryStoreDeleteStaleFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockSearchContextsStore instance is invoked and the hook queue is
-// empty.
-func (f *SearchContextsStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+// SetDefaultHook sets function that is called when the DeleteStale method
+// of the parent MockSearchHistoryStore instance is invoked and the hook
+// queue is empty.
+func (f *SearchHistoryStoreDeleteStaleFunc) SetDefaultHook(hook func(context.Context, int32, time.Duration) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockSearchContextsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SearchContextsStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+// DeleteStale method of the parent MockSearchHistoryStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SearchHistoryStoreDeleteStaleFunc) PushHook(hook func(context.Context, int32, time.Duration) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32702,20 +37458,20 @@ func (f *SearchContextsStoreHandleFunc) PushHook(hook func() basestore.Transacta
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
-	f.SetDefaultHook(func() basestore.TransactableHandle {
+func (f *SearchHistoryStoreDeleteStaleFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, time.Duration) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
-	f.PushHook(func() basestore.TransactableHandle {
+func (f *SearchHistoryStoreDeleteStaleFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, time.Duration) error {
 		return r0
 	})
 }
 
-func (f *SearchContextsStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+func (f *SearchHistoryStoreDeleteStaleFunc) nextHook() func(context.Context, int32, time.Duration) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32728,74 +37484,82 @@ func (f *SearchContextsStoreHandleFunc) nextHook() func() basestore.Transactable
 	return hook
 }
 
-func (f *SearchContextsStoreHandleFunc) appendCall(r0 SearchContextsStoreHandleFuncCall) {
+func (f *SearchHistoryStoreDeleteStaleFunc) appendCall(r0 SearchHistoryStoreDeleteStaleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreHandleFuncCall objects
-// describing the invocations of this function.
-func (f *SearchContextsStoreHandleFunc) History() []SearchContextsStoreHandleFuncCall {
+// History returns a sequence of SearchHistoryStoreDeleteStaleFuncCall
+// objects describing the invocations of this function.
+func (f *SearchHistoryStoreDeleteStaleFunc) History() []SearchHistoryStoreDeleteStaleFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreHandleFuncCall, len(f.history))
+	history := make([]SearchHistoryStoreDeleteStaleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreHandleFuncCall is an object that describes an
-// invocation of method Handle on an instance of MockSearchContextsStore.
-type SearchContextsStoreHandleFuncCall struct {
+// SearchHistoryStoreDeleteStaleFuncCall is an object that describes an
+// invocation of method DeleteStale on an instance of
+// MockSearchHistoryStore.
+type SearchHistoryStoreDeleteStaleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Duration
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 basestore.TransactableHandle
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c SearchHistoryStoreDeleteStaleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreHandleFuncCall) Results() []interface{} {
+func (c SearchHistoryStoreDeleteStaleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// SearchContextsStoreListSearchContextsFunc describes the behavior when the
-// ListSearchContexts method of the parent MockSearchContextsStore instance
-// is invoked.
-type SearchContextsStoreListSearchContextsFunc struct {
-	defaultHook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)
-	hooks       []func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)
-	history     []SearchContextsStoreListSearchContextsFuncCall
+// SearchHistoryStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockSearchHistoryStore instance is invoked.
+type SearchHistoryStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []SearchHistoryStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// ListSearchContexts delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) ListSearchContexts(v0 context.Context, v1 ListSearchContextsPageOptions, v2 ListSearchContextsOptions) ([]*types.SearchContext, error) {
-	r0, r1 := m.ListSearchContextsFunc.nextHook()(v0, v1, v2)
-	m.ListSearchContextsFunc.appendCall(SearchContextsStoreListSearchContextsFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchHistoryStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(SearchHistoryStoreHandleFuncCall{r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the ListSearchContexts
-// method of the parent MockSearchContextsStore instance is invoked and the
-// hook queue is empty.
-func (f *SearchContextsStoreListSearchContextsFunc) SetDefaultHook(hook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)) {
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockSearchHistoryStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchHistoryStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListSearchContexts method of the parent MockSearchContextsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *SearchContextsStoreListSearchContextsFunc) PushHook(hook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)) {
+// Handle method of the parent MockSearchHistoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchHistoryStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32803,20 +37567,20 @@ func (f *SearchContextsStoreListSearchContextsFunc) PushHook(hook func(context.C
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreListSearchContextsFunc) SetDefaultReturn(r0 []*types.SearchContext, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
-		return r0, r1
+func (f *SearchHistoryStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreListSearchContextsFunc) PushReturn(r0 []*types.SearchContext, r1 error) {
-	f.PushHook(func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
-		return r0, r1
+func (f *SearchHistoryStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
 	})
 }
 
-func (f *SearchContextsStoreListSearchContextsFunc) nextHook() func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
+func (f *SearchHistoryStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32829,89 +37593,72 @@ func (f *SearchContextsStoreListSearchContextsFunc) nextHook() func(context.Cont
 	return hook
 }
 
-func (f *SearchContextsStoreListSearchContextsFunc) appendCall(r0 SearchContextsStoreListSearchContextsFuncCall) {
+func (f *SearchHistoryStoreHandleFunc) appendCall(r0 SearchHistoryStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreListSearchContextsFuncCall objects describing the
-// invocations of this function.
-func (f *SearchContextsStoreListSearchContextsFunc) History() []SearchContextsStoreListSearchContextsFuncCall {
+// History returns a sequence of SearchHistoryStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *SearchHistoryStoreHandleFunc) History() []SearchHistoryStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreListSearchContextsFuncCall, len(f.history))
+	history := make([]SearchHistoryStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreListSearchContextsFuncCall is an object that describes
-// an invocation of method ListSearchContexts on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreListSearchContextsFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 ListSearchContextsPageOptions
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 ListSearchContextsOptions
+// SearchHistoryStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockSearchHistoryStore.
+type SearchHistoryStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.SearchContext
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 basestore.TransactableHandle
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreListSearchContextsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c SearchHistoryStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreListSearchContextsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SearchHistoryStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SearchContextsStoreSetSearchContextRepositoryRevisionsFunc describes the
-// behavior when the SetSearchContextRepositoryRevisions method of the
-// parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreSetSearchContextRepositoryRevisionsFunc struct {
-	defaultHook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
-	hooks       []func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
-	history     []SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall
+// SearchHistoryStoreListFunc describes the behavior when the List method of
+// the parent MockSearchHistoryStore instance is invoked.
+type SearchHistoryStoreListFunc struct {
+	defaultHook func(context.Context, SearchHistoryListOpts) ([]*SearchHistoryEntry, int64, error)
+	hooks       []func(context.Context, SearchHistoryListOpts) ([]*SearchHistoryEntry, int64, error)
+	history     []SearchHistoryStoreListFuncCall
 	mutex       sync.Mutex
 }
 
-// SetSearchContextRepositoryRevisions delegates to the next hook function
-// in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockSearchContextsStore) SetSearchContextRepositoryRevisions(v0 context.Context, v1 int64, v2 []*types.SearchContextRepositoryRevisions) error {
-	r0 := m.SetSearchContextRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
-	m.SetSearchContextRepositoryRevisionsFunc.appendCall(SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall{v0, v1, v2, r0})
-	return r0
+// List delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchHistoryStore) List(v0 context.Context, v1 SearchHistoryListOpts) ([]*SearchHistoryEntry, int64, error) {
+	r0, r1, r2 := m.ListFunc.nextHook()(v0, v1)
+	m.ListFunc.appendCall(SearchHistoryStoreListFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the
-// SetSearchContextRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error) {
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockSearchHistoryStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchHistoryStoreListFunc) SetDefaultHook(hook func(context.Context, SearchHistoryListOpts) ([]*SearchHistoryEntry, int64, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// SetSearchContextRepositoryRevisions method of the parent
-// MockSearchContextsStore instance invokes the hook at the front of the
-// queue and discards it. After the queue is empty, the default hook
-// function is invoked for any future action.
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) PushHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error) {
+// List method of the parent MockSearchHistoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchHistoryStoreListFunc) PushHook(hook func(context.Context, SearchHistoryListOpts) ([]*SearchHistoryEntry, int64, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32919,20 +37666,20 @@ func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) PushHook(ho
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
-		return r0
+func (f *SearchHistoryStoreListFunc) SetDefaultReturn(r0 []*SearchHistoryEntry, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, SearchHistoryListOpts) ([]*SearchHistoryEntry, int64, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
-		return r0
+func (f *SearchHistoryStoreListFunc) PushReturn(r0 []*SearchHistoryEntry, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, SearchHistoryListOpts) ([]*SearchHistoryEntry, int64, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) nextHook() func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
+func (f *SearchHistoryStoreListFunc) nextHook() func(context.Context, SearchHistoryListOpts) ([]*SearchHistoryEntry, int64, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32945,84 +37692,86 @@ func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) nextHook()
 	return hook
 }
 
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) {
+func (f *SearchHistoryStoreListFunc) appendCall(r0 SearchHistoryStoreListFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall objects
+// History returns a sequence of SearchHistoryStoreListFuncCall objects
 // describing the invocations of this function.
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) History() []SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall {
+func (f *SearchHistoryStoreListFunc) History() []SearchHistoryStoreListFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall, len(f.history))
+	history := make([]SearchHistoryStoreListFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall is an
-// object that describes an invocation of method
-// SetSearchContextRepositoryRevisions on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall struct {
+// SearchHistoryStoreListFuncCall is an object that describes an invocation
+// of method List on an instance of MockSearchHistoryStore.
+type SearchHistoryStoreListFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int64
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 []*types.SearchContextRepositoryRevisions
+	Arg1 SearchHistoryListOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []*SearchHistoryEntry
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c SearchHistoryStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SearchHistoryStoreListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// SearchContextsStoreTransactFunc describes the behavior when the Transact
-// method of the parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreTransactFunc struct {
-	defaultHook func(context.Context) (SearchContextsStore, error)
-	hooks       []func(context.Context) (SearchContextsStore, error)
-	history     []SearchContextsStoreTransactFuncCall
+// SearchHistoryStoreListUsersWithStaleEntriesFunc describes the behavior
+// when the ListUsersWithStaleEntries method of the parent
+// MockSearchHistoryStore instance is invoked.
+type SearchHistoryStoreListUsersWithStaleEntriesFunc struct {
+	defaultHook func(context.Context, time.Duration) ([]int32, error)
+	hooks       []func(context.Context, time.Duration) ([]int32, error)
+	history     []SearchHistoryStoreListUsersWithStaleEntriesFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSearchContextsStore) Transact(v0 context.Context) (SearchContextsStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(SearchContextsStoreTransactFuncCall{v0, r0, r1})
+// ListUsersWithStaleEntries delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSearchHistoryStore) ListUsersWithStaleEntries(v0 context.Context, v1 time.Duration) ([]int32, error) {
+	r0, r1 := m.ListUsersWithStaleEntriesFunc.nextHook()(v0, v1)
+	m.ListUsersWithStaleEntriesFunc.appendCall(SearchHistoryStoreListUsersWithStaleEntriesFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockSearchContextsStore instance is invoked and the hook queue
-// is empty.
-func (f *SearchContextsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SearchContextsStore, error)) {
+// SetDefaultHook sets function that is called when the
+// ListUsersWithStaleEntries method of the parent MockSearchHistoryStore
+// instance is invoked and the hook queue is empty.
+func (f *SearchHistoryStoreListUsersWithStaleEntriesFunc) SetDefaultHook(hook func(context.Context, time.Duration) ([]int32, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockSearchContextsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *SearchContextsStoreTransactFunc) PushHook(hook func(context.Context) (SearchContextsStore, error)) {
+// ListUsersWithStaleEntries method of the parent MockSearchHistoryStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SearchHistoryStoreListUsersWithStaleEntriesFunc) PushHook(hook func(context.Context, time.Duration) ([]int32, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -33030,20 +37779,20 @@ func (f *SearchContextsStoreTransactFunc) PushHook(hook func(context.Context) (S
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreTransactFunc) SetDefaultReturn(r0 SearchContextsStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (SearchContextsStore, error) {
+func (f *SearchHistoryStoreListUsersWithStaleEntriesFunc) SetDefaultReturn(r0 []int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration) ([]int32, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreTransactFunc) PushReturn(r0 SearchContextsStore, r1 error) {
-	f.PushHook(func(context.Context) (SearchContextsStore, error) {
+func (f *SearchHistoryStoreListUsersWithStaleEntriesFunc) PushReturn(r0 []int32, r1 error) {
+	f.PushHook(func(context.Context, time.Duration) ([]int32, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreTransactFunc) nextHook() func(context.Context) (SearchContextsStore, error) {
+func (f *SearchHistoryStoreListUsersWithStaleEntriesFunc) nextHook() func(context.Context, time.Duration) ([]int32, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -33056,32 +37805,37 @@ func (f *SearchContextsStoreTransactFunc) nextHook() func(context.Context) (Sear
 	return hook
 }
 
-func (f *SearchContextsStoreTransactFunc) appendCall(r0 SearchContextsStoreTransactFuncCall) {
+func (f *SearchHistoryStoreListUsersWithStaleEntriesFunc) appendCall(r0 SearchHistoryStoreListUsersWithStaleEntriesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreTransactFuncCall objects
-// describing the invocations of this function.
-func (f *SearchContextsStoreTransactFunc) History() []SearchContextsStoreTransactFuncCall {
+// History returns a sequence of
+// SearchHistoryStoreListUsersWithStaleEntriesFuncCall objects describing
+// the invocations of this function.
+func (f *SearchHistoryStoreListUsersWithStaleEntriesFunc) History() []SearchHistoryStoreListUsersWithStaleEntriesFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreTransactFuncCall, len(f.history))
+	history := make([]SearchHistoryStoreListUsersWithStaleEntriesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreTransactFuncCall is an object that describes an
-// invocation of method Transact on an instance of MockSearchContextsStore.
-type SearchContextsStoreTransactFuncCall struct {
+// SearchHistoryStoreListUsersWithStaleEntriesFuncCall is an object that
+// describes an invocation of method ListUsersWithStaleEntries on an
+// instance of MockSearchHistoryStore.
+type SearchHistoryStoreListUsersWithStaleEntriesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Duration
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 SearchContextsStore
+	Result0 []int32
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -33089,49 +37843,45 @@ type SearchContextsStoreTransactFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SearchHistoryStoreListUsersWithStaleEntriesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreTransactFuncCall) Results() []interface{} {
+func (c SearchHistoryStoreListUsersWithStaleEntriesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc
-// describes the behavior when the
-// UpdateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked.
-type SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc struct {
-	defaultHook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
-	hooks       []func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
-	history     []SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall
+// SearchHistoryStoreSetPinnedFunc describes the behavior when the SetPinned
+// method of the parent MockSearchHistoryStore instance is invoked.
+type SearchHistoryStoreSetPinnedFunc struct {
+	defaultHook func(context.Context, int32, int64, bool) (*SearchHistoryEntry, error)
+	hooks       []func(context.Context, int32, int64, bool) (*SearchHistoryEntry, error)
+	history     []SearchHistoryStoreSetPinnedFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateSearchContextWithRepositoryRevisions delegates to the next hook
-// function in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockSearchContextsStore) UpdateSearchContextWithRepositoryRevisions(v0 context.Context, v1 *types.SearchContext, v2 []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-	r0, r1 := m.UpdateSearchContextWithRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
-	m.UpdateSearchContextWithRepositoryRevisionsFunc.appendCall(SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall{v0, v1, v2, r0, r1})
+// SetPinned delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchHistoryStore) SetPinned(v0 context.Context, v1 int32, v2 int64, v3 bool) (*SearchHistoryEntry, error) {
+	r0, r1 := m.SetPinnedFunc.nextHook()(v0, v1, v2, v3)
+	m.SetPinnedFunc.appendCall(SearchHistoryStoreSetPinnedFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// UpdateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+// SetDefaultHook sets function that is called when the SetPinned method of
+// the parent MockSearchHistoryStore instance is invoked and the hook queue
+// is empty.
+func (f *SearchHistoryStoreSetPinnedFunc) SetDefaultHook(hook func(context.Context, int32, int64, bool) (*SearchHistoryEntry, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance invokes the hook at the front of the
-// queue and discards it. After the queue is empty, the default hook
-// function is invoked for any future action.
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) PushHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+// SetPinned method of the parent MockSearchHistoryStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SearchHistoryStoreSetPinnedFunc) PushHook(hook func(context.Context, int32, int64, bool) (*SearchHistoryEntry, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -33139,20 +37889,20 @@ func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) Push
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
-	f.SetDefaultHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+func (f *SearchHistoryStoreSetPinnedFunc) SetDefaultReturn(r0 *SearchHistoryEntry, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, int64, bool) (*SearchHistoryEntry, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) PushReturn(r0 *types.SearchContext, r1 error) {
-	f.PushHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+func (f *SearchHistoryStoreSetPinnedFunc) PushReturn(r0 *SearchHistoryEntry, r1 error) {
+	f.PushHook(func(context.Context, int32, int64, bool) (*SearchHistoryEntry, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) nextHook() func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+func (f *SearchHistoryStoreSetPinnedFunc) nextHook() func(context.Context, int32, int64, bool) (*SearchHistoryEntry, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -33165,41 +37915,41 @@ func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) next
 	return hook
 }
 
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) {
+func (f *SearchHistoryStoreSetPinnedFunc) appendCall(r0 SearchHistoryStoreSetPinnedFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall
-// objects describing the invocations of this function.
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) History() []SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall {
+// History returns a sequence of SearchHistoryStoreSetPinnedFuncCall objects
+// describing the invocations of this function.
+func (f *SearchHistoryStoreSetPinnedFunc) History() []SearchHistoryStoreSetPinnedFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall, len(f.history))
+	history := make([]SearchHistoryStoreSetPinnedFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall is
-// an object that describes an invocation of method
-// UpdateSearchContextWithRepositoryRevisions on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall struct {
+// SearchHistoryStoreSetPinnedFuncCall is an object that describes an
+// invocation of method SetPinned on an instance of MockSearchHistoryStore.
+type SearchHistoryStoreSetPinnedFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *types.SearchContext
+	Arg1 int32
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 []*types.SearchContextRepositoryRevisions
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SearchContext
+	Result0 *SearchHistoryEntry
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -33207,13 +37957,13 @@ type SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall struc
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c SearchHistoryStoreSetPinnedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) Results() []interface{} {
+func (c SearchHistoryStoreSetPinnedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -42880,6 +47630,9 @@ type MockUserStore struct {
 	// a mock function object controlling the behavior of the method
 	// RandomizePasswordAndClearPasswordResetRateLimit.
 	RandomizePasswordAndClearPasswordResetRateLimitFunc *UserStoreRandomizePasswordAndClearPasswordResetRateLimitFunc
+	// RecoverListFunc is an instance of a mock function object controlling
+	// the behavior of the method RecoverList.
+	RecoverListFunc *UserStoreRecoverListFunc
 	// RenewPasswordResetCodeFunc is an instance of a mock function object
 	// controlling the behavior of the method RenewPasswordResetCode.
 	RenewPasswordResetCodeFunc *UserStoreRenewPasswordResetCodeFunc
@@ -43047,6 +47800,11 @@ func NewMockUserStore() *MockUserStore {
 				return
 			},
 		},
+		RecoverListFunc: &UserStoreRecoverListFunc{
+			defaultHook: func(context.Context, []int32) (r0 error) {
+				return
+			},
+		},
 		RenewPasswordResetCodeFunc: &UserStoreRenewPasswordResetCodeFunc{
 			defaultHook: func(context.Context, int32) (r0 string, r1 error) {
 				return
@@ -43234,6 +47992,11 @@ func NewStrictMockUserStore() *MockUserStore {
 				panic("unexpected invocation of MockUserStore.RandomizePasswordAndClearPasswordResetRateLimit")
 			},
 		},
+		RecoverListFunc: &UserStoreRecoverListFunc{
+			defaultHook: func(context.Context, []int32) error {
+				panic("unexpected invocation of MockUserStore.RecoverList")
+			},
+		},
 		RenewPasswordResetCodeFunc: &UserStoreRenewPasswordResetCodeFunc{
 			defaultHook: func(context.Context, int32) (string, error) {
 				panic("unexpected invocation of MockUserStore.RenewPasswordResetCode")
@@ -43369,6 +48132,9 @@ func NewMockUserStoreFrom(i UserStore) *MockUserStore {
 		RandomizePasswordAndClearPasswordResetRateLimitFunc: &UserStoreRandomizePasswordAndClearPasswordResetRateLimitFunc{
 			defaultHook: i.RandomizePasswordAndClearPasswordResetRateLimit,
 		},
+		RecoverListFunc: &UserStoreRecoverListFunc{
+			defaultHook: i.RecoverList,
+		},
 		RenewPasswordResetCodeFunc: &UserStoreRenewPasswordResetCodeFunc{
 			defaultHook: i.RenewPasswordResetCode,
 		},
@@ -46195,6 +50961,111 @@ func (c UserStoreRandomizePasswordAndClearPasswordResetRateLimitFuncCall) Result
 	return []interface{}{c.Result0}
 }
 
+// UserStoreRecoverListFunc describes the behavior when the RecoverList
+// method of the parent MockUserStore instance is invoked.
+type UserStoreRecoverListFunc struct {
+	defaultHook func(context.Context, []int32) error
+	hooks       []func(context.Context, []int32) error
+	history     []UserStoreRecoverListFuncCall
+	mutex       sync.Mutex
+}
+
+// RecoverList delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserStore) RecoverList(v0 context.Context, v1 []int32) error {
+	r0 := m.RecoverListFunc.nextHook()(v0, v1)
+	m.RecoverListFunc.appendCall(UserStoreRecoverListFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RecoverList method
+// of the parent MockUserStore instance is invoked and the hook queue is
+// empty.
+func (f *UserStoreRecoverListFunc) SetDefaultHook(hook func(context.Context, []int32) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RecoverList method of the parent MockUserStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UserStoreRecoverListFunc) PushHook(hook func(context.Context, []int32) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserStoreRecoverListFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []int32) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserStoreRecoverListFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []int32) error {
+		return r0
+	})
+}
+
+func (f *UserStoreRecoverListFunc) nextHook() func(context.Context, []int32) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserStoreRecoverListFunc) appendCall(r0 UserStoreRecoverListFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserStoreRecoverListFuncCall objects
+// describing the invocations of this function.
+func (f *UserStoreRecoverListFunc) History() []UserStoreRecoverListFuncCall {
+	f.mutex.Lock()
+	history := make([]UserStoreRecoverListFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserStoreRecoverListFuncCall is an object that describes an invocation of
+// method RecoverList on an instance of MockUserStore.
+type UserStoreRecoverListFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserStoreRecoverListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserStoreRecoverListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // UserStoreRenewPasswordResetCodeFunc describes the behavior when the
 // RenewPasswordResetCode method of the parent MockUserStore instance is
 // invoked.
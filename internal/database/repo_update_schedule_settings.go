@@ -0,0 +1,199 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RepoUpdateScheduleSetting is the setting of a site admin for how often
+// repo-updater updates a repository.
+type RepoUpdateScheduleSetting struct {
+	RepoID api.RepoID
+	// Interval is the fixed interval at which the repository is updated. It is
+	// zero if the scheduler computes the interval.
+	Interval time.Duration
+	// HighPriority repositories are enqueued ahead of other repositories when
+	// they are due for an update.
+	HighPriority bool
+	UpdatedAt    time.Time
+}
+
+// RepoUpdateScheduleSettingsStore provides persistence for the settings that
+// pin repositories to a fixed update interval or priority.
+type RepoUpdateScheduleSettingsStore interface {
+	basestore.ShareableStore
+
+	Get(context.Context, api.RepoID) (*RepoUpdateScheduleSetting, error)
+	List(context.Context) ([]*RepoUpdateScheduleSetting, error)
+	Upsert(context.Context, *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error)
+	Delete(context.Context, api.RepoID) error
+}
+
+type repoUpdateScheduleSettingsStore struct {
+	*basestore.Store
+}
+
+var _ RepoUpdateScheduleSettingsStore = &repoUpdateScheduleSettingsStore{}
+
+// RepoUpdateScheduleSettingsWith instantiates and returns a new
+// RepoUpdateScheduleSettingsStore using the other store handle.
+func RepoUpdateScheduleSettingsWith(other basestore.ShareableStore) RepoUpdateScheduleSettingsStore {
+	return &repoUpdateScheduleSettingsStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+type repoUpdateScheduleSettingNotFoundError struct{ repoID api.RepoID }
+
+func (e repoUpdateScheduleSettingNotFoundError) Error() string {
+	return fmt.Sprintf("update schedule setting for repo %d not found", e.repoID)
+}
+
+func (repoUpdateScheduleSettingNotFoundError) NotFound() bool {
+	return true
+}
+
+func (s *repoUpdateScheduleSettingsStore) Get(ctx context.Context, repoID api.RepoID) (*RepoUpdateScheduleSetting, error) {
+	q := sqlf.Sprintf(
+		repoUpdateScheduleSettingsGetQueryFmtstr,
+		sqlf.Join(repoUpdateScheduleSettingsColumns, ", "),
+		repoID,
+	)
+
+	var setting RepoUpdateScheduleSetting
+	if err := scanRepoUpdateScheduleSetting(&setting, s.QueryRow(ctx, q)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repoUpdateScheduleSettingNotFoundError{repoID: repoID}
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (s *repoUpdateScheduleSettingsStore) List(ctx context.Context) (_ []*RepoUpdateScheduleSetting, err error) {
+	q := sqlf.Sprintf(
+		repoUpdateScheduleSettingsListQueryFmtstr,
+		sqlf.Join(repoUpdateScheduleSettingsColumns, ", "),
+	)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	settings := []*RepoUpdateScheduleSetting{}
+	for rows.Next() {
+		var setting RepoUpdateScheduleSetting
+		if err := scanRepoUpdateScheduleSetting(&setting, rows); err != nil {
+			return nil, err
+		}
+		settings = append(settings, &setting)
+	}
+	return settings, nil
+}
+
+func (s *repoUpdateScheduleSettingsStore) Upsert(ctx context.Context, setting *RepoUpdateScheduleSetting) (*RepoUpdateScheduleSetting, error) {
+	intervalSeconds := sql.NullInt32{
+		Int32: int32(setting.Interval / time.Second),
+		Valid: setting.Interval > 0,
+	}
+
+	q := sqlf.Sprintf(
+		repoUpdateScheduleSettingsUpsertQueryFmtstr,
+		setting.RepoID,
+		intervalSeconds,
+		setting.HighPriority,
+		timeutil.Now(),
+		sqlf.Join(repoUpdateScheduleSettingsColumns, ", "),
+	)
+
+	var upserted RepoUpdateScheduleSetting
+	if err := scanRepoUpdateScheduleSetting(&upserted, s.QueryRow(ctx, q)); err != nil {
+		return nil, err
+	}
+	return &upserted, nil
+}
+
+func (s *repoUpdateScheduleSettingsStore) Delete(ctx context.Context, repoID api.RepoID) error {
+	return s.Exec(ctx, sqlf.Sprintf(repoUpdateScheduleSettingsDeleteQueryFmtstr, repoID))
+}
+
+var repoUpdateScheduleSettingsColumns = []*sqlf.Query{
+	sqlf.Sprintf("repo_id"),
+	sqlf.Sprintf("interval_seconds"),
+	sqlf.Sprintf("high_priority"),
+	sqlf.Sprintf("updated_at"),
+}
+
+const repoUpdateScheduleSettingsGetQueryFmtstr = `
+-- source: internal/database/repo_update_schedule_settings.go:Get
+SELECT
+	%s
+FROM
+	repo_update_schedule_settings
+WHERE
+	repo_id = %s
+`
+
+const repoUpdateScheduleSettingsListQueryFmtstr = `
+-- source: internal/database/repo_update_schedule_settings.go:List
+SELECT
+	%s
+FROM
+	repo_update_schedule_settings
+ORDER BY
+	repo_id
+`
+
+const repoUpdateScheduleSettingsUpsertQueryFmtstr = `
+-- source: internal/database/repo_update_schedule_settings.go:Upsert
+INSERT INTO
+	repo_update_schedule_settings (
+		repo_id,
+		interval_seconds,
+		high_priority,
+		updated_at
+	)
+	VALUES (
+		%s,
+		%s,
+		%s,
+		%s
+	)
+	ON CONFLICT (repo_id) DO UPDATE SET
+		interval_seconds = EXCLUDED.interval_seconds,
+		high_priority = EXCLUDED.high_priority,
+		updated_at = EXCLUDED.updated_at
+	RETURNING %s
+`
+
+const repoUpdateScheduleSettingsDeleteQueryFmtstr = `
+-- source: internal/database/repo_update_schedule_settings.go:Delete
+DELETE FROM
+	repo_update_schedule_settings
+WHERE
+	repo_id = %s
+`
+
+func scanRepoUpdateScheduleSetting(setting *RepoUpdateScheduleSetting, sc dbutil.Scanner) error {
+	var intervalSeconds sql.NullInt32
+	if err := sc.Scan(
+		&setting.RepoID,
+		&intervalSeconds,
+		&setting.HighPriority,
+		&setting.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	setting.Interval = time.Duration(intervalSeconds.Int32) * time.Second
+	return nil
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepoUpdateScheduleSettingsStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+
+	foo := &types.Repo{Name: "github.com/foo/foo"}
+	bar := &types.Repo{Name: "github.com/foo/bar"}
+	require.NoError(t, db.Repos().Create(ctx, foo, bar))
+
+	store := db.RepoUpdateScheduleSettings()
+
+	_, err := store.Get(ctx, foo.ID)
+	assert.True(t, errcode.IsNotFound(err), "unexpected error: %v", err)
+
+	pinned, err := store.Upsert(ctx, &RepoUpdateScheduleSetting{RepoID: foo.ID, Interval: 10 * time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, pinned.Interval)
+	assert.False(t, pinned.HighPriority)
+
+	_, err = store.Upsert(ctx, &RepoUpdateScheduleSetting{RepoID: bar.ID, HighPriority: true})
+	require.NoError(t, err)
+
+	// Upserting replaces the previous setting.
+	pinned, err = store.Upsert(ctx, &RepoUpdateScheduleSetting{RepoID: foo.ID, HighPriority: true})
+	require.NoError(t, err)
+	assert.Zero(t, pinned.Interval)
+	assert.True(t, pinned.HighPriority)
+
+	got, err := store.Get(ctx, foo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pinned, got)
+
+	settings, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, settings, 2)
+	assert.Equal(t, foo.ID, settings[0].RepoID)
+	assert.Equal(t, bar.ID, settings[1].RepoID)
+
+	require.NoError(t, store.Delete(ctx, foo.ID))
+	_, err = store.Get(ctx, foo.ID)
+	assert.True(t, errcode.IsNotFound(err), "unexpected error: %v", err)
+}
@@ -0,0 +1,556 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SCIMUser maps a user resource provisioned by an identity provider over SCIM
+// to a Sourcegraph user.
+type SCIMUser struct {
+	ID         string
+	UserID     int32
+	ExternalID string
+	UserName   string
+	Active     bool
+	// Attributes is the SCIM user resource as last sent by the identity
+	// provider.
+	Attributes json.RawMessage
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// SCIMGroup maps a group resource provisioned by an identity provider over
+// SCIM to a Sourcegraph organization.
+type SCIMGroup struct {
+	ID          string
+	OrgID       int32
+	ExternalID  string
+	DisplayName string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// SCIMListOpts narrows down the SCIM resources returned by a list. Empty
+// fields match everything.
+type SCIMListOpts struct {
+	// UserName (or DisplayName, for groups) is compared case-insensitively.
+	UserName    string
+	DisplayName string
+	ExternalID  string
+}
+
+// SCIMStore provides persistence for the mapping between SCIM resources and
+// Sourcegraph users and organizations.
+type SCIMStore interface {
+	basestore.ShareableStore
+	Transact(context.Context) (SCIMStore, error)
+	Done(error) error
+
+	// CreateUser creates a mapping for a user, generating its SCIM ID.
+	CreateUser(context.Context, *SCIMUser) (*SCIMUser, error)
+	GetUser(ctx context.Context, id string) (*SCIMUser, error)
+	GetUserByUserID(ctx context.Context, userID int32) (*SCIMUser, error)
+	ListUsers(context.Context, SCIMListOpts) ([]*SCIMUser, error)
+	// UpdateUser updates the external ID, user name, active state and
+	// attributes of a user mapping.
+	UpdateUser(context.Context, *SCIMUser) (*SCIMUser, error)
+	DeleteUser(ctx context.Context, id string) error
+
+	// CreateGroup creates a mapping for a group, generating its SCIM ID.
+	CreateGroup(context.Context, *SCIMGroup) (*SCIMGroup, error)
+	GetGroup(ctx context.Context, id string) (*SCIMGroup, error)
+	ListGroups(context.Context, SCIMListOpts) ([]*SCIMGroup, error)
+	// UpdateGroup updates the external ID and display name of a group
+	// mapping.
+	UpdateGroup(context.Context, *SCIMGroup) (*SCIMGroup, error)
+	DeleteGroup(ctx context.Context, id string) error
+	// ListGroupMembers returns the SCIM users who are members of the given
+	// organization.
+	ListGroupMembers(ctx context.Context, orgID int32) ([]*SCIMUser, error)
+}
+
+type scimStore struct {
+	*basestore.Store
+}
+
+var _ SCIMStore = &scimStore{}
+
+// SCIMWith instantiates and returns a new SCIMStore using the other store
+// handle.
+func SCIMWith(other basestore.ShareableStore) SCIMStore {
+	return &scimStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *scimStore) Transact(ctx context.Context) (SCIMStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &scimStore{Store: txBase}, err
+}
+
+// SCIMResourceNotFoundError is returned when a SCIM user or group mapping
+// does not exist.
+type SCIMResourceNotFoundError struct {
+	resourceType string
+	id           any
+}
+
+func (e *SCIMResourceNotFoundError) Error() string {
+	return fmt.Sprintf("SCIM %s not found: %v", e.resourceType, e.id)
+}
+
+func (e *SCIMResourceNotFoundError) NotFound() bool {
+	return true
+}
+
+// SCIMUniquenessError is returned when creating or updating a SCIM resource
+// would give it the same name as another resource, or map it to a user or
+// organization that is already mapped.
+type SCIMUniquenessError struct {
+	constraint string
+}
+
+func (e *SCIMUniquenessError) Error() string {
+	switch e.constraint {
+	case "scim_users_user_name_key":
+		return "a SCIM user with this userName already exists"
+	case "scim_users_user_id_key":
+		return "the user is already mapped to a SCIM user"
+	case "scim_groups_display_name_key":
+		return "a SCIM group with this displayName already exists"
+	case "scim_groups_org_id_key":
+		return "the organization is already mapped to a SCIM group"
+	}
+	return "SCIM resource already exists"
+}
+
+// IsSCIMUniquenessError reports whether err is a SCIMUniquenessError.
+func IsSCIMUniquenessError(err error) bool {
+	var e *SCIMUniquenessError
+	return errors.As(err, &e)
+}
+
+// wrapSCIMUniquenessError turns unique constraint violations into
+// SCIMUniquenessErrors.
+func wrapSCIMUniquenessError(err error) error {
+	var e *pgconn.PgError
+	if errors.As(err, &e) && e.Code == "23505" {
+		return &SCIMUniquenessError{constraint: e.ConstraintName}
+	}
+	return err
+}
+
+func (s *scimStore) CreateUser(ctx context.Context, u *SCIMUser) (*SCIMUser, error) {
+	attributes := u.Attributes
+	if len(attributes) == 0 {
+		attributes = json.RawMessage(`{}`)
+	}
+
+	row := s.QueryRow(ctx, sqlf.Sprintf(
+		scimUserCreateQueryFmtstr,
+		uuid.New().String(),
+		u.UserID,
+		dbutil.NewNullString(u.ExternalID),
+		u.UserName,
+		u.Active,
+		attributes,
+		sqlf.Join(scimUserColumns, ", "),
+	))
+	created, err := scanSCIMUser(row)
+	if err != nil {
+		return nil, wrapSCIMUniquenessError(err)
+	}
+	return created, nil
+}
+
+func (s *scimStore) GetUser(ctx context.Context, id string) (*SCIMUser, error) {
+	return s.getUser(ctx, sqlf.Sprintf("id = %s", id), id)
+}
+
+func (s *scimStore) GetUserByUserID(ctx context.Context, userID int32) (*SCIMUser, error) {
+	return s.getUser(ctx, sqlf.Sprintf("user_id = %s", userID), userID)
+}
+
+func (s *scimStore) getUser(ctx context.Context, pred *sqlf.Query, id any) (*SCIMUser, error) {
+	u, err := scanSCIMUser(s.QueryRow(ctx, sqlf.Sprintf(
+		scimUserGetQueryFmtstr,
+		sqlf.Join(scimUserColumns, ", "),
+		pred,
+	)))
+	if err == sql.ErrNoRows {
+		return nil, &SCIMResourceNotFoundError{resourceType: "user", id: id}
+	}
+	return u, err
+}
+
+func (s *scimStore) ListUsers(ctx context.Context, opts SCIMListOpts) (_ []*SCIMUser, err error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.UserName != "" {
+		preds = append(preds, sqlf.Sprintf("user_name = %s", opts.UserName))
+	}
+	if opts.ExternalID != "" {
+		preds = append(preds, sqlf.Sprintf("external_id = %s", opts.ExternalID))
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		scimUserListQueryFmtstr,
+		sqlf.Join(scimUserColumns, ", "),
+		sqlf.Join(preds, " AND "),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return scanSCIMUsers(rows)
+}
+
+func (s *scimStore) UpdateUser(ctx context.Context, u *SCIMUser) (*SCIMUser, error) {
+	attributes := u.Attributes
+	if len(attributes) == 0 {
+		attributes = json.RawMessage(`{}`)
+	}
+
+	updated, err := scanSCIMUser(s.QueryRow(ctx, sqlf.Sprintf(
+		scimUserUpdateQueryFmtstr,
+		dbutil.NewNullString(u.ExternalID),
+		u.UserName,
+		u.Active,
+		attributes,
+		u.ID,
+		sqlf.Join(scimUserColumns, ", "),
+	)))
+	if err == sql.ErrNoRows {
+		return nil, &SCIMResourceNotFoundError{resourceType: "user", id: u.ID}
+	} else if err != nil {
+		return nil, wrapSCIMUniquenessError(err)
+	}
+	return updated, nil
+}
+
+func (s *scimStore) DeleteUser(ctx context.Context, id string) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(scimUserDeleteQueryFmtstr, id))
+	if err != nil {
+		return err
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return &SCIMResourceNotFoundError{resourceType: "user", id: id}
+	}
+	return nil
+}
+
+func (s *scimStore) CreateGroup(ctx context.Context, g *SCIMGroup) (*SCIMGroup, error) {
+	created, err := scanSCIMGroup(s.QueryRow(ctx, sqlf.Sprintf(
+		scimGroupCreateQueryFmtstr,
+		uuid.New().String(),
+		g.OrgID,
+		dbutil.NewNullString(g.ExternalID),
+		g.DisplayName,
+		sqlf.Join(scimGroupColumns, ", "),
+	)))
+	if err != nil {
+		return nil, wrapSCIMUniquenessError(err)
+	}
+	return created, nil
+}
+
+func (s *scimStore) GetGroup(ctx context.Context, id string) (*SCIMGroup, error) {
+	g, err := scanSCIMGroup(s.QueryRow(ctx, sqlf.Sprintf(
+		scimGroupGetQueryFmtstr,
+		sqlf.Join(scimGroupColumns, ", "),
+		id,
+	)))
+	if err == sql.ErrNoRows {
+		return nil, &SCIMResourceNotFoundError{resourceType: "group", id: id}
+	}
+	return g, err
+}
+
+func (s *scimStore) ListGroups(ctx context.Context, opts SCIMListOpts) (_ []*SCIMGroup, err error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.DisplayName != "" {
+		preds = append(preds, sqlf.Sprintf("display_name = %s", opts.DisplayName))
+	}
+	if opts.ExternalID != "" {
+		preds = append(preds, sqlf.Sprintf("external_id = %s", opts.ExternalID))
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		scimGroupListQueryFmtstr,
+		sqlf.Join(scimGroupColumns, ", "),
+		sqlf.Join(preds, " AND "),
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var groups []*SCIMGroup
+	for rows.Next() {
+		g, err := scanSCIMGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+func (s *scimStore) UpdateGroup(ctx context.Context, g *SCIMGroup) (*SCIMGroup, error) {
+	updated, err := scanSCIMGroup(s.QueryRow(ctx, sqlf.Sprintf(
+		scimGroupUpdateQueryFmtstr,
+		dbutil.NewNullString(g.ExternalID),
+		g.DisplayName,
+		g.ID,
+		sqlf.Join(scimGroupColumns, ", "),
+	)))
+	if err == sql.ErrNoRows {
+		return nil, &SCIMResourceNotFoundError{resourceType: "group", id: g.ID}
+	} else if err != nil {
+		return nil, wrapSCIMUniquenessError(err)
+	}
+	return updated, nil
+}
+
+func (s *scimStore) DeleteGroup(ctx context.Context, id string) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(scimGroupDeleteQueryFmtstr, id))
+	if err != nil {
+		return err
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return &SCIMResourceNotFoundError{resourceType: "group", id: id}
+	}
+	return nil
+}
+
+func (s *scimStore) ListGroupMembers(ctx context.Context, orgID int32) ([]*SCIMUser, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		scimGroupMembersQueryFmtstr,
+		sqlf.Join(scimUserColumns, ", "),
+		orgID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return scanSCIMUsers(rows)
+}
+
+var scimUserColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("external_id"),
+	sqlf.Sprintf("user_name"),
+	sqlf.Sprintf("active"),
+	sqlf.Sprintf("attributes"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+var scimGroupColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("org_id"),
+	sqlf.Sprintf("external_id"),
+	sqlf.Sprintf("display_name"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+const scimUserCreateQueryFmtstr = `
+-- source: internal/database/scim.go:CreateUser
+INSERT INTO
+	scim_users (
+		id,
+		user_id,
+		external_id,
+		user_name,
+		active,
+		attributes
+	)
+	VALUES (
+		%s,
+		%s,
+		%s,
+		%s,
+		%s,
+		%s
+	)
+RETURNING %s
+`
+
+const scimUserGetQueryFmtstr = `
+-- source: internal/database/scim.go:getUser
+SELECT
+	%s
+FROM
+	scim_users
+WHERE
+	%s
+`
+
+const scimUserListQueryFmtstr = `
+-- source: internal/database/scim.go:ListUsers
+SELECT
+	%s
+FROM
+	scim_users
+WHERE
+	%s
+ORDER BY
+	created_at, id
+`
+
+const scimUserUpdateQueryFmtstr = `
+-- source: internal/database/scim.go:UpdateUser
+UPDATE
+	scim_users
+SET
+	external_id = %s,
+	user_name = %s,
+	active = %s,
+	attributes = %s,
+	updated_at = NOW()
+WHERE
+	id = %s
+RETURNING %s
+`
+
+const scimUserDeleteQueryFmtstr = `
+-- source: internal/database/scim.go:DeleteUser
+DELETE FROM
+	scim_users
+WHERE
+	id = %s
+`
+
+const scimGroupCreateQueryFmtstr = `
+-- source: internal/database/scim.go:CreateGroup
+INSERT INTO
+	scim_groups (
+		id,
+		org_id,
+		external_id,
+		display_name
+	)
+	VALUES (
+		%s,
+		%s,
+		%s,
+		%s
+	)
+RETURNING %s
+`
+
+const scimGroupGetQueryFmtstr = `
+-- source: internal/database/scim.go:GetGroup
+SELECT
+	%s
+FROM
+	scim_groups
+WHERE
+	id = %s
+`
+
+const scimGroupListQueryFmtstr = `
+-- source: internal/database/scim.go:ListGroups
+SELECT
+	%s
+FROM
+	scim_groups
+WHERE
+	%s
+ORDER BY
+	created_at, id
+`
+
+const scimGroupUpdateQueryFmtstr = `
+-- source: internal/database/scim.go:UpdateGroup
+UPDATE
+	scim_groups
+SET
+	external_id = %s,
+	display_name = %s,
+	updated_at = NOW()
+WHERE
+	id = %s
+RETURNING %s
+`
+
+const scimGroupDeleteQueryFmtstr = `
+-- source: internal/database/scim.go:DeleteGroup
+DELETE FROM
+	scim_groups
+WHERE
+	id = %s
+`
+
+const scimGroupMembersQueryFmtstr = `
+-- source: internal/database/scim.go:ListGroupMembers
+SELECT
+	%s
+FROM
+	scim_users
+WHERE
+	user_id IN (SELECT user_id FROM org_members WHERE org_id = %s)
+ORDER BY
+	created_at, id
+`
+
+func scanSCIMUser(sc dbutil.Scanner) (*SCIMUser, error) {
+	var u SCIMUser
+	var attributes []byte
+	if err := sc.Scan(
+		&u.ID,
+		&u.UserID,
+		&dbutil.NullString{S: &u.ExternalID},
+		&u.UserName,
+		&u.Active,
+		&attributes,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	u.Attributes = attributes
+	return &u, nil
+}
+
+func scanSCIMUsers(rows *sql.Rows) (_ []*SCIMUser, err error) {
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var users []*SCIMUser
+	for rows.Next() {
+		u, err := scanSCIMUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func scanSCIMGroup(sc dbutil.Scanner) (*SCIMGroup, error) {
+	var g SCIMGroup
+	if err := sc.Scan(
+		&g.ID,
+		&g.OrgID,
+		&dbutil.NullString{S: &g.ExternalID},
+		&g.DisplayName,
+		&g.CreatedAt,
+		&g.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
@@ -0,0 +1,175 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestSCIMStore_Users(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+	store := db.SCIM()
+
+	alice, err := db.Users().Create(ctx, NewUser{Username: "alice"})
+	require.NoError(t, err)
+	bob, err := db.Users().Create(ctx, NewUser{Username: "bob"})
+	require.NoError(t, err)
+
+	su, err := store.CreateUser(ctx, &SCIMUser{
+		UserID:     alice.ID,
+		ExternalID: "00u1",
+		UserName:   "Alice@example.com",
+		Active:     true,
+		Attributes: json.RawMessage(`{"displayName": "Alice"}`),
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, su.ID)
+	assert.NotZero(t, su.CreatedAt)
+
+	_, err = store.CreateUser(ctx, &SCIMUser{UserID: bob.ID, UserName: "alice@EXAMPLE.com", Active: true})
+	assert.True(t, IsSCIMUniquenessError(err), "got error %v", err)
+	_, err = store.CreateUser(ctx, &SCIMUser{UserID: alice.ID, UserName: "other", Active: true})
+	assert.True(t, IsSCIMUniquenessError(err), "got error %v", err)
+
+	_, err = store.CreateUser(ctx, &SCIMUser{UserID: bob.ID, UserName: "bob", Active: false})
+	require.NoError(t, err)
+
+	t.Run("Get", func(t *testing.T) {
+		got, err := store.GetUser(ctx, su.ID)
+		require.NoError(t, err)
+		assert.Equal(t, su.UserID, got.UserID)
+		assert.Equal(t, "00u1", got.ExternalID)
+		assert.JSONEq(t, `{"displayName": "Alice"}`, string(got.Attributes))
+
+		got, err = store.GetUserByUserID(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, su.ID, got.ID)
+
+		_, err = store.GetUser(ctx, "missing")
+		assert.True(t, errcode.IsNotFound(err), "got error %v", err)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			opts SCIMListOpts
+			want int
+		}{
+			"all":         {opts: SCIMListOpts{}, want: 2},
+			"user name":   {opts: SCIMListOpts{UserName: "ALICE@example.com"}, want: 1},
+			"external ID": {opts: SCIMListOpts{ExternalID: "00u1"}, want: 1},
+			"no match":    {opts: SCIMListOpts{UserName: "carol"}, want: 0},
+		} {
+			t.Run(name, func(t *testing.T) {
+				users, err := store.ListUsers(ctx, tc.opts)
+				require.NoError(t, err)
+				assert.Len(t, users, tc.want)
+			})
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		su.UserName = "alice"
+		su.ExternalID = ""
+		su.Active = false
+		su.Attributes = json.RawMessage(`{}`)
+		updated, err := store.UpdateUser(ctx, su)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", updated.UserName)
+		assert.Empty(t, updated.ExternalID)
+		assert.False(t, updated.Active)
+		assert.True(t, !updated.UpdatedAt.Before(su.UpdatedAt))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.DeleteUser(ctx, su.ID))
+		_, err := store.GetUser(ctx, su.ID)
+		assert.True(t, errcode.IsNotFound(err), "got error %v", err)
+
+		err = store.DeleteUser(ctx, su.ID)
+		assert.True(t, errcode.IsNotFound(err), "got error %v", err)
+	})
+}
+
+func TestSCIMStore_Groups(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+	store := db.SCIM()
+
+	org, err := db.Orgs().Create(ctx, "engineering", nil)
+	require.NoError(t, err)
+
+	sg, err := store.CreateGroup(ctx, &SCIMGroup{OrgID: org.ID, ExternalID: "00g1", DisplayName: "Engineering"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, sg.ID)
+
+	other, err := db.Orgs().Create(ctx, "other", nil)
+	require.NoError(t, err)
+	_, err = store.CreateGroup(ctx, &SCIMGroup{OrgID: other.ID, DisplayName: "ENGINEERING"})
+	assert.True(t, IsSCIMUniquenessError(err), "got error %v", err)
+
+	t.Run("Get and List", func(t *testing.T) {
+		got, err := store.GetGroup(ctx, sg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, org.ID, got.OrgID)
+
+		groups, err := store.ListGroups(ctx, SCIMListOpts{DisplayName: "engineering"})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, sg.ID, groups[0].ID)
+
+		groups, err = store.ListGroups(ctx, SCIMListOpts{ExternalID: "nope"})
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+
+	t.Run("ListGroupMembers", func(t *testing.T) {
+		alice, err := db.Users().Create(ctx, NewUser{Username: "alice"})
+		require.NoError(t, err)
+		bob, err := db.Users().Create(ctx, NewUser{Username: "bob"})
+		require.NoError(t, err)
+		su, err := store.CreateUser(ctx, &SCIMUser{UserID: alice.ID, UserName: "alice", Active: true})
+		require.NoError(t, err)
+
+		// Only members who are SCIM users are returned.
+		for _, userID := range []int32{alice.ID, bob.ID} {
+			_, err := db.OrgMembers().Create(ctx, org.ID, userID)
+			require.NoError(t, err)
+		}
+		members, err := store.ListGroupMembers(ctx, org.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		assert.Equal(t, su.ID, members[0].ID)
+	})
+
+	t.Run("Update and Delete", func(t *testing.T) {
+		sg.DisplayName = "Eng"
+		updated, err := store.UpdateGroup(ctx, sg)
+		require.NoError(t, err)
+		assert.Equal(t, "Eng", updated.DisplayName)
+
+		require.NoError(t, store.DeleteGroup(ctx, sg.ID))
+		_, err = store.GetGroup(ctx, sg.ID)
+		assert.True(t, errcode.IsNotFound(err), "got error %v", err)
+	})
+}
@@ -0,0 +1,344 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SearchHistoryEntry is a search that a user ran.
+type SearchHistoryEntry struct {
+	ID          int64
+	UserID      int32
+	Query       string
+	PatternType string
+	ResultCount int32
+	DurationMs  int32
+	Pinned      bool
+	CreatedAt   time.Time
+}
+
+// SearchHistoryStore provides persistence for the search history of users.
+// The methods that take an ID and a user ID only affect the entry if it
+// belongs to the user.
+type SearchHistoryStore interface {
+	basestore.ShareableStore
+
+	Create(context.Context, *SearchHistoryEntry) (*SearchHistoryEntry, error)
+	Count(context.Context, SearchHistoryListOpts) (int64, error)
+	List(context.Context, SearchHistoryListOpts) ([]*SearchHistoryEntry, int64, error)
+	SetPinned(ctx context.Context, userID int32, id int64, pinned bool) (*SearchHistoryEntry, error)
+	Delete(ctx context.Context, userID int32, id int64) error
+	// DeleteAll removes all entries of the user, including pinned entries.
+	DeleteAll(ctx context.Context, userID int32) error
+	// DeleteStale removes the entries of the user that are older than the
+	// given retention period and not pinned.
+	DeleteStale(ctx context.Context, userID int32, retention time.Duration) error
+	// ListUsersWithStaleEntries returns the IDs of the users that have entries
+	// that are older than the given retention period and not pinned.
+	ListUsersWithStaleEntries(ctx context.Context, retention time.Duration) ([]int32, error)
+}
+
+type searchHistoryStore struct {
+	*basestore.Store
+}
+
+var _ SearchHistoryStore = &searchHistoryStore{}
+
+// SearchHistoryWith instantiates and returns a new SearchHistoryStore using the
+// other store handle.
+func SearchHistoryWith(other basestore.ShareableStore) SearchHistoryStore {
+	return &searchHistoryStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+type searchHistoryEntryNotFoundError struct{ id int64 }
+
+func (e searchHistoryEntryNotFoundError) Error() string {
+	return fmt.Sprintf("search history entry with id %d not found", e.id)
+}
+
+func (searchHistoryEntryNotFoundError) NotFound() bool {
+	return true
+}
+
+func (s *searchHistoryStore) Create(ctx context.Context, entry *SearchHistoryEntry) (*SearchHistoryEntry, error) {
+	q := sqlf.Sprintf(
+		searchHistoryInsertQueryFmtstr,
+		entry.UserID,
+		entry.Query,
+		entry.PatternType,
+		entry.ResultCount,
+		entry.DurationMs,
+		entry.Pinned,
+		timeutil.Now(),
+		sqlf.Join(searchHistoryColumns, ", "),
+	)
+
+	var created SearchHistoryEntry
+	if err := scanSearchHistoryEntry(&created, s.QueryRow(ctx, q)); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+type SearchHistoryListOpts struct {
+	// UserID is the user whose entries are returned. It is required.
+	UserID int32
+
+	// The maximum number of entries to return, and the cursor, if any. Like
+	// audit logs, the cursor is based on the ID, since new entries are added
+	// while paging through the result set.
+	Limit  int
+	Cursor int64
+
+	// If set, only entries whose query contains Query, ignoring case, are
+	// returned.
+	Query string
+	// If set, only pinned or unpinned entries are returned.
+	Pinned *bool
+}
+
+// likeEscaper escapes the characters that have a special meaning in LIKE
+// patterns, so that the query is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (opts *SearchHistoryListOpts) predicates() []*sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("user_id = %s", opts.UserID)}
+	if opts.Query != "" {
+		preds = append(preds, sqlf.Sprintf(`query ILIKE %s ESCAPE '\'`, "%"+likeEscaper.Replace(opts.Query)+"%"))
+	}
+	if opts.Pinned != nil {
+		preds = append(preds, sqlf.Sprintf("pinned = %s", *opts.Pinned))
+	}
+	return preds
+}
+
+func (s *searchHistoryStore) Count(ctx context.Context, opts SearchHistoryListOpts) (int64, error) {
+	q := sqlf.Sprintf(
+		searchHistoryCountQueryFmtstr,
+		sqlf.Join(opts.predicates(), " AND "),
+	)
+
+	count, _, err := basestore.ScanFirstInt64(s.Query(ctx, q))
+	return count, err
+}
+
+// List returns the entries matching the given options, newest first, along
+// with the cursor of the next page. The cursor is zero if there are no more
+// entries.
+func (s *searchHistoryStore) List(ctx context.Context, opts SearchHistoryListOpts) (_ []*SearchHistoryEntry, _ int64, err error) {
+	preds := opts.predicates()
+	if cursor := opts.Cursor; cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("id <= %s", cursor))
+	}
+
+	var limit *sqlf.Query
+	if opts.Limit != 0 {
+		limit = sqlf.Sprintf("LIMIT %s", opts.Limit+1)
+	} else {
+		limit = sqlf.Sprintf("")
+	}
+
+	q := sqlf.Sprintf(
+		searchHistoryListQueryFmtstr,
+		sqlf.Join(searchHistoryColumns, ", "),
+		sqlf.Join(preds, " AND "),
+		limit,
+	)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	entries := []*SearchHistoryEntry{}
+	for rows.Next() {
+		var entry SearchHistoryEntry
+		if err := scanSearchHistoryEntry(&entry, rows); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, &entry)
+	}
+
+	var next int64 = 0
+	if opts.Limit != 0 && len(entries) == opts.Limit+1 {
+		next = entries[len(entries)-1].ID
+		entries = entries[:len(entries)-1]
+	}
+
+	return entries, next, nil
+}
+
+func (s *searchHistoryStore) SetPinned(ctx context.Context, userID int32, id int64, pinned bool) (*SearchHistoryEntry, error) {
+	q := sqlf.Sprintf(
+		searchHistorySetPinnedQueryFmtstr,
+		pinned,
+		id,
+		userID,
+		sqlf.Join(searchHistoryColumns, ", "),
+	)
+
+	var entry SearchHistoryEntry
+	if err := scanSearchHistoryEntry(&entry, s.QueryRow(ctx, q)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, searchHistoryEntryNotFoundError{id: id}
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *searchHistoryStore) Delete(ctx context.Context, userID int32, id int64) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(searchHistoryDeleteQueryFmtstr, id, userID))
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return searchHistoryEntryNotFoundError{id: id}
+	}
+	return nil
+}
+
+func (s *searchHistoryStore) DeleteAll(ctx context.Context, userID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(searchHistoryDeleteAllQueryFmtstr, userID))
+}
+
+func (s *searchHistoryStore) DeleteStale(ctx context.Context, userID int32, retention time.Duration) error {
+	before := timeutil.Now().Add(-retention)
+
+	return s.Exec(ctx, sqlf.Sprintf(searchHistoryDeleteStaleQueryFmtstr, userID, before))
+}
+
+func (s *searchHistoryStore) ListUsersWithStaleEntries(ctx context.Context, retention time.Duration) ([]int32, error) {
+	before := timeutil.Now().Add(-retention)
+
+	return basestore.ScanInt32s(s.Query(ctx, sqlf.Sprintf(searchHistoryListUsersWithStaleEntriesQueryFmtstr, before)))
+}
+
+var searchHistoryColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("query"),
+	sqlf.Sprintf("pattern_type"),
+	sqlf.Sprintf("result_count"),
+	sqlf.Sprintf("duration_ms"),
+	sqlf.Sprintf("pinned"),
+	sqlf.Sprintf("created_at"),
+}
+
+const searchHistoryInsertQueryFmtstr = `
+-- source: internal/database/search_history.go:Create
+INSERT INTO
+	search_history (
+		user_id,
+		query,
+		pattern_type,
+		result_count,
+		duration_ms,
+		pinned,
+		created_at
+	)
+	VALUES (
+		%s,
+		%s,
+		%s,
+		%s,
+		%s,
+		%s,
+		%s
+	)
+	RETURNING %s
+`
+
+const searchHistoryCountQueryFmtstr = `
+-- source: internal/database/search_history.go:Count
+SELECT
+	COUNT(id)
+FROM
+	search_history
+WHERE
+	%s
+`
+
+const searchHistoryListQueryFmtstr = `
+-- source: internal/database/search_history.go:List
+SELECT
+	%s
+FROM
+	search_history
+WHERE
+	%s
+ORDER BY
+	id DESC
+%s -- LIMIT
+`
+
+const searchHistorySetPinnedQueryFmtstr = `
+-- source: internal/database/search_history.go:SetPinned
+UPDATE
+	search_history
+SET
+	pinned = %s
+WHERE
+	id = %s AND user_id = %s
+RETURNING %s
+`
+
+const searchHistoryDeleteQueryFmtstr = `
+-- source: internal/database/search_history.go:Delete
+DELETE FROM
+	search_history
+WHERE
+	id = %s AND user_id = %s
+`
+
+const searchHistoryDeleteAllQueryFmtstr = `
+-- source: internal/database/search_history.go:DeleteAll
+DELETE FROM
+	search_history
+WHERE
+	user_id = %s
+`
+
+const searchHistoryDeleteStaleQueryFmtstr = `
+-- source: internal/database/search_history.go:DeleteStale
+DELETE FROM
+	search_history
+WHERE
+	user_id = %s AND created_at <= %s AND NOT pinned
+`
+
+const searchHistoryListUsersWithStaleEntriesQueryFmtstr = `
+-- source: internal/database/search_history.go:ListUsersWithStaleEntries
+SELECT DISTINCT
+	user_id
+FROM
+	search_history
+WHERE
+	created_at <= %s AND NOT pinned
+`
+
+func scanSearchHistoryEntry(entry *SearchHistoryEntry, sc dbutil.Scanner) error {
+	return sc.Scan(
+		&entry.ID,
+		&entry.UserID,
+		&entry.Query,
+		&entry.PatternType,
+		&entry.ResultCount,
+		&entry.DurationMs,
+		&entry.Pinned,
+		&entry.CreatedAt,
+	)
+}
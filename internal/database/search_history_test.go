@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestSearchHistoryStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+
+	alice, err := db.Users().Create(ctx, NewUser{Username: "alice"})
+	require.NoError(t, err)
+	bob, err := db.Users().Create(ctx, NewUser{Username: "bob"})
+	require.NoError(t, err)
+
+	store := db.SearchHistory()
+	create := func(userID int32, query string) *SearchHistoryEntry {
+		t.Helper()
+		entry, err := store.Create(ctx, &SearchHistoryEntry{
+			UserID:      userID,
+			Query:       query,
+			PatternType: "standard",
+			ResultCount: 10,
+			DurationMs:  120,
+		})
+		require.NoError(t, err)
+		return entry
+	}
+
+	first := create(alice.ID, "repo:foo bar")
+	second := create(alice.ID, "type:diff TODO")
+	third := create(alice.ID, "repo:foo baz")
+	create(bob.ID, "repo:foo bar")
+	create(bob.ID, `content:"100%" file:a_b\.go`)
+
+	t.Run("Create", func(t *testing.T) {
+		assert.NotZero(t, first.ID)
+		assert.NotZero(t, first.CreatedAt)
+		assert.Equal(t, "repo:foo bar", first.Query)
+		assert.Equal(t, int32(10), first.ResultCount)
+		assert.Equal(t, int32(120), first.DurationMs)
+		assert.False(t, first.Pinned)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		entries, next, err := store.List(ctx, SearchHistoryListOpts{UserID: alice.ID})
+		require.NoError(t, err)
+		assert.Zero(t, next)
+		require.Len(t, entries, 3)
+
+		// Entries are returned newest first.
+		assert.Equal(t, third.ID, entries[0].ID)
+		assert.Equal(t, second.ID, entries[1].ID)
+		assert.Equal(t, first.ID, entries[2].ID)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		entries, _, err := store.List(ctx, SearchHistoryListOpts{UserID: alice.ID, Query: "REPO:FOO"})
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+
+		count, err := store.Count(ctx, SearchHistoryListOpts{UserID: bob.ID})
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+
+		// Wildcards in the query are matched literally.
+		for _, q := range []string{"0%", `a_b\.`} {
+			count, err := store.Count(ctx, SearchHistoryListOpts{UserID: bob.ID, Query: q})
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, count, q)
+		}
+		for _, q := range []string{"%", "r_po", `\`} {
+			count, err := store.Count(ctx, SearchHistoryListOpts{UserID: alice.ID, Query: q})
+			require.NoError(t, err)
+			assert.Zero(t, count, q)
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		entries, next, err := store.List(ctx, SearchHistoryListOpts{UserID: alice.ID, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, first.ID, next)
+
+		entries, next, err = store.List(ctx, SearchHistoryListOpts{UserID: alice.ID, Limit: 2, Cursor: next})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Zero(t, next)
+	})
+
+	t.Run("SetPinned", func(t *testing.T) {
+		entry, err := store.SetPinned(ctx, alice.ID, first.ID, true)
+		require.NoError(t, err)
+		assert.True(t, entry.Pinned)
+
+		pinned := true
+		count, err := store.Count(ctx, SearchHistoryListOpts{UserID: alice.ID, Pinned: &pinned})
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+
+		// Users cannot pin the entries of other users.
+		_, err = store.SetPinned(ctx, bob.ID, second.ID, true)
+		assert.True(t, errcode.IsNotFound(err))
+	})
+
+	t.Run("DeleteStale", func(t *testing.T) {
+		_, err := db.Handle().ExecContext(ctx, "UPDATE search_history SET created_at = created_at - interval '30 days' WHERE user_id = $1", alice.ID)
+		require.NoError(t, err)
+
+		userIDs, err := store.ListUsersWithStaleEntries(ctx, 7*24*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{alice.ID}, userIDs)
+
+		require.NoError(t, store.DeleteStale(ctx, alice.ID, 7*24*time.Hour))
+
+		// Pinned entries are kept.
+		entries, _, err := store.List(ctx, SearchHistoryListOpts{UserID: alice.ID})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, first.ID, entries[0].ID)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.True(t, errcode.IsNotFound(store.Delete(ctx, bob.ID, first.ID)))
+		require.NoError(t, store.Delete(ctx, alice.ID, first.ID))
+		assert.True(t, errcode.IsNotFound(store.Delete(ctx, alice.ID, first.ID)))
+	})
+
+	t.Run("DeleteAll", func(t *testing.T) {
+		require.NoError(t, store.DeleteAll(ctx, bob.ID))
+		count, err := store.Count(ctx, SearchHistoryListOpts{UserID: bob.ID})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
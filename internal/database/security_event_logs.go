@@ -27,9 +27,10 @@ const (
 	SecurityEventNameSignInFailed    SecurityEventName = "SignInFailed"
 	SecurityEventNameSignInSucceeded SecurityEventName = "SignInSucceeded"
 
-	SecurityEventNameAccountCreated SecurityEventName = "AccountCreated"
-	SecurityEventNameAccountDeleted SecurityEventName = "AccountDeleted"
-	SecurityEventNameAccountNuked   SecurityEventName = "AccountNuked"
+	SecurityEventNameAccountCreated   SecurityEventName = "AccountCreated"
+	SecurityEventNameAccountDeleted   SecurityEventName = "AccountDeleted"
+	SecurityEventNameAccountNuked     SecurityEventName = "AccountNuked"
+	SecurityEventNameAccountRecovered SecurityEventName = "AccountRecovered"
 
 	SecurityEventNamPasswordResetRequested SecurityEventName = "PasswordResetRequested"
 	SecurityEventNamPasswordRandomized     SecurityEventName = "PasswordRandomized"
@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/cookie"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -78,6 +79,7 @@ type UserStore interface {
 	List(context.Context, *UsersListOptions) (_ []*types.User, err error)
 	ListDates(context.Context) ([]types.UserDates, error)
 	RandomizePasswordAndClearPasswordResetRateLimit(context.Context, int32) error
+	RecoverList(context.Context, []int32) error
 	RenewPasswordResetCode(context.Context, int32) (string, error)
 	SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bool) error
 	SetPassword(ctx context.Context, id int32, resetCode, newPassword string) (bool, error)
@@ -511,6 +513,10 @@ func (u *userStore) Delete(ctx context.Context, id int32) (err error) {
 
 // Bulk "Delete" action.
 func (u *userStore) DeleteList(ctx context.Context, ids []int32) (err error) {
+	if len(ids) == 0 {
+		return nil
+	}
+
 	tx, err := u.Transact(ctx)
 	if err != nil {
 		return err
@@ -543,7 +549,9 @@ func (u *userStore) DeleteList(ctx context.Context, ids []int32) (err error) {
 	if err := tx.Exec(ctx, sqlf.Sprintf("UPDATE access_tokens SET deleted_at=now() WHERE subject_user_id IN (%s) OR creator_user_id IN (%s)", idsCond, idsCond)); err != nil {
 		return err
 	}
-	if err := tx.Exec(ctx, sqlf.Sprintf("DELETE FROM user_emails WHERE user_id IN (%s)", idsCond)); err != nil {
+	// Move the email addresses aside, so that they are free for other users
+	// but can be restored if the user is recovered.
+	if err := tx.Exec(ctx, sqlf.Sprintf(moveUserEmailsToDeletedQuery, idsCond)); err != nil {
 		return err
 	}
 	if err := tx.Exec(ctx, sqlf.Sprintf("UPDATE user_external_accounts SET deleted_at=now() WHERE user_id IN (%s) AND deleted_at IS NULL", idsCond)); err != nil {
@@ -561,6 +569,96 @@ func (u *userStore) DeleteList(ctx context.Context, ids []int32) (err error) {
 	return nil
 }
 
+const moveUserEmailsToDeletedQuery = `
+WITH deleted AS (
+	DELETE FROM user_emails WHERE user_id IN (%s)
+	RETURNING user_id, email, created_at, verified_at, is_primary
+)
+INSERT INTO deleted_user_emails (user_id, email, created_at, verified_at, is_primary)
+SELECT user_id, email, created_at, verified_at, is_primary FROM deleted
+ON CONFLICT (user_id, email) DO UPDATE SET
+	created_at = EXCLUDED.created_at,
+	verified_at = EXCLUDED.verified_at,
+	is_primary = EXCLUDED.is_primary
+`
+
+// RecoverList restores soft-deleted users. Their usernames are reserved again,
+// which fails if another user or org has taken them in the meantime, and the
+// external accounts and email addresses that were deleted along with them are
+// restored. An email address that another user has verified in the meantime is
+// restored unverified. Access tokens are not restored.
+func (u *userStore) RecoverList(ctx context.Context, ids []int32) (err error) {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tx, err := u.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	userIDs := make([]*sqlf.Query, len(ids))
+	for i := range ids {
+		userIDs[i] = sqlf.Sprintf("%d", ids[i])
+	}
+
+	idsCond := sqlf.Join(userIDs, ",")
+
+	// Reserve the usernames in the shared users+orgs namespace again.
+	if err := tx.Exec(ctx, sqlf.Sprintf("INSERT INTO names(name, user_id) SELECT username, id FROM users WHERE id IN (%s) AND deleted_at IS NOT NULL", idsCond)); err != nil {
+		var e *pgconn.PgError
+		if errors.As(err, &e) && e.ConstraintName == "names_pkey" {
+			return errCannotCreateUser{errorCodeUsernameExists}
+		}
+		return err
+	}
+	// External accounts deleted in the same transaction as the user have the
+	// same deleted_at. Ones that were deleted before are left alone.
+	if err := tx.Exec(ctx, sqlf.Sprintf("UPDATE user_external_accounts a SET deleted_at=NULL FROM users u WHERE a.user_id=u.id AND u.id IN (%s) AND a.deleted_at=u.deleted_at", idsCond)); err != nil {
+		return err
+	}
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(restoreDeletedUserEmailsQuery, idsCond)); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecResult(ctx, sqlf.Sprintf("UPDATE users SET deleted_at=NULL, updated_at=now() WHERE id IN (%s) AND deleted_at IS NOT NULL", idsCond))
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows != int64(len(ids)) {
+		return userNotFoundErr{args: []any{fmt.Sprintf("Some users were not found. Expected to recover %d users, but recovered only %d", +len(ids), rows)}}
+	}
+
+	logUserDeletionEvents(ctx, NewDBWith(u.logger, u), ids, SecurityEventNameAccountRecovered)
+
+	return nil
+}
+
+// Only emails of users that are still soft-deleted are restored.
+const restoreDeletedUserEmailsQuery = `
+WITH restored AS (
+	DELETE FROM deleted_user_emails d
+	USING users u
+	WHERE d.user_id = u.id AND u.id IN (%s) AND u.deleted_at IS NOT NULL
+	RETURNING d.user_id, d.email, d.created_at, d.verified_at, d.is_primary
+)
+INSERT INTO user_emails (user_id, email, created_at, verified_at, is_primary)
+SELECT
+	r.user_id,
+	r.email,
+	r.created_at,
+	CASE WHEN EXISTS (SELECT 1 FROM user_emails e WHERE e.email = r.email AND e.verified_at IS NOT NULL) THEN NULL ELSE r.verified_at END,
+	r.is_primary
+FROM restored r
+ON CONFLICT (user_id, email) DO NOTHING
+`
+
 // HardDelete removes the user and all resources associated with this user.
 func (u *userStore) HardDelete(ctx context.Context, id int32) (err error) {
 	return u.HardDeleteList(ctx, []int32{id})
@@ -678,7 +776,19 @@ func (u *userStore) SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bo
 	}
 
 	err := u.Store.Exec(ctx, sqlf.Sprintf("UPDATE users SET site_admin=%s WHERE id=%s", isSiteAdmin, id))
-	return err
+	if err != nil {
+		return err
+	}
+
+	audit.Log(ctx, u.logger, audit.Record{
+		Entity: "user",
+		Action: "set site admin",
+		Fields: []log.Field{
+			log.Int32("userID", id),
+			log.Bool("siteAdmin", isSiteAdmin),
+		},
+	})
+	return nil
 }
 
 // CheckAndDecrementInviteQuota should be called before the user (identified
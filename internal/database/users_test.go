@@ -849,3 +849,102 @@ func normalizeUsers(users []*types.User) []*types.User {
 	}
 	return users
 }
+
+func TestUsers_RecoverList(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "u"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Recovering a user who is not deleted fails.
+	if err := db.Users().RecoverList(ctx, []int32{user.ID}); !errcode.IsNotFound(err) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+
+	if err := db.Users().Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Users().RecoverList(ctx, []int32{user.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Users().GetByID(ctx, user.ID); err != nil {
+		t.Fatalf("expected user to be recovered, got error %v", err)
+	}
+
+	// Recovering fails if the username was taken in the meantime.
+	if err := db.Users().Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Users().Create(ctx, NewUser{Username: "u"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Users().RecoverList(ctx, []int32{user.ID}); !IsUsernameExists(err) {
+		t.Fatalf("got error %v, want username exists", err)
+	}
+
+	// Recovering no users is a no-op.
+	if err := db.Users().RecoverList(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUsers_RecoverList_Emails(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "u", Email: "a@example.com", EmailIsVerified: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UserEmails().Add(ctx, user.ID, "b@example.com", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UserEmails().SetVerified(ctx, user.ID, "b@example.com", true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Users().Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// The deleted user's emails are free for other users in the meantime.
+	if _, err := db.Users().Create(ctx, NewUser{Username: "other", Email: "b@example.com", EmailIsVerified: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Users().RecoverList(ctx, []int32{user.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	emails, err := db.UserEmails().ListByUser(ctx, UserEmailsListOptions{UserID: user.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]bool{}
+	for _, e := range emails {
+		got[e.Email] = e.VerifiedAt != nil
+	}
+	// b@example.com was verified by another user, so it is restored unverified.
+	want := map[string]bool{"a@example.com": true, "b@example.com": false}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected emails (-want +got):\n%s", diff)
+	}
+	if primary, _, err := db.UserEmails().GetPrimaryEmail(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	} else if primary != "a@example.com" {
+		t.Fatalf("got primary email %q, want %q", primary, "a@example.com")
+	}
+}
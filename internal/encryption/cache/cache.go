@@ -51,6 +51,16 @@ func (k *Key) Decrypt(ctx context.Context, ciphertext []byte) (*encryption.Secre
 	return &s, nil
 }
 
+// Rewrap re-encrypts the ciphertext with the underlying key, if it implements
+// encryption.Rewrapper. Otherwise it returns encryption.ErrRewrapUnsupported.
+func (k *Key) Rewrap(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	rw, ok := k.Key.(encryption.Rewrapper)
+	if !ok {
+		return nil, encryption.ErrRewrapUnsupported
+	}
+	return rw.Rewrap(ctx, ciphertext)
+}
+
 func hash(v []byte) uint64 {
 	h := fnv.New64()
 	h.Write(v)
@@ -3,6 +3,8 @@ package encryption
 import (
 	"context"
 	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // Key combines the Encrypter & Decrypter interfaces.
@@ -37,6 +39,17 @@ type Decrypter interface {
 	Decrypt(ctx context.Context, cipherText []byte) (*Secret, error)
 }
 
+// Rewrapper is implemented by keys that can re-encrypt a value that was
+// encrypted with an older version of the key with its current version, without
+// exposing the plaintext. This is used to migrate values after a key was
+// rotated.
+type Rewrapper interface {
+	Rewrap(ctx context.Context, cipherText []byte) ([]byte, error)
+}
+
+// ErrRewrapUnsupported is returned by Rewrap if the key can't rewrap values.
+var ErrRewrapUnsupported = errors.New("encryption key does not support rewrapping")
+
 func NewSecret(v string) Secret {
 	return Secret{
 		value: v,
@@ -0,0 +1,262 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewKey(ctx context.Context, config schema.VaultTransitEncryptionKey) (encryption.Key, error) {
+	return newKey(ctx, config, httpcli.ExternalDoer)
+}
+
+func newKey(ctx context.Context, config schema.VaultTransitEncryptionKey, cli httpcli.Doer) (*Key, error) {
+	if config.Token == "" && config.AppRole == nil {
+		return nil, errors.Errorf("either token or appRole must be set for vault key %q", config.Keyname)
+	}
+	address, err := url.Parse(config.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing vault address")
+	}
+	mountPath := strings.Trim(config.MountPath, "/")
+	if mountPath == "" {
+		mountPath = "transit"
+	}
+
+	k := &Key{
+		address:   address,
+		mountPath: mountPath,
+		name:      config.Keyname,
+		namespace: config.Namespace,
+		appRole:   config.AppRole,
+		token:     config.Token,
+		cli:       cli,
+	}
+	// Test client connection.
+	_, err = k.Version(ctx)
+	return k, err
+}
+
+// Key is an encryption.Key implementation that uses a key of the Transit
+// secrets engine of HashiCorp Vault. Vault includes the version of the key in
+// the ciphertext, so that values encrypted with older versions of a rotated key
+// can still be decrypted, and rewrapped with the latest version.
+type Key struct {
+	address   *url.URL
+	mountPath string
+	name      string
+	namespace string
+	appRole   *schema.VaultAppRole
+	cli       httpcli.Doer
+
+	mu sync.Mutex
+	// token is the Vault token, which is obtained by logging in with the
+	// AppRole if one is configured.
+	token string
+	// tokenExpiresAt is when the token obtained with the AppRole expires. It
+	// is zero if the token doesn't expire.
+	tokenExpiresAt time.Time
+}
+
+var _ encryption.Rewrapper = &Key{}
+
+func (k *Key) Version(ctx context.Context) (encryption.KeyVersion, error) {
+	var res struct {
+		Data struct {
+			Name          string `json:"name"`
+			LatestVersion int    `json:"latest_version"`
+		} `json:"data"`
+	}
+	if err := k.do(ctx, "GET", k.mountPath+"/keys/"+url.PathEscape(k.name), nil, &res); err != nil {
+		return encryption.KeyVersion{}, errors.Wrap(err, "getting key version")
+	}
+	return encryption.KeyVersion{
+		Type:    "vault",
+		Name:    res.Data.Name,
+		Version: strconv.Itoa(res.Data.LatestVersion),
+	}, nil
+}
+
+// Encrypt a secret with the latest version of the key. Encrypted secrets are
+// Vault ciphertexts, such as "vault:v1:...", which include the key version.
+func (k *Key) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	req := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}
+	var res struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	if err := k.do(ctx, "POST", k.mountPath+"/encrypt/"+url.PathEscape(k.name), req, &res); err != nil {
+		return nil, errors.Wrap(err, "encrypting")
+	}
+	return []byte(res.Data.Ciphertext), nil
+}
+
+// Decrypt a secret, it must have been encrypted with a version of the same Key
+// that Vault still allows decryption with.
+func (k *Key) Decrypt(ctx context.Context, cipherText []byte) (*encryption.Secret, error) {
+	req := map[string]string{"ciphertext": string(cipherText)}
+	var res struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	if err := k.do(ctx, "POST", k.mountPath+"/decrypt/"+url.PathEscape(k.name), req, &res); err != nil {
+		return nil, errors.Wrap(err, "decrypting")
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(res.Data.Plaintext)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding plaintext")
+	}
+	s := encryption.NewSecret(string(plaintext))
+	return &s, nil
+}
+
+// Rewrap re-encrypts a secret with the latest version of the key, without the
+// plaintext leaving Vault.
+func (k *Key) Rewrap(ctx context.Context, cipherText []byte) ([]byte, error) {
+	req := map[string]string{"ciphertext": string(cipherText)}
+	var res struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	if err := k.do(ctx, "POST", k.mountPath+"/rewrap/"+url.PathEscape(k.name), req, &res); err != nil {
+		return nil, errors.Wrap(err, "rewrapping")
+	}
+	return []byte(res.Data.Ciphertext), nil
+}
+
+// do sends a request to the given path of the Vault API and decodes the
+// response into result. If the token obtained with an AppRole was revoked, it
+// logs in again and retries once.
+func (k *Key) do(ctx context.Context, method, path string, body, result any) error {
+	token, err := k.getToken(ctx, false)
+	if err != nil {
+		return err
+	}
+	err = k.send(ctx, method, path, token, body, result)
+	if k.appRole != nil && isPermissionDenied(err) {
+		if token, err = k.getToken(ctx, true); err != nil {
+			return err
+		}
+		err = k.send(ctx, method, path, token, body, result)
+	}
+	return err
+}
+
+// getToken returns the token to authenticate requests with. If an AppRole is
+// configured, it logs in when the token expires, or if forceLogin is true.
+func (k *Key) getToken(ctx context.Context, forceLogin bool) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.appRole == nil {
+		return k.token, nil
+	}
+	// Log in again a bit before the token expires, so that it doesn't expire
+	// during a request.
+	expired := !k.tokenExpiresAt.IsZero() && time.Now().Add(10*time.Second).After(k.tokenExpiresAt)
+	if k.token != "" && !expired && !forceLogin {
+		return k.token, nil
+	}
+
+	mountPath := strings.Trim(k.appRole.MountPath, "/")
+	if mountPath == "" {
+		mountPath = "approle"
+	}
+	req := map[string]string{"role_id": k.appRole.RoleId, "secret_id": k.appRole.SecretId}
+	var res struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := k.send(ctx, "POST", "auth/"+mountPath+"/login", "", req, &res); err != nil {
+		return "", errors.Wrap(err, "logging in with AppRole")
+	}
+
+	k.token = res.Auth.ClientToken
+	k.tokenExpiresAt = time.Time{}
+	if res.Auth.LeaseDuration > 0 {
+		k.tokenExpiresAt = time.Now().Add(time.Duration(res.Auth.LeaseDuration) * time.Second)
+	}
+	return k.token, nil
+}
+
+func (k *Key) send(ctx context.Context, method, path, token string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(bs)
+	}
+
+	u := *k.address
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/v1/" + path
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if k.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", k.namespace)
+	}
+
+	resp, err := k.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Vault responds with a list of errors, which never include the
+		// secrets of the request.
+		var errRes struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(bs, &errRes)
+		return &apiError{StatusCode: resp.StatusCode, Errors: errRes.Errors}
+	}
+	return json.Unmarshal(bs, result)
+}
+
+// apiError is an error response of the Vault API.
+type apiError struct {
+	StatusCode int
+	Errors     []string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("vault responded with status %d: %s", e.StatusCode, strings.Join(e.Errors, "; "))
+}
+
+func isPermissionDenied(err error) bool {
+	var e *apiError
+	return errors.As(err, &e) && e.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,263 @@
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestKey(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeTransit(t, "")
+	srv.tokens["root"] = true
+
+	k, err := newKey(ctx, schema.VaultTransitEncryptionKey{
+		Type:    "vault",
+		Address: srv.URL,
+		Keyname: "sourcegraph",
+		Token:   "root",
+	}, http.DefaultClient)
+	require.NoError(t, err)
+
+	version, err := k.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, encryption.KeyVersion{Type: "vault", Name: "sourcegraph", Version: "1"}, version)
+
+	plaintext := "super secret"
+	ciphertext, err := k.Encrypt(ctx, []byte(plaintext))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(ciphertext), "vault:v1:"))
+
+	secret, err := k.Decrypt(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, secret.Secret())
+
+	// After the key is rotated, old ciphertexts can still be decrypted and
+	// rewrapped with the latest version.
+	srv.rotate()
+
+	version, err = k.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "2", version.Version)
+
+	rewrapped, err := k.Rewrap(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(rewrapped), "vault:v2:"))
+
+	for _, c := range [][]byte{ciphertext, rewrapped} {
+		secret, err = k.Decrypt(ctx, c)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, secret.Secret())
+	}
+}
+
+func TestKey_AppRole(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeTransit(t, "team-a")
+
+	k, err := newKey(ctx, schema.VaultTransitEncryptionKey{
+		Type:      "vault",
+		Address:   srv.URL,
+		Keyname:   "sourcegraph",
+		MountPath: "/secrets/transit/",
+		Namespace: "team-a",
+		AppRole: &schema.VaultAppRole{
+			RoleId:   "role",
+			SecretId: "secret",
+		},
+	}, http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, 1, srv.logins)
+
+	ciphertext, err := k.Encrypt(ctx, []byte("secret"))
+	require.NoError(t, err)
+	// The token is reused across requests.
+	assert.Equal(t, 1, srv.logins)
+
+	// If the token is revoked, we log in again.
+	srv.revokeTokens()
+	secret, err := k.Decrypt(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", secret.Secret())
+	assert.Equal(t, 2, srv.logins)
+}
+
+func TestNewKey_Errors(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeTransit(t, "")
+
+	t.Run("no auth", func(t *testing.T) {
+		_, err := newKey(ctx, schema.VaultTransitEncryptionKey{
+			Address: srv.URL,
+			Keyname: "sourcegraph",
+		}, http.DefaultClient)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := newKey(ctx, schema.VaultTransitEncryptionKey{
+			Address: srv.URL,
+			Keyname: "sourcegraph",
+			Token:   "invalid",
+		}, http.DefaultClient)
+		require.Error(t, err)
+		assert.True(t, isPermissionDenied(err))
+	})
+
+	t.Run("invalid AppRole", func(t *testing.T) {
+		_, err := newKey(ctx, schema.VaultTransitEncryptionKey{
+			Address: srv.URL,
+			Keyname: "sourcegraph",
+			AppRole: &schema.VaultAppRole{RoleId: "role", SecretId: "wrong"},
+		}, http.DefaultClient)
+		require.Error(t, err)
+	})
+}
+
+// fakeTransit is an HTTP server that implements the parts of the Vault API that
+// Key uses. Its ciphertexts are not encrypted, but include the key version like
+// the ones of Vault.
+type fakeTransit struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	namespace string
+	version   int
+	tokens    map[string]bool
+	logins    int
+}
+
+func newFakeTransit(t *testing.T, namespace string) *fakeTransit {
+	f := &fakeTransit{
+		namespace: namespace,
+		version:   1,
+		tokens:    map[string]bool{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeTransit) rotate() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.version++
+}
+
+func (f *fakeTransit) revokeTokens() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens = map[string]bool{}
+}
+
+func (f *fakeTransit) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("X-Vault-Namespace") != f.namespace {
+		writeError(w, http.StatusNotFound, "unknown namespace")
+		return
+	}
+
+	var req map[string]string
+	if r.Method == "POST" {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	if path == "auth/approle/login" {
+		if req["role_id"] != "role" || req["secret_id"] != "secret" {
+			writeError(w, http.StatusBadRequest, "invalid role or secret ID")
+			return
+		}
+		f.logins++
+		token := fmt.Sprintf("approle-token-%d", f.logins)
+		f.tokens[token] = true
+		writeJSON(w, map[string]any{"auth": map[string]any{"client_token": token, "lease_duration": 3600}})
+		return
+	}
+
+	if !f.tokens[r.Header.Get("X-Vault-Token")] {
+		writeError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	// Keys are mounted at /transit in the default namespace, and at
+	// /secrets/transit in others.
+	mountPath := "transit/"
+	if f.namespace != "" {
+		mountPath = "secrets/transit/"
+	}
+	op, name, ok := strings.Cut(strings.TrimPrefix(path, mountPath), "/")
+	if !strings.HasPrefix(path, mountPath) || !ok || name != "sourcegraph" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch op {
+	case "keys":
+		writeJSON(w, map[string]any{"data": map[string]any{"name": name, "latest_version": f.version}})
+	case "encrypt":
+		writeJSON(w, map[string]any{"data": map[string]any{"ciphertext": f.ciphertext(req["plaintext"])}})
+	case "decrypt", "rewrap":
+		plaintext, ok := f.plaintext(req["ciphertext"])
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid ciphertext")
+			return
+		}
+		if op == "decrypt" {
+			writeJSON(w, map[string]any{"data": map[string]any{"plaintext": plaintext}})
+		} else {
+			writeJSON(w, map[string]any{"data": map[string]any{"ciphertext": f.ciphertext(plaintext)}})
+		}
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (f *fakeTransit) ciphertext(plaintext string) string {
+	return fmt.Sprintf("vault:v%d:%s", f.version, base64.StdEncoding.EncodeToString([]byte(plaintext)))
+}
+
+func (f *fakeTransit) plaintext(ciphertext string) (string, bool) {
+	parts := strings.SplitN(ciphertext, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return "", false
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(parts[1], "v"))
+	if err != nil || version < 1 || version > f.version {
+		return "", false
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", false
+	}
+	return string(plaintext), true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{msg}})
+}
@@ -0,0 +1,180 @@
+//nolint:bodyclose // Body is closed in Client.Do, but the response is still returned to provide access to the headers
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// apiVersion is the version of the Azure DevOps REST API that this client targets.
+const apiVersion = "7.0"
+
+// Client access Azure DevOps via the REST API.
+type Client struct {
+	// Config is the code host connection config for this client
+	Config *schema.AzureDevOpsConnection
+
+	// URL is the base URL of Azure DevOps.
+	URL *url.URL
+
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// RateLimit is the self-imposed rate limiter (since Azure DevOps throttles based on
+	// resource consumption rather than a fixed number of requests).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an authenticated Azure DevOps API client with
+// the provided configuration. If a nil httpClient is provided, http.DefaultClient
+// will be used.
+func NewClient(urn string, config *schema.AzureDevOpsConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		Config:     config,
+		URL:        u,
+		httpClient: httpClient,
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// ListRepositoriesByProjectOrOrgArgs defines options to be set on
+// ListRepositoriesByProjectOrOrg method calls.
+type ListRepositoriesByProjectOrOrgArgs struct {
+	// ProjectOrOrgName is either the name of an organization ("org") or the
+	// name of a project qualified by its organization ("org/project").
+	ProjectOrOrgName string
+}
+
+// ListRepositoriesResponse defines a response struct returned from
+// ListRepositoriesByProjectOrOrg method calls.
+type ListRepositoriesResponse struct {
+	Value []*Repository `json:"value"`
+	Count int           `json:"count"`
+}
+
+// ListRepositoriesByProjectOrOrg returns all Git repositories of the given
+// organization or project. The Azure DevOps API does not paginate this
+// endpoint, so all repositories are returned in a single response.
+func (c *Client) ListRepositoriesByProjectOrOrg(ctx context.Context, opts ListRepositoriesByProjectOrOrgArgs) ([]*Repository, error) {
+	qs := make(url.Values)
+	qs.Set("api-version", apiVersion)
+
+	u := url.URL{
+		Path:     path.Join(opts.ProjectOrOrgName, "_apis/git/repositories"),
+		RawQuery: qs.Encode(),
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListRepositoriesResponse
+	if _, err = c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Value, nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+	if req.Header.Get("Content-Type") == "" && req.Method != "GET" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.Config.Token != "" {
+		req.SetBasicAuth(c.Config.Username, c.Config.Token)
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Azure DevOps answers requests with invalid credentials with a 203 and
+	// an HTML sign-in page instead of a 401, so we treat it as an error too.
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+// Repository is a Git repository hosted on Azure DevOps.
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	URL           string  `json:"url"`
+	Project       Project `json:"project"`
+	DefaultBranch string  `json:"defaultBranch,omitempty"`
+	Size          int     `json:"size"`
+	RemoteURL     string  `json:"remoteUrl"`
+	SSHURL        string  `json:"sshUrl"`
+	WebURL        string  `json:"webUrl"`
+	IsDisabled    bool    `json:"isDisabled"`
+	IsFork        bool    `json:"isFork"`
+}
+
+// Project is an Azure DevOps project, which groups repositories within an
+// organization.
+type Project struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	State      string `json:"state"`
+	Revision   int    `json:"revision"`
+	Visibility string `json:"visibility"`
+	URL        string `json:"url"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusNonAuthoritativeInfo
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
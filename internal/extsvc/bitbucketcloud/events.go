@@ -33,6 +33,8 @@ func ParseWebhookEvent(eventKey string, payload []byte) (any, error) {
 		target = &RepoCommitStatusCreatedEvent{}
 	case "repo:commit_status_updated":
 		target = &RepoCommitStatusUpdatedEvent{}
+	case "repo:push":
+		target = &PushEvent{}
 	default:
 		return nil, UnknownWebhookEventKey(eventKey)
 	}
@@ -132,6 +134,31 @@ type RepoCommitStatusUpdatedEvent struct {
 	RepoCommitStatusEvent
 }
 
+type PushEvent struct {
+	RepoEvent
+	Push Push `json:"push"`
+}
+
+type Push struct {
+	Changes []PushChange `json:"changes"`
+}
+
+// PushChange is a change of a branch or tag. New is nil if the branch or tag
+// was deleted, and Old is nil if it was created.
+type PushChange struct {
+	New     *PushChangeRef `json:"new"`
+	Old     *PushChangeRef `json:"old"`
+	Created bool           `json:"created"`
+	Closed  bool           `json:"closed"`
+	Forced  bool           `json:"forced"`
+}
+
+type PushChangeRef struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target Commit `json:"target"`
+}
+
 type CommitStatus struct {
 	Name        string                 `json:"name"`
 	Description string                 `json:"description"`
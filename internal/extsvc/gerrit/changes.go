@@ -0,0 +1,303 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ChangeStatus is the status of a Gerrit change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Change is a Gerrit change (a ChangeInfo entity), as returned with the
+// options in changeOptions.
+type Change struct {
+	// ID is the triplet that identifies the change, in the form
+	// "<project>~<branch>~<Change-Id>".
+	ID string `json:"id"`
+	// Number is the legacy numeric ID of the change, which is unique within
+	// the Gerrit instance.
+	Number   int          `json:"_number"`
+	Project  string       `json:"project"`
+	Branch   string       `json:"branch"`
+	Topic    string       `json:"topic,omitempty"`
+	ChangeID string       `json:"change_id"`
+	Subject  string       `json:"subject"`
+	Status   ChangeStatus `json:"status"`
+	Created  Timestamp    `json:"created"`
+	Updated  Timestamp    `json:"updated"`
+	Owner    Account      `json:"owner"`
+
+	WorkInProgress bool `json:"work_in_progress,omitempty"`
+
+	// Labels are the votes on the change, keyed by label name, such as
+	// "Code-Review" and "Verified".
+	Labels map[string]LabelInfo `json:"labels,omitempty"`
+
+	CurrentRevision string                  `json:"current_revision"`
+	Revisions       map[string]RevisionInfo `json:"revisions,omitempty"`
+
+	// URL is the web URL of the change. It is not part of the Gerrit API, but
+	// set by the client.
+	URL string `json:"sourcegraph_url,omitempty"`
+}
+
+// Labels that are configured on Gerrit projects by default.
+const (
+	LabelCodeReview = "Code-Review"
+	LabelVerified   = "Verified"
+)
+
+// Description returns the commit message of the current patch set of the
+// change without its subject and footers, such as Change-Id.
+func (c *Change) Description() string {
+	rev := c.CurrentRevisionInfo()
+	if rev == nil {
+		return ""
+	}
+
+	paragraphs := strings.Split(strings.TrimSpace(rev.Commit.Message), "\n\n")
+	if len(paragraphs) < 2 {
+		return ""
+	}
+	paragraphs = paragraphs[1:]
+	if last := paragraphs[len(paragraphs)-1]; isFooter(last) {
+		paragraphs = paragraphs[:len(paragraphs)-1]
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// isFooter returns true if every line of the given paragraph is a footer, such
+// as "Change-Id: I8473b95934b5732ac55d26311a706c9c2bde9940".
+func isFooter(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		key, _, ok := strings.Cut(line, ": ")
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return false
+		}
+	}
+	return true
+}
+
+// CurrentRevisionInfo returns the current patch set of the change, or nil if
+// the change was not requested with its current revision.
+func (c *Change) CurrentRevisionInfo() *RevisionInfo {
+	if rev, ok := c.Revisions[c.CurrentRevision]; ok {
+		return &rev
+	}
+	return nil
+}
+
+// LabelInfo contains the votes on a label of a change. Approved, Rejected,
+// Recommended and Disliked are set to one of the accounts that voted with the
+// maximum, minimum, positive and negative values respectively.
+type LabelInfo struct {
+	Approved    *Account       `json:"approved,omitempty"`
+	Rejected    *Account       `json:"rejected,omitempty"`
+	Recommended *Account       `json:"recommended,omitempty"`
+	Disliked    *Account       `json:"disliked,omitempty"`
+	Blocking    bool           `json:"blocking,omitempty"`
+	All         []ApprovalInfo `json:"all,omitempty"`
+}
+
+// ApprovalInfo is a vote of an account on a label.
+type ApprovalInfo struct {
+	Account
+	Value int        `json:"value"`
+	Date  *Timestamp `json:"date,omitempty"`
+}
+
+// RevisionInfo is a patch set of a change.
+type RevisionInfo struct {
+	Number int        `json:"_number"`
+	Ref    string     `json:"ref"`
+	Commit CommitInfo `json:"commit"`
+}
+
+// CommitInfo is a commit of a patch set.
+type CommitInfo struct {
+	Commit  string `json:"commit"`
+	Parents []struct {
+		Commit  string `json:"commit"`
+		Subject string `json:"subject"`
+	} `json:"parents"`
+	Author  GitPerson `json:"author"`
+	Subject string    `json:"subject"`
+	Message string    `json:"message"`
+}
+
+// GitPerson is the author or committer of a commit.
+type GitPerson struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  Timestamp `json:"date"`
+}
+
+// timestampLayout is the format of timestamps in the Gerrit API, which are
+// always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a timestamp in the format used by the Gerrit API.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	parsed, err := time.ParseInLocation(timestampLayout, s, time.UTC)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// changeOptions are the options with which changes are requested, so that
+// they include everything that batch changes needs.
+var changeOptions = []string{"CURRENT_REVISION", "CURRENT_COMMIT", "DETAILED_LABELS", "DETAILED_ACCOUNTS"}
+
+// GetChange returns the change with the given ID, which can be the numeric ID,
+// the Change-Id or the "<project>~<branch>~<Change-Id>" triplet. The ID is
+// escaped, so the triplet must not be URL-encoded.
+func (c *Client) GetChange(ctx context.Context, id string) (*Change, error) {
+	u := "a/changes/" + url.PathEscape(id) + "?" + url.Values{"o": changeOptions}.Encode()
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err := c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	c.setChangeURL(&change)
+	return &change, nil
+}
+
+// QueryChanges returns the changes that match the given query, such as
+// "change:I8473b95934b5732ac55d26311a706c9c2bde9940 project:foo".
+func (c *Client) QueryChanges(ctx context.Context, query string) ([]*Change, error) {
+	u := "a/changes/?" + url.Values{"q": {query}, "o": changeOptions}.Encode()
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*Change
+	if _, err := c.do(ctx, req, &changes); err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		c.setChangeURL(change)
+	}
+	return changes, nil
+}
+
+// AbandonChange abandons a change.
+func (c *Client) AbandonChange(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "abandon", struct{}{})
+}
+
+// RestoreChange restores an abandoned change.
+func (c *Client) RestoreChange(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "restore", struct{}{})
+}
+
+// SetWorkInProgress marks a change as work in progress, which is Gerrit's
+// equivalent of a draft.
+func (c *Client) SetWorkInProgress(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "wip", struct{}{})
+}
+
+// SetReadyForReview marks a work in progress change as ready for review.
+func (c *Client) SetReadyForReview(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "ready", struct{}{})
+}
+
+// MoveChange moves a change to another destination branch.
+func (c *Client) MoveChange(ctx context.Context, id, branch string) error {
+	return c.postChange(ctx, id, "move", map[string]string{"destination_branch": branch})
+}
+
+// SubmitChange submits (merges) a change. Gerrit responds with 409 Conflict
+// if the change cannot be submitted.
+func (c *Client) SubmitChange(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "submit", struct{}{})
+}
+
+// SetCommitMessage creates a new patch set of the change with the given
+// commit message. The message must contain the Change-Id footer of the change.
+func (c *Client) SetCommitMessage(ctx context.Context, id, message string) error {
+	return c.sendChange(ctx, "PUT", id, "message", map[string]string{"message": message})
+}
+
+// PostReviewComment posts a comment on the current patch set of a change.
+func (c *Client) PostReviewComment(ctx context.Context, id, message string) error {
+	return c.postChange(ctx, id, "revisions/current/review", map[string]string{"message": message})
+}
+
+// GetAuthenticatedAccount returns the account the client is authenticated as.
+func (c *Client) GetAuthenticatedAccount(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if _, err := c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (c *Client) postChange(ctx context.Context, id, action string, body any) error {
+	return c.sendChange(ctx, "POST", id, action, body)
+}
+
+// sendChange sends a request to an endpoint of a change. The responses are
+// ignored, since they don't include everything in changeOptions; callers
+// should get the change again instead.
+func (c *Client) sendChange(ctx context.Context, method, id, action string, body any) error {
+	bs, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	u := fmt.Sprintf("a/changes/%s/%s", url.PathEscape(id), action)
+	req, err := http.NewRequest(method, u, bytes.NewReader(bs))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+func (c *Client) setChangeURL(change *Change) {
+	u := *c.URL
+	u.User = nil
+	u.Path = strings.TrimSuffix(u.Path, "/") + fmt.Sprintf("/c/%s/+/%d", change.Project, change.Number)
+	change.URL = u.String()
+}
@@ -0,0 +1,206 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const testChange = `)]}'
+{
+  "id": "sourcegraph~main~I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "project": "sourcegraph",
+  "branch": "main",
+  "topic": "batch-change",
+  "change_id": "I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "subject": "Update README",
+  "status": "NEW",
+  "created": "2022-09-20 10:15:30.000000000",
+  "updated": "2022-09-21 08:00:00.123000000",
+  "_number": 42,
+  "owner": {"_account_id": 1000096, "name": "Alice", "email": "alice@example.com", "username": "alice"},
+  "work_in_progress": true,
+  "labels": {
+    "Code-Review": {"approved": {"_account_id": 1000097}, "all": [{"_account_id": 1000097, "value": 2}]}
+  },
+  "current_revision": "27cc4558b5a3d3387dd11ee2df7a117e7e581822",
+  "revisions": {
+    "27cc4558b5a3d3387dd11ee2df7a117e7e581822": {
+      "_number": 2,
+      "ref": "refs/changes/42/42/2",
+      "commit": {
+        "commit": "27cc4558b5a3d3387dd11ee2df7a117e7e581822",
+        "parents": [{"commit": "1eee2c9d8f352483781e772f35dc586a69ff5646", "subject": "Initial commit"}],
+        "subject": "Update README",
+        "message": "Update README\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n"
+      }
+    }
+  }
+}`
+
+func newTestServerClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("urn", &schema.GerritConnection{Url: srv.URL, Username: "alice", Password: "secret"}, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
+
+func TestClient_GetChange(t *testing.T) {
+	cli := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "alice" || p != "secret" {
+			t.Errorf("unexpected credentials %q:%q", u, p)
+		}
+		if want := "/a/changes/sourcegraph~main~I8473b95934b5732ac55d26311a706c9c2bde9940"; r.URL.Path != want {
+			t.Errorf("got path %q, want %q", r.URL.Path, want)
+		}
+		if got := r.URL.Query()["o"]; len(got) != len(changeOptions) {
+			t.Errorf("got options %v, want %v", got, changeOptions)
+		}
+		_, _ = io.WriteString(w, testChange)
+	})
+
+	change, err := cli.GetChange(context.Background(), "sourcegraph~main~I8473b95934b5732ac55d26311a706c9c2bde9940")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if change.Number != 42 || change.Status != ChangeStatusNew || !change.WorkInProgress {
+		t.Errorf("unexpected change %+v", change)
+	}
+	if want := time.Date(2022, 9, 21, 8, 0, 0, 123000000, time.UTC); !change.Updated.Equal(want) {
+		t.Errorf("got updated %s, want %s", change.Updated, want)
+	}
+	if change.Labels["Code-Review"].Approved == nil {
+		t.Error("expected Code-Review label to be approved")
+	}
+	rev := change.CurrentRevisionInfo()
+	if rev == nil || rev.Number != 2 || rev.Commit.Parents[0].Commit != "1eee2c9d8f352483781e772f35dc586a69ff5646" {
+		t.Errorf("unexpected current revision %+v", rev)
+	}
+	if want := cli.URL.String() + "/c/sourcegraph/+/42"; change.URL != want {
+		t.Errorf("got URL %q, want %q", change.URL, want)
+	}
+}
+
+func TestClient_GetChange_NotFound(t *testing.T) {
+	cli := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not found: 42", http.StatusNotFound)
+	})
+
+	_, err := cli.GetChange(context.Background(), "42")
+	if !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+}
+
+func TestClient_ChangeActions(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		call       func(*Client) error
+		wantMethod string
+		wantPath   string
+		wantBody   map[string]string
+	}{
+		{
+			name:       "abandon",
+			call:       func(c *Client) error { return c.AbandonChange(context.Background(), "42") },
+			wantMethod: "POST",
+			wantPath:   "/a/changes/42/abandon",
+		},
+		{
+			name:       "abandon by triplet",
+			call:       func(c *Client) error { return c.AbandonChange(context.Background(), "sourcegraph/about~main~I1") },
+			wantMethod: "POST",
+			wantPath:   "/a/changes/sourcegraph%2Fabout~main~I1/abandon",
+		},
+		{
+			name:       "ready",
+			call:       func(c *Client) error { return c.SetReadyForReview(context.Background(), "42") },
+			wantMethod: "POST",
+			wantPath:   "/a/changes/42/ready",
+		},
+		{
+			name:       "move",
+			call:       func(c *Client) error { return c.MoveChange(context.Background(), "42", "release") },
+			wantMethod: "POST",
+			wantPath:   "/a/changes/42/move",
+			wantBody:   map[string]string{"destination_branch": "release"},
+		},
+		{
+			name:       "message",
+			call:       func(c *Client) error { return c.SetCommitMessage(context.Background(), "42", "Title\n\nChange-Id: I1") },
+			wantMethod: "PUT",
+			wantPath:   "/a/changes/42/message",
+			wantBody:   map[string]string{"message": "Title\n\nChange-Id: I1"},
+		},
+		{
+			name:       "comment",
+			call:       func(c *Client) error { return c.PostReviewComment(context.Background(), "42", "LGTM") },
+			wantMethod: "POST",
+			wantPath:   "/a/changes/42/revisions/current/review",
+			wantBody:   map[string]string{"message": "LGTM"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tc.wantMethod || r.URL.EscapedPath() != tc.wantPath {
+					t.Errorf("got %s %s, want %s %s", r.Method, r.URL.EscapedPath(), tc.wantMethod, tc.wantPath)
+				}
+				if ct := r.Header.Get("Content-Type"); ct != "application/json; charset=UTF-8" {
+					t.Errorf("got content type %q", ct)
+				}
+				var body map[string]string
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				for k, v := range tc.wantBody {
+					if body[k] != v {
+						t.Errorf("got body %v, want %v", body, tc.wantBody)
+					}
+				}
+				// Some actions respond without a body.
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			if err := tc.call(cli); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestChange_Description(t *testing.T) {
+	for name, tc := range map[string]struct {
+		message string
+		want    string
+	}{
+		"subject only":   {message: "Update README\n\nChange-Id: I1\n", want: ""},
+		"body":           {message: "Update README\n\nThis is a body.\n\nWith two paragraphs.\n\nChange-Id: I1\nSigned-off-by: Alice <alice@example.com>\n", want: "This is a body.\n\nWith two paragraphs."},
+		"no footers":     {message: "Update README\n\nThis is a body.", want: "This is a body."},
+		"colon in body":  {message: "Update README\n\nNote: this is not a footer, really.\n\nChange-Id: I1", want: "Note: this is not a footer, really."},
+		"no commit info": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			change := &Change{CurrentRevision: "abc", Revisions: map[string]RevisionInfo{}}
+			if tc.message != "" {
+				change.Revisions["abc"] = RevisionInfo{Commit: CommitInfo{Message: tc.message}}
+			}
+			if got := change.Description(); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -45,6 +45,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 const git = "git"
@@ -78,6 +79,7 @@ func NewClient(db database.DB) Client {
 			return conf.Get().ServiceConnections().GitServers
 		},
 		pinned:      pinnedReposFromConfig,
+		replication: replicationFromConfig,
 		db:          db,
 		httpClient:  defaultDoer,
 		HTTPLimiter: defaultLimiter,
@@ -98,6 +100,7 @@ func NewTestClient(cli httpcli.Doer, db database.DB, addrs []string) Client {
 			return addrs
 		},
 		pinned:      pinnedReposFromConfig,
+		replication: replicationFromConfig,
 		httpClient:  cli,
 		HTTPLimiter: parallel.NewRun(500),
 		// Use the binary name for userAgent. This should effectively identify
@@ -134,6 +137,11 @@ type clientImplementor struct {
 	// and sync the pinned map.
 	pinned func() map[string]string
 
+	// replication returns the configuration of the repositories that are
+	// replicated to additional gitserver instances. Like pinned, it should
+	// query the conf each time it is called.
+	replication func() *schema.GitServerReplication
+
 	// db is a connection to the database
 	db database.DB
 
@@ -227,6 +235,9 @@ type Client interface {
 	// returned map and will be appended to the error. If no errors occur err will
 	// be nil.
 	//
+	// The statistics include the number of replicas on each gitserver and how
+	// many of them are stale.
+	//
 	// Note: If the statistics for a gitserver have not been computed, the
 	// UpdatedAt field will be zero. This can happen for new gitservers.
 	ReposStats(context.Context) (map[string]*protocol.ReposStats, error)
@@ -428,6 +439,9 @@ func AddrForRepo(ctx context.Context, userAgent string, db database.DB, repo api
 type GitServerAddresses struct {
 	Addresses     []string
 	PinnedServers map[string]string
+	// Replication configures the repositories that are replicated to
+	// additional gitserver instances. It may be nil.
+	Replication *schema.GitServerReplication
 }
 
 // RendezvousAddrForRepo returns the gitserver address to use for the given repo name using the
@@ -596,8 +610,8 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 		return false, err
 	}
 
-	uri := "http://" + addrForRepo + "/search"
-	resp, err := c.do(ctx, repoName, "POST", uri, buf.Bytes())
+	u := &url.URL{Scheme: "http", Host: addrForRepo, Path: "/search"}
+	resp, err := c.doWithFailover(ctx, repoName, "POST", u, buf.Bytes())
 	if err != nil {
 		return false, err
 	}
@@ -837,7 +851,7 @@ func (c *clientImplementor) gitCommand(repo api.RepoName, arg ...string) GitComm
 	}
 	return &RemoteGitCommand{
 		repo:   repo,
-		execFn: c.httpPost,
+		execFn: c.httpPostWithFailover,
 		args:   append([]string{git}, arg...),
 	}
 }
@@ -847,7 +861,46 @@ func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.Repo
 		Repo:  repo,
 		Since: since,
 	}
-	resp, err := c.httpPost(ctx, repo, "repo-update", req)
+
+	addrForRepo, err := c.AddrForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replicas are updated together with the gitserver instance the repo is
+	// assigned to, so that they are as up to date when reads fail over to
+	// them. Replicas that are not cloned yet are cloned by the update. We
+	// don't wait for the replicas, so that a slow or unreachable replica does
+	// not delay the update of the repo.
+	for _, addr := range c.replicaAddrsForRepo(repo, addrForRepo) {
+		go c.requestReplicaUpdate(addr, req)
+	}
+
+	return c.requestRepoUpdateFrom(ctx, addrForRepo, req)
+}
+
+// requestReplicaUpdate requests that the replica at addr updates the repo of
+// req, and logs if it fails. It does not use the context of the caller, which
+// can be canceled as soon as the repo is updated on its gitserver instance.
+func (c *clientImplementor) requestReplicaUpdate(addr string, req *protocol.RepoUpdateRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), replicaUpdateTimeout)
+	defer cancel()
+
+	info, err := c.requestRepoUpdateFrom(ctx, addr, req)
+	if err == nil && info != nil && info.Error != "" {
+		err = errors.New(info.Error)
+	}
+	if err != nil {
+		c.logger.Warn("updating replica",
+			sglog.String("repo", string(req.Repo)),
+			sglog.String("replica", addr),
+			sglog.Error(err),
+		)
+	}
+}
+
+func (c *clientImplementor) requestRepoUpdateFrom(ctx context.Context, addr string, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
+	resp, err := c.httpPostWithURI(ctx, req.Repo, "http://"+addr+"/repo-update", req)
 	if err != nil {
 		return nil, err
 	}
@@ -1232,7 +1285,7 @@ func (c *clientImplementor) GetObject(ctx context.Context, repo api.RepoName, ob
 		Repo:       repo,
 		ObjectName: objectName,
 	}
-	resp, err := c.httpPost(ctx, req.Repo, "commands/get-object", req)
+	resp, err := c.httpPostWithFailover(ctx, req.Repo, "commands/get-object", req)
 	if err != nil {
 		return nil, err
 	}
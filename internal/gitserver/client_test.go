@@ -17,6 +17,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -631,6 +632,144 @@ func TestClient_AddrForRepo_Rendezvous(t *testing.T) {
 	}
 }
 
+func TestReplicaAddrsForRepo(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3", "gitserver-4"}
+	replication := &schema.GitServerReplication{
+		Repos:             []string{"^github\\.com/foo/"},
+		ReplicationFactor: 2,
+	}
+	addresses := gitserver.GitServerAddresses{Addresses: addrs, Replication: replication}
+
+	require.Empty(t, gitserver.ReplicaAddrsForRepo("github.com/bar/baz", "gitserver-1", addresses))
+	require.Empty(t, gitserver.ReplicaAddrsForRepo("github.com/foo/bar", "gitserver-1", gitserver.GitServerAddresses{Addresses: addrs}))
+
+	replicas := gitserver.ReplicaAddrsForRepo("github.com/foo/bar", "gitserver-1", addresses)
+	require.Len(t, replicas, 2)
+	require.NotEqual(t, replicas[0], replicas[1])
+	require.NotContains(t, replicas, "gitserver-1")
+	require.Equal(t, replicas, gitserver.ReplicaAddrsForRepo("github.com/foo/bar.git", "gitserver-1", addresses))
+
+	// Removing a gitserver that holds no replica does not move the replicas.
+	var remaining []string
+	for _, addr := range addrs {
+		if addr == "gitserver-1" || addr == replicas[0] || addr == replicas[1] {
+			remaining = append(remaining, addr)
+		}
+	}
+	require.Equal(t, replicas, gitserver.ReplicaAddrsForRepo("github.com/foo/bar", "gitserver-1", gitserver.GitServerAddresses{
+		Addresses:   remaining,
+		Replication: replication,
+	}))
+
+	// There can't be more replicas than other gitservers.
+	replication.ReplicationFactor = 5
+	require.ElementsMatch(t, []string{"gitserver-2", "gitserver-3", "gitserver-4"}, gitserver.ReplicaAddrsForRepo("github.com/foo/bar", "gitserver-1", addresses))
+}
+
+func TestClient_ReplicaFailover(t *testing.T) {
+	ctx := context.Background()
+	repo := api.RepoName("github.com/foo/bar")
+	addrs := []string{"172.16.8.1:8080", "172.16.8.2:8080", "172.16.8.3:8080"}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			GitServerReplication: &schema.GitServerReplication{
+				Repos:             []string{"^github\\.com/foo/"},
+				ReplicationFactor: 1,
+			},
+		},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	var (
+		mu       sync.Mutex
+		requests []string
+		down     string
+		hang     string
+	)
+	cli := gitserver.NewTestClient(
+		httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requests = append(requests, r.URL.Host+r.URL.Path)
+			if r.URL.Host == hang {
+				mu.Unlock()
+				<-r.Context().Done()
+				return nil, r.Context().Err()
+			}
+			defer mu.Unlock()
+			if r.URL.Host == down {
+				return nil, errors.New("connection refused")
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString("{}")),
+			}, nil
+		}),
+		newMockDB(),
+		addrs,
+	)
+
+	primary, err := cli.AddrForRepo(ctx, repo)
+	require.NoError(t, err)
+	replicas := gitserver.ReplicaAddrsForRepo(repo, primary, gitserver.GitServerAddresses{
+		Addresses:   addrs,
+		Replication: conf.Get().ExperimentalFeatures.GitServerReplication,
+	})
+	require.Len(t, replicas, 1)
+	replica := replicas[0]
+
+	// Updates are sent to the primary and, in the background, the replica.
+	requestedUpdates := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(requests) == 2
+	}
+	_, err = cli.RequestRepoUpdate(ctx, repo, 0)
+	require.NoError(t, err)
+	require.Eventually(t, requestedUpdates, time.Second, 10*time.Millisecond)
+	require.ElementsMatch(t, []string{primary + "/repo-update", replica + "/repo-update"}, requests)
+
+	// Updates don't wait for replicas that hang.
+	requests = nil
+	hang = replica
+	_, err = cli.RequestRepoUpdate(ctx, repo, 0)
+	require.NoError(t, err)
+	require.Eventually(t, requestedUpdates, time.Second, 10*time.Millisecond)
+	require.ElementsMatch(t, []string{primary + "/repo-update", replica + "/repo-update"}, requests)
+
+	// Reads go to the primary while it is available.
+	mu.Lock()
+	requests = nil
+	hang = ""
+	mu.Unlock()
+	_, err = cli.GetObject(ctx, repo, "HEAD")
+	require.NoError(t, err)
+	require.Equal(t, []string{primary + "/commands/get-object"}, requests)
+
+	// Reads fail over to the replica when the primary is unavailable.
+	requests = nil
+	down = primary
+	_, err = cli.GetObject(ctx, repo, "HEAD")
+	require.NoError(t, err)
+	require.Equal(t, []string{primary + "/commands/get-object", replica + "/commands/get-object"}, requests)
+
+	// Subsequent reads go to the replica first.
+	requests = nil
+	_, err = cli.GetObject(ctx, repo, "HEAD")
+	require.NoError(t, err)
+	require.Equal(t, []string{replica + "/commands/get-object"}, requests)
+
+	// Reads of repositories that are not replicated don't fail over.
+	requests = nil
+	other := api.RepoName("github.com/bar/baz")
+	otherPrimary, err := cli.AddrForRepo(ctx, other)
+	require.NoError(t, err)
+	down = otherPrimary
+	_, err = cli.GetObject(ctx, other, "HEAD")
+	require.Error(t, err)
+	require.Equal(t, []string{otherPrimary + "/commands/get-object"}, requests)
+}
+
 func TestClient_BatchLog(t *testing.T) {
 	addrs := []string{"172.16.8.1:8080", "172.16.8.2:8080", "172.16.8.3:8080"}
 
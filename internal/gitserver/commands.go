@@ -2391,7 +2391,7 @@ func (c *clientImplementor) ArchiveReader(
 		return nil, err
 	}
 
-	resp, err := c.do(ctx, repo, "POST", u.String(), nil)
+	resp, err := c.doWithFailover(ctx, repo, "POST", u, nil)
 	if err != nil {
 		return nil, err
 	}
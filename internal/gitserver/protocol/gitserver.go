@@ -224,6 +224,18 @@ type ReposStats struct {
 
 	// GitDirBytes is the amount of bytes stored in .git directories.
 	GitDirBytes int64
+
+	// ReplicaRepos is the number of repositories on the gitserver that are
+	// replicas of repositories assigned to other gitservers.
+	ReplicaRepos int64
+
+	// ReplicaGitDirBytes is the amount of bytes of GitDirBytes stored in
+	// replicas.
+	ReplicaGitDirBytes int64
+
+	// StaleReplicaRepos is the number of replicas that were not fetched when
+	// the gitserver the repository is assigned to last fetched it.
+	StaleReplicaRepos int64
 }
 
 // RepoCloneProgressRequest is a request for information about the clone progress of multiple
@@ -294,6 +306,12 @@ type PushConfig struct {
 	// Passphrase is the passphrase to decrypt the private key. It is required
 	// when passing PrivateKey.
 	Passphrase string
+
+	// PushRef, if set, is the ref on the remote to which the commit is pushed
+	// instead of the target ref. Code hosts like Gerrit expect changes to be
+	// pushed to a magic ref, such as refs/for/main. The target ref is still
+	// created in the local repository.
+	PushRef string
 }
 
 // CreateCommitFromPatchResponse is the response type returned after creating
@@ -0,0 +1,235 @@
+package gitserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/grafana/regexp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/go-rendezvous"
+
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// unavailableTTL is how long a gitserver instance that failed to respond is
+// skipped when reading replicated repositories.
+const unavailableTTL = 10 * time.Second
+
+// replicaUpdateTimeout is how long to wait for a replica to update a repository.
+// The replica keeps updating the repository after the timeout, it is only an
+// upper bound on the requests to replicas that hang.
+const replicaUpdateTimeout = 5 * time.Minute
+
+var replicaFailoverCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_gitserver_client_replica_failover_total",
+	Help: "Number of requests for replicated repositories that were sent to a replica because the gitserver the repository is assigned to was unavailable",
+})
+
+// IsReplicated returns true if the repo is replicated to additional gitserver
+// instances according to the given replication configuration.
+func IsReplicated(repo api.RepoName, replication *schema.GitServerReplication) bool {
+	if replication == nil {
+		return false
+	}
+	for _, pattern := range replication.Repos {
+		// Invalid patterns are rejected by the validation of the site
+		// configuration.
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(string(repo)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplicaAddrsForRepo returns the addresses of the gitserver instances that
+// hold replicas of repo, which is assigned to the gitserver instance at
+// primary. Replicas are chosen with rendezvous hashing among the other
+// gitserver instances, so that adding or removing an instance only moves few
+// replicas. It returns nil if repo is not replicated.
+func ReplicaAddrsForRepo(repo api.RepoName, primary string, addresses GitServerAddresses) []string {
+	repo = protocol.NormalizeRepo(repo)
+	if !IsReplicated(repo, addresses.Replication) {
+		return nil
+	}
+
+	candidates := make([]string, 0, len(addresses.Addresses))
+	for _, addr := range addresses.Addresses {
+		if addr != primary {
+			candidates = append(candidates, addr)
+		}
+	}
+
+	n := addresses.Replication.ReplicationFactor
+	if n <= 0 {
+		n = 1
+	}
+	replicas := make([]string, 0, n)
+	for len(replicas) < n && len(candidates) > 0 {
+		replica := rendezvous.New(candidates, xxhash.Sum64String).Lookup(string(repo))
+		replicas = append(replicas, replica)
+		for i, addr := range candidates {
+			if addr == replica {
+				candidates = append(candidates[:i], candidates[i+1:]...)
+				break
+			}
+		}
+	}
+	return replicas
+}
+
+// replicaAddrsForRepo returns the addresses of the gitserver instances that
+// hold replicas of repo, which is assigned to the gitserver instance at
+// primary.
+func (c *clientImplementor) replicaAddrsForRepo(repo api.RepoName, primary string) []string {
+	replication := c.replication()
+	if replication == nil {
+		return nil
+	}
+	return ReplicaAddrsForRepo(repo, primary, GitServerAddresses{
+		Addresses:     c.Addrs(),
+		PinnedServers: c.pinned(),
+		Replication:   replication,
+	})
+}
+
+// httpPostWithFailover is like httpPost, but for requests that only read from
+// the repository. If the gitserver instance that the repository is assigned to
+// is unavailable, the request is sent to the replicas of the repository.
+func (c *clientImplementor) httpPostWithFailover(ctx context.Context, repo api.RepoName, op string, payload any) (*http.Response, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	addrForRepo, err := c.AddrForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	u := &url.URL{
+		Scheme: "http",
+		Host:   addrForRepo,
+		Path:   "/" + op,
+	}
+	return c.doWithFailover(ctx, repo, "POST", u, b)
+}
+
+// doWithFailover performs a request to the gitserver instance in u. If repo is
+// replicated and the instance is unavailable, the request is retried against
+// the replicas of repo. Instances that were unavailable recently are tried
+// last.
+func (c *clientImplementor) doWithFailover(ctx context.Context, repo api.RepoName, method string, u *url.URL, payload []byte) (resp *http.Response, err error) {
+	replicas := c.replicaAddrsForRepo(repo, u.Host)
+	if len(replicas) == 0 {
+		return c.do(ctx, repo, method, u.String(), payload)
+	}
+
+	addrs := unavailableAddrs.sort(append([]string{u.Host}, replicas...))
+	for i, addr := range addrs {
+		if addr != u.Host {
+			replicaFailoverCounter.Inc()
+		}
+
+		attempt := *u
+		attempt.Host = addr
+		resp, err = c.do(ctx, repo, method, attempt.String(), payload)
+		if ctx.Err() != nil {
+			return resp, err
+		}
+		if !unavailable(resp, err) {
+			unavailableAddrs.unmark(addr)
+			return resp, err
+		}
+		unavailableAddrs.mark(addr)
+
+		if i < len(addrs)-1 {
+			c.logger.Warn("gitserver unavailable, trying replica",
+				sglog.String("repo", string(repo)),
+				sglog.String("addr", addr),
+				sglog.String("replica", addrs[i+1]),
+				sglog.Error(err),
+			)
+			if resp != nil {
+				resp.Body.Close()
+			}
+		}
+	}
+	return resp, err
+}
+
+// unavailable returns true if the response to a request or its error indicate
+// that the gitserver instance is unavailable, as opposed to the request
+// failing.
+func unavailable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// unavailableAddrs records the gitserver instances that failed to respond
+// recently, so that reads of replicated repositories go to replicas first
+// instead of waiting for the unavailable instance on every request.
+var unavailableAddrs = &addrAvailability{until: map[string]time.Time{}}
+
+type addrAvailability struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+func (a *addrAvailability) mark(addr string) {
+	a.mu.Lock()
+	a.until[addr] = time.Now().Add(unavailableTTL)
+	a.mu.Unlock()
+}
+
+func (a *addrAvailability) unmark(addr string) {
+	a.mu.Lock()
+	delete(a.until, addr)
+	a.mu.Unlock()
+}
+
+// sort returns addrs with the instances that were unavailable recently moved
+// to the end, keeping the order otherwise.
+func (a *addrAvailability) sort(addrs []string) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	sorted := make([]string, 0, len(addrs))
+	var last []string
+	now := time.Now()
+	for _, addr := range addrs {
+		if until, ok := a.until[addr]; ok && now.Before(until) {
+			last = append(last, addr)
+		} else {
+			sorted = append(sorted, addr)
+		}
+	}
+	return append(sorted, last...)
+}
+
+func replicationFromConfig() *schema.GitServerReplication {
+	cfg := conf.Get()
+	if cfg.ExperimentalFeatures != nil {
+		return cfg.ExperimentalFeatures.GitServerReplication
+	}
+	return nil
+}
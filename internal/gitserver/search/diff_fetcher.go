@@ -15,6 +15,11 @@ import (
 // started with StartDiffFetcher
 type DiffFetcher struct {
 	dir string
+	env []string
+
+	// fetchObjects is called with the hash of each commit before its diff is
+	// fetched, if it is not nil.
+	fetchObjects func(commit []byte) error
 
 	startOnce sync.Once
 	stdin     io.Writer
@@ -25,10 +30,11 @@ type DiffFetcher struct {
 }
 
 // NewDiffFetcher starts a git diff-tree subprocess that waits, listening on stdin
-// for comimt hashes to generate patches for.
-func NewDiffFetcher(dir string) (*DiffFetcher, error) {
+// for comimt hashes to generate patches for. If env is nil, the subprocess
+// inherits the environment of the current process.
+func NewDiffFetcher(dir string, env []string) (*DiffFetcher, error) {
 
-	return &DiffFetcher{dir: dir}, nil
+	return &DiffFetcher{dir: dir, env: env}, nil
 }
 
 func (d *DiffFetcher) Stop() {
@@ -51,6 +57,7 @@ func (d *DiffFetcher) start() (err error) {
 			"--root",           // Treat the root commit as a big creation event (otherwise the diff would be empty)
 		)
 		d.cmd.Dir = d.dir
+		d.cmd.Env = d.env
 
 		var stdoutReader io.ReadCloser
 		stdoutReader, err = d.cmd.StdoutPipe()
@@ -97,6 +104,11 @@ func (d *DiffFetcher) Fetch(hash []byte) ([]byte, error) {
 	if err := d.start(); err != nil {
 		return nil, err
 	}
+	if d.fetchObjects != nil {
+		if err := d.fetchObjects(hash); err != nil {
+			return nil, err
+		}
+	}
 	// HACK: There is no way (as far as I can tell) to make `git diff-tree --stdin` to
 	// write a trailing null byte or tell us how much to read in advance, and since we're
 	// using a long-running process, the stream doesn't close at the end, and we can't use the
@@ -90,6 +90,15 @@ type CommitSearcher struct {
 	IncludeDiff          bool
 	IncludeModifiedFiles bool
 	RepoName             api.RepoName
+
+	// Env is the environment of the git commands that the searcher runs. If
+	// nil, they inherit the environment of the current process.
+	Env []string
+
+	// FetchDiffObjects is called with the hash of a commit before its diff is
+	// read, if it is not nil. Partial clones use it to fetch the files of the
+	// diff that they don't have.
+	FetchDiffObjects func(commit []byte) error
 }
 
 // Search runs a search for commits matching the given predicate across the revisions passed in as revisionArgs.
@@ -143,6 +152,7 @@ func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, result
 	}
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = cs.RepoDir
+	cmd.Env = cs.Env
 	stdoutReader, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -216,10 +226,11 @@ func getSubRepoFilterFunc(ctx context.Context, checker authz.SubRepoPermissionCh
 
 func (cs *CommitSearcher) runJobs(ctx context.Context, jobs chan job) error {
 	// Create a new diff fetcher subprocess for each worker
-	diffFetcher, err := NewDiffFetcher(cs.RepoDir)
+	diffFetcher, err := NewDiffFetcher(cs.RepoDir, cs.Env)
 	if err != nil {
 		return err
 	}
+	diffFetcher.fetchObjects = cs.FetchDiffObjects
 	defer diffFetcher.Stop()
 
 	startBuf := make([]byte, 1024)
@@ -4,20 +4,26 @@ import (
 	"context"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/gomodule/redigo/redis"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
 	"golang.org/x/time/rate"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // DefaultRegistry is the default global rate limit registry, which holds rate
-// limit mappings for each instance of our services.
-var DefaultRegistry = NewRegistry()
+// limit mappings for each instance of our services. Its rate limiters share
+// their budget with the other instances through Redis.
+var DefaultRegistry = NewDistributedRegistry(redispool.Store)
 
 const defaultBurst = 10
 
@@ -29,12 +35,27 @@ func NewRegistry() *Registry {
 	}
 }
 
+// NewDistributedRegistry creates and returns an empty rate limit registry,
+// whose rate limiters share their budget with those of the same URN in other
+// instances of our services through the given Redis pool. If Redis can't be
+// reached, the rate limiters fall back to limiting the requests of only this
+// instance.
+func NewDistributedRegistry(pool *redis.Pool) *Registry {
+	r := NewRegistry()
+	r.pool = pool
+	return r
+}
+
 // Registry manages rate limiters for external services.
 type Registry struct {
 	mu sync.Mutex
 	// rateLimiters contains mappings of external service to its *rate.Limiter. The
 	// key should be the URN of the external service.
 	rateLimiters map[string]*InstrumentedLimiter
+
+	// pool is the Redis pool the rate limiters share their budget through, if
+	// not nil.
+	pool *redis.Pool
 }
 
 // Get returns the rate limiter configured for the given URN of an external
@@ -69,6 +90,9 @@ func (r *Registry) getOrSet(urn string, fallback *InstrumentedLimiter) *Instrume
 		}
 		fallback = NewInstrumentedLimiter(urn, rate.NewLimiter(fallbackRateLimit, defaultBurst))
 	}
+	if r.pool != nil && fallback.global == nil {
+		fallback.global = newGlobalLimiter(r.pool, urn)
+	}
 	r.rateLimiters[urn] = fallback
 	return fallback
 }
@@ -89,11 +113,22 @@ type LimitInfo struct {
 	// Infinite is true if Limit is infinite. This is required since infinity cannot
 	// be marshalled in JSON.
 	Infinite bool
+	// Distributed is true if the budget is shared by all instances of our
+	// services.
+	Distributed bool
+	// Consumed is the number of tokens this instance has waited for. Most
+	// requests take one token, but some take more.
+	Consumed int64
+	// Remaining is the budget that is currently left for all instances, which
+	// is negative if requests are waiting. Only set if Distributed is true and
+	// Limit is not infinite.
+	Remaining float64
 }
 
 // LimitInfo reports how all the existing rate limiters are configured, keyed by
-// URN.
-func (r *Registry) LimitInfo() map[string]LimitInfo {
+// URN. The remaining budget of distributed limiters is left unset if Redis
+// does not answer in time.
+func (r *Registry) LimitInfo(ctx context.Context) map[string]LimitInfo {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -108,6 +143,15 @@ func (r *Registry) LimitInfo() map[string]LimitInfo {
 			info.Limit = 0
 			info.Infinite = true
 		}
+		info.Consumed = atomic.LoadInt64(&rl.consumed)
+		if rl.global != nil {
+			info.Distributed = true
+			if !info.Infinite && limit > 0 && rl.global.available() {
+				// The remaining budget is only informational, so we ignore
+				// errors.
+				info.Remaining, _ = rl.global.tokens(ctx, limit, rl.Burst())
+			}
+		}
 		m[urn] = info
 	}
 	return m
@@ -117,6 +161,14 @@ func (r *Registry) LimitInfo() map[string]LimitInfo {
 type InstrumentedLimiter struct {
 	urn string
 	*rate.Limiter
+
+	// global is the token bucket in Redis shared with other instances, if the
+	// limiter is distributed. The *rate.Limiter configures its rate and burst,
+	// and is used if Redis can't be reached.
+	global *globalLimiter
+
+	// consumed is the number of tokens waited for.
+	consumed int64
 }
 
 // NewInstrumentedLimiter creates new InstrumentedLimiter with given URN and rate.Limiter
@@ -138,12 +190,76 @@ func (i *InstrumentedLimiter) Wait(ctx context.Context) error {
 // The burst limit is ignored if the rate limit is Inf.
 func (i *InstrumentedLimiter) WaitN(ctx context.Context, n int) error {
 	start := time.Now()
-	err := i.Limiter.WaitN(ctx, n)
+	err := i.waitN(ctx, n)
 	d := time.Since(start)
 	failedLabel := "false"
 	if err != nil {
 		failedLabel = "true"
 	}
+
+	metricWaitDuration.WithLabelValues(i.metricURN(), failedLabel).Observe(d.Seconds())
+	return err
+}
+
+func (i *InstrumentedLimiter) waitN(ctx context.Context, n int) error {
+	limit := i.Limit()
+	if i.global == nil || limit == rate.Inf || limit <= 0 || !i.global.available() {
+		return i.localWaitN(ctx, n)
+	}
+
+	// Same checks as rate.Limiter.WaitN.
+	burst := i.Burst()
+	if n > burst {
+		return errors.Errorf("rate: Wait(n=%d) exceeds limiter's burst %d", n, burst)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	maxWait := time.Duration(-1)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = time.Until(deadline)
+	}
+
+	wait, ok, err := i.global.reserve(ctx, limit, burst, n, maxWait)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		i.global.logger().Warn("falling back to local rate limiting", log.Error(err))
+		metricRedisFallback.WithLabelValues(i.metricURN()).Inc()
+		i.global.markUnavailable()
+		return i.localWaitN(ctx, n)
+	}
+	if !ok {
+		return errors.Errorf("rate: Wait(n=%d) would exceed context deadline", n)
+	}
+	atomic.AddInt64(&i.consumed, int64(n))
+	if wait == 0 {
+		return nil
+	}
+
+	// Unlike rate.Limiter, the tokens stay taken if the context is canceled
+	// while waiting.
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (i *InstrumentedLimiter) localWaitN(ctx context.Context, n int) error {
+	err := i.Limiter.WaitN(ctx, n)
+	if err == nil {
+		atomic.AddInt64(&i.consumed, int64(n))
+	}
+	return err
+}
+
+// metricURN returns the URN to use as label in metrics.
+func (i *InstrumentedLimiter) metricURN() string {
 	urn := i.urn
 
 	// On sourcegraph.com the cardinality of code hosts is too high, so instead just
@@ -153,9 +269,7 @@ func (i *InstrumentedLimiter) WaitN(ctx context.Context, n int) error {
 			urn = kind
 		}
 	}
-
-	metricWaitDuration.WithLabelValues(urn, failedLabel).Observe(d.Seconds())
-	return err
+	return urn
 }
 
 // SetBurst is calling SetBurstAt(time.Now(), newBurst) method of the wrapped *rate.Limiter.
@@ -173,3 +287,8 @@ var metricWaitDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
 	Help:    "Time spent waiting for our internal rate limiter",
 	Buckets: []float64{0.2, 0.5, 1, 2, 5, 10, 30, 60},
 }, []string{"urn", "failed"})
+
+var metricRedisFallback = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_internal_rate_limit_redis_fallback_total",
+	Help: "Number of times a distributed rate limiter fell back to local rate limiting because Redis could not be reached",
+}, []string{"urn"})
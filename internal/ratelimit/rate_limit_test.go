@@ -1,6 +1,7 @@
 package ratelimit
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,7 +47,7 @@ func TestLimitInfo(t *testing.T) {
 	r.getOrSet("extsvc:github:1", NewInstrumentedLimiter("extsvc:github:1", rate.NewLimiter(rate.Inf, 1)))
 	r.getOrSet("extsvc:github:2", NewInstrumentedLimiter("extsvc:github:2", rate.NewLimiter(10, 1)))
 
-	info := r.LimitInfo()
+	info := r.LimitInfo(context.Background())
 
 	assert.Equal(t, info["extsvc:github:1"], LimitInfo{
 		Limit:    0,
@@ -0,0 +1,196 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/sourcegraph/log"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// redisKeyPrefix is the prefix of the keys of the token buckets in Redis. The
+// rest of the key is the URN of the external service, so that all services
+// share the same bucket for a code host.
+const redisKeyPrefix = "ratelimit:"
+
+// redisInfoTimeout is how long we wait for Redis when reporting the remaining
+// budget, which is only informational.
+const redisInfoTimeout = time.Second
+
+// redisReserveTimeout is how long we wait for Redis when taking tokens before
+// falling back to local rate limiting, so that a Redis that hangs doesn't block
+// requests to code hosts.
+const redisReserveTimeout = 500 * time.Millisecond
+
+// redisBackoff is how long we use local rate limiting for after Redis could not
+// be reached, so that we don't try to connect on every request.
+const redisBackoff = 30 * time.Second
+
+// takeTokensScript atomically takes tokens from a token bucket, which is
+// stored as a hash with the number of tokens and the time they were last
+// updated at.
+//
+// KEYS[1] is the key of the bucket. The arguments are the rate in tokens per
+// second, the burst, the current time in milliseconds, the number of tokens to
+// take and the maximum time in milliseconds that the caller is willing to wait
+// for them, or -1 to wait for any time.
+//
+// It returns the time in milliseconds to wait until the tokens are available,
+// or -1 if that is longer than the maximum time, in which case no tokens are
+// taken. Like rate.Limiter, the bucket can go into debt: tokens are reserved
+// even if the caller has to wait for them.
+var takeTokensScript = redis.NewScript(1, `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local n = tonumber(ARGV[4])
+local max_wait = tonumber(ARGV[5])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1])
+local updated = tonumber(bucket[2])
+if tokens == nil or updated == nil then
+  tokens = burst
+  updated = now
+end
+
+-- Clocks of different instances can be out of sync, so time never goes back.
+local elapsed = math.max(now - updated, 0)
+tokens = math.min(burst, tokens + elapsed * rate / 1000)
+
+local wait = 0
+if tokens < n then
+  wait = math.ceil((n - tokens) * 1000 / rate)
+end
+if max_wait >= 0 and wait > max_wait then
+  return -1
+end
+
+tokens = tokens - n
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', tostring(math.max(now, updated)))
+-- Once the bucket is full again it is the same as a bucket that doesn't exist.
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) * 1000 / rate) + 1000)
+return wait
+`)
+
+// globalLimiter is a token bucket in Redis that is shared by all instances of
+// our services. It is configured by the rate.Limiter of the InstrumentedLimiter
+// that uses it.
+type globalLimiter struct {
+	pool *redis.Pool
+	key  string
+
+	mu sync.Mutex
+	// unavailableUntil is set when Redis could not be reached, to use local
+	// rate limiting until then.
+	unavailableUntil time.Time
+
+	clock func() time.Time
+}
+
+func newGlobalLimiter(pool *redis.Pool, urn string) *globalLimiter {
+	return &globalLimiter{
+		pool: pool,
+		key:  redisKeyPrefix + urn,
+	}
+}
+
+// available returns false if Redis recently could not be reached.
+func (g *globalLimiter) available() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !g.now().Before(g.unavailableUntil)
+}
+
+// markUnavailable makes the limiter fall back to local rate limiting for the
+// next redisBackoff.
+func (g *globalLimiter) markUnavailable() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.unavailableUntil = g.now().Add(redisBackoff)
+}
+
+// reserve takes n tokens from the bucket and returns how long to wait until
+// they are available. If the wait would be longer than maxWait, no tokens are
+// taken and ok is false. A negative maxWait means there is no maximum.
+//
+// It gives up after redisReserveTimeout.
+func (g *globalLimiter) reserve(ctx context.Context, limit rate.Limit, burst, n int, maxWait time.Duration) (wait time.Duration, ok bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, redisReserveTimeout)
+	defer cancel()
+
+	c, err := g.pool.GetContext(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer c.Close()
+
+	maxWaitMillis := int64(-1)
+	if maxWait >= 0 {
+		maxWaitMillis = maxWait.Milliseconds()
+	}
+	deadline, _ := ctx.Deadline()
+	waitMillis, err := redis.Int64(takeTokensScript.Do(connWithDeadline{Conn: c, deadline: deadline}, g.key, float64(limit), burst, g.now().UnixMilli(), n, maxWaitMillis))
+	if err != nil {
+		return 0, false, err
+	}
+	if waitMillis < 0 {
+		return 0, false, nil
+	}
+	return time.Duration(waitMillis) * time.Millisecond, true, nil
+}
+
+// tokens returns the number of tokens currently in the bucket, which is
+// negative if requests are waiting for tokens. It gives up after
+// redisInfoTimeout.
+func (g *globalLimiter) tokens(ctx context.Context, limit rate.Limit, burst int) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, redisInfoTimeout)
+	defer cancel()
+
+	c, err := g.pool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	deadline, _ := ctx.Deadline()
+	values, err := redis.Float64s(redis.DoWithTimeout(c, time.Until(deadline), "HMGET", g.key, "tokens", "updated"))
+	if err != nil {
+		if errors.Is(err, redis.ErrNil) {
+			return float64(burst), nil
+		}
+		return 0, err
+	}
+	if len(values) != 2 {
+		return float64(burst), nil
+	}
+	elapsed := math.Max(float64(g.now().UnixMilli())-values[1], 0)
+	return math.Min(float64(burst), values[0]+elapsed*float64(limit)/1000), nil
+}
+
+// connWithDeadline is a connection whose commands give up at the deadline. It
+// lets redis.Script run with a timeout.
+type connWithDeadline struct {
+	redis.Conn
+	deadline time.Time
+}
+
+func (c connWithDeadline) Do(commandName string, args ...interface{}) (interface{}, error) {
+	return redis.DoWithTimeout(c.Conn, time.Until(c.deadline), commandName, args...)
+}
+
+func (g *globalLimiter) now() time.Time {
+	if g.clock != nil {
+		return g.clock()
+	}
+	return time.Now()
+}
+
+func (g *globalLimiter) logger() log.Logger {
+	return log.Scoped("ratelimit", "rate limiting shared through Redis").With(log.String("key", g.key))
+}
@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/rafaeljusto/redigomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestGlobalLimiter(t *testing.T) {
+	pool := &redis.Pool{
+		MaxIdle: 3,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", "127.0.0.1:6379")
+		},
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	c := pool.Get()
+	defer c.Close()
+	// If we are not on CI, skip the test if our redis connection fails.
+	if _, err := c.Do("PING"); err != nil && os.Getenv("CI") == "" {
+		t.Skip("could not connect to redis", err)
+	}
+
+	urn := "__test__" + t.Name()
+	_, err := c.Do("DEL", redisKeyPrefix+urn)
+	require.NoError(t, err)
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	// Two limiters with the same URN, as in two instances of a service.
+	a := newGlobalLimiter(pool, urn)
+	a.clock = clock
+	b := newGlobalLimiter(pool, urn)
+	b.clock = clock
+
+	ctx := context.Background()
+
+	// The bucket starts full.
+	wait, ok, err := a.reserve(ctx, 1, 2, 2, -1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	// The other instance has to wait for the bucket to refill.
+	wait, ok, err = b.reserve(ctx, 1, 2, 1, -1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, wait)
+
+	remaining, err := a.tokens(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, float64(-1), remaining)
+
+	// Nothing is taken if the wait would be too long.
+	_, ok, err = a.reserve(ctx, 1, 2, 1, time.Second)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	now = now.Add(3 * time.Second)
+	remaining, err = b.tokens(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), remaining)
+}
+
+func TestInstrumentedLimiter_RedisFallback(t *testing.T) {
+	dials := 0
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			dials++
+			return nil, errors.New("redis is down")
+		},
+	}
+
+	r := NewDistributedRegistry(pool)
+	l := r.getOrSet("extsvc:github:1", NewInstrumentedLimiter("extsvc:github:1", rate.NewLimiter(10, 1)))
+
+	ctx := context.Background()
+	require.NoError(t, l.Wait(ctx))
+	require.NoError(t, l.Wait(ctx))
+
+	// Redis is not tried again right after it failed.
+	assert.Equal(t, 1, dials)
+
+	info := r.LimitInfo(context.Background())["extsvc:github:1"]
+	assert.True(t, info.Distributed)
+	assert.Equal(t, int64(2), info.Consumed)
+}
+
+func TestInstrumentedLimiter_RedisHangs(t *testing.T) {
+	// Redis accepts connections but never replies.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+
+	dials := 0
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			dials++
+			return redis.Dial("tcp", listener.Addr().String())
+		},
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	r := NewDistributedRegistry(pool)
+	l := r.getOrSet("extsvc:github:1", NewInstrumentedLimiter("extsvc:github:1", rate.NewLimiter(10, 1)))
+
+	start := time.Now()
+	require.NoError(t, l.Wait(context.Background()))
+	require.NoError(t, l.Wait(context.Background()))
+	assert.Less(t, time.Since(start), 5*redisReserveTimeout)
+
+	// Redis is not tried again right after it timed out.
+	assert.Equal(t, 1, dials)
+}
+
+func TestInstrumentedLimiter_InfiniteSkipsRedis(t *testing.T) {
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			t.Fatal("unexpected dial")
+			return nil, nil
+		},
+	}
+
+	r := NewDistributedRegistry(pool)
+	l := r.getOrSet("extsvc:github:1", NewInstrumentedLimiter("extsvc:github:1", rate.NewLimiter(rate.Inf, 1)))
+	require.NoError(t, l.Wait(context.Background()))
+
+	info := r.LimitInfo(context.Background())["extsvc:github:1"]
+	assert.Equal(t, LimitInfo{Burst: 1, Infinite: true, Distributed: true, Consumed: 1}, info)
+}
+
+func TestGlobalLimiter_TokensTimeout(t *testing.T) {
+	// The only connection of the pool is in use, so getting another one blocks.
+	pool := &redis.Pool{
+		MaxActive: 1,
+		Wait:      true,
+		Dial: func() (redis.Conn, error) {
+			return redigomock.NewConn(), nil
+		},
+	}
+	t.Cleanup(func() { pool.Close() })
+	c := pool.Get()
+	defer c.Close()
+
+	g := newGlobalLimiter(pool, "extsvc:github:1")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := g.tokens(ctx, 1, 2)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,141 @@
+package repos
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An AzureDevOpsSource yields repositories from a single Azure DevOps connection configured
+// in Sourcegraph via the external services configuration.
+type AzureDevOpsSource struct {
+	svc       *types.ExternalService
+	cli       *azuredevops.Client
+	serviceID string
+	config    schema.AzureDevOpsConnection
+	exclude   excludeFunc
+}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external service.
+func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := azuredevops.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureDevOpsSource{
+		svc:       svc,
+		cli:       cli,
+		serviceID: extsvc.NormalizeBaseURL(cli.URL).String(),
+		config:    c,
+		exclude:   exclude,
+	}, nil
+}
+
+// ListRepos returns all Azure DevOps repositories configured with this AzureDevOpsSource's config.
+func (s *AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	// A project may belong to one of the configured orgs, so we deduplicate
+	// repositories by ID across all listings.
+	seen := make(map[string]bool)
+
+	for _, org := range s.config.Orgs {
+		s.listRepos(ctx, org, seen, results)
+	}
+
+	for _, project := range s.config.Projects {
+		s.listRepos(ctx, project, seen, results)
+	}
+}
+
+func (s *AzureDevOpsSource) listRepos(ctx context.Context, projectOrOrg string, seen map[string]bool, results chan SourceResult) {
+	org, _, _ := strings.Cut(projectOrOrg, "/")
+
+	repos, err := s.cli.ListRepositoriesByProjectOrOrg(ctx, azuredevops.ListRepositoriesByProjectOrOrgArgs{
+		ProjectOrOrgName: projectOrOrg,
+	})
+	if err != nil {
+		results <- SourceResult{Source: s, Err: errors.Wrapf(err, "azuredevops.repos: item=%q", projectOrOrg)}
+		return
+	}
+
+	for _, r := range repos {
+		// Disabled repositories can be neither cloned nor browsed.
+		if r.IsDisabled || seen[r.ID] || s.excludes(org, r) {
+			continue
+		}
+		seen[r.ID] = true
+
+		results <- SourceResult{Source: s, Repo: s.makeRepo(org, r)}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *AzureDevOpsSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *AzureDevOpsSource) excludes(org string, r *azuredevops.Repository) bool {
+	return s.exclude(path.Join(org, r.Project.Name, r.Name))
+}
+
+func (s *AzureDevOpsSource) makeRepo(org string, r *azuredevops.Repository) *types.Repo {
+	urn := s.svc.URN()
+
+	name := path.Join(s.cli.URL.Host, org, r.Project.Name, r.Name)
+
+	return &types.Repo{
+		Name: api.RepoName(name),
+		URI:  name,
+		Fork: r.IsFork,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.ID,
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   s.serviceID,
+		},
+		Private: r.Project.Visibility != "public",
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: r.RemoteURL,
+			},
+		},
+		Metadata: r,
+	}
+}
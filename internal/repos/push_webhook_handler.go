@@ -0,0 +1,163 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GitLabWebhookHandler enqueues an update of a repository when GitLab sends a
+// push event for it.
+type GitLabWebhookHandler struct {
+	pushWebhookHandler
+}
+
+func NewGitLabWebhookHandler(repos database.RepoStore) *GitLabWebhookHandler {
+	return &GitLabWebhookHandler{pushWebhookHandler{repos: repos}}
+}
+
+func (g *GitLabWebhookHandler) Register(router *webhooks.GitLabWebhook) {
+	g.logger = log.Scoped("repos.GitLabWebhookHandler", "gitlab webhook handler")
+	router.Register(g.handleGitLabWebhook, "push", "tag_push")
+}
+
+func (g *GitLabWebhookHandler) handleGitLabWebhook(ctx context.Context, extSvc *types.ExternalService, payload any) error {
+	event, ok := payload.(*gitlabwebhooks.PushEvent)
+	if !ok {
+		return errors.Newf("expected GitLab PushEvent, got %T", payload)
+	}
+
+	projectID := event.Project.ID
+	if projectID == 0 {
+		projectID = event.ProjectID
+	}
+	return g.enqueueRepoUpdate(ctx, extSvc, strconv.Itoa(projectID))
+}
+
+// BitbucketServerWebhookHandler enqueues an update of a repository when
+// Bitbucket Server sends an event for changed refs of it.
+type BitbucketServerWebhookHandler struct {
+	pushWebhookHandler
+}
+
+func NewBitbucketServerWebhookHandler(repos database.RepoStore) *BitbucketServerWebhookHandler {
+	return &BitbucketServerWebhookHandler{pushWebhookHandler{repos: repos}}
+}
+
+func (b *BitbucketServerWebhookHandler) Register(router *webhooks.BitbucketServerWebhook) {
+	b.logger = log.Scoped("repos.BitbucketServerWebhookHandler", "bitbucket server webhook handler")
+	router.Register(b.handleBitbucketServerWebhook, "repo:refs_changed")
+}
+
+func (b *BitbucketServerWebhookHandler) handleBitbucketServerWebhook(ctx context.Context, extSvc *types.ExternalService, payload any) error {
+	event, ok := payload.(*bitbucketserver.RefsChangedEvent)
+	if !ok {
+		return errors.Newf("expected Bitbucket Server RefsChangedEvent, got %T", payload)
+	}
+	return b.enqueueRepoUpdate(ctx, extSvc, strconv.Itoa(event.Repository.ID))
+}
+
+// BitbucketCloudWebhookHandler enqueues an update of a repository when
+// Bitbucket Cloud sends a push event for it.
+type BitbucketCloudWebhookHandler struct {
+	pushWebhookHandler
+}
+
+func NewBitbucketCloudWebhookHandler(repos database.RepoStore) *BitbucketCloudWebhookHandler {
+	return &BitbucketCloudWebhookHandler{pushWebhookHandler{repos: repos}}
+}
+
+func (b *BitbucketCloudWebhookHandler) Register(router *webhooks.BitbucketCloudWebhook) {
+	b.logger = log.Scoped("repos.BitbucketCloudWebhookHandler", "bitbucket cloud webhook handler")
+	router.Register(b.handleBitbucketCloudWebhook, "repo:push")
+}
+
+func (b *BitbucketCloudWebhookHandler) handleBitbucketCloudWebhook(ctx context.Context, extSvc *types.ExternalService, payload any) error {
+	event, ok := payload.(*bitbucketcloud.PushEvent)
+	if !ok {
+		return errors.Newf("expected Bitbucket Cloud PushEvent, got %T", payload)
+	}
+	return b.enqueueRepoUpdate(ctx, extSvc, event.Repository.UUID)
+}
+
+// pushWebhookHandler enqueues repository updates for push events of code hosts
+// that identify repositories by their external ID instead of their URL.
+type pushWebhookHandler struct {
+	logger log.Logger
+	repos  database.RepoStore
+}
+
+// enqueueRepoUpdate enqueues an update of the repository with the given
+// external ID on the code host of extSvc.
+func (h *pushWebhookHandler) enqueueRepoUpdate(ctx context.Context, extSvc *types.ExternalService, externalID string) error {
+	serviceID, err := webhookServiceID(ctx, extSvc)
+	if err != nil {
+		return errors.Wrap(err, "get service ID failed")
+	}
+
+	rs, err := h.repos.List(ctx, database.ReposListOptions{
+		ExternalRepos: []api.ExternalRepoSpec{{
+			ID:          externalID,
+			ServiceType: extsvc.KindToType(extSvc.Kind),
+			ServiceID:   serviceID,
+		}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing repositories failed")
+	}
+	if len(rs) == 0 {
+		// The repository is not synced from this external service, for
+		// example because it is excluded in its configuration.
+		h.logger.Debug("no repository for webhook", log.String("externalID", externalID))
+		return nil
+	}
+
+	resp, err := repoupdater.DefaultClient.EnqueueRepoUpdate(ctx, rs[0].Name)
+	if err != nil {
+		return errors.Wrap(err, "EnqueueRepoUpdate failed")
+	}
+
+	h.logger.Info("successfully updated", log.String("name", resp.Name))
+	return nil
+}
+
+// webhookServiceID returns the service ID of the repositories of extSvc, the
+// normalized URL of the code host.
+func webhookServiceID(ctx context.Context, extSvc *types.ExternalService) (string, error) {
+	c, err := extSvc.Configuration(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var rawURL string
+	switch c := c.(type) {
+	case *schema.GitLabConnection:
+		rawURL = c.Url
+	case *schema.BitbucketServerConnection:
+		rawURL = c.Url
+	case *schema.BitbucketCloudConnection:
+		rawURL = c.Url
+	default:
+		return "", errors.Errorf("unsupported external service kind %q", extSvc.Kind)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return extsvc.NormalizeBaseURL(u).String(), nil
+}
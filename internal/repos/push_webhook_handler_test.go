@@ -0,0 +1,122 @@
+package repos_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPushWebhookHandlers(t *testing.T) {
+	var enqueued []api.RepoName
+	mux := http.NewServeMux()
+	mux.HandleFunc("/enqueue-repo-update", func(w http.ResponseWriter, r *http.Request) {
+		var req protocol.RepoUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		enqueued = append(enqueued, req.Repo)
+		json.NewEncoder(w).Encode(&protocol.RepoUpdateResponse{Name: string(req.Repo)})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	oldClient := repoupdater.DefaultClient
+	repoupdater.DefaultClient = repoupdater.NewClient(server.URL)
+	t.Cleanup(func() { repoupdater.DefaultClient = oldClient })
+
+	// The repo store returns a repository named after the external repo spec
+	// it is listed by.
+	repoStore := database.NewMockRepoStore()
+	repoStore.ListFunc.SetDefaultHook(func(ctx context.Context, opts database.ReposListOptions) ([]*types.Repo, error) {
+		if len(opts.ExternalRepos) != 1 {
+			return nil, nil
+		}
+		spec := opts.ExternalRepos[0]
+		if spec.ID == "unknown" {
+			return nil, nil
+		}
+		return []*types.Repo{{Name: api.RepoName(spec.ServiceType + "/" + spec.ServiceID + "/" + spec.ID)}}, nil
+	})
+
+	newExternalService := func(kind string, config any) *types.ExternalService {
+		raw, err := json.Marshal(config)
+		require.NoError(t, err)
+		return &types.ExternalService{ID: 1, Kind: kind, Config: extsvc.NewUnencryptedConfig(string(raw))}
+	}
+	externalServices := func(e *types.ExternalService) database.ExternalServiceStore {
+		store := database.NewMockExternalServiceStore()
+		store.ListFunc.SetDefaultReturn([]*types.ExternalService{e}, nil)
+		return store
+	}
+
+	serve := func(t *testing.T, h http.Handler, req *http.Request) []api.RepoName {
+		t.Helper()
+		enqueued = nil
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		return enqueued
+	}
+
+	t.Run("GitLab", func(t *testing.T) {
+		router := &webhooks.GitLabWebhook{ExternalServices: externalServices(newExternalService(extsvc.KindGitLab, schema.GitLabConnection{
+			Url:      "https://gitlab.com/",
+			Webhooks: []*schema.GitLabWebhook{{Secret: "secret"}},
+		}))}
+		repos.NewGitLabWebhookHandler(repoStore).Register(router)
+
+		for _, kind := range []string{"push", "tag_push"} {
+			req := httptest.NewRequest("POST", "/.api/gitlab-webhooks?externalServiceID=1", strings.NewReader(`{"object_kind":"`+kind+`","project_id":42,"project":{"id":42}}`))
+			req.Header.Set("X-Gitlab-Token", "secret")
+			assert.Equal(t, []api.RepoName{"gitlab/https://gitlab.com//42"}, serve(t, router, req), kind)
+		}
+	})
+
+	t.Run("Bitbucket Server", func(t *testing.T) {
+		router := &webhooks.BitbucketServerWebhook{ExternalServices: externalServices(newExternalService(extsvc.KindBitbucketServer, schema.BitbucketServerConnection{
+			Url:    "https://bitbucket.sgdev.org",
+			Plugin: &schema.BitbucketServerPlugin{Webhooks: &schema.BitbucketServerPluginWebhooks{Secret: "secret"}},
+		}))}
+		repos.NewBitbucketServerWebhookHandler(repoStore).Register(router)
+
+		payload := `{"repository":{"id":42},"changes":[{"refId":"refs/heads/main","type":"UPDATE"}]}`
+		req := httptest.NewRequest("POST", "/.api/bitbucket-server-webhooks?externalServiceID=1", strings.NewReader(payload))
+		req.Header.Set("X-Event-Key", "repo:refs_changed")
+		req.Header.Set("X-Hub-Signature", sign(t, []byte(payload), []byte("secret")))
+		assert.Equal(t, []api.RepoName{"bitbucketServer/https://bitbucket.sgdev.org//42"}, serve(t, router, req))
+	})
+
+	t.Run("Bitbucket Cloud", func(t *testing.T) {
+		router := &webhooks.BitbucketCloudWebhook{ExternalServices: externalServices(newExternalService(extsvc.KindBitbucketCloud, schema.BitbucketCloudConnection{
+			Url:           "https://bitbucket.org",
+			WebhookSecret: "secret",
+		}))}
+		repos.NewBitbucketCloudWebhookHandler(repoStore).Register(router)
+
+		req := httptest.NewRequest("POST", "/.api/bitbucket-cloud-webhooks?secret=secret", strings.NewReader(`{"repository":{"uuid":"{42}"},"push":{"changes":[]}}`))
+		req.Header.Set("X-Event-Key", "repo:push")
+		assert.Equal(t, []api.RepoName{"bitbucketCloud/https://bitbucket.org//{42}"}, serve(t, router, req))
+
+		// Pushes to repositories that are not synced are ignored.
+		req = httptest.NewRequest("POST", "/.api/bitbucket-cloud-webhooks?secret=secret", strings.NewReader(`{"repository":{"uuid":"unknown"},"push":{"changes":[]}}`))
+		req.Header.Set("X-Event-Key", "repo:push")
+		assert.Empty(t, serve(t, router, req))
+	})
+}
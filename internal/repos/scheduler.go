@@ -4,6 +4,7 @@ import (
 	"container/heap"
 	"context"
 	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -35,6 +36,12 @@ func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateSched
 		stop context.CancelFunc
 	)
 
+	// Settings that pin repos to an update interval or priority are applied
+	// when the repos are updated. Failing to load them doesn't prevent updates.
+	if err := scheduler.LoadScheduleSettings(ctx); err != nil {
+		logger.Error("failed to load repo update schedule settings", log.Error(err))
+	}
+
 	conf.Watch(func() {
 		c := conf.Get()
 
@@ -100,6 +107,12 @@ const (
 // backoff by doubling the current interval. This ensures that problematic repos
 // don't stay in the front of the schedule clogging up the queue.
 //
+// Intervals configured with the gitUpdateInterval site configuration take
+// precedence over both. Site admins can also pin a repo to a fixed interval, which
+// takes precedence over everything else, or to high priority, so that the repo is
+// updated before other repos when it is due. These settings are stored in the
+// repo_update_schedule_settings table.
+//
 // When it is time for a repo to update, the scheduler inserts the repo into a queue.
 //
 // A worker continuously dequeues repos and sends updates to gitserver, but its concurrency
@@ -108,6 +121,7 @@ type UpdateScheduler struct {
 	db          database.DB
 	updateQueue *updateQueue
 	schedule    *schedule
+	settings    *scheduleSettings
 	logger      log.Logger
 }
 
@@ -140,6 +154,9 @@ func NewUpdateScheduler(logger log.Logger, db database.DB) *UpdateScheduler {
 			randGenerator: rand.New(rand.NewSource(time.Now().UnixNano())),
 			logger:        updateSchedLogger.Scoped("Schedule", ""),
 		},
+		settings: &scheduleSettings{
+			byRepo: make(map[api.RepoID]database.RepoUpdateScheduleSetting),
+		},
 		logger: updateSchedLogger,
 	}
 }
@@ -171,7 +188,7 @@ func (s *UpdateScheduler) runSchedule() {
 		}
 
 		schedAutoFetch.Inc()
-		s.updateQueue.enqueue(repoUpdate.Repo, priorityLow)
+		s.updateQueue.enqueue(repoUpdate.Repo, s.settings.priority(repoUpdate.Repo.ID))
 		repoUpdate.Due = timeNow().Add(repoUpdate.Interval)
 		heap.Fix(s.schedule, 0)
 	}
@@ -212,31 +229,41 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 				// if it doesn't exist or update it if it does. The timeout of this request depends
 				// on the value of conf.GitLongCommandTimeout() or if the passed context has a set
 				// deadline shorter than the value of this config.
+				start := timeNow()
 				resp, err := requestRepoUpdate(ctx, s.db, repo, 1*time.Second)
+				var lastError string
 				if err != nil {
 					schedError.WithLabelValues("requestRepoUpdate").Inc()
 					subLogger.Error("error requesting repo update", log.Error(err), log.String("uri", string(repo.Name)))
+					lastError = err.Error()
 				} else if resp != nil && resp.Error != "" {
 					schedError.WithLabelValues("repoUpdateResponse").Inc()
 					subLogger.Error("error updating repo", log.String("err", resp.Error), log.String("uri", string(repo.Name)))
+					lastError = resp.Error
+				}
+				s.schedule.recordUpdate(repo, timeNow().Sub(start), lastError)
+
+				if setting, ok := s.settings.get(repo.ID); ok && setting.Interval > 0 {
+					s.schedule.updateInterval(repo, setting.Interval, intervalReasonPinned)
+					return
 				}
 
 				if interval := getCustomInterval(subLogger, conf.Get(), string(repo.Name)); interval > 0 {
-					s.schedule.updateInterval(repo, interval)
+					s.schedule.updateInterval(repo, interval, intervalReasonSiteConfig)
 					return
 				}
 
-				if err != nil || (resp != nil && resp.Error != "") {
+				if lastError != "" {
 					// On error we will double the current interval so that we back off and don't
 					// get stuck with problematic repos with low intervals.
 					if currentInterval, ok := s.schedule.getCurrentInterval(repo); ok {
-						s.schedule.updateInterval(repo, currentInterval*2)
+						s.schedule.updateInterval(repo, currentInterval*2, intervalReasonFetchError)
 					}
 				} else if resp != nil && resp.LastFetched != nil && resp.LastChanged != nil {
 					// This is the heuristic that is described in the UpdateScheduler documentation.
 					// Update that documentation if you update this logic.
 					interval := resp.LastFetched.Sub(*resp.LastChanged) / 2
-					s.schedule.updateInterval(repo, interval)
+					s.schedule.updateInterval(repo, interval, intervalReasonCommitFrequency)
 				}
 			}(ctx, repo, cancel)
 		}
@@ -459,29 +486,103 @@ func (s *UpdateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedu
 
 	s.schedule.mu.Lock()
 	if update := s.schedule.index[id]; update != nil {
-		result.Schedule = &protocol.RepoScheduleState{
-			Index:           update.Index,
-			Total:           len(s.schedule.index),
-			IntervalSeconds: int(update.Interval / time.Second),
-			Due:             update.Due,
-		}
+		result.Schedule = s.scheduleState(update)
 	}
 	s.schedule.mu.Unlock()
 
 	s.updateQueue.mu.Lock()
 	if update := s.updateQueue.index[id]; update != nil {
-		result.Queue = &protocol.RepoQueueState{
-			Index:    update.Index,
-			Total:    len(s.updateQueue.index),
-			Updating: update.Updating,
-			Priority: int(update.Priority),
+		result.Queue = s.queueState(update)
+	}
+	s.updateQueue.mu.Unlock()
+
+	return &result
+}
+
+// ScheduleList returns the repos in the update queue, in the order in which
+// they are updated, followed by the other repos in the schedule, ordered by the
+// time when they are due for an update. At most limit repos are returned,
+// starting at offset. A limit of zero returns all repos.
+func (s *UpdateScheduler) ScheduleList(offset, limit int) *protocol.RepoUpdateSchedulerListResult {
+	var result protocol.RepoUpdateSchedulerListResult
+
+	// The schedule is locked while the queue is read, so that repos can't move
+	// from the schedule to the queue and be listed twice.
+	s.schedule.mu.Lock()
+	s.updateQueue.mu.Lock()
+	queued := make([]*repoUpdate, len(s.updateQueue.heap))
+	copy(queued, s.updateQueue.heap)
+	sort.Slice(queued, func(i, j int) bool {
+		return (&updateQueue{heap: queued}).Less(i, j)
+	})
+	for _, update := range queued {
+		entry := protocol.RepoUpdateSchedulerEntry{
+			ID:    update.Repo.ID,
+			Name:  update.Repo.Name,
+			Queue: s.queueState(update),
+		}
+		if scheduled := s.schedule.index[update.Repo.ID]; scheduled != nil {
+			entry.Schedule = s.scheduleState(scheduled)
 		}
+		result.Entries = append(result.Entries, entry)
 	}
 	s.updateQueue.mu.Unlock()
 
+	scheduled := make([]*scheduledRepoUpdate, 0, len(s.schedule.heap))
+	for _, update := range s.schedule.heap {
+		if _, ok := s.updateQueue.index[update.Repo.ID]; !ok {
+			scheduled = append(scheduled, update)
+		}
+	}
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Due.Before(scheduled[j].Due)
+	})
+	for _, update := range scheduled {
+		result.Entries = append(result.Entries, protocol.RepoUpdateSchedulerEntry{
+			ID:       update.Repo.ID,
+			Name:     update.Repo.Name,
+			Schedule: s.scheduleState(update),
+		})
+	}
+	s.schedule.mu.Unlock()
+
+	result.Total = len(result.Entries)
+	if offset > len(result.Entries) {
+		offset = len(result.Entries)
+	}
+	result.Entries = result.Entries[offset:]
+	if limit > 0 && limit < len(result.Entries) {
+		result.Entries = result.Entries[:limit]
+	}
 	return &result
 }
 
+// scheduleState returns the state of update in the schedule.
+// The caller must hold the lock on s.schedule.mu.
+func (s *UpdateScheduler) scheduleState(update *scheduledRepoUpdate) *protocol.RepoScheduleState {
+	return &protocol.RepoScheduleState{
+		Index:              update.Index,
+		Total:              len(s.schedule.index),
+		IntervalSeconds:    int(update.Interval / time.Second),
+		Due:                update.Due,
+		IntervalReason:     update.IntervalReason.String(),
+		LastUpdateDuration: update.LastUpdateDuration,
+		LastError:          update.LastError,
+		Priority:           int(s.settings.priority(update.Repo.ID)),
+	}
+}
+
+// queueState returns the state of update in the update queue.
+// The caller must hold the lock on s.updateQueue.mu.
+func (s *UpdateScheduler) queueState(update *repoUpdate) *protocol.RepoQueueState {
+	return &protocol.RepoQueueState{
+		Index:    update.Index,
+		Total:    len(s.updateQueue.index),
+		Updating: update.Updating,
+		Priority: int(update.Priority),
+	}
+}
+
 // updateQueue is a priority queue of repos to update.
 // A repo can't have more than one location in the queue.
 // Implements heap.Interface and sort.Interface.
@@ -686,10 +787,44 @@ type schedule struct {
 
 // scheduledRepoUpdate is the update schedule for a single repo.
 type scheduledRepoUpdate struct {
-	Repo     configuredRepo // the repo to update
-	Interval time.Duration  // how regularly the repo is updated
-	Due      time.Time      // the next time that the repo will be enqueued for a update
-	Index    int            `json:"-"` // the index in the heap
+	Repo               configuredRepo // the repo to update
+	Interval           time.Duration  // how regularly the repo is updated
+	IntervalReason     intervalReason // why the repo is updated at Interval
+	Due                time.Time      // the next time that the repo will be enqueued for a update
+	LastUpdateDuration time.Duration  // how long the last update of the repo took
+	LastError          string         // the error of the last update, if it failed
+	Index              int            `json:"-"` // the index in the heap
+}
+
+// intervalReason is the reason why a repo is updated at its current interval.
+type intervalReason int
+
+const (
+	// Repos are updated at minDelay until they were updated once.
+	intervalReasonDefault intervalReason = iota
+	// The interval is computed from the time since the last commit.
+	intervalReasonCommitFrequency
+	// The interval was doubled because the last update failed.
+	intervalReasonFetchError
+	// The interval is set by the gitUpdateInterval site configuration.
+	intervalReasonSiteConfig
+	// The interval is pinned by a site admin.
+	intervalReasonPinned
+)
+
+func (r intervalReason) String() string {
+	switch r {
+	case intervalReasonCommitFrequency:
+		return "commit-frequency"
+	case intervalReasonFetchError:
+		return "fetch-error"
+	case intervalReasonSiteConfig:
+		return "site-config"
+	case intervalReasonPinned:
+		return "pinned"
+	default:
+		return "default"
+	}
 }
 
 // upsert inserts or updates a repo in the schedule.
@@ -790,7 +925,7 @@ func (s *schedule) insertNew(repos []types.MinimalRepo) {
 
 // updateInterval updates the update interval of a repo in the schedule.
 // It does nothing if the repo is not in the schedule.
-func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
+func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration, reason intervalReason) {
 	if repo.ID == 0 {
 		panic("repo.id is zero")
 	}
@@ -810,6 +945,7 @@ func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
 		// repos getting updated at the same time.
 		delta := int64(update.Interval) / 20
 		update.Interval = update.Interval + time.Duration(s.randGenerator.Int63n(2*delta)-delta)
+		update.IntervalReason = reason
 
 		update.Due = timeNow().Add(update.Interval)
 		s.logger.Debug("updated repo",
@@ -821,6 +957,19 @@ func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
 	s.mu.Unlock()
 }
 
+// recordUpdate records the duration and the error, if any, of the last update
+// of a repo in the schedule. It does nothing if the repo is not in the
+// schedule.
+func (s *schedule) recordUpdate(repo configuredRepo, duration time.Duration, lastError string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if update := s.index[repo.ID]; update != nil {
+		update.LastUpdateDuration = duration
+		update.LastError = lastError
+	}
+}
+
 // getCurrentInterval gets the current interval for the supplied repo and a bool
 // indicating whether it was found.
 func (s *schedule) getCurrentInterval(repo configuredRepo) (time.Duration, bool) {
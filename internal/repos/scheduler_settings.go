@@ -0,0 +1,125 @@
+package repos
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// scheduleSettings holds the settings with which site admins pin repos to a
+// fixed update interval or to high priority. The settings are stored in the
+// database and kept in memory, so that they survive resets of the schedule.
+type scheduleSettings struct {
+	mu     sync.RWMutex
+	byRepo map[api.RepoID]database.RepoUpdateScheduleSetting
+}
+
+// get returns the setting of the given repo, if there is one.
+func (s *scheduleSettings) get(id api.RepoID) (database.RepoUpdateScheduleSetting, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	setting, ok := s.byRepo[id]
+	return setting, ok
+}
+
+// priority returns the priority with which the given repo is enqueued when it
+// is due for an update.
+func (s *scheduleSettings) priority(id api.RepoID) priority {
+	if setting, ok := s.get(id); ok && setting.HighPriority {
+		return priorityHigh
+	}
+	return priorityLow
+}
+
+// LoadScheduleSettings loads the settings of all repos from the database,
+// replacing the settings that were loaded before.
+func (s *UpdateScheduler) LoadScheduleSettings(ctx context.Context) error {
+	settings, err := s.db.RepoUpdateScheduleSettings().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	byRepo := make(map[api.RepoID]database.RepoUpdateScheduleSetting, len(settings))
+	for _, setting := range settings {
+		byRepo[setting.RepoID] = *setting
+	}
+
+	s.settings.mu.Lock()
+	s.settings.byRepo = byRepo
+	s.settings.mu.Unlock()
+
+	for _, setting := range settings {
+		if setting.Interval > 0 {
+			s.schedule.pinInterval(setting.RepoID, setting.Interval)
+		}
+	}
+	return nil
+}
+
+// ReloadScheduleSetting loads the setting of the given repo from the database
+// and applies it to the schedule. It is called after a site admin changed the
+// setting.
+func (s *UpdateScheduler) ReloadScheduleSetting(ctx context.Context, id api.RepoID) error {
+	setting, err := s.db.RepoUpdateScheduleSettings().Get(ctx, id)
+	if err != nil && !errcode.IsNotFound(err) {
+		return err
+	}
+
+	s.settings.mu.Lock()
+	if setting == nil {
+		delete(s.settings.byRepo, id)
+	} else {
+		s.settings.byRepo[id] = *setting
+	}
+	s.settings.mu.Unlock()
+
+	if setting != nil && setting.Interval > 0 {
+		s.schedule.pinInterval(id, setting.Interval)
+	} else {
+		s.schedule.unpinInterval(id)
+	}
+
+	s.logger.Debug("reloaded schedule setting", log.Int32("repo", int32(id)), log.Bool("found", setting != nil))
+	return nil
+}
+
+// pinInterval sets the update interval of a repo in the schedule to an
+// interval pinned by a site admin. It does nothing if the repo is not in the
+// schedule.
+func (s *schedule) pinInterval(id api.RepoID, interval time.Duration) {
+	if repo, ok := s.repo(id); ok {
+		s.updateInterval(repo, interval, intervalReasonPinned)
+	}
+}
+
+// unpinInterval resets the update interval of a repo in the schedule that was
+// pinned by a site admin, so that the repo is updated soon and its interval is
+// computed again. It does nothing if the interval of the repo is not pinned.
+func (s *schedule) unpinInterval(id api.RepoID) {
+	s.mu.Lock()
+	update := s.index[id]
+	pinned := update != nil && update.IntervalReason == intervalReasonPinned
+	s.mu.Unlock()
+
+	if pinned {
+		s.updateInterval(update.Repo, minDelay, intervalReasonDefault)
+	}
+}
+
+// repo returns the repo with the given ID if it is in the schedule.
+func (s *schedule) repo(id api.RepoID) (configuredRepo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if update := s.index[id]; update != nil {
+		return update.Repo, true
+	}
+	return configuredRepo{}, false
+}
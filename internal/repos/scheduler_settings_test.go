@@ -0,0 +1,162 @@
+package repos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestUpdateScheduler_LoadScheduleSettings(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+
+	r, stop := startRecording()
+	defer stop()
+
+	settings := database.NewMockRepoUpdateScheduleSettingsStore()
+	settings.ListFunc.SetDefaultReturn([]*database.RepoUpdateScheduleSetting{
+		{RepoID: a.ID, Interval: time.Hour},
+		{RepoID: b.ID, HighPriority: true},
+	}, nil)
+	db := database.NewMockDB()
+	db.RepoUpdateScheduleSettingsFunc.SetDefaultReturn(settings)
+
+	s := NewUpdateScheduler(logtest.Scoped(t), db)
+	s.schedule.randGenerator = &mockRandomGenerator{}
+	setupInitialSchedule(s, []*scheduledRepoUpdate{
+		{Repo: a, Interval: minDelay, Due: defaultTime.Add(minDelay)},
+		{Repo: b, Interval: minDelay, Due: defaultTime.Add(minDelay)},
+	})
+	// Settings that were loaded before are replaced.
+	s.settings.byRepo[3] = database.RepoUpdateScheduleSetting{RepoID: 3, HighPriority: true}
+
+	if err := s.LoadScheduleSettings(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := s.settings.priority(a.ID); got != priorityLow {
+		t.Errorf("got priority %d for a, want %d", got, priorityLow)
+	}
+	if got := s.settings.priority(b.ID); got != priorityHigh {
+		t.Errorf("got priority %d for b, want %d", got, priorityHigh)
+	}
+	if got := s.settings.priority(3); got != priorityLow {
+		t.Errorf("got priority %d for replaced setting, want %d", got, priorityLow)
+	}
+
+	verifySchedule(t, s, []*scheduledRepoUpdate{
+		{Repo: b, Interval: minDelay, Due: defaultTime.Add(minDelay)},
+		{Repo: a, Interval: time.Hour, IntervalReason: intervalReasonPinned, Due: defaultTime.Add(time.Hour)},
+	})
+	verifyScheduleRecording(t, s, []time.Duration{minDelay}, 1, r)
+}
+
+func TestUpdateScheduler_ReloadScheduleSetting(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+
+	notFound := &errcode.Mock{IsNotFound: true}
+
+	tests := []struct {
+		name            string
+		initialSchedule []*scheduledRepoUpdate
+		setting         *database.RepoUpdateScheduleSetting
+		err             error
+		wantErr         bool
+		wantPriority    priority
+		finalSchedule   []*scheduledRepoUpdate
+	}{
+		{
+			name: "pin interval",
+			initialSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: minDelay, Due: defaultTime.Add(minDelay)},
+			},
+			setting:      &database.RepoUpdateScheduleSetting{RepoID: a.ID, Interval: 2 * time.Hour},
+			wantPriority: priorityLow,
+			finalSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: 2 * time.Hour, IntervalReason: intervalReasonPinned, Due: defaultTime.Add(2 * time.Hour)},
+			},
+		},
+		{
+			name: "pin high priority",
+			initialSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Hour, IntervalReason: intervalReasonCommitFrequency, Due: defaultTime.Add(time.Hour)},
+			},
+			setting:      &database.RepoUpdateScheduleSetting{RepoID: a.ID, HighPriority: true},
+			wantPriority: priorityHigh,
+			finalSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Hour, IntervalReason: intervalReasonCommitFrequency, Due: defaultTime.Add(time.Hour)},
+			},
+		},
+		{
+			name: "unpin resets pinned interval",
+			initialSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: 2 * time.Hour, IntervalReason: intervalReasonPinned, Due: defaultTime.Add(2 * time.Hour)},
+			},
+			err:          notFound,
+			wantPriority: priorityLow,
+			finalSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: minDelay, Due: defaultTime.Add(minDelay)},
+			},
+		},
+		{
+			name: "unpin keeps computed interval",
+			initialSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Hour, IntervalReason: intervalReasonSiteConfig, Due: defaultTime.Add(time.Hour)},
+			},
+			err:          notFound,
+			wantPriority: priorityLow,
+			finalSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Hour, IntervalReason: intervalReasonSiteConfig, Due: defaultTime.Add(time.Hour)},
+			},
+		},
+		{
+			name: "database error",
+			initialSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Hour, Due: defaultTime.Add(time.Hour)},
+			},
+			err:          &errcode.Mock{Message: "connection refused"},
+			wantErr:      true,
+			wantPriority: priorityLow,
+			finalSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Hour, Due: defaultTime.Add(time.Hour)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, stop := startRecording()
+			defer stop()
+
+			settings := database.NewMockRepoUpdateScheduleSettingsStore()
+			settings.GetFunc.SetDefaultHook(func(_ context.Context, id api.RepoID) (*database.RepoUpdateScheduleSetting, error) {
+				if id != a.ID {
+					t.Errorf("got repo ID %d, want %d", id, a.ID)
+				}
+				return test.setting, test.err
+			})
+			db := database.NewMockDB()
+			db.RepoUpdateScheduleSettingsFunc.SetDefaultReturn(settings)
+
+			s := NewUpdateScheduler(logtest.Scoped(t), db)
+			s.schedule.randGenerator = &mockRandomGenerator{}
+			setupInitialSchedule(s, test.initialSchedule)
+
+			err := s.ReloadScheduleSetting(context.Background(), a.ID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error: %t", err, test.wantErr)
+			}
+
+			if got := s.settings.priority(a.ID); got != test.wantPriority {
+				t.Errorf("got priority %d, want %d", got, test.wantPriority)
+			}
+			verifySchedule(t, s, test.finalSchedule)
+		})
+	}
+}
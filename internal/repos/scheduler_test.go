@@ -9,6 +9,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/mutablelimiter"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -893,6 +895,7 @@ func TestSchedule_updateInterval(t *testing.T) {
 		time     time.Time
 		repo     configuredRepo
 		interval time.Duration
+		reason   intervalReason
 	}
 
 	tests := []struct {
@@ -1013,6 +1016,20 @@ func TestSchedule_updateInterval(t *testing.T) {
 			timeAfterFuncDelays: []time.Duration{123 * time.Minute},
 			wakeupNotifications: 1,
 		},
+		{
+			name: "records the reason",
+			initialSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: minDelay, Due: defaultTime.Add(minDelay)},
+			},
+			updateCalls: []*updateCall{
+				{repo: a, time: defaultTime, interval: time.Hour, reason: intervalReasonPinned},
+			},
+			finalSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Hour, IntervalReason: intervalReasonPinned, Due: defaultTime.Add(time.Hour)},
+			},
+			timeAfterFuncDelays: []time.Duration{time.Hour},
+			wakeupNotifications: 1,
+		},
 		{
 			name: "heap reorders correctly",
 			initialSchedule: []*scheduledRepoUpdate{
@@ -1052,7 +1069,7 @@ func TestSchedule_updateInterval(t *testing.T) {
 
 			for _, call := range test.updateCalls {
 				mockTime(call.time)
-				s.schedule.updateInterval(call.repo, call.interval)
+				s.schedule.updateInterval(call.repo, call.interval, call.reason)
 			}
 
 			verifySchedule(t, s, test.finalSchedule)
@@ -1208,6 +1225,7 @@ func TestUpdateScheduler_runSchedule(t *testing.T) {
 	tests := []struct {
 		name                  string
 		initialSchedule       []*scheduledRepoUpdate
+		settings              []database.RepoUpdateScheduleSetting
 		finalSchedule         []*scheduledRepoUpdate
 		finalQueue            []*repoUpdate
 		timeAfterFuncDelays   []time.Duration
@@ -1247,6 +1265,27 @@ func TestUpdateScheduler_runSchedule(t *testing.T) {
 				return []chan struct{}{s.updateQueue.notifyEnqueue, s.schedule.wakeup}
 			},
 		},
+		{
+			name: "one update due, high priority",
+			initialSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: 11 * time.Second, Due: defaultTime.Add(1 * time.Microsecond)},
+				{Repo: b, Interval: 22 * time.Second, Due: defaultTime.Add(time.Minute)},
+			},
+			settings: []database.RepoUpdateScheduleSetting{
+				{RepoID: a.ID, HighPriority: true},
+			},
+			finalSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: 11 * time.Second, Due: defaultTime.Add(11 * time.Second)},
+				{Repo: b, Interval: 22 * time.Second, Due: defaultTime.Add(time.Minute)},
+			},
+			finalQueue: []*repoUpdate{
+				{Repo: a, Priority: priorityHigh, Seq: 1},
+			},
+			timeAfterFuncDelays: []time.Duration{11 * time.Second},
+			expectedNotifications: func(s *UpdateScheduler) []chan struct{} {
+				return []chan struct{}{s.updateQueue.notifyEnqueue, s.schedule.wakeup}
+			},
+		},
 		{
 			name: "one update due, rescheduled to back",
 			initialSchedule: []*scheduledRepoUpdate{
@@ -1310,6 +1349,9 @@ func TestUpdateScheduler_runSchedule(t *testing.T) {
 			s := NewUpdateScheduler(logtest.Scoped(t), database.NewMockDB())
 
 			setupInitialSchedule(s, test.initialSchedule)
+			for _, setting := range test.settings {
+				s.settings.byRepo[setting.RepoID] = setting
+			}
 
 			s.runSchedule()
 
@@ -1336,6 +1378,7 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 		gitMaxConcurrentClones int
 		initialSchedule        []*scheduledRepoUpdate
 		initialQueue           []*repoUpdate
+		settings               []database.RepoUpdateScheduleSetting
 		mockRequestRepoUpdates []*mockRequestRepoUpdate
 		finalSchedule          []*scheduledRepoUpdate
 		finalQueue             []*repoUpdate
@@ -1395,13 +1438,65 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 				},
 			},
 			finalSchedule: []*scheduledRepoUpdate{
-				{Repo: a, Interval: time.Minute, Due: defaultTime.Add(time.Minute)},
+				{Repo: a, Interval: time.Minute, IntervalReason: intervalReasonCommitFrequency, Due: defaultTime.Add(time.Minute)},
 			},
 			timeAfterFuncDelays: []time.Duration{time.Minute},
 			expectedNotifications: func(s *UpdateScheduler) []chan struct{} {
 				return []chan struct{}{s.schedule.wakeup}
 			},
 		},
+		{
+			name:                   "schedule backs off on error",
+			gitMaxConcurrentClones: 1,
+			initialSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Hour, Due: defaultTime.Add(time.Hour)},
+			},
+			initialQueue: []*repoUpdate{
+				{Repo: a, Seq: 1},
+			},
+			mockRequestRepoUpdates: []*mockRequestRepoUpdate{
+				{
+					repo: a,
+					resp: &gitserverprotocol.RepoUpdateResponse{Error: "repository not found"},
+				},
+			},
+			finalSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: 2 * time.Hour, IntervalReason: intervalReasonFetchError, Due: defaultTime.Add(2 * time.Hour), LastError: "repository not found"},
+			},
+			timeAfterFuncDelays: []time.Duration{2 * time.Hour},
+			expectedNotifications: func(s *UpdateScheduler) []chan struct{} {
+				return []chan struct{}{s.schedule.wakeup}
+			},
+		},
+		{
+			name:                   "pinned interval takes precedence",
+			gitMaxConcurrentClones: 1,
+			initialSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Hour, Due: defaultTime.Add(time.Hour)},
+			},
+			initialQueue: []*repoUpdate{
+				{Repo: a, Seq: 1},
+			},
+			settings: []database.RepoUpdateScheduleSetting{
+				{RepoID: a.ID, Interval: 3 * time.Hour},
+			},
+			mockRequestRepoUpdates: []*mockRequestRepoUpdate{
+				{
+					repo: a,
+					resp: &gitserverprotocol.RepoUpdateResponse{
+						LastFetched: timePtr(defaultTime.Add(2 * time.Minute)),
+						LastChanged: timePtr(defaultTime),
+					},
+				},
+			},
+			finalSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: 3 * time.Hour, IntervalReason: intervalReasonPinned, Due: defaultTime.Add(3 * time.Hour)},
+			},
+			timeAfterFuncDelays: []time.Duration{3 * time.Hour},
+			expectedNotifications: func(s *UpdateScheduler) []chan struct{} {
+				return []chan struct{}{s.schedule.wakeup}
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -1446,6 +1541,9 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 
 			setupInitialSchedule(s, test.initialSchedule)
 			setupInitialQueue(s, test.initialQueue)
+			for _, setting := range test.settings {
+				s.settings.byRepo[setting.RepoID] = setting
+			}
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -1500,6 +1598,80 @@ func timePtr(t time.Time) *time.Time {
 	return &t
 }
 
+func TestUpdateScheduler_ScheduleList(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+	c := configuredRepo{ID: 3, Name: "c"}
+	d := configuredRepo{ID: 4, Name: "d"}
+
+	s := NewUpdateScheduler(logtest.Scoped(t), database.NewMockDB())
+	setupInitialSchedule(s, []*scheduledRepoUpdate{
+		{Repo: a, Interval: time.Hour, IntervalReason: intervalReasonFetchError, Due: defaultTime.Add(3 * time.Hour), LastError: "boom"},
+		{Repo: b, Interval: time.Minute, Due: defaultTime.Add(time.Minute)},
+		{Repo: c, Interval: time.Hour, IntervalReason: intervalReasonPinned, Due: defaultTime.Add(2 * time.Hour), LastUpdateDuration: time.Second},
+	})
+	setupInitialQueue(s, []*repoUpdate{
+		{Repo: b, Priority: priorityLow, Seq: 2},
+		{Repo: d, Priority: priorityHigh, Seq: 1, Updating: true},
+	})
+	s.settings.byRepo[c.ID] = database.RepoUpdateScheduleSetting{RepoID: c.ID, Interval: time.Hour, HighPriority: true}
+
+	schedule := func(index int, update *scheduledRepoUpdate, priority priority) *protocol.RepoScheduleState {
+		return &protocol.RepoScheduleState{
+			Index:              index,
+			Total:              3,
+			IntervalSeconds:    int(update.Interval / time.Second),
+			Due:                update.Due,
+			IntervalReason:     update.IntervalReason.String(),
+			LastUpdateDuration: update.LastUpdateDuration,
+			LastError:          update.LastError,
+			Priority:           int(priority),
+		}
+	}
+	all := []protocol.RepoUpdateSchedulerEntry{
+		// Repos that are updating are listed after the other repos in the queue.
+		{
+			ID:       b.ID,
+			Name:     b.Name,
+			Schedule: schedule(s.schedule.index[b.ID].Index, s.schedule.index[b.ID], priorityLow),
+			Queue:    &protocol.RepoQueueState{Index: s.updateQueue.index[b.ID].Index, Total: 2, Priority: int(priorityLow)},
+		},
+		{
+			ID:    d.ID,
+			Name:  d.Name,
+			Queue: &protocol.RepoQueueState{Index: s.updateQueue.index[d.ID].Index, Total: 2, Updating: true, Priority: int(priorityHigh)},
+		},
+		{
+			ID:       c.ID,
+			Name:     c.Name,
+			Schedule: schedule(s.schedule.index[c.ID].Index, s.schedule.index[c.ID], priorityHigh),
+		},
+		{
+			ID:       a.ID,
+			Name:     a.Name,
+			Schedule: schedule(s.schedule.index[a.ID].Index, s.schedule.index[a.ID], priorityLow),
+		},
+	}
+
+	for _, tc := range []struct {
+		offset, limit int
+		want          []protocol.RepoUpdateSchedulerEntry
+	}{
+		{offset: 0, limit: 0, want: all},
+		{offset: 1, limit: 2, want: all[1:3]},
+		{offset: 3, limit: 2, want: all[3:]},
+		{offset: 5, limit: 2, want: all[4:]},
+	} {
+		got := s.ScheduleList(tc.offset, tc.limit)
+		if got.Total != len(all) {
+			t.Errorf("offset %d, limit %d: got total %d, want %d", tc.offset, tc.limit, got.Total, len(all))
+		}
+		if diff := cmp.Diff(tc.want, got.Entries, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("offset %d, limit %d: unexpected entries (-want +got):\n%s", tc.offset, tc.limit, diff)
+		}
+	}
+}
+
 func Test_updateQueue_Less(t *testing.T) {
 	q := &updateQueue{}
 	tests := []struct {
@@ -62,6 +62,45 @@ func (c *Client) RepoUpdateSchedulerInfo(
 	return result, err
 }
 
+// RepoUpdateSchedulerList returns the repos in the update scheduler, starting
+// with the repos in the update queue.
+func (c *Client) RepoUpdateSchedulerList(
+	ctx context.Context,
+	args protocol.RepoUpdateSchedulerListArgs,
+) (result *protocol.RepoUpdateSchedulerListResult, err error) {
+	resp, err := c.httpPost(ctx, "repo-update-scheduler-list", args)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		stack := fmt.Sprintf("RepoUpdateSchedulerList: %+v", args)
+		return nil, errors.Wrap(errors.Errorf("http status %d", resp.StatusCode), stack)
+	}
+	defer resp.Body.Close()
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	return result, err
+}
+
+// RepoUpdateSchedulerReloadSetting requests the update scheduler to apply the
+// update schedule setting of the repo after it was changed in the database.
+func (c *Client) RepoUpdateSchedulerReloadSetting(ctx context.Context, id api.RepoID) error {
+	resp, err := c.httpPost(ctx, "repo-update-scheduler-reload-setting", protocol.RepoUpdateSchedulerReloadSettingArgs{ID: id})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		bs, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "read response body")
+		}
+		return errors.New(string(bs))
+	}
+	return nil
+}
+
 // MockRepoLookup mocks (*Client).RepoLookup for tests.
 var MockRepoLookup func(protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error)
 
@@ -31,6 +31,17 @@ type RepoScheduleState struct {
 	Total           int
 	IntervalSeconds int
 	Due             time.Time
+
+	// IntervalReason is the reason why the repo is updated at the current
+	// interval: one of "default", "commit-frequency", "fetch-error",
+	// "site-config" or "pinned".
+	IntervalReason string `json:",omitempty"`
+	// LastUpdateDuration is how long the last update of the repo took.
+	LastUpdateDuration time.Duration `json:",omitempty"`
+	// LastError is the error of the last update of the repo, if it failed.
+	LastError string `json:",omitempty"`
+	// Priority is the priority with which the repo is enqueued when it is due.
+	Priority int `json:",omitempty"`
 }
 
 type RepoQueueState struct {
@@ -40,6 +51,33 @@ type RepoQueueState struct {
 	Priority int
 }
 
+type RepoUpdateSchedulerListArgs struct {
+	// The number of repos to skip.
+	Offset int
+	// The maximum number of repos to return. Zero returns all repos.
+	Limit int
+}
+
+type RepoUpdateSchedulerListResult struct {
+	// The repos in the update queue, in the order in which they are updated,
+	// followed by the other repos in the schedule, ordered by due time.
+	Entries []RepoUpdateSchedulerEntry
+	// The total number of repos, ignoring Offset and Limit.
+	Total int
+}
+
+type RepoUpdateSchedulerEntry struct {
+	ID       api.RepoID
+	Name     api.RepoName
+	Schedule *RepoScheduleState `json:",omitempty"`
+	Queue    *RepoQueueState    `json:",omitempty"`
+}
+
+type RepoUpdateSchedulerReloadSettingArgs struct {
+	// The ID of the repo whose update schedule setting changed.
+	ID api.RepoID
+}
+
 // RepoLookupArgs is a request for information about a repository on repoupdater.
 type RepoLookupArgs struct {
 	// Repo is the repository name to look up.
@@ -100,6 +100,13 @@ func AlertForQuery(queryString string, err error) *Alert {
 			Description:    `I'm having trouble understanding that query. Putting parentheses around the search pattern may help.`,
 		}
 	}
+	if errors.HasType(err, &query.MacroError{}) {
+		return &Alert{
+			PrometheusType: "invalid_macro",
+			Title:          "Unable To Expand Macro",
+			Description:    capFirst(err.Error()) + ". Macros are defined in the `search.macros` setting.",
+		}
+	}
 	return &Alert{
 		PrometheusType: "generic_invalid_query",
 		Title:          "Unable To Process Query",
@@ -175,6 +182,19 @@ func AlertForInvalidRevision(revision string) *Alert {
 	}
 }
 
+// AlertForRevisionDiffLimitHit is returned instead of the results of a
+// revision diff search if the search at either revision hit its result limit,
+// since matches missing from the truncated side would be reported as added or
+// removed.
+func AlertForRevisionDiffLimitHit() *Alert {
+	return &Alert{
+		PrometheusType: "revision_diff_limit_hit",
+		Title:          "Too many results to compare revisions",
+		Description:    "The search hit its result limit at one of the revisions, so the differences between the revisions would be incomplete. Narrow the search with filters like `file:`, or raise the limit with `count:`.",
+		Priority:       1,
+	}
+}
+
 func AlertForUnindexedLockfile(repoName api.RepoName, revisions []string) *Alert {
 	var description strings.Builder
 	fmt.Fprintf(&description, "No lockfile indexed in **%s** at these revisions yet:\n", repoName)
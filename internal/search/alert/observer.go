@@ -226,6 +226,7 @@ func (o *Observer) errorToAlert(ctx context.Context, err error) (*search.Alert,
 		mErr *searchrepos.MissingRepoRevsError
 		oErr *errOverRepoLimit
 		lErr *ErrLuckyQueries
+		cErr *ErrQueryCostLimit
 	)
 
 	if errors.HasType(err, authz.ErrStalePermissions{}) {
@@ -252,6 +253,10 @@ func (o *Observer) errorToAlert(ctx context.Context, err error) (*search.Alert,
 		}, nil
 	}
 
+	if errors.As(err, &cErr) {
+		return alertForQueryCostLimit(cErr), nil
+	}
+
 	if errors.As(err, &mErr) {
 		var a *search.Alert
 		a = AlertForMissingRepoRevs(mErr.Missing)
@@ -350,6 +355,55 @@ func (e *errOverRepoLimit) Error() string {
 	return "Too many matching repositories"
 }
 
+// ErrQueryCostLimit is returned for searches that exceed the query cost
+// limits in the site configuration.
+type ErrQueryCostLimit struct {
+	// Cost is the estimated cost of the search.
+	Cost int
+	// Limit is the limit that the search exceeds.
+	Limit int
+	// Running is the estimated cost of the other searches that the user is
+	// running. It is zero if the search exceeds the limit on its own.
+	Running int
+
+	// Unindexed is true if the search searches unindexed repositories.
+	Unindexed bool
+	// Commits is true if the search searches commits or diffs.
+	Commits bool
+}
+
+func (e *ErrQueryCostLimit) Error() string {
+	if e.Running > 0 {
+		return fmt.Sprintf("search with cost %d exceeds the query cost limit of %d per user, with other searches of cost %d running", e.Cost, e.Limit, e.Running)
+	}
+	return fmt.Sprintf("search with cost %d exceeds the query cost limit of %d", e.Cost, e.Limit)
+}
+
+func alertForQueryCostLimit(e *ErrQueryCostLimit) *search.Alert {
+	if e.Running > 0 {
+		return &search.Alert{
+			PrometheusType: "query_cost_limit__per_user",
+			Title:          "Too many expensive searches",
+			Description:    fmt.Sprintf("This search is estimated to cost %d, and your other searches that are still running are estimated to cost %d. Together they exceed the limit of %d per user set by your site administrator. Try again when your other searches finish.", e.Cost, e.Running, e.Limit),
+		}
+	}
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "This search is estimated to cost %d, which exceeds the limit of %d set by your site administrator. Try searching fewer repositories with the `repo:` filter", e.Cost, e.Limit)
+	if e.Commits {
+		b.WriteString(", or fewer commits with the `after:` and `before:` filters")
+	}
+	b.WriteString(".")
+	if e.Unindexed {
+		b.WriteString(" Searching unindexed repositories, for example with `index:no` or structural search, is more expensive than searching indexed repositories.")
+	}
+	return &search.Alert{
+		PrometheusType: "query_cost_limit",
+		Title:          "Search is too expensive",
+		Description:    b.String(),
+	}
+}
+
 type LuckyAlertType int
 
 const (
@@ -48,6 +48,19 @@ func TestErrorToAlertStructuralSearch(t *testing.T) {
 	}
 }
 
+func TestErrorToAlertQueryCostLimit(t *testing.T) {
+	o := &Observer{Logger: logtest.Scoped(t)}
+
+	alert, err := o.errorToAlert(context.Background(), errors.Wrap(&ErrQueryCostLimit{Cost: 50000, Limit: 10000, Commits: true}, "admission"))
+	require.NoError(t, err)
+	require.Equal(t, "query_cost_limit", alert.PrometheusType)
+	require.Equal(t, "This search is estimated to cost 50000, which exceeds the limit of 10000 set by your site administrator. Try searching fewer repositories with the `repo:` filter, or fewer commits with the `after:` and `before:` filters.", alert.Description)
+
+	alert, err = o.errorToAlert(context.Background(), &ErrQueryCostLimit{Cost: 600, Limit: 1000, Running: 800})
+	require.NoError(t, err)
+	require.Equal(t, "query_cost_limit__per_user", alert.PrometheusType)
+}
+
 func TestAlertForNoResolvedReposWithNonGlobalSearchContext(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, nil)
@@ -217,3 +217,15 @@ func TestQuoteSuggestions(t *testing.T) {
 		}
 	})
 }
+
+func TestAlertForQuery_Macro(t *testing.T) {
+	raw := "@a foo"
+	_, err := query.Pipeline(query.InitWithMacros(raw, query.SearchTypeStandard, query.Macros{"a": "@a"}))
+	if err == nil {
+		t.Fatalf("expected an error for the recursive macro in %q", raw)
+	}
+	alert := AlertForQuery(raw, err)
+	if alert.PrometheusType != "invalid_macro" {
+		t.Errorf("got alert type %q, want invalid_macro", alert.PrometheusType)
+	}
+}
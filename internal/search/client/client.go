@@ -99,7 +99,7 @@ func (s *searchClient) Plan(
 
 	var plan query.Plan
 	plan, err = query.Pipeline(
-		query.Init(searchQuery, searchType),
+		query.InitWithMacros(searchQuery, searchType, query.Macros(settings.SearchMacros)),
 		query.With(searchContextsQueryEnabled, substituteContextsStep),
 	)
 	if err != nil {
@@ -15,11 +15,15 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func New(child job.Job, includeOwners, excludeOwners []string) job.Job {
+// New returns a job that resolves the code owners of the file matches of its
+// child and sets them on the matches. File matches are filtered by the given
+// owners, and replaced with their owners if selectOwners is true.
+func New(child job.Job, includeOwners, excludeOwners []string, selectOwners bool) job.Job {
 	return &codeownershipJob{
 		child:         child,
 		includeOwners: includeOwners,
 		excludeOwners: excludeOwners,
+		selectOwners:  selectOwners,
 	}
 }
 
@@ -28,6 +32,9 @@ type codeownershipJob struct {
 
 	includeOwners []string
 	excludeOwners []string
+
+	// selectOwners is true for select:file.owners.
+	selectOwners bool
 }
 
 func (s *codeownershipJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
@@ -39,16 +46,24 @@ func (s *codeownershipJob) Run(ctx context.Context, clients job.RuntimeClients,
 		errs error
 	)
 
+	includeOwners, err := resolveOwners(ctx, clients.DB, s.includeOwners)
+	if err != nil {
+		return nil, err
+	}
+
 	rules := NewRulesCache()
 
 	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
 		var err error
-		event.Results, err = applyCodeOwnershipFiltering(ctx, clients.Gitserver, &rules, s.includeOwners, s.excludeOwners, event.Results)
+		event.Results, err = applyCodeOwnershipFiltering(ctx, clients.Gitserver, &rules, includeOwners, s.excludeOwners, event.Results)
 		if err != nil {
 			mu.Lock()
 			errs = errors.Append(errs, err)
 			mu.Unlock()
 		}
+		if s.selectOwners {
+			event.Results = selectOwners(event.Results)
+		}
 		stream.Send(event)
 	})
 
@@ -71,6 +86,7 @@ func (s *codeownershipJob) Fields(v job.Verbosity) (res []otlog.Field) {
 		res = append(res,
 			trace.Strings("includeOwners", s.includeOwners),
 			trace.Strings("excludeOwners", s.excludeOwners),
+			otlog.Bool("selectOwners", s.selectOwners),
 		)
 	}
 	return res
@@ -90,7 +106,7 @@ func applyCodeOwnershipFiltering(
 	ctx context.Context,
 	gitserver gitserver.Client,
 	rules *RulesCache,
-	includeOwners,
+	includeOwners [][]string,
 	excludeOwners []string,
 	matches []result.Match) ([]result.Match, error) {
 	var errs error
@@ -99,9 +115,13 @@ func applyCodeOwnershipFiltering(
 
 matchesLoop:
 	for _, m := range matches {
-		// Code ownership is currently only implemented for files.
+		// Code ownership is currently only implemented for files, so other
+		// matches are only kept if they are not filtered by owner.
 		mm, ok := m.(*result.FileMatch)
 		if !ok {
+			if len(includeOwners) == 0 {
+				filtered = append(filtered, m)
+			}
 			continue
 		}
 
@@ -116,22 +136,45 @@ matchesLoop:
 			errs = errors.Append(errs, err)
 		}
 
-		for _, owner := range includeOwners {
-			if !containsOwner(owners, owner) {
+		for _, alternatives := range includeOwners {
+			if !containsAnyOwner(owners, alternatives) {
 				continue matchesLoop
 			}
 		}
 
+		mm.Owners = make([]string, 0, len(owners))
+		for _, o := range owners {
+			mm.Owners = append(mm.Owners, o.String())
+		}
+
 		filtered = append(filtered, m)
 	}
 
 	return filtered, errs
 }
 
-func containsOwner(owners Owners, owner string) bool {
+// selectOwners replaces the file matches with their owners. Owners are
+// deduplicated by the select job, which runs after this job.
+func selectOwners(matches []result.Match) []result.Match {
+	var selected []result.Match
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		for _, owner := range fm.Owners {
+			selected = append(selected, &result.OwnerMatch{Owner: owner})
+		}
+	}
+	return selected
+}
+
+func containsAnyOwner(owners Owners, alternatives []string) bool {
 	for _, o := range owners {
-		if o.String() == owner {
-			return true
+		for _, owner := range alternatives {
+			if o.String() == owner {
+				return true
+			}
 		}
 	}
 	return false
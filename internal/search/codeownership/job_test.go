@@ -5,12 +5,15 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hexops/autogold"
 
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 func Test_applyCodeOwnershipFiltering(t *testing.T) {
@@ -66,6 +69,7 @@ func Test_applyCodeOwnershipFiltering(t *testing.T) {
 					File: result.File{
 						Path: "README.md",
 					},
+					Owners: []string{"@sqs"},
 				},
 			}),
 		},
@@ -95,6 +99,38 @@ func Test_applyCodeOwnershipFiltering(t *testing.T) {
 					File: result.File{
 						Path: "README.md",
 					},
+					Owners: []string{"@sqs"},
+				},
+			}),
+		},
+		{
+			name: "sets owners without filtering by owner",
+			args: args{
+				includeOwners: []string{},
+				excludeOwners: []string{},
+				matches: []result.Match{
+					&result.FileMatch{
+						File: result.File{
+							Path: "README.md",
+						},
+					},
+					&result.RepoMatch{
+						Name: "github.com/sourcegraph/sourcegraph",
+					},
+				},
+				repoContent: map[string]string{
+					"CODEOWNERS": "README.md @sqs test@example.com\n",
+				},
+			},
+			want: autogold.Want("results with owners", []result.Match{
+				&result.FileMatch{
+					File: result.File{
+						Path: "README.md",
+					},
+					Owners: []string{"@sqs", "test@example.com"},
+				},
+				&result.RepoMatch{
+					Name: "github.com/sourcegraph/sourcegraph",
 				},
 			}),
 		},
@@ -115,9 +151,61 @@ func Test_applyCodeOwnershipFiltering(t *testing.T) {
 			}
 			t.Cleanup(func() { gitserver.Mocks.ReadFile = nil })
 
-			matches, _ := applyCodeOwnershipFiltering(ctx, gitserver.NewClient(db), &rules, tt.args.includeOwners, tt.args.excludeOwners, tt.args.matches)
+			includeOwners, err := resolveOwners(ctx, db, tt.args.includeOwners)
+			if err != nil {
+				t.Fatal(err)
+			}
+			matches, _ := applyCodeOwnershipFiltering(ctx, gitserver.NewClient(db), &rules, includeOwners, tt.args.excludeOwners, tt.args.matches)
 
 			tt.want.Equal(t, matches)
 		})
 	}
 }
+
+func Test_resolveOwners(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 1, Username: "alice"}, nil)
+	userEmails := database.NewMockUserEmailsStore()
+	userEmails.ListByUserFunc.SetDefaultHook(func(_ context.Context, opts database.UserEmailsListOptions) ([]*database.UserEmail, error) {
+		if !opts.OnlyVerified {
+			t.Error("expected only verified emails to be listed")
+		}
+		return []*database.UserEmail{{UserID: 1, Email: "alice@example.com"}}, nil
+	})
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserEmailsFunc.SetDefaultReturn(userEmails)
+
+	t.Run("expands @me", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+		got, err := resolveOwners(ctx, db, []string{"@sqs", "@me"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := [][]string{{"@sqs"}, {"@alice", "alice@example.com"}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected owners (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("@me requires a user", func(t *testing.T) {
+		if _, err := resolveOwners(context.Background(), db, []string{"@me"}); err == nil {
+			t.Error("expected error for anonymous user")
+		}
+	})
+}
+
+func Test_selectOwners(t *testing.T) {
+	got := selectOwners([]result.Match{
+		&result.FileMatch{File: result.File{Path: "README.md"}, Owners: []string{"@sqs", "test@example.com"}},
+		&result.FileMatch{File: result.File{Path: "main.go"}},
+		&result.RepoMatch{Name: "github.com/sourcegraph/sourcegraph"},
+	})
+	want := []result.Match{
+		&result.OwnerMatch{Owner: "@sqs"},
+		&result.OwnerMatch{Owner: "test@example.com"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected matches (-want +got):\n%s", diff)
+	}
+}
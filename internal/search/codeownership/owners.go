@@ -0,0 +1,66 @@
+package codeownership
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// meOwner is the owner in file:has.owner(...) that stands for the user running
+// the search.
+const meOwner = "@me"
+
+// resolveOwners returns, for each of the given owners, the owners in CODEOWNERS
+// files that match it. @me matches the handle and the verified emails of the
+// current user, every other owner only matches itself.
+func resolveOwners(ctx context.Context, db database.DB, owners []string) ([][]string, error) {
+	resolved := make([][]string, 0, len(owners))
+	var me []string
+	for _, owner := range owners {
+		if owner != meOwner {
+			resolved = append(resolved, []string{owner})
+			continue
+		}
+
+		if me == nil {
+			var err error
+			if me, err = currentUserOwners(ctx, db); err != nil {
+				return nil, err
+			}
+		}
+		resolved = append(resolved, me)
+	}
+	return resolved, nil
+}
+
+// currentUserOwners returns the owners that refer to the current user in
+// CODEOWNERS files: their handle and their verified emails.
+func currentUserOwners(ctx context.Context, db database.DB) ([]string, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, errors.Errorf("file:has.owner(%s) can only be used by signed-in users", meOwner)
+	}
+
+	user, err := db.Users().GetByID(ctx, a.UID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting current user")
+	}
+	// 🚨 SECURITY: Only verified emails are used, since anyone can add an
+	// unverified email to their account.
+	emails, err := db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{
+		UserID:       user.ID,
+		OnlyVerified: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing verified emails of current user")
+	}
+
+	owners := make([]string, 0, len(emails)+1)
+	owners = append(owners, "@"+user.Username)
+	for _, email := range emails {
+		owners = append(owners, email.Email)
+	}
+	return owners, nil
+}
@@ -0,0 +1,51 @@
+package exportstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigDefaults(t *testing.T) {
+	config := Config{}
+	config.SetMockGetter(mapGetter(nil))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.Backend != "minio" {
+		t.Errorf("unexpected value for Backend. want=%s have=%s", "minio", config.Backend)
+	}
+	if config.Bucket != "search-exports" {
+		t.Errorf("unexpected value for Bucket. want=%s have=%s", "search-exports", config.Bucket)
+	}
+	if config.TTL != 24*time.Hour {
+		t.Errorf("unexpected value for TTL. want=%v have=%v", 24*time.Hour, config.TTL)
+	}
+	if config.S3Endpoint != "http://minio:9000" {
+		t.Errorf("unexpected value for S3.Endpoint. want=%s have=%s", "http://minio:9000", config.S3Endpoint)
+	}
+}
+
+func TestConfigInvalidBackend(t *testing.T) {
+	config := Config{}
+	config.SetMockGetter(mapGetter(map[string]string{
+		"SEARCH_EXPORT_UPLOAD_BACKEND": "ftp",
+	}))
+	config.Load()
+
+	if err := config.Validate(); err == nil {
+		t.Fatal("expected a validation error")
+	}
+}
+
+func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
+	return func(name, defaultValue, description string) string {
+		if v, ok := env[name]; ok {
+			return v
+		}
+
+		return defaultValue
+	}
+}
@@ -0,0 +1,184 @@
+package federated
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// toMatch converts a match event of the remote with the given name into a
+// match with the remote as its source. The IDs of repositories are IDs on the
+// remote. It returns nil for match events that cannot be converted.
+func toMatch(source string, event streamhttp.EventMatch) result.Match {
+	switch e := event.(type) {
+	case *streamhttp.EventContentMatch:
+		fm := &result.FileMatch{
+			File:         toFile(source, e.RepositoryID, e.Repository, e.RepoStars, e.Commit, e.Path, e.Branches),
+			PathMatches:  toRanges(e.PathMatches),
+			RevisionDiff: result.RevisionDiffKind(e.RevisionDiff),
+		}
+		for _, cm := range e.ChunkMatches {
+			fm.ChunkMatches = append(fm.ChunkMatches, result.ChunkMatch{
+				Content:      cm.Content,
+				ContentStart: toLocation(cm.ContentStart),
+				Ranges:       toRanges(cm.Ranges),
+			})
+		}
+		return fm
+
+	case *streamhttp.EventPathMatch:
+		return &result.FileMatch{
+			File:         toFile(source, e.RepositoryID, e.Repository, e.RepoStars, e.Commit, e.Path, e.Branches),
+			PathMatches:  toRanges(e.PathMatches),
+			RevisionDiff: result.RevisionDiffKind(e.RevisionDiff),
+		}
+
+	case *streamhttp.EventSymbolMatch:
+		fm := &result.FileMatch{
+			File: toFile(source, e.RepositoryID, e.Repository, e.RepoStars, e.Commit, e.Path, e.Branches),
+		}
+		for _, sym := range e.Symbols {
+			fm.Symbols = append(fm.Symbols, &result.SymbolMatch{
+				File: &fm.File,
+				Symbol: result.Symbol{
+					Name:   sym.Name,
+					Path:   e.Path,
+					Line:   int(sym.Line),
+					Kind:   strings.ToLower(sym.Kind),
+					Parent: sym.ContainerName,
+				},
+			})
+		}
+		return fm
+
+	case *streamhttp.EventRepoMatch:
+		rm := &result.RepoMatch{
+			Name:               api.RepoName(e.Repository),
+			ID:                 api.RepoID(e.RepositoryID),
+			DescriptionMatches: toRanges(e.DescriptionMatches),
+			RepoNameMatches:    toRanges(e.RepositoryMatches),
+			Source:             source,
+		}
+		if len(e.Branches) > 0 {
+			rm.Rev = e.Branches[0]
+		}
+		return rm
+
+	case *streamhttp.EventCommitMatch:
+		cm := &result.CommitMatch{
+			Commit: gitdomain.Commit{
+				ID:      api.CommitID(e.OID),
+				Author:  gitdomain.Signature{Name: e.AuthorName, Date: e.AuthorDate},
+				Message: gitdomain.Message(e.Message),
+			},
+			Repo: types.MinimalRepo{
+				ID:    api.RepoID(e.RepositoryID),
+				Name:  api.RepoName(e.Repository),
+				Stars: e.RepoStars,
+			},
+			Source: source,
+		}
+		if preview, ok := toPreview(e.Content, e.Ranges, diffPreviewPrefix); ok {
+			cm.DiffPreview = preview
+		} else if preview, ok := toPreview(e.Content, e.Ranges, messagePreviewPrefix); ok {
+			cm.MessagePreview = preview
+		} else {
+			cm.MessagePreview = &result.MatchedString{Content: e.Message}
+		}
+		return cm
+
+	case *streamhttp.EventOwnerMatch:
+		return &result.OwnerMatch{Owner: e.Owner}
+
+	default:
+		return nil
+	}
+}
+
+func toFile(source string, repoID int32, repoName string, repoStars int, commit, path string, branches []string) result.File {
+	f := result.File{
+		Repo: types.MinimalRepo{
+			ID:    api.RepoID(repoID),
+			Name:  api.RepoName(repoName),
+			Stars: repoStars,
+		},
+		CommitID: api.CommitID(commit),
+		Path:     path,
+		Source:   source,
+	}
+	if len(branches) > 0 {
+		f.InputRev = &branches[0]
+	}
+	return f
+}
+
+func toLocation(l streamhttp.Location) result.Location {
+	return result.Location{
+		Offset: l.Offset,
+		Line:   l.Line,
+		Column: l.Column,
+	}
+}
+
+func toRanges(rs []streamhttp.Range) result.Ranges {
+	if len(rs) == 0 {
+		return nil
+	}
+	res := make(result.Ranges, 0, len(rs))
+	for _, r := range rs {
+		res = append(res, result.Range{
+			Start: toLocation(r.Start),
+			End:   toLocation(r.End),
+		})
+	}
+	return res
+}
+
+// The content of commit match events is the body of the commit match, which
+// is its diff or message preview in a Markdown code block. See
+// (*result.CommitMatch).Body.
+const (
+	diffPreviewPrefix    = "```diff\n"
+	messagePreviewPrefix = "```COMMIT_EDITMSG\n"
+	previewSuffix        = "\n```"
+)
+
+// toPreview converts the content and highlighted ranges of a commit match event
+// back into the preview of a commit match, if the content is a code block that
+// starts with prefix. Ranges are [line, character, length] triples, where the
+// first line of the preview is line 1 of the content.
+func toPreview(content string, ranges [][3]int32, prefix string) (*result.MatchedString, bool) {
+	if !strings.HasPrefix(content, prefix) || !strings.HasSuffix(content, previewSuffix) {
+		return nil, false
+	}
+	content = strings.TrimSuffix(strings.TrimPrefix(content, prefix), previewSuffix)
+
+	// lineOffsets[i] is the offset of the first character of line i.
+	lineOffsets := []int{0}
+	for i, c := range content {
+		if c == '\n' {
+			lineOffsets = append(lineOffsets, i+1)
+		}
+	}
+
+	preview := &result.MatchedString{Content: content}
+	for _, r := range ranges {
+		line, character, length := int(r[0])-1, int(r[1]), int(r[2])
+		if line < 0 || line >= len(lineOffsets) {
+			continue
+		}
+		offset := lineOffsets[line] + character
+		if offset+length > len(content) {
+			continue
+		}
+		preview.MatchedRanges = append(preview.MatchedRanges, result.Range{
+			Start: result.Location{Offset: offset, Line: line, Column: character},
+			End:   result.Location{Offset: offset + length, Line: line, Column: character + length},
+		})
+	}
+	return preview, true
+}
@@ -0,0 +1,207 @@
+package federated
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/opentracing/opentracing-go/log"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Header is set on the search requests that federated search sends to remotes.
+// Remotes do not federate these searches further, so that remotes that search
+// each other do not loop.
+const Header = "X-Sourcegraph-Federated-Search"
+
+// NewJobs returns a job for each remote that runs the original query of the
+// inputs on the remote.
+func NewJobs(inputs *search.Inputs, remotes []Remote) []job.Job {
+	jobs := make([]job.Job, 0, len(remotes))
+	for _, remote := range remotes {
+		jobs = append(jobs, &SearchJob{
+			Remote:      remote,
+			Query:       inputs.OriginalQuery,
+			PatternType: inputs.PatternType,
+			doer:        httpcli.ExternalDoer,
+		})
+	}
+	return jobs
+}
+
+// SearchJob runs a search on a remote Sourcegraph instance with the streaming
+// search API, and sends its results to the stream with the name of the remote
+// as their source.
+//
+// A remote that fails or times out does not fail the search. Its error is
+// reported in the progress of the search instead, next to the results that the
+// remote returned before it failed.
+type SearchJob struct {
+	Remote      Remote
+	Query       string
+	PatternType query.SearchType
+
+	doer httpcli.Doer
+}
+
+func (j *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	// Searches that Sourcegraph runs internally, like code insights, are only
+	// about this instance.
+	if actor.FromContext(ctx).IsInternal() {
+		return nil, nil
+	}
+
+	remoteCtx, cancel := context.WithTimeout(ctx, j.Remote.Timeout)
+	defer cancel()
+
+	progress := streamapi.RemoteProgress{}
+	alert, err = j.search(remoteCtx, stream, &progress)
+	if err != nil {
+		if ctx.Err() != nil {
+			// The search was canceled, for example because it found enough
+			// results.
+			return nil, nil
+		}
+		if remoteCtx.Err() == context.DeadlineExceeded {
+			err = errors.Errorf("timed out after %s", j.Remote.Timeout)
+		}
+		clients.Logger.Warn("federated search failed", sglog.String("remote", j.Remote.Name), sglog.Error(err))
+		progress.Error = err.Error()
+	}
+
+	stream.Send(streaming.SearchEvent{
+		Stats: streaming.Stats{
+			Remotes: map[string]streamapi.RemoteProgress{j.Remote.Name: progress},
+		},
+	})
+	return alert, nil
+}
+
+func (j *SearchJob) search(ctx context.Context, stream streaming.Sender, progress *streamapi.RemoteProgress) (alert *search.Alert, err error) {
+	req, err := streamhttp.NewRequest(j.Remote.URL+"/.api", j.Query)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("t", patternTypeParam(j.PatternType))
+	// Chunk matches can be converted to file matches without losing
+	// information, unlike line matches.
+	q.Set("cm", "t")
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("Authorization", "token "+j.Remote.Token)
+	req.Header.Set(Header, "true")
+
+	resp, err := j.doer.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+	}
+
+	var remoteErr error
+	err = streamhttp.FrontendStreamDecoder{
+		OnProgress: func(p *streamapi.Progress) {
+			if p.RepositoriesCount != nil {
+				progress.RepositoriesCount = *p.RepositoriesCount
+			}
+			progress.Skipped = p.Skipped
+			stream.Send(streaming.SearchEvent{
+				Stats: streaming.Stats{
+					Remotes: map[string]streamapi.RemoteProgress{j.Remote.Name: *progress},
+				},
+			})
+		},
+		OnMatches: func(events []streamhttp.EventMatch) {
+			matches := make(result.Matches, 0, len(events))
+			for _, e := range events {
+				if m := toMatch(j.Remote.Name, e); m != nil {
+					matches = append(matches, m)
+				}
+			}
+			stream.Send(streaming.SearchEvent{Results: matches})
+		},
+		OnAlert: func(e *streamhttp.EventAlert) {
+			alert = j.toAlert(e)
+		},
+		OnError: func(e *streamhttp.EventError) {
+			remoteErr = errors.New(e.Message)
+		},
+	}.ReadAll(resp.Body)
+	if err != nil {
+		return alert, err
+	}
+	return alert, remoteErr
+}
+
+// toAlert converts an alert of the remote into an alert of this instance,
+// with the name of the remote in its title.
+func (j *SearchJob) toAlert(e *streamhttp.EventAlert) *search.Alert {
+	proposed := make([]*search.QueryDescription, 0, len(e.ProposedQueries))
+	for _, pq := range e.ProposedQueries {
+		proposed = append(proposed, &search.QueryDescription{
+			Description: pq.Description,
+			Query:       pq.Query,
+			PatternType: j.PatternType,
+		})
+	}
+
+	return &search.Alert{
+		PrometheusType:  "federated_search_remote",
+		Title:           fmt.Sprintf("%s: %s", j.Remote.Name, e.Title),
+		Description:     e.Description,
+		Kind:            e.Kind,
+		ProposedQueries: proposed,
+	}
+}
+
+// patternTypeParam returns the value of the "t" parameter of the streaming
+// search API for the pattern type.
+func patternTypeParam(patternType query.SearchType) string {
+	if patternType == query.SearchTypeRegex {
+		return "regexp"
+	}
+	return patternType.String()
+}
+
+func (j *SearchJob) Name() string {
+	return "FederatedSearchJob"
+}
+
+func (j *SearchJob) Fields(v job.Verbosity) (res []log.Field) {
+	switch v {
+	case job.VerbosityMax:
+		res = append(res,
+			log.String("timeout", j.Remote.Timeout.String()),
+		)
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			log.String("remote", j.Remote.Name),
+			log.String("query", j.Query),
+			log.String("patternType", j.PatternType.String()),
+		)
+	}
+	return res
+}
+
+func (j *SearchJob) Children() []job.Describer       { return nil }
+func (j *SearchJob) MapChildren(job.MapFunc) job.Job { return j }
@@ -0,0 +1,189 @@
+package federated
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+)
+
+func TestSearchJob(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	clients := job.RuntimeClients{Logger: logtest.Scoped(t)}
+
+	newJob := func(url string, timeout time.Duration) *SearchJob {
+		return &SearchJob{
+			Remote:      Remote{Name: "eu", URL: url, Token: "secret", Timeout: timeout},
+			Query:       "repo:foo bar.*",
+			PatternType: query.SearchTypeRegex,
+			doer:        http.DefaultClient,
+		}
+	}
+
+	t.Run("results, progress and alerts", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/.api/search/stream", r.URL.Path)
+			assert.Equal(t, "repo:foo bar.*", r.URL.Query().Get("q"))
+			assert.Equal(t, "regexp", r.URL.Query().Get("t"))
+			assert.Equal(t, "t", r.URL.Query().Get("cm"))
+			assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+			assert.Equal(t, "true", r.Header.Get(Header))
+
+			ew, err := streamhttp.NewWriter(w)
+			require.NoError(t, err)
+			_ = ew.Event("matches", []streamhttp.EventMatch{
+				&streamhttp.EventContentMatch{
+					Type:         streamhttp.ContentMatchType,
+					RepositoryID: 5,
+					Repository:   "github.com/foo/foo",
+					Commit:       "deadbeef",
+					Path:         "main.go",
+					ChunkMatches: []streamhttp.ChunkMatch{{
+						Content: "bar()",
+						Ranges: []streamhttp.Range{{
+							End: streamhttp.Location{Offset: 3, Column: 3},
+						}},
+					}},
+				},
+				&streamhttp.EventRepoMatch{
+					Type:         streamhttp.RepoMatchType,
+					RepositoryID: 5,
+					Repository:   "github.com/foo/foo",
+				},
+			})
+			_ = ew.Event("progress", streamapi.Progress{
+				RepositoriesCount: intPtr(3),
+				MatchCount:        2,
+			})
+			_ = ew.Event("alert", streamhttp.EventAlert{
+				Title:           "Some repositories are missing",
+				ProposedQueries: []streamhttp.QueryDescription{{Query: "repo:foo bar"}},
+			})
+			_ = ew.Event("done", map[string]any{})
+		}))
+		t.Cleanup(srv.Close)
+
+		stream := streaming.NewAggregatingStream()
+		alert, err := newJob(srv.URL, time.Minute).Run(ctx, clients, stream)
+		require.NoError(t, err)
+
+		require.NotNil(t, alert)
+		assert.Equal(t, "eu: Some repositories are missing", alert.Title)
+		require.Len(t, alert.ProposedQueries, 1)
+		assert.Equal(t, query.SearchTypeRegex, alert.ProposedQueries[0].PatternType)
+
+		require.Len(t, stream.Results, 2)
+		fm := stream.Results[0].(*result.FileMatch)
+		assert.Equal(t, "eu", fm.Source)
+		assert.Equal(t, api.RepoName("github.com/foo/foo"), fm.Repo.Name)
+		assert.Equal(t, 1, fm.ResultCount())
+		assert.Equal(t, "eu", result.Source(stream.Results[1]))
+
+		assert.Equal(t, map[string]streamapi.RemoteProgress{"eu": {RepositoriesCount: 3}}, stream.Stats.Remotes)
+	})
+
+	t.Run("failing remote", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid access token", http.StatusUnauthorized)
+		}))
+		t.Cleanup(srv.Close)
+
+		stream := streaming.NewAggregatingStream()
+		alert, err := newJob(srv.URL, time.Minute).Run(ctx, clients, stream)
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+		assert.Contains(t, stream.Stats.Remotes["eu"].Error, "unexpected status code 401")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ew, err := streamhttp.NewWriter(w)
+			require.NoError(t, err)
+			_ = ew.Event("matches", []streamhttp.EventMatch{
+				&streamhttp.EventPathMatch{
+					Type:       streamhttp.PathMatchType,
+					Repository: "github.com/foo/foo",
+					Path:       "main.go",
+				},
+			})
+			<-r.Context().Done()
+		}))
+		t.Cleanup(srv.Close)
+
+		stream := streaming.NewAggregatingStream()
+		_, err := newJob(srv.URL, 100*time.Millisecond).Run(ctx, clients, stream)
+		require.NoError(t, err)
+
+		// Results that the remote returned in time are kept.
+		assert.Len(t, stream.Results, 1)
+		assert.Equal(t, "timed out after 100ms", stream.Stats.Remotes["eu"].Error)
+	})
+
+	t.Run("internal actor", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected request")
+		}))
+		t.Cleanup(srv.Close)
+
+		stream := streaming.NewAggregatingStream()
+		_, err := newJob(srv.URL, time.Minute).Run(actor.WithInternalActor(context.Background()), clients, stream)
+		require.NoError(t, err)
+		assert.True(t, stream.Stats.Zero())
+	})
+}
+
+func TestToPreview(t *testing.T) {
+	for _, diff := range []bool{false, true} {
+		preview := &result.MatchedString{
+			Content: "first line\nsecond match line",
+			MatchedRanges: result.Ranges{{
+				Start: result.Location{Offset: 18, Line: 1, Column: 7},
+				End:   result.Location{Offset: 23, Line: 1, Column: 12},
+			}},
+		}
+		cm := &result.CommitMatch{Commit: gitdomain.Commit{ID: "deadbeef"}}
+		if diff {
+			cm.DiffPreview = preview
+		} else {
+			cm.MessagePreview = preview
+		}
+
+		hls := cm.Body().ToHighlightedString()
+		ranges := make([][3]int32, 0, len(hls.Highlights))
+		for _, h := range hls.Highlights {
+			ranges = append(ranges, [3]int32{h.Line, h.Character, h.Length})
+		}
+
+		got := toMatch("eu", &streamhttp.EventCommitMatch{
+			OID:     "deadbeef",
+			Content: hls.Value,
+			Ranges:  ranges,
+		}).(*result.CommitMatch)
+
+		if diff {
+			assert.Nil(t, got.MessagePreview)
+			assert.Equal(t, preview, got.DiffPreview)
+		} else {
+			assert.Nil(t, got.DiffPreview)
+			assert.Equal(t, preview, got.MessagePreview)
+		}
+		assert.Equal(t, "eu", got.Source)
+	}
+}
+
+func intPtr(i int) *int { return &i }
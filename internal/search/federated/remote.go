@@ -0,0 +1,92 @@
+// Package federated implements federated search, which sends searches to
+// remote Sourcegraph instances and merges their results into the results of
+// this instance.
+package federated
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+const defaultTimeout = 20 * time.Second
+
+// Remote is a remote Sourcegraph instance that federated search sends
+// searches to.
+type Remote struct {
+	// Name labels the results of the remote.
+	Name string
+	// URL is the URL of the remote, without a trailing slash.
+	URL string
+	// Token is an access token of a user on the remote.
+	Token string
+	// Timeout is the maximum time to wait for the results of the remote.
+	Timeout time.Duration
+}
+
+// Remotes returns the remotes in the site configuration.
+func Remotes(c *conf.Unified) []Remote {
+	if c.SearchFederation == nil {
+		return nil
+	}
+
+	remotes := make([]Remote, 0, len(c.SearchFederation.Remotes))
+	for _, r := range c.SearchFederation.Remotes {
+		timeout := defaultTimeout
+		if r.TimeoutSeconds > 0 {
+			timeout = time.Duration(r.TimeoutSeconds) * time.Second
+		}
+		remotes = append(remotes, Remote{
+			Name:    r.Name,
+			URL:     strings.TrimSuffix(r.Url, "/"),
+			Token:   r.Token,
+			Timeout: timeout,
+		})
+	}
+	return remotes
+}
+
+// Allowed returns whether the searches of the user in ctx are federated. The
+// remotes return everything that the users of their access tokens can see,
+// regardless of the permissions of the user on this instance, so only site
+// admins and the users and organizations that are allowed in the site
+// configuration can run federated searches.
+func Allowed(ctx context.Context, db database.DB, c *conf.Unified) (bool, error) {
+	a := actor.FromContext(ctx)
+	if c.SearchFederation == nil || len(c.SearchFederation.Remotes) == 0 || !a.IsAuthenticated() || a.IsInternal() {
+		return false, nil
+	}
+
+	user, err := db.Users().GetByID(ctx, a.UID)
+	if err != nil {
+		return false, err
+	}
+	if user.SiteAdmin {
+		return true, nil
+	}
+	for _, username := range c.SearchFederation.AllowedUsers {
+		if username == user.Username {
+			return true, nil
+		}
+	}
+
+	if len(c.SearchFederation.AllowedOrgs) == 0 {
+		return false, nil
+	}
+	orgs, err := db.Orgs().GetByUserID(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, org := range orgs {
+		for _, name := range c.SearchFederation.AllowedOrgs {
+			if org.Name == name {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,71 @@
+package federated
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAllowed(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		return map[int32]*types.User{
+			1: {ID: 1, Username: "admin", SiteAdmin: true},
+			2: {ID: 2, Username: "alice"},
+			3: {ID: 3, Username: "bob"},
+			4: {ID: 4, Username: "carol"},
+		}[id], nil
+	})
+	orgs := database.NewMockOrgStore()
+	orgs.GetByUserIDFunc.SetDefaultHook(func(_ context.Context, userID int32) ([]*types.Org, error) {
+		if userID == 3 {
+			return []*types.Org{{Name: "security"}}, nil
+		}
+		return nil, nil
+	})
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.OrgsFunc.SetDefaultReturn(orgs)
+
+	c := &conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		SearchFederation: &schema.SearchFederation{
+			AllowedUsers: []string{"alice"},
+			AllowedOrgs:  []string{"security"},
+			Remotes:      []*schema.SearchFederationRemote{{Name: "eu", Url: "https://eu.example.com", Token: "secret"}},
+		},
+	}}
+
+	for _, tc := range []struct {
+		name  string
+		actor *actor.Actor
+		want  bool
+	}{
+		{name: "anonymous", actor: &actor.Actor{}, want: false},
+		{name: "internal", actor: &actor.Actor{Internal: true}, want: false},
+		{name: "site admin", actor: actor.FromUser(1), want: true},
+		{name: "allowed user", actor: actor.FromUser(2), want: true},
+		{name: "member of allowed org", actor: actor.FromUser(3), want: true},
+		{name: "other user", actor: actor.FromUser(4), want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			allowed, err := Allowed(actor.WithActor(context.Background(), tc.actor), db, c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, allowed)
+		})
+	}
+
+	t.Run("no remotes", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+		allowed, err := Allowed(ctx, db, &conf.Unified{})
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+}
@@ -12,6 +12,9 @@ const (
 	File       = "file"
 	Repository = "repo"
 	Symbol     = "symbol"
+
+	// Owners selects the code owners of file results, as in select:file.owners.
+	Owners = "owners"
 )
 
 // SelectPath represents a parsed and validated select value
@@ -43,6 +46,7 @@ var validSelectors = object{
 	File: {
 		"directory": nil,
 		"path":      nil,
+		Owners:      nil,
 	},
 	Repository: nil,
 	Symbol: object{
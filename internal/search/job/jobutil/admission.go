@@ -0,0 +1,167 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	searchalert "github.com/sourcegraph/sourcegraph/internal/search/alert"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/limits"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// hasQueryCostLimits returns true if the site configuration limits the
+// cost of searches.
+func hasQueryCostLimits(l schema.SearchLimits) bool {
+	return l.MaxQueryCost > 0 || l.MaxQueryCostPerUser > 0
+}
+
+// NewAdmissionJob creates a job that estimates the cost of child before
+// running it, and enforces the query cost limits of the site configuration.
+// Searches that exceed search.limits.maxQueryCost are rejected. Searches
+// that would exceed search.limits.maxQueryCostPerUser together with the other
+// searches of the user are queued or rejected.
+func NewAdmissionJob(child job.Job) job.Job {
+	return &admissionJob{child: child}
+}
+
+type admissionJob struct {
+	child job.Job
+}
+
+func (j *admissionJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	tr, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	l := limits.SearchLimits(conf.Get())
+	a := actor.FromContext(ctx)
+	if !hasQueryCostLimits(l) || a.IsInternal() {
+		return j.child.Run(ctx, clients, stream)
+	}
+
+	cost, err := EstimateCost(ctx, clients, j.child)
+	if err != nil {
+		return nil, err
+	}
+	tr.LogFields(log.Int("cost", cost.Units), log.Int("repos", cost.Repos))
+
+	if l.MaxQueryCost > 0 && cost.Units > l.MaxQueryCost {
+		return nil, &searchalert.ErrQueryCostLimit{
+			Cost:      cost.Units,
+			Limit:     l.MaxQueryCost,
+			Unindexed: cost.Unindexed,
+			Commits:   cost.Commits,
+		}
+	}
+
+	if l.MaxQueryCostPerUser > 0 {
+		var maxWait time.Duration
+		if l.QueryCostLimitAction == "queue" {
+			maxWait = time.Duration(l.MaxTimeoutSeconds) * time.Second
+		}
+		release, err := runningQueryCosts.acquire(ctx, actorKey(a), cost.Units, l.MaxQueryCostPerUser, maxWait)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	return j.child.Run(ctx, clients, stream)
+}
+
+func (j *admissionJob) Name() string {
+	return "AdmissionJob"
+}
+
+func (j *admissionJob) Fields(job.Verbosity) []log.Field { return nil }
+
+func (j *admissionJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *admissionJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// actorKey identifies the user whose searches share a budget in
+// runningQueryCosts.
+func actorKey(a *actor.Actor) string {
+	if a.IsAuthenticated() {
+		return a.UIDString()
+	}
+	return "anonymous:" + a.AnonymousUID
+}
+
+// runningQueryCosts tracks the cost of the searches that are running in this
+// process. The budget of search.limits.maxQueryCostPerUser is not shared
+// between frontend replicas, so a user's searches are only limited per
+// replica.
+var runningQueryCosts = newQueryCosts()
+
+type queryCosts struct {
+	mu      sync.Mutex
+	running map[string]int
+	// released is closed and replaced whenever a search releases its cost.
+	released chan struct{}
+}
+
+func newQueryCosts() *queryCosts {
+	return &queryCosts{
+		running:  map[string]int{},
+		released: make(chan struct{}),
+	}
+}
+
+// acquire adds cost to the cost of the searches that user is running, if the
+// total stays within limit or the user is not running other searches. Otherwise
+// it waits for up to maxWait for other searches to release their cost. The
+// returned function must be called when the search is done.
+func (q *queryCosts) acquire(ctx context.Context, user string, cost, limit int, maxWait time.Duration) (release func(), err error) {
+	timer := time.NewTimer(maxWait)
+	defer timer.Stop()
+
+	for {
+		q.mu.Lock()
+		running := q.running[user]
+		if running == 0 || running+cost <= limit {
+			q.running[user] = running + cost
+			q.mu.Unlock()
+			return func() { q.release(user, cost) }, nil
+		}
+		released := q.released
+		q.mu.Unlock()
+
+		errLimit := &searchalert.ErrQueryCostLimit{Cost: cost, Limit: limit, Running: running}
+		if maxWait <= 0 {
+			return nil, errLimit
+		}
+		select {
+		case <-released:
+		case <-timer.C:
+			return nil, errLimit
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (q *queryCosts) release(user string, cost int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.running[user] -= cost
+	if q.running[user] <= 0 {
+		delete(q.running, user)
+	}
+	close(q.released)
+	q.released = make(chan struct{})
+}
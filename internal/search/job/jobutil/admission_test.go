@@ -0,0 +1,75 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	searchalert "github.com/sourcegraph/sourcegraph/internal/search/alert"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestQueryCosts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("first search always runs", func(t *testing.T) {
+		q := newQueryCosts()
+		release, err := q.acquire(ctx, "1", 500, 100, 0)
+		require.NoError(t, err)
+		release()
+		require.Empty(t, q.running)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		q := newQueryCosts()
+		release, err := q.acquire(ctx, "1", 60, 100, 0)
+		require.NoError(t, err)
+		defer release()
+
+		// Other users have their own budget.
+		releaseOther, err := q.acquire(ctx, "2", 60, 100, 0)
+		require.NoError(t, err)
+		defer releaseOther()
+
+		_, err = q.acquire(ctx, "1", 60, 100, 0)
+		var e *searchalert.ErrQueryCostLimit
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, searchalert.ErrQueryCostLimit{Cost: 60, Limit: 100, Running: 60}, *e)
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		q := newQueryCosts()
+		release, err := q.acquire(ctx, "1", 60, 100, 0)
+		require.NoError(t, err)
+
+		acquired := make(chan error)
+		go func() {
+			releaseQueued, err := q.acquire(ctx, "1", 60, 100, time.Minute)
+			if err == nil {
+				releaseQueued()
+			}
+			acquired <- err
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("queued search ran before the running search released its cost")
+		case <-time.After(10 * time.Millisecond):
+		}
+		release()
+		require.NoError(t, <-acquired)
+	})
+
+	t.Run("queue times out", func(t *testing.T) {
+		q := newQueryCosts()
+		release, err := q.acquire(ctx, "1", 60, 100, 0)
+		require.NoError(t, err)
+		defer release()
+
+		_, err = q.acquire(ctx, "1", 60, 100, time.Millisecond)
+		var e *searchalert.ErrQueryCostLimit
+		require.True(t, errors.As(err, &e))
+	})
+}
@@ -0,0 +1,173 @@
+package jobutil
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/structural"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+)
+
+// The cost of searching one repository, by how it is searched. The site
+// configuration documents these weights for search.limits.maxQueryCost.
+const (
+	costIndexedRepo   = 1
+	costUnindexedRepo = 10
+	costCommitRepo    = 20
+	costDiffRepo      = 50
+)
+
+// Cost is an estimate of how expensive a job is to run.
+type Cost struct {
+	// Units is the estimated cost of the job, where searching one indexed
+	// repository costs one unit.
+	Units int
+
+	// Repos is the largest number of repositories that one of the jobs in
+	// the job tree searches.
+	Repos int
+
+	// Unindexed is true if the job searches repositories without the index.
+	Unindexed bool
+
+	// Commits is true if the job searches commits or diffs.
+	Commits bool
+}
+
+func (c Cost) add(other Cost) Cost {
+	c.Units += other.Units
+	if other.Repos > c.Repos {
+		c.Repos = other.Repos
+	}
+	c.Unindexed = c.Unindexed || other.Unindexed
+	c.Commits = c.Commits || other.Commits
+	return c
+}
+
+// EstimateCost estimates the cost of running j, without running it. The
+// estimate is based on how many repositories the jobs in j search, and on how
+// expensive it is to search one repository with each job.
+func EstimateCost(ctx context.Context, clients job.RuntimeClients, j job.Job) (Cost, error) {
+	resolver := searchrepos.NewResolver(clients.Logger, clients.DB, clients.SearcherURLs, clients.Zoekt)
+	return estimateCost(ctx, j, resolver.Count)
+}
+
+type countReposFunc func(context.Context, search.RepoOptions) (int, error)
+
+func estimateCost(ctx context.Context, j job.Describer, countRepos countReposFunc) (Cost, error) {
+	// Many jobs of a job tree search the same repositories, so we only count
+	// them once.
+	counts := map[string]int{}
+	count := func(opts search.RepoOptions) (int, error) {
+		key := opts.String()
+		if n, ok := counts[key]; ok {
+			return n, nil
+		}
+		n, err := countRepos(ctx, opts)
+		if err != nil {
+			return 0, err
+		}
+		counts[key] = n
+		return n, nil
+	}
+
+	// NewBasicJob searches repositories with separate pagers for Zoekt and
+	// searcher, and the searcher pager only searches the repositories that
+	// the Zoekt pager with the same options does not find in the index.
+	indexedPagers := map[string]bool{}
+	job.VisitType(j, func(p *repoPagerJob) {
+		if indexed, _ := pagerJobKinds(p); indexed && p.repoOpts.UseIndex != query.No {
+			indexedPagers[p.repoOpts.String()] = true
+		}
+	})
+
+	var visit func(job.Describer) (Cost, error)
+	visit = func(j job.Describer) (Cost, error) {
+		var (
+			opts   search.RepoOptions
+			weight int
+		)
+		switch v := j.(type) {
+		case *repoPagerJob:
+			opts, weight = v.repoOpts, repoPagerCost(v, indexedPagers[v.repoOpts.String()])
+		case *zoekt.GlobalTextSearchJob:
+			opts, weight = v.RepoOpts, costIndexedRepo
+		case *zoekt.GlobalSymbolSearchJob:
+			opts, weight = v.RepoOpts, costIndexedRepo
+		case *structural.SearchJob:
+			// Structural search matches files with searcher, even in indexed
+			// repositories.
+			opts, weight = v.RepoOpts, costUnindexedRepo
+		case *commit.SearchJob:
+			opts, weight = v.RepoOpts, costCommitRepo
+			if v.Diff {
+				weight = costDiffRepo
+			}
+		default:
+			var cost Cost
+			for _, child := range j.Children() {
+				childCost, err := visit(child)
+				if err != nil {
+					return Cost{}, err
+				}
+				cost = cost.add(childCost)
+			}
+			return cost, nil
+		}
+
+		n, err := count(opts)
+		if err != nil {
+			return Cost{}, err
+		}
+		return Cost{
+			Units:     n * weight,
+			Repos:     n,
+			Unindexed: weight == costUnindexedRepo,
+			Commits:   weight == costCommitRepo || weight == costDiffRepo,
+		}, nil
+	}
+	return visit(j)
+}
+
+// repoPagerCost returns the cost of searching one repository with the jobs
+// that p runs on each page of repositories. Repositories are searched with
+// the index unless the query says otherwise or they are not indexed. If p
+// only has unindexed jobs but a sibling pager searches the index, p only
+// searches the few repositories missing from the index, which we don't count
+// without resolving them, so p costs nothing on top of its sibling.
+func repoPagerCost(p *repoPagerJob, indexedSibling bool) int {
+	indexed, unindexed := pagerJobKinds(p)
+	switch {
+	case p.repoOpts.UseIndex == query.No:
+		// The indexed jobs of p don't get any repositories.
+		if unindexed {
+			return costUnindexedRepo
+		}
+		return 0
+	case indexed:
+		return costIndexedRepo
+	case indexedSibling:
+		return 0
+	default:
+		return costUnindexedRepo
+	}
+}
+
+// pagerJobKinds returns whether p searches the index, and whether it searches
+// repositories without the index.
+func pagerJobKinds(p *repoPagerJob) (indexed, unindexed bool) {
+	job.Visit(p, func(d job.Describer) {
+		switch d.(type) {
+		case *zoekt.RepoSubsetTextSearchJob, *zoekt.SymbolSearchJob:
+			indexed = true
+		case *searcher.TextSearchJob, *searcher.SymbolSearchJob:
+			unindexed = true
+		}
+	})
+	return indexed, unindexed
+}
@@ -0,0 +1,143 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestEstimateCost(t *testing.T) {
+	all := search.RepoOptions{RepoFilters: []string{".*"}}
+	few := search.RepoOptions{RepoFilters: []string{"^github\\.com/sourcegraph/"}}
+	unindexed := search.RepoOptions{RepoFilters: []string{".*"}, UseIndex: query.No}
+
+	var counted []string
+	countRepos := func(_ context.Context, opts search.RepoOptions) (int, error) {
+		counted = append(counted, opts.String())
+		if len(opts.RepoFilters) > 0 && opts.RepoFilters[0] == ".*" {
+			return 1000, nil
+		}
+		return 10, nil
+	}
+
+	pager := func(opts search.RepoOptions, children ...job.Job) job.Job {
+		return &repoPagerJob{
+			repoOpts: opts,
+			child:    &reposPartialJob{NewParallelJob(children...)},
+		}
+	}
+
+	cases := []struct {
+		name string
+		job  job.Job
+		want Cost
+	}{
+		{
+			name: "indexed",
+			job:  &zoekt.GlobalTextSearchJob{RepoOpts: all},
+			want: Cost{Units: 1000, Repos: 1000},
+		},
+		{
+			name: "indexed and unindexed",
+			job:  pager(few, &zoekt.RepoSubsetTextSearchJob{}, &searcher.TextSearchJob{}),
+			want: Cost{Units: 10, Repos: 10},
+		},
+		{
+			name: "unindexed",
+			job:  pager(unindexed, &searcher.TextSearchJob{}),
+			want: Cost{Units: 10000, Repos: 1000, Unindexed: true},
+		},
+		{
+			name: "commits",
+			job:  &commit.SearchJob{RepoOpts: few},
+			want: Cost{Units: 200, Repos: 10, Commits: true},
+		},
+		{
+			name: "diffs",
+			job:  &commit.SearchJob{RepoOpts: all, Diff: true},
+			want: Cost{Units: 50000, Repos: 1000, Commits: true},
+		},
+		{
+			name: "sum of jobs",
+			job: NewParallelJob(
+				&zoekt.GlobalTextSearchJob{RepoOpts: all},
+				&commit.SearchJob{RepoOpts: few},
+				&RepoSearchJob{RepoOpts: all},
+			),
+			want: Cost{Units: 1200, Repos: 1000, Commits: true},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := estimateCost(context.Background(), tc.job, countRepos)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("repos are counted once", func(t *testing.T) {
+		counted = nil
+		_, err := estimateCost(context.Background(), NewParallelJob(
+			&zoekt.GlobalTextSearchJob{RepoOpts: all},
+			&zoekt.GlobalSymbolSearchJob{RepoOpts: all},
+		), countRepos)
+		require.NoError(t, err)
+		require.Len(t, counted, 1)
+	})
+}
+
+func TestEstimateCost_BasicJob(t *testing.T) {
+	countRepos := func(context.Context, search.RepoOptions) (int, error) {
+		return 10, nil
+	}
+
+	cases := []struct {
+		query string
+		want  Cost
+	}{
+		{
+			// The searcher pager only searches the repositories that the
+			// Zoekt pager does not find in the index.
+			query: "repo:foo bar",
+			want:  Cost{Units: 10, Repos: 10},
+		},
+		{
+			query: "repo:foo bar type:symbol",
+			want:  Cost{Units: 10, Repos: 10},
+		},
+		{
+			query: "repo:foo bar index:no",
+			want:  Cost{Units: 100, Repos: 10, Unindexed: true},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := query.ParseLiteral(tc.query)
+			require.NoError(t, err)
+			b, err := query.ToBasicQuery(q)
+			require.NoError(t, err)
+
+			inputs := &search.Inputs{
+				UserSettings: &schema.Settings{},
+				PatternType:  query.SearchTypeLiteral,
+				Protocol:     search.Streaming,
+				Features:     &search.Features{},
+			}
+			j, err := NewBasicJob(inputs, b)
+			require.NoError(t, err)
+
+			got, err := estimateCost(context.Background(), j, countRepos)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	codeownershipjob "github.com/sourcegraph/sourcegraph/internal/search/codeownership"
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/federated"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/keyword"
@@ -55,11 +56,33 @@ func NewPlanJob(inputs *search.Inputs, plan query.Plan) (job.Job, error) {
 		}
 	}
 
+	if hasQueryCostLimits(limits.SearchLimits(conf.Get())) {
+		jobTree = NewAdmissionJob(jobTree)
+	}
+
+	if inputs.Federated {
+		if remotes := federated.Remotes(conf.Get()); len(remotes) > 0 {
+			jobTree = NewParallelJob(append([]job.Job{jobTree}, federated.NewJobs(inputs, remotes)...)...)
+		}
+	}
+
 	return NewAlertJob(inputs, jobTree), nil
 }
 
 // NewBasicJob converts a query.Basic into its job tree representation.
 func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
+	if head, base, ok := splitRevisionDiff(b); ok {
+		headJob, err := NewBasicJob(inputs, head)
+		if err != nil {
+			return nil, err
+		}
+		baseJob, err := NewBasicJob(inputs, base)
+		if err != nil {
+			return nil, err
+		}
+		return NewRevisionDiffJob(headJob, baseJob), nil
+	}
+
 	var children []job.Job
 	addJob := func(j job.Job) {
 		children = append(children, j)
@@ -184,8 +207,16 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 	}
 
 	{ // Apply code ownership post-search filter
-		if includeOwners, excludeOwners := b.FileHasOwner(); inputs.Features.CodeOwnershipFilters == true && (len(includeOwners) > 0 || len(excludeOwners) > 0) {
-			basicJob = codeownershipjob.New(basicJob, includeOwners, excludeOwners)
+		// Resolving ownership reads the CODEOWNERS file of every repository
+		// with results, so it is only done for queries that ask for owners.
+		// The owner filters of the results are only shown for those queries.
+		if inputs.Features.CodeOwnershipFilters {
+			includeOwners, excludeOwners := b.FileHasOwner()
+			v, _ := b.ToParseTree().StringValue(query.FieldSelect)
+			selectOwners := v == filter.File+"."+filter.Owners
+			if len(includeOwners) > 0 || len(excludeOwners) > 0 || selectOwners {
+				basicJob = codeownershipjob.New(basicJob, includeOwners, excludeOwners, selectOwners)
+			}
 		}
 	}
 
@@ -683,6 +683,36 @@ func TestNewPlanJob(t *testing.T) {
 	}
 }
 
+func TestNewPlanJob_CodeOwnership(t *testing.T) {
+	hasCodeOwnershipJob := func(q string) bool {
+		plan, err := query.Pipeline(query.Init(q, query.SearchTypeLiteral))
+		require.NoError(t, err)
+
+		inputs := &search.Inputs{
+			UserSettings:        &schema.Settings{},
+			PatternType:         query.SearchTypeLiteral,
+			Protocol:            search.Streaming,
+			Features:            &search.Features{CodeOwnershipFilters: true},
+			OnSourcegraphDotCom: true,
+		}
+		j, err := NewPlanJob(inputs, plan)
+		require.NoError(t, err)
+
+		found := false
+		job.Visit(j, func(d job.Describer) {
+			if d.Name() == "CodeOwnershipFilterJob" {
+				found = true
+			}
+		})
+		return found
+	}
+
+	// Ownership is only resolved for queries that ask for it.
+	require.False(t, hasCodeOwnershipJob("foo"))
+	require.True(t, hasCodeOwnershipJob("foo file:has.owner(@alice)"))
+	require.True(t, hasCodeOwnershipJob("foo select:file.owners"))
+}
+
 func TestToEvaluateJob(t *testing.T) {
 	test := func(input string, protocol search.Protocol) string {
 		q, _ := query.ParseLiteral(input)
@@ -0,0 +1,241 @@
+package jobutil
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// splitRevisionDiff returns the queries for the head and base revisions of a
+// revision diff search like `repo:foo rev:feature...main bar`, or false if b
+// does not search a revision diff.
+func splitRevisionDiff(b query.Basic) (head, base query.Basic, ok bool) {
+	var headRev, baseRev string
+	query.VisitParameter(b.ToParseTree(), func(field, value string, negated bool, _ query.Annotation) {
+		if negated || (field != query.FieldRepo && field != query.FieldRev) {
+			return
+		}
+		if field == query.FieldRepo {
+			_, value, _ = strings.Cut(value, "@")
+		}
+		if headSpec, baseSpec, isDiff := search.ParseRevisionDiff(value); isDiff {
+			headRev, baseRev, ok = headSpec, baseSpec, true
+		}
+	})
+	if !ok {
+		return query.Basic{}, query.Basic{}, false
+	}
+	return withRevision(b, headRev), withRevision(b, baseRev), true
+}
+
+// withRevision replaces the revision diffs of the repo and rev filters of b
+// with rev.
+func withRevision(b query.Basic, rev string) query.Basic {
+	parameters := make([]query.Parameter, 0, len(b.Parameters))
+	for _, p := range b.Parameters {
+		switch {
+		case p.Negated:
+		case p.Field == query.FieldRev:
+			if _, _, isDiff := search.ParseRevisionDiff(p.Value); isDiff {
+				p.Value = rev
+			}
+		case p.Field == query.FieldRepo:
+			if repo, revs, hasRevs := strings.Cut(p.Value, "@"); hasRevs {
+				if _, _, isDiff := search.ParseRevisionDiff(revs); isDiff {
+					p.Value = repo + "@" + rev
+				}
+			}
+		}
+		parameters = append(parameters, p)
+	}
+	return query.Basic{Parameters: parameters, Pattern: b.Pattern}
+}
+
+// NewRevisionDiffJob creates a job that runs the same search at the head and
+// base revisions of a revision diff, and only returns the chunk matches that
+// differ between them. Chunk matches are compared by their matched text and
+// the lines that contain it, so matches that only moved within a file are not
+// returned. If either search hits its result limit, no matches are returned
+// and the job returns an alert instead, since the diff would be incomplete.
+func NewRevisionDiffJob(head, base job.Job) job.Job {
+	return &RevisionDiffJob{head: head, base: base}
+}
+
+type RevisionDiffJob struct {
+	head, base job.Job
+}
+
+func (j *RevisionDiffJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu                   sync.Mutex
+		headFiles, baseFiles []*result.FileMatch
+		limitHit             bool
+	)
+	collect := func(files *[]*result.FileMatch) streaming.Sender {
+		return streaming.StreamFunc(func(event streaming.SearchEvent) {
+			mu.Lock()
+			for _, m := range event.Results {
+				if fm, ok := m.(*result.FileMatch); ok && len(fm.Symbols) == 0 {
+					*files = append(*files, fm)
+				}
+			}
+			limitHit = limitHit || event.Stats.IsLimitHit
+			mu.Unlock()
+
+			// Progress is reported as the searches run, but results can only
+			// be compared once both searches are done.
+			stream.Send(streaming.SearchEvent{Stats: event.Stats})
+		})
+	}
+
+	var (
+		wg        sync.WaitGroup
+		maxAlerts search.MaxAlerter
+		errs      errors.MultiError
+	)
+	for _, side := range []struct {
+		job   job.Job
+		files *[]*result.FileMatch
+	}{{j.head, &headFiles}, {j.base, &baseFiles}} {
+		side := side
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			alert, err := side.job.Run(ctx, clients, collect(side.files))
+			mu.Lock()
+			defer mu.Unlock()
+			maxAlerts.Add(alert)
+			errs = errors.Append(errs, err)
+		}()
+	}
+	wg.Wait()
+
+	if errs != nil {
+		return maxAlerts.Alert, errs
+	}
+	if limitHit {
+		// Matches missing from a truncated side would be reported as added
+		// or removed, so we don't diff incomplete results.
+		maxAlerts.Add(search.AlertForRevisionDiffLimitHit())
+		return maxAlerts.Alert, nil
+	}
+	stream.Send(streaming.SearchEvent{Results: diffFileMatches(headFiles, baseFiles)})
+	return maxAlerts.Alert, nil
+}
+
+func (j *RevisionDiffJob) Name() string {
+	return "RevisionDiffJob"
+}
+
+func (j *RevisionDiffJob) Fields(job.Verbosity) []log.Field { return nil }
+
+func (j *RevisionDiffJob) Children() []job.Describer {
+	return []job.Describer{j.head, j.base}
+}
+
+func (j *RevisionDiffJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.head = job.Map(j.head, fn)
+	cp.base = job.Map(j.base, fn)
+	return &cp
+}
+
+// diffFileMatches returns the file matches of head with the chunk matches that
+// are not in base, and the file matches of base with the chunk matches that
+// are not in head. Path matches are compared by their path.
+func diffFileMatches(head, base []*result.FileMatch) result.Matches {
+	type fileKey struct {
+		repo api.RepoID
+		path string
+	}
+	byFile := func(fms []*result.FileMatch) map[fileKey][]*result.FileMatch {
+		m := make(map[fileKey][]*result.FileMatch, len(fms))
+		for _, fm := range fms {
+			k := fileKey{repo: fm.Repo.ID, path: fm.Path}
+			m[k] = append(m[k], fm)
+		}
+		return m
+	}
+	headByFile, baseByFile := byFile(head), byFile(base)
+
+	var matches result.Matches
+	diff := func(from []*result.FileMatch, against map[fileKey][]*result.FileMatch, kind result.RevisionDiffKind) {
+		for _, fm := range from {
+			others := against[fileKey{repo: fm.Repo.ID, path: fm.Path}]
+			if fm.IsPathMatch() {
+				if len(others) == 0 {
+					matches = append(matches, withRevisionDiff(fm, nil, kind))
+				}
+				continue
+			}
+
+			seen := map[string]int{}
+			for _, other := range others {
+				for _, cm := range other.ChunkMatches {
+					for _, rr := range cm.Ranges {
+						seen[chunkRangeKey(cm, rr)]++
+					}
+				}
+			}
+
+			var chunks result.ChunkMatches
+			for _, cm := range fm.ChunkMatches {
+				var ranges result.Ranges
+				for _, rr := range cm.Ranges {
+					if k := chunkRangeKey(cm, rr); seen[k] > 0 {
+						seen[k]--
+						continue
+					}
+					ranges = append(ranges, rr)
+				}
+				if len(ranges) > 0 {
+					cm.Ranges = ranges
+					chunks = append(chunks, cm)
+				}
+			}
+			if len(chunks) > 0 {
+				matches = append(matches, withRevisionDiff(fm, chunks, kind))
+			}
+		}
+	}
+	diff(head, baseByFile, result.RevisionDiffAdded)
+	diff(base, headByFile, result.RevisionDiffRemoved)
+	return matches
+}
+
+func withRevisionDiff(fm *result.FileMatch, chunks result.ChunkMatches, kind result.RevisionDiffKind) *result.FileMatch {
+	cp := *fm
+	cp.ChunkMatches = chunks
+	cp.RevisionDiff = kind
+	return &cp
+}
+
+// chunkRangeKey identifies a matched range by the lines that contain it and
+// its offsets within them, which do not change if the lines move.
+func chunkRangeKey(cm result.ChunkMatch, rr result.Range) string {
+	start := rr.Start.Offset - cm.ContentStart.Offset
+	end := rr.End.Offset - cm.ContentStart.Offset
+	if start < 0 || end > len(cm.Content) || start > end {
+		return cm.Content
+	}
+	lineStart := strings.LastIndexByte(cm.Content[:start], '\n') + 1
+	lineEnd := len(cm.Content)
+	if i := strings.IndexByte(cm.Content[end:], '\n'); i >= 0 {
+		lineEnd = end + i
+	}
+	return strconv.Itoa(start-lineStart) + ":" + strconv.Itoa(end-lineStart) + ":" + cm.Content[lineStart:lineEnd]
+}
@@ -0,0 +1,148 @@
+package jobutil
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSplitRevisionDiff(t *testing.T) {
+	cases := []struct {
+		query      string
+		head, base string
+	}{
+		{
+			query: "repo:foo rev:feature...main oldFunc(",
+			head:  `repo:foo@feature oldFunc(`,
+			base:  `repo:foo@main oldFunc(`,
+		},
+		{
+			query: "repo:foo@...v1 -repo:bar oldFunc",
+			head:  `repo:foo@HEAD -repo:bar oldFunc`,
+			base:  `repo:foo@v1 -repo:bar oldFunc`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			plan, err := query.Pipeline(query.Init(tc.query, query.SearchTypeLiteral))
+			require.NoError(t, err)
+			head, base, ok := splitRevisionDiff(plan[0])
+			require.True(t, ok)
+			require.Equal(t, tc.head, head.StringHuman())
+			require.Equal(t, tc.base, base.StringHuman())
+		})
+	}
+
+	t.Run("no revision diff", func(t *testing.T) {
+		plan, err := query.Pipeline(query.Init("repo:foo@main:v1 oldFunc", query.SearchTypeLiteral))
+		require.NoError(t, err)
+		_, _, ok := splitRevisionDiff(plan[0])
+		require.False(t, ok)
+	})
+}
+
+func TestRevisionDiffJob(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "foo"}
+	fileMatch := func(rev, path string, chunks ...result.ChunkMatch) *result.FileMatch {
+		return &result.FileMatch{
+			File:         result.File{Repo: repo, Path: path, InputRev: &rev, CommitID: api.CommitID("commit-" + rev)},
+			ChunkMatches: chunks,
+		}
+	}
+	// chunk returns a chunk of a single line, which matches the first
+	// occurrence of match in line.
+	chunk := func(lineNumber int, offset int, line, match string) result.ChunkMatch {
+		column := strings.Index(line, match)
+		return result.ChunkMatch{
+			Content:      line,
+			ContentStart: result.Location{Offset: offset, Line: lineNumber},
+			Ranges: result.Ranges{{
+				Start: result.Location{Offset: offset + column, Line: lineNumber, Column: column},
+				End:   result.Location{Offset: offset + column + len(match), Line: lineNumber, Column: column + len(match)},
+			}},
+		}
+	}
+
+	moved := chunk(10, 100, "\toldFunc(a)", "oldFunc(")
+	removed := chunk(20, 200, "\toldFunc(b)", "oldFunc(")
+	added := chunk(5, 50, "\tx := oldFunc(c)", "oldFunc(")
+
+	runJob := func(head, base []result.Match) []*result.FileMatch {
+		newJob := func(matches []result.Match) job.Job {
+			j := mockjob.NewMockJob()
+			j.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: matches})
+				return nil, nil
+			})
+			return j
+		}
+
+		agg := streaming.NewAggregatingStream()
+		_, err := NewRevisionDiffJob(newJob(head), newJob(base)).Run(context.Background(), job.RuntimeClients{}, agg)
+		require.NoError(t, err)
+
+		var fms []*result.FileMatch
+		for _, m := range agg.Results {
+			fms = append(fms, m.(*result.FileMatch))
+		}
+		return fms
+	}
+
+	// At head, the call at line 10 moved to line 12, the call at line 20 was
+	// removed and a call was added to a new file.
+	movedAtHead := chunk(12, 120, "\toldFunc(a)", "oldFunc(")
+	got := runJob(
+		[]result.Match{
+			fileMatch("feature", "a.go", movedAtHead),
+			fileMatch("feature", "b.go", added),
+		},
+		[]result.Match{
+			fileMatch("main", "a.go", moved, removed),
+		},
+	)
+
+	require.Len(t, got, 2)
+	require.Equal(t, "b.go", got[0].Path)
+	require.Equal(t, result.RevisionDiffAdded, got[0].RevisionDiff)
+	require.Equal(t, "feature", *got[0].InputRev)
+	require.Equal(t, result.ChunkMatches{added}, got[0].ChunkMatches)
+
+	require.Equal(t, "a.go", got[1].Path)
+	require.Equal(t, result.RevisionDiffRemoved, got[1].RevisionDiff)
+	require.Equal(t, "main", *got[1].InputRev)
+	require.Equal(t, result.ChunkMatches{removed}, got[1].ChunkMatches)
+}
+
+func TestRevisionDiffJob_LimitHit(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "foo"}
+	newJob := func(path string, limitHit bool) job.Job {
+		j := mockjob.NewMockJob()
+		j.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{
+				Results: []result.Match{&result.FileMatch{File: result.File{Repo: repo, Path: path}}},
+				Stats:   streaming.Stats{IsLimitHit: limitHit},
+			})
+			return nil, nil
+		})
+		return j
+	}
+
+	// The base search was truncated, so a.go is not known to be added.
+	agg := streaming.NewAggregatingStream()
+	alert, err := NewRevisionDiffJob(newJob("a.go", false), newJob("b.go", true)).Run(context.Background(), job.RuntimeClients{}, agg)
+	require.NoError(t, err)
+	require.Equal(t, search.AlertForRevisionDiffLimitHit(), alert)
+	require.Empty(t, agg.Results)
+	require.True(t, agg.Stats.IsLimitHit)
+}
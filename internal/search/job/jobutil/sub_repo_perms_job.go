@@ -117,6 +117,10 @@ func applySubRepoFiltering(ctx context.Context, logger log.Logger, checker authz
 		case *result.RepoMatch:
 			// Repo filtering is taking care of by our usual repo filtering logic
 			filtered = append(filtered, m)
+		case *result.OwnerMatch:
+			// 🚨 SECURITY: Owner matches don't record which files they were
+			// selected from, so we can't check those files. They are dropped
+			// like any other match we can't check.
 		}
 
 	}
@@ -40,5 +40,9 @@ func SearchLimits(c *conf.Unified) schema.SearchLimits {
 	withDefault(&limits.CommitDiffWithTimeFilterMaxRepos, 10000)
 	withDefault(&limits.MaxTimeoutSeconds, 60)
 
+	if limits.QueryCostLimitAction == "" {
+		limits.QueryCostLimitAction = "queue"
+	}
+
 	return limits
 }
@@ -0,0 +1,144 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Macros maps the names of query macros to the query strings they expand to.
+// A pattern of the form `@name` is substituted for the query of the macro
+// `name`, for example `@prod-services` for `repo:^github\.com/acme/(api|web)$
+// -file:_test\.go$`. Patterns that do not refer to a macro, like `@Override`,
+// are searched for as usual.
+type Macros map[string]string
+
+// MacroError is an error that occurs while expanding a macro, for example
+// because its query does not parse or because it refers to itself.
+type MacroError struct {
+	Msg string
+}
+
+func (e *MacroError) Error() string {
+	return e.Msg
+}
+
+var macroPattern = lazyregexp.New(`^@([a-zA-Z0-9_.-]+)$`)
+
+// SubstituteMacros substitutes patterns of the form `@name` for the parse tree
+// of the macro `name`. Macros may refer to other macros, but not to
+// themselves. Macros expand to nodes that are not yet processed for the search
+// type, so this step must run before For, as it does in InitWithMacros.
+func SubstituteMacros(macros Macros, searchType SearchType) step {
+	if len(macros) == 0 {
+		return identity
+	}
+	return func(nodes []Node) ([]Node, error) {
+		e := &macroExpander{macros: macros, searchType: searchType}
+		expanded := e.MapNodes(e, nodes)
+		return expanded, e.errs
+	}
+}
+
+// macroExpander is a mapper that substitutes macros.
+type macroExpander struct {
+	BaseMapper
+	macros     Macros
+	searchType SearchType
+	// stack is the sequence of macros being expanded, to detect cycles.
+	stack []string
+	errs  error
+}
+
+// MapOperator moves macros out of concatenated patterns, so that `foo @macro
+// bar` searches for the pattern `foo bar` in the scope of the macro.
+func (e *macroExpander) MapOperator(mapper Mapper, kind OperatorKind, operands []Node) []Node {
+	if kind != Concat {
+		return e.BaseMapper.MapOperator(mapper, kind, operands)
+	}
+
+	var patterns, expanded []Node
+	for _, operand := range operands {
+		if pattern, ok := operand.(Pattern); ok {
+			if nodes, ok := e.expand(pattern); ok {
+				expanded = append(expanded, nodes...)
+				continue
+			}
+		}
+		patterns = append(patterns, mapper.MapNodes(mapper, []Node{operand})...)
+	}
+	if len(expanded) == 0 {
+		return NewOperator(patterns, Concat)
+	}
+	return NewOperator(append(NewOperator(patterns, Concat), expanded...), And)
+}
+
+func (e *macroExpander) MapPattern(mapper Mapper, value string, negated bool, annotation Annotation) Node {
+	pattern := Pattern{Value: value, Negated: negated, Annotation: annotation}
+	nodes, ok := e.expand(pattern)
+	if !ok {
+		return pattern
+	}
+	switch len(nodes) {
+	case 0:
+		return nil
+	case 1:
+		return nodes[0]
+	default:
+		return Operator{Kind: And, Operands: nodes}
+	}
+}
+
+// expand returns the expanded nodes of the macro that pattern refers to, if
+// any. Errors are accumulated in e.errs.
+func (e *macroExpander) expand(pattern Pattern) ([]Node, bool) {
+	name, ok := e.macroName(pattern)
+	if !ok {
+		return nil, false
+	}
+	queryString, ok := e.macros[name]
+	if !ok {
+		return nil, false
+	}
+
+	if pattern.Negated {
+		e.errs = errors.Append(e.errs, &MacroError{Msg: fmt.Sprintf("the macro @%s cannot be negated", name)})
+		return nil, true
+	}
+	for i, expanding := range e.stack {
+		if expanding == name {
+			cycle := append(append([]string{}, e.stack[i:]...), name)
+			e.errs = errors.Append(e.errs, &MacroError{Msg: fmt.Sprintf("the macro @%s refers to itself: @%s", name, strings.Join(cycle, " -> @"))})
+			return nil, true
+		}
+	}
+
+	nodes, err := Parse(queryString, e.searchType)
+	if err != nil {
+		e.errs = errors.Append(e.errs, &MacroError{Msg: fmt.Sprintf("the query of the macro @%s is invalid: %s", name, err)})
+		return nil, true
+	}
+
+	e.stack = append(e.stack, name)
+	nodes = e.MapNodes(e, nodes)
+	e.stack = e.stack[:len(e.stack)-1]
+	return nodes, true
+}
+
+// macroName returns the name of the macro that pattern refers to. Quoted
+// patterns and patterns delimited by slashes are never macros.
+func (e *macroExpander) macroName(pattern Pattern) (string, bool) {
+	if pattern.Annotation.Labels.IsSet(Quoted) {
+		return "", false
+	}
+	if e.searchType != SearchTypeRegex && pattern.Annotation.Labels.IsSet(Regexp) {
+		return "", false
+	}
+	match := macroPattern.FindStringSubmatch(pattern.Value)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
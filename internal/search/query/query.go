@@ -109,10 +109,16 @@ func For(searchType SearchType) step {
 // Init creates a step from an input string and search type. It parses the
 // initial input string.
 func Init(in string, searchType SearchType) step {
+	return InitWithMacros(in, searchType, nil)
+}
+
+// InitWithMacros is Init where patterns of the form `@name` are substituted
+// for the queries of the given macros.
+func InitWithMacros(in string, searchType SearchType, macros Macros) step {
 	parser := func([]Node) ([]Node, error) {
 		return Parse(in, searchType)
 	}
-	return Sequence(parser, For(searchType))
+	return Sequence(parser, SubstituteMacros(macros, searchType), For(searchType))
 }
 
 // InitLiteral is Init where SearchType is Literal.
@@ -44,3 +44,29 @@ func TestSubstituteSearchContexts(t *testing.T) {
 		autogold.Equal(t, autogold.Raw(test("context:gordo repo:contains.path(gordo)", true)))
 	})
 }
+
+func TestSubstituteMacros(t *testing.T) {
+	macros := Macros{
+		"prod-services": `repo:^github\.com/acme/(api|web)$ -file:_test\.go$`,
+		"go":            "@prod-services lang:go",
+		"cycle":         "@cycle-2",
+		"cycle-2":       "@cycle",
+		"invalid":       "not (foo or bar)",
+	}
+	test := func(input string) string {
+		plan, err := Pipeline(InitWithMacros(input, SearchTypeStandard, macros))
+		if err != nil {
+			return err.Error()
+		}
+		return plan.ToQ().String()
+	}
+
+	autogold.Want("macro", `(and "repo:^github\\.com/acme/(api|web)$" "-file:_test\\.go$" "foo")`).Equal(t, test("@prod-services foo"))
+	autogold.Want("macro between patterns", `(and "repo:^github\\.com/acme/(api|web)$" "-file:_test\\.go$" "foo bar")`).Equal(t, test("foo @prod-services bar"))
+	autogold.Want("nested macro", `(and "lang:go" "repo:^github\\.com/acme/(api|web)$" "-file:_test\\.go$" "foo")`).Equal(t, test("@go foo"))
+	autogold.Want("undefined macro", `"@Override"`).Equal(t, test("@Override"))
+	autogold.Want("quoted macro", `"\"@prod-services\""`).Equal(t, test(`"@prod-services"`))
+	autogold.Want("cycle", "the macro @cycle refers to itself: @cycle -> @cycle-2 -> @cycle").Equal(t, test("@cycle foo"))
+	autogold.Want("negated macro", "the macro @prod-services cannot be negated").Equal(t, test("foo not @prod-services"))
+	autogold.Want("invalid macro", "the query of the macro @invalid is invalid: it looks like you tried to use an expression after NOT. The NOT operator can only be used with simple search patterns or filters, and is not supported for expressions or subqueries").Equal(t, test("@invalid"))
+}
@@ -376,6 +376,55 @@ func validateTypeStructural(nodes []Node) error {
 	return nil
 }
 
+// validateRevisionRanges validates that revision ranges such as rev:v1..v2 are
+// only used by symbol searches, which search the history of symbols in the
+// range, and that revision diffs such as rev:feature...main are only used by
+// searches of file contents, which return the matches that differ between
+// the revisions.
+func validateRevisionRanges(nodes []Node) error {
+	var seenRange, seenDiff, combinedDiff string
+	var resultType string
+	VisitParameter(nodes, func(field, value string, negated bool, _ Annotation) {
+		var revs string
+		switch field {
+		case FieldRev:
+			revs = value
+		case FieldRepo:
+			if negated {
+				return
+			}
+			if _, r, ok := strings.Cut(value, "@"); ok {
+				revs = r
+			}
+		case FieldType:
+			resultType = value
+			return
+		}
+		revList := strings.Split(revs, ":")
+		for _, rev := range revList {
+			switch {
+			case strings.Contains(rev, "..."):
+				seenDiff = rev
+				if len(revList) > 1 {
+					combinedDiff = rev
+				}
+			case strings.Contains(rev, ".."):
+				seenRange = rev
+			}
+		}
+	})
+	if seenRange != "" && resultType != "symbol" {
+		return errors.Errorf("the revision range %q requires type:symbol. Revision ranges search the history of symbols", seenRange)
+	}
+	if combinedDiff != "" {
+		return errors.Errorf("the revision diff %q cannot be combined with other revisions", combinedDiff)
+	}
+	if seenDiff != "" && resultType != "" && resultType != "file" && resultType != "path" {
+		return errors.Errorf("the revision diff %q is not supported for type:%s. Revision diffs compare the matches in file contents", seenDiff, resultType)
+	}
+	return nil
+}
+
 func validateRefGlobs(nodes []Node) error {
 	if !ContainsRefGlobs(nodes) {
 		return nil
@@ -494,6 +543,7 @@ func validate(nodes []Node) error {
 		validateCommitParameters,
 		validateTypeStructural,
 		validateRefGlobs,
+		validateRevisionRanges,
 	)
 }
 
@@ -79,6 +79,22 @@ func TestValidation(t *testing.T) {
 			input: `repo:'' rev:bedge`,
 			want:  "invalid syntax. The query contains `rev:` without `repo:`. Add a `repo:` filter and try again",
 		},
+		{
+			input: "repo:foo rev:v1..v2 Deprecated",
+			want:  `the revision range "v1..v2" requires type:symbol. Revision ranges search the history of symbols`,
+		},
+		{
+			input: "repo:foo@..main type:commit Deprecated",
+			want:  `the revision range "..main" requires type:symbol. Revision ranges search the history of symbols`,
+		},
+		{
+			input: "repo:foo rev:feature...main type:symbol Deprecated",
+			want:  `the revision diff "feature...main" is not supported for type:symbol. Revision diffs compare the matches in file contents`,
+		},
+		{
+			input: "repo:foo@feature...main:v1 Deprecated",
+			want:  `the revision diff "feature...main" cannot be combined with other revisions`,
+		},
 		{
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
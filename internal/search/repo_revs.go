@@ -115,6 +115,42 @@ func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpec
 	return repo, revs
 }
 
+// ParseRevisionRange parses a revision range "base..head", which symbol history
+// searches use to search the symbols of the first-parent history of head after
+// base. An empty base starts at the first commit, and an empty head is HEAD.
+// ok is false if spec is not a range.
+func ParseRevisionRange(spec string) (base, head string, ok bool) {
+	if strings.Contains(spec, "...") {
+		// Symmetric differences are not first-parent histories.
+		return "", "", false
+	}
+	base, head, ok = strings.Cut(spec, "..")
+	if !ok {
+		return "", "", false
+	}
+	if head == "" {
+		head = "HEAD"
+	}
+	return base, head, true
+}
+
+// ParseRevisionDiff parses a revision diff "head...base", which searches for
+// the matches at head that do not exist at base and vice versa. An empty head
+// or base is HEAD. ok is false if spec is not a revision diff.
+func ParseRevisionDiff(spec string) (head, base string, ok bool) {
+	head, base, ok = strings.Cut(spec, "...")
+	if !ok {
+		return "", "", false
+	}
+	if head == "" {
+		head = "HEAD"
+	}
+	if base == "" {
+		base = "HEAD"
+	}
+	return head, base, true
+}
+
 func parseRev(spec string) RevisionSpecifier {
 	if strings.HasPrefix(spec, "*!") {
 		return RevisionSpecifier{ExcludeRefGlob: spec[2:]}
@@ -43,3 +43,47 @@ func TestParseRepositoryRevisions(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRevisionRange(t *testing.T) {
+	tests := map[string]struct {
+		base, head string
+		ok         bool
+	}{
+		"main":        {},
+		"v1..v2":      {base: "v1", head: "v2", ok: true},
+		"..main":      {head: "main", ok: true},
+		"v1..":        {base: "v1", head: "HEAD", ok: true},
+		"main...dev":  {},
+		"refs/a..b/c": {base: "refs/a", head: "b/c", ok: true},
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			base, head, ok := ParseRevisionRange(input)
+			if base != want.base || head != want.head || ok != want.ok {
+				t.Fatalf("got (%q, %q, %v), want (%q, %q, %v)", base, head, ok, want.base, want.head, want.ok)
+			}
+		})
+	}
+}
+
+func TestParseRevisionDiff(t *testing.T) {
+	tests := map[string]struct {
+		head, base string
+		ok         bool
+	}{
+		"main":            {},
+		"v1..v2":          {},
+		"feature...main":  {head: "feature", base: "main", ok: true},
+		"...main":         {head: "HEAD", base: "main", ok: true},
+		"feature...":      {head: "feature", base: "HEAD", ok: true},
+		"refs/a/b...v1.2": {head: "refs/a/b", base: "v1.2", ok: true},
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			head, base, ok := ParseRevisionDiff(input)
+			if head != want.head || base != want.base || ok != want.ok {
+				t.Fatalf("got (%q, %q, %v), want (%q, %q, %v)", head, base, ok, want.head, want.base, want.ok)
+			}
+		})
+	}
+}
@@ -272,6 +272,60 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 	}, err
 }
 
+// Count returns the number of repositories that op matches, without
+// resolving their revisions. It is an upper bound on the number of
+// repositories that Paginate returns, because filters that need to look at
+// the contents of repositories, like repo:has.file(), are not applied.
+func (r *Resolver) Count(ctx context.Context, op search.RepoOptions) (_ int, err error) {
+	tr, ctx := trace.New(ctx, "searchrepos.Count", op.String())
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	includePatterns, _, err := findPatternRevs(op.RepoFilters)
+	if err != nil {
+		return 0, err
+	}
+
+	searchContext, err := searchcontexts.ResolveSearchContextSpec(ctx, r.db, op.SearchContextSpec)
+	if err != nil {
+		return 0, err
+	}
+
+	kvpFilters := make([]database.RepoKVPFilter, 0, len(op.HasKVPs))
+	for _, filter := range op.HasKVPs {
+		kvpFilters = append(kvpFilters, database.RepoKVPFilter{
+			Key:     filter.Key,
+			Value:   filter.Value,
+			Negated: filter.Negated,
+		})
+	}
+
+	options := database.ReposListOptions{
+		IncludePatterns:       includePatterns,
+		ExcludePattern:        query.UnionRegExps(op.MinusRepoFilters),
+		DescriptionPatterns:   op.DescriptionPatterns,
+		CaseSensitivePatterns: op.CaseSensitiveRepoFilters,
+		KVPFilters:            kvpFilters,
+		NoForks:               op.NoForks,
+		OnlyForks:             op.OnlyForks,
+		NoArchived:            op.NoArchived,
+		OnlyArchived:          op.OnlyArchived,
+		NoPrivate:             op.Visibility == query.Public,
+		OnlyPrivate:           op.Visibility == query.Private,
+		OnlyCloned:            op.OnlyCloned,
+	}
+	if searchContext.Query == "" {
+		options.SearchContextID = searchContext.ID
+		options.UserID = searchContext.NamespaceUserID
+		options.OrgID = searchContext.NamespaceOrgID
+		options.IncludeUserPublicRepos = searchContext.ID == 0 && searchContext.NamespaceUserID != 0
+	}
+
+	return r.db.Repos().Count(ctx, options)
+}
+
 // associateReposWithRevs re-associates revisions with the repositories fetched from the db
 func (r *Resolver) associateReposWithRevs(
 	repos []types.MinimalRepo,
@@ -381,6 +435,24 @@ func (r *Resolver) normalizeRefs(ctx context.Context, repoRevSpecs []RepoRevSpec
 	return filteredResults, missing, nil
 }
 
+func isRevisionRange(spec string) bool {
+	_, _, ok := search.ParseRevisionRange(spec)
+	return ok
+}
+
+func (r *Resolver) resolveRevisionRange(ctx context.Context, repo api.RepoName, spec string) error {
+	base, head, _ := search.ParseRevisionRange(spec)
+	for _, rev := range []string{base, head} {
+		if rev == "" || rev == "HEAD" {
+			continue
+		}
+		if _, err := r.gitserver.ResolveRevision(ctx, repo, rev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *Resolver) normalizeRepoRefs(
 	ctx context.Context,
 	repo types.MinimalRepo,
@@ -401,6 +473,17 @@ func (r *Resolver) normalizeRepoRefs(
 			// instead of just []string because we have the exact commit hashes,
 			// so we could avoid resolving later.
 			revs = append(revs, rev.RevSpec)
+		case rev.RevSpec != "" && isRevisionRange(rev.RevSpec):
+			// Revision ranges are used by symbol history searches, both ends
+			// of the range have to exist.
+			if err := r.resolveRevisionRange(ctx, repo.Name, rev.RevSpec); err != nil {
+				if errors.Is(err, context.DeadlineExceeded) || errors.HasType(err, &gitdomain.BadCommitError{}) {
+					return nil, err
+				}
+				reportMissing(RepoRevSpecs{Repo: repo, Revs: []search.RevisionSpecifier{rev}})
+				continue
+			}
+			revs = append(revs, rev.RevSpec)
 		case rev.RevSpec != "":
 			trimmedRev := strings.TrimPrefix(rev.RevSpec, "^")
 			_, err := r.gitserver.ResolveRevision(ctx, repo.Name, trimmedRev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
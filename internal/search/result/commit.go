@@ -41,6 +41,10 @@ type CommitMatch struct {
 	// ModifiedFiles will include the list of files modified in the commit when
 	// sub-repo permissions filtering has been enabled.
 	ModifiedFiles []string
+
+	// Source is the name of the remote Sourcegraph instance that federated
+	// search found the commit on. It is empty for commits on this instance.
+	Source string
 }
 
 func (cm *CommitMatch) Body() MatchedString {
@@ -107,8 +111,9 @@ func (cm *CommitMatch) Select(path filter.SelectPath) Match {
 	switch path.Root() {
 	case filter.Repository:
 		return &RepoMatch{
-			Name: cm.Repo.Name,
-			ID:   cm.Repo.ID,
+			Name:   cm.Repo.Name,
+			ID:     cm.Repo.ID,
+			Source: cm.Source,
 		}
 	case filter.Commit:
 		fields := path[1:]
@@ -153,6 +158,7 @@ func (cm *CommitMatch) Key() Key {
 	}
 	return Key{
 		TypeRank:   typeRank,
+		Source:     cm.Source,
 		Repo:       cm.Repo.Name,
 		AuthorDate: cm.Commit.Author.Date,
 		Commit:     cm.Commit.ID,
@@ -20,6 +20,10 @@ type File struct {
 	Repo     types.MinimalRepo `json:"-"`
 	CommitID api.CommitID      `json:"-"`
 	Path     string
+
+	// Source is the name of the remote Sourcegraph instance that federated
+	// search found the file on. It is empty for files on this instance.
+	Source string `json:"-"`
 }
 
 func (f *File) URL() *url.URL {
@@ -47,9 +51,32 @@ type FileMatch struct {
 	Symbols      []*SymbolMatch `json:"-"`
 	PathMatches  []Range
 
+	// Owners are the code owners of the file, such as "@sourcegraph/search".
+	// They are only set if ownership of the file was resolved while searching.
+	Owners []string `json:"-"`
+
+	// RevisionDiff is set for the results of revision diff searches like
+	// `rev:feature...main`, which only return the chunk matches that were
+	// added at the head revision or removed from the base revision.
+	RevisionDiff RevisionDiffKind `json:"-"`
+
 	LimitHit bool
 }
 
+// RevisionDiffKind is the kind of difference of a file match between the
+// revisions of a revision diff search.
+type RevisionDiffKind string
+
+const (
+	// RevisionDiffAdded file matches are at the head revision, and contain the
+	// chunk matches that do not exist at the base revision.
+	RevisionDiffAdded RevisionDiffKind = "added"
+
+	// RevisionDiffRemoved file matches are at the base revision, and contain
+	// the chunk matches that do not exist at the head revision.
+	RevisionDiffRemoved RevisionDiffKind = "removed"
+)
+
 func (fm *FileMatch) RepoName() types.MinimalRepo {
 	return fm.File.Repo
 }
@@ -75,10 +102,16 @@ func (fm *FileMatch) Select(selectPath filter.SelectPath) Match {
 	switch selectPath.Root() {
 	case filter.Repository:
 		return &RepoMatch{
-			Name: fm.Repo.Name,
-			ID:   fm.Repo.ID,
+			Name:   fm.Repo.Name,
+			ID:     fm.Repo.ID,
+			Source: fm.Source,
 		}
 	case filter.File:
+		if len(selectPath) > 1 && selectPath[1] == filter.Owners {
+			// Owners are selected while resolving ownership, since a
+			// file match can have more than one owner.
+			return nil
+		}
 		fm.ChunkMatches = nil
 		fm.Symbols = nil
 		if len(selectPath) > 1 && selectPath[1] == "directory" {
@@ -155,6 +188,7 @@ func (fm *FileMatch) Limit(limit int) int {
 func (fm *FileMatch) Key() Key {
 	k := Key{
 		TypeRank: rankFileMatch,
+		Source:   fm.Source,
 		Repo:     fm.Repo.Name,
 		Commit:   fm.CommitID,
 		Path:     fm.Path,
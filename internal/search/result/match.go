@@ -8,7 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
-// Match is *FileMatch | *RepoMatch | *CommitMatch. We have a private method
+// Match is *FileMatch | *RepoMatch | *CommitMatch | *OwnerMatch. We have a private method
 // to ensure only those types implement Match.
 type Match interface {
 	ResultCount() int
@@ -34,6 +34,7 @@ var (
 	_ Match = (*RepoMatch)(nil)
 	_ Match = (*CommitMatch)(nil)
 	_ Match = (*CommitDiffMatch)(nil)
+	_ Match = (*OwnerMatch)(nil)
 )
 
 // Match ranks are used for sorting the different match types.
@@ -44,6 +45,7 @@ const (
 	rankCommitMatch = 1
 	rankDiffMatch   = 2
 	rankRepoMatch   = 3
+	rankOwnerMatch  = 4
 )
 
 // Key is a sorting or deduplicating key for a Match. It contains all the
@@ -52,6 +54,10 @@ const (
 // will be treated as the same result for the purpose of deduplication/merging
 // in and/or queries.
 type Key struct {
+	// Source is the name of the remote Sourcegraph instance the match was
+	// found on by federated search. Empty for matches on this instance.
+	Source string
+
 	// Repo is the name of the repo the match belongs to
 	Repo api.RepoName
 
@@ -74,12 +80,20 @@ type Key struct {
 	// Empty if there is no file associated with the match (e.g. RepoMatch or CommitMatch)
 	Path string
 
+	// Owner is the code owner of an owner match.
+	// Empty if the match is not an OwnerMatch.
+	Owner string
+
 	// TypeRank is the sorting rank of the type this key belongs to.
 	TypeRank int
 }
 
 // Less compares one key to another for sorting
 func (k Key) Less(other Key) bool {
+	if k.Source != other.Source {
+		return k.Source < other.Source
+	}
+
 	if k.Repo != other.Repo {
 		return k.Repo < other.Repo
 	}
@@ -100,9 +114,28 @@ func (k Key) Less(other Key) bool {
 		return k.Path < other.Path
 	}
 
+	if k.Owner != other.Owner {
+		return k.Owner < other.Owner
+	}
+
 	return k.TypeRank < other.TypeRank
 }
 
+// Source returns the name of the remote Sourcegraph instance that federated
+// search found the match on. It is empty for matches on this instance.
+func Source(m Match) string {
+	switch v := m.(type) {
+	case *FileMatch:
+		return v.Source
+	case *RepoMatch:
+		return v.Source
+	case *CommitMatch:
+		return v.Source
+	default:
+		return ""
+	}
+}
+
 // Matches implements sort.Interface
 type Matches []Match
 
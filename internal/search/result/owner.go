@@ -0,0 +1,45 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is a code owner of files that matched a search, as selected with
+// select:file.owners. Owners are not scoped to a repository, so that the
+// matches of all repositories are aggregated by owner.
+type OwnerMatch struct {
+	// Owner is the owner as written in a CODEOWNERS file, either a handle
+	// such as "@sourcegraph/search" or an email address.
+	Owner string
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	// Owners are not associated with a single repository.
+	return types.MinimalRepo{}
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	if path.Root() == filter.File && len(path) > 1 && path[1] == filter.Owners {
+		return o
+	}
+	return nil
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Owner:    o.Owner,
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
@@ -17,6 +17,11 @@ type RepoMatch struct {
 
 	DescriptionMatches []Range
 	RepoNameMatches    []Range
+
+	// Source is the name of the remote Sourcegraph instance that federated
+	// search found the repository on. It is empty for repositories on this
+	// instance.
+	Source string
 }
 
 func (r RepoMatch) RepoName() types.MinimalRepo {
@@ -59,6 +64,7 @@ func (r *RepoMatch) AppendMatches(src *RepoMatch) {
 func (r *RepoMatch) Key() Key {
 	return Key{
 		TypeRank: rankRepoMatch,
+		Source:   r.Source,
 		Repo:     r.Name,
 		Rev:      r.Rev,
 	}
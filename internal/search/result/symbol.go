@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
 )
 
 // Symbol is a code symbol.
@@ -26,6 +28,24 @@ type Symbol struct {
 	Signature  string
 
 	FileLimited bool
+
+	// History is set for the symbols returned by symbol history searches.
+	History *SymbolHistory `json:",omitempty"`
+}
+
+// SymbolHistory is the range of commits of a first-parent history in which a
+// symbol existed.
+type SymbolHistory struct {
+	// AddedCommitID is the commit that added the symbol.
+	AddedCommitID api.CommitID
+
+	// DeletedCommitID is the commit that removed the symbol, or empty if the
+	// symbol still exists at the end of the history.
+	DeletedCommitID api.CommitID `json:",omitempty"`
+
+	// LastCommitID is the last commit at which the symbol existed. The
+	// location of the symbol is its location at this commit.
+	LastCommitID api.CommitID
 }
 
 // NewSymbolMatch returns a new SymbolMatch. Passing -1 as the character will make NewSymbolMatch infer
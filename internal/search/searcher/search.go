@@ -264,7 +264,7 @@ func convertMatches(repo types.MinimalRepo, commit api.CommitID, rev *string, se
 			}
 		}
 
-		matches = append(matches, &result.FileMatch{
+		fileMatch := &result.FileMatch{
 			File: result.File{
 				Path:     fm.Path,
 				Repo:     repo,
@@ -274,7 +274,25 @@ func convertMatches(repo types.MinimalRepo, commit api.CommitID, rev *string, se
 			ChunkMatches: chunkMatches,
 			PathMatches:  pathMatches,
 			LimitHit:     fm.LimitHit,
-		})
+		}
+
+		// Searcher only extracts symbols if the query selects them.
+		for _, s := range fm.Symbols {
+			fileMatch.Symbols = append(fileMatch.Symbols, result.NewSymbolMatch(
+				&fileMatch.File,
+				int(s.Line),
+				int(s.Column),
+				s.Name,
+				s.Kind,
+				s.Parent,
+				s.ParentKind,
+				s.Language,
+				"", // Unused when column is set
+				false,
+			))
+		}
+
+		matches = append(matches, fileMatch)
 	}
 	return matches
 }
@@ -108,19 +108,9 @@ func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.Reposito
 
 	inputRev := repoRevs.Revs[0]
 	span.SetTag("rev", inputRev)
-	// Do not trigger a repo-updater lookup (e.g.,
-	// backend.{GitRepo,Repos.ResolveRev}) because that would slow this operation
-	// down by a lot (if we're looping over many repos). This means that it'll fail if a
-	// repo is not on gitserver.
-	commitID, err := gitserver.NewClient(db).ResolveRevision(ctx, repoRevs.GitserverRepo(), inputRev, gitserver.ResolveRevisionOptions{})
-	if err != nil {
-		return nil, err
-	}
-	span.SetTag("commit", string(commitID))
 
-	symbols, err := backend.Symbols.ListTags(ctx, search.SymbolsParameters{
+	args := search.SymbolsParameters{
 		Repo:            repoRevs.Repo.Name,
-		CommitID:        commitID,
 		Query:           patternInfo.Pattern,
 		IsCaseSensitive: patternInfo.IsCaseSensitive,
 		IsRegExp:        patternInfo.IsRegExp,
@@ -128,7 +118,36 @@ func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.Reposito
 		ExcludePattern:  patternInfo.ExcludePattern,
 		// Ask for limit + 1 so we can detect whether there are more results than the limit.
 		First: limit + 1,
-	})
+	}
+
+	// A revision range searches the history of symbols in the range.
+	rev := inputRev
+	base, head, isRange := search.ParseRevisionRange(inputRev)
+	if isRange {
+		rev = head
+		args.History = true
+	}
+
+	// Do not trigger a repo-updater lookup (e.g.,
+	// backend.{GitRepo,Repos.ResolveRev}) because that would slow this operation
+	// down by a lot (if we're looping over many repos). This means that it'll fail if a
+	// repo is not on gitserver.
+	client := gitserver.NewClient(db)
+	commitID, err := client.ResolveRevision(ctx, repoRevs.GitserverRepo(), rev, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil, err
+	}
+	span.SetTag("commit", string(commitID))
+	args.CommitID = commitID
+
+	if base != "" {
+		args.BaseCommitID, err = client.ResolveRevision(ctx, repoRevs.GitserverRepo(), base, gitserver.ResolveRevisionOptions{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	symbols, err := backend.Symbols.ListTags(ctx, args)
 
 	// All symbols are from the same repo, so we can just partition them by path
 	// to build file matches
@@ -136,19 +155,31 @@ func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.Reposito
 }
 
 func symbolsToMatches(symbols []result.Symbol, repo types.MinimalRepo, commitID api.CommitID, inputRev string) result.Matches {
-	symbolsByPath := make(map[string][]result.Symbol)
+	// Symbols of symbol history searches are located at the last commit at
+	// which they existed, so they are partitioned by commit as well.
+	type fileKey struct {
+		path     string
+		commitID api.CommitID
+		inputRev string
+	}
+	symbolsByFile := make(map[fileKey][]result.Symbol)
 	for _, symbol := range symbols {
-		cur := symbolsByPath[symbol.Path]
-		symbolsByPath[symbol.Path] = append(cur, symbol)
+		key := fileKey{path: symbol.Path, commitID: commitID, inputRev: inputRev}
+		if symbol.History != nil {
+			key.commitID = symbol.History.LastCommitID
+			key.inputRev = string(symbol.History.LastCommitID)
+		}
+		symbolsByFile[key] = append(symbolsByFile[key], symbol)
 	}
 
 	// Create file matches from partitioned symbols
-	matches := make(result.Matches, 0, len(symbolsByPath))
-	for path, symbols := range symbolsByPath {
+	matches := make(result.Matches, 0, len(symbolsByFile))
+	for key, symbols := range symbolsByFile {
+		inputRev := key.inputRev
 		file := result.File{
-			Path:     path,
+			Path:     key.path,
 			Repo:     repo,
-			CommitID: commitID,
+			CommitID: key.commitID,
 			InputRev: &inputRev,
 		}
 
@@ -48,3 +48,40 @@ func Test_symbolsToMatches(t *testing.T) {
 		t.Errorf("symbolsToMatches() returned diff (-got +want):\n%s", diff)
 	}
 }
+
+func Test_symbolsToMatches_History(t *testing.T) {
+	input := []result.Symbol{
+		{Path: "a.go", Name: "Removed", History: &result.SymbolHistory{AddedCommitID: "c1", DeletedCommitID: "c3", LastCommitID: "c2"}},
+		{Path: "a.go", Name: "Current", History: &result.SymbolHistory{AddedCommitID: "c1", LastCommitID: "c4"}},
+	}
+
+	output := symbolsToMatches(input, types.MinimalRepo{Name: "somerepo"}, "c4", "c1..c4")
+
+	type fileType struct {
+		CommitID string
+		URL      string
+		Symbols  []string
+	}
+	got := []fileType{}
+	for _, match := range output {
+		fileMatch := match.(*result.FileMatch)
+		symbols := []string{}
+		for _, symbol := range fileMatch.Symbols {
+			symbols = append(symbols, symbol.Symbol.Name)
+		}
+		got = append(got, fileType{
+			CommitID: string(fileMatch.CommitID),
+			URL:      fileMatch.URL().String(),
+			Symbols:  symbols,
+		})
+	}
+
+	// Symbols are located at the last commit at which they existed.
+	want := []fileType{
+		{CommitID: "c2", URL: "/somerepo@c2/-/blob/a.go", Symbols: []string{"Removed"}},
+		{CommitID: "c4", URL: "/somerepo@c4/-/blob/a.go", Symbols: []string{"Current"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("symbolsToMatches() returned diff (-want +got):\n%s", diff)
+	}
+}
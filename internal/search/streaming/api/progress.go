@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -24,6 +25,7 @@ func BuildProgressEvent(stats ProgressStats, namer RepoNamer) Progress {
 			skipped = append(skipped, sk)
 		}
 	}
+	skipped = append(skipped, remotesSkipped(stats.Remotes)...)
 
 	return Progress{
 		RepositoriesCount: stats.RepositoriesCount,
@@ -54,6 +56,10 @@ type ProgressStats struct {
 
 	DisplayLimit int
 
+	// Remotes is the progress of federated search on remote Sourcegraph
+	// instances, keyed by the name of the remote.
+	Remotes map[string]RemoteProgress
+
 	// we smuggle in the namer via this field. Note: we don't calculate the
 	// name of every repository in Timedout, Missing, etc since we only need a
 	// subset of the names. As such we lazily calculate the names via namer.
@@ -196,6 +202,39 @@ func excludedArchiveHandler(resultsResolver ProgressStats) (Skipped, bool) {
 	}, true
 }
 
+// remotesSkipped returns the skipped events of the remotes searched by
+// federated search, in the order of their names. The display limit of a remote
+// is not relevant, since its results are displayed by this instance.
+func remotesSkipped(remotes map[string]RemoteProgress) []Skipped {
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var skipped []Skipped
+	for _, name := range names {
+		remote := remotes[name]
+		if remote.Error != "" {
+			skipped = append(skipped, Skipped{
+				Reason:   RemoteInstance,
+				Title:    fmt.Sprintf("%s failed", name),
+				Message:  fmt.Sprintf("Results from the remote Sourcegraph instance `%s` may be missing: %s", name, remote.Error),
+				Severity: SeverityWarn,
+			})
+		}
+
+		for _, sk := range remote.Skipped {
+			if sk.Reason == DisplayLimit {
+				continue
+			}
+			sk.Title = fmt.Sprintf("%s: %s", name, sk.Title)
+			skipped = append(skipped, sk)
+		}
+	}
+	return skipped
+}
+
 // TODO implement all skipped reasons
 var skippedHandlers = []func(stats ProgressStats) (Skipped, bool){
 	repositoryMissingHandler,
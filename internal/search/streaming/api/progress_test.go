@@ -57,6 +57,28 @@ func TestSearchProgress(t *testing.T) {
 		"traced": {
 			Trace: "abcd",
 		},
+		"remotes": {
+			MatchCount:   10,
+			DisplayLimit: math.MaxInt32,
+			Remotes: map[string]RemoteProgress{
+				"eu": {
+					RepositoriesCount: 5,
+					Skipped: []Skipped{{
+						Reason:   ShardTimeout,
+						Title:    "1 timed out",
+						Message:  "`repo-1` could not be searched in time.",
+						Severity: SeverityWarn,
+					}, {
+						Reason:   DisplayLimit,
+						Title:    "display limit hit",
+						Severity: SeverityInfo,
+					}},
+				},
+				"us": {
+					Error: "context deadline exceeded",
+				},
+			},
+		},
 	}
 
 	for name, c := range cases {
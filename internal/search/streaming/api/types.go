@@ -80,6 +80,9 @@ const (
 	// ExcludedArchive is when we did not search a repository because it is
 	// archived.
 	ExcludedArchive SkippedReason = "excluded-archive"
+	// RemoteInstance is when a federated search on a remote Sourcegraph
+	// instance failed, timed out or did not return all results.
+	RemoteInstance SkippedReason = "remote-instance"
 )
 
 // SkippedSeverity is an enum for Skipped.Severity.
@@ -89,3 +92,16 @@ const (
 	SeverityInfo SkippedSeverity = "info"
 	SeverityWarn SkippedSeverity = "warn"
 )
+
+// RemoteProgress is the progress of a federated search on a remote Sourcegraph
+// instance.
+type RemoteProgress struct {
+	// RepositoriesCount is the number of repositories searched on the remote.
+	RepositoriesCount int
+
+	// Skipped is the skipped list of the last progress event of the remote.
+	Skipped []Skipped
+
+	// Error is set if the search on the remote failed or timed out.
+	Error string
+}
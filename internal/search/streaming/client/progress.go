@@ -6,6 +6,7 @@ import (
 
 	sgapi "github.com/sourcegraph/sourcegraph/internal/api"
 	searchshared "github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 )
@@ -38,6 +39,12 @@ func (p *ProgressAggregator) Update(event streaming.SearchEvent) {
 	for _, match := range event.Results {
 		p.MatchCount += match.ResultCount()
 
+		// Repositories on remote instances are counted with the progress of
+		// the remote, since their IDs are not IDs on this instance.
+		if result.Source(match) != "" {
+			continue
+		}
+
 		// Historically we only had one event populate Stats.Repos and it was
 		// the full universe of repos. With Repo Pagination this is no longer
 		// true. Rather than updating every backend to populate this field, we
@@ -67,6 +74,7 @@ func (p *ProgressAggregator) currentStats() api.ProgressStats {
 		SuggestedLimit:      suggestedLimit,
 		Trace:               p.Trace,
 		DisplayLimit:        p.DisplayLimit,
+		Remotes:             p.Stats.Remotes,
 	}
 }
 
@@ -86,7 +94,11 @@ func (p *ProgressAggregator) Final() api.Progress {
 
 	// We only send RepositoriesCount at the end because the number is
 	// confusing to users to see while searching.
-	if c := len(p.Stats.Repos); c > 0 {
+	c := len(p.Stats.Repos)
+	for _, remote := range p.Stats.Remotes {
+		c += remote.RepositoriesCount
+	}
+	if c > 0 {
 		s.RepositoriesCount = intPtr(c)
 	}
 
@@ -20,7 +20,7 @@ type Filter struct {
 	// incomplete.
 	IsLimitHit bool
 
-	// Kind of filter. Should be "repo", "file", or "lang".
+	// Kind of filter. Should be "repo", "file", "lang", "owner" or "utility".
 	Kind string
 
 	// important is used to prioritize the order that filters appear in.
@@ -30,6 +30,13 @@ type EventContentMatch struct {
 	Hunks           []DecoratedHunk  `json:"hunks"`
 	LineMatches     []EventLineMatch `json:"lineMatches,omitempty"`
 	ChunkMatches    []ChunkMatch     `json:"chunkMatches,omitempty"`
+
+	// RevisionDiff is "added" or "removed" for the results of revision diff
+	// searches like rev:feature...main.
+	RevisionDiff string `json:"revisionDiff,omitempty"`
+	// Source is the name of the remote Sourcegraph instance that federated
+	// search found the match on. It is empty for matches on this instance.
+	Source string `json:"source,omitempty"`
 }
 
 func (e *EventContentMatch) eventMatch() {}
@@ -50,6 +57,13 @@ type EventPathMatch struct {
 	RepoLastFetched *time.Time `json:"repoLastFetched,omitempty"`
 	Branches        []string   `json:"branches,omitempty"`
 	Commit          string     `json:"commit,omitempty"`
+
+	// RevisionDiff is "added" or "removed" for the results of revision diff
+	// searches like rev:feature...main.
+	RevisionDiff string `json:"revisionDiff,omitempty"`
+	// Source is the name of the remote Sourcegraph instance that federated
+	// search found the match on. It is empty for matches on this instance.
+	Source string `json:"source,omitempty"`
 }
 
 func (e *EventPathMatch) eventMatch() {}
@@ -107,6 +121,9 @@ type EventRepoMatch struct {
 	Archived           bool               `json:"archived,omitempty"`
 	Private            bool               `json:"private,omitempty"`
 	KeyValuePairs      map[string]*string `json:"keyValuePairs,omitempty"`
+	// Source is the name of the remote Sourcegraph instance that federated
+	// search found the match on. It is empty for matches on this instance.
+	Source string `json:"source,omitempty"`
 }
 
 func (e *EventRepoMatch) eventMatch() {}
@@ -125,6 +142,9 @@ type EventSymbolMatch struct {
 	Commit          string     `json:"commit,omitempty"`
 
 	Symbols []Symbol `json:"symbols"`
+	// Source is the name of the remote Sourcegraph instance that federated
+	// search found the match on. It is empty for matches on this instance.
+	Source string `json:"source,omitempty"`
 }
 
 func (e *EventSymbolMatch) eventMatch() {}
@@ -135,6 +155,16 @@ type Symbol struct {
 	ContainerName string `json:"containerName"`
 	Kind          string `json:"kind"`
 	Line          int32  `json:"line"`
+
+	// History is set for symbols returned by searches over a revision range.
+	History *SymbolHistory `json:"history,omitempty"`
+}
+
+// SymbolHistory is the range of commits in which a symbol existed.
+type SymbolHistory struct {
+	AddedCommit   string `json:"addedCommit"`
+	DeletedCommit string `json:"deletedCommit,omitempty"`
+	LastCommit    string `json:"lastCommit"`
 }
 
 // EventCommitMatch is the generic results interface from GQL. There is a lot
@@ -159,10 +189,25 @@ type EventCommitMatch struct {
 	Content         string     `json:"content"`
 	// [line, character, length]
 	Ranges [][3]int32 `json:"ranges"`
+	// Source is the name of the remote Sourcegraph instance that federated
+	// search found the match on. It is empty for matches on this instance.
+	Source string `json:"source,omitempty"`
 }
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventOwnerMatch is a code owner of matching files, as selected with
+// select:file.owners.
+type EventOwnerMatch struct {
+	// Type is always OwnerMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	// Owner is a handle, such as "@sourcegraph/search", or an email address.
+	Owner string `json:"owner"`
+}
+
+func (e *EventOwnerMatch) eventMatch() {}
+
 // EventFilter is a suggestion for a search filter. Currently has a 1-1
 // correspondance with the SearchFilter graphql type.
 type EventFilter struct {
@@ -200,6 +245,25 @@ type EventError struct {
 	Message string `json:"message"`
 }
 
+// EventExportProgress is sent periodically while a search export runs.
+type EventExportProgress struct {
+	// RowCount is the number of rows written to the export so far.
+	RowCount int `json:"rowCount"`
+}
+
+// EventExport is sent when a search export has been written to the upload
+// store and can be downloaded.
+type EventExport struct {
+	// URL is the URL to download the export from. Only the user who ran the
+	// export can download it.
+	URL      string `json:"url"`
+	Format   string `json:"format"`
+	RowCount int    `json:"rowCount"`
+	// LimitHit is true if the search stopped at its result limit, so the
+	// export does not contain all matches.
+	LimitHit bool `json:"limitHit"`
+}
+
 type MatchType int
 
 const (
@@ -208,6 +272,7 @@ const (
 	SymbolMatchType
 	CommitMatchType
 	PathMatchType
+	OwnerMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -222,6 +287,8 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"commit"`), nil
 	case PathMatchType:
 		return []byte(`"path"`), nil
+	case OwnerMatchType:
+		return []byte(`"owner"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -238,6 +305,8 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = CommitMatchType
 	} else if bytes.Equal(b, []byte(`"path"`)) {
 		*t = PathMatchType
+	} else if bytes.Equal(b, []byte(`"owner"`)) {
+		*t = OwnerMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
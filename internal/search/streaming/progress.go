@@ -7,6 +7,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 )
 
 // Stats contains fields that should be returned by all funcs
@@ -28,6 +29,10 @@ type Stats struct {
 	// ExcludedArchived is the count of excluded archived repos because the
 	// search query doesn't apply to them, but that we want to know about.
 	ExcludedArchived int
+
+	// Remotes is the progress of federated search on remote Sourcegraph
+	// instances, keyed by the name of the remote.
+	Remotes map[string]streamapi.RemoteProgress
 }
 
 // Update updates c with the other data, deduping as necessary. It modifies c but
@@ -52,6 +57,15 @@ func (c *Stats) Update(other *Stats) {
 
 	c.ExcludedForks = c.ExcludedForks + other.ExcludedForks
 	c.ExcludedArchived = c.ExcludedArchived + other.ExcludedArchived
+
+	// The progress of a remote is the progress of its last event, so it
+	// replaces the progress we have seen before.
+	if c.Remotes == nil && len(other.Remotes) > 0 {
+		c.Remotes = make(map[string]streamapi.RemoteProgress, len(other.Remotes))
+	}
+	for name, remote := range other.Remotes {
+		c.Remotes[name] = remote
+	}
 }
 
 // Zero returns true if stats is empty. IE calling Update will result in no
@@ -65,7 +79,8 @@ func (c *Stats) Zero() bool {
 		len(c.Repos) > 0 ||
 		c.Status.Len() > 0 ||
 		c.ExcludedForks > 0 ||
-		c.ExcludedArchived > 0)
+		c.ExcludedArchived > 0 ||
+		len(c.Remotes) > 0)
 }
 
 func (c *Stats) String() string {
@@ -83,6 +98,7 @@ func (c *Stats) String() string {
 		{"repos", len(c.Repos)},
 		{"excludedForks", c.ExcludedForks},
 		{"excludedArchived", c.ExcludedArchived},
+		{"remotes", len(c.Remotes)},
 	}
 	for _, p := range nums {
 		if p.n != 0 {
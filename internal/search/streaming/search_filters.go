@@ -107,6 +107,13 @@ func (s *SearchFilters) Update(event SearchEvent) {
 		}
 	}
 
+	addOwnerFilter := func(owners []string, lineMatchCount int32, limitHit bool) {
+		for _, owner := range owners {
+			value := fmt.Sprintf(`file:has.owner(%s)`, owner)
+			s.filters.Add(value, owner, lineMatchCount, limitHit, "owner")
+		}
+	}
+
 	if event.Stats.ExcludedForks > 0 {
 		s.filters.Add("fork:yes", "Include forked repos", int32(event.Stats.ExcludedForks), event.Stats.IsLimitHit, "utility")
 		s.filters.MarkImportant("fork:yes")
@@ -127,6 +134,7 @@ func (s *SearchFilters) Update(event SearchEvent) {
 			addRepoFilter(v.Repo.Name, v.Repo.ID, rev, lines)
 			addLangFilter(v.Path, lines, v.LimitHit)
 			addFileFilter(v.Path, lines, v.LimitHit)
+			addOwnerFilter(v.Owners, lines, v.LimitHit)
 		case *result.RepoMatch:
 			// It should be fine to leave this blank since revision specifiers
 			// can only be used with the 'repo:' scope. In that case,
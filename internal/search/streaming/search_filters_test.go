@@ -69,6 +69,33 @@ func TestSearchFiltersUpdate(t *testing.T) {
 			wantFilterKind:  "repo",
 			wantFilterCount: 2,
 		},
+		{
+			name: "FileMatch, owner filter",
+			events: []SearchEvent{
+				{
+					Results: []result.Match{
+						&result.FileMatch{
+							File: result.File{
+								Repo: repo,
+								Path: "README.md",
+							},
+							ChunkMatches: result.ChunkMatches{{Ranges: make(result.Ranges, 2)}},
+							Owners:       []string{"@sourcegraph/search", "alice@example.com"},
+						},
+						&result.FileMatch{
+							File: result.File{
+								Repo: repo,
+								Path: "main.go",
+							},
+							Owners: []string{"@sourcegraph/search"},
+						},
+					},
+				},
+			},
+			wantFilterName:  "file:has.owner(@sourcegraph/search)",
+			wantFilterKind:  "owner",
+			wantFilterCount: 3,
+		},
 	}
 
 	for _, c := range cases {
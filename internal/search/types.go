@@ -29,6 +29,10 @@ type Inputs struct {
 	OnSourcegraphDotCom bool
 	Features            *Features
 	Protocol            Protocol
+
+	// Federated is true if the search should also run on the remote
+	// Sourcegraph instances in the "search.federation" site configuration.
+	Federated bool
 }
 
 // MaxResults computes the limit for the query.
@@ -97,6 +101,16 @@ type SymbolsParameters struct {
 
 	// Timeout in seconds.
 	Timeout int
+
+	// History, if true, searches the symbols that existed at any commit of the
+	// first-parent history of CommitID after BaseCommitID, instead of the
+	// symbols at CommitID. The returned symbols report the commits that added
+	// and removed them. Only Rockskip supports symbol history searches.
+	History bool
+
+	// BaseCommitID is the commit after which a symbol history search starts.
+	// If empty, it starts at the first commit of the repository.
+	BaseCommitID api.CommitID
 }
 
 type SymbolsResponse struct {
@@ -85,6 +85,11 @@ func TestExternalService_RedactedConfig(t *testing.T) {
 			in:   schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: "bar"},
 			out:  schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: RedactedSecret},
 		},
+		{
+			kind: extsvc.KindAzureDevOps,
+			in:   schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "foo", Token: "bar"},
+			out:  schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "foo", Token: RedactedSecret},
+		},
 		{
 			kind: extsvc.KindJVMPackages,
 			in:   schema.JVMPackagesConnection{Maven: &schema.Maven{Credentials: "foobar", Dependencies: []string{"baz"}}},
@@ -265,6 +270,12 @@ func TestExternalService_UnredactConfig(t *testing.T) {
 			in:   schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: RedactedSecret},
 			out:  schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: "bar"},
 		},
+		{
+			kind: extsvc.KindAzureDevOps,
+			old:  schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "foo", Token: "bar"},
+			in:   schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "baz", Token: RedactedSecret},
+			out:  schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "baz", Token: "bar"},
+		},
 		{
 			kind: extsvc.KindJVMPackages,
 			old:  schema.JVMPackagesConnection{Maven: &schema.Maven{Credentials: "foobar", Dependencies: []string{"baz"}}},
@@ -25,6 +25,15 @@ func Correlate(ctx context.Context, r io.Reader, root string, getChildren pathex
 		return nil, err
 	}
 
+	return CorrelateState(ctx, state, root, getChildren)
+}
+
+// CorrelateState canonicalizes and prunes the given correlation state and converts it into
+// the format we send to the writer. This allows correlation states populated from a source
+// other than an LSIF stream (e.g., a SCIP index) to share the same storage path.
+//
+// If getChildren == nil, no pruning of irrelevant data is performed.
+func CorrelateState(ctx context.Context, state *State, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
 	// Remove duplicate elements, collapse linked elements
 	canonicalize(state)
 
@@ -157,7 +166,7 @@ type wrappedState struct {
 
 func newWrappedState(dumpRoot string) *wrappedState {
 	return &wrappedState{
-		State:               newState(),
+		State:               NewState(),
 		dumpRoot:            dumpRoot,
 		unsupportedVertices: datastructures.NewIDSet(),
 		rangeToDoc:          map[int]int{},
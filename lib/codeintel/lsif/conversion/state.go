@@ -29,8 +29,8 @@ type State struct {
 	Diagnostics            *datastructures.DefaultIDSetMap         // maps document ID -> diagnostic IDs
 }
 
-// newState create a new State with zero-valued map fields.
-func newState() *State {
+// NewState create a new State with zero-valued map fields.
+func NewState() *State {
 	return &State{
 		DocumentData:           map[int]string{},
 		RangeData:              map[int]Range{},
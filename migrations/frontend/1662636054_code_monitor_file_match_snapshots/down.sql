@@ -0,0 +1,5 @@
+ALTER TABLE cm_trigger_jobs
+    DROP CONSTRAINT IF EXISTS file_results_is_array,
+    DROP COLUMN IF EXISTS file_results;
+
+DROP TABLE IF EXISTS cm_file_match_snapshots;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS cm_file_match_snapshots (
+    monitor_id bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    commit_oid text NOT NULL,
+    paths text[] NOT NULL,
+    PRIMARY KEY (monitor_id, repo_id)
+);
+
+COMMENT ON TABLE cm_file_match_snapshots IS 'The files matched by the last run of a file content code monitor in each repository';
+COMMENT ON COLUMN cm_file_match_snapshots.commit_oid IS 'The default branch commit that was searched';
+COMMENT ON COLUMN cm_file_match_snapshots.paths IS 'The paths of the files that matched the query at commit_oid';
+
+ALTER TABLE cm_trigger_jobs
+    ADD COLUMN IF NOT EXISTS file_results jsonb;
+
+ALTER TABLE cm_trigger_jobs
+    DROP CONSTRAINT IF EXISTS file_results_is_array,
+    ADD CONSTRAINT file_results_is_array CHECK (jsonb_typeof(file_results) = 'array'::text);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    actor_uid text NOT NULL,
+    ip text NOT NULL,
+    forwarded_for text NOT NULL,
+    entity text NOT NULL,
+    action text NOT NULL,
+    fields jsonb DEFAULT '{}'::jsonb NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_logs_created_at_idx ON audit_logs USING btree (created_at);
+CREATE INDEX IF NOT EXISTS audit_logs_actor_uid_idx ON audit_logs USING btree (actor_uid);
+CREATE INDEX IF NOT EXISTS audit_logs_entity_action_idx ON audit_logs USING btree (entity, action);
+
+COMMENT ON TABLE audit_logs IS 'Audit log records of actors taking actions on entities.';
+COMMENT ON COLUMN audit_logs.actor_uid IS 'The user ID or anonymous user ID of the actor, or "unknown".';
+COMMENT ON COLUMN audit_logs.fields IS 'Additional context of the action, as a JSON object.';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS scim_groups;
+DROP TABLE IF EXISTS scim_users;
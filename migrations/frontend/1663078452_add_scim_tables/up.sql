@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS scim_users (
+    id text PRIMARY KEY,
+    user_id integer NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    external_id text,
+    user_name citext NOT NULL UNIQUE,
+    active boolean DEFAULT true NOT NULL,
+    attributes jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE scim_users IS 'Maps users provisioned by an identity provider over SCIM to Sourcegraph users.';
+COMMENT ON COLUMN scim_users.id IS 'The SCIM ID of the user, which is generated by Sourcegraph.';
+COMMENT ON COLUMN scim_users.external_id IS 'The ID of the user in the identity provider, if it sent one.';
+COMMENT ON COLUMN scim_users.attributes IS 'The SCIM user resource as last sent by the identity provider.';
+
+CREATE TABLE IF NOT EXISTS scim_groups (
+    id text PRIMARY KEY,
+    org_id integer NOT NULL UNIQUE REFERENCES orgs(id) ON DELETE CASCADE,
+    external_id text,
+    display_name citext NOT NULL UNIQUE,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE scim_groups IS 'Maps groups provisioned by an identity provider over SCIM to Sourcegraph organizations.';
+COMMENT ON COLUMN scim_groups.id IS 'The SCIM ID of the group, which is generated by Sourcegraph.';
+COMMENT ON COLUMN scim_groups.external_id IS 'The ID of the group in the identity provider, if it sent one.';
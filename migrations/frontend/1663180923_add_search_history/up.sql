@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS search_history (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    query text NOT NULL,
+    pattern_type text NOT NULL,
+    result_count integer NOT NULL,
+    duration_ms integer NOT NULL,
+    pinned boolean DEFAULT false NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE search_history IS 'The searches that users ran in the web app.';
+COMMENT ON COLUMN search_history.pattern_type IS 'The pattern type that the query was run with, like "standard" or "regexp".';
+COMMENT ON COLUMN search_history.pinned IS 'Pinned searches are not deleted when they are older than the retention period of the user.';
+
+CREATE INDEX IF NOT EXISTS search_history_user_id_id ON search_history USING btree (user_id, id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS repo_update_schedule_settings (
+    repo_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    interval_seconds integer,
+    high_priority boolean DEFAULT false NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE repo_update_schedule_settings IS 'Settings of site admins for how often repo-updater updates a repository, overriding the gitUpdateInterval site configuration.';
+COMMENT ON COLUMN repo_update_schedule_settings.interval_seconds IS 'The fixed interval at which the repository is updated, if set.';
+COMMENT ON COLUMN repo_update_schedule_settings.high_priority IS 'High priority repositories are enqueued ahead of other repositories when they are due for an update.';
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS deleted_user_emails (
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email citext NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    verified_at timestamp with time zone,
+    is_primary boolean DEFAULT false NOT NULL,
+    PRIMARY KEY (user_id, email)
+);
+
+COMMENT ON TABLE deleted_user_emails IS 'Email addresses of soft-deleted users, which are restored when the user is recovered.';
@@ -120,6 +120,15 @@ func Worker() *monitoring.Dashboard {
 					Number of database record encryption/decryption errors every 5m
 				`).Observable(),
 			},
+			{
+				shared.Standard.Count("records rewrapped")(shared.ObservableConstructorOptions{
+					MetricNameRoot:        "records_rewrapped",
+					MetricDescriptionRoot: "database",
+					By:                    []string{"tableName"},
+				})(containerName, monitoring.ObservableOwnerRepoManagement).WithNoAlerts(`
+					Number of encrypted database records re-encrypted with the latest version of a rotated key every 5m
+				`).Observable(),
+			},
 		},
 	}
 
@@ -135,6 +144,7 @@ func Worker() *monitoring.Dashboard {
 			// src_records_unencrypted_at_rest_total
 			// src_records_encrypted_total
 			// src_records_decrypted_total
+			// src_records_rewrapped_total
 			// src_record_encryption_errors_total
 			recordEncrypterGroup,
 
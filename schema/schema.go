@@ -66,6 +66,12 @@ type ApiRatelimit struct {
 	PerUser int `json:"perUser"`
 }
 
+// AuditLog description: Configuration for audit log records stored in the database.
+type AuditLog struct {
+	// Retention description: How long audit log records are retained in the database. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration). Values lower than 1 hour will be treated as 1 hour. By default, this is "2160h", or 90 days.
+	Retention string `json:"retention,omitempty"`
+}
+
 // AuthAccessTokens description: Settings for access tokens, which enable external tools to access the Sourcegraph API with the privileges of the user.
 type AuthAccessTokens struct {
 	// Allow description: Allow or restrict the use of access tokens. The default is "all-users-create", which enables all users to create access tokens. Use "none" to disable access tokens entirely. Use "site-admin-create" to restrict creation of new tokens to admin users (existing tokens will still work until revoked).
@@ -106,6 +112,7 @@ type AuthProviders struct {
 	HttpHeader    *HTTPHeaderAuthProvider
 	Github        *GitHubAuthProvider
 	Gitlab        *GitLabAuthProvider
+	Ldap          *LDAPAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -127,6 +134,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Ldap != nil {
+		return json.Marshal(v.Ldap)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -145,14 +155,41 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Gitlab)
 	case "http-header":
 		return json.Unmarshal(data, &v.HttpHeader)
+	case "ldap":
+		return json.Unmarshal(data, &v.Ldap)
 	case "openidconnect":
 		return json.Unmarshal(data, &v.Openidconnect)
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "ldap"})
 }
 
+// AzureDevOpsConnection description: Configuration for a connection to Azure DevOps.
+type AzureDevOpsConnection struct {
+	// Exclude description: A list of repositories to never mirror from Azure DevOps Services.
+	Exclude []*ExcludedAzureDevOpsRepo `json:"exclude,omitempty"`
+	// Orgs description: An array of organization names identifying Azure DevOps organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// Projects description: An array of projects "org/project" strings specifying which Azure DevOps projects' repositories should be mirrored on Sourcegraph.
+	Projects []string `json:"projects,omitempty"`
+	// RateLimit description: Rate limit applied when making API requests to Azure DevOps.
+	RateLimit *AzureDevOpsRateLimit `json:"rateLimit,omitempty"`
+	// Token description: The Personal Access Token associated with the Azure DevOps username used for authentication. The token needs the Code (Read) scope.
+	Token string `json:"token"`
+	// Url description: URL for Azure DevOps Services, set to https://dev.azure.com.
+	Url string `json:"url"`
+	// Username description: A username for authentication with the Azure DevOps code host.
+	Username string `json:"username"`
+}
+
+// AzureDevOpsRateLimit description: Rate limit applied when making API requests to Azure DevOps.
+type AzureDevOpsRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type BackendInsight struct {
 	// Description description: The description of this insight
 	Description string          `json:"description,omitempty"`
@@ -479,6 +516,7 @@ type EncryptionKey struct {
 	Cloudkms *CloudKMSEncryptionKey
 	Awskms   *AWSKMSEncryptionKey
 	Mounted  *MountedEncryptionKey
+	Vault    *VaultTransitEncryptionKey
 	Noop     *NoOpEncryptionKey
 }
 
@@ -492,6 +530,9 @@ func (v EncryptionKey) MarshalJSON() ([]byte, error) {
 	if v.Mounted != nil {
 		return json.Marshal(v.Mounted)
 	}
+	if v.Vault != nil {
+		return json.Marshal(v.Vault)
+	}
 	if v.Noop != nil {
 		return json.Marshal(v.Noop)
 	}
@@ -513,8 +554,10 @@ func (v *EncryptionKey) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Mounted)
 	case "noop":
 		return json.Unmarshal(data, &v.Noop)
+	case "vault":
+		return json.Unmarshal(data, &v.Vault)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"cloudkms", "awskms", "mounted", "noop"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"cloudkms", "awskms", "mounted", "vault", "noop"})
 }
 
 // EncryptionKeys description: Configuration for encryption keys used to encrypt data at rest in the database.
@@ -534,6 +577,12 @@ type ExcludedAWSCodeCommitRepo struct {
 	// Name description: The name of an AWS CodeCommit repository ("repo-name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedAzureDevOpsRepo struct {
+	// Name description: The name of an Azure DevOps Services organization, project, and repository ("orgName/projectName/repositoryName") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of an Azure DevOps Services repo.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedBitbucketCloudRepo struct {
 	// Name description: The name of a Bitbucket Cloud repo ("myorg/myrepo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -619,6 +668,8 @@ type ExperimentalFeatures struct {
 	Gerrit string `json:"gerrit,omitempty"`
 	// GitServerPinnedRepos description: List of repositories pinned to specific gitserver instances. The specified repositories will remain at their pinned servers on scaling the cluster. If the specified pinned server differs from the current server that stores the repository, then it must be re-cloned to the specified server.
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
+	// GitServerReplication description: Replicates repositories to additional gitserver instances. Reads of a replicated repository fail over to a replica when the gitserver instance that the repository is assigned to is unavailable, for example while it restarts.
+	GitServerReplication *GitServerReplication `json:"gitServerReplication,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
 	GoPackages string `json:"goPackages,omitempty"`
 	// JvmPackages description: Allow adding JVM package host connections
@@ -966,6 +1017,22 @@ type GitLabWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GitPartialClones description: Clone the matching Git repositories as partial clones without file contents (git clone --filter=blob:none). gitserver fetches the contents of files at the default branch after each clone and fetch, and fetches other file contents from the code host when they are read. This reduces the disk usage of repositories with a long history. Only applies to repositories that are cloned after the setting changes.
+type GitPartialClones struct {
+	// MaxFetchedObjectsPerRequest description: The maximum number of missing objects that gitserver fetches from the code host for a single request, like reading a file or creating an archive. Requests that need more missing objects fail.
+	MaxFetchedObjectsPerRequest int `json:"maxFetchedObjectsPerRequest,omitempty"`
+	// Repos description: Regular expressions matching the names of the repositories to clone as partial clones. To use partial clones for all repositories of a code host, match the prefix of their names, like "^github\\.example\\.com/".
+	Repos []string `json:"repos,omitempty"`
+}
+
+// GitServerReplication description: Replicates repositories to additional gitserver instances. Reads of a replicated repository fail over to a replica when the gitserver instance that the repository is assigned to is unavailable, for example while it restarts.
+type GitServerReplication struct {
+	// ReplicationFactor description: The number of gitserver instances that hold a replica of each replicated repository, in addition to the gitserver instance that the repository is assigned to.
+	ReplicationFactor int `json:"replicationFactor,omitempty"`
+	// Repos description: Regular expressions matching the names of the repositories to replicate. Replicas use disk space and are fetched on every update of the repository, so only frequently searched repositories should be replicated.
+	Repos []string `json:"repos,omitempty"`
+}
+
 // Github description: GitHub configuration, both for queries and receiving release webhooks.
 type Github struct {
 	// Repository description: The repository to get the latest version of.
@@ -1107,8 +1174,44 @@ type JVMPackagesConnection struct {
 	Maven *Maven `json:"maven,omitempty"`
 }
 
+// LDAPAuthProvider description: Configures the LDAP authentication provider, which authenticates users by binding to an LDAP directory (such as Active Directory or OpenLDAP) with their username and password.
+type LDAPAuthProvider struct {
+	// AllowGroups description: Restrict login to members of these groups, given by their DNs. Leave empty or unset for no group restrictions.
+	AllowGroups []string `json:"allowGroups,omitempty"`
+	// AllowSignup description: Allows new visitors to sign up for accounts via LDAP authentication. If false, users signing in via LDAP must have an existing Sourcegraph account, which will be linked to their LDAP identity after sign-in.
+	AllowSignup *bool `json:"allowSignup,omitempty"`
+	// BindDN description: The DN of the account used to search for users. If empty, users are searched for with an anonymous bind.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPassword description: The password of the account given in bindDN.
+	BindPassword string `json:"bindPassword,omitempty"`
+	// Certificate description: TLS certificate of the LDAP server, or of the certificate authority that issued it. Only necessary if the certificate is self-signed or signed by an internal CA.
+	Certificate string `json:"certificate,omitempty"`
+	// ConfigID description: An identifier that can be used to reference this authentication provider in other parts of the config. For example, in configuration for a code host, you may want to designate this authentication provider as the identity provider for the code host.
+	ConfigID    string `json:"configID,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	// DisplayNameAttribute description: The attribute of user entries that holds the display name.
+	DisplayNameAttribute string `json:"displayNameAttribute,omitempty"`
+	// EmailAttribute description: The attribute of user entries that holds the email address.
+	EmailAttribute string `json:"emailAttribute,omitempty"`
+	// GroupsAttributeName description: The attribute of user entries that lists the DNs of the groups the user is a member of, used for the allowGroups setting.
+	GroupsAttributeName string `json:"groupsAttributeName,omitempty"`
+	// StartTLS description: Upgrade the connection to TLS with the StartTLS operation before sending any credentials. Only valid for ldap:// URLs.
+	StartTLS bool   `json:"startTLS,omitempty"`
+	Type     string `json:"type"`
+	// Url description: The URL of the LDAP server. Use the ldaps scheme to connect over TLS. If no port is given, the default port for the scheme (389 or 636) is used.
+	Url string `json:"url"`
+	// UserSearchBase description: The DN under which users are searched for.
+	UserSearchBase string `json:"userSearchBase"`
+	// UserSearchFilter description: An LDAP filter (RFC 4515) that user entries must match, in addition to the username attribute matching the username that was entered.
+	UserSearchFilter string `json:"userSearchFilter,omitempty"`
+	// UsernameAttribute description: The attribute of user entries that holds the username. Users sign in with this value, and it is used as their Sourcegraph username (after normalization).
+	UsernameAttribute string `json:"usernameAttribute,omitempty"`
+}
+
 // Log description: Configuration for logging and alerting, including to external services.
 type Log struct {
+	// AuditLog description: Configuration for audit log records stored in the database.
+	AuditLog *AuditLog `json:"auditLog,omitempty"`
 	// GitserverAccessLogs description: Enable gitserver access logging.
 	GitserverAccessLogs bool `json:"gitserver.accessLogs,omitempty"`
 	// Sentry description: Configuration for Sentry
@@ -1383,6 +1486,8 @@ type OtherExternalServiceConnection struct {
 	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
 	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
 	Url                   string `json:"url,omitempty"`
+	// Vcs description: The version control system of the repositories. Mercurial ("hg") repositories are converted to Git repositories when they are cloned.
+	Vcs string `json:"vcs,omitempty"`
 }
 type OutputVariable struct {
 	// Format description: The expected format of the output. If set, the output is being parsed in that format before being stored in the var. If not set, 'text' is assumed to the format.
@@ -1638,6 +1743,26 @@ type SMTPServerConfig struct {
 	// Username description: The username to use when communicating with the SMTP server.
 	Username string `json:"username,omitempty"`
 }
+
+// SearchFederation description: Federated search sends the searches that site admins and the allowed users and organizations run on this instance to remote Sourcegraph instances, and shows their results next to the results of this instance. Searches of other users are not federated.
+type SearchFederation struct {
+	// AllowedOrgs description: The names of the organizations on this instance whose members' searches are federated, in addition to site admins.
+	AllowedOrgs []string `json:"allowedOrgs,omitempty"`
+	// AllowedUsers description: The usernames of the users on this instance whose searches are federated, in addition to site admins. Every user that runs federated searches can see the results that the users of the remote access tokens can see.
+	AllowedUsers []string `json:"allowedUsers,omitempty"`
+	// Remotes description: The remote Sourcegraph instances to search.
+	Remotes []*SearchFederationRemote `json:"remotes,omitempty"`
+}
+type SearchFederationRemote struct {
+	// Name description: The name of the remote, which labels its results. It must be unique.
+	Name string `json:"name"`
+	// TimeoutSeconds description: The maximum time to wait for the results of the remote. Searches on a remote that times out show the results that the remote returned in time. Defaults to 20 seconds.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// Token description: An access token of a user on the remote. Searches on the remote only return results that this user has access to.
+	Token string `json:"token"`
+	// Url description: The URL of the remote Sourcegraph instance.
+	Url string `json:"url"`
+}
 type SearchIndexRevisionsRule struct {
 	// Name description: Regular expression which matches against the name of a repository (e.g. "^github\.com/owner/name$").
 	Name string `json:"name,omitempty"`
@@ -1651,10 +1776,16 @@ type SearchLimits struct {
 	CommitDiffMaxRepos int `json:"commitDiffMaxRepos,omitempty"`
 	// CommitDiffWithTimeFilterMaxRepos description: The maximum number of repositories to search across when doing a "type:diff" or "type:commit" with a "after:" or "before:" filter. The user is prompted to narrow their query if the limit is exceeded. There is a separate limit (commitDiffMaxRepos) when "after:" or "before:" is not specified because those queries are slower. Defaults to 10000.
 	CommitDiffWithTimeFilterMaxRepos int `json:"commitDiffWithTimeFilterMaxRepos,omitempty"`
+	// MaxQueryCost description: The maximum estimated cost of a search. Searches that are estimated to cost more are rejected before they run, and the user is prompted to narrow their query. Searching one indexed repository costs 1, searching one unindexed repository costs 10, searching the commits of one repository ("type:commit") costs 20 and searching the diffs of one repository ("type:diff") costs 50. Any value less than or equal to zero means unlimited.
+	MaxQueryCost int `json:"maxQueryCost,omitempty"`
+	// MaxQueryCostPerUser description: The maximum total estimated cost of the searches that a user runs at the same time. A search that would exceed this limit is queued until the user's other searches finish, or rejected, depending on queryCostLimitAction. A search always runs if the user has no other searches running. See maxQueryCost for how the cost of a search is estimated. The running searches are tracked by each frontend replica, so with several replicas a user can run up to that many times this cost at once. Any value less than or equal to zero means unlimited.
+	MaxQueryCostPerUser int `json:"maxQueryCostPerUser,omitempty"`
 	// MaxRepos description: The maximum number of repositories to search across. The user is prompted to narrow their query if exceeded. Any value less than or equal to zero means unlimited.
 	MaxRepos int `json:"maxRepos,omitempty"`
 	// MaxTimeoutSeconds description: The maximum value for "timeout:" that search will respect. "timeout:" values larger than maxTimeoutSeconds are capped at maxTimeoutSeconds. Note: You need to ensure your load balancer / reverse proxy in front of Sourcegraph won't timeout the request for larger values. Note: Too many large rearch requests may harm Soucregraph for other users. Defaults to 1 minute.
 	MaxTimeoutSeconds int `json:"maxTimeoutSeconds,omitempty"`
+	// QueryCostLimitAction description: What happens to a search that would exceed maxQueryCostPerUser. "queue" waits until enough of the user's other searches finish, for up to maxTimeoutSeconds. "reject" rejects the search and prompts the user to try again later.
+	QueryCostLimitAction string `json:"queryCostLimitAction,omitempty"`
 }
 type SearchSavedQueries struct {
 	// Description description: Description of this saved query
@@ -1772,10 +1903,14 @@ type Settings struct {
 	SearchGlobbing *bool `json:"search.globbing,omitempty"`
 	// SearchHideSuggestions description: Disable search suggestions below the search bar when constructing queries. Defaults to false.
 	SearchHideSuggestions *bool `json:"search.hideSuggestions,omitempty"`
+	// SearchHistoryRetentionDays description: The number of days that searches are kept in your search history. Pinned searches are kept until you delete them. Defaults to 90 days.
+	SearchHistoryRetentionDays int `json:"search.history.retentionDays,omitempty"`
 	// SearchIncludeArchived description: Whether searches should include searching archived repositories.
 	SearchIncludeArchived *bool `json:"search.includeArchived,omitempty"`
 	// SearchIncludeForks description: Whether searches should include searching forked repositories.
 	SearchIncludeForks *bool `json:"search.includeForks,omitempty"`
+	// SearchMacros description: Named query fragments that can be used in any search query as `@name`, e.g. `@prod-services` for `repo:^github\.com/acme/(api|web|worker)$ -file:_test\.go$`. Macros may refer to other macros. Macros defined in user settings take precedence over those with the same name in organization and global settings.
+	SearchMacros map[string]string `json:"search.macros,omitempty"`
 	// SearchMigrateParser description: REMOVED. Previously, a flag to enable and/or-expressions in queries as an aid transition to new language features in versions <= 3.24.0.
 	SearchMigrateParser *bool `json:"search.migrateParser,omitempty"`
 	// SearchRepositoryGroups description: DEPRECATED: Use search contexts instead.
@@ -2044,6 +2179,8 @@ type SiteConfiguration struct {
 	GitMaxCodehostRequestsPerSecond *int `json:"gitMaxCodehostRequestsPerSecond,omitempty"`
 	// GitMaxConcurrentClones description: Maximum number of git clone processes that will be run concurrently per gitserver to update repositories. Note: the global git update scheduler respects gitMaxConcurrentClones. However, we allow each gitserver to run upto gitMaxConcurrentClones to allow for urgent fetches. Urgent fetches are used when a user is browsing a PR and we do not have the commit yet.
 	GitMaxConcurrentClones int `json:"gitMaxConcurrentClones,omitempty"`
+	// GitPartialClones description: Clone the matching Git repositories as partial clones without file contents (git clone --filter=blob:none). gitserver fetches the contents of files at the default branch after each clone and fetch, and fetches other file contents from the code host when they are read. This reduces the disk usage of repositories with a long history. Only applies to repositories that are cloned after the setting changes.
+	GitPartialClones *GitPartialClones `json:"gitPartialClones,omitempty"`
 	// GitUpdateInterval description: JSON array of repo name patterns and update intervals. If a repo matches a pattern, the associated interval will be used. If it matches no patterns a default backoff heuristic will be used. Pattern matches are attempted in the order they are provided.
 	GitUpdateInterval []*UpdateIntervalRule `json:"gitUpdateInterval,omitempty"`
 	// GithubClientID description: Client ID for GitHub. (DEPRECATED)
@@ -2126,6 +2263,12 @@ type SiteConfiguration struct {
 	RepoConcurrentExternalServiceSyncers int `json:"repoConcurrentExternalServiceSyncers,omitempty"`
 	// RepoListUpdateInterval description: Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.
 	RepoListUpdateInterval int `json:"repoListUpdateInterval,omitempty"`
+	// ScimAuthToken description: The bearer token that identity providers must send to provision users and groups with the SCIM 2.0 API at /.api/scim/v2. The API is disabled if this is not set.
+	ScimAuthToken string `json:"scim.authToken,omitempty"`
+	// SearchFederation description: Federated search sends the searches that site admins and the allowed users and organizations run on this instance to remote Sourcegraph instances, and shows their results next to the results of this instance. Searches of other users are not federated.
+	SearchFederation *SearchFederation `json:"search.federation,omitempty"`
+	// SearchHistoryEnabled description: Whether the searches that users run in the web app are saved to their search history on the server. Users can list, search, pin and delete the entries of their search history. When disabled, no new searches are saved, and users can still delete the entries that were saved before.
+	SearchHistoryEnabled *bool `json:"search.history.enabled,omitempty"`
 	// SearchIndexEnabled description: Whether indexed search is enabled. If : unset Sourcegraph detects the environment to decide if indexed search is enabled. Indexed search is RAM heavy, and is disabled by default in the single docker image. All other environments will have it enabled by default. The size of all your repository working copies is the amount of additional RAM required.
 	SearchIndexEnabled *bool `json:"search.index.enabled,omitempty"`
 	// SearchIndexSymbolsEnabled description: Whether indexed symbol search is enabled. This is contingent on the indexed search configuration, and is true by default for instances with indexed search enabled. Enabling this will cause every repository to re-index, which is a time consuming (several hours) operation. Additionally, it requires more storage and ram to accommodate the added symbols information in the search index.
@@ -2238,6 +2381,31 @@ type UsernameIdentity struct {
 	Type string `json:"type"`
 }
 
+// VaultAppRole description: Credentials of a Vault AppRole.
+type VaultAppRole struct {
+	// MountPath description: The path the AppRole auth method is mounted at.
+	MountPath string `json:"mountPath,omitempty"`
+	RoleId    string `json:"roleId"`
+	SecretId  string `json:"secretId"`
+}
+
+// VaultTransitEncryptionKey description: HashiCorp Vault Transit Encryption Key, used to encrypt data with a key of the Vault Transit secrets engine. Rotating the key in Vault re-encrypts existing data with the latest key version in the background.
+type VaultTransitEncryptionKey struct {
+	// Address description: The URL of the Vault server.
+	Address string `json:"address"`
+	// AppRole description: The AppRole to authenticate with. Either token or appRole must be set.
+	AppRole *VaultAppRole `json:"appRole,omitempty"`
+	// Keyname description: The name of the key in the Transit secrets engine.
+	Keyname string `json:"keyname"`
+	// MountPath description: The path the Transit secrets engine is mounted at.
+	MountPath string `json:"mountPath,omitempty"`
+	// Namespace description: The Vault Enterprise namespace of the Transit secrets engine.
+	Namespace string `json:"namespace,omitempty"`
+	// Token description: The Vault token to authenticate with. Either token or appRole must be set.
+	Token string `json:"token,omitempty"`
+	Type  string `json:"type"`
+}
+
 // VersionContext description: Configuration of the version context
 type VersionContext struct {
 	// Description description: Description of the version context